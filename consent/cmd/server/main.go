@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
@@ -17,7 +18,16 @@ import (
 	"github.com/thatlq1812/policy-system/consent/internal/handler"
 	"github.com/thatlq1812/policy-system/consent/internal/repository"
 	"github.com/thatlq1812/policy-system/consent/internal/service"
+	auditpb "github.com/thatlq1812/policy-system/shared/pkg/api/audit"
 	pb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+	webhookpb "github.com/thatlq1812/policy-system/shared/pkg/api/webhook"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit/pgstore"
+	"github.com/thatlq1812/policy-system/shared/pkg/netguard"
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	outboxstore "github.com/thatlq1812/policy-system/shared/pkg/outbox/pgstore"
+	"github.com/thatlq1812/policy-system/shared/pkg/webhook"
+	webhookstore "github.com/thatlq1812/policy-system/shared/pkg/webhook/pgstore"
 )
 
 func main() {
@@ -50,13 +60,46 @@ func main() {
 	log.Printf("Connected to document service at %s", cfg.DocumentServiceURL)
 
 	// 4. Initialize layers
+	// file_url và webhook endpoint không được trỏ vào mạng nội bộ (trừ allow-list)
+	outboundGuard, err := netguard.New(cfg.OutboundAllowedNetworks...)
+	if err != nil {
+		log.Fatalf("Invalid OUTBOUND_ALLOWED_NETWORKS: %v", err)
+	}
+	contentFetcher := clients.NewContentFetcher(outboundGuard, cfg.ContentFetchTimeout, cfg.SnapshotMaxBytes)
 	consentRepo := repository.NewConsentRepository(dbPool)
-	consentService := service.NewConsentService(consentRepo, docClient)
-	consentHandler := handler.NewConsentHandler(consentService)
+	auditStore := pgstore.NewStore(dbPool)
+	auditLog := audit.NewLogger(auditStore, "consent")
+	campaignRepo := repository.NewCampaignRepository(dbPool)
+	consentService := service.NewConsentService(consentRepo, campaignRepo, docClient, contentFetcher, cfg.SnapshotMaxBytes, cfg.PendingGracePeriod, auditLog)
+	campaignService := service.NewCampaignService(campaignRepo, docClient, cfg.PendingGracePeriod, auditLog)
+	consentHandler := handler.NewConsentHandler(consentService, campaignService)
+
+	jobCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+
+	// Background job: mở re-consent campaign khi version bắt buộc mới có hiệu lực
+	go runCampaignSync(jobCtx, campaignService, cfg.CampaignSyncPeriod)
+	go runConsentExpiry(jobCtx, consentService, cfg.ConsentExpiryPeriod)
+
+	// Webhook subscriptions do Admin đăng ký: worker gửi delivery, retry và chuyển vào dead-letter
+	webhookStore := webhookstore.NewStore(dbPool, "consent")
+	go webhook.NewWorker(webhookStore, webhook.WorkerConfig{Guard: outboundGuard}).Run(jobCtx)
+
+	// Outbox dispatcher: gửi domain events tới webhook subscriptions và các sink đã cấu hình
+	sinks := []outbox.Sink{webhook.NewFanoutSink(webhookStore)}
+	if cfg.OutboxWebhookURL != "" {
+		sinks = append(sinks, outbox.NewWebhookSink(cfg.OutboxWebhookURL, cfg.OutboxWebhookSecret, 10*time.Second))
+		log.Printf("Outbox webhook sink: %s", cfg.OutboxWebhookURL)
+	}
+	dispatcher := outbox.NewDispatcher(outboxstore.NewStore(dbPool), sinks, outbox.DispatcherConfig{PollInterval: cfg.OutboxPollInterval})
+	go dispatcher.Run(jobCtx)
 
 	// 5. Create gRPC server
-	grpcServer := grpc.NewServer()
+	// Interceptor ghi audit mọi call Admin thao tác thay user khác (on_behalf_of từ Gateway)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(audit.UnaryServerInterceptor(auditLog)))
 	pb.RegisterConsentServiceServer(grpcServer, consentHandler)
+	auditpb.RegisterAuditServiceServer(grpcServer, audit.NewServer(auditStore))
+	webhookpb.RegisterWebhookServiceServer(grpcServer, webhook.NewServer(webhookStore, outboundGuard, outbox.EventConsentRecorded, outbox.EventConsentRevoked, outbox.EventConsentExpired, outbox.EventConsentDeclined))
 
 	// Enable reflection for testing with grpcurl
 	reflection.Register(grpcServer)
@@ -82,6 +125,53 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down consent service...")
+	stopJobs()
 	grpcServer.GracefulStop()
 	log.Println("Consent service stopped")
 }
+
+// runCampaignSync periodically starts re-consent campaigns for newly effective mandatory versions
+func runCampaignSync(ctx context.Context, svc service.CampaignService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Chạy ngay lúc khởi động, không chờ tick đầu tiên
+		started, err := svc.SyncCampaigns(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("WARNING: Failed to sync re-consent campaigns: %v", err)
+		}
+		for _, c := range started {
+			log.Printf("INFO: Started re-consent campaign %s (%s/%s, version %d, %d affected users)",
+				c.ID, c.Platform, c.DocumentName, c.VersionTimestamp, c.AffectedUsers)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runConsentExpiry periodically marks consents past expires_at as expired (emits ConsentExpired)
+func runConsentExpiry(ctx context.Context, svc service.ConsentService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := svc.ExpireConsents(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("WARNING: Failed to expire consents: %v", err)
+		}
+		if len(expired) > 0 {
+			log.Printf("INFO: Marked %d consents as expired", len(expired))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
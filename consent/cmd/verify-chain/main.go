@@ -0,0 +1,99 @@
+// Command verify-chain verifies the consent hash chain directly against the database,
+// without going through the running Consent Service.
+//
+// Usage:
+//
+//	DATABASE_URL=... go run ./cmd/verify-chain              # verify all users
+//	DATABASE_URL=... go run ./cmd/verify-chain -user <id>   # verify 1 user
+//	DATABASE_URL=... go run ./cmd/verify-chain -backfill    # chain rows created before migration 000003, then verify
+//
+// Exit code 1 nếu phát hiện bất kỳ row nào bị sửa/xóa.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/consent/internal/chain"
+	configs "github.com/thatlq1812/policy-system/consent/internal/configs"
+	"github.com/thatlq1812/policy-system/consent/internal/repository"
+)
+
+func main() {
+	userID := flag.String("user", "", "only verify this user_id (default: all users)")
+	backfill := flag.Bool("backfill", false, "chain legacy consent rows (record_hash IS NULL) before verifying")
+	flag.Parse()
+
+	// 1. Load configuration (chỉ cần DATABASE_URL)
+	cfg, err := configs.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// 2. Connect to database
+	ctx := context.Background()
+	dbPool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbPool.Close()
+
+	repo := repository.NewConsentRepository(dbPool)
+
+	// 3. Resolve users cần verify
+	userIDs := []string{*userID}
+	if *userID == "" {
+		userIDs, err = repo.ListChainUserIDs(ctx)
+		if err != nil {
+			log.Fatalf("Failed to list users: %v", err)
+		}
+	}
+
+	// 4. Verify từng chain
+	failed := 0
+	for _, id := range userIDs {
+		if *backfill {
+			n, err := repo.BackfillChain(ctx, id)
+			if err != nil {
+				log.Fatalf("Failed to backfill chain for user %s: %v", id, err)
+			}
+			if n > 0 {
+				log.Printf("Backfilled %d consent(s) for user %s", n, id)
+			}
+		}
+
+		events, err := repo.GetChainEvents(ctx, id)
+		if err != nil {
+			log.Fatalf("Failed to load chain for user %s: %v", id, err)
+		}
+		head, err := repo.GetChainHead(ctx, id)
+		if err != nil {
+			log.Fatalf("Failed to load chain head for user %s: %v", id, err)
+		}
+		consents, err := repo.GetUserConsents(ctx, id, true)
+		if err != nil {
+			log.Fatalf("Failed to load consents for user %s: %v", id, err)
+		}
+
+		report := chain.Verify(id, events, head, consents)
+		if report.Valid() {
+			log.Printf("OK    user=%s events=%d head=%s", id, report.ChainLength, report.HeadHash)
+			continue
+		}
+
+		failed++
+		log.Printf("FAIL  user=%s events=%d issues=%d", id, report.ChainLength, len(report.Issues))
+		for _, issue := range report.Issues {
+			log.Printf("      [%s] seq=%d consent=%s: %s", issue.Code, issue.SeqNo, issue.ConsentID, issue.Message)
+		}
+	}
+
+	log.Printf("Verified %d user chain(s), %d failed", len(userIDs), failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
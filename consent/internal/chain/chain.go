@@ -0,0 +1,334 @@
+// Package chain implements the tamper-evident hash chain over consent records.
+//
+// Mỗi user có 1 chain riêng trong bảng consent_chain. Mỗi event (GRANTED/REVOKED/DECLINED/ERASED)
+// lưu content_hash của bằng chứng consent và record_hash = SHA-256(event + prev_hash).
+// Sửa/xóa bất kỳ consent row hay chain event nào đều làm Verify báo lỗi.
+//
+// Canonical encoding là JSON của các struct bên dưới (thứ tự field cố định, thời gian
+// là Unix microseconds) để auditor bên ngoài có thể tự tính lại hash.
+package chain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/thatlq1812/policy-system/consent/internal/domain"
+)
+
+// grantContent là bằng chứng pháp lý bất biến của 1 consent
+// (is_latest là trạng thái suy ra nên không nằm trong hash)
+type grantContent struct {
+	ConsentID        string  `json:"consent_id"`
+	UserID           string  `json:"user_id"`
+	Platform         string  `json:"platform"`
+	DocumentID       string  `json:"document_id"`
+	DocumentName     string  `json:"document_name"`
+	VersionTimestamp int64   `json:"version_timestamp"`
+	AgreedAt         int64   `json:"agreed_at"`
+	AgreedFileURL    *string `json:"agreed_file_url"`
+	ConsentMethod    string  `json:"consent_method"`
+	IPAddress        *string `json:"ip_address"`
+	UserAgent        *string `json:"user_agent"`
+	// omitempty giữ nguyên hash của các consent ghi trước khi có content hash
+	DocumentContentHash *string         `json:"document_content_hash,omitempty"`
+	Locale              *string         `json:"locale,omitempty"`
+	RecordedBy          *string         `json:"recorded_by,omitempty"`
+	OnBehalfReason      *string         `json:"on_behalf_reason,omitempty"`
+	ExpiresAt           *int64          `json:"expires_at,omitempty"` // Consent có thời hạn (Unix microseconds)
+	Purposes            []purposeChoice `json:"purposes,omitempty"`   // Sắp theo purpose key
+}
+
+type purposeChoice struct {
+	Purpose  string `json:"purpose"`
+	Accepted bool   `json:"accepted"`
+}
+
+type revokeContent struct {
+	ConsentID        string `json:"consent_id"`
+	UserID           string `json:"user_id"`
+	DocumentID       string `json:"document_id"`
+	VersionTimestamp int64  `json:"version_timestamp"`
+	DeletedAt        int64  `json:"deleted_at"`
+	// omitempty giữ nguyên hash của các revoke ghi trước khi lưu người thu hồi
+	RevokedBy     *string `json:"revoked_by,omitempty"`
+	RevokedReason *string `json:"revoked_reason,omitempty"`
+}
+
+// declineContent là bằng chứng user chủ động từ chối (row declined không có GRANTED event)
+type declineContent struct {
+	ConsentID        string  `json:"consent_id"`
+	UserID           string  `json:"user_id"`
+	Platform         string  `json:"platform"`
+	DocumentID       string  `json:"document_id"`
+	DocumentName     string  `json:"document_name"`
+	VersionTimestamp int64   `json:"version_timestamp"`
+	DeclinedAt       int64   `json:"declined_at"`
+	ConsentMethod    string  `json:"consent_method"`
+	IPAddress        *string `json:"ip_address"`
+	UserAgent        *string `json:"user_agent"`
+	DeclinedBy       *string `json:"declined_by"`
+	Reason           *string `json:"reason"`
+}
+
+// erasureContent là bằng chứng còn lại sau khi IP/user agent bị xóa khỏi consent row.
+// Evidence là GrantContentHash/DeclineContentHash của row sau khi xóa nên phủ mọi field được giữ lại.
+type erasureContent struct {
+	ConsentID string `json:"consent_id"`
+	UserID    string `json:"user_id"`
+	ErasedAt  int64  `json:"erased_at"`
+	Evidence  string `json:"evidence"`
+}
+
+type record struct {
+	SeqNo       int64  `json:"seq_no"`
+	EventType   string `json:"event_type"`
+	UserID      string `json:"user_id"`
+	ConsentID   string `json:"consent_id"`
+	ContentHash string `json:"content_hash"`
+	OccurredAt  int64  `json:"occurred_at"`
+	PrevHash    string `json:"prev_hash"`
+}
+
+// GrantContentHash hashes the evidence fields of a consent row
+func GrantContentHash(c *domain.UserConsent) string {
+	return hashJSON(grantContent{
+		ConsentID:           c.ID,
+		UserID:              c.UserID,
+		Platform:            c.Platform,
+		DocumentID:          c.DocumentID,
+		DocumentName:        c.DocumentName,
+		VersionTimestamp:    c.VersionTimestamp,
+		AgreedAt:            c.AgreedAt.UnixMicro(),
+		AgreedFileURL:       c.AgreedFileURL,
+		ConsentMethod:       c.ConsentMethod,
+		IPAddress:           c.IPAddress,
+		UserAgent:           c.UserAgent,
+		DocumentContentHash: c.DocumentContentHash,
+		Locale:              c.Locale,
+		RecordedBy:          c.RecordedBy,
+		OnBehalfReason:      c.OnBehalfReason,
+		ExpiresAt:           optionalUnixMicro(c.ExpiresAt),
+		Purposes:            purposeChoices(c.Purposes),
+	})
+}
+
+// RevokeContentHash hashes the revocation state of a soft-deleted consent row
+func RevokeContentHash(c *domain.UserConsent) string {
+	return hashJSON(revokeContent{
+		ConsentID:        c.ID,
+		UserID:           c.UserID,
+		DocumentID:       c.DocumentID,
+		VersionTimestamp: c.VersionTimestamp,
+		DeletedAt:        unixMicro(c.DeletedAt),
+		RevokedBy:        c.RevokedBy,
+		RevokedReason:    c.RevokedReason,
+	})
+}
+
+// DeclineContentHash hashes the refusal evidence of a declined consent row
+func DeclineContentHash(c *domain.UserConsent) string {
+	return hashJSON(declineContent{
+		ConsentID:        c.ID,
+		UserID:           c.UserID,
+		Platform:         c.Platform,
+		DocumentID:       c.DocumentID,
+		DocumentName:     c.DocumentName,
+		VersionTimestamp: c.VersionTimestamp,
+		DeclinedAt:       c.AgreedAt.UnixMicro(),
+		ConsentMethod:    c.ConsentMethod,
+		IPAddress:        c.IPAddress,
+		UserAgent:        c.UserAgent,
+		DeclinedBy:       c.RevokedBy,
+		Reason:           c.RevokedReason,
+	})
+}
+
+// ErasureContentHash hashes the evidence retained by an erased consent row
+func ErasureContentHash(c *domain.UserConsent) string {
+	evidence := GrantContentHash(c)
+	if c.Status == domain.ConsentStatusDeclined {
+		evidence = DeclineContentHash(c)
+	}
+	return hashJSON(erasureContent{
+		ConsentID: c.ID,
+		UserID:    c.UserID,
+		ErasedAt:  unixMicro(c.ErasedAt),
+		Evidence:  evidence,
+	})
+}
+
+// RecordHash computes the chained hash of an event (uses e.PrevHash)
+func RecordHash(e *domain.ChainEvent) string {
+	return hashJSON(record{
+		SeqNo:       e.SeqNo,
+		EventType:   e.EventType,
+		UserID:      e.UserID,
+		ConsentID:   e.ConsentID,
+		ContentHash: e.ContentHash,
+		OccurredAt:  e.OccurredAt.UnixMicro(),
+		PrevHash:    e.PrevHash,
+	})
+}
+
+// Verify checks a user's chain events (ordered by seq_no) against the chain head
+// and the current consent rows (including soft-deleted ones)
+func Verify(userID string, events []*domain.ChainEvent, head *domain.ChainHead, consents []*domain.UserConsent) *domain.ChainReport {
+	report := &domain.ChainReport{
+		UserID:      userID,
+		ChainLength: int64(len(events)),
+		HeadHash:    domain.GenesisHash,
+	}
+	addIssue := func(seqNo int64, consentID, code, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, domain.ChainIssue{
+			SeqNo:     seqNo,
+			ConsentID: consentID,
+			Code:      code,
+			Message:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	rows := make(map[string]*domain.UserConsent, len(consents))
+	for _, c := range consents {
+		rows[c.ID] = c
+	}
+	granted := make(map[string]bool)
+	revoked := make(map[string]bool)
+	declined := make(map[string]bool)
+	erased := make(map[string]bool)
+	missing := make(map[string]bool)
+
+	// Step 1: Đi lần lượt từng event, kiểm tra link + hash + consent row tương ứng
+	prevHash := domain.GenesisHash
+	for i, e := range events {
+		if e.SeqNo != int64(i+1) {
+			addIssue(e.SeqNo, e.ConsentID, domain.ChainIssueSeqGap, "expected seq_no %d, got %d", i+1, e.SeqNo)
+		}
+		if e.PrevHash != prevHash {
+			addIssue(e.SeqNo, e.ConsentID, domain.ChainIssueBrokenLink, "prev_hash does not match previous record_hash")
+		}
+		if RecordHash(e) != e.RecordHash {
+			addIssue(e.SeqNo, e.ConsentID, domain.ChainIssueHashMismatch, "record_hash does not match event content")
+		}
+		prevHash = e.RecordHash
+
+		row := rows[e.ConsentID]
+		if row == nil {
+			if !missing[e.ConsentID] {
+				missing[e.ConsentID] = true
+				addIssue(e.SeqNo, e.ConsentID, domain.ChainIssueRowMissing, "consent row referenced by %s event no longer exists", e.EventType)
+			}
+			continue
+		}
+
+		switch e.EventType {
+		case domain.ChainEventGranted:
+			granted[row.ID] = true
+			if row.Status == domain.ConsentStatusDeclined {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRowModified, "consent was granted but row is marked declined")
+			} else if row.ErasedAt == nil && GrantContentHash(row) != e.ContentHash {
+				// Row đã xóa dữ liệu cá nhân được kiểm tra với ERASED event
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRowModified, "consent evidence does not match content_hash")
+			}
+			if row.RecordHash == nil || *row.RecordHash != e.RecordHash {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRowModified, "consent record_hash does not match chain")
+			}
+		case domain.ChainEventRevoked:
+			revoked[row.ID] = true
+			if !row.IsDeleted {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRevokeMismatch, "consent was revoked but row is not marked deleted")
+			} else if RevokeContentHash(row) != e.ContentHash {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRowModified, "revocation data does not match content_hash")
+			}
+		case domain.ChainEventDeclined:
+			declined[row.ID] = true
+			if row.Status != domain.ConsentStatusDeclined {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRowModified, "consent was declined but row status is %q", row.Status)
+			} else if row.ErasedAt == nil && DeclineContentHash(row) != e.ContentHash {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRowModified, "refusal evidence does not match content_hash")
+			}
+			if row.RecordHash == nil || *row.RecordHash != e.RecordHash {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRowModified, "consent record_hash does not match chain")
+			}
+		case domain.ChainEventErased:
+			erased[row.ID] = true
+			if row.ErasedAt == nil {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueEraseMismatch, "consent was erased but row is not marked erased")
+			} else if ErasureContentHash(row) != e.ContentHash {
+				addIssue(e.SeqNo, row.ID, domain.ChainIssueRowModified, "retained evidence does not match erasure content_hash")
+			}
+		default:
+			addIssue(e.SeqNo, e.ConsentID, domain.ChainIssueHashMismatch, "unknown event type %q", e.EventType)
+		}
+	}
+	report.HeadHash = prevHash
+
+	// Step 2: Consent rows không có trong chain (chèn ngoài luồng hoặc chưa backfill)
+	for _, c := range consents {
+		if c.ErasedAt != nil && !erased[c.ID] {
+			addIssue(0, c.ID, domain.ChainIssueEraseMismatch, "row is marked erased but chain has no ERASED event")
+		}
+		if c.Status == domain.ConsentStatusDeclined {
+			if !declined[c.ID] {
+				addIssue(0, c.ID, domain.ChainIssueUnchainedRow, "declined row has no DECLINED event in chain")
+			}
+			continue
+		}
+		if !granted[c.ID] {
+			addIssue(0, c.ID, domain.ChainIssueUnchainedRow, "consent row has no GRANTED event in chain")
+			continue
+		}
+		if c.IsDeleted && !revoked[c.ID] {
+			addIssue(0, c.ID, domain.ChainIssueRevokeMismatch, "row is marked deleted but chain has no REVOKED event")
+		}
+	}
+
+	// Step 3: So với chain head để phát hiện event cuối bị xóa
+	switch {
+	case head == nil && len(events) > 0:
+		addIssue(0, "", domain.ChainIssueHeadMismatch, "chain head is missing")
+	case head != nil && (head.LastSeq != int64(len(events)) || head.LastHash != prevHash):
+		addIssue(0, "", domain.ChainIssueHeadMismatch, "chain head (seq %d) does not match last event (seq %d)", head.LastSeq, len(events))
+	}
+
+	return report
+}
+
+func hashJSON(v interface{}) string {
+	// json.Marshal của struct có thứ tự field cố định nên kết quả deterministic
+	data, _ := json.Marshal(v)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func unixMicro(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.UnixMicro()
+}
+
+// optionalUnixMicro - nil giữ nguyên hash của các consent không có thời hạn
+func optionalUnixMicro(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	v := t.UnixMicro()
+	return &v
+}
+
+// purposeChoices sắp lựa chọn theo purpose key để hash không phụ thuộc thứ tự đọc
+func purposeChoices(purposes []domain.ConsentPurpose) []purposeChoice {
+	if len(purposes) == 0 {
+		return nil
+	}
+	choices := make([]purposeChoice, len(purposes))
+	for i, p := range purposes {
+		choices[i] = purposeChoice{Purpose: p.Purpose, Accepted: p.Accepted}
+	}
+	sort.Slice(choices, func(i, j int) bool { return choices[i].Purpose < choices[j].Purpose })
+	return choices
+}
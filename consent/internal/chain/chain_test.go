@@ -0,0 +1,215 @@
+package chain
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/thatlq1812/policy-system/consent/internal/domain"
+)
+
+const testUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// fixture là chain hợp lệ của 1 user:
+// c1 granted, c2 granted rồi revoked, c3 declined, c4 granted rồi erased
+type fixture struct {
+	events   []*domain.ChainEvent
+	head     *domain.ChainHead
+	consents map[string]*domain.UserConsent
+}
+
+func strPtr(s string) *string { return &s }
+
+func newFixture() *fixture {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	f := &fixture{consents: make(map[string]*domain.UserConsent)}
+
+	newRow := func(id string, offset time.Duration) *domain.UserConsent {
+		row := &domain.UserConsent{
+			ID:               id,
+			UserID:           testUserID,
+			Platform:         "Client",
+			DocumentID:       "doc-" + id,
+			DocumentName:     "Terms " + id,
+			VersionTimestamp: base.Unix(),
+			AgreedAt:         base.Add(offset),
+			ConsentMethod:    "explicit",
+			IPAddress:        strPtr("203.0.113.7"),
+			UserAgent:        strPtr("Mozilla/5.0"),
+			Status:           domain.ConsentStatusGranted,
+		}
+		f.consents[id] = row
+		return row
+	}
+
+	c1 := newRow("c1", time.Minute)
+	f.append(domain.ChainEventGranted, c1, GrantContentHash(c1))
+
+	c2 := newRow("c2", 2*time.Minute)
+	f.append(domain.ChainEventGranted, c2, GrantContentHash(c2))
+	revokedAt := base.Add(3 * time.Minute)
+	c2.IsDeleted, c2.DeletedAt, c2.RevokedBy, c2.RevokedReason = true, &revokedAt, strPtr(testUserID), strPtr("user_request")
+	c2.Status = domain.ConsentStatusWithdrawn
+	f.append(domain.ChainEventRevoked, c2, RevokeContentHash(c2))
+
+	c3 := newRow("c3", 4*time.Minute)
+	c3.IsDeleted, c3.Status, c3.RevokedReason = true, domain.ConsentStatusDeclined, strPtr("not interested")
+	f.append(domain.ChainEventDeclined, c3, DeclineContentHash(c3))
+
+	c4 := newRow("c4", 5*time.Minute)
+	f.append(domain.ChainEventGranted, c4, GrantContentHash(c4))
+	erasedAt := base.Add(6 * time.Minute)
+	c4.IPAddress, c4.UserAgent, c4.ErasedAt = nil, nil, &erasedAt
+	f.append(domain.ChainEventErased, c4, ErasureContentHash(c4))
+
+	return f
+}
+
+// append nối event mới vào cuối chain như repository (GRANTED/DECLINED lưu record_hash vào row)
+func (f *fixture) append(eventType string, row *domain.UserConsent, contentHash string) {
+	prev := domain.GenesisHash
+	if n := len(f.events); n > 0 {
+		prev = f.events[n-1].RecordHash
+	}
+	e := &domain.ChainEvent{
+		UserID:      testUserID,
+		SeqNo:       int64(len(f.events) + 1),
+		EventType:   eventType,
+		ConsentID:   row.ID,
+		ContentHash: contentHash,
+		OccurredAt:  time.Date(2025, 1, 1, 10, len(f.events), 0, 0, time.UTC),
+		PrevHash:    prev,
+	}
+	e.RecordHash = RecordHash(e)
+	f.events = append(f.events, e)
+	f.head = &domain.ChainHead{UserID: testUserID, LastSeq: e.SeqNo, LastHash: e.RecordHash}
+	if eventType == domain.ChainEventGranted || eventType == domain.ChainEventDeclined {
+		row.RecordHash = strPtr(e.RecordHash)
+	}
+}
+
+// rehash tính lại toàn bộ link/hash (kẻ sửa dữ liệu có quyền ghi DB tự tính lại chain)
+func (f *fixture) rehash() {
+	prev := domain.GenesisHash
+	for _, e := range f.events {
+		e.PrevHash = prev
+		e.RecordHash = RecordHash(e)
+		prev = e.RecordHash
+		if row := f.consents[e.ConsentID]; row != nil && (e.EventType == domain.ChainEventGranted || e.EventType == domain.ChainEventDeclined) {
+			row.RecordHash = strPtr(e.RecordHash)
+		}
+	}
+	last := f.events[len(f.events)-1]
+	f.head = &domain.ChainHead{UserID: testUserID, LastSeq: last.SeqNo, LastHash: last.RecordHash}
+}
+
+func (f *fixture) verify() *domain.ChainReport {
+	ids := make([]string, 0, len(f.consents))
+	for id := range f.consents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	consents := make([]*domain.UserConsent, len(ids))
+	for i, id := range ids {
+		consents[i] = f.consents[id]
+	}
+	return Verify(testUserID, f.events, f.head, consents)
+}
+
+// issueCodes trả về các issue code khác nhau của report (đã sắp xếp)
+func issueCodes(r *domain.ChainReport) []string {
+	seen := make(map[string]bool)
+	codes := []string{}
+	for _, issue := range r.Issues {
+		if !seen[issue.Code] {
+			seen[issue.Code] = true
+			codes = append(codes, issue.Code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(f *fixture)
+		wantCodes []string
+	}{
+		{"Valid chain", func(f *fixture) {}, []string{}},
+		{"Tampered - consent IP address changed", func(f *fixture) {
+			f.consents["c1"].IPAddress = strPtr("198.51.100.1")
+		}, []string{domain.ChainIssueRowModified}},
+		{"Tampered - consent record_hash changed", func(f *fixture) {
+			f.consents["c1"].RecordHash = strPtr(domain.GenesisHash)
+		}, []string{domain.ChainIssueRowModified}},
+		{"Tampered - revocation reason changed", func(f *fixture) {
+			f.consents["c2"].RevokedReason = strPtr("admin_action")
+		}, []string{domain.ChainIssueRowModified}},
+		{"Tampered - declined reason changed", func(f *fixture) {
+			f.consents["c3"].RevokedReason = strPtr("accepted")
+		}, []string{domain.ChainIssueRowModified}},
+		{"Tampered - event time changed", func(f *fixture) {
+			f.events[0].OccurredAt = f.events[0].OccurredAt.Add(-time.Hour)
+		}, []string{domain.ChainIssueHashMismatch}},
+		{"Tampered - event rehashed after content change", func(f *fixture) {
+			f.events[0].ContentHash = domain.GenesisHash
+			f.rehash()
+		}, []string{domain.ChainIssueRowModified}},
+		{"Tampered - revoked row restored", func(f *fixture) {
+			c2 := f.consents["c2"]
+			c2.IsDeleted, c2.DeletedAt, c2.Status = false, nil, domain.ConsentStatusGranted
+		}, []string{domain.ChainIssueRevokeMismatch}},
+		{"Consent row deleted", func(f *fixture) {
+			delete(f.consents, "c1")
+		}, []string{domain.ChainIssueRowMissing}},
+		{"Consent row inserted outside the chain", func(f *fixture) {
+			c5 := *f.consents["c1"]
+			c5.ID = "c5"
+			f.consents["c5"] = &c5
+		}, []string{domain.ChainIssueUnchainedRow}},
+		{"Event deleted from the middle", func(f *fixture) {
+			f.events = append(f.events[:2], f.events[3:]...) // REVOKED của c2
+		}, []string{domain.ChainIssueBrokenLink, domain.ChainIssueHeadMismatch, domain.ChainIssueRevokeMismatch, domain.ChainIssueSeqGap}},
+		{"Last event truncated", func(f *fixture) {
+			f.events = f.events[:len(f.events)-1] // ERASED của c4
+		}, []string{domain.ChainIssueEraseMismatch, domain.ChainIssueHeadMismatch}},
+		{"Chain head missing", func(f *fixture) {
+			f.head = nil
+		}, []string{domain.ChainIssueHeadMismatch}},
+		{"Seq gap with recomputed hashes", func(f *fixture) {
+			for _, e := range f.events[2:] {
+				e.SeqNo++
+			}
+			f.rehash()
+		}, []string{domain.ChainIssueHeadMismatch, domain.ChainIssueSeqGap}},
+		{"Erased - retained evidence changed", func(f *fixture) {
+			f.consents["c4"].DocumentName = "Other terms"
+		}, []string{domain.ChainIssueRowModified}},
+		{"Erased - row un-erased", func(f *fixture) {
+			c4 := f.consents["c4"]
+			c4.IPAddress, c4.UserAgent, c4.ErasedAt = strPtr("203.0.113.7"), strPtr("Mozilla/5.0"), nil
+		}, []string{domain.ChainIssueEraseMismatch}},
+		{"Erased - row erased without ERASED event", func(f *fixture) {
+			erasedAt := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+			c1 := f.consents["c1"]
+			c1.IPAddress, c1.UserAgent, c1.ErasedAt = nil, nil, &erasedAt
+		}, []string{domain.ChainIssueEraseMismatch}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture()
+			tt.mutate(f)
+
+			report := f.verify()
+			if got := issueCodes(report); !reflect.DeepEqual(got, tt.wantCodes) {
+				t.Errorf("Verify() issues = %v, want %v (%+v)", got, tt.wantCodes, report.Issues)
+			}
+			if report.Valid() != (len(tt.wantCodes) == 0) {
+				t.Errorf("Verify().Valid() = %v, want %v", report.Valid(), len(tt.wantCodes) == 0)
+			}
+		})
+	}
+}
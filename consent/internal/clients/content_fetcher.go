@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/netguard"
+)
+
+// ContentFetcher downloads document files (file_url) so their exact bytes can be
+// hashed and snapshotted at consent time
+type ContentFetcher struct {
+	httpClient *http.Client
+	maxBytes   int64
+}
+
+// NewContentFetcher creates a ContentFetcher; guard chặn file_url trỏ vào địa chỉ nội bộ
+func NewContentFetcher(guard *netguard.Guard, timeout time.Duration, maxBytes int64) *ContentFetcher {
+	return &ContentFetcher{
+		httpClient: guard.HTTPClient(timeout),
+		maxBytes:   maxBytes,
+	}
+}
+
+// Fetch downloads url and returns its content and content type.
+// File lớn hơn maxBytes bị từ chối thay vì cắt bớt (hash phải là của toàn bộ file).
+func (f *ContentFetcher) Fetch(ctx context.Context, url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch document file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to fetch document file: unexpected status %d", resp.StatusCode)
+	}
+
+	// Đọc thêm 1 byte để phát hiện file vượt giới hạn
+	content, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read document file: %w", err)
+	}
+	if int64(len(content)) > f.maxBytes {
+		return nil, "", fmt.Errorf("document file exceeds %d bytes", f.maxBytes)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(content)
+	}
+
+	return content, contentType, nil
+}
@@ -3,6 +3,7 @@ package clients
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"google.golang.org/grpc"
@@ -39,15 +40,81 @@ func (c *DocumentClient) Close() error {
 }
 
 // VerifyDocument checks if document exists and gets its info
-func (c *DocumentClient) VerifyDocument(ctx context.Context, platform, documentName string) (*pb.PolicyDocument, error) {
+// Chỉ version đã được duyệt (scheduled/published) mới được coi là hợp lệ để consent
+// locale chọn bản dịch, Document Service trả nội dung gốc nếu chưa có bản dịch
+func (c *DocumentClient) VerifyDocument(ctx context.Context, platform, documentName, locale string) (*pb.PolicyDocument, error) {
 	resp, err := c.client.GetLatestPolicyByPlatform(ctx, &pb.GetLatestPolicyRequest{
 		Platform:     platform,
 		DocumentName: documentName,
+		Locale:       locale,
 	})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify document: %w", err)
 	}
 
+	// Defensive check: Document Service đã lọc draft/pending, nhưng không tin tưởng tuyệt đối
+	if resp.Document != nil && !isApprovedStatus(resp.Document.Status) {
+		return nil, nil
+	}
+
 	return resp.Document, nil
 }
+
+// ListActivePolicies gets the effective version of every document on a platform
+func (c *DocumentClient) ListActivePolicies(ctx context.Context, platform string) ([]*pb.PolicyDocument, error) {
+	resp, err := c.client.ListActivePolicies(ctx, &pb.ListActivePoliciesRequest{
+		Platform: platform,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active policies: %w", err)
+	}
+
+	// Cùng defensive check như VerifyDocument
+	documents := make([]*pb.PolicyDocument, 0, len(resp.Documents))
+	for _, doc := range resp.Documents {
+		if isApprovedStatus(doc.Status) {
+			documents = append(documents, doc)
+		}
+	}
+
+	return documents, nil
+}
+
+// DownloadFile downloads an uploaded policy file by content hash (tối đa maxBytes)
+func (c *DocumentClient) DownloadFile(ctx context.Context, contentHash string, maxBytes int64) ([]byte, string, error) {
+	stream, err := c.client.DownloadFile(ctx, &pb.DownloadFileRequest{ContentHash: contentHash})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download file: %w", err)
+	}
+
+	var contentType string
+	var content []byte
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to download file: %w", err)
+		}
+		if msg.File != nil {
+			contentType = msg.File.ContentType
+			if msg.File.SizeBytes > maxBytes {
+				return nil, "", fmt.Errorf("document file exceeds %d bytes", maxBytes)
+			}
+		}
+		content = append(content, msg.Chunk...)
+		if int64(len(content)) > maxBytes {
+			return nil, "", fmt.Errorf("document file exceeds %d bytes", maxBytes)
+		}
+	}
+
+	return content, contentType, nil
+}
+
+// isApprovedStatus - status rỗng là document service cũ chưa có review flow
+func isApprovedStatus(status string) bool {
+	return status == "" || status == "scheduled" || status == "published"
+}
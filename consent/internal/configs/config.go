@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,6 +15,22 @@ type Config struct {
 	DatabaseURL        string
 	DBMaxConn          int
 	DocumentServiceURL string // NEW: URL to Document Service
+	// Thời gian ân hạn để user đồng ý policy bắt buộc mới, tính từ effective_timestamp
+	PendingGracePeriod time.Duration
+	// Chu kỳ quét version bắt buộc mới có hiệu lực để mở re-consent campaign
+	CampaignSyncPeriod time.Duration
+	// Chu kỳ đánh dấu hết hạn consent quá expires_at (consent_validity_period của document)
+	ConsentExpiryPeriod time.Duration
+	// Giới hạn khi tải file_url để hash/snapshot nội dung document lúc consent
+	ContentFetchTimeout time.Duration
+	SnapshotMaxBytes    int64
+	// Outbox: gửi domain events tới webhook (để trống = không gửi, event vẫn nằm trong outbox_events)
+	OutboxWebhookURL    string
+	OutboxWebhookSecret string // Ký body bằng HMAC-SHA256 (header X-Signature)
+	OutboxPollInterval  time.Duration
+	// Mạng nội bộ (CIDR) được phép gọi tới từ webhook và file_url;
+	// mặc định chặn loopback, RFC1918, link-local
+	OutboundAllowedNetworks []string
 }
 
 func Load() (*Config, error) {
@@ -20,10 +38,19 @@ func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		GRPCPort:           getEnv("GRPC_PORT", "50053"),
-		DatabaseURL:        getEnv("DATABASE_URL", ""),
-		DBMaxConn:          getEnvAsInt("DB_MAX_CONN", 10),
-		DocumentServiceURL: getEnv("DOCUMENT_SERVICE_URL", "localhost:50052"),
+		GRPCPort:                getEnv("GRPC_PORT", "50053"),
+		DatabaseURL:             getEnv("DATABASE_URL", ""),
+		DBMaxConn:               getEnvAsInt("DB_MAX_CONN", 10),
+		DocumentServiceURL:      getEnv("DOCUMENT_SERVICE_URL", "localhost:50052"),
+		PendingGracePeriod:      time.Duration(getEnvAsInt("PENDING_CONSENT_GRACE_DAYS", 7)) * 24 * time.Hour,
+		CampaignSyncPeriod:      time.Duration(getEnvAsInt("CAMPAIGN_SYNC_INTERVAL_SECONDS", 60)) * time.Second,
+		ConsentExpiryPeriod:     time.Duration(getEnvAsInt("CONSENT_EXPIRY_INTERVAL_SECONDS", 300)) * time.Second,
+		ContentFetchTimeout:     time.Duration(getEnvAsInt("CONTENT_FETCH_TIMEOUT_SECONDS", 10)) * time.Second,
+		SnapshotMaxBytes:        int64(getEnvAsInt("DOCUMENT_SNAPSHOT_MAX_BYTES", 10*1024*1024)),
+		OutboxWebhookURL:        getEnv("OUTBOX_WEBHOOK_URL", ""),
+		OutboxWebhookSecret:     getEnv("OUTBOX_WEBHOOK_SECRET", ""),
+		OutboxPollInterval:      time.Duration(getEnvAsInt("OUTBOX_POLL_INTERVAL_SECONDS", 2)) * time.Second,
+		OutboundAllowedNetworks: getEnvAsSlice("OUTBOUND_ALLOWED_NETWORKS"),
 	}
 
 	// Validate required fields
@@ -48,3 +75,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsSlice đọc danh sách phân cách bằng dấu phẩy
+func getEnvAsSlice(key string) []string {
+	var result []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
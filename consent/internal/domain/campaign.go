@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// ReconsentCampaign yêu cầu user đã đồng ý version cũ đồng ý lại version bắt buộc mới
+type ReconsentCampaign struct {
+	ID               string           `db:"id"`
+	Platform         string           `db:"platform"`
+	DocumentName     string           `db:"document_name"`
+	DocumentID       string           `db:"document_id"` // Version mới
+	VersionTimestamp int64            `db:"version_timestamp"`
+	Status           string           `db:"status"`
+	GraceDeadline    time.Time        `db:"grace_deadline"`
+	AffectedUsers    int              `db:"affected_users"`
+	SupersededBy     *string          `db:"superseded_by"` // Campaign của version mới hơn
+	UpdatedBy        *string          `db:"updated_by"`
+	CreatedAt        time.Time        `db:"created_at"`
+	UpdatedAt        time.Time        `db:"updated_at"`
+	Progress         CampaignProgress // Tính từ user_consents khi đọc
+}
+
+// CampaignProgress đếm affected users theo trạng thái đồng ý lại
+type CampaignProgress struct {
+	Accepted int
+	Pending  int // Chưa đồng ý, còn trong grace period
+	Overdue  int // Chưa đồng ý, đã quá grace deadline
+}
+
+// CampaignUser là 1 affected user của campaign
+type CampaignUser struct {
+	UserID                   string     `db:"user_id"`
+	PreviousVersionTimestamp int64      `db:"previous_version_timestamp"`
+	AcceptedAt               *time.Time `db:"accepted_at"` // NULL = chưa đồng ý version mới
+	Status                   string     // accepted, pending hoặc overdue
+}
+
+// CampaignFilter lọc danh sách campaign
+type CampaignFilter struct {
+	Platform     string
+	DocumentName string
+	Status       string
+	Page         int
+	PageSize     int
+}
+
+// CampaignUserFilter lọc affected users của campaign
+type CampaignUserFilter struct {
+	CampaignID string
+	Status     string // accepted, pending, overdue; rỗng = tất cả
+	Page       int
+	PageSize   int
+}
+
+// Phân trang campaigns/affected users
+const (
+	DefaultCampaignPageSize = 20
+	MaxCampaignPageSize     = 100
+)
+
+// Normalize áp dụng giá trị mặc định cho phân trang
+func (f *CampaignFilter) Normalize() {
+	f.Page, f.PageSize = normalizePage(f.Page, f.PageSize)
+}
+
+// Normalize áp dụng giá trị mặc định cho phân trang
+func (f *CampaignUserFilter) Normalize() {
+	f.Page, f.PageSize = normalizePage(f.Page, f.PageSize)
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultCampaignPageSize
+	}
+	if pageSize > MaxCampaignPageSize {
+		pageSize = MaxCampaignPageSize
+	}
+	return page, pageSize
+}
+
+// Campaign status constants
+const (
+	CampaignStatusActive     = "active"
+	CampaignStatusSuperseded = "superseded" // Đã có version bắt buộc mới hơn
+)
+
+// Campaign user status constants
+const (
+	CampaignUserAccepted = "accepted"
+	CampaignUserPending  = "pending"
+	CampaignUserOverdue  = "overdue"
+)
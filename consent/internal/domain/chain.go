@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// Chain event types - mỗi thay đổi mang tính bằng chứng pháp lý là 1 event trong chain
+const (
+	ChainEventGranted  = "GRANTED"  // User đồng ý (INSERT user_consents)
+	ChainEventRevoked  = "REVOKED"  // User thu hồi đồng ý (SoftDelete)
+	ChainEventDeclined = "DECLINED" // User từ chối policy không bắt buộc (row declined, không có GRANTED)
+	ChainEventErased   = "ERASED"   // IP/user agent bị xóa theo yêu cầu xóa dữ liệu (GRANTED/DECLINED gốc giữ nguyên)
+)
+
+// GenesisHash is the prev_hash of the first event in every user's chain
+const GenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// ChainEvent is one append-only entry of a user's consent hash chain
+type ChainEvent struct {
+	UserID      string    `db:"user_id"`
+	SeqNo       int64     `db:"seq_no"`
+	EventType   string    `db:"event_type"`
+	ConsentID   string    `db:"consent_id"`
+	ContentHash string    `db:"content_hash"` // SHA-256 of the consent evidence at event time
+	OccurredAt  time.Time `db:"occurred_at"`
+	PrevHash    string    `db:"prev_hash"`
+	RecordHash  string    `db:"record_hash"`
+}
+
+// ChainHead stores the last event of a user's chain (detects truncated tails)
+type ChainHead struct {
+	UserID   string `db:"user_id"`
+	LastSeq  int64  `db:"last_seq"`
+	LastHash string `db:"last_hash"`
+}
+
+// Chain issue codes reported by the verifier
+const (
+	ChainIssueBrokenLink     = "BROKEN_LINK"     // prev_hash không khớp event trước (event bị xóa/chèn)
+	ChainIssueSeqGap         = "SEQ_GAP"         // seq_no không liên tục
+	ChainIssueHashMismatch   = "HASH_MISMATCH"   // record_hash không khớp nội dung event
+	ChainIssueHeadMismatch   = "HEAD_MISMATCH"   // chain head không khớp event cuối (tail bị cắt)
+	ChainIssueRowMissing     = "ROW_MISSING"     // consent row đã bị xóa khỏi user_consents
+	ChainIssueRowModified    = "ROW_MODIFIED"    // nội dung consent row không khớp content_hash
+	ChainIssueRevokeMismatch = "REVOKE_MISMATCH" // trạng thái is_deleted không khớp REVOKED event
+	ChainIssueUnchainedRow   = "UNCHAINED_ROW"   // consent row không có trong chain
+	ChainIssueEraseMismatch  = "ERASE_MISMATCH"  // trạng thái erased_at không khớp ERASED event
+)
+
+// ChainIssue describes one integrity problem found in a user's chain
+type ChainIssue struct {
+	SeqNo     int64 // 0 nếu issue không gắn với event cụ thể
+	ConsentID string
+	Code      string
+	Message   string
+}
+
+// ChainReport is the result of verifying one user's consent chain
+type ChainReport struct {
+	UserID      string
+	ChainLength int64
+	HeadHash    string
+	Issues      []ChainIssue
+}
+
+// Valid returns true when no integrity issue was found
+func (r *ChainReport) Valid() bool {
+	return len(r.Issues) == 0
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // UserConsent represents a consent record
 type UserConsent struct {
@@ -21,8 +24,42 @@ type UserConsent struct {
 	RevokedAt        *time.Time `db:"revoked_at"`     // NEW: Phase 2 - Revocation time
 	RevokedReason    *string    `db:"revoked_reason"` // NEW: Phase 2 - Revocation reason
 	RevokedBy        *string    `db:"revoked_by"`     // NEW: Phase 2 - Who revoked
-	CreatedAt        time.Time  `db:"created_at"`
-	UpdatedAt        time.Time  `db:"updated_at"`
+	RecordHash       *string    `db:"record_hash"`    // Hash của GRANTED event trong consent chain (NULL = chưa backfill)
+	// SHA-256 của nội dung document tại thời điểm đồng ý (NULL = consent cũ / không verify document)
+	DocumentContentHash *string `db:"document_content_hash"`
+	Locale              *string `db:"locale"`           // Locale của nội dung đã hiển thị (NULL = consent cũ)
+	RecordedBy          *string `db:"recorded_by"`      // Admin ghi thay user (NULL = user tự đồng ý)
+	OnBehalfReason      *string `db:"on_behalf_reason"` // Lý do Admin ghi thay
+	// Hết hạn theo consent_validity_period của document, user phải xác nhận lại (NULL = không hết hạn)
+	ExpiresAt *time.Time `db:"expires_at"`
+	ExpiredAt *time.Time `db:"expired_at"` // Job đánh dấu hết hạn và phát ConsentExpired
+	// granted, withdrawn hoặc declined; is_deleted = status khác granted
+	Status string `db:"status"`
+	// IP/user agent đã bị xóa theo yêu cầu xóa dữ liệu (NULL = chưa), bằng chứng còn lại nằm trong ERASED event
+	ErasedAt  *time.Time `db:"erased_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	// Lựa chọn theo purpose của document (sắp theo key), đọc từ user_consent_purposes
+	Purposes []ConsentPurpose
+}
+
+// ConsentPurpose là lựa chọn đồng ý/từ chối 1 mục đích xử lý dữ liệu của document
+type ConsentPurpose struct {
+	Purpose  string `db:"purpose_key" json:"purpose"`
+	Accepted bool   `db:"accepted" json:"accepted"`
+}
+
+// PurposeDecision là lựa chọn mới nhất (consent còn hiệu lực) của user với 1 purpose
+type PurposeDecision struct {
+	Purpose  string
+	Accepted bool
+	Consent  *UserConsent // Consent ghi lại lựa chọn
+}
+
+// ErasureResult là kết quả xóa dữ liệu cá nhân khỏi consent của 1 user
+type ErasureResult struct {
+	ErasedConsents int    // Tổng số consent của user đã xóa IP/user agent (kể cả lần xóa trước)
+	ChainHeadHash  string // record_hash cuối chain sau khi xóa, đưa vào erasure certificate
 }
 
 // CreateConsentParams for inserting new consent
@@ -36,8 +73,157 @@ type CreateConsentParams struct {
 	ConsentMethod    string
 	IPAddress        *string // Optional
 	UserAgent        *string // Optional
+	// SHA-256 của nội dung document user đã đồng ý (xem DocumentSnapshot)
+	DocumentContentHash *string
+	Locale              *string // Locale của nội dung user đã xem
+	RecordedBy          *string // Admin ghi thay user (act on behalf of)
+	OnBehalfReason      *string
+	// Số ngày consent còn hiệu lực (consent_validity_period của document), 0 = không hết hạn
+	ValidityDays int
+	Purposes     []ConsentPurpose // Lựa chọn theo purpose, sắp theo key
+	// Thu hồi consent hiện tại của cùng version trong cùng transaction (user đổi lựa chọn purpose)
+	ReplacesExisting bool
+}
+
+// Purpose trả về lựa chọn của consent với purpose (false nếu consent không có purpose này)
+func (c *UserConsent) Purpose(key string) (ConsentPurpose, bool) {
+	for _, p := range c.Purposes {
+		if p.Purpose == key {
+			return p, true
+		}
+	}
+	return ConsentPurpose{}, false
+}
+
+// IsExpired - consent đã quá expires_at (kể cả khi job chưa kịp đánh dấu expired_at)
+func (c *UserConsent) IsExpired(now time.Time) bool {
+	return c.ExpiresAt != nil && !c.ExpiresAt.After(now)
+}
+
+// DeclineConsentParams for recording an explicit refusal of an optional policy
+type DeclineConsentParams struct {
+	UserID           string
+	Platform         string
+	DocumentID       string
+	DocumentName     string
+	VersionTimestamp int64
+	ConsentMethod    string
+	IPAddress        *string // Optional
+	UserAgent        *string // Optional
+	DeclinedBy       string  // User, hoặc Admin từ chối thay user
+	Reason           string
 }
 
+// ConsentTimelineEvent is one step of a user's consent timeline for a document
+type ConsentTimelineEvent struct {
+	ConsentID        string
+	DocumentID       string
+	DocumentName     string
+	VersionTimestamp int64
+	Action           string // granted, withdrawn, declined hoặc expired
+	At               time.Time
+	ActorID          string // Rỗng với expired (hết hạn tự động)
+	Reason           string
+}
+
+// BuildTimeline trải các consent row (kể cả đã thu hồi/từ chối/hết hạn) thành các event theo thời gian:
+// đồng ý → thu hồi → đồng ý lại...
+func BuildTimeline(consents []*UserConsent) []ConsentTimelineEvent {
+	var events []ConsentTimelineEvent
+	for _, c := range consents {
+		event := ConsentTimelineEvent{
+			ConsentID:        c.ID,
+			DocumentID:       c.DocumentID,
+			DocumentName:     c.DocumentName,
+			VersionTimestamp: c.VersionTimestamp,
+		}
+
+		if c.Status == ConsentStatusDeclined {
+			event.Action = ConsentStatusDeclined
+			event.At = c.AgreedAt
+			event.ActorID = derefString(c.RevokedBy)
+			event.Reason = derefString(c.RevokedReason)
+			events = append(events, event)
+			continue
+		}
+
+		granted := event
+		granted.Action = ConsentStatusGranted
+		granted.At = c.AgreedAt
+		granted.ActorID = c.UserID
+		if c.RecordedBy != nil {
+			granted.ActorID, granted.Reason = *c.RecordedBy, derefString(c.OnBehalfReason)
+		}
+		events = append(events, granted)
+
+		if c.ExpiredAt != nil {
+			expired := event
+			expired.Action = TimelineActionExpired
+			expired.At = *c.ExpiresAt
+			events = append(events, expired)
+		}
+
+		if c.Status == ConsentStatusWithdrawn {
+			withdrawn := event
+			withdrawn.Action = ConsentStatusWithdrawn
+			withdrawn.At = c.AgreedAt
+			if c.RevokedAt != nil {
+				withdrawn.At = *c.RevokedAt
+			} else if c.DeletedAt != nil {
+				withdrawn.At = *c.DeletedAt
+			}
+			withdrawn.ActorID = derefString(c.RevokedBy)
+			withdrawn.Reason = derefString(c.RevokedReason)
+			events = append(events, withdrawn)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+	return events
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// DocumentSnapshot is the exact document content a user agreed to, addressed by its SHA-256
+type DocumentSnapshot struct {
+	ContentHash string    `db:"content_hash"`
+	Content     []byte    `db:"content"`
+	ContentType string    `db:"content_type"`
+	Source      string    `db:"source"`     // 'content_html', 'file_hash' hoặc 'file_url'
+	SourceURL   *string   `db:"source_url"` // file_url nếu content tải từ URL
+	SizeBytes   int64     `db:"size_bytes"`
+	CapturedAt  time.Time `db:"captured_at"`
+}
+
+// Snapshot source constants
+const (
+	SnapshotSourceContentHTML = "content_html"
+	SnapshotSourceFileHash    = "file_hash" // File upload lưu ở Document Service
+	SnapshotSourceFileURL     = "file_url"
+)
+
+// Revocation reasons
+const (
+	RevokeReasonUserRequest     = "user_request"
+	RevokeReasonUserDeclined    = "user_declined"    // User từ chối không kèm lý do
+	RevokeReasonPurposesChanged = "purposes_changed" // User đồng ý lại cùng version với lựa chọn purpose khác
+)
+
+// Consent status constants
+const (
+	ConsentStatusGranted   = "granted"
+	ConsentStatusWithdrawn = "withdrawn" // Thu hồi (RevokeConsent)
+	ConsentStatusDeclined  = "declined"  // Chủ động từ chối policy không bắt buộc (DeclineConsent)
+)
+
+// TimelineActionExpired - consent quá expires_at (không phải status, consent vẫn granted)
+const TimelineActionExpired = "expired"
+
 // ConsentMethod constants
 const (
 	ConsentMethodRegistration = "REGISTRATION"
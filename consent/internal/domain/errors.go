@@ -18,4 +18,10 @@ var (
 
 	// ErrDocumentNotFound indicates the referenced document does not exist
 	ErrDocumentNotFound = errors.New("document not found")
+
+	// ErrSnapshotNotFound indicates no document snapshot exists for the content hash
+	ErrSnapshotNotFound = errors.New("document snapshot not found")
+
+	// ErrCampaignNotFound indicates the re-consent campaign does not exist
+	ErrCampaignNotFound = errors.New("re-consent campaign not found")
 )
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/thatlq1812/policy-system/consent/internal/domain"
+	"github.com/thatlq1812/policy-system/consent/internal/service"
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+)
+
+// ListReconsentCampaigns - List re-consent campaigns with progress
+func (h *ConsentHandler) ListReconsentCampaigns(ctx context.Context, req *pb.ListReconsentCampaignsRequest) (*pb.ListReconsentCampaignsResponse, error) {
+	filter := domain.CampaignFilter{
+		Platform:     req.Platform,
+		DocumentName: req.DocumentName,
+		Status:       req.Status,
+		Page:         int(req.Page),
+		PageSize:     int(req.PageSize),
+	}
+	filter.Normalize()
+
+	// Call service
+	campaigns, total, err := h.campaigns.ListCampaigns(ctx, filter)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	resp := &pb.ListReconsentCampaignsResponse{
+		Campaigns:  make([]*pb.ReconsentCampaign, len(campaigns)),
+		TotalCount: int32(total),
+		Page:       int32(filter.Page),
+		PageSize:   int32(filter.PageSize),
+	}
+	for i, c := range campaigns {
+		resp.Campaigns[i] = campaignToProto(c)
+	}
+
+	return resp, nil
+}
+
+// GetReconsentCampaign - Get a re-consent campaign with progress
+func (h *ConsentHandler) GetReconsentCampaign(ctx context.Context, req *pb.GetReconsentCampaignRequest) (*pb.GetReconsentCampaignResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	campaign, err := h.campaigns.GetCampaign(ctx, req.Id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.GetReconsentCampaignResponse{Campaign: campaignToProto(campaign)}, nil
+}
+
+// ListCampaignUsers - List affected users of a campaign (accepted/pending/overdue)
+func (h *ConsentHandler) ListCampaignUsers(ctx context.Context, req *pb.ListCampaignUsersRequest) (*pb.ListCampaignUsersResponse, error) {
+	if req.CampaignId == "" {
+		return nil, status.Error(codes.InvalidArgument, "campaign_id is required")
+	}
+
+	filter := domain.CampaignUserFilter{
+		CampaignID: req.CampaignId,
+		Status:     req.Status,
+		Page:       int(req.Page),
+		PageSize:   int(req.PageSize),
+	}
+	filter.Normalize()
+
+	users, total, err := h.campaigns.ListCampaignUsers(ctx, filter)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	resp := &pb.ListCampaignUsersResponse{
+		Users:      make([]*pb.CampaignUser, len(users)),
+		TotalCount: int32(total),
+		Page:       int32(filter.Page),
+		PageSize:   int32(filter.PageSize),
+	}
+	for i, u := range users {
+		resp.Users[i] = &pb.CampaignUser{
+			UserId:                   u.UserID,
+			PreviousVersionTimestamp: u.PreviousVersionTimestamp,
+			Status:                   u.Status,
+		}
+		if u.AcceptedAt != nil {
+			resp.Users[i].AcceptedAt = u.AcceptedAt.Unix()
+		}
+	}
+
+	return resp, nil
+}
+
+// UpdateReconsentCampaign - Admin changes the grace deadline of a campaign
+func (h *ConsentHandler) UpdateReconsentCampaign(ctx context.Context, req *pb.UpdateReconsentCampaignRequest) (*pb.UpdateReconsentCampaignResponse, error) {
+	if req.Id == "" || req.AdminId == "" || req.GraceDeadline == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id, admin_id and grace_deadline are required")
+	}
+
+	campaign, err := h.campaigns.UpdateGraceDeadline(ctx, service.UpdateCampaignParams{
+		ID:            req.Id,
+		GraceDeadline: req.GraceDeadline,
+		AdminID:       req.AdminId,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.UpdateReconsentCampaignResponse{Campaign: campaignToProto(campaign)}, nil
+}
+
+func campaignToProto(c *domain.ReconsentCampaign) *pb.ReconsentCampaign {
+	msg := &pb.ReconsentCampaign{
+		Id:               c.ID,
+		Platform:         c.Platform,
+		DocumentName:     c.DocumentName,
+		DocumentId:       c.DocumentID,
+		VersionTimestamp: c.VersionTimestamp,
+		Status:           c.Status,
+		GraceDeadline:    c.GraceDeadline.Unix(),
+		AffectedUsers:    int32(c.AffectedUsers),
+		AcceptedUsers:    int32(c.Progress.Accepted),
+		PendingUsers:     int32(c.Progress.Pending),
+		OverdueUsers:     int32(c.Progress.Overdue),
+		CreatedAt:        c.CreatedAt.Unix(),
+		UpdatedAt:        c.UpdatedAt.Unix(),
+	}
+	if c.SupersededBy != nil {
+		msg.SupersededBy = *c.SupersededBy
+	}
+	if c.UpdatedBy != nil {
+		msg.UpdatedBy = *c.UpdatedBy
+	}
+	return msg
+}
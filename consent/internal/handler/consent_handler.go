@@ -14,11 +14,12 @@ import (
 
 type ConsentHandler struct {
 	pb.UnimplementedConsentServiceServer
-	service service.ConsentService
+	service   service.ConsentService
+	campaigns service.CampaignService
 }
 
-func NewConsentHandler(service service.ConsentService) *ConsentHandler {
-	return &ConsentHandler{service: service}
+func NewConsentHandler(service service.ConsentService, campaigns service.CampaignService) *ConsentHandler {
+	return &ConsentHandler{service: service, campaigns: campaigns}
 }
 
 // RecordConsent - Lưu đồng ý mới (single hoặc bulk)
@@ -40,11 +41,18 @@ func (h *ConsentHandler) RecordConsent(ctx context.Context, req *pb.RecordConsen
 			agreedFileURL = &c.AgreedFileUrl
 		}
 
+		var purposes []domain.ConsentPurpose
+		for _, p := range c.Purposes {
+			purposes = append(purposes, domain.ConsentPurpose{Purpose: p.Purpose, Accepted: p.Accepted})
+		}
+
 		consents = append(consents, service.ConsentInput{
 			DocumentID:       c.DocumentId,
 			DocumentName:     c.DocumentName,
 			VersionTimestamp: c.VersionTimestamp,
 			AgreedFileURL:    agreedFileURL,
+			Locale:           c.Locale,
+			Purposes:         purposes,
 		})
 	}
 
@@ -65,6 +73,9 @@ func (h *ConsentHandler) RecordConsent(ctx context.Context, req *pb.RecordConsen
 		ConsentMethod: req.ConsentMethod,
 		IPAddress:     ipAddress,
 		UserAgent:     userAgent,
+		// Gateway chỉ set khi Admin token ghi thay user
+		ActingAdminID:  req.ActingAdminId,
+		OnBehalfReason: req.OnBehalfReason,
 	}
 
 	// Call service
@@ -137,19 +148,27 @@ func (h *ConsentHandler) CheckPendingConsents(ctx context.Context, req *pb.Check
 		return nil, status.Error(codes.InvalidArgument, "user_id and platform are required")
 	}
 
-	// Convert protobuf policies to service type
-	var latestPolicies []service.PolicyInfo
-	for _, p := range req.LatestPolicies {
-		latestPolicies = append(latestPolicies, service.PolicyInfo{
-			DocumentID:       p.DocumentId,
-			DocumentName:     p.DocumentName,
-			VersionTimestamp: p.VersionTimestamp,
-			Platform:         p.Platform,
-		})
-	}
+	var pending []service.PolicyInfo
+	var err error
+	if req.ResolveActivePolicies {
+		// Server-side mode: không tin danh sách policies từ caller
+		pending, err = h.service.CheckPendingConsentsForPlatform(ctx, req.UserId, req.Platform)
+	} else {
+		// Convert protobuf policies to service type
+		var latestPolicies []service.PolicyInfo
+		for _, p := range req.LatestPolicies {
+			latestPolicies = append(latestPolicies, service.PolicyInfo{
+				DocumentID:       p.DocumentId,
+				DocumentName:     p.DocumentName,
+				VersionTimestamp: p.VersionTimestamp,
+				Platform:         p.Platform,
+				IsMandatory:      p.IsMandatory,
+			})
+		}
 
-	// Call service
-	pending, err := h.service.CheckPendingConsents(ctx, req.UserId, latestPolicies)
+		// Call service
+		pending, err = h.service.CheckPendingConsents(ctx, req.UserId, latestPolicies)
+	}
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -162,6 +181,10 @@ func (h *ConsentHandler) CheckPendingConsents(ctx context.Context, req *pb.Check
 			DocumentName:     p.DocumentName,
 			VersionTimestamp: p.VersionTimestamp,
 			Platform:         p.Platform,
+			IsMandatory:      p.IsMandatory,
+			GraceDeadline:    p.GraceDeadline,
+			IsOverdue:        p.IsOverdue,
+			ConsentExpiredAt: p.ConsentExpiredAt,
 		})
 	}
 
@@ -177,7 +200,13 @@ func (h *ConsentHandler) RevokeConsent(ctx context.Context, req *pb.RevokeConsen
 		return nil, status.Error(codes.InvalidArgument, "user_id, document_id, and version_timestamp are required")
 	}
 
-	err := h.service.RevokeConsent(ctx, req.UserId, req.DocumentId, req.VersionTimestamp)
+	err := h.service.RevokeConsent(ctx, service.RevokeConsentParams{
+		UserID:           req.UserId,
+		DocumentID:       req.DocumentId,
+		VersionTimestamp: req.VersionTimestamp,
+		ActingAdminID:    req.ActingAdminId,
+		Reason:           req.Reason,
+	})
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -188,6 +217,41 @@ func (h *ConsentHandler) RevokeConsent(ctx context.Context, req *pb.RevokeConsen
 	}, nil
 }
 
+// DeclineConsent - User chủ động từ chối policy không bắt buộc
+func (h *ConsentHandler) DeclineConsent(ctx context.Context, req *pb.DeclineConsentRequest) (*pb.DeclineConsentResponse, error) {
+	if req.UserId == "" || req.DocumentId == "" || req.VersionTimestamp == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id, document_id, and version_timestamp are required")
+	}
+
+	var ipAddress *string
+	if req.IpAddress != "" {
+		ipAddress = &req.IpAddress
+	}
+
+	var userAgent *string
+	if req.UserAgent != "" {
+		userAgent = &req.UserAgent
+	}
+
+	consent, err := h.service.DeclineConsent(ctx, service.DeclineConsentParams{
+		UserID:           req.UserId,
+		Platform:         req.Platform,
+		DocumentID:       req.DocumentId,
+		DocumentName:     req.DocumentName,
+		VersionTimestamp: req.VersionTimestamp,
+		ConsentMethod:    req.ConsentMethod,
+		IPAddress:        ipAddress,
+		UserAgent:        userAgent,
+		ActingAdminID:    req.ActingAdminId,
+		Reason:           req.Reason,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.DeclineConsentResponse{Consent: domainToProto(consent)}, nil
+}
+
 // Helper: Convert domain to protobuf
 func domainToProto(c *domain.UserConsent) *pb.Consent {
 	consent := &pb.Consent{
@@ -199,6 +263,7 @@ func domainToProto(c *domain.UserConsent) *pb.Consent {
 		VersionTimestamp: c.VersionTimestamp,
 		AgreedAt:         c.AgreedAt.Unix(),
 		ConsentMethod:    c.ConsentMethod,
+		Status:           c.Status,
 		IsDeleted:        c.IsDeleted,
 		IsLatest:         c.IsLatest, // Phase 2
 		CreatedAt:        c.CreatedAt.Unix(),
@@ -235,6 +300,42 @@ func domainToProto(c *domain.UserConsent) *pb.Consent {
 		consent.RevokedBy = *c.RevokedBy
 	}
 
+	if c.RecordHash != nil {
+		consent.RecordHash = *c.RecordHash
+	}
+
+	if c.DocumentContentHash != nil {
+		consent.DocumentContentHash = *c.DocumentContentHash
+	}
+
+	if c.Locale != nil {
+		consent.Locale = *c.Locale
+	}
+
+	if c.RecordedBy != nil {
+		consent.RecordedBy = *c.RecordedBy
+	}
+
+	if c.OnBehalfReason != nil {
+		consent.OnBehalfReason = *c.OnBehalfReason
+	}
+
+	if c.ExpiresAt != nil {
+		consent.ExpiresAt = c.ExpiresAt.Unix()
+	}
+
+	if c.ExpiredAt != nil {
+		consent.ExpiredAt = c.ExpiredAt.Unix()
+	}
+
+	if c.ErasedAt != nil {
+		consent.ErasedAt = c.ErasedAt.Unix()
+	}
+
+	for _, p := range c.Purposes {
+		consent.Purposes = append(consent.Purposes, &pb.PurposeChoice{Purpose: p.Purpose, Accepted: p.Accepted})
+	}
+
 	return consent
 }
 
@@ -261,6 +362,10 @@ func mapError(err error) error {
 		return status.Error(codes.InvalidArgument, err.Error())
 	case errors.Is(err, domain.ErrDocumentNotFound):
 		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrSnapshotNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrCampaignNotFound):
+		return status.Error(codes.NotFound, err.Error())
 	default:
 		// Default to internal error for unknown errors
 		return status.Error(codes.Internal, "internal server error")
@@ -286,9 +391,24 @@ func (h *ConsentHandler) GetConsentHistory(ctx context.Context, req *pb.GetConse
 		pbConsents = append(pbConsents, domainConsentToProto(c))
 	}
 
+	var timeline []*pb.ConsentTimelineEvent
+	for _, e := range domain.BuildTimeline(history) {
+		timeline = append(timeline, &pb.ConsentTimelineEvent{
+			ConsentId:        e.ConsentID,
+			DocumentId:       e.DocumentID,
+			DocumentName:     e.DocumentName,
+			VersionTimestamp: e.VersionTimestamp,
+			Action:           e.Action,
+			At:               e.At.Unix(),
+			ActorId:          e.ActorID,
+			Reason:           e.Reason,
+		})
+	}
+
 	return &pb.GetConsentHistoryResponse{
-		History: pbConsents,
-		Total:   int32(len(pbConsents)),
+		History:  pbConsents,
+		Total:    int32(len(pbConsents)),
+		Timeline: timeline,
 	}, nil
 }
 
@@ -305,6 +425,7 @@ func (h *ConsentHandler) GetConsentStats(ctx context.Context, req *pb.GetConsent
 		TotalConsents:      int32(stats["total_consents"]),
 		ActiveConsents:     int32(stats["active_consents"]),
 		RevokedConsents:    int32(stats["revoked_consents"]),
+		DeclinedConsents:   int32(stats["declined_consents"]),
 		ConsentsByDocument: make(map[string]int32),
 		ConsentsByPlatform: make(map[string]int32),
 		ConsentsByMethod:   make(map[string]int32),
@@ -323,3 +444,107 @@ func (h *ConsentHandler) GetConsentStats(ctx context.Context, req *pb.GetConsent
 
 	return response, nil
 }
+
+// VerifyConsentChain - Verify tamper-evident hash chain of a user's consents
+func (h *ConsentHandler) VerifyConsentChain(ctx context.Context, req *pb.VerifyConsentChainRequest) (*pb.VerifyConsentChainResponse, error) {
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	// Call service
+	report, err := h.service.VerifyConsentChain(ctx, req.UserId)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	issues := make([]*pb.ChainIssue, len(report.Issues))
+	for i, issue := range report.Issues {
+		issues[i] = &pb.ChainIssue{
+			SeqNo:     issue.SeqNo,
+			ConsentId: issue.ConsentID,
+			Code:      issue.Code,
+			Message:   issue.Message,
+		}
+	}
+
+	return &pb.VerifyConsentChainResponse{
+		UserId:      report.UserID,
+		Valid:       report.Valid(),
+		ChainLength: report.ChainLength,
+		HeadHash:    report.HeadHash,
+		Issues:      issues,
+	}, nil
+}
+
+// GetDocumentSnapshot - Get the exact document content a user agreed to
+func (h *ConsentHandler) GetDocumentSnapshot(ctx context.Context, req *pb.GetDocumentSnapshotRequest) (*pb.GetDocumentSnapshotResponse, error) {
+	// Validate request
+	if req.ContentHash == "" {
+		return nil, status.Error(codes.InvalidArgument, "content_hash is required")
+	}
+
+	// Call service
+	snapshot, err := h.service.GetDocumentSnapshot(ctx, req.ContentHash)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	resp := &pb.GetDocumentSnapshotResponse{
+		ContentHash: snapshot.ContentHash,
+		Content:     snapshot.Content,
+		ContentType: snapshot.ContentType,
+		Source:      snapshot.Source,
+		SizeBytes:   snapshot.SizeBytes,
+		CapturedAt:  snapshot.CapturedAt.Unix(),
+	}
+	if snapshot.SourceURL != nil {
+		resp.SourceUrl = *snapshot.SourceURL
+	}
+
+	return resp, nil
+}
+
+// CheckPurposeConsent - Lựa chọn mới nhất của user với 1 purpose (chưa quyết định = không cho phép)
+func (h *ConsentHandler) CheckPurposeConsent(ctx context.Context, req *pb.CheckPurposeConsentRequest) (*pb.CheckPurposeConsentResponse, error) {
+	if req.UserId == "" || req.Purpose == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and purpose are required")
+	}
+
+	decision, err := h.service.CheckPurposeConsent(ctx, req.UserId, req.Purpose)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	if decision == nil {
+		return &pb.CheckPurposeConsentResponse{}, nil
+	}
+
+	return &pb.CheckPurposeConsentResponse{
+		Allowed:          decision.Accepted,
+		HasDecision:      true,
+		ConsentId:        decision.Consent.ID,
+		DocumentId:       decision.Consent.DocumentID,
+		DocumentName:     decision.Consent.DocumentName,
+		VersionTimestamp: decision.Consent.VersionTimestamp,
+		DecidedAt:        decision.Consent.AgreedAt.Unix(),
+	}, nil
+}
+
+// EraseUserConsents - Xóa dữ liệu cá nhân khỏi consent của user (bước của erasure saga)
+func (h *ConsentHandler) EraseUserConsents(ctx context.Context, req *pb.EraseUserConsentsRequest) (*pb.EraseUserConsentsResponse, error) {
+	if req.UserId == "" || req.ErasedBy == "" || req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id, erased_by and reason are required")
+	}
+
+	result, err := h.service.EraseUserConsents(ctx, req.UserId, req.ErasedBy, req.Reason)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &pb.EraseUserConsentsResponse{
+		ErasedConsents: int32(result.ErasedConsents),
+		ChainHeadHash:  result.ChainHeadHash,
+	}, nil
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/consent/internal/domain"
+)
+
+type CampaignRepository interface {
+	// Create mở campaign cho 1 version và chốt affected users.
+	// Trả về false nếu version đã có campaign (idempotent).
+	Create(ctx context.Context, campaign *domain.ReconsentCampaign) (bool, error)
+
+	// GetByID trả về campaign kèm tiến độ (nil nếu không tồn tại)
+	GetByID(ctx context.Context, id string) (*domain.ReconsentCampaign, error)
+
+	// List campaigns kèm tiến độ, version mới nhất trước
+	List(ctx context.Context, filter domain.CampaignFilter) ([]*domain.ReconsentCampaign, int, error)
+
+	// ListUsers liệt kê affected users của campaign kèm trạng thái đồng ý lại
+	ListUsers(ctx context.Context, filter domain.CampaignUserFilter) ([]*domain.CampaignUser, int, error)
+
+	// UpdateGraceDeadline đổi grace deadline (nil nếu campaign không tồn tại)
+	UpdateGraceDeadline(ctx context.Context, id string, deadline time.Time, updatedBy string) (*domain.ReconsentCampaign, error)
+
+	// GetGraceDeadlines trả về grace deadline theo document_id (version không có campaign thì không có key)
+	GetGraceDeadlines(ctx context.Context, documentIDs []string) (map[string]time.Time, error)
+}
+
+type campaignRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCampaignRepository(db *pgxpool.Pool) CampaignRepository {
+	return &campaignRepository{db: db}
+}
+
+// campaignColumns - thứ tự khớp scanCampaign, accepted đếm user có consent (chưa thu hồi, chưa hết hạn) với version mới
+const campaignColumns = `c.id, c.platform, c.document_name, c.document_id, c.version_timestamp, c.status,
+        c.grace_deadline, c.affected_users, c.superseded_by, c.updated_by, c.created_at, c.updated_at,
+        (SELECT COUNT(*) FROM reconsent_campaign_users u
+         WHERE u.campaign_id = c.id
+           AND EXISTS (
+               SELECT 1 FROM user_consents uc
+               WHERE uc.user_id = u.user_id AND uc.document_id = c.document_id AND uc.is_deleted = FALSE
+                 AND (uc.expires_at IS NULL OR uc.expires_at > NOW())
+           )) AS accepted_users`
+
+func scanCampaign(row pgx.Row) (*domain.ReconsentCampaign, error) {
+	var c domain.ReconsentCampaign
+	err := row.Scan(
+		&c.ID, &c.Platform, &c.DocumentName, &c.DocumentID, &c.VersionTimestamp, &c.Status,
+		&c.GraceDeadline, &c.AffectedUsers, &c.SupersededBy, &c.UpdatedBy, &c.CreatedAt, &c.UpdatedAt,
+		&c.Progress.Accepted,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chưa đồng ý: pending trong grace period, overdue sau grace deadline
+	remaining := c.AffectedUsers - c.Progress.Accepted
+	if time.Now().After(c.GraceDeadline) {
+		c.Progress.Overdue = remaining
+	} else {
+		c.Progress.Pending = remaining
+	}
+	return &c, nil
+}
+
+func (r *campaignRepository) Create(ctx context.Context, campaign *domain.ReconsentCampaign) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO reconsent_campaigns (platform, document_name, document_id, version_timestamp, grace_deadline)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (document_id) DO NOTHING
+		RETURNING id, status, created_at, updated_at
+	`, campaign.Platform, campaign.DocumentName, campaign.DocumentID, campaign.VersionTimestamp, campaign.GraceDeadline,
+	).Scan(&campaign.ID, &campaign.Status, &campaign.CreatedAt, &campaign.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	// Affected users: đang có consent (chưa thu hồi) với version cũ hơn của cùng document
+	result, err := tx.Exec(ctx, `
+		INSERT INTO reconsent_campaign_users (campaign_id, user_id, previous_version_timestamp)
+		SELECT $1, user_id, MAX(version_timestamp)
+		FROM user_consents
+		WHERE platform = $2 AND document_name = $3 AND version_timestamp < $4 AND is_deleted = FALSE
+		GROUP BY user_id
+	`, campaign.ID, campaign.Platform, campaign.DocumentName, campaign.VersionTimestamp)
+	if err != nil {
+		return false, fmt.Errorf("failed to snapshot affected users: %w", err)
+	}
+	campaign.AffectedUsers = int(result.RowsAffected())
+
+	_, err = tx.Exec(ctx, `UPDATE reconsent_campaigns SET affected_users = $2 WHERE id = $1`,
+		campaign.ID, campaign.AffectedUsers)
+	if err != nil {
+		return false, fmt.Errorf("failed to update affected users: %w", err)
+	}
+
+	// Campaign của version cũ không còn ý nghĩa: user chỉ cần đồng ý version mới nhất
+	_, err = tx.Exec(ctx, `
+		UPDATE reconsent_campaigns
+		SET status = 'superseded', superseded_by = $1, updated_at = NOW()
+		WHERE platform = $2 AND document_name = $3 AND version_timestamp < $4 AND status = 'active'
+	`, campaign.ID, campaign.Platform, campaign.DocumentName, campaign.VersionTimestamp)
+	if err != nil {
+		return false, fmt.Errorf("failed to supersede old campaigns: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	campaign.Progress = domain.CampaignProgress{Pending: campaign.AffectedUsers}
+	return true, nil
+}
+
+func (r *campaignRepository) GetByID(ctx context.Context, id string) (*domain.ReconsentCampaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM reconsent_campaigns c WHERE c.id::text = $1`
+
+	campaign, err := scanCampaign(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+	return campaign, nil
+}
+
+func (r *campaignRepository) List(ctx context.Context, filter domain.CampaignFilter) ([]*domain.ReconsentCampaign, int, error) {
+	where := `WHERE ($1 = '' OR c.platform = $1) AND ($2 = '' OR c.document_name = $2) AND ($3 = '' OR c.status = $3)`
+	args := []any{filter.Platform, filter.DocumentName, filter.Status}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM reconsent_campaigns c `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count campaigns: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT `+campaignColumns+`
+		FROM reconsent_campaigns c
+		`+where+`
+		ORDER BY c.version_timestamp DESC, c.id
+		LIMIT $4 OFFSET $5
+	`, append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+
+	campaigns, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.ReconsentCampaign, error) {
+		return scanCampaign(row)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan campaigns: %w", err)
+	}
+	return campaigns, total, nil
+}
+
+func (r *campaignRepository) ListUsers(ctx context.Context, filter domain.CampaignUserFilter) ([]*domain.CampaignUser, int, error) {
+	// Trạng thái tính theo consent hiện tại: thu hồi (hoặc hết hạn) consent version mới thì user quay lại pending/overdue
+	users := `
+		SELECT u.user_id::text AS user_id, u.previous_version_timestamp, a.accepted_at,
+		       CASE WHEN a.accepted_at IS NOT NULL THEN 'accepted'
+		            WHEN NOW() > c.grace_deadline THEN 'overdue'
+		            ELSE 'pending' END AS status
+		FROM reconsent_campaign_users u
+		JOIN reconsent_campaigns c ON c.id = u.campaign_id
+		LEFT JOIN LATERAL (
+			SELECT MIN(uc.agreed_at) AS accepted_at
+			FROM user_consents uc
+			WHERE uc.user_id = u.user_id AND uc.document_id = c.document_id AND uc.is_deleted = FALSE
+			  AND (uc.expires_at IS NULL OR uc.expires_at > NOW())
+		) a ON TRUE
+		WHERE u.campaign_id::text = $1
+	`
+	where := `WHERE ($2 = '' OR s.status = $2)`
+
+	var total int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM (`+users+`) s `+where, filter.CampaignID, filter.Status).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count campaign users: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT s.user_id, s.previous_version_timestamp, s.accepted_at, s.status
+		FROM (`+users+`) s
+		`+where+`
+		ORDER BY s.user_id
+		LIMIT $3 OFFSET $4
+	`, filter.CampaignID, filter.Status, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list campaign users: %w", err)
+	}
+
+	result, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.CampaignUser, error) {
+		var u domain.CampaignUser
+		err := row.Scan(&u.UserID, &u.PreviousVersionTimestamp, &u.AcceptedAt, &u.Status)
+		return &u, err
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan campaign users: %w", err)
+	}
+	return result, total, nil
+}
+
+func (r *campaignRepository) UpdateGraceDeadline(ctx context.Context, id string, deadline time.Time, updatedBy string) (*domain.ReconsentCampaign, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE reconsent_campaigns
+		SET grace_deadline = $2, updated_by = $3, updated_at = NOW()
+		WHERE id::text = $1
+	`, id, deadline, updatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update grace deadline: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, nil
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func (r *campaignRepository) GetGraceDeadlines(ctx context.Context, documentIDs []string) (map[string]time.Time, error) {
+	deadlines := make(map[string]time.Time, len(documentIDs))
+	if len(documentIDs) == 0 {
+		return deadlines, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT document_id::text, grace_deadline
+		FROM reconsent_campaigns
+		WHERE document_id::text = ANY($1)
+	`, documentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grace deadlines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var documentID string
+		var deadline time.Time
+		if err := rows.Scan(&documentID, &deadline); err != nil {
+			return nil, fmt.Errorf("failed to scan grace deadline: %w", err)
+		}
+		deadlines[documentID] = deadline
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating grace deadlines: %w", err)
+	}
+
+	return deadlines, nil
+}
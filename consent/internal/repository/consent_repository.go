@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/thatlq1812/policy-system/consent/internal/chain"
 	"github.com/thatlq1812/policy-system/consent/internal/domain"
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	outboxstore "github.com/thatlq1812/policy-system/shared/pkg/outbox/pgstore"
 )
 
 type ConsentRepository interface {
@@ -28,11 +32,18 @@ type ConsentRepository interface {
 	GetByUserAndDocument(ctx context.Context, userID, documentID string) ([]*domain.UserConsent, error)
 
 	// Soft delete consent
-	SoftDelete(ctx context.Context, userID, documentID string, versionTimestamp int64) error
+	// SoftDelete thu hồi consent, ghi lại ai thu hồi (user hoặc Admin) và lý do
+	SoftDelete(ctx context.Context, userID, documentID string, versionTimestamp int64, revokedBy, reason string) error
 
 	// GetExisting checks if a consent already exists (idempotent check)
 	GetExisting(ctx context.Context, userID, documentID string, versionTimestamp int64) (*domain.UserConsent, error)
 
+	// GetLatestForVersion trả về row mới nhất của version bất kể trạng thái (nil nếu chưa có)
+	GetLatestForVersion(ctx context.Context, userID, documentID string, versionTimestamp int64) (*domain.UserConsent, error)
+
+	// Decline ghi lại việc user từ chối policy không bắt buộc (DECLINED event trong chain)
+	Decline(ctx context.Context, params domain.DeclineConsentParams) (*domain.UserConsent, error)
+
 	// Transaction support for Phase 2
 	BeginTx(ctx context.Context) (pgx.Tx, error)
 	CreateWithTx(ctx context.Context, tx pgx.Tx, params domain.CreateConsentParams) (*domain.UserConsent, error)
@@ -41,8 +52,30 @@ type ConsentRepository interface {
 	GetConsentHistory(ctx context.Context, userID, documentID string) ([]*domain.UserConsent, error)
 	MarkOldConsentsAsNotLatest(ctx context.Context, tx pgx.Tx, userID, documentID string) error
 
+	// GetPurposeDecision trả về lựa chọn mới nhất của user với purpose trong các consent còn hiệu lực (nil nếu chưa có)
+	GetPurposeDecision(ctx context.Context, userID, purpose string) (*domain.PurposeDecision, error)
+
+	// Erase xóa IP/user agent khỏi mọi consent của user và ghi ERASED event cho từng row (idempotent)
+	Erase(ctx context.Context, userID string) (*domain.ErasureResult, error)
+
+	// ExpireDue đánh dấu hết hạn các consent đã quá expires_at (tối đa limit) và phát ConsentExpired
+	ExpireDue(ctx context.Context, limit int) ([]*domain.UserConsent, error)
+
 	// Phase 4: Statistics methods
 	GetConsentStats(ctx context.Context, platform string) (map[string]int, error)
+
+	// Consent hash chain (tamper evidence)
+	GetChainEvents(ctx context.Context, userID string) ([]*domain.ChainEvent, error)
+	GetChainHead(ctx context.Context, userID string) (*domain.ChainHead, error)
+	ListChainUserIDs(ctx context.Context) ([]string, error)
+	BackfillChain(ctx context.Context, userID string) (int, error)
+
+	// Document content snapshots (content-addressed by SHA-256)
+	// SaveDocumentSnapshot trả về content hash của version (request song song: snapshot ghi trước thắng)
+	SaveDocumentSnapshot(ctx context.Context, documentID, locale string, snapshot *domain.DocumentSnapshot) (string, error)
+	GetDocumentSnapshot(ctx context.Context, contentHash string) (*domain.DocumentSnapshot, error)
+	// GetVersionSnapshotHash trả về content hash đã capture cho version (document_id, locale), "" nếu chưa có
+	GetVersionSnapshotHash(ctx context.Context, documentID, locale string) (string, error)
 }
 
 type consentRepository struct {
@@ -53,37 +86,126 @@ func NewConsentRepository(db *pgxpool.Pool) ConsentRepository {
 	return &consentRepository{db: db}
 }
 
-func (r *consentRepository) Create(ctx context.Context, params domain.CreateConsentParams) (*domain.UserConsent, error) {
-	query := `
-        INSERT INTO user_consents (
-            user_id, platform, document_id, document_name, 
-            version_timestamp, agreed_file_url, consent_method,
-            ip_address, user_agent, is_latest
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, TRUE)
-        RETURNING id, user_id, platform, document_id, document_name,
-                  version_timestamp, agreed_at, agreed_file_url, consent_method,
-                  ip_address, user_agent, is_deleted, deleted_at, is_latest,
-                  revoked_at, revoked_reason, revoked_by, created_at, updated_at
-    `
-
-	var consent domain.UserConsent
-	err := r.db.QueryRow(ctx, query,
-		params.UserID, params.Platform, params.DocumentID, params.DocumentName,
-		params.VersionTimestamp, params.AgreedFileURL, params.ConsentMethod,
-		params.IPAddress, params.UserAgent,
-	).Scan(
-		&consent.ID, &consent.UserID, &consent.Platform, &consent.DocumentID, &consent.DocumentName,
-		&consent.VersionTimestamp, &consent.AgreedAt, &consent.AgreedFileURL, &consent.ConsentMethod,
-		&consent.IPAddress, &consent.UserAgent, &consent.IsDeleted, &consent.DeletedAt, &consent.IsLatest,
-		&consent.RevokedAt, &consent.RevokedReason, &consent.RevokedBy,
-		&consent.CreatedAt, &consent.UpdatedAt,
+// consentColumns là danh sách cột dùng chung cho mọi SELECT/RETURNING (thứ tự khớp scanConsent)
+const consentColumns = `id, user_id, platform, document_id, document_name,
+        version_timestamp, agreed_at, agreed_file_url, consent_method,
+        ip_address, user_agent, is_deleted, deleted_at, is_latest,
+        revoked_at, revoked_reason, revoked_by, record_hash, document_content_hash, locale,
+        recorded_by, on_behalf_reason, expires_at, expired_at, status, erased_at, created_at, updated_at`
+
+func scanConsent(row pgx.Row) (*domain.UserConsent, error) {
+	var c domain.UserConsent
+	err := row.Scan(
+		&c.ID, &c.UserID, &c.Platform, &c.DocumentID, &c.DocumentName,
+		&c.VersionTimestamp, &c.AgreedAt, &c.AgreedFileURL, &c.ConsentMethod,
+		&c.IPAddress, &c.UserAgent, &c.IsDeleted, &c.DeletedAt, &c.IsLatest,
+		&c.RevokedAt, &c.RevokedReason, &c.RevokedBy, &c.RecordHash, &c.DocumentContentHash, &c.Locale,
+		&c.RecordedBy, &c.OnBehalfReason, &c.ExpiresAt, &c.ExpiredAt, &c.Status, &c.ErasedAt, &c.CreatedAt, &c.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
 
+func scanConsents(rows pgx.Rows) ([]*domain.UserConsent, error) {
+	defer rows.Close()
+
+	var consents []*domain.UserConsent
+	for rows.Next() {
+		consent, err := scanConsent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan consent: %w", err)
+		}
+		consents = append(consents, consent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating consents: %w", err)
+	}
+
+	return consents, nil
+}
+
+// queryer là pool hoặc transaction
+type queryer interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// loadPurposes điền lựa chọn purpose (sắp theo key) cho các consent
+func loadPurposes(ctx context.Context, q queryer, consents ...*domain.UserConsent) error {
+	if len(consents) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.UserConsent, len(consents))
+	ids := make([]string, 0, len(consents))
+	for _, c := range consents {
+		if c == nil {
+			continue
+		}
+		byID[c.ID] = c
+		ids = append(ids, c.ID)
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT consent_id::text, purpose_key, accepted
+		FROM user_consent_purposes
+		WHERE consent_id::text = ANY($1)
+		ORDER BY consent_id, purpose_key
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get consent purposes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var consentID string
+		var p domain.ConsentPurpose
+		if err := rows.Scan(&consentID, &p.Purpose, &p.Accepted); err != nil {
+			return fmt.Errorf("failed to scan consent purpose: %w", err)
+		}
+		if c, ok := byID[consentID]; ok {
+			c.Purposes = append(c.Purposes, p)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating consent purposes: %w", err)
+	}
+
+	return nil
+}
+
+// scanConsentsWithPurposes đọc consents kèm lựa chọn purpose
+func scanConsentsWithPurposes(ctx context.Context, q queryer, rows pgx.Rows) ([]*domain.UserConsent, error) {
+	consents, err := scanConsents(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadPurposes(ctx, q, consents...); err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
+
+func (r *consentRepository) Create(ctx context.Context, params domain.CreateConsentParams) (*domain.UserConsent, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	consent, err := r.insertChained(ctx, tx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create consent: %w", err)
 	}
 
-	return &consent, nil
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return consent, nil
 }
 
 func (r *consentRepository) CreateBulk(ctx context.Context, consents []domain.CreateConsentParams) ([]*domain.UserConsent, error) {
@@ -94,39 +216,15 @@ func (r *consentRepository) CreateBulk(ctx context.Context, consents []domain.Cr
 	}
 	defer tx.Rollback(ctx)
 
-	query := `
-        INSERT INTO user_consents (
-            user_id, platform, document_id, document_name, 
-            version_timestamp, agreed_file_url, consent_method,
-            ip_address, user_agent, is_latest
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, TRUE)
-        RETURNING id, user_id, platform, document_id, document_name,
-                  version_timestamp, agreed_at, agreed_file_url, consent_method,
-                  ip_address, user_agent, is_deleted, deleted_at, is_latest,
-                  revoked_at, revoked_reason, revoked_by, created_at, updated_at
-    `
-
 	var result []*domain.UserConsent
 
 	for _, params := range consents {
-		var consent domain.UserConsent
-		err := tx.QueryRow(ctx, query,
-			params.UserID, params.Platform, params.DocumentID, params.DocumentName,
-			params.VersionTimestamp, params.AgreedFileURL, params.ConsentMethod,
-			params.IPAddress, params.UserAgent,
-		).Scan(
-			&consent.ID, &consent.UserID, &consent.Platform, &consent.DocumentID, &consent.DocumentName,
-			&consent.VersionTimestamp, &consent.AgreedAt, &consent.AgreedFileURL, &consent.ConsentMethod,
-			&consent.IPAddress, &consent.UserAgent, &consent.IsDeleted, &consent.DeletedAt, &consent.IsLatest,
-			&consent.RevokedAt, &consent.RevokedReason, &consent.RevokedBy,
-			&consent.CreatedAt, &consent.UpdatedAt,
-		)
-
+		consent, err := r.insertChained(ctx, tx, params)
 		if err != nil {
 			return nil, fmt.Errorf("failed to insert consent: %w", err)
 		}
 
-		result = append(result, &consent)
+		result = append(result, consent)
 	}
 
 	// Commit transaction
@@ -137,30 +235,193 @@ func (r *consentRepository) CreateBulk(ctx context.Context, consents []domain.Cr
 	return result, nil
 }
 
+// insertChained inserts a consent and appends its GRANTED event to the user's chain
+func (r *consentRepository) insertChained(ctx context.Context, tx pgx.Tx, params domain.CreateConsentParams) (*domain.UserConsent, error) {
+	// Xác nhận lại version đã hết hạn: consent cũ phải được đánh dấu hết hạn trước (unique index)
+	if _, err := expireConsents(ctx, tx, params.UserID, params.DocumentID, 0); err != nil {
+		return nil, err
+	}
+
+	// Đổi lựa chọn purpose: consent cũ bị thu hồi (REVOKED trong chain) trước khi ghi consent mới
+	if params.ReplacesExisting {
+		revokedBy := params.UserID
+		if params.RecordedBy != nil {
+			revokedBy = *params.RecordedBy
+		}
+		_, err := r.revoke(ctx, tx, params.UserID, params.DocumentID, params.VersionTimestamp,
+			revokedBy, domain.RevokeReasonPurposesChanged)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// expires_at tính từ cùng NOW() với agreed_at
+	query := `
+        INSERT INTO user_consents (
+            user_id, platform, document_id, document_name,
+            version_timestamp, agreed_file_url, consent_method,
+            ip_address, user_agent, document_content_hash, locale,
+            recorded_by, on_behalf_reason, is_latest, expires_at
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, TRUE,
+            CASE WHEN $14::int > 0 THEN NOW() + make_interval(days => $14::int) END)
+        RETURNING ` + consentColumns
+
+	consent, err := scanConsent(tx.QueryRow(ctx, query,
+		params.UserID, params.Platform, params.DocumentID, params.DocumentName,
+		params.VersionTimestamp, params.AgreedFileURL, params.ConsentMethod,
+		params.IPAddress, params.UserAgent, params.DocumentContentHash, params.Locale,
+		params.RecordedBy, params.OnBehalfReason, params.ValidityDays,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	// Lựa chọn purpose nằm trong GRANTED event nên phải lưu trước khi tính hash
+	for _, p := range params.Purposes {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO user_consent_purposes (consent_id, purpose_key, accepted) VALUES ($1, $2, $3)`,
+			consent.ID, p.Purpose, p.Accepted,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record purpose %s: %w", p.Purpose, err)
+		}
+	}
+	consent.Purposes = params.Purposes
+
+	// Hash dùng agreed_at do DB trả về để khớp khi verify đọc lại
+	recordHash, err := r.appendChainEvent(ctx, tx, consent.UserID, domain.ChainEventGranted,
+		consent.ID, chain.GrantContentHash(consent), consent.AgreedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx,
+		`UPDATE user_consents SET record_hash = $2 WHERE id = $1 RETURNING updated_at`,
+		consent.ID, recordHash,
+	).Scan(&consent.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set record hash: %w", err)
+	}
+	consent.RecordHash = &recordHash
+
+	// ConsentRecorded được gửi qua outbox, commit cùng consent
+	err = enqueueEvent(ctx, tx, outbox.EventConsentRecorded, consent.ID, outbox.ConsentRecorded{
+		ConsentID:        consent.ID,
+		UserID:           consent.UserID,
+		Platform:         consent.Platform,
+		DocumentID:       consent.DocumentID,
+		DocumentName:     consent.DocumentName,
+		VersionTimestamp: consent.VersionTimestamp,
+		ConsentMethod:    consent.ConsentMethod,
+		Locale:           derefString(consent.Locale),
+		RecordedBy:       derefString(consent.RecordedBy),
+		AgreedAt:         consent.AgreedAt.Unix(),
+		Purposes:         purposeMap(consent.Purposes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+// purposeMap - lựa chọn purpose trong payload của event (nil nếu document không có purpose)
+func purposeMap(purposes []domain.ConsentPurpose) map[string]bool {
+	if len(purposes) == 0 {
+		return nil
+	}
+	m := make(map[string]bool, len(purposes))
+	for _, p := range purposes {
+		m[p.Purpose] = p.Accepted
+	}
+	return m
+}
+
+// enqueueEvent ghi domain event của consent vào outbox trong transaction tx
+func enqueueEvent(ctx context.Context, tx pgx.Tx, eventType, consentID string, payload any) error {
+	event, err := outbox.NewEvent("consent", eventType, outbox.AggregateConsent, consentID, payload)
+	if err != nil {
+		return err
+	}
+	return outboxstore.Enqueue(ctx, tx, event)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// appendChainEvent appends an event to the user's chain and returns its record_hash.
+// Lock chain head (FOR UPDATE) để các transaction song song của cùng user không fork chain.
+func (r *consentRepository) appendChainEvent(ctx context.Context, tx pgx.Tx, userID, eventType, consentID, contentHash string, occurredAt time.Time) (string, error) {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO consent_chain_heads (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING`,
+		userID,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to init chain head: %w", err)
+	}
+
+	var lastSeq int64
+	var lastHash string
+	err = tx.QueryRow(ctx,
+		`SELECT last_seq, last_hash FROM consent_chain_heads WHERE user_id = $1 FOR UPDATE`,
+		userID,
+	).Scan(&lastSeq, &lastHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to lock chain head: %w", err)
+	}
+
+	event := &domain.ChainEvent{
+		UserID:      userID,
+		SeqNo:       lastSeq + 1,
+		EventType:   eventType,
+		ConsentID:   consentID,
+		ContentHash: contentHash,
+		OccurredAt:  occurredAt,
+		PrevHash:    lastHash,
+	}
+	event.RecordHash = chain.RecordHash(event)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO consent_chain (
+			user_id, seq_no, event_type, consent_id, content_hash,
+			occurred_at, prev_hash, record_hash
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, event.UserID, event.SeqNo, event.EventType, event.ConsentID, event.ContentHash,
+		event.OccurredAt, event.PrevHash, event.RecordHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to append chain event: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE consent_chain_heads
+		SET last_seq = $2, last_hash = $3, updated_at = NOW()
+		WHERE user_id = $1
+	`, userID, event.SeqNo, event.RecordHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to update chain head: %w", err)
+	}
+
+	return event.RecordHash, nil
+}
+
 func (r *consentRepository) HasConsented(ctx context.Context, userID, documentID string, minVersion int64) (*domain.UserConsent, error) {
 	query := `
-        SELECT id, user_id, platform, document_id, document_name,
-               version_timestamp, agreed_at, agreed_file_url, consent_method,
-               ip_address, user_agent, is_deleted, deleted_at, is_latest,
-               revoked_at, revoked_reason, revoked_by, created_at, updated_at
+        SELECT ` + consentColumns + `
         FROM user_consents
-        WHERE user_id = $1 
-          AND document_id = $2 
+        WHERE user_id = $1
+          AND document_id = $2
           AND version_timestamp >= $3
           AND is_deleted = FALSE
+          AND (expires_at IS NULL OR expires_at > NOW())
         ORDER BY version_timestamp DESC
         LIMIT 1
     `
 
-	var consent domain.UserConsent
-	err := r.db.QueryRow(ctx, query, userID, documentID, minVersion).Scan(
-		&consent.ID, &consent.UserID, &consent.Platform, &consent.DocumentID, &consent.DocumentName,
-		&consent.VersionTimestamp, &consent.AgreedAt, &consent.AgreedFileURL, &consent.ConsentMethod,
-		&consent.IPAddress, &consent.UserAgent, &consent.IsDeleted, &consent.DeletedAt, &consent.IsLatest,
-		&consent.RevokedAt, &consent.RevokedReason, &consent.RevokedBy,
-		&consent.CreatedAt, &consent.UpdatedAt,
-	)
-
+	consent, err := scanConsent(r.db.QueryRow(ctx, query, userID, documentID, minVersion))
 	if err == pgx.ErrNoRows {
 		return nil, nil // Not found is not an error
 	}
@@ -168,15 +429,15 @@ func (r *consentRepository) HasConsented(ctx context.Context, userID, documentID
 		return nil, fmt.Errorf("failed to check consent: %w", err)
 	}
 
-	return &consent, nil
+	if err := loadPurposes(ctx, r.db, consent); err != nil {
+		return nil, err
+	}
+	return consent, nil
 }
 
 func (r *consentRepository) GetUserConsents(ctx context.Context, userID string, includeDeleted bool) ([]*domain.UserConsent, error) {
 	query := `
-        SELECT id, user_id, platform, document_id, document_name,
-               version_timestamp, agreed_at, agreed_file_url, consent_method,
-               ip_address, user_agent, is_deleted, deleted_at, is_latest,
-               revoked_at, revoked_reason, revoked_by, created_at, updated_at
+        SELECT ` + consentColumns + `
         FROM user_consents
         WHERE user_id = $1
     `
@@ -191,33 +452,13 @@ func (r *consentRepository) GetUserConsents(ctx context.Context, userID string,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user consents: %w", err)
 	}
-	defer rows.Close()
-
-	var consents []*domain.UserConsent
-	for rows.Next() {
-		var consent domain.UserConsent
-		err := rows.Scan(
-			&consent.ID, &consent.UserID, &consent.Platform, &consent.DocumentID, &consent.DocumentName,
-			&consent.VersionTimestamp, &consent.AgreedAt, &consent.AgreedFileURL, &consent.ConsentMethod,
-			&consent.IPAddress, &consent.UserAgent, &consent.IsDeleted, &consent.DeletedAt, &consent.IsLatest,
-			&consent.RevokedAt, &consent.RevokedReason, &consent.RevokedBy,
-			&consent.CreatedAt, &consent.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan consent: %w", err)
-		}
-		consents = append(consents, &consent)
-	}
 
-	return consents, nil
+	return scanConsentsWithPurposes(ctx, r.db, rows)
 }
 
 func (r *consentRepository) GetByUserAndDocument(ctx context.Context, userID, documentID string) ([]*domain.UserConsent, error) {
 	query := `
-        SELECT id, user_id, platform, document_id, document_name,
-               version_timestamp, agreed_at, agreed_file_url, consent_method,
-               ip_address, user_agent, is_deleted, deleted_at, is_latest,
-               revoked_at, revoked_reason, revoked_by, created_at, updated_at
+        SELECT ` + consentColumns + `
         FROM user_consents
         WHERE user_id = $1 AND document_id = $2 AND is_deleted = FALSE
         ORDER BY version_timestamp DESC
@@ -227,73 +468,324 @@ func (r *consentRepository) GetByUserAndDocument(ctx context.Context, userID, do
 	if err != nil {
 		return nil, fmt.Errorf("failed to get consents: %w", err)
 	}
-	defer rows.Close()
 
-	var consents []*domain.UserConsent
-	for rows.Next() {
-		var consent domain.UserConsent
-		err := rows.Scan(
-			&consent.ID, &consent.UserID, &consent.Platform, &consent.DocumentID, &consent.DocumentName,
-			&consent.VersionTimestamp, &consent.AgreedAt, &consent.AgreedFileURL, &consent.ConsentMethod,
-			&consent.IPAddress, &consent.UserAgent, &consent.IsDeleted, &consent.DeletedAt, &consent.IsLatest,
-			&consent.RevokedAt, &consent.RevokedReason, &consent.RevokedBy,
-			&consent.CreatedAt, &consent.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan consent: %w", err)
-		}
-		consents = append(consents, &consent)
+	return scanConsentsWithPurposes(ctx, r.db, rows)
+}
+
+func (r *consentRepository) SoftDelete(ctx context.Context, userID, documentID string, versionTimestamp int64, revokedBy, reason string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	return consents, nil
+	if _, err := r.revoke(ctx, tx, userID, documentID, versionTimestamp, revokedBy, reason); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
-func (r *consentRepository) SoftDelete(ctx context.Context, userID, documentID string, versionTimestamp int64) error {
+// revoke thu hồi consent còn hiệu lực của version trong transaction tx,
+// ghi REVOKED event vào chain và ConsentRevoked vào outbox
+func (r *consentRepository) revoke(ctx context.Context, tx pgx.Tx, userID, documentID string, versionTimestamp int64, revokedBy, reason string) (*domain.UserConsent, error) {
 	query := `
         UPDATE user_consents
-        SET is_deleted = TRUE, deleted_at = $4
-        WHERE user_id = $1 AND document_id = $2 AND version_timestamp = $3 AND is_deleted = FALSE
-    `
+        SET is_deleted = TRUE, deleted_at = $4, revoked_at = $4, revoked_by = $5, revoked_reason = $6,
+            status = 'withdrawn'
+        WHERE user_id = $1 AND document_id = $2 AND version_timestamp = $3 AND is_deleted = FALSE AND expired_at IS NULL
+        RETURNING ` + consentColumns
 
-	result, err := r.db.Exec(ctx, query, userID, documentID, versionTimestamp, time.Now())
+	consent, err := scanConsent(tx.QueryRow(ctx, query, userID, documentID, versionTimestamp, time.Now(), revokedBy, reason))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("%w: consent not found or already deleted", domain.ErrNotFound)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to soft delete consent: %w", err)
+		return nil, fmt.Errorf("failed to soft delete consent: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("consent not found or already deleted")
+	// Revocation cũng là bằng chứng → ghi REVOKED event vào chain
+	_, err = r.appendChainEvent(ctx, tx, consent.UserID, domain.ChainEventRevoked,
+		consent.ID, chain.RevokeContentHash(consent), *consent.DeletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to soft delete consent: %w", err)
 	}
 
-	return nil
+	err = enqueueEvent(ctx, tx, outbox.EventConsentRevoked, consent.ID, outbox.ConsentRevoked{
+		ConsentID:        consent.ID,
+		UserID:           consent.UserID,
+		Platform:         consent.Platform,
+		DocumentID:       consent.DocumentID,
+		DocumentName:     consent.DocumentName,
+		VersionTimestamp: consent.VersionTimestamp,
+		RevokedBy:        revokedBy,
+		Reason:           reason,
+		RevokedAt:        consent.DeletedAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consent, nil
 }
 
 // GetExisting checks if a consent already exists
 func (r *consentRepository) GetExisting(ctx context.Context, userID, documentID string, versionTimestamp int64) (*domain.UserConsent, error) {
 	query := `
-		SELECT id, user_id, platform, document_id, document_name,
-		       version_timestamp, agreed_at, agreed_file_url, consent_method,
-		       ip_address, user_agent, is_deleted, deleted_at, is_latest,
-		       revoked_at, revoked_reason, revoked_by, created_at, updated_at
+		SELECT ` + consentColumns + `
 		FROM user_consents
-		WHERE user_id = $1 AND document_id = $2 AND version_timestamp = $3 AND is_deleted = FALSE
+		WHERE user_id = $1 AND document_id = $2 AND version_timestamp = $3 AND is_deleted = FALSE AND expired_at IS NULL
 		LIMIT 1
 	`
 
-	var consent domain.UserConsent
-	err := r.db.QueryRow(ctx, query, userID, documentID, versionTimestamp).Scan(
-		&consent.ID, &consent.UserID, &consent.Platform, &consent.DocumentID, &consent.DocumentName,
-		&consent.VersionTimestamp, &consent.AgreedAt, &consent.AgreedFileURL, &consent.ConsentMethod,
-		&consent.IPAddress, &consent.UserAgent, &consent.IsDeleted, &consent.DeletedAt, &consent.IsLatest,
-		&consent.RevokedAt, &consent.RevokedReason, &consent.RevokedBy,
-		&consent.CreatedAt, &consent.UpdatedAt,
-	)
+	consent, err := scanConsent(r.db.QueryRow(ctx, query, userID, documentID, versionTimestamp))
 	if err == pgx.ErrNoRows {
 		return nil, nil // Not found is not an error
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing consent: %w", err)
 	}
-	return &consent, nil
+
+	if err := loadPurposes(ctx, r.db, consent); err != nil {
+		return nil, err
+	}
+	return consent, nil
+}
+
+func (r *consentRepository) GetLatestForVersion(ctx context.Context, userID, documentID string, versionTimestamp int64) (*domain.UserConsent, error) {
+	query := `
+		SELECT ` + consentColumns + `
+		FROM user_consents
+		WHERE user_id = $1 AND document_id = $2 AND version_timestamp = $3
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	consent, err := scanConsent(r.db.QueryRow(ctx, query, userID, documentID, versionTimestamp))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest consent of version: %w", err)
+	}
+	return consent, nil
+}
+
+func (r *consentRepository) Decline(ctx context.Context, params domain.DeclineConsentParams) (*domain.UserConsent, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Row declined không còn hiệu lực ngay từ đầu: is_deleted = TRUE, agreed_at = thời điểm từ chối
+	query := `
+        INSERT INTO user_consents (
+            user_id, platform, document_id, document_name, version_timestamp,
+            consent_method, ip_address, user_agent, is_latest, status,
+            is_deleted, deleted_at, revoked_at, revoked_by, revoked_reason
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, FALSE, 'declined', TRUE, NOW(), NOW(), $9, $10)
+        RETURNING ` + consentColumns
+
+	consent, err := scanConsent(tx.QueryRow(ctx, query,
+		params.UserID, params.Platform, params.DocumentID, params.DocumentName, params.VersionTimestamp,
+		params.ConsentMethod, params.IPAddress, params.UserAgent, params.DeclinedBy, params.Reason,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decline consent: %w", err)
+	}
+
+	recordHash, err := r.appendChainEvent(ctx, tx, consent.UserID, domain.ChainEventDeclined,
+		consent.ID, chain.DeclineContentHash(consent), consent.AgreedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx,
+		`UPDATE user_consents SET record_hash = $2 WHERE id = $1 RETURNING updated_at`,
+		consent.ID, recordHash,
+	).Scan(&consent.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set record hash: %w", err)
+	}
+	consent.RecordHash = &recordHash
+
+	err = enqueueEvent(ctx, tx, outbox.EventConsentDeclined, consent.ID, outbox.ConsentDeclined{
+		ConsentID:        consent.ID,
+		UserID:           consent.UserID,
+		Platform:         consent.Platform,
+		DocumentID:       consent.DocumentID,
+		DocumentName:     consent.DocumentName,
+		VersionTimestamp: consent.VersionTimestamp,
+		DeclinedBy:       params.DeclinedBy,
+		Reason:           params.Reason,
+		DeclinedAt:       consent.AgreedAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return consent, nil
+}
+
+func (r *consentRepository) Erase(ctx context.Context, userID string) (*domain.ErasureResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Kể cả consent đã thu hồi/từ chối/hết hạn; row đã xóa ở lần chạy trước được bỏ qua
+	rows, err := tx.Query(ctx, `
+		UPDATE user_consents
+		SET ip_address = NULL, user_agent = NULL, erased_at = NOW(), updated_at = NOW()
+		WHERE user_id = $1 AND erased_at IS NULL
+		RETURNING `+consentColumns, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase consents: %w", err)
+	}
+	consents, err := scanConsentsWithPurposes(ctx, tx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Thứ tự event cố định theo thời điểm ghi consent
+	sort.Slice(consents, func(i, j int) bool { return consents[i].CreatedAt.Before(consents[j].CreatedAt) })
+	for _, c := range consents {
+		_, err := r.appendChainEvent(ctx, tx, userID, domain.ChainEventErased, c.ID, chain.ErasureContentHash(c), *c.ErasedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &domain.ErasureResult{ChainHeadHash: domain.GenesisHash}
+	err = tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM user_consents WHERE user_id = $1 AND erased_at IS NOT NULL`, userID,
+	).Scan(&result.ErasedConsents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count erased consents: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `SELECT last_hash FROM consent_chain_heads WHERE user_id = $1`, userID).Scan(&result.ChainHeadHash)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to get chain head: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *consentRepository) GetPurposeDecision(ctx context.Context, userID, purpose string) (*domain.PurposeDecision, error) {
+	// Cùng purpose key có thể nằm trong nhiều document: lựa chọn ghi gần nhất thắng
+	query := `
+		SELECT ` + consentColumns + `
+		FROM user_consents
+		WHERE id = (
+			SELECT uc.id
+			FROM user_consent_purposes p
+			JOIN user_consents uc ON uc.id = p.consent_id
+			WHERE uc.user_id::text = $1 AND p.purpose_key = $2
+			  AND uc.is_deleted = FALSE
+			  AND (uc.expires_at IS NULL OR uc.expires_at > NOW())
+			ORDER BY uc.agreed_at DESC, uc.version_timestamp DESC
+			LIMIT 1
+		)
+	`
+
+	consent, err := scanConsent(r.db.QueryRow(ctx, query, userID, purpose))
+	if err == pgx.ErrNoRows {
+		return nil, nil // Chưa có lựa chọn không phải lỗi
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purpose decision: %w", err)
+	}
+
+	if err := loadPurposes(ctx, r.db, consent); err != nil {
+		return nil, err
+	}
+	choice, _ := consent.Purpose(purpose)
+	return &domain.PurposeDecision{
+		Purpose:  purpose,
+		Accepted: choice.Accepted,
+		Consent:  consent,
+	}, nil
+}
+
+func (r *consentRepository) ExpireDue(ctx context.Context, limit int) ([]*domain.UserConsent, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	consents, err := expireConsents(ctx, tx, "", "", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return consents, nil
+}
+
+// expireConsents đánh dấu expired_at cho các consent đã quá expires_at và ghi ConsentExpired vào outbox.
+// userID/documentID rỗng = không lọc, limit = 0 = không giới hạn.
+// Hết hạn không phải hành động của ai nên không ghi vào consent chain (expires_at đã nằm trong GRANTED).
+func expireConsents(ctx context.Context, tx pgx.Tx, userID, documentID string, limit int) ([]*domain.UserConsent, error) {
+	query := `
+        UPDATE user_consents
+        SET expired_at = NOW()
+        WHERE id IN (
+            SELECT id FROM user_consents
+            WHERE is_deleted = FALSE AND expired_at IS NULL AND expires_at <= NOW()
+              AND ($1 = '' OR user_id::text = $1)
+              AND ($2 = '' OR document_id::text = $2)
+            ORDER BY expires_at
+            LIMIT NULLIF($3::int, 0)
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + consentColumns
+
+	rows, err := tx.Query(ctx, query, userID, documentID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire consents: %w", err)
+	}
+
+	consents, err := scanConsents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range consents {
+		err := enqueueEvent(ctx, tx, outbox.EventConsentExpired, c.ID, outbox.ConsentExpired{
+			ConsentID:        c.ID,
+			UserID:           c.UserID,
+			Platform:         c.Platform,
+			DocumentID:       c.DocumentID,
+			DocumentName:     c.DocumentName,
+			VersionTimestamp: c.VersionTimestamp,
+			AgreedAt:         c.AgreedAt.Unix(),
+			ExpiresAt:        c.ExpiresAt.Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return consents, nil
 }
 
 // BeginTx starts a new transaction
@@ -303,45 +795,18 @@ func (r *consentRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
 
 // CreateWithTx creates a consent within a transaction
 func (r *consentRepository) CreateWithTx(ctx context.Context, tx pgx.Tx, params domain.CreateConsentParams) (*domain.UserConsent, error) {
-	query := `
-        INSERT INTO user_consents (
-            user_id, platform, document_id, document_name, 
-            version_timestamp, agreed_file_url, consent_method,
-            ip_address, user_agent, is_latest
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, TRUE)
-        RETURNING id, user_id, platform, document_id, document_name,
-                  version_timestamp, agreed_at, agreed_file_url, consent_method,
-                  ip_address, user_agent, is_deleted, deleted_at, is_latest,
-                  revoked_at, revoked_reason, revoked_by, created_at, updated_at
-    `
-
-	var consent domain.UserConsent
-	err := tx.QueryRow(ctx, query,
-		params.UserID, params.Platform, params.DocumentID, params.DocumentName,
-		params.VersionTimestamp, params.AgreedFileURL, params.ConsentMethod,
-		params.IPAddress, params.UserAgent,
-	).Scan(
-		&consent.ID, &consent.UserID, &consent.Platform, &consent.DocumentID, &consent.DocumentName,
-		&consent.VersionTimestamp, &consent.AgreedAt, &consent.AgreedFileURL, &consent.ConsentMethod,
-		&consent.IPAddress, &consent.UserAgent, &consent.IsDeleted, &consent.DeletedAt, &consent.IsLatest,
-		&consent.RevokedAt, &consent.RevokedReason, &consent.RevokedBy,
-		&consent.CreatedAt, &consent.UpdatedAt,
-	)
-
+	consent, err := r.insertChained(ctx, tx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create consent with tx: %w", err)
 	}
 
-	return &consent, nil
+	return consent, nil
 }
 
 // GetConsentHistory retrieves all consent records for a user+document (including old versions)
 func (r *consentRepository) GetConsentHistory(ctx context.Context, userID, documentID string) ([]*domain.UserConsent, error) {
 	query := `
-		SELECT id, user_id, platform, document_id, document_name,
-		       version_timestamp, agreed_at, agreed_file_url, consent_method,
-		       ip_address, user_agent, is_deleted, deleted_at, is_latest,
-		       revoked_at, revoked_reason, revoked_by, created_at, updated_at
+		SELECT ` + consentColumns + `
 		FROM user_consents
 		WHERE user_id = $1 AND document_id = $2
 		ORDER BY version_timestamp DESC, agreed_at DESC
@@ -351,49 +816,253 @@ func (r *consentRepository) GetConsentHistory(ctx context.Context, userID, docum
 	if err != nil {
 		return nil, fmt.Errorf("failed to get consent history: %w", err)
 	}
+
+	return scanConsentsWithPurposes(ctx, r.db, rows)
+}
+
+// MarkOldConsentsAsNotLatest marks all previous consents as not latest (for version upgrades)
+// is_latest là trạng thái suy ra, không nằm trong consent chain
+func (r *consentRepository) MarkOldConsentsAsNotLatest(ctx context.Context, tx pgx.Tx, userID, documentID string) error {
+	query := `
+		UPDATE user_consents
+		SET is_latest = FALSE,
+		    updated_at = NOW()
+		WHERE user_id = $1
+		  AND document_id = $2
+		  AND is_latest = TRUE
+		  AND is_deleted = FALSE
+	`
+
+	_, err := tx.Exec(ctx, query, userID, documentID)
+	if err != nil {
+		return fmt.Errorf("failed to mark old consents as not latest: %w", err)
+	}
+
+	return nil
+}
+
+// GetChainEvents retrieves a user's consent chain ordered by seq_no
+func (r *consentRepository) GetChainEvents(ctx context.Context, userID string) ([]*domain.ChainEvent, error) {
+	query := `
+		SELECT user_id, seq_no, event_type, consent_id, content_hash,
+		       occurred_at, prev_hash, record_hash
+		FROM consent_chain
+		WHERE user_id = $1
+		ORDER BY seq_no ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain events: %w", err)
+	}
 	defer rows.Close()
 
-	var consents []*domain.UserConsent
+	var events []*domain.ChainEvent
 	for rows.Next() {
-		var c domain.UserConsent
+		var e domain.ChainEvent
 		err := rows.Scan(
-			&c.ID, &c.UserID, &c.Platform, &c.DocumentID, &c.DocumentName,
-			&c.VersionTimestamp, &c.AgreedAt, &c.AgreedFileURL, &c.ConsentMethod,
-			&c.IPAddress, &c.UserAgent, &c.IsDeleted, &c.DeletedAt, &c.IsLatest,
-			&c.RevokedAt, &c.RevokedReason, &c.RevokedBy,
-			&c.CreatedAt, &c.UpdatedAt,
+			&e.UserID, &e.SeqNo, &e.EventType, &e.ConsentID, &e.ContentHash,
+			&e.OccurredAt, &e.PrevHash, &e.RecordHash,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan consent history: %w", err)
+			return nil, fmt.Errorf("failed to scan chain event: %w", err)
 		}
-		consents = append(consents, &c)
+		events = append(events, &e)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating consent history: %w", err)
+		return nil, fmt.Errorf("error iterating chain events: %w", err)
 	}
 
-	return consents, nil
+	return events, nil
 }
 
-// MarkOldConsentsAsNotLatest marks all previous consents as not latest (for version upgrades)
-func (r *consentRepository) MarkOldConsentsAsNotLatest(ctx context.Context, tx pgx.Tx, userID, documentID string) error {
+// GetChainHead retrieves the head of a user's chain (nil if the user has no chain)
+func (r *consentRepository) GetChainHead(ctx context.Context, userID string) (*domain.ChainHead, error) {
+	var head domain.ChainHead
+	err := r.db.QueryRow(ctx,
+		`SELECT user_id, last_seq, last_hash FROM consent_chain_heads WHERE user_id = $1`,
+		userID,
+	).Scan(&head.UserID, &head.LastSeq, &head.LastHash)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain head: %w", err)
+	}
+	return &head, nil
+}
+
+// ListChainUserIDs lists every user that has consent rows or chain records
+func (r *consentRepository) ListChainUserIDs(ctx context.Context) ([]string, error) {
 	query := `
-		UPDATE user_consents
-		SET is_latest = FALSE,
-		    updated_at = NOW()
-		WHERE user_id = $1 
-		  AND document_id = $2 
-		  AND is_latest = TRUE
-		  AND is_deleted = FALSE
+		SELECT user_id::text FROM user_consents
+		UNION
+		SELECT user_id::text FROM consent_chain
+		UNION
+		SELECT user_id::text FROM consent_chain_heads
+		ORDER BY 1
 	`
 
-	_, err := tx.Exec(ctx, query, userID, documentID)
+	rows, err := r.db.Query(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to mark old consents as not latest: %w", err)
+		return nil, fmt.Errorf("failed to list chain users: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating chain users: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// BackfillChain chains a user's consent rows created before the hash chain existed
+// (record_hash IS NULL), in agreed_at order. Returns number of rows chained.
+func (r *consentRepository) BackfillChain(ctx context.Context, userID string) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT `+consentColumns+`
+		FROM user_consents
+		WHERE user_id = $1 AND record_hash IS NULL
+		ORDER BY agreed_at ASC, created_at ASC
+		FOR UPDATE
+	`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unchained consents: %w", err)
+	}
+	consents, err := scanConsentsWithPurposes(ctx, tx, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range consents {
+		eventType, contentHash := domain.ChainEventGranted, chain.GrantContentHash(c)
+		if c.Status == domain.ConsentStatusDeclined {
+			eventType, contentHash = domain.ChainEventDeclined, chain.DeclineContentHash(c)
+		}
+		recordHash, err := r.appendChainEvent(ctx, tx, c.UserID, eventType, c.ID, contentHash, c.AgreedAt)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE user_consents SET record_hash = $2 WHERE id = $1`, c.ID, recordHash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to set record hash: %w", err)
+		}
+
+		if c.Status == domain.ConsentStatusWithdrawn && c.DeletedAt != nil {
+			_, err = r.appendChainEvent(ctx, tx, c.UserID, domain.ChainEventRevoked,
+				c.ID, chain.RevokeContentHash(c), *c.DeletedAt)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(consents), nil
+}
+
+// SaveDocumentSnapshot stores a document snapshot and links it to the document version it
+// was captured from. Snapshots are immutable so a snapshot with the same content hash is kept
+// as is; snapshot.Content nil nghĩa là snapshot đã có, chỉ ghi liên kết version.
+// Version đã có snapshot (request song song) giữ snapshot ghi trước, hash của nó được trả về.
+func (r *consentRepository) SaveDocumentSnapshot(ctx context.Context, documentID, locale string, snapshot *domain.DocumentSnapshot) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if snapshot.Content != nil {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO document_snapshots (
+				content_hash, content, content_type, source, source_url, size_bytes
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (content_hash) DO NOTHING
+		`,
+			snapshot.ContentHash, snapshot.Content, snapshot.ContentType,
+			snapshot.Source, snapshot.SourceURL, snapshot.SizeBytes,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to save document snapshot: %w", err)
+		}
+	}
+
+	// DO UPDATE không đổi gì để RETURNING luôn trả về row (kể cả row đã có)
+	var contentHash string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO document_version_snapshots (document_id, locale, content_hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (document_id, locale) DO UPDATE SET document_id = EXCLUDED.document_id
+		RETURNING content_hash
+	`, documentID, locale, snapshot.ContentHash).Scan(&contentHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to save document version snapshot: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return contentHash, nil
+}
+
+// GetVersionSnapshotHash retrieves the content hash captured for a document version
+func (r *consentRepository) GetVersionSnapshotHash(ctx context.Context, documentID, locale string) (string, error) {
+	var contentHash string
+	err := r.db.QueryRow(ctx, `
+		SELECT content_hash
+		FROM document_version_snapshots
+		WHERE document_id = $1::uuid AND locale = $2
+	`, documentID, locale).Scan(&contentHash)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get document version snapshot: %w", err)
+	}
+	return contentHash, nil
+}
+
+// GetDocumentSnapshot retrieves a document snapshot by content hash
+func (r *consentRepository) GetDocumentSnapshot(ctx context.Context, contentHash string) (*domain.DocumentSnapshot, error) {
+	query := `
+		SELECT content_hash, content, content_type, source, source_url, size_bytes, captured_at
+		FROM document_snapshots
+		WHERE content_hash = $1
+	`
+
+	var snapshot domain.DocumentSnapshot
+	err := r.db.QueryRow(ctx, query, contentHash).Scan(
+		&snapshot.ContentHash, &snapshot.Content, &snapshot.ContentType,
+		&snapshot.Source, &snapshot.SourceURL, &snapshot.SizeBytes, &snapshot.CapturedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document snapshot: %w", err)
+	}
+
+	return &snapshot, nil
 }
 
 // GetConsentStats retrieves aggregated statistics about consents
@@ -432,20 +1101,25 @@ func (r *consentRepository) GetConsentStats(ctx context.Context, platform string
 	}
 	stats["active_consents"] = activeConsents
 
-	// Revoked consents (revoked_at IS NOT NULL)
-	revokedFilter := platformFilter
-	if revokedFilter == "" {
-		revokedFilter = " WHERE revoked_at IS NOT NULL"
-	} else {
-		revokedFilter += " AND revoked_at IS NOT NULL"
-	}
-	query = "SELECT COUNT(*) FROM user_consents" + revokedFilter
-	var revokedConsents int
-	err = r.db.QueryRow(ctx, query, args...).Scan(&revokedConsents)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count revoked consents: %w", err)
+	// Revoked (withdrawn) và declined consents
+	for key, status := range map[string]string{
+		"revoked_consents":  domain.ConsentStatusWithdrawn,
+		"declined_consents": domain.ConsentStatusDeclined,
+	} {
+		statusFilter := platformFilter
+		if statusFilter == "" {
+			statusFilter = " WHERE status = '" + status + "'"
+		} else {
+			statusFilter += " AND status = '" + status + "'"
+		}
+		query = "SELECT COUNT(*) FROM user_consents" + statusFilter
+		var count int
+		err = r.db.QueryRow(ctx, query, args...).Scan(&count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s consents: %w", status, err)
+		}
+		stats[key] = count
 	}
-	stats["revoked_consents"] = revokedConsents
 
 	// Consents by document
 	query = "SELECT document_name, COUNT(*) FROM user_consents" + platformFilter + " GROUP BY document_name"
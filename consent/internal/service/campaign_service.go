@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thatlq1812/policy-system/consent/internal/clients"
+	"github.com/thatlq1812/policy-system/consent/internal/domain"
+	"github.com/thatlq1812/policy-system/consent/internal/repository"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+)
+
+// CampaignService quản lý re-consent campaigns khi policy bắt buộc có version mới
+type CampaignService interface {
+	// SyncCampaigns mở campaign cho các version bắt buộc mới có hiệu lực (chạy định kỳ)
+	SyncCampaigns(ctx context.Context) ([]*domain.ReconsentCampaign, error)
+
+	// ListCampaigns liệt kê campaigns kèm tiến độ accepted/pending/overdue
+	ListCampaigns(ctx context.Context, filter domain.CampaignFilter) ([]*domain.ReconsentCampaign, int, error)
+
+	// GetCampaign lấy 1 campaign kèm tiến độ
+	GetCampaign(ctx context.Context, id string) (*domain.ReconsentCampaign, error)
+
+	// ListCampaignUsers liệt kê affected users, lọc theo trạng thái đồng ý lại
+	ListCampaignUsers(ctx context.Context, filter domain.CampaignUserFilter) ([]*domain.CampaignUser, int, error)
+
+	// UpdateGraceDeadline gia hạn/rút ngắn grace period của campaign (Admin, bắt buộc lý do)
+	UpdateGraceDeadline(ctx context.Context, params UpdateCampaignParams) (*domain.ReconsentCampaign, error)
+}
+
+// UpdateCampaignParams input for changing a campaign's grace deadline
+type UpdateCampaignParams struct {
+	ID            string
+	GraceDeadline int64 // Unix timestamp
+	AdminID       string
+	Reason        string
+}
+
+type campaignService struct {
+	repo        repository.CampaignRepository
+	docClient   *clients.DocumentClient
+	gracePeriod time.Duration // Grace period mặc định khi mở campaign, tính từ effective_timestamp
+	auditLog    *audit.Logger
+}
+
+func NewCampaignService(repo repository.CampaignRepository, docClient *clients.DocumentClient, gracePeriod time.Duration, auditLog *audit.Logger) CampaignService {
+	return &campaignService{
+		repo:        repo,
+		docClient:   docClient,
+		gracePeriod: gracePeriod,
+		auditLog:    auditLog,
+	}
+}
+
+// Audit actions của re-consent campaigns
+const (
+	auditActionCampaignStart  = "consent.campaign_start"
+	auditActionCampaignUpdate = "consent.campaign_update"
+
+	// auditActorCampaignSync là actor của các campaign được mở tự động
+	auditActorCampaignSync = "system:campaign-sync"
+)
+
+// SyncCampaigns đọc version đang hiệu lực của mọi platform từ Document Service.
+// Campaign mở theo version có hiệu lực (không theo lúc UpdatePolicy tạo draft) nên
+// bắt được cả version publish ngay, publish theo lịch hay approve khi đã quá effective_timestamp.
+func (s *campaignService) SyncCampaigns(ctx context.Context) ([]*domain.ReconsentCampaign, error) {
+	if s.docClient == nil {
+		return nil, fmt.Errorf("document service client is not configured")
+	}
+
+	var started []*domain.ReconsentCampaign
+	var errs []error
+	for _, platform := range []string{domain.PlatformClient, domain.PlatformMerchant, domain.PlatformAdmin} {
+		documents, err := s.docClient.ListActivePolicies(ctx, platform)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", platform, err))
+			continue
+		}
+
+		for _, doc := range documents {
+			if !doc.IsMandatory {
+				continue
+			}
+
+			campaign := &domain.ReconsentCampaign{
+				Platform:         doc.Platform,
+				DocumentName:     doc.DocumentName,
+				DocumentID:       doc.Id,
+				VersionTimestamp: doc.EffectiveTimestamp,
+				GraceDeadline:    time.Unix(doc.EffectiveTimestamp, 0).Add(s.gracePeriod),
+			}
+			created, err := s.repo.Create(ctx, campaign)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s/%s: %w", platform, doc.DocumentName, err))
+				continue
+			}
+			if !created {
+				continue
+			}
+
+			s.auditLog.Record(ctx, audit.Event{
+				Action:     auditActionCampaignStart,
+				TargetType: "reconsent_campaign",
+				TargetID:   campaign.ID,
+				After:      campaignAuditView(campaign),
+				Reason:     "mandatory policy version became effective",
+				ActorID:    auditActorCampaignSync,
+			})
+			started = append(started, campaign)
+		}
+	}
+
+	return started, errors.Join(errs...)
+}
+
+func (s *campaignService) ListCampaigns(ctx context.Context, filter domain.CampaignFilter) ([]*domain.ReconsentCampaign, int, error) {
+	if filter.Platform != "" {
+		if err := validatePlatform(filter.Platform); err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+		}
+	}
+	switch filter.Status {
+	case "", domain.CampaignStatusActive, domain.CampaignStatusSuperseded:
+	default:
+		return nil, 0, fmt.Errorf("%w: status must be one of: '%s' or '%s'", domain.ErrInvalidInput,
+			domain.CampaignStatusActive, domain.CampaignStatusSuperseded)
+	}
+	filter.Normalize()
+
+	return s.repo.List(ctx, filter)
+}
+
+func (s *campaignService) GetCampaign(ctx context.Context, id string) (*domain.ReconsentCampaign, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", domain.ErrInvalidInput)
+	}
+
+	campaign, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrCampaignNotFound, id)
+	}
+	return campaign, nil
+}
+
+func (s *campaignService) ListCampaignUsers(ctx context.Context, filter domain.CampaignUserFilter) ([]*domain.CampaignUser, int, error) {
+	if filter.CampaignID == "" {
+		return nil, 0, fmt.Errorf("%w: campaign_id is required", domain.ErrInvalidInput)
+	}
+	switch filter.Status {
+	case "", domain.CampaignUserAccepted, domain.CampaignUserPending, domain.CampaignUserOverdue:
+	default:
+		return nil, 0, fmt.Errorf("%w: status must be one of: '%s', '%s' or '%s'", domain.ErrInvalidInput,
+			domain.CampaignUserAccepted, domain.CampaignUserPending, domain.CampaignUserOverdue)
+	}
+	filter.Normalize()
+
+	// Phân biệt campaign không tồn tại với campaign không có affected user
+	if _, err := s.GetCampaign(ctx, filter.CampaignID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.repo.ListUsers(ctx, filter)
+}
+
+func (s *campaignService) UpdateGraceDeadline(ctx context.Context, params UpdateCampaignParams) (*domain.ReconsentCampaign, error) {
+	if params.ID == "" || params.AdminID == "" {
+		return nil, fmt.Errorf("%w: id and admin_id are required", domain.ErrInvalidInput)
+	}
+	if strings.TrimSpace(params.Reason) == "" {
+		return nil, fmt.Errorf("%w: reason is required when changing a grace deadline", domain.ErrInvalidInput)
+	}
+
+	before, err := s.GetCampaign(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if params.GraceDeadline <= before.VersionTimestamp {
+		return nil, fmt.Errorf("%w: grace_deadline must be after the version's effective_timestamp (%d)",
+			domain.ErrInvalidInput, before.VersionTimestamp)
+	}
+
+	updated, err := s.repo.UpdateGraceDeadline(ctx, params.ID, time.Unix(params.GraceDeadline, 0), params.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrCampaignNotFound, params.ID)
+	}
+
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionCampaignUpdate,
+		TargetType: "reconsent_campaign",
+		TargetID:   updated.ID,
+		Before:     campaignAuditView(before),
+		After:      campaignAuditView(updated),
+		Reason:     params.Reason,
+		ActorID:    params.AdminID,
+	})
+
+	return updated, nil
+}
+
+// campaignAuditView trả về các field của campaign được ghi vào audit log
+func campaignAuditView(c *domain.ReconsentCampaign) map[string]any {
+	return map[string]any{
+		"id":                c.ID,
+		"platform":          c.Platform,
+		"document_id":       c.DocumentID,
+		"document_name":     c.DocumentName,
+		"version_timestamp": c.VersionTimestamp,
+		"grace_deadline":    c.GraceDeadline.Unix(),
+		"affected_users":    c.AffectedUsers,
+	}
+}
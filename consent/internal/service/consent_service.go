@@ -2,11 +2,20 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/thatlq1812/policy-system/consent/internal/chain"
 	"github.com/thatlq1812/policy-system/consent/internal/clients"
 	"github.com/thatlq1812/policy-system/consent/internal/domain"
 	"github.com/thatlq1812/policy-system/consent/internal/repository"
+	documentpb "github.com/thatlq1812/policy-system/shared/pkg/api/document"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
 )
 
 type ConsentService interface {
@@ -22,28 +31,74 @@ type ConsentService interface {
 	// Check pending consents by comparing with latest policies
 	CheckPendingConsents(ctx context.Context, userID string, latestPolicies []PolicyInfo) ([]PolicyInfo, error)
 
-	// Revoke consent (soft delete)
-	RevokeConsent(ctx context.Context, userID, documentID string, versionTimestamp int64) error
+	// Check pending consents against active policies resolved from Document Service (authoritative)
+	CheckPendingConsentsForPlatform(ctx context.Context, userID, platform string) ([]PolicyInfo, error)
+
+	// Revoke consent (soft delete), ghi lại người thu hồi
+	RevokeConsent(ctx context.Context, params RevokeConsentParams) error
+
+	// DeclineConsent ghi lại việc user chủ động từ chối policy không bắt buộc
+	DeclineConsent(ctx context.Context, params DeclineConsentParams) (*domain.UserConsent, error)
 
 	// Phase 2: Get consent history for a user+document
 	GetConsentHistory(ctx context.Context, userID, documentID string) ([]*domain.UserConsent, error)
 
 	// Phase 4: Get consent statistics
 	GetConsentStats(ctx context.Context, platform string) (map[string]int, error)
+
+	// Verify tamper-evident hash chain of a user's consents
+	VerifyConsentChain(ctx context.Context, userID string) (*domain.ChainReport, error)
+
+	// Get the document content a user agreed to, by content hash
+	GetDocumentSnapshot(ctx context.Context, contentHash string) (*domain.DocumentSnapshot, error)
+
+	// ExpireConsents đánh dấu hết hạn các consent quá expires_at và phát ConsentExpired (chạy định kỳ)
+	ExpireConsents(ctx context.Context) ([]*domain.UserConsent, error)
+
+	// CheckPurposeConsent trả về lựa chọn mới nhất của user với 1 purpose (nil nếu user chưa quyết định)
+	CheckPurposeConsent(ctx context.Context, userID, purpose string) (*domain.PurposeDecision, error)
+
+	// EraseUserConsents xóa IP/user agent khỏi mọi consent của user, giữ bằng chứng đã ẩn danh trong chain
+	EraseUserConsents(ctx context.Context, userID, erasedBy, reason string) (*domain.ErasureResult, error)
 }
 
 type consentService struct {
-	repo      repository.ConsentRepository
-	docClient *clients.DocumentClient // NEW: Document Service client
+	repo        repository.ConsentRepository
+	campaigns   repository.CampaignRepository // Grace deadline của re-consent campaign (nếu có)
+	docClient   *clients.DocumentClient       // NEW: Document Service client
+	fetcher     *clients.ContentFetcher       // Tải file_url để hash nội dung document
+	maxSnapshot int64                         // Giới hạn kích thước snapshot (bytes)
+	gracePeriod time.Duration                 // Thời gian ân hạn cho policy bắt buộc chưa consent
+	auditLog    *audit.Logger
 }
 
-func NewConsentService(repo repository.ConsentRepository, docClient *clients.DocumentClient) ConsentService {
+func NewConsentService(repo repository.ConsentRepository, campaigns repository.CampaignRepository, docClient *clients.DocumentClient, fetcher *clients.ContentFetcher, maxSnapshot int64, gracePeriod time.Duration, auditLog *audit.Logger) ConsentService {
 	return &consentService{
-		repo:      repo,
-		docClient: docClient,
+		repo:        repo,
+		campaigns:   campaigns,
+		docClient:   docClient,
+		fetcher:     fetcher,
+		maxSnapshot: maxSnapshot,
+		gracePeriod: gracePeriod,
+		auditLog:    auditLog,
 	}
 }
 
+// Audit actions của Consent Service
+const (
+	auditActionRecord  = "consent.record"
+	auditActionRevoke  = "consent.revoke"
+	auditActionDecline = "consent.decline"
+	auditActionExpire  = "consent.expire"
+	auditActionErase   = "consent.erase"
+
+	// auditActorConsentExpiry là actor của các consent hết hạn tự động
+	auditActorConsentExpiry = "system:consent-expiry"
+)
+
+// expireBatchSize - số consent đánh dấu hết hạn trong 1 transaction
+const expireBatchSize = 500
+
 // RecordConsentsParams input for bulk consent
 type RecordConsentsParams struct {
 	UserID        string
@@ -52,6 +107,9 @@ type RecordConsentsParams struct {
 	ConsentMethod string
 	IPAddress     *string
 	UserAgent     *string
+	// Admin ghi thay user (act on behalf of), rỗng = user tự đồng ý
+	ActingAdminID  string
+	OnBehalfReason string
 }
 
 type ConsentInput struct {
@@ -59,6 +117,32 @@ type ConsentInput struct {
 	DocumentName     string
 	VersionTimestamp int64
 	AgreedFileURL    *string
+	Locale           string // Locale user đã xem, Document Service fallback nếu chưa có bản dịch
+	// Lựa chọn theo purpose của document. Purpose không gửi: bắt buộc = đồng ý, tùy chọn = từ chối
+	Purposes []domain.ConsentPurpose
+}
+
+// RevokeConsentParams input for revocation
+type RevokeConsentParams struct {
+	UserID           string
+	DocumentID       string
+	VersionTimestamp int64
+	ActingAdminID    string // Admin thu hồi thay user, rỗng = user tự thu hồi
+	Reason           string // Bắt buộc khi ActingAdminID có giá trị, user tự thu hồi mặc định user_request
+}
+
+// DeclineConsentParams input for refusing an optional policy
+type DeclineConsentParams struct {
+	UserID           string
+	Platform         string
+	DocumentID       string
+	DocumentName     string
+	VersionTimestamp int64
+	ConsentMethod    string
+	IPAddress        *string
+	UserAgent        *string
+	ActingAdminID    string // Admin từ chối thay user, rỗng = user tự từ chối
+	Reason           string // Bắt buộc khi ActingAdminID có giá trị, user tự từ chối mặc định user_declined
 }
 
 // PolicyInfo for comparing with user consents
@@ -67,6 +151,10 @@ type PolicyInfo struct {
 	DocumentName     string
 	VersionTimestamp int64
 	Platform         string
+	IsMandatory      bool
+	GraceDeadline    int64 // Unix timestamp, 0 nếu policy không bắt buộc
+	IsOverdue        bool  // true nếu đã quá GraceDeadline mà user chưa consent
+	ConsentExpiredAt int64 // Unix timestamp, > 0 nếu user đã đồng ý version này nhưng consent đã hết hạn
 }
 
 func (s *consentService) RecordConsents(ctx context.Context, params RecordConsentsParams) ([]*domain.UserConsent, error) {
@@ -85,6 +173,11 @@ func (s *consentService) RecordConsents(ctx context.Context, params RecordConsen
 		return nil, fmt.Errorf("consents list cannot be empty")
 	}
 
+	// Ghi thay user phải có lý do (bằng chứng pháp lý)
+	if err := validateOnBehalf(params.UserID, params.ActingAdminID, params.OnBehalfReason); err != nil {
+		return nil, err
+	}
+
 	var result []*domain.UserConsent
 
 	// Convert to repository params
@@ -96,8 +189,15 @@ func (s *consentService) RecordConsents(ctx context.Context, params RecordConsen
 		}
 
 		// PHASE 1: Verify document exists in Document Service
+		var contentHash *string
+		var validityDays int
+		purposes, err := sortedPurposes(c.Purposes)
+		if err != nil {
+			return nil, err
+		}
+		locale := normalizeLocale(c.Locale)
 		if s.docClient != nil {
-			doc, err := s.docClient.VerifyDocument(ctx, params.Platform, c.DocumentName)
+			doc, err := s.docClient.VerifyDocument(ctx, params.Platform, c.DocumentName, locale)
 			if err != nil {
 				return nil, fmt.Errorf("document verification failed for %s: %w", c.DocumentName, err)
 			}
@@ -109,6 +209,27 @@ func (s *consentService) RecordConsents(ctx context.Context, params RecordConsen
 				return nil, fmt.Errorf("document version mismatch for %s: requested %d, current %d",
 					c.DocumentName, c.VersionTimestamp, doc.EffectiveTimestamp)
 			}
+			// Verify document ID matches - tránh consent vào version draft/pending trùng timestamp
+			if c.DocumentID != "" && doc.Id != c.DocumentID {
+				return nil, fmt.Errorf("document id mismatch for %s: requested %s, current %s",
+					c.DocumentName, c.DocumentID, doc.Id)
+			}
+
+			// Capture nội dung chính xác user đang đồng ý (URL có thể đổi, row có thể bị sửa)
+			hash, err := s.captureDocumentContent(ctx, doc)
+			if err != nil {
+				return nil, fmt.Errorf("failed to capture content of %s: %w", c.DocumentName, err)
+			}
+			contentHash = &hash
+
+			// Ghi locale thực sự được hiển thị (sau fallback), khớp với content hash
+			locale = doc.Locale
+			validityDays = int(doc.ConsentValidityPeriod)
+
+			purposes, err = resolvePurposes(doc, purposes)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		// PHASE 1: Check if consent already exists (idempotency)
@@ -117,38 +238,69 @@ func (s *consentService) RecordConsents(ctx context.Context, params RecordConsen
 			return nil, fmt.Errorf("failed to check existing consent: %w", err)
 		}
 
-		if existing != nil {
-			// Consent already exists, return it (idempotent)
-			result = append(result, existing)
-			continue
+		replaces := false
+		if existing != nil && !existing.IsExpired(time.Now()) {
+			if samePurposes(existing.Purposes, purposes) {
+				// Consent already exists, return it (idempotent)
+				result = append(result, existing)
+				continue
+			}
+			// Đổi lựa chọn purpose: thu hồi consent cũ và ghi consent mới trong cùng transaction
+			replaces = true
 		}
+		// Consent đã hết hạn: xác nhận lại tạo consent mới (bằng chứng mới), consent cũ được giữ lại
 
 		repoParams = append(repoParams, domain.CreateConsentParams{
-			UserID:           params.UserID,
-			Platform:         params.Platform,
-			DocumentID:       c.DocumentID,
-			DocumentName:     c.DocumentName,
-			VersionTimestamp: c.VersionTimestamp,
-			AgreedFileURL:    c.AgreedFileURL,
-			ConsentMethod:    params.ConsentMethod,
-			IPAddress:        params.IPAddress,
-			UserAgent:        params.UserAgent,
+			UserID:              params.UserID,
+			Platform:            params.Platform,
+			DocumentID:          c.DocumentID,
+			DocumentName:        c.DocumentName,
+			VersionTimestamp:    c.VersionTimestamp,
+			AgreedFileURL:       c.AgreedFileURL,
+			ConsentMethod:       params.ConsentMethod,
+			IPAddress:           params.IPAddress,
+			UserAgent:           params.UserAgent,
+			DocumentContentHash: contentHash,
+			Locale:              optionalString(locale),
+			RecordedBy:          optionalString(params.ActingAdminID),
+			OnBehalfReason:      optionalString(params.OnBehalfReason),
+			ValidityDays:        validityDays,
+			Purposes:            purposes,
+			ReplacesExisting:    replaces,
 		})
 	}
 
 	// Use bulk insert if multiple, single insert if one
+	var consents []*domain.UserConsent
 	if len(repoParams) == 1 {
 		consent, err := s.repo.Create(ctx, repoParams[0])
 		if err != nil {
 			return nil, fmt.Errorf("failed to record consent: %w", err)
 		}
-		return []*domain.UserConsent{consent}, nil
+		consents = []*domain.UserConsent{consent}
+	} else {
+		// Bulk insert with transaction
+		bulk, err := s.repo.CreateBulk(ctx, repoParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record bulk consents: %w", err)
+		}
+		consents = bulk
 	}
 
-	// Bulk insert with transaction
-	consents, err := s.repo.CreateBulk(ctx, repoParams)
-	if err != nil {
-		return nil, fmt.Errorf("failed to record bulk consents: %w", err)
+	// Request không mang actor (không qua Gateway): actor là Admin ghi thay hoặc chính user
+	actorID := params.UserID
+	if params.ActingAdminID != "" {
+		actorID = params.ActingAdminID
+	}
+	for _, c := range consents {
+		s.auditLog.Record(ctx, audit.Event{
+			Action:     auditActionRecord,
+			TargetType: "consent",
+			TargetID:   c.ID,
+			After:      consentAuditView(c),
+			Reason:     params.OnBehalfReason,
+			ActorID:    actorID,
+		})
 	}
 
 	return consents, nil
@@ -185,45 +337,273 @@ func (s *consentService) CheckPendingConsents(ctx context.Context, userID string
 		return nil, fmt.Errorf("user_id is required")
 	}
 
-	// Get all user's active consents
-	userConsents, err := s.repo.GetUserConsents(ctx, userID, false)
+	// Get all user's consents (kể cả declined: policy không bắt buộc đã từ chối không còn pending)
+	userConsents, err := s.repo.GetUserConsents(ctx, userID, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user consents: %w", err)
 	}
 
 	// Build map of user's consents: documentID -> max version
+	// Consent hết hạn coi như chưa đồng ý, chỉ giữ thời điểm hết hạn để tính grace period
+	now := time.Now()
 	consentMap := make(map[string]int64)
+	expiredMap := make(map[string]time.Time)
+	declinedMap := make(map[string]int64)
 	for _, consent := range userConsents {
+		if consent.Status == domain.ConsentStatusDeclined {
+			if consent.VersionTimestamp > declinedMap[consent.DocumentID] {
+				declinedMap[consent.DocumentID] = consent.VersionTimestamp
+			}
+			continue
+		}
+		if consent.IsDeleted {
+			continue
+		}
+		if consent.IsExpired(now) {
+			if consent.ExpiresAt.After(expiredMap[consent.DocumentID]) {
+				expiredMap[consent.DocumentID] = *consent.ExpiresAt
+			}
+			continue
+		}
 		if existing, exists := consentMap[consent.DocumentID]; !exists || consent.VersionTimestamp > existing {
 			consentMap[consent.DocumentID] = consent.VersionTimestamp
 		}
 	}
 
-	// Find pending policies (not consented or consented to older version)
+	// Find pending policies (not consented, consented to older version or consent expired)
 	var pending []PolicyInfo
+	var mandatoryIDs []string
 	for _, policy := range latestPolicies {
 		userVersion, hasConsented := consentMap[policy.DocumentID]
 		if !hasConsented || userVersion < policy.VersionTimestamp {
+			// User đã từ chối version này (chỉ policy không bắt buộc) → không hỏi lại
+			if !policy.IsMandatory && declinedMap[policy.DocumentID] >= policy.VersionTimestamp {
+				continue
+			}
+			if expiredAt, ok := expiredMap[policy.DocumentID]; ok {
+				policy.ConsentExpiredAt = expiredAt.Unix()
+			}
 			pending = append(pending, policy)
+			if policy.IsMandatory {
+				mandatoryIDs = append(mandatoryIDs, policy.DocumentID)
+			}
 		}
 	}
+	if len(mandatoryIDs) == 0 {
+		return pending, nil
+	}
+
+	// Grace deadline chỉ áp dụng cho policy bắt buộc: lấy từ re-consent campaign (Admin có thể
+	// gia hạn), version chưa có campaign thì tính mặc định từ effective_timestamp
+	deadlines := map[string]time.Time{}
+	if s.campaigns != nil {
+		deadlines, err = s.campaigns.GetGraceDeadlines(ctx, mandatoryIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get grace deadlines: %w", err)
+		}
+	}
+
+	for i := range pending {
+		policy := &pending[i]
+		if !policy.IsMandatory {
+			continue
+		}
+		policy.GraceDeadline = policy.VersionTimestamp + int64(s.gracePeriod.Seconds())
+		if deadline, ok := deadlines[policy.DocumentID]; ok {
+			policy.GraceDeadline = deadline.Unix()
+		}
+		// Consent hết hạn: user có grace period tính từ lúc hết hạn để xác nhận lại
+		if policy.ConsentExpiredAt > 0 {
+			policy.GraceDeadline = max(policy.GraceDeadline, policy.ConsentExpiredAt+int64(s.gracePeriod.Seconds()))
+		}
+		policy.IsOverdue = now.Unix() > policy.GraceDeadline
+	}
 
 	return pending, nil
 }
 
-func (s *consentService) RevokeConsent(ctx context.Context, userID, documentID string, versionTimestamp int64) error {
-	if userID == "" || documentID == "" || versionTimestamp == 0 {
+// CheckPendingConsentsForPlatform resolves the active policies of a platform through
+// Document Service instead of trusting the caller, then computes pending consents.
+func (s *consentService) CheckPendingConsentsForPlatform(ctx context.Context, userID, platform string) ([]PolicyInfo, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if err := validatePlatform(platform); err != nil {
+		return nil, err
+	}
+	if s.docClient == nil {
+		return nil, fmt.Errorf("document service client is not configured")
+	}
+
+	// Step 1: Lấy tất cả policies đang hiệu lực từ Document Service
+	documents, err := s.docClient.ListActivePolicies(ctx, platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve active policies: %w", err)
+	}
+
+	// Step 2: Convert sang PolicyInfo (kèm cờ mandatory từ Document Service)
+	latestPolicies := make([]PolicyInfo, len(documents))
+	for i, doc := range documents {
+		latestPolicies[i] = PolicyInfo{
+			DocumentID:       doc.Id,
+			DocumentName:     doc.DocumentName,
+			VersionTimestamp: doc.EffectiveTimestamp,
+			Platform:         doc.Platform,
+			IsMandatory:      doc.IsMandatory,
+		}
+	}
+
+	// Step 3: So sánh với consents của user
+	return s.CheckPendingConsents(ctx, userID, latestPolicies)
+}
+
+func (s *consentService) RevokeConsent(ctx context.Context, params RevokeConsentParams) error {
+	if params.UserID == "" || params.DocumentID == "" || params.VersionTimestamp == 0 {
 		return fmt.Errorf("user_id, document_id, and version_timestamp are required")
 	}
+	if err := validateOnBehalf(params.UserID, params.ActingAdminID, params.Reason); err != nil {
+		return err
+	}
+
+	// Người thu hồi: chính user (lý do tùy chọn), hoặc Admin thu hồi thay (bắt buộc lý do)
+	revokedBy, reason := params.UserID, domain.RevokeReasonUserRequest
+	if params.ActingAdminID != "" {
+		revokedBy = params.ActingAdminID
+	}
+	if r := strings.TrimSpace(params.Reason); r != "" {
+		reason = r
+	}
+
+	before, err := s.repo.GetExisting(ctx, params.UserID, params.DocumentID, params.VersionTimestamp)
+	if err != nil {
+		return fmt.Errorf("failed to get consent: %w", err)
+	}
 
-	err := s.repo.SoftDelete(ctx, userID, documentID, versionTimestamp)
+	err = s.repo.SoftDelete(ctx, params.UserID, params.DocumentID, params.VersionTimestamp, revokedBy, reason)
 	if err != nil {
 		return fmt.Errorf("failed to revoke consent: %w", err)
 	}
 
+	ev := audit.Event{
+		Action:     auditActionRevoke,
+		TargetType: "consent",
+		Before:     consentAuditView(before),
+		After:      map[string]any{"status": domain.ConsentStatusWithdrawn, "revoked_by": revokedBy, "revoked_reason": reason},
+		Reason:     reason,
+		ActorID:    revokedBy,
+	}
+	if before != nil {
+		ev.TargetID = before.ID
+	}
+	s.auditLog.Record(ctx, ev)
+
 	return nil
 }
 
+func (s *consentService) DeclineConsent(ctx context.Context, params DeclineConsentParams) (*domain.UserConsent, error) {
+	if params.UserID == "" || params.DocumentID == "" || params.DocumentName == "" || params.VersionTimestamp == 0 {
+		return nil, fmt.Errorf("%w: user_id, document_id, document_name and version_timestamp are required", domain.ErrInvalidInput)
+	}
+	if err := validatePlatform(params.Platform); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if err := validateConsentMethod(params.ConsentMethod); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if err := validateOnBehalf(params.UserID, params.ActingAdminID, params.Reason); err != nil {
+		return nil, err
+	}
+
+	// Chỉ từ chối được version đang hiệu lực của policy không bắt buộc
+	if s.docClient != nil {
+		doc, err := s.docClient.VerifyDocument(ctx, params.Platform, params.DocumentName, "")
+		if err != nil {
+			return nil, fmt.Errorf("document verification failed for %s: %w", params.DocumentName, err)
+		}
+		if doc == nil || doc.Id != params.DocumentID || doc.EffectiveTimestamp != params.VersionTimestamp {
+			return nil, fmt.Errorf("%w: %s version %d is not the effective version", domain.ErrDocumentNotFound,
+				params.DocumentName, params.VersionTimestamp)
+		}
+		if doc.IsMandatory {
+			return nil, fmt.Errorf("%w: %s is mandatory and cannot be declined", domain.ErrInvalidInput, params.DocumentName)
+		}
+	}
+
+	latest, err := s.repo.GetLatestForVersion(ctx, params.UserID, params.DocumentID, params.VersionTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing consent: %w", err)
+	}
+	if latest != nil {
+		switch {
+		case latest.Status == domain.ConsentStatusDeclined:
+			// Đã từ chối version này (idempotent)
+			return latest, nil
+		case latest.Status == domain.ConsentStatusGranted && !latest.IsExpired(time.Now()):
+			return nil, fmt.Errorf("%w: user has consented to this version, withdraw it with RevokeConsent",
+				domain.ErrInvalidConsent)
+		}
+	}
+
+	declinedBy, reason := params.UserID, domain.RevokeReasonUserDeclined
+	if params.ActingAdminID != "" {
+		declinedBy = params.ActingAdminID
+	}
+	if r := strings.TrimSpace(params.Reason); r != "" {
+		reason = r
+	}
+
+	consent, err := s.repo.Decline(ctx, domain.DeclineConsentParams{
+		UserID:           params.UserID,
+		Platform:         params.Platform,
+		DocumentID:       params.DocumentID,
+		DocumentName:     params.DocumentName,
+		VersionTimestamp: params.VersionTimestamp,
+		ConsentMethod:    params.ConsentMethod,
+		IPAddress:        params.IPAddress,
+		UserAgent:        params.UserAgent,
+		DeclinedBy:       declinedBy,
+		Reason:           reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decline consent: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionDecline,
+		TargetType: "consent",
+		TargetID:   consent.ID,
+		After:      consentAuditView(consent),
+		Reason:     reason,
+		ActorID:    declinedBy,
+	})
+
+	return consent, nil
+}
+
+// consentAuditView trả về các field của consent được ghi vào audit log
+func consentAuditView(c *domain.UserConsent) map[string]any {
+	if c == nil {
+		return nil
+	}
+	return map[string]any{
+		"id":                    c.ID,
+		"user_id":               c.UserID,
+		"platform":              c.Platform,
+		"document_id":           c.DocumentID,
+		"document_name":         c.DocumentName,
+		"version_timestamp":     c.VersionTimestamp,
+		"consent_method":        c.ConsentMethod,
+		"locale":                c.Locale,
+		"document_content_hash": c.DocumentContentHash,
+		"record_hash":           c.RecordHash,
+		"recorded_by":           c.RecordedBy,
+		"on_behalf_reason":      c.OnBehalfReason,
+		"expires_at":            c.ExpiresAt,
+		"purposes":              c.Purposes,
+		"status":                c.Status,
+	}
+}
+
 // GetConsentHistory retrieves all historical consents for a user+document combination
 func (s *consentService) GetConsentHistory(ctx context.Context, userID, documentID string) ([]*domain.UserConsent, error) {
 	if userID == "" || documentID == "" {
@@ -245,6 +625,264 @@ func (s *consentService) GetConsentStats(ctx context.Context, platform string) (
 	return s.repo.GetConsentStats(ctx, platform)
 }
 
+// VerifyConsentChain recomputes the user's hash chain and compares it with the
+// current consent rows (including soft-deleted ones)
+func (s *consentService) VerifyConsentChain(ctx context.Context, userID string) (*domain.ChainReport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user_id is required", domain.ErrInvalidInput)
+	}
+
+	events, err := s.repo.GetChainEvents(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent chain: %w", err)
+	}
+
+	head, err := s.repo.GetChainHead(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent chain head: %w", err)
+	}
+
+	consents, err := s.repo.GetUserConsents(ctx, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user consents: %w", err)
+	}
+
+	return chain.Verify(userID, events, head, consents), nil
+}
+
+// GetDocumentSnapshot retrieves the snapshot of the document content a consent refers to
+func (s *consentService) GetDocumentSnapshot(ctx context.Context, contentHash string) (*domain.DocumentSnapshot, error) {
+	if !contentHashPattern.MatchString(contentHash) {
+		return nil, fmt.Errorf("%w: content_hash must be a lowercase hex SHA-256", domain.ErrInvalidInput)
+	}
+
+	snapshot, err := s.repo.GetDocumentSnapshot(ctx, contentHash)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrSnapshotNotFound, contentHash)
+	}
+
+	return snapshot, nil
+}
+
+// ExpireConsents marks due consents as expired in batches until none are left
+func (s *consentService) ExpireConsents(ctx context.Context) ([]*domain.UserConsent, error) {
+	var expired []*domain.UserConsent
+	for {
+		batch, err := s.repo.ExpireDue(ctx, expireBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		for _, c := range batch {
+			s.auditLog.Record(ctx, audit.Event{
+				Action:     auditActionExpire,
+				TargetType: "consent",
+				TargetID:   c.ID,
+				Before:     map[string]any{"expires_at": c.ExpiresAt.Unix()},
+				After:      map[string]any{"expired_at": c.ExpiredAt.Unix()},
+				Reason:     "consent validity period elapsed",
+				ActorID:    auditActorConsentExpiry,
+			})
+		}
+		expired = append(expired, batch...)
+
+		if len(batch) < expireBatchSize || ctx.Err() != nil {
+			return expired, nil
+		}
+	}
+}
+
+// EraseUserConsents chạy lại được: consent đã xóa ở lần trước không bị ghi thêm ERASED event
+func (s *consentService) EraseUserConsents(ctx context.Context, userID, erasedBy, reason string) (*domain.ErasureResult, error) {
+	if userID == "" || erasedBy == "" {
+		return nil, fmt.Errorf("%w: user_id and erased_by are required", domain.ErrInvalidInput)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("%w: reason is required when erasing consents", domain.ErrInvalidInput)
+	}
+
+	result, err := s.repo.Erase(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase consents: %w", err)
+	}
+
+	// Chỉ ghi số lượng và hash, không ghi lại dữ liệu vừa xóa
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionErase,
+		TargetType: "user",
+		TargetID:   userID,
+		After:      map[string]any{"erased_consents": result.ErasedConsents, "chain_head_hash": result.ChainHeadHash},
+		Reason:     reason,
+		ActorID:    erasedBy,
+	})
+
+	return result, nil
+}
+
+func (s *consentService) CheckPurposeConsent(ctx context.Context, userID, purpose string) (*domain.PurposeDecision, error) {
+	if userID == "" || purpose == "" {
+		return nil, fmt.Errorf("%w: user_id and purpose are required", domain.ErrInvalidInput)
+	}
+
+	decision, err := s.repo.GetPurposeDecision(ctx, userID, purpose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check purpose consent: %w", err)
+	}
+
+	return decision, nil
+}
+
+// sortedPurposes kiểm tra lựa chọn purpose không trùng và sắp theo key (thứ tự lưu và hash)
+func sortedPurposes(choices []domain.ConsentPurpose) ([]domain.ConsentPurpose, error) {
+	sorted := make([]domain.ConsentPurpose, len(choices))
+	copy(sorted, choices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Purpose < sorted[j].Purpose })
+
+	for i, p := range sorted {
+		if p.Purpose == "" {
+			return nil, fmt.Errorf("%w: purpose is required for each purpose choice", domain.ErrInvalidInput)
+		}
+		if i > 0 && sorted[i-1].Purpose == p.Purpose {
+			return nil, fmt.Errorf("%w: duplicate purpose %q", domain.ErrInvalidInput, p.Purpose)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil, nil
+	}
+	return sorted, nil
+}
+
+// resolvePurposes đối chiếu lựa chọn với purposes của version: purpose lạ bị từ chối,
+// purpose bắt buộc không được từ chối, purpose không gửi lấy mặc định (bắt buộc = đồng ý)
+func resolvePurposes(doc *documentpb.PolicyDocument, choices []domain.ConsentPurpose) ([]domain.ConsentPurpose, error) {
+	chosen := make(map[string]bool, len(choices))
+	for _, c := range choices {
+		chosen[c.Purpose] = c.Accepted
+	}
+
+	var resolved []domain.ConsentPurpose
+	for _, p := range doc.Purposes {
+		accepted, ok := chosen[p.Key]
+		if !ok {
+			accepted = p.IsRequired
+		}
+		if p.IsRequired && !accepted {
+			return nil, fmt.Errorf("%w: purpose %q is required by %s and cannot be declined",
+				domain.ErrInvalidInput, p.Key, doc.DocumentName)
+		}
+		delete(chosen, p.Key)
+		resolved = append(resolved, domain.ConsentPurpose{Purpose: p.Key, Accepted: accepted})
+	}
+	for key := range chosen {
+		return nil, fmt.Errorf("%w: %s has no purpose %q", domain.ErrInvalidInput, doc.DocumentName, key)
+	}
+
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].Purpose < resolved[j].Purpose })
+	return resolved, nil
+}
+
+// samePurposes so sánh 2 danh sách lựa chọn đã sắp theo key
+func samePurposes(a, b []domain.ConsentPurpose) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var contentHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// captureDocumentContent hashes the exact content of a document version and stores
+// it as a content-addressed snapshot. content_html được ưu tiên (là nội dung hiển thị
+// cho user), sau đó là file upload (file_hash), cuối cùng là tải bytes của file_url.
+// Mỗi version (theo locale) chỉ capture 1 lần: consent sau đó, kể cả đồng ý lại, dùng lại
+// content hash đã lưu để không tải lại file ở mỗi request.
+func (s *consentService) captureDocumentContent(ctx context.Context, doc *documentpb.PolicyDocument) (string, error) {
+	captured, err := s.repo.GetVersionSnapshotHash(ctx, doc.Id, doc.Locale)
+	if err != nil {
+		return "", err
+	}
+	if captured != "" {
+		return captured, nil
+	}
+
+	snapshot := &domain.DocumentSnapshot{}
+
+	switch {
+	case doc.ContentHtml != "":
+		snapshot.Content = []byte(doc.ContentHtml)
+		snapshot.ContentType = "text/html; charset=utf-8"
+		snapshot.Source = domain.SnapshotSourceContentHTML
+	case doc.FileHash != "":
+		// File upload là content-addressed: snapshot đã có (version khác dùng cùng file) thì không tải lại
+		existing, err := s.repo.GetDocumentSnapshot(ctx, doc.FileHash)
+		if err != nil {
+			return "", err
+		}
+		if existing != nil {
+			snapshot.ContentHash = existing.ContentHash
+			return s.repo.SaveDocumentSnapshot(ctx, doc.Id, doc.Locale, snapshot)
+		}
+
+		content, contentType, err := s.docClient.DownloadFile(ctx, doc.FileHash, s.maxSnapshot)
+		if err != nil {
+			return "", err
+		}
+		// Không tin Document Service tuyệt đối: bytes nhận được phải khớp hash
+		if sum := sha256.Sum256(content); hex.EncodeToString(sum[:]) != doc.FileHash {
+			return "", fmt.Errorf("downloaded file does not match file_hash %s", doc.FileHash)
+		}
+		snapshot.Content = content
+		snapshot.ContentType = contentType
+		snapshot.Source = domain.SnapshotSourceFileHash
+	case doc.FileUrl != "":
+		if s.fetcher == nil {
+			return "", fmt.Errorf("content fetcher is not configured")
+		}
+		content, contentType, err := s.fetcher.Fetch(ctx, doc.FileUrl)
+		if err != nil {
+			return "", err
+		}
+		fileURL := doc.FileUrl
+		snapshot.Content = content
+		snapshot.ContentType = contentType
+		snapshot.Source = domain.SnapshotSourceFileURL
+		snapshot.SourceURL = &fileURL
+	default:
+		return "", fmt.Errorf("document has no content_html, file_hash or file_url")
+	}
+
+	sum := sha256.Sum256(snapshot.Content)
+	snapshot.ContentHash = hex.EncodeToString(sum[:])
+	snapshot.SizeBytes = int64(len(snapshot.Content))
+
+	// Request song song đã capture version trước thì dùng hash đã lưu: mọi consent của version cùng hash
+	return s.repo.SaveDocumentSnapshot(ctx, doc.Id, doc.Locale, snapshot)
+}
+
+// normalizeLocale đưa language tag về locale Document Service lưu: "en-US" -> "en"
+func normalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // Validation helpers
 func validatePlatform(platform string) error {
 	if platform != domain.PlatformClient && platform != domain.PlatformMerchant && platform != domain.PlatformAdmin {
@@ -253,6 +891,20 @@ func validatePlatform(platform string) error {
 	return nil
 }
 
+// validateOnBehalf kiểm tra chế độ Admin thao tác thay user
+func validateOnBehalf(userID, actingAdminID, reason string) error {
+	if actingAdminID == "" {
+		return nil
+	}
+	if actingAdminID == userID {
+		return fmt.Errorf("%w: acting_admin_id must differ from user_id", domain.ErrInvalidInput)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("%w: on_behalf_reason is required when acting on behalf of a user", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
 func validateConsentMethod(method string) error {
 	validMethods := []string{
 		domain.ConsentMethodRegistration,
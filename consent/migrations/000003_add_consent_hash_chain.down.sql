@@ -0,0 +1,9 @@
+-- Rollback consent hash chain
+
+DROP TRIGGER IF EXISTS trigger_prevent_consent_chain_mutation ON consent_chain;
+DROP FUNCTION IF EXISTS prevent_consent_chain_mutation();
+
+ALTER TABLE user_consents DROP COLUMN IF EXISTS record_hash;
+
+DROP TABLE IF EXISTS consent_chain_heads;
+DROP TABLE IF EXISTS consent_chain;
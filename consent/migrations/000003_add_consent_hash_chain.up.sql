@@ -0,0 +1,47 @@
+-- Tamper-evident hash chain cho consent records
+-- user_consents là bằng chứng pháp lý nhưng vẫn bị UPDATE (soft delete, is_latest),
+-- nên mỗi thay đổi mang tính bằng chứng được ghi thành event append-only trong consent_chain.
+-- Mỗi event chain theo user: record_hash = SHA-256(event, prev_hash)
+
+-- Append-only ledger (1 chain / user)
+CREATE TABLE IF NOT EXISTS consent_chain (
+    user_id UUID NOT NULL,
+    seq_no BIGINT NOT NULL,
+    event_type VARCHAR(20) NOT NULL CHECK (event_type IN ('GRANTED', 'REVOKED')),
+    consent_id UUID NOT NULL,
+    content_hash CHAR(64) NOT NULL, -- SHA-256 của bằng chứng consent tại thời điểm event
+    occurred_at TIMESTAMPTZ NOT NULL,
+    prev_hash CHAR(64) NOT NULL, -- record_hash của event trước (genesis = 64 số 0)
+    record_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (user_id, seq_no)
+);
+
+CREATE INDEX idx_consent_chain_consent ON consent_chain(consent_id);
+
+-- Event cuối của mỗi chain: dùng để lock khi append và phát hiện tail bị cắt
+CREATE TABLE IF NOT EXISTS consent_chain_heads (
+    user_id UUID PRIMARY KEY,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    last_hash CHAR(64) NOT NULL DEFAULT '0000000000000000000000000000000000000000000000000000000000000000',
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Consent row mang record_hash của GRANTED event (NULL = row cũ, cần backfill)
+ALTER TABLE user_consents ADD COLUMN record_hash CHAR(64);
+
+-- Chặn UPDATE/DELETE trên ledger ở tầng DB (verifier vẫn phát hiện nếu trigger bị bỏ qua)
+CREATE OR REPLACE FUNCTION prevent_consent_chain_mutation()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'consent_chain is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER trigger_prevent_consent_chain_mutation
+BEFORE UPDATE OR DELETE ON consent_chain
+FOR EACH ROW
+EXECUTE FUNCTION prevent_consent_chain_mutation();
+
+COMMENT ON TABLE consent_chain IS 'Append-only hash chain of consent evidence events (GRANTED/REVOKED) per user';
+COMMENT ON COLUMN user_consents.record_hash IS 'record_hash of the GRANTED event for this consent in consent_chain';
@@ -0,0 +1,5 @@
+-- Rollback document content hash
+
+DROP INDEX IF EXISTS idx_user_consents_content_hash;
+DROP TABLE IF EXISTS document_snapshots;
+ALTER TABLE user_consents DROP COLUMN IF EXISTS document_content_hash;
@@ -0,0 +1,20 @@
+-- Snapshot + SHA-256 của nội dung document tại thời điểm user đồng ý
+-- agreed_file_url chỉ là URL có thể thay đổi; content hash chứng minh chính xác nội dung user đã chấp nhận
+-- kể cả khi document row hoặc file bị sửa sau đó.
+
+ALTER TABLE user_consents ADD COLUMN document_content_hash CHAR(64);
+
+-- Content-addressed snapshot (nhiều consent cùng version dùng chung 1 snapshot)
+CREATE TABLE IF NOT EXISTS document_snapshots (
+    content_hash CHAR(64) PRIMARY KEY, -- SHA-256 hex của content
+    content BYTEA NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('content_html', 'file_url')),
+    source_url VARCHAR(512), -- file_url nếu content được tải từ URL
+    size_bytes BIGINT NOT NULL,
+    captured_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_consents_content_hash ON user_consents(document_content_hash);
+
+COMMENT ON COLUMN user_consents.document_content_hash IS 'SHA-256 of the exact document content the user agreed to (see document_snapshots)';
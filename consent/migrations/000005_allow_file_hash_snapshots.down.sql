@@ -0,0 +1,9 @@
+-- Revert file_hash snapshot source
+
+DELETE FROM document_snapshots WHERE source = 'file_hash';
+
+ALTER TABLE document_snapshots DROP CONSTRAINT IF EXISTS document_snapshots_source_check;
+
+ALTER TABLE document_snapshots
+ADD CONSTRAINT document_snapshots_source_check
+CHECK (source IN ('content_html', 'file_url'));
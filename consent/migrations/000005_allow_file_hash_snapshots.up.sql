@@ -0,0 +1,7 @@
+-- Document Service lưu file upload theo SHA-256 (file_hash), consent snapshot lấy bytes qua DownloadFile RPC
+
+ALTER TABLE document_snapshots DROP CONSTRAINT IF EXISTS document_snapshots_source_check;
+
+ALTER TABLE document_snapshots
+ADD CONSTRAINT document_snapshots_source_check
+CHECK (source IN ('content_html', 'file_hash', 'file_url'));
@@ -0,0 +1,3 @@
+-- Rollback consent locale
+
+ALTER TABLE user_consents DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,4 @@
+-- Locale của nội dung document hiển thị cho user khi đồng ý (sau khi fallback)
+-- NULL = consent ghi trước khi có đa ngôn ngữ
+
+ALTER TABLE user_consents ADD COLUMN locale VARCHAR(10);
@@ -0,0 +1,4 @@
+-- Rollback consent actor
+
+ALTER TABLE user_consents DROP COLUMN IF EXISTS on_behalf_reason;
+ALTER TABLE user_consents DROP COLUMN IF EXISTS recorded_by;
@@ -0,0 +1,8 @@
+-- Ai thực sự ghi consent: NULL = chính user (subject lấy từ JWT),
+-- admin_id = Admin ghi/thu hồi thay user (act on behalf of), bắt buộc kèm lý do
+
+ALTER TABLE user_consents ADD COLUMN recorded_by VARCHAR(255);
+ALTER TABLE user_consents ADD COLUMN on_behalf_reason TEXT;
+
+COMMENT ON COLUMN user_consents.recorded_by IS 'Admin user ID that recorded the consent on behalf of the user (NULL = recorded by the user)';
+COMMENT ON COLUMN user_consents.on_behalf_reason IS 'Reason given by the admin acting on behalf of the user';
@@ -0,0 +1,4 @@
+-- Rollback re-consent campaigns
+
+DROP TABLE IF EXISTS reconsent_campaign_users;
+DROP TABLE IF EXISTS reconsent_campaigns;
@@ -0,0 +1,30 @@
+-- Re-consent campaign: mỗi version bắt buộc mới có hiệu lực mở 1 campaign yêu cầu user đồng ý lại.
+-- Affected users được chốt lúc mở campaign (user đang có consent với version cũ của document),
+-- tiến độ accepted/pending/overdue tính trực tiếp từ user_consents với version mới.
+
+CREATE TABLE IF NOT EXISTS reconsent_campaigns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    platform VARCHAR(50) NOT NULL,
+    document_name VARCHAR(255) NOT NULL,
+    document_id UUID NOT NULL, -- Version mới (policy_documents.id ở Document Service)
+    version_timestamp BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    -- Quá hạn mà chưa đồng ý thì Gateway chặn truy cập (451)
+    grace_deadline TIMESTAMPTZ NOT NULL,
+    affected_users INT NOT NULL DEFAULT 0,
+    superseded_by UUID REFERENCES reconsent_campaigns(id),
+    updated_by VARCHAR(255), -- Admin đổi grace_deadline gần nhất
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT reconsent_campaigns_status_check CHECK (status IN ('active', 'superseded')),
+    CONSTRAINT reconsent_campaigns_document_unique UNIQUE (document_id)
+);
+
+CREATE INDEX idx_reconsent_campaigns_document ON reconsent_campaigns(platform, document_name, version_timestamp DESC);
+
+CREATE TABLE IF NOT EXISTS reconsent_campaign_users (
+    campaign_id UUID NOT NULL REFERENCES reconsent_campaigns(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    previous_version_timestamp BIGINT NOT NULL, -- Version cũ mới nhất user đã đồng ý
+    PRIMARY KEY (campaign_id, user_id)
+);
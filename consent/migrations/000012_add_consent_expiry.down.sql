@@ -0,0 +1,16 @@
+-- Rollback consent expiry
+-- Consent đã hết hạn và đã được xác nhận lại trùng (user, document, version) với consent mới,
+-- xóa mềm để khôi phục unique index cũ
+
+UPDATE user_consents
+SET is_deleted = TRUE, deleted_at = expired_at
+WHERE expired_at IS NOT NULL AND is_deleted = FALSE;
+
+DROP INDEX IF EXISTS idx_user_consents_expires_at;
+DROP INDEX IF EXISTS idx_active_consents;
+CREATE UNIQUE INDEX idx_active_consents
+ON user_consents (user_id, document_id, version_timestamp)
+WHERE is_deleted = FALSE;
+
+ALTER TABLE user_consents DROP COLUMN IF EXISTS expired_at;
+ALTER TABLE user_consents DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,18 @@
+-- Consent có thời hạn (consent_validity_period của document): quá expires_at thì user phải xác nhận lại
+-- NULL = consent không hết hạn (document không đặt thời hạn / consent cũ)
+
+ALTER TABLE user_consents ADD COLUMN expires_at TIMESTAMPTZ;
+ALTER TABLE user_consents ADD COLUMN expired_at TIMESTAMPTZ; -- Job đánh dấu hết hạn (đã phát ConsentExpired)
+
+-- Xác nhận lại cùng version tạo consent mới, consent đã hết hạn vẫn được giữ làm bằng chứng
+DROP INDEX IF EXISTS idx_active_consents;
+CREATE UNIQUE INDEX idx_active_consents
+ON user_consents (user_id, document_id, version_timestamp)
+WHERE is_deleted = FALSE AND expired_at IS NULL;
+
+-- Job tìm consent tới hạn
+CREATE INDEX idx_user_consents_expires_at ON user_consents (expires_at)
+WHERE is_deleted = FALSE AND expired_at IS NULL AND expires_at IS NOT NULL;
+
+COMMENT ON COLUMN user_consents.expires_at IS 'When the consent must be re-confirmed (NULL = never expires)';
+COMMENT ON COLUMN user_consents.expired_at IS 'When the expiry job marked the consent expired and emitted ConsentExpired';
@@ -0,0 +1,3 @@
+-- Rollback lựa chọn theo purpose của consent
+
+DROP TABLE IF EXISTS user_consent_purposes;
@@ -0,0 +1,15 @@
+-- Lựa chọn của user với từng mục đích xử lý dữ liệu (purpose) của document khi đồng ý
+-- Mỗi consent lưu đủ mọi purpose của version: bắt buộc luôn accepted, tùy chọn accepted/declined
+-- Đổi lựa chọn trên cùng version = thu hồi consent cũ + ghi consent mới (lựa chọn cũ vẫn là bằng chứng)
+
+CREATE TABLE IF NOT EXISTS user_consent_purposes (
+    consent_id UUID NOT NULL REFERENCES user_consents(id) ON DELETE CASCADE,
+    purpose_key VARCHAR(64) NOT NULL,
+    accepted BOOLEAN NOT NULL,
+    PRIMARY KEY (consent_id, purpose_key)
+);
+
+-- CheckPurposeConsent tìm lựa chọn mới nhất của user theo purpose
+CREATE INDEX idx_user_consent_purposes_key ON user_consent_purposes (purpose_key, consent_id);
+
+COMMENT ON TABLE user_consent_purposes IS 'Per-purpose accept/decline choices recorded with a consent';
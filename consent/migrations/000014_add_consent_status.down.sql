@@ -0,0 +1,7 @@
+-- Rollback consent status
+-- Row declined vẫn được giữ (is_deleted = TRUE) làm bằng chứng
+-- consent_chain vẫn cho phép DECLINED: chain append-only, event đã ghi không bị xóa khi rollback
+
+ALTER TABLE user_consents DROP CONSTRAINT IF EXISTS chk_user_consents_status_deleted;
+ALTER TABLE user_consents DROP CONSTRAINT IF EXISTS chk_user_consents_status;
+ALTER TABLE user_consents DROP COLUMN IF EXISTS status;
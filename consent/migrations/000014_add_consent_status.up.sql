@@ -0,0 +1,27 @@
+-- Trạng thái tường minh của consent: granted (đang hiệu lực), withdrawn (user/Admin thu hồi),
+-- declined (user chủ động từ chối policy không bắt buộc)
+-- is_deleted giữ nghĩa "không còn hiệu lực" (withdrawn hoặc declined) để các query hiện có không đổi
+-- revoked_at/revoked_by/revoked_reason = thời điểm, người và lý do chuyển sang withdrawn/declined
+
+ALTER TABLE user_consents ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'granted';
+
+UPDATE user_consents SET status = 'withdrawn' WHERE is_deleted = TRUE;
+
+-- Consent thu hồi trước khi revoked_at được ghi
+UPDATE user_consents SET revoked_at = deleted_at WHERE is_deleted = TRUE AND revoked_at IS NULL;
+
+ALTER TABLE user_consents ADD CONSTRAINT chk_user_consents_status
+CHECK (status IN ('granted', 'withdrawn', 'declined'));
+
+ALTER TABLE user_consents ADD CONSTRAINT chk_user_consents_status_deleted
+CHECK ((status = 'granted') = (is_deleted = FALSE));
+
+-- Từ chối được ghi thành DECLINED event trong chain
+ALTER TABLE consent_chain DROP CONSTRAINT IF EXISTS consent_chain_event_type_check;
+ALTER TABLE consent_chain ADD CONSTRAINT consent_chain_event_type_check
+CHECK (event_type IN ('GRANTED', 'REVOKED', 'DECLINED'));
+
+COMMENT ON COLUMN user_consents.status IS 'granted, withdrawn (revoked) or declined (user refused an optional policy)';
+COMMENT ON COLUMN user_consents.revoked_at IS 'When the consent was withdrawn or declined';
+COMMENT ON COLUMN user_consents.revoked_reason IS 'Reason for withdrawal/refusal (e.g., user_request, user_declined, purposes_changed)';
+COMMENT ON COLUMN user_consents.revoked_by IS 'User ID or admin ID that withdrew or declined the consent';
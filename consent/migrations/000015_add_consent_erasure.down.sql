@@ -0,0 +1,5 @@
+-- Rollback consent erasure
+-- IP/user agent đã xóa không khôi phục được; ERASED event vẫn nằm trong chain (append-only)
+
+DROP INDEX IF EXISTS idx_user_consents_erased;
+ALTER TABLE user_consents DROP COLUMN IF EXISTS erased_at;
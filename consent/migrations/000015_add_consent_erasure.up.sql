@@ -0,0 +1,15 @@
+-- Quyền xóa dữ liệu (GDPR Art. 17 / Nghị định 13): xóa IP, user agent khỏi consent của user
+-- nhưng giữ bằng chứng consent dưới dạng bút danh (user_id là UUID, không còn liên kết với
+-- số điện thoại/tên sau khi User Service ẩn danh hóa user)
+-- Mỗi row bị xóa có 1 ERASED event trong chain: content_hash phủ các field còn lại của row,
+-- GRANTED/DECLINED event gốc vẫn giữ nguyên nên chain vẫn verify được
+
+ALTER TABLE user_consents ADD COLUMN erased_at TIMESTAMPTZ;
+
+ALTER TABLE consent_chain DROP CONSTRAINT IF EXISTS consent_chain_event_type_check;
+ALTER TABLE consent_chain ADD CONSTRAINT consent_chain_event_type_check
+CHECK (event_type IN ('GRANTED', 'REVOKED', 'DECLINED', 'ERASED'));
+
+CREATE INDEX idx_user_consents_erased ON user_consents(user_id) WHERE erased_at IS NOT NULL;
+
+COMMENT ON COLUMN user_consents.erased_at IS 'When ip_address/user_agent were erased (right to erasure); NULL = not erased';
@@ -0,0 +1,4 @@
+-- Rollback document version snapshots
+-- Snapshot trong document_snapshots vẫn được giữ (consent tham chiếu theo content hash)
+
+DROP TABLE IF EXISTS document_version_snapshots;
@@ -0,0 +1,12 @@
+-- Snapshot được capture 1 lần cho mỗi version (theo locale hiển thị): consent sau đó của cùng
+-- version, kể cả đồng ý lại, dùng lại content hash đã lưu thay vì tải lại file_url/file upload
+
+CREATE TABLE IF NOT EXISTS document_version_snapshots (
+    document_id UUID NOT NULL, -- policy_documents.id ở Document Service (1 version)
+    locale VARCHAR(10) NOT NULL,
+    content_hash CHAR(64) NOT NULL REFERENCES document_snapshots(content_hash),
+    captured_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (document_id, locale)
+);
+
+COMMENT ON TABLE document_version_snapshots IS 'Content hash captured for each document version and locale on its first consent';
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
@@ -16,7 +17,17 @@ import (
 	"github.com/thatlq1812/policy-system/document/internal/handler"
 	"github.com/thatlq1812/policy-system/document/internal/repository"
 	"github.com/thatlq1812/policy-system/document/internal/service"
+	"github.com/thatlq1812/policy-system/document/internal/storage"
+	auditpb "github.com/thatlq1812/policy-system/shared/pkg/api/audit"
 	pb "github.com/thatlq1812/policy-system/shared/pkg/api/document"
+	webhookpb "github.com/thatlq1812/policy-system/shared/pkg/api/webhook"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit/pgstore"
+	"github.com/thatlq1812/policy-system/shared/pkg/netguard"
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	outboxstore "github.com/thatlq1812/policy-system/shared/pkg/outbox/pgstore"
+	"github.com/thatlq1812/policy-system/shared/pkg/webhook"
+	webhookstore "github.com/thatlq1812/policy-system/shared/pkg/webhook/pgstore"
 )
 
 func main() {
@@ -39,12 +50,46 @@ func main() {
 	}
 	log.Println("Database connection established")
 
-	// 3. Initialize layers (bottom-up)
+	// 3. Initialize file storage backend
+	blobStore, err := storage.New(cfg.StorageBackend, cfg.StorageLocalDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize file storage: %v", err)
+	}
+	log.Printf("File storage backend: %s", cfg.StorageBackend)
+
+	// 4. Initialize layers (bottom-up)
 	repo := repository.NewPostgresDocumentRepository(dbpool)
-	svc := service.NewDocumentService(repo)
-	hdl := handler.NewDocumentHandler(svc)
+	auditStore := pgstore.NewStore(dbpool)
+	auditLog := audit.NewLogger(auditStore, "document")
+	fileSvc := service.NewFileService(repository.NewPostgresFileRepository(dbpool), blobStore, cfg.MaxUploadBytes, auditLog)
+	svc := service.NewDocumentService(repo, fileSvc, auditLog)
+	hdl := handler.NewDocumentHandler(svc, fileSvc)
+
+	// Background job: phát hành các version scheduled khi tới effective_timestamp
+	// GetLatest đã tự lọc theo thời gian, job này chỉ cập nhật status cho đúng thực tế
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go runScheduledPublisher(jobCtx, svc, cfg.PublishCheckInterval)
+
+	// Webhook subscriptions do Admin đăng ký: worker gửi delivery, retry và chuyển vào dead-letter.
+	// Endpoint không được trỏ vào mạng nội bộ (trừ allow-list)
+	outboundGuard, err := netguard.New(cfg.OutboundAllowedNetworks...)
+	if err != nil {
+		log.Fatalf("Invalid OUTBOUND_ALLOWED_NETWORKS: %v", err)
+	}
+	webhookStore := webhookstore.NewStore(dbpool, "document")
+	go webhook.NewWorker(webhookStore, webhook.WorkerConfig{Guard: outboundGuard}).Run(jobCtx)
 
-	// 4. Setup gRPC server
+	// Outbox dispatcher: gửi domain events tới webhook subscriptions và các sink đã cấu hình
+	sinks := []outbox.Sink{webhook.NewFanoutSink(webhookStore)}
+	if cfg.OutboxWebhookURL != "" {
+		sinks = append(sinks, outbox.NewWebhookSink(cfg.OutboxWebhookURL, cfg.OutboxWebhookSecret, 10*time.Second))
+		log.Printf("Outbox webhook sink: %s", cfg.OutboxWebhookURL)
+	}
+	dispatcher := outbox.NewDispatcher(outboxstore.NewStore(dbpool), sinks, outbox.DispatcherConfig{PollInterval: cfg.OutboxPollInterval})
+	go dispatcher.Run(jobCtx)
+
+	// 5. Setup gRPC server
 	lis, err := net.Listen("tcp", ":"+cfg.ServerPort)
 	if err != nil {
 		log.Fatal(err)
@@ -52,6 +97,8 @@ func main() {
 
 	grpcServer := grpc.NewServer()
 	pb.RegisterDocumentServiceServer(grpcServer, hdl)
+	auditpb.RegisterAuditServiceServer(grpcServer, audit.NewServer(auditStore))
+	webhookpb.RegisterWebhookServiceServer(grpcServer, webhook.NewServer(webhookStore, outboundGuard, outbox.EventPolicyPublished))
 
 	//
 	reflection.Register(grpcServer)
@@ -63,6 +110,7 @@ func main() {
 	go func() {
 		<-sigChan
 		log.Println("Shutting down gracefully...")
+		stopJobs()
 		grpcServer.GracefulStop()
 	}()
 
@@ -71,3 +119,26 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// runScheduledPublisher periodically promotes due scheduled versions to published
+func runScheduledPublisher(ctx context.Context, svc service.DocumentService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := svc.PublishDuePolicies(ctx)
+			if err != nil {
+				log.Printf("WARNING: Failed to publish scheduled policies: %v", err)
+				continue
+			}
+			for _, doc := range published {
+				log.Printf("INFO: Published scheduled policy %s (%s/%s, effective %d)",
+					doc.ID, doc.Platform, doc.DocumentName, doc.EffectiveTimestamp)
+			}
+		}
+	}
+}
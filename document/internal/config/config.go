@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,15 +14,36 @@ type Config struct {
 	ServerPort      string
 	DatabaseURL     string
 	DatabaseMaxConn int
+	// Chu kỳ job chuyển version scheduled -> published
+	PublishCheckInterval time.Duration
+	// File storage (content-addressed)
+	StorageBackend  string // "local" (S3-compatible backend cắm qua storage.BlobStore)
+	StorageLocalDir string
+	MaxUploadBytes  int64
+	// Outbox: gửi domain events tới webhook (để trống = không gửi, event vẫn nằm trong outbox_events)
+	OutboxWebhookURL    string
+	OutboxWebhookSecret string // Ký body bằng HMAC-SHA256 (header X-Signature)
+	OutboxPollInterval  time.Duration
+	// Mạng nội bộ (CIDR) được phép gọi tới từ webhook;
+	// mặc định chặn loopback, RFC1918, link-local
+	OutboundAllowedNetworks []string
 }
 
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		ServerPort:      getEnv("GRPC_PORT", "50051"),
-		DatabaseURL:     getEnv("DATABASE_URL", ""),
-		DatabaseMaxConn: getEnvAsInt("DB_MAX_CONN", 10),
+		ServerPort:              getEnv("GRPC_PORT", "50051"),
+		DatabaseURL:             getEnv("DATABASE_URL", ""),
+		DatabaseMaxConn:         getEnvAsInt("DB_MAX_CONN", 10),
+		PublishCheckInterval:    time.Duration(getEnvAsInt("PUBLISH_CHECK_INTERVAL_SECONDS", 60)) * time.Second,
+		StorageBackend:          getEnv("STORAGE_BACKEND", "local"),
+		StorageLocalDir:         getEnv("STORAGE_LOCAL_DIR", "./data/files"),
+		MaxUploadBytes:          int64(getEnvAsInt("MAX_UPLOAD_BYTES", 20*1024*1024)),
+		OutboxWebhookURL:        getEnv("OUTBOX_WEBHOOK_URL", ""),
+		OutboxWebhookSecret:     getEnv("OUTBOX_WEBHOOK_SECRET", ""),
+		OutboxPollInterval:      time.Duration(getEnvAsInt("OUTBOX_POLL_INTERVAL_SECONDS", 2)) * time.Second,
+		OutboundAllowedNetworks: getEnvAsSlice("OUTBOUND_ALLOWED_NETWORKS"),
 	}
 
 	// validate required fields
@@ -45,3 +68,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsSlice đọc danh sách phân cách bằng dấu phẩy
+func getEnvAsSlice(key string) []string {
+	var result []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
@@ -0,0 +1,176 @@
+// Package diff computes token-level differences between two versions of a policy document
+package diff
+
+import (
+	"strings"
+)
+
+// Op is the kind of change a segment represents
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+)
+
+// maxEditDistance - vượt quá số thay đổi này thì coi như thay toàn bộ nội dung
+// (Myers giữ trace O(D^2), giới hạn để tránh tốn bộ nhớ với 2 version khác hẳn nhau)
+const maxEditDistance = 4000
+
+// Segment is a run of consecutive tokens with the same Op
+type Segment struct {
+	Op   Op
+	Text string
+}
+
+// Stats counts changed words, ignoring tags and whitespace
+type Stats struct {
+	Insertions int
+	Deletions  int
+}
+
+// Diff returns the segments that turn a into b (Myers O((N+M)D) algorithm)
+func Diff(a, b []string) []Segment {
+	edits := myersTrimmed(a, b)
+
+	// Gộp các token liên tiếp cùng Op thành một segment
+	var segs []Segment
+	for i := 0; i < len(edits); {
+		j := i
+		var sb strings.Builder
+		for j < len(edits) && edits[j].op == edits[i].op {
+			sb.WriteString(edits[j].token)
+			j++
+		}
+		segs = append(segs, Segment{Op: edits[i].op, Text: sb.String()})
+		i = j
+	}
+
+	return segs
+}
+
+type edit struct {
+	op    Op
+	token string
+}
+
+// myersTrimmed bỏ phần đầu/cuối giống nhau trước khi chạy Myers
+func myersTrimmed(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, len(a)+len(b))
+	for _, t := range a[:prefix] {
+		edits = append(edits, edit{OpEqual, t})
+	}
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, t := range a[len(a)-suffix:] {
+		edits = append(edits, edit{OpEqual, t})
+	}
+	return edits
+}
+
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	max := n + m
+	offset := max
+	v := make([]int, 2*max+2)
+	// trace[d] là trạng thái V (các đường chéo -d..d) trước vòng d
+	var trace [][]int
+
+	for d := 0; d <= max; d++ {
+		if d > maxEditDistance {
+			return replaceAll(a, b)
+		}
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // đi xuống: insert
+			} else {
+				x = v[offset+k-1] + 1 // đi sang phải: delete
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+
+	return replaceAll(a, b)
+}
+
+func backtrack(a, b []string, trace [][]int) []edit {
+	x, y := len(a), len(b)
+	edits := make([]edit, 0, x+y)
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := 0
+		if d > 0 {
+			prevX = at(prevK)
+		}
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			edits = append(edits, edit{OpEqual, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{OpInsert, b[y-1]})
+			} else {
+				edits = append(edits, edit{OpDelete, a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	// Đảo ngược vì backtrack đi từ cuối về đầu
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+func replaceAll(a, b []string) []edit {
+	edits := make([]edit, 0, len(a)+len(b))
+	for _, t := range a {
+		edits = append(edits, edit{OpDelete, t})
+	}
+	for _, t := range b {
+		edits = append(edits, edit{OpInsert, t})
+	}
+	return edits
+}
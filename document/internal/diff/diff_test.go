@@ -0,0 +1,115 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// apply dựng lại 2 version từ segments: equal+delete là version cũ, equal+insert là version mới
+func apply(segs []Segment) (from, to string) {
+	var a, b strings.Builder
+	for _, s := range segs {
+		if s.Op != OpInsert {
+			a.WriteString(s.Text)
+		}
+		if s.Op != OpDelete {
+			b.WriteString(s.Text)
+		}
+	}
+	return a.String(), b.String()
+}
+
+func TestCompareHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     []Segment
+	}{
+		{"Equal inputs", "a b c", "a b c", []Segment{{OpEqual, "a b c"}}},
+		{"Both empty", "", "", nil},
+		{"Pure insert into empty", "", "a b", []Segment{{OpInsert, "a b"}}},
+		{"Pure delete to empty", "a b", "", []Segment{{OpDelete, "a b"}}},
+		{"Insert in the middle", "the quick fox", "the quick brown fox", []Segment{
+			{OpEqual, "the quick "}, {OpInsert, "brown "}, {OpEqual, "fox"},
+		}},
+		{"Delete in the middle", "the quick brown fox", "the fox", []Segment{
+			{OpEqual, "the "}, {OpDelete, "quick brown "}, {OpEqual, "fox"},
+		}},
+		{"Mixed edits", "users must accept terms", "users should accept the new terms", []Segment{
+			{OpEqual, "users "}, {OpDelete, "must"}, {OpInsert, "should"},
+			{OpEqual, " accept"}, {OpInsert, " the new"}, {OpEqual, " terms"},
+		}},
+		{"Tags are single tokens", "<p>old text</p>", "<p>new text</p>", []Segment{
+			{OpEqual, "<p>"}, {OpDelete, "old"}, {OpInsert, "new"}, {OpEqual, " text</p>"},
+		}},
+		{"Vietnamese words", "Người dùng đồng ý", "Người dùng không đồng ý", []Segment{
+			{OpEqual, "Người dùng "}, {OpInsert, "không "}, {OpEqual, "đồng ý"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompareHTML(tt.from, tt.to)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompareHTML() = %#v, want %#v", got, tt.want)
+			}
+			if from, to := apply(got); from != tt.from || to != tt.to {
+				t.Errorf("segments rebuild %q -> %q, want %q -> %q", from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestDiffMaxEditDistance(t *testing.T) {
+	// tokens trả về n token khác nhau với prefix cho trước
+	tokens := func(prefix string, n int) []string {
+		out := make([]string, n)
+		for i := range out {
+			out[i] = fmt.Sprintf("%s%d ", prefix, i)
+		}
+		return out
+	}
+	join := func(parts ...[]string) []string {
+		var out []string
+		for _, p := range parts {
+			out = append(out, p...)
+		}
+		return out
+	}
+	common := []string{"shared "}
+
+	tests := []struct {
+		name       string
+		a, b       []string
+		wantEquals bool // Token chung ở giữa được giữ là equal
+	}{
+		{"Within limit - common token kept",
+			join(tokens("a", 100), common, tokens("x", 100)), join(tokens("b", 100), common, tokens("y", 100)), true},
+		// Cần hơn maxEditDistance thay đổi: coi như thay toàn bộ, không tìm token chung
+		{"Over limit - replaced entirely",
+			join(tokens("a", maxEditDistance/4+100), common, tokens("x", maxEditDistance/4+100)),
+			join(tokens("b", maxEditDistance/4+100), common, tokens("y", maxEditDistance/4+100)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segs := Diff(tt.a, tt.b)
+
+			hasEqual := false
+			for _, s := range segs {
+				hasEqual = hasEqual || s.Op == OpEqual
+			}
+			if hasEqual != tt.wantEquals {
+				t.Errorf("Diff() has equal segment = %v, want %v (%d segments)", hasEqual, tt.wantEquals, len(segs))
+			}
+			if !tt.wantEquals && (len(segs) != 2 || segs[0].Op != OpDelete || segs[1].Op != OpInsert) {
+				t.Errorf("Diff() over limit = %d segments, want delete then insert", len(segs))
+			}
+			if from, to := apply(segs); from != strings.Join(tt.a, "") || to != strings.Join(tt.b, "") {
+				t.Error("segments do not rebuild both versions")
+			}
+		})
+	}
+}
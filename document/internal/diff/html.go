@@ -0,0 +1,226 @@
+package diff
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// tokenPattern tách HTML thành: tag nguyên khối, entity, từ, khoảng trắng, ký tự đơn
+var tokenPattern = regexp.MustCompile(`<[^>]*>|&[#a-zA-Z0-9]+;|[\p{L}\p{N}_]+|\s+|.`)
+
+// blockTags - tag kết thúc một dòng khi chuyển HTML sang text
+var blockTags = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/h[1-6]|/li|/tr|/ul|/ol|/table|/blockquote|/section|/article)\b[^>]*>`)
+
+var (
+	anyTag       = regexp.MustCompile(`<[^>]*>`)
+	spaceRun     = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLineRun = regexp.MustCompile(`\n{3,}`)
+)
+
+// Tokenize tách content_html thành token để diff theo từ (tag HTML không bị cắt đôi)
+func Tokenize(s string) []string {
+	return tokenPattern.FindAllString(s, -1)
+}
+
+// CompareHTML diff 2 đoạn content_html theo từ
+func CompareHTML(from, to string) []Segment {
+	return Diff(Tokenize(from), Tokenize(to))
+}
+
+// Count trả về số từ được thêm/xóa (bỏ qua tag và khoảng trắng)
+func Count(segs []Segment) Stats {
+	var st Stats
+	for _, s := range segs {
+		if s.Op == OpEqual {
+			continue
+		}
+		n := 0
+		for _, t := range Tokenize(s.Text) {
+			if strings.TrimSpace(t) != "" && !isTag(t) {
+				n++
+			}
+		}
+		if s.Op == OpInsert {
+			st.Insertions += n
+		} else {
+			st.Deletions += n
+		}
+	}
+	return st
+}
+
+// RenderHTML dựng HTML của version mới, đánh dấu phần thêm bằng <ins> và phần xóa bằng <del>.
+// Tag bị xóa được bỏ đi (chỉ giữ chữ) để cấu trúc HTML luôn theo version mới.
+func RenderHTML(segs []Segment) string {
+	var sb strings.Builder
+	for _, s := range segs {
+		switch s.Op {
+		case OpEqual:
+			sb.WriteString(s.Text)
+		case OpInsert:
+			writeMarked(&sb, "ins", Tokenize(s.Text), true)
+		case OpDelete:
+			writeMarked(&sb, "del", Tokenize(s.Text), false)
+		}
+	}
+	return sb.String()
+}
+
+// writeMarked bọc các đoạn chữ liên tiếp trong <tag>, tag HTML nằm ngoài để không lồng sai
+func writeMarked(sb *strings.Builder, tag string, tokens []string, keepTags bool) {
+	open := false
+	for _, t := range tokens {
+		if isTag(t) {
+			if open {
+				sb.WriteString("</" + tag + ">")
+				open = false
+			}
+			if keepTags {
+				sb.WriteString(t)
+			}
+			continue
+		}
+		if !open {
+			sb.WriteString(`<` + tag + ` class="policy-diff-` + tag + `">`)
+			open = true
+		}
+		sb.WriteString(t)
+	}
+	if open {
+		sb.WriteString("</" + tag + ">")
+	}
+}
+
+// ToText chuyển content_html sang plain text, mỗi block một dòng
+func ToText(s string) string {
+	s = blockTags.ReplaceAllString(s, "$0\n")
+	s = anyTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = spaceRun.ReplaceAllString(s, " ")
+
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	s = strings.Join(lines, "\n")
+	s = blankLineRun.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+// Unified trả về diff dạng text theo dòng (giống `diff -u`), context là số dòng giữ quanh thay đổi
+func Unified(fromLabel, toLabel, from, to string, context int) string {
+	a, b := splitLines(from), splitLines(to)
+	edits := lineEdits(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromLabel, toLabel)
+
+	// Tìm các hunk: nhóm thay đổi cách nhau không quá 2*context dòng
+	for i := 0; i < len(edits); {
+		if edits[i].op == OpEqual {
+			i++
+			continue
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(edits) {
+			if edits[end].op != OpEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].op == OpEqual {
+				run++
+			}
+			if run == len(edits) || run-end > 2*context {
+				break
+			}
+			end = run
+		}
+		stop := end + context
+		if stop > len(edits) {
+			stop = len(edits)
+		}
+
+		writeHunk(&sb, edits, start, stop)
+		i = stop
+	}
+
+	return sb.String()
+}
+
+type lineEdit struct {
+	op     Op
+	text   string
+	aIndex int // số dòng (0-based) ở version cũ, -1 nếu là dòng thêm
+	bIndex int // số dòng (0-based) ở version mới, -1 nếu là dòng xóa
+}
+
+func lineEdits(a, b []string) []lineEdit {
+	segs := make([]lineEdit, 0, len(a)+len(b))
+	ai, bi := 0, 0
+	for _, e := range myersTrimmed(a, b) {
+		switch e.op {
+		case OpEqual:
+			segs = append(segs, lineEdit{OpEqual, e.token, ai, bi})
+			ai++
+			bi++
+		case OpDelete:
+			segs = append(segs, lineEdit{OpDelete, e.token, ai, -1})
+			ai++
+		case OpInsert:
+			segs = append(segs, lineEdit{OpInsert, e.token, -1, bi})
+			bi++
+		}
+	}
+	return segs
+}
+
+func writeHunk(sb *strings.Builder, edits []lineEdit, start, stop int) {
+	aStart, bStart := -1, -1
+	aCount, bCount := 0, 0
+	for _, e := range edits[start:stop] {
+		if e.aIndex >= 0 {
+			if aStart < 0 {
+				aStart = e.aIndex
+			}
+			aCount++
+		}
+		if e.bIndex >= 0 {
+			if bStart < 0 {
+				bStart = e.bIndex
+			}
+			bCount++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart+1, aCount, bStart+1, bCount)
+
+	for _, e := range edits[start:stop] {
+		switch e.op {
+		case OpEqual:
+			sb.WriteString(" ")
+		case OpDelete:
+			sb.WriteString("-")
+		case OpInsert:
+			sb.WriteString("+")
+		}
+		sb.WriteString(e.text)
+		sb.WriteString("\n")
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func isTag(t string) bool {
+	return strings.HasPrefix(t, "<") && strings.HasSuffix(t, ">") && len(t) > 1
+}
@@ -0,0 +1,73 @@
+package diff
+
+import "testing"
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     string
+	}{
+		{"Unchanged", "<p>Terms</p>", "<p>Terms</p>", "<p>Terms</p>"},
+		{"Inserted text", "<p>Hello world</p>", "<p>Hello brave world</p>",
+			`<p>Hello <ins class="policy-diff-ins">brave </ins>world</p>`},
+		{"Replaced word", "<p>old text</p>", "<p>new text</p>",
+			`<p><del class="policy-diff-del">old</del><ins class="policy-diff-ins">new</ins> text</p>`},
+		// Tag thêm mới được giữ, <ins> không bọc qua tag để HTML không lồng sai
+		{"Inserted tag kept outside marker", "<p>Hello world</p>", "<p>Hello <b>brave</b> world</p>",
+			`<p>Hello <b><ins class="policy-diff-ins">brave</ins></b><ins class="policy-diff-ins"> </ins>world</p>`},
+		// Tag bị xóa bị bỏ đi, chỉ giữ chữ: cấu trúc HTML theo version mới
+		{"Deleted tag dropped", "<p>Hello <b>brave</b> world</p>", "<p>Hello world</p>",
+			`<p>Hello <del class="policy-diff-del">brave</del><del class="policy-diff-del"> </del>world</p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderHTML(CompareHTML(tt.from, tt.to)); got != tt.want {
+				t.Errorf("RenderHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	segs := CompareHTML("<p>users must accept terms</p>", "<p>users should accept <b>the new</b> terms</p>")
+	want := Stats{Insertions: 3, Deletions: 1}
+	if got := Count(segs); got != want {
+		t.Errorf("Count() = %+v, want %+v", got, want)
+	}
+}
+
+func TestToText(t *testing.T) {
+	got := ToText("<h1>Terms</h1><p>First&nbsp;rule &amp; more</p>\n\n\n<ul><li>One</li><li>Two</li></ul>")
+	want := "Terms\nFirst rule & more\n\nOne\nTwo"
+	if got != want {
+		t.Errorf("ToText() = %q, want %q", got, want)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     string
+	}{
+		{"No changes", "same\nlines", "same\nlines", "--- v1\n+++ v2\n"},
+		{"One changed line with context", "a\nb\nc\nd\ne\nf\ng\nh", "a\nb\nc\nD\ne\nf\ng\nh",
+			"--- v1\n+++ v2\n@@ -3,3 +3,3 @@\n c\n-d\n+D\n e\n"},
+		{"Distant changes in separate hunks", "a\nb\nc\nd\ne\nf\ng\nh\ni\nj", "A\nb\nc\nd\ne\nf\ng\nh\ni\nJ",
+			"--- v1\n+++ v2\n@@ -1,2 +1,2 @@\n-a\n+A\n b\n@@ -9,2 +9,2 @@\n i\n-j\n+J\n"},
+		{"Close changes merged into one hunk", "a\nb\nc\nd", "A\nb\nC\nd",
+			"--- v1\n+++ v2\n@@ -1,4 +1,4 @@\n-a\n+A\n b\n-c\n+C\n d\n"},
+		{"Insert into empty", "", "new line", "--- v1\n+++ v2\n@@ -0,0 +1,1 @@\n+new line\n"},
+		{"Delete everything", "old\nlines", "", "--- v1\n+++ v2\n@@ -1,2 +0,0 @@\n-old\n-lines\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("v1", "v2", tt.from, tt.to, 1); got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"regexp"
+	"strings"
+	"time"
+)
 
 // Platform constants - EXACT values accepted
 const (
@@ -9,16 +13,88 @@ const (
 	PlatformAdmin    = "Admin"
 )
 
+// Publication status constants
+// Vòng đời: draft -> pending_review -> scheduled -> published
+// Version bị từ chối (rejected) không thể phát hành, admin phải tạo version mới
+const (
+	StatusDraft         = "draft"
+	StatusPendingReview = "pending_review"
+	StatusRejected      = "rejected"
+	StatusScheduled     = "scheduled"
+	StatusPublished     = "published"
+)
+
+// MaxConsentValidityPeriod - thời hạn consent tối đa (ngày), tránh nhập nhầm đơn vị
+const MaxConsentValidityPeriod = 3650
+
+// PurposeKeyPattern - key của purpose: chữ thường, số, gạch dưới (vd: "marketing", "third_party_sharing")
+var PurposeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// Locale constants - ngôn ngữ đang vận hành
+// Nội dung gốc của version ở một locale (mặc định vi), các locale khác nằm ở bản dịch
+const (
+	LocaleVietnamese = "vi"
+	LocaleEnglish    = "en"
+	DefaultLocale    = LocaleVietnamese
+)
+
 type PolicyDocument struct {
-	ID                 string    `db:"id"`
-	DocumentName       string    `db:"document_name"`
-	Platform           string    `db:"platform"`
-	IsMandatory        bool      `db:"is_mandatory"`
-	EffectiveTimestamp int64     `db:"effective_timestamp"`
-	ContentHTML        string    `db:"content_html"`
-	FileURL            string    `db:"file_url"`
-	CreatedAt          time.Time `db:"created_at"`
-	CreatedBy          string    `db:"created_by"`
+	ID                 string     `db:"id"`
+	DocumentName       string     `db:"document_name"`
+	Platform           string     `db:"platform"`
+	IsMandatory        bool       `db:"is_mandatory"`
+	EffectiveTimestamp int64      `db:"effective_timestamp"`
+	ContentHTML        string     `db:"content_html"`
+	FileURL            string     `db:"file_url"`
+	CreatedAt          time.Time  `db:"created_at"`
+	CreatedBy          string     `db:"created_by"`
+	Status             string     `db:"status"`
+	PublishedAt        *time.Time `db:"published_at"`
+	PublishedBy        *string    `db:"published_by"`
+	SubmittedBy        *string    `db:"submitted_by"`
+	SubmittedAt        *time.Time `db:"submitted_at"`
+	ReviewedBy         *string    `db:"reviewed_by"`
+	ReviewedAt         *time.Time `db:"reviewed_at"`
+	ReviewComment      *string    `db:"review_comment"`
+	FileHash           *string    `db:"file_hash"` // File đã upload (content-addressed), thay cho file_url
+	Locale             string     `db:"locale"`    // Locale của nội dung (sau khi service chọn bản dịch)
+	AvailableLocales   []string   // Nội dung gốc + bản dịch, service điền khi trả về
+	// Số ngày consent với version này còn hiệu lực trước khi user phải xác nhận lại (0 = không hết hạn)
+	ConsentValidityPeriod int              `db:"consent_validity_period"`
+	Purposes              []*PolicyPurpose // Mục đích user bật/tắt riêng, service điền khi trả về
+}
+
+// PolicyPurpose is a separately toggleable purpose of a version (analytics, marketing...)
+type PolicyPurpose struct {
+	DocumentID  string `db:"document_id"`
+	Key         string `db:"purpose_key"` // Ổn định giữa các version, consent và CheckPurposeConsent dùng key này
+	Name        string `db:"name"`
+	Description string `db:"description"`
+	IsRequired  bool   `db:"is_required"` // Đồng ý document = đồng ý mục đích này
+}
+
+// PurposeParams is one purpose sent when creating a version
+type PurposeParams struct {
+	Key         string
+	Name        string
+	Description string
+	IsRequired  bool
+}
+
+// PolicyTranslation is the content of a version in another locale
+type PolicyTranslation struct {
+	DocumentID  string    `db:"document_id"`
+	Locale      string    `db:"locale"`
+	ContentHTML string    `db:"content_html"`
+	FileHash    *string   `db:"file_hash"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// TranslationParams is the content of one extra locale sent when creating a version
+type TranslationParams struct {
+	Locale      string
+	ContentHTML string
+	FileHash    string
 }
 
 type CreateDocumentParams struct {
@@ -28,10 +104,99 @@ type CreateDocumentParams struct {
 	EffectiveTimestamp int64
 	ContentHTML        string
 	FileURL            string
+	FileHash           string // SHA-256 của file đã upload qua UploadFile (optional)
 	CreatedBy          string
+	Status             string // draft hoặc pending_review, service quyết định nếu để trống
+	Locale             string // Locale của ContentHTML/FileHash, rỗng = DefaultLocale
+	Translations       []TranslationParams
+	// Số ngày consent còn hiệu lực (0 = không hết hạn), tối đa MaxConsentValidityPeriod
+	ConsentValidityPeriod int
+	Purposes              []PurposeParams
+}
+
+// AvailableLocales trả về locale của nội dung gốc và các bản dịch
+func (p CreateDocumentParams) AvailableLocales() []string {
+	locales := []string{p.Locale}
+	for _, t := range p.Translations {
+		locales = append(locales, t.Locale)
+	}
+	return locales
+}
+
+// PolicyPurposes trả về purposes của version vừa tạo (theo thứ tự khai báo)
+func (p CreateDocumentParams) PolicyPurposes(documentID string) []*PolicyPurpose {
+	purposes := make([]*PolicyPurpose, len(p.Purposes))
+	for i, pp := range p.Purposes {
+		purposes[i] = &PolicyPurpose{
+			DocumentID:  documentID,
+			Key:         pp.Key,
+			Name:        pp.Name,
+			Description: pp.Description,
+			IsRequired:  pp.IsRequired,
+		}
+	}
+	return purposes
+}
+
+// FileBlob is the metadata of an uploaded file, addressed by the SHA-256 of its content
+type FileBlob struct {
+	ContentHash string    `db:"content_hash"`
+	ContentType string    `db:"content_type"`
+	SizeBytes   int64     `db:"size_bytes"`
+	Filename    string    `db:"filename"`
+	UploadedBy  string    `db:"uploaded_by"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// UploadFileParams is the metadata sent with an upload
+type UploadFileParams struct {
+	Filename    string
+	ContentType string // Content type client khai báo, service tự xác định lại theo extension + nội dung
+	UploadedBy  string
+}
+
+// ComparePoliciesParams chọn 2 version của cùng một document theo effective_timestamp
+type ComparePoliciesParams struct {
+	Platform           string
+	DocumentName       string
+	FromTimestamp      int64
+	ToTimestamp        int64
+	IncludeUnpublished bool   // true = cho phép so sánh cả draft/pending_review/rejected (chỉ admin)
+	Locale             string // So sánh bản dịch của locale này nếu có
+}
+
+// DiffSegment is a run of content_html that is unchanged, inserted or deleted
+type DiffSegment struct {
+	Op   string // equal, insert hoặc delete
+	Text string
+}
+
+// PolicyComparison is the difference in content_html between two versions of a document
+type PolicyComparison struct {
+	From       *PolicyDocument
+	To         *PolicyDocument
+	Segments   []DiffSegment // Diff theo từ trên HTML gốc
+	HTMLDiff   string        // HTML của version mới với <ins>/<del> đánh dấu thay đổi
+	TextDiff   string        // Unified diff trên plain text (mỗi block một dòng)
+	Insertions int           // Số từ được thêm
+	Deletions  int           // Số từ bị xóa
 }
 
 // Helper function for validation
 func IsValidPlatform(platform string) bool {
 	return platform == PlatformClient || platform == PlatformMerchant || platform == PlatformAdmin
 }
+
+// IsSupportedLocale checks a normalized locale
+func IsSupportedLocale(locale string) bool {
+	return locale == LocaleVietnamese || locale == LocaleEnglish
+}
+
+// NormalizeLocale đưa language tag về dạng locale đang lưu: "en-US", "EN_us" -> "en"
+func NormalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
@@ -18,4 +18,16 @@ var (
 
 	// ErrNoActiveVersion indicates no active version exists for the document
 	ErrNoActiveVersion = errors.New("no active version")
+
+	// ErrInvalidStatusTransition indicates the version cannot move to the requested status
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+	// ErrSelfReview indicates an admin tried to approve a version they authored or submitted
+	ErrSelfReview = errors.New("reviewer must be a different admin")
+
+	// ErrFileNotFound indicates no uploaded file exists for the content hash
+	ErrFileNotFound = errors.New("file not found")
+
+	// ErrFileTooLarge indicates an upload exceeded the configured size limit
+	ErrFileTooLarge = errors.New("file too large")
 )
@@ -15,22 +15,29 @@ import (
 type DocumentHandler struct {
 	pb.UnimplementedDocumentServiceServer
 	service service.DocumentService
+	files   service.FileService
 }
 
-func NewDocumentHandler(service service.DocumentService) *DocumentHandler {
-	return &DocumentHandler{service: service}
+func NewDocumentHandler(service service.DocumentService, files service.FileService) *DocumentHandler {
+	return &DocumentHandler{service: service, files: files}
 }
 
 func (h *DocumentHandler) CreatePolicy(ctx context.Context, req *pb.CreateDocumentRequest) (*pb.CreateDocumentResponse, error) {
 	// Step 1: Convert protobuf to domain
 	params := domain.CreateDocumentParams{
-		DocumentName:       req.DocumentName,
-		Platform:           req.Platform,
-		IsMandatory:        req.IsMandatory,
-		EffectiveTimestamp: req.EffectiveTimestamp, // Pass từ client, service sẽ handle nếu = 0
-		ContentHTML:        req.ContentHtml,
-		FileURL:            req.FileUrl,
-		CreatedBy:          req.CreatedBy, // UpdaedBy map sang CreatedBy vì create record mới
+		DocumentName:          req.DocumentName,
+		Platform:              req.Platform,
+		IsMandatory:           req.IsMandatory,
+		EffectiveTimestamp:    req.EffectiveTimestamp, // Pass từ client, service sẽ handle nếu = 0
+		ContentHTML:           req.ContentHtml,
+		FileURL:               req.FileUrl,
+		FileHash:              req.FileHash,
+		CreatedBy:             req.CreatedBy, // UpdaedBy map sang CreatedBy vì create record mới
+		Status:                statusFromDraftFlag(req.IsDraft),
+		Locale:                req.Locale,
+		Translations:          translationsFromPb(req.Translations),
+		ConsentValidityPeriod: int(req.ConsentValidityPeriod),
+		Purposes:              purposesFromPb(req.Purposes),
 	}
 
 	// Step 2: Call service layer
@@ -48,7 +55,7 @@ func (h *DocumentHandler) CreatePolicy(ctx context.Context, req *pb.CreateDocume
 
 func (h *DocumentHandler) GetLatestPolicyByPlatform(ctx context.Context, req *pb.GetLatestPolicyRequest) (*pb.GetLatestPolicyResponse, error) {
 	// Step 1: Call service
-	doc, err := h.service.GetLatestPolicy(ctx, req.Platform, req.DocumentName)
+	doc, err := h.service.GetLatestPolicy(ctx, req.Platform, req.DocumentName, req.Locale)
 	if err != nil {
 		return nil, mapErrorToGRPCStatus(err)
 	}
@@ -67,13 +74,19 @@ func (h *DocumentHandler) GetLatestPolicyByPlatform(ctx context.Context, req *pb
 func (h *DocumentHandler) UpdatePolicy(ctx context.Context, req *pb.UpdatePolicyRequest) (*pb.UpdatePolicyResponse, error) {
 	// Step 1: Convert protobuf to domain
 	params := domain.CreateDocumentParams{
-		DocumentName:       req.DocumentName,
-		Platform:           req.Platform,
-		IsMandatory:        req.IsMandatory,
-		EffectiveTimestamp: req.EffectiveTimestamp, // Pass từ client, service sẽ handle nếu = 0
-		ContentHTML:        req.ContentHtml,
-		FileURL:            req.FileUrl,
-		CreatedBy:          req.UpdatedBy, // UpdatedBy map sang CreatedBy vì create record mới
+		DocumentName:          req.DocumentName,
+		Platform:              req.Platform,
+		IsMandatory:           req.IsMandatory,
+		EffectiveTimestamp:    req.EffectiveTimestamp, // Pass từ client, service sẽ handle nếu = 0
+		ContentHTML:           req.ContentHtml,
+		FileURL:               req.FileUrl,
+		FileHash:              req.FileHash,
+		CreatedBy:             req.UpdatedBy, // UpdatedBy map sang CreatedBy vì create record mới
+		Status:                statusFromDraftFlag(req.IsDraft),
+		Locale:                req.Locale,
+		Translations:          translationsFromPb(req.Translations),
+		ConsentValidityPeriod: int(req.ConsentValidityPeriod),
+		Purposes:              purposesFromPb(req.Purposes),
 	}
 
 	// Step 2: Call service layer
@@ -92,17 +105,89 @@ func (h *DocumentHandler) UpdatePolicy(ctx context.Context, req *pb.UpdatePolicy
 
 // Helper: Convert domain model to protobuf message
 func domainToPb(doc *domain.PolicyDocument) *pb.PolicyDocument {
-	return &pb.PolicyDocument{
-		Id:                 doc.ID,
-		DocumentName:       doc.DocumentName,
-		Platform:           doc.Platform,
-		IsMandatory:        doc.IsMandatory,
-		EffectiveTimestamp: doc.EffectiveTimestamp,
-		ContentHtml:        doc.ContentHTML,
-		FileUrl:            doc.FileURL,
-		CreatedAt:          doc.CreatedAt.Unix(),
-		CreatedBy:          doc.CreatedBy,
+	pbDoc := &pb.PolicyDocument{
+		Id:                    doc.ID,
+		DocumentName:          doc.DocumentName,
+		Platform:              doc.Platform,
+		IsMandatory:           doc.IsMandatory,
+		EffectiveTimestamp:    doc.EffectiveTimestamp,
+		ContentHtml:           doc.ContentHTML,
+		FileUrl:               doc.FileURL,
+		CreatedAt:             doc.CreatedAt.Unix(),
+		CreatedBy:             doc.CreatedBy,
+		Status:                doc.Status,
+		Locale:                doc.Locale,
+		AvailableLocales:      doc.AvailableLocales,
+		ConsentValidityPeriod: int32(doc.ConsentValidityPeriod),
+	}
+	for _, p := range doc.Purposes {
+		pbDoc.Purposes = append(pbDoc.Purposes, &pb.PolicyPurpose{
+			Key:         p.Key,
+			Name:        p.Name,
+			Description: p.Description,
+			IsRequired:  p.IsRequired,
+		})
+	}
+	if doc.PublishedAt != nil {
+		pbDoc.PublishedAt = doc.PublishedAt.Unix()
+	}
+	if doc.PublishedBy != nil {
+		pbDoc.PublishedBy = *doc.PublishedBy
+	}
+	if doc.SubmittedBy != nil {
+		pbDoc.SubmittedBy = *doc.SubmittedBy
+	}
+	if doc.SubmittedAt != nil {
+		pbDoc.SubmittedAt = doc.SubmittedAt.Unix()
+	}
+	if doc.ReviewedBy != nil {
+		pbDoc.ReviewedBy = *doc.ReviewedBy
+	}
+	if doc.ReviewedAt != nil {
+		pbDoc.ReviewedAt = doc.ReviewedAt.Unix()
+	}
+	if doc.ReviewComment != nil {
+		pbDoc.ReviewComment = *doc.ReviewComment
+	}
+	if doc.FileHash != nil {
+		pbDoc.FileHash = *doc.FileHash
 	}
+	return pbDoc
+}
+
+// Helper: Convert translations from protobuf to domain
+func translationsFromPb(translations []*pb.PolicyTranslation) []domain.TranslationParams {
+	result := make([]domain.TranslationParams, len(translations))
+	for i, t := range translations {
+		result[i] = domain.TranslationParams{
+			Locale:      t.Locale,
+			ContentHTML: t.ContentHtml,
+			FileHash:    t.FileHash,
+		}
+	}
+	return result
+}
+
+// Helper: Convert purposes from protobuf to domain
+func purposesFromPb(purposes []*pb.PolicyPurpose) []domain.PurposeParams {
+	result := make([]domain.PurposeParams, len(purposes))
+	for i, p := range purposes {
+		result[i] = domain.PurposeParams{
+			Key:         p.Key,
+			Name:        p.Name,
+			Description: p.Description,
+			IsRequired:  p.IsRequired,
+		}
+	}
+	return result
+}
+
+// Helper: is_draft flag -> status, để trống cho service mặc định gửi duyệt
+func statusFromDraftFlag(isDraft bool) string {
+	if isDraft {
+		return domain.StatusDraft
+	}
+	return ""
 }
 
 // GetPolicyHistory retrieves the version history of a policy document
@@ -115,17 +200,7 @@ func (h *DocumentHandler) GetPolicyHistory(ctx context.Context, req *pb.GetPolic
 	// Convert domain models to proto messages
 	pbDocument := make([]*pb.PolicyDocument, len(documents))
 	for i, doc := range documents {
-		pbDocument[i] = &pb.PolicyDocument{
-			Id:                 doc.ID,
-			DocumentName:       doc.DocumentName,
-			Platform:           doc.Platform,
-			IsMandatory:        doc.IsMandatory,
-			EffectiveTimestamp: doc.EffectiveTimestamp,
-			ContentHtml:        doc.ContentHTML,
-			FileUrl:            doc.FileURL,
-			CreatedAt:          doc.CreatedAt.Unix(),
-			CreatedBy:          doc.CreatedBy,
-		}
+		pbDocument[i] = domainToPb(doc)
 	}
 
 	return &pb.GetPolicyHistoryResponse{
@@ -134,6 +209,137 @@ func (h *DocumentHandler) GetPolicyHistory(ctx context.Context, req *pb.GetPolic
 	}, nil
 }
 
+// ComparePolicyVersions trả về diff content_html giữa 2 version của một document
+func (h *DocumentHandler) ComparePolicyVersions(ctx context.Context, req *pb.ComparePolicyVersionsRequest) (*pb.ComparePolicyVersionsResponse, error) {
+	cmp, err := h.service.ComparePolicyVersions(ctx, domain.ComparePoliciesParams{
+		Platform:           req.Platform,
+		DocumentName:       req.DocumentName,
+		FromTimestamp:      req.FromTimestamp,
+		ToTimestamp:        req.ToTimestamp,
+		IncludeUnpublished: req.IncludeUnpublished,
+		Locale:             req.Locale,
+	})
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	segments := make([]*pb.DiffSegment, len(cmp.Segments))
+	for i, seg := range cmp.Segments {
+		segments[i] = &pb.DiffSegment{Op: seg.Op, Text: seg.Text}
+	}
+
+	return &pb.ComparePolicyVersionsResponse{
+		FromDocument: domainToPb(cmp.From),
+		ToDocument:   domainToPb(cmp.To),
+		Segments:     segments,
+		HtmlDiff:     cmp.HTMLDiff,
+		TextDiff:     cmp.TextDiff,
+		Insertions:   int32(cmp.Insertions),
+		Deletions:    int32(cmp.Deletions),
+	}, nil
+}
+
+// ListActivePolicies returns the effective version of every document on a platform
+func (h *DocumentHandler) ListActivePolicies(ctx context.Context, req *pb.ListActivePoliciesRequest) (*pb.ListActivePoliciesResponse, error) {
+	documents, err := h.service.ListActivePolicies(ctx, req.Platform, req.Locale)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	pbDocuments := make([]*pb.PolicyDocument, len(documents))
+	for i, doc := range documents {
+		pbDocuments[i] = domainToPb(doc)
+	}
+
+	return &pb.ListActivePoliciesResponse{
+		Documents: pbDocuments,
+		Total:     int32(len(pbDocuments)),
+	}, nil
+}
+
+// SubmitForReview submits a draft version for approval by another admin
+func (h *DocumentHandler) SubmitForReview(ctx context.Context, req *pb.SubmitForReviewRequest) (*pb.SubmitForReviewResponse, error) {
+	doc, err := h.service.SubmitForReview(ctx, req.DocumentId, req.SubmittedBy)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.SubmitForReviewResponse{
+		Document: domainToPb(doc),
+		Message:  "Policy version submitted for review",
+	}, nil
+}
+
+// ApprovePolicy approves a pending version (scheduled or published depending on effective time)
+func (h *DocumentHandler) ApprovePolicy(ctx context.Context, req *pb.ApprovePolicyRequest) (*pb.ApprovePolicyResponse, error) {
+	doc, err := h.service.ApprovePolicy(ctx, req.DocumentId, req.ReviewedBy, req.Comment)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.ApprovePolicyResponse{
+		Document: domainToPb(doc),
+		Message:  "Policy version approved and " + doc.Status,
+	}, nil
+}
+
+// RejectPolicy rejects a pending version
+func (h *DocumentHandler) RejectPolicy(ctx context.Context, req *pb.RejectPolicyRequest) (*pb.RejectPolicyResponse, error) {
+	doc, err := h.service.RejectPolicy(ctx, req.DocumentId, req.ReviewedBy, req.Comment)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.RejectPolicyResponse{
+		Document: domainToPb(doc),
+		Message:  "Policy version rejected",
+	}, nil
+}
+
+// SchedulePolicy re-schedules an approved version for publication at a future timestamp
+func (h *DocumentHandler) SchedulePolicy(ctx context.Context, req *pb.SchedulePolicyRequest) (*pb.SchedulePolicyResponse, error) {
+	doc, err := h.service.SchedulePolicy(ctx, req.DocumentId, req.EffectiveTimestamp, req.ScheduledBy)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.SchedulePolicyResponse{
+		Document: domainToPb(doc),
+		Message:  "Policy version scheduled successfully",
+	}, nil
+}
+
+// PublishPolicy publishes an approved (scheduled) version immediately
+func (h *DocumentHandler) PublishPolicy(ctx context.Context, req *pb.PublishPolicyRequest) (*pb.PublishPolicyResponse, error) {
+	doc, err := h.service.PublishPolicy(ctx, req.DocumentId, req.PublishedBy)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.PublishPolicyResponse{
+		Document: domainToPb(doc),
+		Message:  "Policy version published successfully",
+	}, nil
+}
+
+// ListUpcomingPolicies lists draft, pending and scheduled versions that are not yet effective
+func (h *DocumentHandler) ListUpcomingPolicies(ctx context.Context, req *pb.ListUpcomingPoliciesRequest) (*pb.ListUpcomingPoliciesResponse, error) {
+	documents, err := h.service.ListUpcomingPolicies(ctx, req.Platform, req.DocumentName)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	pbDocuments := make([]*pb.PolicyDocument, len(documents))
+	for i, doc := range documents {
+		pbDocuments[i] = domainToPb(doc)
+	}
+
+	return &pb.ListUpcomingPoliciesResponse{
+		Documents: pbDocuments,
+		Total:     int32(len(pbDocuments)),
+	}, nil
+}
+
 // Helper: Map service errors to gRPC status codes
 func mapErrorToGRPCStatus(err error) error {
 	if err == nil {
@@ -152,6 +358,14 @@ func mapErrorToGRPCStatus(err error) error {
 		return status.Error(codes.Aborted, err.Error())
 	case errors.Is(err, domain.ErrNoActiveVersion):
 		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidStatusTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrSelfReview):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrFileNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrFileTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
 	default:
 		// Default to internal error for unknown errors
 		return status.Error(codes.Internal, "internal server error")
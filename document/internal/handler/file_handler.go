@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/thatlq1812/policy-system/document/internal/domain"
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/document"
+)
+
+// downloadChunkSize - kích thước mỗi chunk khi stream file về client
+const downloadChunkSize = 64 * 1024
+
+// UploadFile nhận file theo stream: message đầu mang metadata, mọi message mang chunk
+func (h *DocumentHandler) UploadFile(stream grpc.ClientStreamingServer[pb.UploadFileRequest, pb.UploadFileResponse]) error {
+	// Step 1: Message đầu tiên chứa metadata
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "upload stream is empty")
+	}
+	if err != nil {
+		return err
+	}
+
+	params := domain.UploadFileParams{
+		Filename:    first.Filename,
+		ContentType: first.ContentType,
+		UploadedBy:  first.UploadedBy,
+	}
+
+	// Step 2: Service đọc chunk trực tiếp từ stream
+	reader := &uploadStreamReader{stream: stream, buf: first.Chunk}
+	blob, err := h.files.UploadFile(stream.Context(), params, reader)
+	if err != nil {
+		return mapErrorToGRPCStatus(err)
+	}
+
+	return stream.SendAndClose(&pb.UploadFileResponse{
+		File: fileBlobToPb(blob),
+	})
+}
+
+// DownloadFile stream file về client: message đầu mang metadata, các message sau mang chunk
+func (h *DocumentHandler) DownloadFile(req *pb.DownloadFileRequest, stream grpc.ServerStreamingServer[pb.DownloadFileResponse]) error {
+	blob, reader, err := h.files.OpenFile(stream.Context(), req.ContentHash)
+	if err != nil {
+		return mapErrorToGRPCStatus(err)
+	}
+	defer reader.Close()
+
+	if err := stream.Send(&pb.DownloadFileResponse{File: fileBlobToPb(blob)}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&pb.DownloadFileResponse{Chunk: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "failed to read file")
+		}
+	}
+}
+
+// uploadStreamReader adapts the upload stream to io.Reader
+type uploadStreamReader struct {
+	stream grpc.ClientStreamingServer[pb.UploadFileRequest, pb.UploadFileResponse]
+	buf    []byte
+}
+
+func (r *uploadStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err // io.EOF khi client đã gửi hết
+		}
+		r.buf = msg.Chunk
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Helper: Convert file metadata to protobuf message
+func fileBlobToPb(blob *domain.FileBlob) *pb.FileBlob {
+	return &pb.FileBlob{
+		ContentHash: blob.ContentHash,
+		ContentType: blob.ContentType,
+		SizeBytes:   blob.SizeBytes,
+		Filename:    blob.Filename,
+		UploadedBy:  blob.UploadedBy,
+		CreatedAt:   blob.CreatedAt.Unix(),
+	}
+}
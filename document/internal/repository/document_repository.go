@@ -3,20 +3,45 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/thatlq1812/policy-system/document/internal/domain"
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	outboxstore "github.com/thatlq1812/policy-system/shared/pkg/outbox/pgstore"
 )
 
 type DocumentRepository interface {
 	Create(ctx context.Context, params domain.CreateDocumentParams) (*domain.PolicyDocument, error)
+	// GetLatest chỉ trả về version đã được duyệt và đã tới thời điểm hiệu lực
 	GetLatest(ctx context.Context, platform, documentName string) (*domain.PolicyDocument, error)
 	GetHistory(ctx context.Context, platform, documentName string) ([]*domain.PolicyDocument, error)
+	// ListActive trả về version đang hiệu lực của từng document trên platform
+	ListActive(ctx context.Context, platform string) ([]*domain.PolicyDocument, error)
+	GetByID(ctx context.Context, id string) (*domain.PolicyDocument, error)
+	// UpdateStatus chuyển trạng thái một version (schedule/publish)
+	UpdateStatus(ctx context.Context, id, status string, effectiveTimestamp int64, actor string) (*domain.PolicyDocument, error)
+	// ListUpcoming trả về các version chưa có hiệu lực: draft, pending_review và scheduled trong tương lai
+	ListUpcoming(ctx context.Context, platform, documentName string) ([]*domain.PolicyDocument, error)
+	// SubmitForReview chuyển version draft sang pending_review
+	SubmitForReview(ctx context.Context, id, submittedBy string) (*domain.PolicyDocument, error)
+	// Review ghi nhận kết quả duyệt cho version pending_review (scheduled/published hoặc rejected)
+	Review(ctx context.Context, id, status string, effectiveTimestamp int64, reviewedBy, comment string) (*domain.PolicyDocument, error)
+	// PublishDueScheduled chuyển các version scheduled đã tới hạn sang published
+	PublishDueScheduled(ctx context.Context) ([]*domain.PolicyDocument, error)
+	// GetTranslations trả về bản dịch của các version, key là document_id
+	GetTranslations(ctx context.Context, documentIDs []string) (map[string][]*domain.PolicyTranslation, error)
+	// GetPurposes trả về purposes của các version (theo thứ tự khai báo), key là document_id
+	GetPurposes(ctx context.Context, documentIDs []string) (map[string][]*domain.PolicyPurpose, error)
 }
 
+// documentColumns - danh sách cột dùng chung cho mọi SELECT/RETURNING
+const documentColumns = `id, document_name, platform, is_mandatory, effective_timestamp, content_html, file_url, created_at, created_by, status, published_at, published_by,
+	submitted_by, submitted_at, reviewed_by, reviewed_at, review_comment, file_hash, locale, consent_validity_period`
+
 type postgresDocumentRepository struct {
 	db *pgxpool.Pool
 }
@@ -25,19 +50,86 @@ func NewPostgresDocumentRepository(db *pgxpool.Pool) DocumentRepository {
 	return &postgresDocumentRepository{db: db}
 }
 
+// scanDocument scan một row theo thứ tự documentColumns
+func scanDocument(row pgx.Row) (*domain.PolicyDocument, error) {
+	var doc domain.PolicyDocument
+	err := row.Scan(
+		&doc.ID,
+		&doc.DocumentName,
+		&doc.Platform,
+		&doc.IsMandatory,
+		&doc.EffectiveTimestamp,
+		&doc.ContentHTML,
+		&doc.FileURL,
+		&doc.CreatedAt,
+		&doc.CreatedBy,
+		&doc.Status,
+		&doc.PublishedAt,
+		&doc.PublishedBy,
+		&doc.SubmittedBy,
+		&doc.SubmittedAt,
+		&doc.ReviewedBy,
+		&doc.ReviewedAt,
+		&doc.ReviewComment,
+		&doc.FileHash,
+		&doc.Locale,
+		&doc.ConsentValidityPeriod,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// scanDocuments scan toàn bộ rows và đóng rows khi xong
+func scanDocuments(rows pgx.Rows) ([]*domain.PolicyDocument, error) {
+	defer rows.Close() // CRITICAL: Always close rows
+
+	var documents []*domain.PolicyDocument
+	for rows.Next() {
+		doc, err := scanDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		documents = append(documents, doc)
+	}
+
+	// Check for errors from iterating
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return documents, nil
+}
+
 func (r *postgresDocumentRepository) Create(ctx context.Context, params domain.CreateDocumentParams) (*domain.PolicyDocument, error) {
 	// 1. Generate UUID for ID
 	id := uuid.New().String()
-	// 2. Write INSERT query
+
+	// Version và bản dịch được ghi cùng transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 2. Version mới chỉ có thể là draft hoặc pending_review (người tạo = người gửi duyệt).
+	// submitted_by/submitted_at tính ở Go: dùng lại $8/$9 trong CASE làm Postgres suy ra kiểu text
+	// cho param đồng thời là giá trị VARCHAR → "inconsistent types deduced for parameter"
+	var submittedBy *string
+	var submittedAt *time.Time
+	if params.Status == domain.StatusPendingReview {
+		now := time.Now()
+		submittedBy, submittedAt = &params.CreatedBy, &now
+	}
+
 	query := `
 		INSERT INTO policy_documents (
-			id, document_name, platform, is_mandatory, effective_timestamp, content_html, file_url, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, document_name, platform, is_mandatory, effective_timestamp, content_html, file_url, created_at, created_by`
-	// 3. Execute query with QueryRow
-	var doc domain.PolicyDocument
-	// 4. Scan result into PolicyDocument struct
-	err := r.db.QueryRow(ctx, query,
+			id, document_name, platform, is_mandatory, effective_timestamp, content_html, file_url, created_by,
+			status, submitted_by, submitted_at, file_hash, locale, consent_validity_period)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''), $13, $14)
+		RETURNING ` + documentColumns
+	// 3. Execute query with QueryRow and scan result
+	doc, err := scanDocument(tx.QueryRow(ctx, query,
 		id,
 		params.DocumentName,
 		params.Platform,
@@ -46,35 +138,128 @@ func (r *postgresDocumentRepository) Create(ctx context.Context, params domain.C
 		params.ContentHTML,
 		params.FileURL,
 		params.CreatedBy,
-	).Scan(
-		&doc.ID,
-		&doc.DocumentName,
-		&doc.Platform,
-		&doc.IsMandatory,
-		&doc.EffectiveTimestamp,
-		&doc.ContentHTML,
-		&doc.FileURL,
-		&doc.CreatedAt,
-		&doc.CreatedBy,
-	)
-	// 5. Handle errors properly
+		params.Status,
+		submittedBy,
+		submittedAt,
+		params.FileHash,
+		params.Locale,
+		params.ConsentValidityPeriod,
+	))
+	// 4. Handle errors properly
 	if err != nil {
 		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
-	return &doc, nil
+
+	// 5. Lưu bản dịch của version
+	for _, t := range params.Translations {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO policy_document_translations (document_id, locale, content_html, file_hash)
+			VALUES ($1, $2, $3, NULLIF($4, ''))`,
+			id, t.Locale, t.ContentHTML, t.FileHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create translation %s: %w", t.Locale, err)
+		}
+	}
+
+	// 6. Lưu purposes của version
+	for i, p := range params.Purposes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO policy_document_purposes (document_id, purpose_key, name, description, is_required, sort_order)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			id, p.Key, p.Name, p.Description, p.IsRequired, i,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create purpose %s: %w", p.Key, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return doc, nil
+}
+
+func (r *postgresDocumentRepository) GetTranslations(ctx context.Context, documentIDs []string) (map[string][]*domain.PolicyTranslation, error) {
+	result := make(map[string][]*domain.PolicyTranslation, len(documentIDs))
+	if len(documentIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT document_id, locale, content_html, file_hash, created_at
+		FROM policy_document_translations
+		WHERE document_id = ANY($1::uuid[])
+		ORDER BY document_id, locale
+	`
+
+	rows, err := r.db.Query(ctx, query, documentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t domain.PolicyTranslation
+		if err := rows.Scan(&t.DocumentID, &t.Locale, &t.ContentHTML, &t.FileHash, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		}
+		result[t.DocumentID] = append(result[t.DocumentID], &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *postgresDocumentRepository) GetPurposes(ctx context.Context, documentIDs []string) (map[string][]*domain.PolicyPurpose, error) {
+	result := make(map[string][]*domain.PolicyPurpose, len(documentIDs))
+	if len(documentIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT document_id, purpose_key, name, description, is_required
+		FROM policy_document_purposes
+		WHERE document_id = ANY($1::uuid[])
+		ORDER BY document_id, sort_order
+	`
+
+	rows, err := r.db.Query(ctx, query, documentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purposes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p domain.PolicyPurpose
+		if err := rows.Scan(&p.DocumentID, &p.Key, &p.Name, &p.Description, &p.IsRequired); err != nil {
+			return nil, fmt.Errorf("failed to scan purpose: %w", err)
+		}
+		result[p.DocumentID] = append(result[p.DocumentID], &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return result, nil
 }
 
 func (r *postgresDocumentRepository) GetLatest(ctx context.Context, platform, documentName string) (*domain.PolicyDocument, error) {
 	// 1. Write SELECT query with ORDER BY effective_timestamp DESC LIMIT 1
 	// 2. Add WHERE clause for platform and optionally document_name
+	// 3. Chỉ lấy version đã duyệt (scheduled/published) và đã tới effective_timestamp
 	var query string
 	var args []interface{}
 
 	if documentName != "" {
 		query = `
-			SELECT id, document_name, platform, is_mandatory, effective_timestamp, content_html, file_url, created_at, created_by
+			SELECT ` + documentColumns + `
 			FROM policy_documents
 			WHERE platform = $1 AND document_name = $2
+			  AND status IN ('scheduled', 'published')
+			  AND effective_timestamp <= EXTRACT(EPOCH FROM NOW())::BIGINT
 			ORDER BY effective_timestamp DESC
 			LIMIT 1
 		`
@@ -82,29 +267,20 @@ func (r *postgresDocumentRepository) GetLatest(ctx context.Context, platform, do
 	} else {
 		// Get any latest policy for platform (for registration auto-consent)
 		query = `
-			SELECT id, document_name, platform, is_mandatory, effective_timestamp, content_html, file_url, created_at, created_by
+			SELECT ` + documentColumns + `
 			FROM policy_documents
 			WHERE platform = $1
+			  AND status IN ('scheduled', 'published')
+			  AND effective_timestamp <= EXTRACT(EPOCH FROM NOW())::BIGINT
 			ORDER BY effective_timestamp DESC
 			LIMIT 1
 		`
 		args = []interface{}{platform}
 	}
 
-	// 3. Execute query
-	var doc domain.PolicyDocument
-	err := r.db.QueryRow(ctx, query, args...).Scan(
-		&doc.ID,
-		&doc.DocumentName,
-		&doc.Platform,
-		&doc.IsMandatory,
-		&doc.EffectiveTimestamp,
-		&doc.ContentHTML,
-		&doc.FileURL,
-		&doc.CreatedAt,
-		&doc.CreatedBy,
-	)
-	// 4. Handle pgx.ErrNoRows case
+	// 4. Execute query
+	doc, err := scanDocument(r.db.QueryRow(ctx, query, args...))
+	// 5. Handle pgx.ErrNoRows case
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
@@ -113,13 +289,13 @@ func (r *postgresDocumentRepository) GetLatest(ctx context.Context, platform, do
 		return nil, fmt.Errorf("failed to get latest document: %w", err)
 	}
 
-	return &doc, nil
+	return doc, nil
 }
 
 func (r *postgresDocumentRepository) GetHistory(ctx context.Context, platform, documentName string) ([]*domain.PolicyDocument, error) {
-	// SQL Query: Lấy tất cả versions, sắp xếp theo timestamp giảm dần
+	// SQL Query: Lấy tất cả versions (kể cả draft/scheduled), sắp xếp theo timestamp giảm dần
 	query := `
-		SELECT id, document_name, platform, is_mandatory, effective_timestamp, content_html, file_url, created_at, created_by
+		SELECT ` + documentColumns + `
 		FROM policy_documents
 		WHERE platform = $1 AND document_name = $2
 		ORDER BY effective_timestamp DESC
@@ -130,33 +306,232 @@ func (r *postgresDocumentRepository) GetHistory(ctx context.Context, platform, d
 	if err != nil {
 		return nil, fmt.Errorf("failed to get document history: %w", err)
 	}
-	defer rows.Close() // CRITICAL: Always close rows
 
-	// Scan results vào slice
-	var documents []*domain.PolicyDocument
-	for rows.Next() {
-		var doc domain.PolicyDocument
-		err := rows.Scan(
-			&doc.ID,
-			&doc.DocumentName,
-			&doc.Platform,
-			&doc.IsMandatory,
-			&doc.EffectiveTimestamp,
-			&doc.ContentHTML,
-			&doc.FileURL,
-			&doc.CreatedAt,
-			&doc.CreatedBy,
-		)
+	return scanDocuments(rows)
+}
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-		documents = append(documents, &doc)
+func (r *postgresDocumentRepository) ListActive(ctx context.Context, platform string) ([]*domain.PolicyDocument, error) {
+	// DISTINCT ON lấy 1 row cho mỗi document_name - row có effective_timestamp lớn nhất
+	// Cùng điều kiện với GetLatest: chỉ version đã duyệt và đã tới thời điểm hiệu lực
+	query := `
+		SELECT DISTINCT ON (document_name) ` + documentColumns + `
+		FROM policy_documents
+		WHERE platform = $1
+		  AND status IN ('scheduled', 'published')
+		  AND effective_timestamp <= EXTRACT(EPOCH FROM NOW())::BIGINT
+		ORDER BY document_name, effective_timestamp DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active documents: %w", err)
 	}
 
-	// Check for errors from iterating
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %w", err)
+	return scanDocuments(rows)
+}
+
+func (r *postgresDocumentRepository) GetByID(ctx context.Context, id string) (*domain.PolicyDocument, error) {
+	query := `
+		SELECT ` + documentColumns + `
+		FROM policy_documents
+		WHERE id = $1
+	`
+
+	doc, err := scanDocument(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
 	}
-	return documents, nil
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document by id: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (r *postgresDocumentRepository) UpdateStatus(ctx context.Context, id, status string, effectiveTimestamp int64, actor string) (*domain.PolicyDocument, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// published_at chỉ có giá trị khi version thực sự được phát hành
+	query := `
+		UPDATE policy_documents
+		SET status = $2,
+		    effective_timestamp = $3,
+		    published_by = $4,
+		    published_at = CASE WHEN $2::varchar = 'published' THEN NOW() END
+		WHERE id = $1
+		RETURNING ` + documentColumns
+
+	doc, err := scanDocument(tx.QueryRow(ctx, query, id, status, effectiveTimestamp, actor))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update document status: %w", err)
+	}
+
+	if err := enqueuePublished(ctx, tx, doc); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (r *postgresDocumentRepository) ListUpcoming(ctx context.Context, platform, documentName string) ([]*domain.PolicyDocument, error) {
+	// document_name rỗng = lấy tất cả document của platform
+	query := `
+		SELECT ` + documentColumns + `
+		FROM policy_documents
+		WHERE platform = $1
+		  AND ($2 = '' OR document_name = $2)
+		  AND (status IN ('draft', 'pending_review')
+		       OR (status = 'scheduled' AND effective_timestamp > EXTRACT(EPOCH FROM NOW())::BIGINT))
+		ORDER BY effective_timestamp ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, platform, documentName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming documents: %w", err)
+	}
+
+	return scanDocuments(rows)
+}
+
+func (r *postgresDocumentRepository) PublishDueScheduled(ctx context.Context) ([]*domain.PolicyDocument, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// published_at = thời điểm hiệu lực đã lên lịch, không phải thời điểm job chạy
+	query := `
+		UPDATE policy_documents
+		SET status = 'published',
+		    published_at = TO_TIMESTAMP(effective_timestamp)
+		WHERE status = 'scheduled'
+		  AND effective_timestamp <= EXTRACT(EPOCH FROM NOW())::BIGINT
+		RETURNING ` + documentColumns
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish scheduled documents: %w", err)
+	}
+
+	docs, err := scanDocuments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := enqueuePublished(ctx, tx, docs...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return docs, nil
+}
+
+func (r *postgresDocumentRepository) SubmitForReview(ctx context.Context, id, submittedBy string) (*domain.PolicyDocument, error) {
+	// Guard status trong WHERE để tránh race giữa 2 admin
+	query := `
+		UPDATE policy_documents
+		SET status = 'pending_review',
+		    submitted_by = $2,
+		    submitted_at = NOW()
+		WHERE id = $1 AND status = 'draft'
+		RETURNING ` + documentColumns
+
+	doc, err := scanDocument(r.db.QueryRow(ctx, query, id, submittedBy))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit document for review: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (r *postgresDocumentRepository) Review(ctx context.Context, id, status string, effectiveTimestamp int64, reviewedBy, comment string) (*domain.PolicyDocument, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Approve: status = scheduled/published, reviewer cũng là người phát hành
+	// Reject: status = rejected, giữ nguyên thông tin phát hành (rỗng)
+	// $2/$4 vừa là giá trị gán vừa nằm trong CASE nên cast rõ kiểu, không để Postgres tự suy ra
+	query := `
+		UPDATE policy_documents
+		SET status = $2,
+		    effective_timestamp = $3,
+		    reviewed_by = $4,
+		    reviewed_at = NOW(),
+		    review_comment = NULLIF($5, ''),
+		    published_by = CASE WHEN $2::varchar IN ('scheduled', 'published') THEN $4::varchar ELSE published_by END,
+		    published_at = CASE WHEN $2::varchar = 'published' THEN NOW() ELSE published_at END
+		WHERE id = $1 AND status = 'pending_review'
+		RETURNING ` + documentColumns
+
+	doc, err := scanDocument(tx.QueryRow(ctx, query, id, status, effectiveTimestamp, reviewedBy, comment))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to review document: %w", err)
+	}
+
+	if err := enqueuePublished(ctx, tx, doc); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return doc, nil
+}
+
+// enqueuePublished ghi PolicyPublished vào outbox cho các version vừa chuyển sang published.
+// Version scheduled chỉ phát event khi job PublishDueScheduled chuyển status.
+func enqueuePublished(ctx context.Context, tx pgx.Tx, docs ...*domain.PolicyDocument) error {
+	for _, doc := range docs {
+		if doc.Status != domain.StatusPublished {
+			continue
+		}
+
+		payload := outbox.PolicyPublished{
+			DocumentID:         doc.ID,
+			Platform:           doc.Platform,
+			DocumentName:       doc.DocumentName,
+			IsMandatory:        doc.IsMandatory,
+			EffectiveTimestamp: doc.EffectiveTimestamp,
+		}
+		if doc.PublishedAt != nil {
+			payload.PublishedAt = doc.PublishedAt.Unix()
+		}
+		if doc.PublishedBy != nil {
+			payload.PublishedBy = *doc.PublishedBy
+		}
+
+		event, err := outbox.NewEvent("document", outbox.EventPolicyPublished, outbox.AggregatePolicy, doc.ID, payload)
+		if err != nil {
+			return err
+		}
+		if err := outboxstore.Enqueue(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/document/internal/domain"
+)
+
+// testDB tạo schema riêng trên TEST_DATABASE_URL và chạy migrations của service vào đó.
+// Bỏ qua test khi không có Postgres (go test ./... chạy được không cần DB)
+func testDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	ctx := context.Background()
+
+	admin, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := fmt.Sprintf("document_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close()
+	})
+
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema + ",public"
+	db, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+
+	files, err := filepath.Glob("../../migrations/*.up.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		sql, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Không có argument → simple protocol, chạy được nhiều statement
+		if _, err := db.Exec(ctx, string(sql)); err != nil {
+			t.Fatalf("migration %s: %v", filepath.Base(f), err)
+		}
+	}
+	return db
+}
+
+func TestDocumentRepositoryCreate(t *testing.T) {
+	db := testDB(t)
+	repo := NewPostgresDocumentRepository(db)
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		status        string
+		wantSubmitted bool
+	}{
+		{"Draft", domain.StatusDraft, false},
+		{"Pending review", domain.StatusPendingReview, true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := repo.Create(ctx, domain.CreateDocumentParams{
+				DocumentName:       "Terms of Service",
+				Platform:           "Client",
+				IsMandatory:        true,
+				EffectiveTimestamp: time.Now().Add(time.Duration(i+1) * time.Hour).Unix(),
+				ContentHTML:        "<p>Terms</p>",
+				CreatedBy:          "admin-1",
+				Status:             tt.status,
+				Locale:             "vi",
+				Translations:       []domain.TranslationParams{{Locale: "en", ContentHTML: "<p>Terms</p>"}},
+				Purposes:           []domain.PurposeParams{{Key: "marketing", Name: "Marketing"}},
+			})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if doc.Status != tt.status {
+				t.Errorf("Create() status = %q, want %q", doc.Status, tt.status)
+			}
+			if gotSubmitted := doc.SubmittedBy != nil && doc.SubmittedAt != nil; gotSubmitted != tt.wantSubmitted {
+				t.Errorf("Create() submitted_by = %v, submitted_at = %v, want set %v", doc.SubmittedBy, doc.SubmittedAt, tt.wantSubmitted)
+			}
+			if tt.wantSubmitted && *doc.SubmittedBy != "admin-1" {
+				t.Errorf("Create() submitted_by = %q, want %q", *doc.SubmittedBy, "admin-1")
+			}
+		})
+	}
+}
+
+func TestDocumentRepositoryUpdateStatus(t *testing.T) {
+	db := testDB(t)
+	repo := NewPostgresDocumentRepository(db)
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		status        string
+		wantPublished bool
+	}{
+		{"Publish now", domain.StatusPublished, true},
+		{"Schedule", domain.StatusScheduled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draft, err := repo.Create(ctx, domain.CreateDocumentParams{
+				DocumentName:       "Cookie Policy " + tt.status,
+				Platform:           "Client",
+				EffectiveTimestamp: time.Now().Unix(),
+				ContentHTML:        "<p>Cookies</p>",
+				CreatedBy:          "admin-1",
+				Status:             domain.StatusDraft,
+				Locale:             "vi",
+			})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			doc, err := repo.UpdateStatus(ctx, draft.ID, tt.status, time.Now().Add(time.Hour).Unix(), "admin-1")
+			if err != nil || doc == nil {
+				t.Fatalf("UpdateStatus() = %+v, %v", doc, err)
+			}
+			if doc.Status != tt.status || (doc.PublishedAt != nil) != tt.wantPublished {
+				t.Errorf("UpdateStatus() status = %q, published_at = %v, want %q (published %v)", doc.Status, doc.PublishedAt, tt.status, tt.wantPublished)
+			}
+		})
+	}
+}
+
+func TestDocumentRepositoryReviewFlow(t *testing.T) {
+	db := testDB(t)
+	repo := NewPostgresDocumentRepository(db)
+	ctx := context.Background()
+
+	newDraft := func(t *testing.T, name string) *domain.PolicyDocument {
+		t.Helper()
+		doc, err := repo.Create(ctx, domain.CreateDocumentParams{
+			DocumentName:       name,
+			Platform:           "Client",
+			EffectiveTimestamp: time.Now().Unix(),
+			ContentHTML:        "<p>Policy</p>",
+			CreatedBy:          "admin-1",
+			Status:             domain.StatusDraft,
+			Locale:             "vi",
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return doc
+	}
+
+	tests := []struct {
+		name          string
+		reviewStatus  string
+		wantPublished bool
+	}{
+		{"Approve and publish", domain.StatusPublished, true},
+		{"Approve and schedule", domain.StatusScheduled, false},
+		{"Reject", domain.StatusRejected, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draft := newDraft(t, "Privacy Policy "+tt.reviewStatus)
+
+			submitted, err := repo.SubmitForReview(ctx, draft.ID, "admin-1")
+			if err != nil || submitted == nil || submitted.Status != domain.StatusPendingReview {
+				t.Fatalf("SubmitForReview() = %+v, %v", submitted, err)
+			}
+
+			reviewed, err := repo.Review(ctx, draft.ID, tt.reviewStatus, time.Now().Add(time.Hour).Unix(), "admin-2", "checked")
+			if err != nil || reviewed == nil {
+				t.Fatalf("Review() = %+v, %v", reviewed, err)
+			}
+			if reviewed.Status != tt.reviewStatus || reviewed.ReviewedBy == nil || *reviewed.ReviewedBy != "admin-2" {
+				t.Errorf("Review() status = %q, reviewed_by = %v", reviewed.Status, reviewed.ReviewedBy)
+			}
+			if (reviewed.PublishedAt != nil) != tt.wantPublished {
+				t.Errorf("Review() published_at = %v, want set %v", reviewed.PublishedAt, tt.wantPublished)
+			}
+
+			// Version đã duyệt không gửi duyệt lại được
+			again, err := repo.Review(ctx, draft.ID, tt.reviewStatus, time.Now().Unix(), "admin-2", "")
+			if err != nil || again != nil {
+				t.Errorf("Review() twice = %+v, %v, want nil", again, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/document/internal/domain"
+)
+
+// FileRepository stores metadata of uploaded files (bytes live in the storage backend)
+type FileRepository interface {
+	// Create lưu metadata; file cùng content_hash đã tồn tại thì trả về bản ghi cũ
+	Create(ctx context.Context, blob *domain.FileBlob) (*domain.FileBlob, error)
+	GetByHash(ctx context.Context, contentHash string) (*domain.FileBlob, error)
+}
+
+type postgresFileRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresFileRepository(db *pgxpool.Pool) FileRepository {
+	return &postgresFileRepository{db: db}
+}
+
+func (r *postgresFileRepository) Create(ctx context.Context, blob *domain.FileBlob) (*domain.FileBlob, error) {
+	// DO UPDATE no-op để RETURNING luôn trả về row (kể cả khi đã tồn tại)
+	query := `
+		INSERT INTO file_blobs (content_hash, content_type, size_bytes, filename, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (content_hash) DO UPDATE SET content_hash = EXCLUDED.content_hash
+		RETURNING content_hash, content_type, size_bytes, filename, uploaded_by, created_at`
+
+	var saved domain.FileBlob
+	err := r.db.QueryRow(ctx, query,
+		blob.ContentHash,
+		blob.ContentType,
+		blob.SizeBytes,
+		blob.Filename,
+		blob.UploadedBy,
+	).Scan(
+		&saved.ContentHash,
+		&saved.ContentType,
+		&saved.SizeBytes,
+		&saved.Filename,
+		&saved.UploadedBy,
+		&saved.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file blob: %w", err)
+	}
+	return &saved, nil
+}
+
+func (r *postgresFileRepository) GetByHash(ctx context.Context, contentHash string) (*domain.FileBlob, error) {
+	query := `
+		SELECT content_hash, content_type, size_bytes, filename, uploaded_by, created_at
+		FROM file_blobs
+		WHERE content_hash = $1`
+
+	var blob domain.FileBlob
+	err := r.db.QueryRow(ctx, query, contentHash).Scan(
+		&blob.ContentHash,
+		&blob.ContentType,
+		&blob.SizeBytes,
+		&blob.Filename,
+		&blob.UploadedBy,
+		&blob.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file blob: %w", err)
+	}
+	return &blob, nil
+}
@@ -2,55 +2,124 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/url"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/thatlq1812/policy-system/document/internal/diff"
 	"github.com/thatlq1812/policy-system/document/internal/domain"
 	"github.com/thatlq1812/policy-system/document/internal/repository"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
 )
 
 // DocumentService defines business operatons for policy documents
 type DocumentService interface {
 	CreatePolicy(ctx context.Context, params domain.CreateDocumentParams) (*domain.PolicyDocument, error)
-	GetLatestPolicy(ctx context.Context, platform, documentName string) (*domain.PolicyDocument, error)
+	// GetLatestPolicy trả về nội dung theo locale (fallback về nội dung gốc nếu chưa có bản dịch)
+	GetLatestPolicy(ctx context.Context, platform, documentName, locale string) (*domain.PolicyDocument, error)
 	// Update new method for UpdatePolicy
 	UpdatePolicy(ctx context.Context, params domain.CreateDocumentParams) (*domain.PolicyDocument, error)
 
 	// Update new method for GetPolicyHistory
 	GetPolicyHistory(ctx context.Context, platform, documentName string) ([]*domain.PolicyDocument, error)
+
+	// ComparePolicyVersions so sánh content_html của 2 version (chọn theo effective_timestamp trong history)
+	ComparePolicyVersions(ctx context.Context, params domain.ComparePoliciesParams) (*domain.PolicyComparison, error)
+
+	// ListActivePolicies trả về version đang hiệu lực của mọi document trên platform
+	ListActivePolicies(ctx context.Context, platform, locale string) ([]*domain.PolicyDocument, error)
+
+	// SubmitForReview gửi một version draft cho admin khác duyệt
+	SubmitForReview(ctx context.Context, documentID, submittedBy string) (*domain.PolicyDocument, error)
+
+	// ApprovePolicy duyệt version pending_review (reviewer phải khác người tạo/gửi duyệt)
+	ApprovePolicy(ctx context.Context, documentID, reviewedBy, comment string) (*domain.PolicyDocument, error)
+
+	// RejectPolicy từ chối version pending_review, comment bắt buộc
+	RejectPolicy(ctx context.Context, documentID, reviewedBy, comment string) (*domain.PolicyDocument, error)
+
+	// SchedulePolicy đổi lịch phát hành cho version đã duyệt (scheduled)
+	SchedulePolicy(ctx context.Context, documentID string, effectiveTimestamp int64, scheduledBy string) (*domain.PolicyDocument, error)
+
+	// PublishPolicy phát hành ngay một version đã duyệt (effective_timestamp = now)
+	PublishPolicy(ctx context.Context, documentID, publishedBy string) (*domain.PolicyDocument, error)
+
+	// ListUpcomingPolicies liệt kê các version chưa có hiệu lực (draft, pending_review, scheduled)
+	ListUpcomingPolicies(ctx context.Context, platform, documentName string) ([]*domain.PolicyDocument, error)
+
+	// PublishDuePolicies chuyển các version scheduled đã tới hạn sang published (chạy định kỳ)
+	PublishDuePolicies(ctx context.Context) ([]*domain.PolicyDocument, error)
 }
 
 // documentService implements DocumentService
 type documentService struct {
-	repo repository.DocumentRepository
+	repo     repository.DocumentRepository
+	files    FileService // Kiểm tra file_hash tham chiếu tới file đã upload
+	auditLog *audit.Logger
 }
 
 // NewDocumentService creates a new service instance
-func NewDocumentService(repo repository.DocumentRepository) DocumentService {
-	return &documentService{repo: repo}
+func NewDocumentService(repo repository.DocumentRepository, files FileService, auditLog *audit.Logger) DocumentService {
+	return &documentService{repo: repo, files: files, auditLog: auditLog}
 }
 
+// Audit actions của Document Service
+const (
+	auditActionCreate   = "policy.create"
+	auditActionUpdate   = "policy.update"
+	auditActionSubmit   = "policy.submit_for_review"
+	auditActionApprove  = "policy.approve"
+	auditActionReject   = "policy.reject"
+	auditActionSchedule = "policy.schedule"
+	auditActionPublish  = "policy.publish"
+	auditActionUpload   = "file.upload"
+
+	// auditActorScheduler là actor của các version được publish tự động khi tới hạn
+	auditActorScheduler = "system:scheduler"
+)
+
 // CreatePolicy creates a new policy document with validation
 func (s *documentService) CreatePolicy(ctx context.Context, params domain.CreateDocumentParams) (*domain.PolicyDocument, error) {
 	// Validate input
 	if err := s.validateCreateParams(params); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if err := s.checkFileHash(ctx, params.FileHash); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.normalizeLocales(ctx, &params); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := validatePurposes(params.Purposes); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Xác định trạng thái ban đầu: draft hoặc chờ duyệt
+	status, err := resolveInitialStatus(params.Status)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	params.Status = status
 
 	// Call repository
 	doc, err := s.repo.Create(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("service: failed to create policy: %w", err)
 	}
+	doc.AvailableLocales = params.AvailableLocales()
+	doc.Purposes = params.PolicyPurposes(doc.ID)
+
+	s.recordAudit(ctx, auditActionCreate, nil, doc, "")
 
 	return doc, nil
 }
 
 // GetLatestPolicy retrieves the most recent policy version
-func (s *documentService) GetLatestPolicy(ctx context.Context, platform, documentName string) (*domain.PolicyDocument, error) {
+func (s *documentService) GetLatestPolicy(ctx context.Context, platform, documentName, locale string) (*domain.PolicyDocument, error) {
 	// Validate input
 	if platform == "" {
 		return nil, fmt.Errorf("platform is required")
@@ -62,6 +131,13 @@ func (s *documentService) GetLatestPolicy(ctx context.Context, platform, documen
 	if err != nil {
 		return nil, fmt.Errorf("service: failed to get latest policy: %w", err)
 	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	if err := s.localize(ctx, []*domain.PolicyDocument{doc}, locale); err != nil {
+		return nil, err
+	}
 
 	return doc, nil
 }
@@ -75,20 +151,33 @@ func (s *documentService) UpdatePolicy(ctx context.Context, params domain.Create
 	if params.Platform != "Client" && params.Platform != "Merchant" && params.Platform != "Admin" {
 		return nil, fmt.Errorf("validation failed: platform must be one of: 'Client', 'Merchant', or 'Admin'")
 	}
-	if params.ContentHTML == "" && params.FileURL == "" {
-		return nil, fmt.Errorf("validation failed: either content_html or file_url must be provided")
+	if params.ContentHTML == "" && params.FileURL == "" && params.FileHash == "" {
+		return nil, fmt.Errorf("validation failed: either content_html, file_url or file_hash must be provided")
 	}
 	if params.CreatedBy == "" {
 		return nil, fmt.Errorf("validation failed: created_by is required")
 	}
+	if err := validateConsentValidityPeriod(params.ConsentValidityPeriod); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.checkFileHash(ctx, params.FileHash); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.normalizeLocales(ctx, &params); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := validatePurposes(params.Purposes); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
 
 	// Step 2: Check document cũ có tồn tại không
-	existingDoc, err := s.repo.GetLatest(ctx, params.Platform, params.DocumentName)
+	// Dùng history thay vì GetLatest vì document có thể chỉ mới có version draft/scheduled
+	existingDocs, err := s.repo.GetHistory(ctx, params.Platform, params.DocumentName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing document: %w", err)
 	}
 
-	if existingDoc == nil {
+	if len(existingDocs) == 0 {
 		return nil, fmt.Errorf("document not found: cannot update non-existent document")
 	}
 
@@ -98,12 +187,24 @@ func (s *documentService) UpdatePolicy(ctx context.Context, params domain.Create
 		params.EffectiveTimestamp = time.Now().Unix()
 	}
 
+	// Step 3b: Version mới luôn phải qua review, không trở thành latest cho tới khi được duyệt
+	status, err := resolveInitialStatus(params.Status)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	params.Status = status
+
 	// Step 4: Tạo record MỚI bằng cách gọi repo.Create()
 	// Đây là KEY POINT: Update = Insert record mới
 	newDoc, err := s.repo.Create(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new version: %w", err)
 	}
+	newDoc.AvailableLocales = params.AvailableLocales()
+	newDoc.Purposes = params.PolicyPurposes(newDoc.ID)
+
+	// Version mới là row mới: before = version mới nhất trước đó của document
+	s.recordAudit(ctx, auditActionUpdate, existingDocs[0], newDoc, "")
 
 	// Step 5: Return document mới
 	return newDoc, nil
@@ -130,9 +231,431 @@ func (s *documentService) GetPolicyHistory(ctx context.Context, platform, docume
 		return nil, fmt.Errorf("service: failed to get policy history: %w", err)
 	}
 
+	// History trả nội dung gốc, chỉ điền danh sách locale
+	if err := s.localize(ctx, documents, ""); err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// diffContextLines - số dòng giữ quanh mỗi thay đổi trong text diff
+const diffContextLines = 3
+
+// ComparePolicyVersions diffs content_html between two versions of the same document
+func (s *documentService) ComparePolicyVersions(ctx context.Context, params domain.ComparePoliciesParams) (*domain.PolicyComparison, error) {
+	// Step 1: Validate input
+	if !domain.IsValidPlatform(params.Platform) {
+		return nil, fmt.Errorf("%w: platform must be one of: 'Client', 'Merchant', or 'Admin'", domain.ErrInvalidInput)
+	}
+	if params.DocumentName == "" {
+		return nil, fmt.Errorf("%w: document_name is required", domain.ErrInvalidInput)
+	}
+	if params.FromTimestamp <= 0 || params.ToTimestamp <= 0 {
+		return nil, fmt.Errorf("%w: from_timestamp and to_timestamp are required", domain.ErrInvalidInput)
+	}
+	if params.FromTimestamp == params.ToTimestamp {
+		return nil, fmt.Errorf("%w: from_timestamp and to_timestamp must be different", domain.ErrInvalidInput)
+	}
+
+	// Step 2: Tìm 2 version trong history
+	history, err := s.repo.GetHistory(ctx, params.Platform, params.DocumentName)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to get policy history: %w", err)
+	}
+
+	from := pickVersion(history, params.FromTimestamp, params.IncludeUnpublished)
+	if from == nil {
+		return nil, fmt.Errorf("%w: no version of %s/%s with effective_timestamp %d",
+			domain.ErrNotFound, params.Platform, params.DocumentName, params.FromTimestamp)
+	}
+	to := pickVersion(history, params.ToTimestamp, params.IncludeUnpublished)
+	if to == nil {
+		return nil, fmt.Errorf("%w: no version of %s/%s with effective_timestamp %d",
+			domain.ErrNotFound, params.Platform, params.DocumentName, params.ToTimestamp)
+	}
+
+	// Cùng locale cho cả 2 version (version nào thiếu bản dịch thì dùng nội dung gốc)
+	if err := s.localize(ctx, []*domain.PolicyDocument{from, to}, params.Locale); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Diff theo từ trên HTML và theo dòng trên plain text
+	segs := diff.CompareHTML(from.ContentHTML, to.ContentHTML)
+	stats := diff.Count(segs)
+
+	segments := make([]domain.DiffSegment, len(segs))
+	for i, seg := range segs {
+		segments[i] = domain.DiffSegment{Op: string(seg.Op), Text: seg.Text}
+	}
+
+	textDiff := diff.Unified(
+		fmt.Sprintf("%s@%d", params.DocumentName, from.EffectiveTimestamp),
+		fmt.Sprintf("%s@%d", params.DocumentName, to.EffectiveTimestamp),
+		diff.ToText(from.ContentHTML),
+		diff.ToText(to.ContentHTML),
+		diffContextLines,
+	)
+
+	return &domain.PolicyComparison{
+		From:       from,
+		To:         to,
+		Segments:   segments,
+		HTMLDiff:   diff.RenderHTML(segs),
+		TextDiff:   textDiff,
+		Insertions: stats.Insertions,
+		Deletions:  stats.Deletions,
+	}, nil
+}
+
+// pickVersion chọn version có effective_timestamp = ts.
+// Mặc định chỉ xét version đã duyệt (scheduled/published); nếu nhiều version trùng timestamp
+// thì ưu tiên published, sau đó tới version tạo sau cùng.
+func pickVersion(history []*domain.PolicyDocument, ts int64, includeUnpublished bool) *domain.PolicyDocument {
+	rank := func(doc *domain.PolicyDocument) int {
+		switch doc.Status {
+		case domain.StatusPublished:
+			return 2
+		case domain.StatusScheduled:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	var picked *domain.PolicyDocument
+	for _, doc := range history {
+		if doc.EffectiveTimestamp != ts {
+			continue
+		}
+		if !includeUnpublished && rank(doc) == 0 {
+			continue
+		}
+		if picked == nil || rank(doc) > rank(picked) ||
+			(rank(doc) == rank(picked) && doc.CreatedAt.After(picked.CreatedAt)) {
+			picked = doc
+		}
+	}
+	return picked
+}
+
+// ListActivePolicies returns the currently effective version of every document on a platform
+func (s *documentService) ListActivePolicies(ctx context.Context, platform, locale string) ([]*domain.PolicyDocument, error) {
+	if !domain.IsValidPlatform(platform) {
+		return nil, fmt.Errorf("%w: platform must be one of: 'Client', 'Merchant', or 'Admin'", domain.ErrInvalidInput)
+	}
+
+	documents, err := s.repo.ListActive(ctx, platform)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to list active policies: %w", err)
+	}
+
+	if err := s.localize(ctx, documents, locale); err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// SubmitForReview moves a draft version to pending_review
+func (s *documentService) SubmitForReview(ctx context.Context, documentID, submittedBy string) (*domain.PolicyDocument, error) {
+	if documentID == "" {
+		return nil, fmt.Errorf("%w: document_id is required", domain.ErrInvalidInput)
+	}
+	if submittedBy == "" {
+		return nil, fmt.Errorf("%w: submitted_by is required", domain.ErrInvalidInput)
+	}
+
+	doc, err := s.getWithStatus(ctx, documentID, domain.StatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.SubmitForReview(ctx, doc.ID, submittedBy)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to submit policy for review: %w", err)
+	}
+	if updated == nil {
+		// Status đã bị đổi bởi request khác giữa lúc đọc và lúc update
+		return nil, fmt.Errorf("%w: version %s is no longer a draft", domain.ErrVersionConflict, documentID)
+	}
+
+	s.recordAudit(ctx, auditActionSubmit, doc, updated, "")
+
+	return updated, nil
+}
+
+// ApprovePolicy approves a pending version. The version becomes scheduled when its
+// effective time is in the future, otherwise it is published immediately.
+func (s *documentService) ApprovePolicy(ctx context.Context, documentID, reviewedBy, comment string) (*domain.PolicyDocument, error) {
+	// Step 1: Validate input
+	if documentID == "" {
+		return nil, fmt.Errorf("%w: document_id is required", domain.ErrInvalidInput)
+	}
+	if reviewedBy == "" {
+		return nil, fmt.Errorf("%w: reviewed_by is required", domain.ErrInvalidInput)
+	}
+
+	// Step 2: Load version đang chờ duyệt
+	doc, err := s.getWithStatus(ctx, documentID, domain.StatusPendingReview)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Four-eyes principle - người tạo/gửi duyệt không được tự duyệt
+	if err := checkReviewer(doc, reviewedBy); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Quyết định scheduled/published theo effective_timestamp
+	// Nếu thời điểm hiệu lực đã qua trong lúc chờ duyệt thì phát hành với timestamp = now
+	// để version này thực sự trở thành latest
+	status := domain.StatusScheduled
+	effectiveTimestamp := doc.EffectiveTimestamp
+	if now := time.Now().Unix(); effectiveTimestamp <= now {
+		status = domain.StatusPublished
+		effectiveTimestamp = now
+	}
+
+	updated, err := s.repo.Review(ctx, doc.ID, status, effectiveTimestamp, reviewedBy, comment)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to approve policy: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: version %s is no longer pending review", domain.ErrVersionConflict, documentID)
+	}
+
+	s.recordAudit(ctx, auditActionApprove, doc, updated, comment)
+
+	return updated, nil
+}
+
+// RejectPolicy rejects a pending version with a mandatory comment
+func (s *documentService) RejectPolicy(ctx context.Context, documentID, reviewedBy, comment string) (*domain.PolicyDocument, error) {
+	// Step 1: Validate input
+	if documentID == "" {
+		return nil, fmt.Errorf("%w: document_id is required", domain.ErrInvalidInput)
+	}
+	if reviewedBy == "" {
+		return nil, fmt.Errorf("%w: reviewed_by is required", domain.ErrInvalidInput)
+	}
+	if strings.TrimSpace(comment) == "" {
+		return nil, fmt.Errorf("%w: comment is required when rejecting a policy", domain.ErrInvalidInput)
+	}
+
+	// Step 2: Load version đang chờ duyệt
+	doc, err := s.getWithStatus(ctx, documentID, domain.StatusPendingReview)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Người gửi duyệt không tự từ chối được - họ chỉ cần tạo version mới
+	if err := checkReviewer(doc, reviewedBy); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Giữ nguyên effective_timestamp
+	updated, err := s.repo.Review(ctx, doc.ID, domain.StatusRejected, doc.EffectiveTimestamp, reviewedBy, comment)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to reject policy: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: version %s is no longer pending review", domain.ErrVersionConflict, documentID)
+	}
+
+	s.recordAudit(ctx, auditActionReject, doc, updated, comment)
+
+	return updated, nil
+}
+
+// SchedulePolicy re-schedules an approved version for a different future time
+func (s *documentService) SchedulePolicy(ctx context.Context, documentID string, effectiveTimestamp int64, scheduledBy string) (*domain.PolicyDocument, error) {
+	// Step 1: Validate input
+	if documentID == "" {
+		return nil, fmt.Errorf("%w: document_id is required", domain.ErrInvalidInput)
+	}
+	if scheduledBy == "" {
+		return nil, fmt.Errorf("%w: scheduled_by is required", domain.ErrInvalidInput)
+	}
+	if effectiveTimestamp <= time.Now().Unix() {
+		return nil, fmt.Errorf("%w: effective_timestamp must be in the future, use PublishPolicy to publish now", domain.ErrInvalidInput)
+	}
+
+	// Step 2: Load version và check trạng thái hiện tại - chỉ version đã duyệt mới được lên lịch
+	doc, err := s.getWithStatus(ctx, documentID, domain.StatusScheduled)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Update status
+	updated, err := s.repo.UpdateStatus(ctx, doc.ID, domain.StatusScheduled, effectiveTimestamp, scheduledBy)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to schedule policy: %w", err)
+	}
+
+	s.recordAudit(ctx, auditActionSchedule, doc, updated, "")
+
+	return updated, nil
+}
+
+// PublishPolicy publishes an approved (scheduled) version immediately
+func (s *documentService) PublishPolicy(ctx context.Context, documentID, publishedBy string) (*domain.PolicyDocument, error) {
+	// Step 1: Validate input
+	if documentID == "" {
+		return nil, fmt.Errorf("%w: document_id is required", domain.ErrInvalidInput)
+	}
+	if publishedBy == "" {
+		return nil, fmt.Errorf("%w: published_by is required", domain.ErrInvalidInput)
+	}
+
+	// Step 2: Load version và check trạng thái hiện tại - chỉ version đã duyệt mới được phát hành
+	doc, err := s.getWithStatus(ctx, documentID, domain.StatusScheduled)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Phát hành ngay - effective_timestamp = now để version này thành latest
+	updated, err := s.repo.UpdateStatus(ctx, doc.ID, domain.StatusPublished, time.Now().Unix(), publishedBy)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to publish policy: %w", err)
+	}
+
+	s.recordAudit(ctx, auditActionPublish, doc, updated, "")
+
+	return updated, nil
+}
+
+// ListUpcomingPolicies lists drafts, pending and scheduled versions that are not yet effective
+func (s *documentService) ListUpcomingPolicies(ctx context.Context, platform, documentName string) ([]*domain.PolicyDocument, error) {
+	if !domain.IsValidPlatform(platform) {
+		return nil, fmt.Errorf("%w: platform must be one of: 'Client', 'Merchant', or 'Admin'", domain.ErrInvalidInput)
+	}
+
+	documents, err := s.repo.ListUpcoming(ctx, platform, documentName)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to list upcoming policies: %w", err)
+	}
+	if err := s.localize(ctx, documents, ""); err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// PublishDuePolicies marks scheduled versions whose effective time has arrived as published
+func (s *documentService) PublishDuePolicies(ctx context.Context) ([]*domain.PolicyDocument, error) {
+	documents, err := s.repo.PublishDueScheduled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to publish due policies: %w", err)
+	}
+
+	for _, doc := range documents {
+		s.auditLog.Record(ctx, audit.Event{
+			Action:     auditActionPublish,
+			TargetType: "policy",
+			TargetID:   doc.ID,
+			After:      policyAuditView(doc),
+			Reason:     "effective_timestamp reached",
+			ActorID:    auditActorScheduler,
+		})
+	}
+
 	return documents, nil
 }
 
+// recordAudit ghi thay đổi của 1 version vào audit log
+func (s *documentService) recordAudit(ctx context.Context, action string, before, after *domain.PolicyDocument, reason string) {
+	ev := audit.Event{
+		Action:     action,
+		TargetType: "policy",
+		Before:     policyAuditView(before),
+		After:      policyAuditView(after),
+		Reason:     reason,
+	}
+	if after != nil {
+		ev.TargetID = after.ID
+	}
+	s.auditLog.Record(ctx, ev)
+}
+
+// policyAuditView trả về các field của version được ghi vào audit log.
+// Nội dung HTML chỉ ghi SHA-256 để audit log không phình theo kích thước policy.
+func policyAuditView(doc *domain.PolicyDocument) map[string]any {
+	if doc == nil {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(doc.ContentHTML))
+	return map[string]any{
+		"id":                      doc.ID,
+		"document_name":           doc.DocumentName,
+		"platform":                doc.Platform,
+		"is_mandatory":            doc.IsMandatory,
+		"effective_timestamp":     doc.EffectiveTimestamp,
+		"status":                  doc.Status,
+		"locale":                  doc.Locale,
+		"content_html_sha256":     hex.EncodeToString(sum[:]),
+		"file_url":                doc.FileURL,
+		"file_hash":               doc.FileHash,
+		"created_by":              doc.CreatedBy,
+		"submitted_by":            doc.SubmittedBy,
+		"reviewed_by":             doc.ReviewedBy,
+		"review_comment":          doc.ReviewComment,
+		"published_by":            doc.PublishedBy,
+		"consent_validity_period": doc.ConsentValidityPeriod,
+		"purposes":                purposeKeys(doc.Purposes),
+	}
+}
+
+// purposeKeys trả về key của các purpose theo thứ tự khai báo
+func purposeKeys(purposes []*domain.PolicyPurpose) []string {
+	keys := make([]string, len(purposes))
+	for i, p := range purposes {
+		keys[i] = p.Key
+	}
+	return keys
+}
+
+// getWithStatus loads a version and checks it is in the expected status
+func (s *documentService) getWithStatus(ctx context.Context, documentID, expected string) (*domain.PolicyDocument, error) {
+	doc, err := s.repo.GetByID(ctx, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to get policy: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("%w: id %s", domain.ErrNotFound, documentID)
+	}
+	if doc.Status != expected {
+		return nil, fmt.Errorf("%w: version %s is %s, expected %s",
+			domain.ErrInvalidStatusTransition, documentID, doc.Status, expected)
+	}
+
+	return doc, nil
+}
+
+// checkReviewer đảm bảo reviewer không phải người tạo hoặc người gửi duyệt
+func checkReviewer(doc *domain.PolicyDocument, reviewedBy string) error {
+	if reviewedBy == doc.CreatedBy || (doc.SubmittedBy != nil && reviewedBy == *doc.SubmittedBy) {
+		return fmt.Errorf("%w: %s authored or submitted version %s", domain.ErrSelfReview, reviewedBy, doc.ID)
+	}
+	return nil
+}
+
+// resolveInitialStatus quyết định status khi tạo version mới:
+// - draft nếu admin muốn lưu nháp
+// - pending_review (mặc định) để admin khác duyệt
+// Version mới không bao giờ được phát hành trực tiếp.
+func resolveInitialStatus(requested string) (string, error) {
+	switch requested {
+	case domain.StatusDraft:
+		return domain.StatusDraft, nil
+	case "", domain.StatusPendingReview:
+		return domain.StatusPendingReview, nil
+	default:
+		return "", fmt.Errorf("status must be either '%s' or '%s', got '%s'",
+			domain.StatusDraft, domain.StatusPendingReview, requested)
+	}
+}
+
 // validateCreateParams validates document creation parameters
 func (s *documentService) validateCreateParams(params domain.CreateDocumentParams) error {
 	if params.DocumentName == "" {
@@ -148,8 +671,8 @@ func (s *documentService) validateCreateParams(params domain.CreateDocumentParam
 	}
 
 	// At least one content source must be provided
-	if params.ContentHTML == "" && params.FileURL == "" {
-		return fmt.Errorf("either content_html or file_url must be provided")
+	if params.ContentHTML == "" && params.FileURL == "" && params.FileHash == "" {
+		return fmt.Errorf("either content_html, file_url or file_hash must be provided")
 	}
 
 	// Validate URL
@@ -163,9 +686,123 @@ func (s *documentService) validateCreateParams(params domain.CreateDocumentParam
 		return fmt.Errorf("created_by is required")
 	}
 
+	return validateConsentValidityPeriod(params.ConsentValidityPeriod)
+}
+
+// validatePurposes kiểm tra key (duy nhất trong version) và tên của từng purpose
+func validatePurposes(purposes []domain.PurposeParams) error {
+	seen := make(map[string]bool, len(purposes))
+	for _, p := range purposes {
+		if !domain.PurposeKeyPattern.MatchString(p.Key) {
+			return fmt.Errorf("%w: purpose key %q must match %s", domain.ErrInvalidInput, p.Key, domain.PurposeKeyPattern)
+		}
+		if seen[p.Key] {
+			return fmt.Errorf("%w: duplicate purpose %q", domain.ErrInvalidInput, p.Key)
+		}
+		seen[p.Key] = true
+
+		if strings.TrimSpace(p.Name) == "" {
+			return fmt.Errorf("%w: purpose %q needs a name", domain.ErrInvalidInput, p.Key)
+		}
+	}
 	return nil
 }
 
+// validateConsentValidityPeriod - số ngày consent còn hiệu lực, 0 = không hết hạn
+func validateConsentValidityPeriod(days int) error {
+	if days < 0 || days > domain.MaxConsentValidityPeriod {
+		return fmt.Errorf("%w: consent_validity_period must be between 0 and %d days",
+			domain.ErrInvalidInput, domain.MaxConsentValidityPeriod)
+	}
+	return nil
+}
+
+// normalizeLocales chuẩn hóa locale của nội dung gốc và các bản dịch
+// Mỗi locale chỉ xuất hiện một lần, bản dịch phải có content_html hoặc file_hash đã upload
+func (s *documentService) normalizeLocales(ctx context.Context, params *domain.CreateDocumentParams) error {
+	params.Locale = domain.NormalizeLocale(params.Locale)
+	if params.Locale == "" {
+		params.Locale = domain.DefaultLocale
+	}
+	if !domain.IsSupportedLocale(params.Locale) {
+		return fmt.Errorf("%w: unsupported locale %q", domain.ErrInvalidInput, params.Locale)
+	}
+
+	seen := map[string]bool{params.Locale: true}
+	for i := range params.Translations {
+		t := &params.Translations[i]
+		t.Locale = domain.NormalizeLocale(t.Locale)
+		if !domain.IsSupportedLocale(t.Locale) {
+			return fmt.Errorf("%w: unsupported translation locale %q", domain.ErrInvalidInput, t.Locale)
+		}
+		if seen[t.Locale] {
+			return fmt.Errorf("%w: duplicate content for locale %q", domain.ErrInvalidInput, t.Locale)
+		}
+		seen[t.Locale] = true
+
+		if t.ContentHTML == "" && t.FileHash == "" {
+			return fmt.Errorf("%w: translation %q needs content_html or file_hash", domain.ErrInvalidInput, t.Locale)
+		}
+		if err := s.checkFileHash(ctx, t.FileHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// localize điền AvailableLocales, Purposes và thay nội dung bằng bản dịch theo locale yêu cầu.
+// Fallback: locale yêu cầu (bỏ region, "en-US" -> "en") -> nội dung gốc của version.
+func (s *documentService) localize(ctx context.Context, docs []*domain.PolicyDocument, locale string) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	translations, err := s.repo.GetTranslations(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("service: failed to get translations: %w", err)
+	}
+	purposes, err := s.repo.GetPurposes(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("service: failed to get purposes: %w", err)
+	}
+
+	locale = domain.NormalizeLocale(locale)
+	for _, doc := range docs {
+		doc.Purposes = purposes[doc.ID]
+		doc.AvailableLocales = []string{doc.Locale}
+		var chosen *domain.PolicyTranslation
+		for _, t := range translations[doc.ID] {
+			doc.AvailableLocales = append(doc.AvailableLocales, t.Locale)
+			if t.Locale == locale && locale != doc.Locale {
+				chosen = t
+			}
+		}
+
+		if chosen != nil {
+			doc.Locale = chosen.Locale
+			doc.ContentHTML = chosen.ContentHTML
+			doc.FileHash = chosen.FileHash
+			doc.FileURL = "" // file_url thuộc nội dung gốc
+		}
+	}
+
+	return nil
+}
+
+// checkFileHash ensures file_hash (if provided) refers to an uploaded file
+func (s *documentService) checkFileHash(ctx context.Context, fileHash string) error {
+	if fileHash == "" {
+		return nil
+	}
+	_, err := s.files.GetFile(ctx, fileHash)
+	return err
+}
+
 // validateFileURL checks if the provided URL is valid (basic check)
 func validateFileURL(fileURL string) error {
 	// Basic validation: must start with http:// or https://
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/thatlq1812/policy-system/document/internal/domain"
+	"github.com/thatlq1812/policy-system/document/internal/repository"
+	"github.com/thatlq1812/policy-system/document/internal/storage"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+)
+
+// FileService handles content-addressed uploads of policy files
+type FileService interface {
+	// UploadFile đọc toàn bộ content từ r, lưu vào storage theo SHA-256 và trả về metadata
+	UploadFile(ctx context.Context, params domain.UploadFileParams, r io.Reader) (*domain.FileBlob, error)
+
+	// GetFile trả về metadata của file theo content hash
+	GetFile(ctx context.Context, contentHash string) (*domain.FileBlob, error)
+
+	// OpenFile trả về metadata và reader của file (caller phải Close)
+	OpenFile(ctx context.Context, contentHash string) (*domain.FileBlob, io.ReadCloser, error)
+}
+
+type fileService struct {
+	repo     repository.FileRepository
+	store    storage.BlobStore
+	maxBytes int64
+	auditLog *audit.Logger
+}
+
+// NewFileService creates a new file service instance
+func NewFileService(repo repository.FileRepository, store storage.BlobStore, maxBytes int64, auditLog *audit.Logger) FileService {
+	return &fileService{repo: repo, store: store, maxBytes: maxBytes, auditLog: auditLog}
+}
+
+// fileTypes maps allowed extensions (cùng whitelist với validateFileURL) to the content type
+// served on download and the type http.DetectContentType must report for the content
+var fileTypes = map[string]struct {
+	contentType string
+	sniffPrefix string
+}{
+	".pdf":  {"application/pdf", "application/pdf"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip"},
+	".html": {"text/html; charset=utf-8", "text/"},
+	".txt":  {"text/plain; charset=utf-8", "text/"},
+	".md":   {"text/markdown; charset=utf-8", "text/"},
+	".jpg":  {"image/jpeg", "image/jpeg"},
+	".jpeg": {"image/jpeg", "image/jpeg"},
+	".png":  {"image/png", "image/png"},
+}
+
+var contentHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+func (s *fileService) UploadFile(ctx context.Context, params domain.UploadFileParams, r io.Reader) (*domain.FileBlob, error) {
+	// Step 1: Validate metadata
+	filename := filepath.Base(strings.TrimSpace(params.Filename))
+	if filename == "" || filename == "." || filename == "/" {
+		return nil, fmt.Errorf("%w: filename is required", domain.ErrInvalidInput)
+	}
+	if params.UploadedBy == "" {
+		return nil, fmt.Errorf("%w: uploaded_by is required", domain.ErrInvalidInput)
+	}
+	fileType, ok := fileTypes[strings.ToLower(filepath.Ext(filename))]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported file extension %q", domain.ErrInvalidInput, filepath.Ext(filename))
+	}
+
+	// Step 2: Spool ra file tạm và hash cùng lúc (key chỉ biết được sau khi đọc hết)
+	tmp, err := os.CreateTemp("", "policy-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(r, s.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if size > s.maxBytes {
+		return nil, fmt.Errorf("%w: limit is %d bytes", domain.ErrFileTooLarge, s.maxBytes)
+	}
+	if size == 0 {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalidInput)
+	}
+	contentHash := hex.EncodeToString(hasher.Sum(nil))
+
+	// Step 3: Nội dung phải khớp extension (tránh upload file thực thi dưới tên .pdf)
+	head := make([]byte, 512)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if sniffed := http.DetectContentType(head[:n]); !strings.HasPrefix(sniffed, fileType.sniffPrefix) {
+		return nil, fmt.Errorf("%w: content of %s looks like %s", domain.ErrInvalidInput, filename, sniffed)
+	}
+
+	// Step 4: Lưu bytes vào storage backend
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind upload: %w", err)
+	}
+	if err := s.store.Put(ctx, contentHash, tmp, size, fileType.contentType); err != nil {
+		return nil, fmt.Errorf("service: failed to store file: %w", err)
+	}
+
+	// Step 5: Lưu metadata (file trùng nội dung trả về bản ghi đã có)
+	blob, err := s.repo.Create(ctx, &domain.FileBlob{
+		ContentHash: contentHash,
+		ContentType: fileType.contentType,
+		SizeBytes:   size,
+		Filename:    filename,
+		UploadedBy:  params.UploadedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to save file metadata: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionUpload,
+		TargetType: "file",
+		TargetID:   blob.ContentHash,
+		After: map[string]any{
+			"content_hash": blob.ContentHash,
+			"content_type": blob.ContentType,
+			"size_bytes":   blob.SizeBytes,
+			"filename":     filename, // Tên file của lần upload này (bản ghi có thể giữ tên lần đầu)
+			"uploaded_by":  params.UploadedBy,
+		},
+	})
+
+	return blob, nil
+}
+
+func (s *fileService) GetFile(ctx context.Context, contentHash string) (*domain.FileBlob, error) {
+	if !contentHashPattern.MatchString(contentHash) {
+		return nil, fmt.Errorf("%w: content_hash must be a lowercase hex SHA-256", domain.ErrInvalidInput)
+	}
+
+	blob, err := s.repo.GetByHash(ctx, contentHash)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to get file: %w", err)
+	}
+	if blob == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrFileNotFound, contentHash)
+	}
+
+	return blob, nil
+}
+
+func (s *fileService) OpenFile(ctx context.Context, contentHash string) (*domain.FileBlob, io.ReadCloser, error) {
+	blob, err := s.GetFile(ctx, contentHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader, err := s.store.Get(ctx, contentHash)
+	if err == storage.ErrBlobNotFound {
+		// Metadata còn nhưng bytes mất khỏi storage backend
+		return nil, nil, fmt.Errorf("%w: content missing from storage: %s", domain.ErrFileNotFound, contentHash)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("service: failed to open file: %w", err)
+	}
+
+	return blob, reader, nil
+}
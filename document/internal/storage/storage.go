@@ -0,0 +1,46 @@
+// Package storage provides pluggable blob storage for uploaded policy files.
+//
+// Blobs are addressed by the hex SHA-256 of their content, so the same file is
+// stored once and a key can never point to different bytes. The BlobStore
+// interface mirrors the S3 object API (PutObject/GetObject/HeadObject) so an
+// S3-compatible backend (AWS S3, MinIO, R2...) can be plugged in next to the
+// local filesystem backend.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrBlobNotFound indicates no blob exists for the key
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore stores immutable blobs by key
+type BlobStore interface {
+	// Put stores size bytes from r under key. Storing an existing key is a no-op
+	// (content-addressed keys always map to the same bytes)
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get opens the blob for reading. Returns ErrBlobNotFound if key does not exist
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Exists reports whether a blob is stored under key
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// Backend names for STORAGE_BACKEND
+const (
+	BackendLocal = "local"
+)
+
+// New creates a BlobStore for the configured backend
+func New(backend, localDir string) (BlobStore, error) {
+	switch backend {
+	case BackendLocal, "":
+		return NewLocalStore(localDir)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend: %s", backend)
+	}
+}
@@ -0,0 +1,12 @@
+-- document/migrations/000003_add_publication_status.down.sql
+-- Revert publication lifecycle
+
+DROP INDEX IF EXISTS idx_policy_status_effective_ts;
+
+ALTER TABLE policy_documents
+DROP CONSTRAINT IF EXISTS policy_documents_status_check;
+
+ALTER TABLE policy_documents
+DROP COLUMN IF EXISTS published_by,
+DROP COLUMN IF EXISTS published_at,
+DROP COLUMN IF EXISTS status;
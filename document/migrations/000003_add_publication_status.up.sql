@@ -0,0 +1,19 @@
+-- document/migrations/000003_add_publication_status.up.sql
+-- Vòng đời phát hành: draft -> scheduled -> published
+
+ALTER TABLE policy_documents
+ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published',
+ADD COLUMN IF NOT EXISTS published_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN IF NOT EXISTS published_by VARCHAR(100);
+
+-- Các version đã có trước đây coi như đã phát hành
+UPDATE policy_documents
+SET published_at = created_at, published_by = created_by
+WHERE published_at IS NULL;
+
+ALTER TABLE policy_documents
+ADD CONSTRAINT policy_documents_status_check
+CHECK (status IN ('draft', 'scheduled', 'published'));
+
+-- Phục vụ query latest (chỉ version đã tới thời điểm hiệu lực) và job phát hành theo lịch
+CREATE INDEX IF NOT EXISTS idx_policy_status_effective_ts ON policy_documents(status, effective_timestamp);
@@ -0,0 +1,21 @@
+-- document/migrations/000004_add_review_flow.down.sql
+-- Revert review flow
+
+ALTER TABLE policy_documents
+DROP COLUMN IF EXISTS review_comment,
+DROP COLUMN IF EXISTS reviewed_at,
+DROP COLUMN IF EXISTS reviewed_by,
+DROP COLUMN IF EXISTS submitted_at,
+DROP COLUMN IF EXISTS submitted_by;
+
+-- Version đang chờ duyệt/bị từ chối quay về draft
+UPDATE policy_documents
+SET status = 'draft'
+WHERE status IN ('pending_review', 'rejected');
+
+ALTER TABLE policy_documents
+DROP CONSTRAINT IF EXISTS policy_documents_status_check;
+
+ALTER TABLE policy_documents
+ADD CONSTRAINT policy_documents_status_check
+CHECK (status IN ('draft', 'scheduled', 'published'));
@@ -0,0 +1,18 @@
+-- document/migrations/000004_add_review_flow.up.sql
+-- Review flow: draft -> pending_review -> (approved) scheduled/published | rejected
+-- Admin thứ hai phải duyệt trước khi version có thể hiển thị
+
+ALTER TABLE policy_documents
+DROP CONSTRAINT IF EXISTS policy_documents_status_check;
+
+ALTER TABLE policy_documents
+ADD CONSTRAINT policy_documents_status_check
+CHECK (status IN ('draft', 'pending_review', 'rejected', 'scheduled', 'published'));
+
+-- Thông tin người gửi duyệt và người duyệt
+ALTER TABLE policy_documents
+ADD COLUMN IF NOT EXISTS submitted_by VARCHAR(100),
+ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN IF NOT EXISTS reviewed_by VARCHAR(100),
+ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN IF NOT EXISTS review_comment TEXT;
@@ -0,0 +1,9 @@
+-- document/migrations/000005_add_file_blobs.down.sql
+-- Revert content-addressed file storage (blobs trong storage backend không bị xóa)
+
+DROP INDEX IF EXISTS idx_policy_documents_file_hash;
+
+ALTER TABLE policy_documents
+DROP COLUMN IF EXISTS file_hash;
+
+DROP TABLE IF EXISTS file_blobs;
@@ -0,0 +1,18 @@
+-- document/migrations/000005_add_file_blobs.up.sql
+-- Content-addressed file storage: file upload qua Document Service, địa chỉ = SHA-256 của nội dung
+-- Bytes nằm trong storage backend (local filesystem / S3-compatible), bảng này chỉ lưu metadata
+
+CREATE TABLE IF NOT EXISTS file_blobs (
+    content_hash CHAR(64) PRIMARY KEY, -- SHA-256 hex, cũng là key trong storage backend
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    filename VARCHAR(255) NOT NULL, -- Tên file lúc upload lần đầu
+    uploaded_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Version có thể tham chiếu file đã upload thay cho file_url bên ngoài
+ALTER TABLE policy_documents
+ADD COLUMN IF NOT EXISTS file_hash CHAR(64) REFERENCES file_blobs(content_hash);
+
+CREATE INDEX IF NOT EXISTS idx_policy_documents_file_hash ON policy_documents(file_hash);
@@ -0,0 +1,7 @@
+-- document/migrations/000006_add_policy_translations.down.sql
+-- Revert đa ngôn ngữ (bản dịch bị xóa, chỉ giữ nội dung gốc)
+
+DROP TABLE IF EXISTS policy_document_translations;
+
+ALTER TABLE policy_documents
+DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,16 @@
+-- document/migrations/000006_add_policy_translations.up.sql
+-- Đa ngôn ngữ: mỗi version có nội dung gốc (locale của row) và các bản dịch cho cùng version đó
+-- Bản dịch thuộc về version (cùng vòng duyệt/phát hành), không phải version riêng
+
+-- Locale của content_html/file trên chính row policy_documents
+ALTER TABLE policy_documents
+ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT 'vi';
+
+CREATE TABLE IF NOT EXISTS policy_document_translations (
+    document_id UUID NOT NULL REFERENCES policy_documents(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL, -- 'vi', 'en'
+    content_html TEXT NOT NULL DEFAULT '',
+    file_hash CHAR(64) REFERENCES file_blobs(content_hash), -- File đã upload cho locale này (optional)
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (document_id, locale)
+);
@@ -0,0 +1,45 @@
+-- Audit log append-only cho các thao tác thay đổi dữ liệu (xem shared/pkg/audit)
+-- Mỗi dòng ghi actor, target, action, giá trị trước/sau, request ID và IP.
+-- Không có UPDATE/DELETE: trigger bên dưới chặn ở tầng DB.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    service VARCHAR(50) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    actor_id VARCHAR(255), -- NULL = system / không xác định
+    actor_role VARCHAR(50),
+    target_type VARCHAR(50) NOT NULL,
+    target_id VARCHAR(255),
+    before_value JSONB,
+    after_value JSONB,
+    reason TEXT,
+    request_id VARCHAR(100),
+    ip_address VARCHAR(50),
+    user_agent TEXT,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_occurred_at ON audit_log(occurred_at DESC);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor_id, occurred_at DESC);
+CREATE INDEX idx_audit_log_target ON audit_log(target_type, target_id, occurred_at DESC);
+CREATE INDEX idx_audit_log_action ON audit_log(action, occurred_at DESC);
+CREATE INDEX idx_audit_log_request ON audit_log(request_id);
+
+CREATE OR REPLACE FUNCTION prevent_audit_log_mutation()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER trigger_prevent_audit_log_mutation
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW
+EXECUTE FUNCTION prevent_audit_log_mutation();
+
+CREATE TRIGGER trigger_prevent_audit_log_truncate
+BEFORE TRUNCATE ON audit_log
+FOR EACH STATEMENT
+EXECUTE FUNCTION prevent_audit_log_mutation();
+
+COMMENT ON TABLE audit_log IS 'Append-only audit log of mutating operations (actor, target, before/after values)';
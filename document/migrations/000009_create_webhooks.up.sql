@@ -0,0 +1,72 @@
+-- Webhook subscriptions cho domain events (xem shared/pkg/webhook)
+-- Outbox dispatcher nhân mỗi event thành 1 delivery cho từng subscription đang nhận event đó,
+-- worker gửi delivery, lưu lịch sử từng lần gửi và chuyển delivery hết lượt thử vào dead-letter.
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY, -- Gateway sinh, dùng chung giữa Document và Consent Service
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL, -- Key HMAC-SHA256 ký body (header X-Signature)
+    event_types TEXT[] NOT NULL,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ -- Xóa mềm để giữ lịch sử delivery
+);
+
+CREATE INDEX idx_webhook_subscriptions_events ON webhook_subscriptions USING GIN(event_types)
+WHERE is_active = TRUE;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id),
+    service VARCHAR(50) NOT NULL,
+    event_id UUID NOT NULL, -- outbox_events.event_id
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ DEFAULT NOW(), -- NULL = không còn gửi
+    last_status_code INT,
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT webhook_deliveries_status_check
+        CHECK (status IN ('pending', 'delivered', 'dead', 'cancelled')),
+    -- Outbox là at-least-once, mỗi event chỉ tạo 1 delivery cho mỗi subscription
+    CONSTRAINT webhook_deliveries_event_unique UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at)
+WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_status ON webhook_deliveries(status, created_at DESC);
+
+-- Lịch sử từng lần gửi
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id UUID NOT NULL REFERENCES webhook_deliveries(id),
+    attempted_at TIMESTAMPTZ NOT NULL,
+    status_code INT, -- NULL = không nhận được response
+    error TEXT,
+    duration_ms BIGINT NOT NULL
+);
+
+CREATE INDEX idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id, attempted_at DESC);
+
+-- Dead-letter: delivery hết lượt thử, chờ Admin gửi lại
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    delivery_id UUID PRIMARY KEY REFERENCES webhook_deliveries(id),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id),
+    event_type VARCHAR(100) NOT NULL,
+    attempts INT NOT NULL,
+    last_error TEXT,
+    dead_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    redelivered_at TIMESTAMPTZ -- Admin đã gửi lại
+);
+
+CREATE INDEX idx_webhook_dead_letters_open ON webhook_dead_letters(dead_at DESC)
+WHERE redelivered_at IS NULL;
+
+COMMENT ON TABLE webhook_subscriptions IS 'Admin-registered webhook endpoints for domain events';
+COMMENT ON TABLE webhook_dead_letters IS 'Webhook deliveries that exhausted their retries';
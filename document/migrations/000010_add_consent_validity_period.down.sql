@@ -0,0 +1,8 @@
+-- document/migrations/000010_add_consent_validity_period.down.sql
+-- Rollback thời hạn hiệu lực của consent
+
+ALTER TABLE policy_documents
+DROP CONSTRAINT IF EXISTS policy_documents_consent_validity_period_check;
+
+ALTER TABLE policy_documents
+DROP COLUMN IF EXISTS consent_validity_period;
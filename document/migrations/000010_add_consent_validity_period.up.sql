@@ -0,0 +1,12 @@
+-- document/migrations/000010_add_consent_validity_period.up.sql
+-- Thời hạn hiệu lực của consent (marketing, chia sẻ dữ liệu...): user phải xác nhận lại sau N ngày
+-- Lưu theo version, 0 = consent không hết hạn
+
+ALTER TABLE policy_documents
+ADD COLUMN IF NOT EXISTS consent_validity_period INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE policy_documents
+ADD CONSTRAINT policy_documents_consent_validity_period_check
+CHECK (consent_validity_period >= 0);
+
+COMMENT ON COLUMN policy_documents.consent_validity_period IS 'Days a consent to this version stays valid before it must be re-confirmed (0 = never expires)';
@@ -0,0 +1,4 @@
+-- document/migrations/000011_add_policy_purposes.down.sql
+-- Rollback mục đích xử lý dữ liệu của document
+
+DROP TABLE IF EXISTS policy_document_purposes;
@@ -0,0 +1,14 @@
+-- document/migrations/000011_add_policy_purposes.up.sql
+-- Mục đích xử lý dữ liệu trong 1 document (analytics, marketing, chia sẻ bên thứ ba...)
+-- User bật/tắt từng mục đích khi đồng ý. Purposes thuộc về version (cùng vòng duyệt/phát hành như bản dịch)
+
+CREATE TABLE IF NOT EXISTS policy_document_purposes (
+    document_id UUID NOT NULL REFERENCES policy_documents(id) ON DELETE CASCADE,
+    purpose_key VARCHAR(64) NOT NULL, -- Định danh ổn định giữa các version, vd: 'marketing'
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_required BOOLEAN NOT NULL DEFAULT FALSE, -- Đồng ý document = đồng ý mục đích này, không tắt được
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (document_id, purpose_key)
+);
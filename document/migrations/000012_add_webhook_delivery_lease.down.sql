@@ -0,0 +1,12 @@
+-- document/migrations/000012_add_webhook_delivery_lease.down.sql
+-- Rollback lease của webhook worker, delivery đang gửi quay về pending
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_sending;
+
+UPDATE webhook_deliveries SET status = 'pending' WHERE status = 'sending';
+
+ALTER TABLE webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_status_check;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT webhook_deliveries_status_check
+CHECK (status IN ('pending', 'delivered', 'dead', 'cancelled'));
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS locked_until;
@@ -0,0 +1,16 @@
+-- document/migrations/000012_add_webhook_delivery_lease.up.sql
+-- Worker nhận delivery bằng lease thay vì giữ transaction trong lúc POST tới endpoint:
+-- transaction ngắn chuyển delivery sang 'sending' tới locked_until rồi commit, request HTTP
+-- chạy ngoài transaction, kết quả được lưu trong transaction riêng.
+-- Worker chết giữa chừng thì delivery được nhận lại khi lease hết hạn.
+
+ALTER TABLE webhook_deliveries ADD COLUMN locked_until TIMESTAMPTZ;
+
+ALTER TABLE webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_status_check;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT webhook_deliveries_status_check
+CHECK (status IN ('pending', 'sending', 'delivered', 'dead', 'cancelled'));
+
+CREATE INDEX idx_webhook_deliveries_sending ON webhook_deliveries(locked_until)
+WHERE status = 'sending';
+
+COMMENT ON COLUMN webhook_deliveries.locked_until IS 'Lease of the worker sending this delivery; reclaimed by another worker after it expires';
@@ -52,6 +52,15 @@ func main() {
 	}
 	defer userClient.Close()
 
+	// Public keys ký access token (JWKS) của User Service, tải lại định kỳ và khi gặp kid mới
+	keySet := middleware.NewKeySet(userClient, cfg.JWT.JWKSRefreshInterval)
+	if err := keySet.Refresh(context.Background()); err != nil {
+		log.Printf("WARNING: Failed to load signing keys, will retry on first request: %v", err)
+	}
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go keySet.Run(jobCtx)
+
 	documentClient, err := clients.NewDocumentClient(cfg.Services.DocumentServiceAddr, cfg.GRPCcallTimeout)
 	if err != nil {
 		log.Fatalf("Failed to create document client: %v", err)
@@ -65,14 +74,24 @@ func main() {
 	defer consentClient.Close()
 
 	// 3. Initialize API handlers
-	// Giải thích: UserAPI cần access cả 3 clients để làm orchestration
-	// - userClient: Register/Delete user
+	// Giải thích: UserAPI cần access cả 3 clients
+	// - userClient: Register (saga đăng ký + consent chạy trong User Service), Login
 	// - documentClient: Get policies
-	// - consentClient: Record consent
+	// - consentClient: Check pending consents
 	userAPI := api.NewUserAPI(userClient, documentClient, consentClient)
+	// Cache kết quả pending consent cho ConsentEnforcement, invalidate khi RecordConsent/RevokeConsent
+	consentCache := middleware.NewConsentCache(cfg.ConsentCacheTTL)
+
 	documentAPI := api.NewDocumentAPI(documentClient)
-	consentAPI := api.NewConsentAPI(consentClient)
+	consentAPI := api.NewConsentAPI(consentClient, consentCache)
 	adminAPI := api.NewAdminAPI(consentClient) // Admin endpoints
+	auditAPI := api.NewAuditAPI(userClient, documentClient, consentClient)
+	webhookAPI := api.NewWebhookAPI(documentClient, consentClient)
+	sagaAPI := api.NewSagaAPI(userClient)
+	campaignAPI := api.NewCampaignAPI(consentClient, consentCache)
+	exportAPI := api.NewExportAPI(userClient, consentClient) // Export dữ liệu cá nhân (GDPR / Nghị định 13)
+	erasureAPI := api.NewErasureAPI(userClient)              // Xóa dữ liệu cá nhân (right to erasure)
+	jwksAPI := api.NewJWKSAPI(keySet)                        // Public keys verify access token
 
 	// 4. Setup Gin router
 	// Set Gin mode based on environment
@@ -90,7 +109,7 @@ func main() {
 		AllowOrigins:     cfg.AllowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
-		ExposeHeaders:    []string{"Content-Length", "X-Request-ID"},
+		ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "X-Consent-Required", "X-Consent-Grace-Deadline"},
 		AllowCredentials: cfg.AllowedCredentials,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -101,6 +120,12 @@ func main() {
 	// Recovery middleware
 	router.Use(gin.Recovery())
 
+	// Request ID + client IP/User-Agent → gRPC metadata cho audit log của các service
+	router.Use(middleware.RequestContext())
+
+	// Locale negotiation (?locale= hoặc Accept-Language) cho nội dung policy đa ngôn ngữ
+	router.Use(middleware.Locale())
+
 	// 6. Register routes
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
@@ -110,6 +135,9 @@ func main() {
 		})
 	})
 
+	// JWKS: public keys để service khác verify access token (RFC 7517)
+	router.GET("/.well-known/jwks.json", jwksAPI.GetJWKS)
+
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -118,24 +146,48 @@ func main() {
 	{
 		// Authentication
 		// Giải thích: Dùng enhanced versions với orchestration
-		// - RegisterWithConsent: Register + Auto-consent orchestration (có rollback)
+		// - RegisterWithConsent: Register + Auto-consent (saga, rollback bền vững)
 		// - LoginWithPendingCheck: Login + Check pending consents (graceful degradation)
 		public.POST("/auth/register", userAPI.RegisterWithConsent)
 		public.POST("/auth/login", userAPI.LoginWithPendingCheck)
 		public.POST("/auth/refresh", userAPI.RefreshToken) // Token refresh
 		public.POST("/auth/logout", userAPI.Logout)        // Logout
 
+		// Two-factor authentication: bước 2 của login (mfa_token từ /auth/login)
+		public.POST("/auth/mfa/verify", userAPI.VerifyMFA)
+		public.POST("/auth/mfa/enroll", userAPI.EnrollMFAOnLogin)   // Admin bị buộc enroll
+		public.POST("/auth/mfa/confirm", userAPI.ConfirmMFAOnLogin) // Bật 2FA + hoàn tất login
+
+		// Đăng nhập không mật khẩu bằng OTP qua SMS (2FA vẫn áp dụng)
+		public.POST("/auth/otp/request", userAPI.RequestLoginOTP)
+		public.POST("/auth/otp/login", userAPI.LoginWithOTP)
+
 		// Documents (public access)
-		public.POST("/policies", documentAPI.CreatePolicy)
 		public.GET("/policies/latest", documentAPI.GetLatestPolicy)
+		public.GET("/policies/compare", documentAPI.ComparePolicyVersions)
+		public.GET("/files/:hash", documentAPI.DownloadFile) // Content-addressed policy files
 	}
 
 	// Protected routes (require JWT authentication with blacklist check)
+	// ConsentEnforcement chặn user quá grace deadline của policy bắt buộc chưa đồng ý (451),
+	// trừ consent endpoints để user có thể đồng ý và export dữ liệu (quyền truy cập không phụ thuộc consent)
 	protected := router.Group("/api/v1")
-	protected.Use(middleware.AuthMiddlewareWithBlacklist(cfg.JWT.Secret, userClient))
+	protected.Use(middleware.AuthMiddlewareWithBlacklist(keySet, userClient))
+	protected.Use(middleware.ConsentEnforcement(consentClient, consentCache, "/api/v1/consents", "/api/v1/user/export"))
 	{
 		// User endpoints
 		protected.POST("/user/change-password", userAPI.ChangePassword)
+		protected.GET("/user/export", exportAPI.ExportMyData)
+		protected.POST("/user/mfa/totp/enroll", userAPI.EnrollTOTP)
+		protected.POST("/user/mfa/totp/confirm", userAPI.ConfirmTOTP)
+		protected.POST("/user/mfa/totp/disable", userAPI.DisableTOTP)
+		protected.POST("/user/phone/verify/request", userAPI.RequestPhoneVerification)
+		protected.POST("/user/phone/verify", userAPI.VerifyPhone)
+		protected.POST("/user/phone/change/request", userAPI.RequestPhoneChange) // OTP gửi tới số mới
+		protected.POST("/user/phone/change", userAPI.ChangePhone)
+
+		// Documents - chỉ Admin được tạo version mới (draft/pending_review, cần admin khác duyệt)
+		protected.POST("/policies", middleware.AdminOnly(), documentAPI.CreatePolicy)
 
 		// Consent endpoints
 		protected.POST("/consents", consentAPI.RecordConsent)
@@ -143,22 +195,61 @@ func main() {
 		protected.GET("/consents/user", consentAPI.GetUserConsents)
 		protected.POST("/consents/pending", consentAPI.CheckPendingConsents)
 		protected.POST("/consents/revoke", consentAPI.RevokeConsent)
+		protected.POST("/consents/decline", consentAPI.DeclineConsent)
+		protected.GET("/consents/history", consentAPI.GetConsentHistory)
+		protected.GET("/consents/purposes/:purpose", consentAPI.CheckPurposeConsent)
 	}
 
 	// Admin routes (require JWT + Admin role + blacklist check)
 	// IMPORTANT: AdminOnly middleware checks platform_role claim from JWT
 	// NOTE: Access tokens are now blacklisted on logout for immediate revocation
 	admin := router.Group("/api/v1/admin")
-	admin.Use(middleware.AuthMiddlewareWithBlacklist(cfg.JWT.Secret, userClient))
+	admin.Use(middleware.AuthMiddlewareWithBlacklist(keySet, userClient))
 	admin.Use(middleware.AdminOnly()) // FIXED: Added admin role check
 	{
 		// User management
 		admin.GET("/users", userAPI.ListUsers)
 		admin.GET("/stats/users", userAPI.GetUserStats)
 		admin.POST("/create-admin", userAPI.CreateAdminUser) // Admin-only: Create new admin accounts
+		admin.GET("/users/:user_id/export", exportAPI.AdminExportUserData)
+		admin.POST("/users/:user_id/erase", erasureAPI.EraseUser)
+		admin.GET("/users/:user_id/erasure-certificate", erasureAPI.GetErasureCertificate)
 
 		// Consent statistics
 		admin.GET("/stats/consents", adminAPI.GetConsentStats)
+		admin.GET("/consents/:user_id/verify-chain", adminAPI.VerifyConsentChain)
+
+		// Re-consent campaigns (mở tự động khi version bắt buộc mới có hiệu lực)
+		admin.GET("/reconsent-campaigns", campaignAPI.ListCampaigns)
+		admin.GET("/reconsent-campaigns/:id", campaignAPI.GetCampaign)
+		admin.GET("/reconsent-campaigns/:id/users", campaignAPI.ListCampaignUsers)
+		admin.PATCH("/reconsent-campaigns/:id", campaignAPI.UpdateCampaign)
+
+		// Audit log (gộp User, Document, Consent Service)
+		admin.GET("/audit", auditAPI.QueryAuditLog)
+
+		// Sagas (đăng ký bị kẹt / đang bù trừ)
+		admin.GET("/sagas", sagaAPI.ListSagas)
+		admin.GET("/sagas/:id", sagaAPI.GetSaga)
+		admin.POST("/sagas/:id/retry", sagaAPI.RetrySaga)
+
+		// Webhooks (subscription lưu ở Document/Consent Service theo event)
+		admin.POST("/webhooks", webhookAPI.CreateWebhook)
+		admin.GET("/webhooks", webhookAPI.ListWebhooks)
+		admin.GET("/webhooks/deliveries", webhookAPI.ListWebhookDeliveries)
+		admin.POST("/webhooks/deliveries/:delivery_id/redeliver", webhookAPI.RedeliverWebhook)
+		admin.DELETE("/webhooks/:id", webhookAPI.DeleteWebhook)
+
+		// Policy review & publication lifecycle
+		// draft -> pending_review -> (approve) scheduled/published | (reject) rejected
+		admin.GET("/policies/upcoming", documentAPI.ListUpcomingPolicies)
+		admin.GET("/policies/compare", documentAPI.AdminComparePolicyVersions)
+		admin.POST("/files", documentAPI.UploadFile) // Upload file → dùng content_hash làm file_hash khi tạo policy
+		admin.POST("/policies/:id/submit", documentAPI.SubmitForReview)
+		admin.POST("/policies/:id/approve", documentAPI.ApprovePolicy)
+		admin.POST("/policies/:id/reject", documentAPI.RejectPolicy)
+		admin.POST("/policies/:id/schedule", documentAPI.SchedulePolicy)
+		admin.POST("/policies/:id/publish", documentAPI.PublishPolicy)
 	}
 
 	// 7. Create HTTP server
@@ -23,6 +23,9 @@ type Config struct {
 
 	// Logging
 	LogLevel string
+
+	// Consent enforcement: TTL cache kết quả check pending consent theo user
+	ConsentCacheTTL time.Duration
 }
 
 type ServerConfig struct {
@@ -40,9 +43,11 @@ type ServicesConfig struct {
 	ConsentServiceAddr  string
 }
 
+// JWTConfig - access token được User Service ký bằng RS256/EdDSA,
+// Gateway verify bằng public keys lấy qua GetSigningKeys (không cần secret)
 type JWTConfig struct {
-	Secret     string
-	Expiration int // hours
+	Expiration          int           // hours
+	JWKSRefreshInterval time.Duration // Chu kỳ tải lại public keys, <= JWT_KEY_PUBLISH_AHEAD_MINUTES của User Service
 }
 
 func Load() *Config {
@@ -56,8 +61,8 @@ func Load() *Config {
 			ConsentServiceAddr:  getEnv("CONSENT_SERVICE_ADDR", "localhost:50053"),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your-secret-key-change-this-in-production"),
-			Expiration: getEnvAsInt("JWT_EXPIRATION", 24), // 24 hours
+			Expiration:          getEnvAsInt("JWT_EXPIRATION", 24), // 24 hours
+			JWKSRefreshInterval: getEnvAsDuration("JWKS_REFRESH_INTERVAL", 5*time.Minute),
 		},
 
 		// Timeouts
@@ -70,6 +75,9 @@ func Load() *Config {
 
 		// Logging
 		LogLevel: getEnv("LOG_LEVEL", "info"),
+
+		// Consent enforcement
+		ConsentCacheTTL: getEnvAsDuration("CONSENT_CACHE_TTL", 60*time.Second),
 	}
 }
 
@@ -32,7 +32,7 @@ func NewAdminAPI(consentClient *clients.ConsentClient) *AdminAPI {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        platform  query  string  false  "Filter by platform (Client, Merchant, Admin)"
-// @Success      200  {object}  object{code=string,message=string,data=object{total_consents=int64,active_consents=int64,revoked_consents=int64,consents_by_document=map[string]int64,consents_by_platform=map[string]int64,consents_by_method=map[string]int64}}
+// @Success      200  {object}  object{code=string,message=string,data=object{total_consents=int64,active_consents=int64,revoked_consents=int64,declined_consents=int64,consents_by_document=map[string]int64,consents_by_platform=map[string]int64,consents_by_method=map[string]int64}}
 // @Failure      401  {object}  object{code=string,message=string}
 // @Failure      403  {object}  object{code=string,message=string}
 // @Failure      500  {object}  object{code=string,message=string}
@@ -63,8 +63,66 @@ func (api *AdminAPI) GetConsentStats(c *gin.Context) {
 		"total_consents":       resp.TotalConsents,
 		"active_consents":      resp.ActiveConsents,
 		"revoked_consents":     resp.RevokedConsents,
+		"declined_consents":    resp.DeclinedConsents,
 		"consents_by_document": resp.ConsentsByDocument,
 		"consents_by_platform": resp.ConsentsByPlatform,
 		"consents_by_method":   resp.ConsentsByMethod,
 	})
 }
+
+// VerifyConsentChain godoc
+// @Summary      Verify a user's consent hash chain (Admin only)
+// @Description  Recompute the tamper-evident hash chain of a user's consents and report any modified or deleted record. Requires Admin role.
+// @Tags         Admin - Consent Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "User ID"
+// @Success      200  {object}  object{code=string,message=string,data=object{user_id=string,valid=bool,chain_length=int64,head_hash=string,issues=[]object}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/consents/{user_id}/verify-chain [get]
+func (api *AdminAPI) VerifyConsentChain(c *gin.Context) {
+	userID := c.Param("user_id")
+
+	log.Printf("[ADMIN] Verifying consent chain (user_id=%s)", userID)
+
+	// Forward to Consent Service
+	resp, err := api.consentClient.VerifyConsentChain(c.Request.Context(), &consentpb.VerifyConsentChainRequest{
+		UserId: userID,
+	})
+
+	if err != nil {
+		log.Printf("[ADMIN] Failed to verify consent chain: %v", err)
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	issues := make([]gin.H, len(resp.Issues))
+	for i, issue := range resp.Issues {
+		issues[i] = gin.H{
+			"seq_no":     issue.SeqNo,
+			"consent_id": issue.ConsentId,
+			"code":       issue.Code,
+			"message":    issue.Message,
+		}
+	}
+
+	message := "Consent chain is intact"
+	if !resp.Valid {
+		message = "Consent chain integrity check failed"
+	}
+
+	successResponse(c, http.StatusOK, message, gin.H{
+		"user_id":      resp.UserId,
+		"valid":        resp.Valid,
+		"chain_length": resp.ChainLength,
+		"head_hash":    resp.HeadHash,
+		"issues":       issues,
+	})
+}
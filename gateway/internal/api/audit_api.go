@@ -0,0 +1,311 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/thatlq1812/policy-system/gateway/internal/clients"
+	"github.com/thatlq1812/policy-system/gateway/internal/middleware"
+	auditpb "github.com/thatlq1812/policy-system/shared/pkg/api/audit"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+)
+
+// maxAuditMergeWindow - số entry tối đa lấy từ mỗi service khi gộp nhiều service
+// (page * page_size), sâu hơn thì phải lọc theo service hoặc khoảng thời gian
+const maxAuditMergeWindow = 1000
+
+// auditSource là service có audit log (User/Document/Consent client)
+type auditSource interface {
+	QueryAuditLog(ctx context.Context, req *auditpb.QueryAuditLogRequest) (*auditpb.QueryAuditLogResponse, error)
+}
+
+// AuditAPI xử lý endpoint tra cứu audit log, gộp audit log của các service
+type AuditAPI struct {
+	sources  map[string]auditSource
+	services []string // Thứ tự cố định khi gọi các service
+}
+
+// NewAuditAPI tạo mới AuditAPI handler
+func NewAuditAPI(userClient *clients.UserClient, documentClient *clients.DocumentClient, consentClient *clients.ConsentClient) *AuditAPI {
+	return &AuditAPI{
+		sources: map[string]auditSource{
+			"user":     userClient,
+			"document": documentClient,
+			"consent":  consentClient,
+		},
+		services: []string{"user", "document", "consent"},
+	}
+}
+
+// QueryAuditLog godoc
+// @Summary      Query the audit log (Admin only)
+// @Description  Query the append-only audit log of mutating operations across User, Document and Consent services, newest first. Each entry has actor, target, action, before/after values, request ID and IP. action accepts a prefix with "*" (e.g. "user.*").
+// @Tags         Admin - Audit
+// @Produce      json
+// @Security     BearerAuth
+// @Param        service      query  string  false  "Comma-separated services: user, document, consent (default: all)"
+// @Param        actor_id     query  string  false  "Filter by actor (user ID from JWT)"
+// @Param        action       query  string  false  "Filter by action, e.g. user.delete or policy.*"
+// @Param        target_type  query  string  false  "Filter by target type: user, session, policy, file, consent"
+// @Param        target_id    query  string  false  "Filter by target ID"
+// @Param        request_id   query  string  false  "Filter by X-Request-ID"
+// @Param        from         query  string  false  "From time (RFC3339 or Unix seconds)"
+// @Param        to           query  string  false  "To time (RFC3339 or Unix seconds)"
+// @Param        page         query  int     false  "Page number (default: 1)"
+// @Param        page_size    query  int     false  "Items per page (default: 20, max: 100)"
+// @Success      200  {object}  object{code=string,message=string,data=object{entries=[]object,total_count=int32,page=int32,page_size=int32,total_pages=int32}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/audit [get]
+func (api *AuditAPI) QueryAuditLog(c *gin.Context) {
+	services, err := api.parseServices(c.Query("service"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := parsePositiveInt(c.Query("page"), 1)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "page must be a positive integer")
+		return
+	}
+	pageSize, err := parsePositiveInt(c.Query("page_size"), audit.DefaultPageSize)
+	if err != nil || pageSize > audit.MaxPageSize {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("page_size must be between 1 and %d", audit.MaxPageSize))
+		return
+	}
+
+	from, err := parseAuditTime(c.Query("from"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "from must be RFC3339 or Unix seconds")
+		return
+	}
+	to, err := parseAuditTime(c.Query("to"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "to must be RFC3339 or Unix seconds")
+		return
+	}
+
+	filter := &auditpb.QueryAuditLogRequest{
+		ActorId:       c.Query("actor_id"),
+		Action:        c.Query("action"),
+		TargetType:    c.Query("target_type"),
+		TargetId:      c.Query("target_id"),
+		RequestId:     c.Query("request_id"),
+		FromTimestamp: from,
+		ToTimestamp:   to,
+	}
+
+	// 1 service: phân trang trực tiếp ở service
+	// Nhiều service: lấy page*page_size entry đầu của mỗi service rồi gộp theo thời gian
+	window := page * pageSize
+	if len(services) > 1 && window > maxAuditMergeWindow {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf(
+			"page * page_size must not exceed %d when querying several services, filter by service or time range", maxAuditMergeWindow))
+		return
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		entries []*auditpb.AuditEntry
+		total   int32
+		errs    []error
+	)
+	for _, name := range services {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			var got []*auditpb.AuditEntry
+			var count int32
+			var err error
+			if len(services) == 1 {
+				got, count, err = api.fetchPage(c.Request.Context(), api.sources[name], filter, page, pageSize)
+			} else {
+				got, count, err = api.fetchWindow(c.Request.Context(), api.sources[name], filter, window)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("[ADMIN] Failed to query %s audit log: %v", name, err)
+				errs = append(errs, err)
+				return
+			}
+			entries = append(entries, got...)
+			total += count
+		}(name)
+	}
+	wg.Wait()
+
+	// Audit log thiếu 1 service dễ gây hiểu nhầm → trả lỗi thay vì kết quả một phần
+	if len(errs) > 0 {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(errs[0])
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	if len(services) > 1 {
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].OccurredAt != entries[j].OccurredAt {
+				return entries[i].OccurredAt > entries[j].OccurredAt
+			}
+			return entries[i].Id > entries[j].Id
+		})
+		start := (page - 1) * pageSize
+		if start > len(entries) {
+			start = len(entries)
+		}
+		end := start + pageSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		entries = entries[start:end]
+	}
+
+	result := make([]gin.H, len(entries))
+	for i, e := range entries {
+		result[i] = auditEntryJSON(e)
+	}
+
+	successResponse(c, http.StatusOK, "Audit log retrieved successfully", gin.H{
+		"entries":     result,
+		"total_count": total,
+		"page":        page,
+		"page_size":   pageSize,
+		"total_pages": (int(total) + pageSize - 1) / pageSize,
+	})
+}
+
+// fetchPage lấy đúng 1 trang từ service
+func (api *AuditAPI) fetchPage(ctx context.Context, src auditSource, filter *auditpb.QueryAuditLogRequest, page, pageSize int) ([]*auditpb.AuditEntry, int32, error) {
+	req := proto.Clone(filter).(*auditpb.QueryAuditLogRequest)
+	req.Page = int32(page)
+	req.PageSize = int32(pageSize)
+
+	resp, err := src.QueryAuditLog(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Entries, resp.TotalCount, nil
+}
+
+// fetchWindow lấy tối đa window entry mới nhất từ service (nhiều trang nếu cần)
+func (api *AuditAPI) fetchWindow(ctx context.Context, src auditSource, filter *auditpb.QueryAuditLogRequest, window int) ([]*auditpb.AuditEntry, int32, error) {
+	pageSize := window
+	if pageSize > audit.MaxPageSize {
+		pageSize = audit.MaxPageSize
+	}
+
+	var entries []*auditpb.AuditEntry
+	var total int32
+	for page := 1; len(entries) < window; page++ {
+		got, count, err := api.fetchPage(ctx, src, filter, page, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, got...)
+		total = count
+		if len(got) < pageSize {
+			break
+		}
+	}
+	if len(entries) > window {
+		entries = entries[:window]
+	}
+	return entries, total, nil
+}
+
+func (api *AuditAPI) parseServices(raw string) ([]string, error) {
+	if raw == "" {
+		return api.services, nil
+	}
+
+	seen := make(map[string]bool)
+	var services []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := api.sources[name]; !ok {
+			return nil, fmt.Errorf("unknown service %q, must be one of: %s", name, strings.Join(api.services, ", "))
+		}
+		seen[name] = true
+		services = append(services, name)
+	}
+	if len(services) == 0 {
+		return api.services, nil
+	}
+	return services, nil
+}
+
+// auditEntryJSON chuyển entry sang JSON response, before/after giữ nguyên dạng object
+func auditEntryJSON(e *auditpb.AuditEntry) gin.H {
+	return gin.H{
+		"id":          e.Id,
+		"service":     e.Service,
+		"action":      e.Action,
+		"actor_id":    e.ActorId,
+		"actor_role":  e.ActorRole,
+		"target_type": e.TargetType,
+		"target_id":   e.TargetId,
+		"before":      rawJSONOrNil(e.BeforeJson),
+		"after":       rawJSONOrNil(e.AfterJson),
+		"reason":      e.Reason,
+		"request_id":  e.RequestId,
+		"ip_address":  e.IpAddress,
+		"user_agent":  e.UserAgent,
+		"occurred_at": time.UnixMilli(e.OccurredAt).UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func rawJSONOrNil(s string) any {
+	if s == "" {
+		return nil
+	}
+	return json.RawMessage(s)
+}
+
+// parseAuditTime nhận RFC3339 hoặc Unix seconds, trả về Unix milliseconds (0 = không lọc)
+func parseAuditTime(raw string) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	if sec, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return sec * 1000, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixMilli(), nil
+}
+
+func parsePositiveInt(raw string, def int) (int, error) {
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid positive integer %q", raw)
+	}
+	return n, nil
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/thatlq1812/policy-system/gateway/internal/clients"
+	"github.com/thatlq1812/policy-system/gateway/internal/middleware"
+	consentpb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+)
+
+// CampaignAPI cho Admin theo dõi re-consent campaigns: user đã đồng ý lại version bắt buộc mới chưa
+type CampaignAPI struct {
+	consentClient *clients.ConsentClient
+	cache         *middleware.ConsentCache // Grace deadline đổi thì kết quả enforcement đã cache không còn đúng
+}
+
+// NewCampaignAPI tạo mới CampaignAPI handler
+func NewCampaignAPI(consentClient *clients.ConsentClient, cache *middleware.ConsentCache) *CampaignAPI {
+	return &CampaignAPI{consentClient: consentClient, cache: cache}
+}
+
+// ListCampaigns godoc
+// @Summary      List re-consent campaigns (Admin only)
+// @Description  A campaign is started automatically when a new mandatory policy version becomes effective. Affected users are those who had consented to an earlier version; progress counts them as accepted, pending (within the grace period) or overdue (access restricted).
+// @Tags         Admin - Re-consent Campaigns
+// @Produce      json
+// @Security     BearerAuth
+// @Param        platform       query  string  false  "Client | Merchant | Admin"
+// @Param        document_name  query  string  false  "Document name"
+// @Param        status         query  string  false  "active | superseded"
+// @Param        page           query  int     false  "Page number (default: 1)"
+// @Param        page_size      query  int     false  "Items per page (default: 20, max: 100)"
+// @Success      200  {object}  object{code=string,message=string,data=object{campaigns=[]object,total_count=int32,page=int32,page_size=int32,total_pages=int32}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/reconsent-campaigns [get]
+func (api *CampaignAPI) ListCampaigns(c *gin.Context) {
+	page, err := parsePositiveInt(c.Query("page"), 1)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "page must be a positive integer")
+		return
+	}
+	pageSize, err := parsePositiveInt(c.Query("page_size"), 20)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "page_size must be a positive integer")
+		return
+	}
+
+	resp, err := api.consentClient.ListReconsentCampaigns(c.Request.Context(), &consentpb.ListReconsentCampaignsRequest{
+		Platform:     c.Query("platform"),
+		DocumentName: c.Query("document_name"),
+		Status:       c.Query("status"),
+		Page:         int32(page),
+		PageSize:     int32(pageSize),
+	})
+	if err != nil {
+		log.Printf("[ADMIN] Failed to list re-consent campaigns: %v", err)
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	campaigns := make([]gin.H, len(resp.Campaigns))
+	for i, campaign := range resp.Campaigns {
+		campaigns[i] = campaignJSON(campaign)
+	}
+
+	totalPages := int32(0)
+	if resp.PageSize > 0 {
+		totalPages = (resp.TotalCount + resp.PageSize - 1) / resp.PageSize
+	}
+	successResponse(c, http.StatusOK, "Re-consent campaigns retrieved successfully", gin.H{
+		"campaigns":   campaigns,
+		"total_count": resp.TotalCount,
+		"page":        resp.Page,
+		"page_size":   resp.PageSize,
+		"total_pages": totalPages,
+	})
+}
+
+// GetCampaign godoc
+// @Summary      Get re-consent campaign progress (Admin only)
+// @Description  Get a campaign with its accepted/pending/overdue counts
+// @Tags         Admin - Re-consent Campaigns
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Campaign ID"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/reconsent-campaigns/{id} [get]
+func (api *CampaignAPI) GetCampaign(c *gin.Context) {
+	resp, err := api.consentClient.GetReconsentCampaign(c.Request.Context(), &consentpb.GetReconsentCampaignRequest{
+		Id: c.Param("id"),
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Re-consent campaign retrieved successfully", campaignJSON(resp.Campaign))
+}
+
+// ListCampaignUsers godoc
+// @Summary      List affected users of a re-consent campaign (Admin only)
+// @Description  List users who must re-accept the new version, filtered by accepted, pending or overdue
+// @Tags         Admin - Re-consent Campaigns
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path   string  true   "Campaign ID"
+// @Param        status     query  string  false  "accepted | pending | overdue"
+// @Param        page       query  int     false  "Page number (default: 1)"
+// @Param        page_size  query  int     false  "Items per page (default: 20, max: 100)"
+// @Success      200  {object}  object{code=string,message=string,data=object{users=[]object,total_count=int32,page=int32,page_size=int32,total_pages=int32}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/reconsent-campaigns/{id}/users [get]
+func (api *CampaignAPI) ListCampaignUsers(c *gin.Context) {
+	page, err := parsePositiveInt(c.Query("page"), 1)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "page must be a positive integer")
+		return
+	}
+	pageSize, err := parsePositiveInt(c.Query("page_size"), 20)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "page_size must be a positive integer")
+		return
+	}
+
+	resp, err := api.consentClient.ListCampaignUsers(c.Request.Context(), &consentpb.ListCampaignUsersRequest{
+		CampaignId: c.Param("id"),
+		Status:     c.Query("status"),
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	users := make([]gin.H, len(resp.Users))
+	for i, u := range resp.Users {
+		users[i] = gin.H{
+			"user_id":                    u.UserId,
+			"previous_version_timestamp": u.PreviousVersionTimestamp,
+			"status":                     u.Status,
+			"accepted_at":                u.AcceptedAt,
+		}
+	}
+
+	totalPages := int32(0)
+	if resp.PageSize > 0 {
+		totalPages = (resp.TotalCount + resp.PageSize - 1) / resp.PageSize
+	}
+	successResponse(c, http.StatusOK, "Campaign users retrieved successfully", gin.H{
+		"users":       users,
+		"total_count": resp.TotalCount,
+		"page":        resp.Page,
+		"page_size":   resp.PageSize,
+		"total_pages": totalPages,
+	})
+}
+
+// UpdateCampaign godoc
+// @Summary      Change a re-consent campaign's grace deadline (Admin only)
+// @Description  Extend or shorten the grace period. Users who have not re-accepted by grace_deadline (Unix seconds) get 451 on protected endpoints. Reason is required and recorded in the audit log.
+// @Tags         Admin - Re-consent Campaigns
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string  true  "Campaign ID"
+// @Param        request  body  object{grace_deadline=int64,reason=string}  true  "New grace deadline"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/reconsent-campaigns/{id} [patch]
+func (api *CampaignAPI) UpdateCampaign(c *gin.Context) {
+	var reqBody struct {
+		GraceDeadline int64  `json:"grace_deadline" binding:"required"`
+		Reason        string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	adminID := c.GetString("user_id")
+	id := c.Param("id")
+	log.Printf("[ADMIN] Admin %s changing grace deadline of re-consent campaign %s to %d", adminID, id, reqBody.GraceDeadline)
+
+	resp, err := api.consentClient.UpdateReconsentCampaign(c.Request.Context(), &consentpb.UpdateReconsentCampaignRequest{
+		Id:            id,
+		GraceDeadline: reqBody.GraceDeadline,
+		AdminId:       adminID,
+		Reason:        reqBody.Reason,
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	// User đang bị chặn (hoặc sắp bị chặn) phải thấy deadline mới ngay
+	api.cache.InvalidateAll()
+
+	successResponse(c, http.StatusOK, "Re-consent campaign updated successfully", campaignJSON(resp.Campaign))
+}
+
+func campaignJSON(campaign *consentpb.ReconsentCampaign) gin.H {
+	return gin.H{
+		"id":                campaign.Id,
+		"platform":          campaign.Platform,
+		"document_name":     campaign.DocumentName,
+		"document_id":       campaign.DocumentId,
+		"version_timestamp": campaign.VersionTimestamp,
+		"status":            campaign.Status,
+		"grace_deadline":    campaign.GraceDeadline,
+		"affected_users":    campaign.AffectedUsers,
+		"accepted_users":    campaign.AcceptedUsers,
+		"pending_users":     campaign.PendingUsers,
+		"overdue_users":     campaign.OverdueUsers,
+		"superseded_by":     campaign.SupersededBy,
+		"updated_by":        campaign.UpdatedBy,
+		"created_at":        campaign.CreatedAt,
+		"updated_at":        campaign.UpdatedAt,
+	}
+}
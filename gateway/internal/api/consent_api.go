@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -9,44 +10,52 @@ import (
 	"github.com/thatlq1812/policy-system/gateway/internal/middleware"
 
 	pb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
 )
 
 // ConsentAPI xử lý các HTTP endpoints liên quan đến Consent
 type ConsentAPI struct {
 	client *clients.ConsentClient
+	cache  *middleware.ConsentCache // Invalidate khi consent thay đổi (có thể nil)
 }
 
 // NewConsentAPI tạo mới ConsentAPI handler
-func NewConsentAPI(client *clients.ConsentClient) *ConsentAPI {
-	return &ConsentAPI{client: client}
+func NewConsentAPI(client *clients.ConsentClient, cache *middleware.ConsentCache) *ConsentAPI {
+	return &ConsentAPI{client: client, cache: cache}
 }
 
 // RecordConsent godoc
 // @Summary      Record user consent
-// @Description  Record user consent for one or more policy documents with audit trail
+// @Description  Record consent of the authenticated user (from the JWT) for one or more policy documents. platform defaults to the token's platform_role. Admin tokens may set on_behalf_of (+ required reason) to record consent for another user; the admin is stored as recorded_by. IP address and user agent are always taken from the request.
 // @Tags         Consent Management
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        request body object{user_id=string,platform=string,consents=[]object,consent_method=string,ip_address=string,user_agent=string} true "Consent recording request"
+// @Param        request body object{platform=string,consents=[]object{document_id=string,document_name=string,version_timestamp=int64,agreed_file_url=string,locale=string,purposes=[]object{purpose=string,accepted=bool}},consent_method=string,on_behalf_of=string,reason=string} true "Consent recording request. locale is the language the user read (defaults to ?locale=/Accept-Language); the consent records the locale actually served. purposes accepts or declines the document's data-processing purposes individually: omitted required purposes are accepted, omitted optional ones declined, and required purposes cannot be declined. Sending different choices for an already consented version revokes the old consent and records a new one."
 // @Success      201  {object}  object{code=string,message=string,data=object{consents=[]object,recorded_count=int32}}
 // @Failure      400  {object}  object{code=string,message=string}
 // @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
 // @Failure      500  {object}  object{code=string,message=string}
 // @Router       /consents [post]
 func (api *ConsentAPI) RecordConsent(c *gin.Context) {
 	var reqBody struct {
-		UserID   string `json:"user_id" binding:"required"`
-		Platform string `json:"platform" binding:"required,oneof=Client Merchant Admin"`
+		UserID   string `json:"user_id"` // Deprecated: subject lấy từ JWT, nếu gửi phải trùng user đang đăng nhập
+		Platform string `json:"platform" binding:"omitempty,oneof=Client Merchant Admin"`
 		Consents []struct {
 			DocumentID       string `json:"document_id" binding:"required"`
 			DocumentName     string `json:"document_name" binding:"required"`
 			VersionTimestamp int64  `json:"version_timestamp" binding:"required"`
 			AgreedFileURL    string `json:"agreed_file_url"`
+			Locale           string `json:"locale"` // Locale nội dung user đã xem, mặc định theo Accept-Language
+			Purposes         []struct {
+				Purpose  string `json:"purpose"`
+				Accepted bool   `json:"accepted"`
+			} `json:"purposes"` // Đồng ý/từ chối từng purpose của document
 		} `json:"consents" binding:"required,min=1"`
 		ConsentMethod string `json:"consent_method"`
-		IPAddress     string `json:"ip_address"`
-		UserAgent     string `json:"user_agent"`
+		OnBehalfOf    string `json:"on_behalf_of"` // Admin only: user được ghi consent thay
+		Reason        string `json:"reason"`       // Bắt buộc khi on_behalf_of
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -57,32 +66,57 @@ func (api *ConsentAPI) RecordConsent(c *gin.Context) {
 		return
 	}
 
-	// Auto-fill IP and UserAgent if not provided
-	if reqBody.IPAddress == "" {
-		reqBody.IPAddress = c.ClientIP()
+	subject, ok := resolveConsentSubject(c, reqBody.UserID, reqBody.OnBehalfOf, reqBody.Reason)
+	if !ok {
+		return
 	}
-	if reqBody.UserAgent == "" {
-		reqBody.UserAgent = c.GetHeader("User-Agent")
+
+	// User tự đồng ý: platform của chính user (trong JWT)
+	// Admin ghi thay: platform của user đích phải được chỉ định
+	platform := reqBody.Platform
+	if subject.ActingAdminID == "" {
+		if platform != "" && platform != subject.Platform {
+			errorResponse(c, http.StatusForbidden, "platform must match the authenticated user's platform")
+			return
+		}
+		platform = subject.Platform
+	} else if platform == "" {
+		errorResponse(c, http.StatusBadRequest, "platform is required when acting on behalf of another user")
+		return
 	}
 
 	// Convert to proto format
 	consentInputs := make([]*pb.ConsentInput, len(reqBody.Consents))
 	for i, consent := range reqBody.Consents {
+		locale := consent.Locale
+		if locale == "" {
+			locale = c.GetString("locale")
+		}
 		consentInputs[i] = &pb.ConsentInput{
 			DocumentId:       consent.DocumentID,
 			DocumentName:     consent.DocumentName,
 			VersionTimestamp: consent.VersionTimestamp,
 			AgreedFileUrl:    consent.AgreedFileURL,
+			Locale:           locale,
+		}
+		for _, p := range consent.Purposes {
+			consentInputs[i].Purposes = append(consentInputs[i].Purposes, &pb.PurposeChoice{
+				Purpose:  p.Purpose,
+				Accepted: p.Accepted,
+			})
 		}
 	}
 
+	// IP và User-Agent luôn lấy từ request thực tế (bằng chứng, không nhận từ body)
 	grpcReq := &pb.RecordConsentRequest{
-		UserId:        reqBody.UserID,
-		Platform:      reqBody.Platform,
-		Consents:      consentInputs,
-		ConsentMethod: reqBody.ConsentMethod,
-		IpAddress:     reqBody.IPAddress,
-		UserAgent:     reqBody.UserAgent,
+		UserId:         subject.UserID,
+		Platform:       platform,
+		Consents:       consentInputs,
+		ConsentMethod:  reqBody.ConsentMethod,
+		IpAddress:      c.ClientIP(),
+		UserAgent:      c.GetHeader("User-Agent"),
+		ActingAdminId:  subject.ActingAdminID,
+		OnBehalfReason: subject.Reason,
 	}
 
 	grpcResp, err := api.client.RecordConsent(c.Request.Context(), grpcReq)
@@ -95,20 +129,29 @@ func (api *ConsentAPI) RecordConsent(c *gin.Context) {
 		return
 	}
 
+	// Consent mới → kết quả pending check cũ không còn đúng
+	api.cache.Invalidate(subject.UserID)
+
 	// Convert consents to response format
 	consents := make([]gin.H, len(grpcResp.Consents))
 	for i, consent := range grpcResp.Consents {
 		consents[i] = gin.H{
-			"id":                consent.Id,
-			"user_id":           consent.UserId,
-			"platform":          consent.Platform,
-			"document_id":       consent.DocumentId,
-			"document_name":     consent.DocumentName,
-			"version_timestamp": consent.VersionTimestamp,
-			"agreed_at":         consent.AgreedAt,
-			"agreed_file_url":   consent.AgreedFileUrl,
-			"consent_method":    consent.ConsentMethod,
-			"ip_address":        consent.IpAddress,
+			"id":                    consent.Id,
+			"user_id":               consent.UserId,
+			"platform":              consent.Platform,
+			"document_id":           consent.DocumentId,
+			"document_name":         consent.DocumentName,
+			"version_timestamp":     consent.VersionTimestamp,
+			"agreed_at":             consent.AgreedAt,
+			"agreed_file_url":       consent.AgreedFileUrl,
+			"consent_method":        consent.ConsentMethod,
+			"ip_address":            consent.IpAddress,
+			"document_content_hash": consent.DocumentContentHash,
+			"locale":                consent.Locale,
+			"recorded_by":           consent.RecordedBy,
+			"purposes":              purposeChoicesJSON(consent.Purposes),
+			"status":                consent.Status,
+			"erased_at":             consent.ErasedAt,
 		}
 	}
 
@@ -124,20 +167,23 @@ func (api *ConsentAPI) RecordConsent(c *gin.Context) {
 
 // CheckConsent godoc
 // @Summary      Check user consent status
-// @Description  Check if a user has consented to a specific document and optionally verify minimum version
+// @Description  Check if the authenticated user has consented to a specific document and optionally verify minimum version. Admin tokens may set on_behalf_of (+ required reason) to check another user.
 // @Tags         Consent Management
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        request body object{user_id=string,document_id=string,min_version_timestamp=int64} true "Consent check request"
+// @Param        request body object{document_id=string,min_version_timestamp=int64,on_behalf_of=string,reason=string} true "Consent check request"
 // @Success      200  {object}  object{code=string,message=string,data=object{has_consented=bool,latest_consent=object}}
 // @Failure      400  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
 // @Router       /consents/check [post]
 func (api *ConsentAPI) CheckConsent(c *gin.Context) {
 	var reqBody struct {
-		UserID              string `json:"user_id" binding:"required"`
+		UserID              string `json:"user_id"` // Deprecated: subject lấy từ JWT
 		DocumentID          string `json:"document_id" binding:"required"`
 		MinVersionTimestamp int64  `json:"min_version_timestamp"`
+		OnBehalfOf          string `json:"on_behalf_of"` // Admin only
+		Reason              string `json:"reason"`       // Bắt buộc khi on_behalf_of
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -148,8 +194,13 @@ func (api *ConsentAPI) CheckConsent(c *gin.Context) {
 		return
 	}
 
+	subject, ok := resolveConsentSubject(c, reqBody.UserID, reqBody.OnBehalfOf, reqBody.Reason)
+	if !ok {
+		return
+	}
+
 	grpcReq := &pb.CheckConsentRequest{
-		UserId:              reqBody.UserID,
+		UserId:              subject.UserID,
 		DocumentId:          reqBody.DocumentID,
 		MinVersionTimestamp: reqBody.MinVersionTimestamp,
 	}
@@ -170,11 +221,13 @@ func (api *ConsentAPI) CheckConsent(c *gin.Context) {
 
 	if grpcResp.LatestConsent != nil {
 		data["latest_consent"] = gin.H{
-			"id":                grpcResp.LatestConsent.Id,
-			"user_id":           grpcResp.LatestConsent.UserId,
-			"document_id":       grpcResp.LatestConsent.DocumentId,
-			"version_timestamp": grpcResp.LatestConsent.VersionTimestamp,
-			"agreed_at":         grpcResp.LatestConsent.AgreedAt,
+			"id":                    grpcResp.LatestConsent.Id,
+			"user_id":               grpcResp.LatestConsent.UserId,
+			"document_id":           grpcResp.LatestConsent.DocumentId,
+			"version_timestamp":     grpcResp.LatestConsent.VersionTimestamp,
+			"agreed_at":             grpcResp.LatestConsent.AgreedAt,
+			"document_content_hash": grpcResp.LatestConsent.DocumentContentHash,
+			"locale":                grpcResp.LatestConsent.Locale,
 		}
 	}
 
@@ -187,30 +240,28 @@ func (api *ConsentAPI) CheckConsent(c *gin.Context) {
 
 // GetUserConsents godoc
 // @Summary      Get user's consent history
-// @Description  Retrieve all consent records for a specific user with optional filtering
+// @Description  Retrieve all consent records of the authenticated user with optional filtering. Admin tokens may set on_behalf_of (+ required reason) to read another user's consents.
 // @Tags         Consent Management
 // @Produce      json
 // @Security     BearerAuth
-// @Param        user_id         query  string  true   "User ID"
 // @Param        include_deleted query  bool    false  "Include revoked consents (default: false)"
+// @Param        on_behalf_of    query  string  false  "Admin only: user ID to read consents for"
+// @Param        reason          query  string  false  "Required with on_behalf_of: why the admin reads this user's consents"
 // @Success      200  {object}  object{code=string,message=string,data=object{consents=[]object,total_count=int32}}
 // @Failure      400  {object}  object{code=string,message=string}
 // @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
 // @Router       /consents/user [get]
 func (api *ConsentAPI) GetUserConsents(c *gin.Context) {
-	userID := c.Query("user_id")
 	includeDeleted := c.Query("include_deleted") == "true"
 
-	if userID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    "400",
-			"message": "user_id query parameter is required",
-		})
+	subject, ok := resolveConsentSubject(c, c.Query("user_id"), c.Query("on_behalf_of"), c.Query("reason"))
+	if !ok {
 		return
 	}
 
 	grpcReq := &pb.GetUserConsentsRequest{
-		UserId:         userID,
+		UserId:         subject.UserID,
 		IncludeDeleted: includeDeleted,
 	}
 
@@ -227,15 +278,25 @@ func (api *ConsentAPI) GetUserConsents(c *gin.Context) {
 	consents := make([]gin.H, len(grpcResp.Consents))
 	for i, consent := range grpcResp.Consents {
 		consents[i] = gin.H{
-			"id":                consent.Id,
-			"user_id":           consent.UserId,
-			"platform":          consent.Platform,
-			"document_id":       consent.DocumentId,
-			"document_name":     consent.DocumentName,
-			"version_timestamp": consent.VersionTimestamp,
-			"agreed_at":         consent.AgreedAt,
-			"is_deleted":        consent.IsDeleted,
-			"deleted_at":        consent.DeletedAt,
+			"id":                    consent.Id,
+			"user_id":               consent.UserId,
+			"platform":              consent.Platform,
+			"document_id":           consent.DocumentId,
+			"document_name":         consent.DocumentName,
+			"version_timestamp":     consent.VersionTimestamp,
+			"agreed_at":             consent.AgreedAt,
+			"is_deleted":            consent.IsDeleted,
+			"deleted_at":            consent.DeletedAt,
+			"document_content_hash": consent.DocumentContentHash,
+			"locale":                consent.Locale,
+			"recorded_by":           consent.RecordedBy,
+			"on_behalf_reason":      consent.OnBehalfReason,
+			"revoked_by":            consent.RevokedBy,
+			"revoked_reason":        consent.RevokedReason,
+			"expires_at":            consent.ExpiresAt,
+			"expired_at":            consent.ExpiredAt,
+			"purposes":              purposeChoicesJSON(consent.Purposes),
+			"status":                consent.Status,
 		}
 	}
 
@@ -251,25 +312,27 @@ func (api *ConsentAPI) GetUserConsents(c *gin.Context) {
 
 // CheckPendingConsents godoc
 // @Summary      Check pending consents
-// @Description  Check if user has any mandatory policies that haven't been consented to yet
+// @Description  Check which policies the authenticated user hasn't consented to yet. When latest_policies is omitted, the Consent Service resolves the active policies of the platform itself (recommended). platform defaults to the token's platform_role. Admin tokens may set on_behalf_of (+ required reason and platform) to check another user.
 // @Tags         Consent Management
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        request body object{user_id=string,platform=string,latest_policies=[]object} true "Pending consent check request"
+// @Param        request body object{platform=string,latest_policies=[]object,on_behalf_of=string,reason=string} true "Pending consent check request"
 // @Success      200  {object}  object{code=string,message=string,data=object{has_pending=bool,pending_documents=[]object,total_pending=int32}}
 // @Failure      400  {object}  object{code=string,message=string}
 // @Router       /consents/pending [post]
 func (api *ConsentAPI) CheckPendingConsents(c *gin.Context) {
 	var reqBody struct {
-		UserID         string `json:"user_id" binding:"required"`
-		Platform       string `json:"platform" binding:"required,oneof=Client Merchant Admin"`
+		UserID         string `json:"user_id"` // Deprecated: subject lấy từ JWT
+		Platform       string `json:"platform" binding:"omitempty,oneof=Client Merchant Admin"`
+		OnBehalfOf     string `json:"on_behalf_of"` // Admin only
+		Reason         string `json:"reason"`       // Bắt buộc khi on_behalf_of
 		LatestPolicies []struct {
 			DocumentID       string `json:"document_id" binding:"required"`
 			DocumentName     string `json:"document_name" binding:"required"`
 			VersionTimestamp int64  `json:"version_timestamp" binding:"required"`
 			Platform         string `json:"platform" binding:"required,oneof=Client Merchant Admin"`
-		} `json:"latest_policies" binding:"required"`
+		} `json:"latest_policies" binding:"omitempty,dive"`
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -280,6 +343,20 @@ func (api *ConsentAPI) CheckPendingConsents(c *gin.Context) {
 		return
 	}
 
+	subject, ok := resolveConsentSubject(c, reqBody.UserID, reqBody.OnBehalfOf, reqBody.Reason)
+	if !ok {
+		return
+	}
+
+	platform := reqBody.Platform
+	if platform == "" {
+		platform = subject.Platform
+	}
+	if platform == "" {
+		errorResponse(c, http.StatusBadRequest, "platform is required when acting on behalf of another user")
+		return
+	}
+
 	policies := make([]*pb.PendingPolicy, len(reqBody.LatestPolicies))
 	for i, p := range reqBody.LatestPolicies {
 		policies[i] = &pb.PendingPolicy{
@@ -291,9 +368,11 @@ func (api *ConsentAPI) CheckPendingConsents(c *gin.Context) {
 	}
 
 	grpcReq := &pb.CheckPendingConsentsRequest{
-		UserId:         reqBody.UserID,
-		Platform:       reqBody.Platform,
+		UserId:         subject.UserID,
+		Platform:       platform,
 		LatestPolicies: policies,
+		// Không truyền latest_policies → Consent Service tự resolve từ Document Service
+		ResolveActivePolicies: len(policies) == 0,
 	}
 
 	grpcResp, err := api.client.CheckPendingConsents(c.Request.Context(), grpcReq)
@@ -309,10 +388,14 @@ func (api *ConsentAPI) CheckPendingConsents(c *gin.Context) {
 	pendingPolicies := make([]gin.H, len(grpcResp.PendingPolicies))
 	for i, p := range grpcResp.PendingPolicies {
 		pendingPolicies[i] = gin.H{
-			"document_id":       p.DocumentId,
-			"document_name":     p.DocumentName,
-			"version_timestamp": p.VersionTimestamp,
-			"platform":          p.Platform,
+			"document_id":        p.DocumentId,
+			"document_name":      p.DocumentName,
+			"version_timestamp":  p.VersionTimestamp,
+			"platform":           p.Platform,
+			"is_mandatory":       p.IsMandatory,
+			"grace_deadline":     p.GraceDeadline,
+			"is_overdue":         p.IsOverdue,
+			"consent_expired_at": p.ConsentExpiredAt,
 		}
 	}
 
@@ -328,22 +411,25 @@ func (api *ConsentAPI) CheckPendingConsents(c *gin.Context) {
 
 // RevokeConsent godoc
 // @Summary      Revoke user consent
-// @Description  Revoke a previously given consent (GDPR compliance)
+// @Description  Withdraw a consent previously given by the authenticated user (GDPR compliance). reason is optional for users (default user_request). Admin tokens may set on_behalf_of (+ required reason) to revoke for another user; the admin is stored as revoked_by.
 // @Tags         Consent Management
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        request body object{user_id=string,document_id=string,version_timestamp=int64} true "Consent revocation request"
+// @Param        request body object{document_id=string,version_timestamp=int64,on_behalf_of=string,reason=string} true "Consent revocation request"
 // @Success      200  {object}  object{code=string,message=string}
 // @Failure      400  {object}  object{code=string,message=string}
 // @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
 // @Failure      404  {object}  object{code=string,message=string}
 // @Router       /consents/revoke [post]
 func (api *ConsentAPI) RevokeConsent(c *gin.Context) {
 	var reqBody struct {
-		UserID           string `json:"user_id" binding:"required"`
+		UserID           string `json:"user_id"` // Deprecated: subject lấy từ JWT
 		DocumentID       string `json:"document_id" binding:"required"`
 		VersionTimestamp int64  `json:"version_timestamp"`
+		OnBehalfOf       string `json:"on_behalf_of"` // Admin only
+		Reason           string `json:"reason"`       // Bắt buộc khi on_behalf_of, tùy chọn khi user tự thu hồi
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -354,10 +440,17 @@ func (api *ConsentAPI) RevokeConsent(c *gin.Context) {
 		return
 	}
 
+	subject, ok := resolveConsentSubject(c, reqBody.UserID, reqBody.OnBehalfOf, reqBody.Reason)
+	if !ok {
+		return
+	}
+
 	grpcReq := &pb.RevokeConsentRequest{
-		UserId:           reqBody.UserID,
+		UserId:           subject.UserID,
 		DocumentId:       reqBody.DocumentID,
 		VersionTimestamp: reqBody.VersionTimestamp,
+		ActingAdminId:    subject.ActingAdminID,
+		Reason:           reqBody.Reason,
 	}
 
 	grpcResp, err := api.client.RevokeConsent(c.Request.Context(), grpcReq)
@@ -370,6 +463,9 @@ func (api *ConsentAPI) RevokeConsent(c *gin.Context) {
 		return
 	}
 
+	// Revoke policy bắt buộc → user phải đồng ý lại, bỏ kết quả cache cũ
+	api.cache.Invalidate(subject.UserID)
+
 	c.JSON(http.StatusOK, gin.H{
 		"code":    "200",
 		"message": grpcResp.Message,
@@ -378,3 +474,284 @@ func (api *ConsentAPI) RevokeConsent(c *gin.Context) {
 		},
 	})
 }
+
+// DeclineConsent godoc
+// @Summary      Decline an optional policy
+// @Description  Record that the authenticated user actively refused an optional policy version (mandatory policies cannot be declined). A declined version is no longer reported as pending; the user can still accept it later. platform defaults to the token's platform_role. Admin tokens may set on_behalf_of (+ required reason and platform).
+// @Tags         Consent Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body object{platform=string,document_id=string,document_name=string,version_timestamp=int64,consent_method=string,reason=string,on_behalf_of=string} true "Refusal request"
+// @Success      201  {object}  object{code=string,message=string,data=object}
+// @Failure      400  {object}  object{code=string,message=string} "Mandatory policy or the user already consented (withdraw it instead)"
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Router       /consents/decline [post]
+func (api *ConsentAPI) DeclineConsent(c *gin.Context) {
+	var reqBody struct {
+		Platform         string `json:"platform" binding:"omitempty,oneof=Client Merchant Admin"`
+		DocumentID       string `json:"document_id" binding:"required"`
+		DocumentName     string `json:"document_name" binding:"required"`
+		VersionTimestamp int64  `json:"version_timestamp" binding:"required"`
+		ConsentMethod    string `json:"consent_method"`
+		Reason           string `json:"reason"`       // Tùy chọn, bắt buộc khi on_behalf_of
+		OnBehalfOf       string `json:"on_behalf_of"` // Admin only
+	}
+
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	subject, ok := resolveConsentSubject(c, "", reqBody.OnBehalfOf, reqBody.Reason)
+	if !ok {
+		return
+	}
+
+	platform := reqBody.Platform
+	if subject.ActingAdminID == "" {
+		if platform != "" && platform != subject.Platform {
+			errorResponse(c, http.StatusForbidden, "platform must match the authenticated user's platform")
+			return
+		}
+		platform = subject.Platform
+	} else if platform == "" {
+		errorResponse(c, http.StatusBadRequest, "platform is required when acting on behalf of another user")
+		return
+	}
+
+	consentMethod := reqBody.ConsentMethod
+	if consentMethod == "" {
+		consentMethod = "UI"
+	}
+
+	grpcResp, err := api.client.DeclineConsent(c.Request.Context(), &pb.DeclineConsentRequest{
+		UserId:           subject.UserID,
+		Platform:         platform,
+		DocumentId:       reqBody.DocumentID,
+		DocumentName:     reqBody.DocumentName,
+		VersionTimestamp: reqBody.VersionTimestamp,
+		ConsentMethod:    consentMethod,
+		IpAddress:        c.ClientIP(),
+		UserAgent:        c.GetHeader("User-Agent"),
+		Reason:           reqBody.Reason,
+		ActingAdminId:    subject.ActingAdminID,
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	// Policy đã từ chối không còn pending
+	api.cache.Invalidate(subject.UserID)
+
+	consent := grpcResp.Consent
+	successResponse(c, http.StatusCreated, "Consent declined successfully", gin.H{
+		"id":                consent.Id,
+		"user_id":           consent.UserId,
+		"platform":          consent.Platform,
+		"document_id":       consent.DocumentId,
+		"document_name":     consent.DocumentName,
+		"version_timestamp": consent.VersionTimestamp,
+		"status":            consent.Status,
+		"declined_at":       consent.AgreedAt,
+		"declined_by":       consent.RevokedBy,
+		"reason":            consent.RevokedReason,
+	})
+}
+
+// GetConsentHistory godoc
+// @Summary      Get consent timeline of a document
+// @Description  Every consent record of the authenticated user for a document (all versions, including withdrawn, declined and expired ones) and the resulting timeline: granted → withdrawn → granted again... oldest first. Admin tokens may set on_behalf_of (+ required reason) to read another user's history.
+// @Tags         Consent Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        document_id   query  string  true   "Document ID"
+// @Param        on_behalf_of  query  string  false  "Admin only: user ID to read history for"
+// @Param        reason        query  string  false  "Required with on_behalf_of: why the admin reads this user's history"
+// @Success      200  {object}  object{code=string,message=string,data=object{history=[]object,timeline=[]object{consent_id=string,version_timestamp=int64,action=string,at=int64,actor_id=string,reason=string},total=int32}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Router       /consents/history [get]
+func (api *ConsentAPI) GetConsentHistory(c *gin.Context) {
+	subject, ok := resolveConsentSubject(c, "", c.Query("on_behalf_of"), c.Query("reason"))
+	if !ok {
+		return
+	}
+
+	documentID := c.Query("document_id")
+	if documentID == "" {
+		errorResponse(c, http.StatusBadRequest, "document_id is required")
+		return
+	}
+
+	grpcResp, err := api.client.GetConsentHistory(c.Request.Context(), &pb.GetConsentHistoryRequest{
+		UserId:     subject.UserID,
+		DocumentId: documentID,
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	history := make([]gin.H, len(grpcResp.History))
+	for i, consent := range grpcResp.History {
+		history[i] = gin.H{
+			"id":                consent.Id,
+			"document_id":       consent.DocumentId,
+			"document_name":     consent.DocumentName,
+			"version_timestamp": consent.VersionTimestamp,
+			"status":            consent.Status,
+			"agreed_at":         consent.AgreedAt,
+			"consent_method":    consent.ConsentMethod,
+			"recorded_by":       consent.RecordedBy,
+			"revoked_at":        consent.RevokedAt,
+			"revoked_by":        consent.RevokedBy,
+			"revoked_reason":    consent.RevokedReason,
+			"expires_at":        consent.ExpiresAt,
+			"expired_at":        consent.ExpiredAt,
+			"purposes":          purposeChoicesJSON(consent.Purposes),
+		}
+	}
+
+	timeline := make([]gin.H, len(grpcResp.Timeline))
+	for i, e := range grpcResp.Timeline {
+		timeline[i] = gin.H{
+			"consent_id":        e.ConsentId,
+			"document_name":     e.DocumentName,
+			"version_timestamp": e.VersionTimestamp,
+			"action":            e.Action,
+			"at":                e.At,
+			"actor_id":          e.ActorId,
+			"reason":            e.Reason,
+		}
+	}
+
+	successResponse(c, http.StatusOK, "Consent history retrieved successfully", gin.H{
+		"history":  history,
+		"timeline": timeline,
+		"total":    grpcResp.Total,
+	})
+}
+
+// CheckPurposeConsent godoc
+// @Summary      Check consent to a data-processing purpose
+// @Description  Return the authenticated user's latest decision on a purpose (e.g. marketing, analytics) across their valid consents. allowed is false when the user declined the purpose or has not decided yet (has_decision=false). Admin tokens may set on_behalf_of (+ required reason) to check another user.
+// @Tags         Consent Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        purpose       path   string  true   "Purpose key"
+// @Param        on_behalf_of  query  string  false  "Admin only: user ID to check"
+// @Param        reason        query  string  false  "Required with on_behalf_of: why the admin checks this user"
+// @Success      200  {object}  object{code=string,message=string,data=object{purpose=string,allowed=bool,has_decision=bool,consent_id=string,document_id=string,document_name=string,version_timestamp=int64,decided_at=int64}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Router       /consents/purposes/{purpose} [get]
+func (api *ConsentAPI) CheckPurposeConsent(c *gin.Context) {
+	subject, ok := resolveConsentSubject(c, "", c.Query("on_behalf_of"), c.Query("reason"))
+	if !ok {
+		return
+	}
+
+	purpose := c.Param("purpose")
+	grpcResp, err := api.client.CheckPurposeConsent(c.Request.Context(), &pb.CheckPurposeConsentRequest{
+		UserId:  subject.UserID,
+		Purpose: purpose,
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    "200",
+		"message": "Success",
+		"data": gin.H{
+			"purpose":           purpose,
+			"allowed":           grpcResp.Allowed,
+			"has_decision":      grpcResp.HasDecision,
+			"consent_id":        grpcResp.ConsentId,
+			"document_id":       grpcResp.DocumentId,
+			"document_name":     grpcResp.DocumentName,
+			"version_timestamp": grpcResp.VersionTimestamp,
+			"decided_at":        grpcResp.DecidedAt,
+		},
+	})
+}
+
+// purposeChoicesJSON - lựa chọn đồng ý/từ chối theo purpose của consent
+func purposeChoicesJSON(purposes []*pb.PurposeChoice) []gin.H {
+	result := make([]gin.H, len(purposes))
+	for i, p := range purposes {
+		result[i] = gin.H{
+			"purpose":  p.Purpose,
+			"accepted": p.Accepted,
+		}
+	}
+	return result
+}
+
+// consentSubject là user mà request consent áp dụng cho
+type consentSubject struct {
+	UserID        string // User trong JWT, hoặc user được Admin thao tác thay
+	Platform      string // platform_role trong JWT (rỗng khi Admin thao tác thay)
+	ActingAdminID string // Admin thao tác thay, rỗng = user tự thao tác
+	Reason        string
+}
+
+// resolveConsentSubject lấy subject từ JWT claims (AuthMiddlewareWithBlacklist), không tin user_id trong request.
+// Chỉ Admin token được chỉ định on_behalf_of để thao tác thay user khác, kể cả chỉ đọc, và luôn phải kèm lý do.
+// Thông tin thao tác thay được chuyển xuống Consent Service qua gRPC metadata để ghi audit log.
+// Trả về false nếu đã ghi error response.
+func resolveConsentSubject(c *gin.Context, requestedUserID, onBehalfOf, reason string) (*consentSubject, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok || userID == "" {
+		errorResponse(c, http.StatusUnauthorized, "User info not found. Did you apply AuthMiddleware first?")
+		return nil, false
+	}
+	role, _ := middleware.GetPlatformRole(c)
+
+	// user_id cũ trong request chỉ được phép là chính user đang đăng nhập (hoặc trùng on_behalf_of)
+	if requestedUserID != "" && requestedUserID != userID && requestedUserID != onBehalfOf {
+		errorResponse(c, http.StatusForbidden, "user_id must match the authenticated user; admins use on_behalf_of")
+		return nil, false
+	}
+
+	if onBehalfOf == "" || onBehalfOf == userID {
+		return &consentSubject{UserID: userID, Platform: role}, true
+	}
+
+	if role != "Admin" {
+		errorResponse(c, http.StatusForbidden, "Only admins can act on behalf of another user")
+		return nil, false
+	}
+	if strings.TrimSpace(reason) == "" {
+		errorResponse(c, http.StatusBadRequest, "reason is required when acting on behalf of another user")
+		return nil, false
+	}
+
+	// Consent Service ghi audit event admin.act_on_behalf (audit.UnaryServerInterceptor)
+	info, _ := audit.RequestInfoFromContext(c.Request.Context())
+	info.OnBehalfOf = onBehalfOf
+	info.OnBehalfReason = reason
+	info.HTTPRoute = c.Request.Method + " " + c.FullPath()
+	c.Request = c.Request.WithContext(audit.WithRequestInfo(c.Request.Context(), info))
+
+	return &consentSubject{UserID: onBehalfOf, ActingAdminID: userID, Reason: reason}, true
+}
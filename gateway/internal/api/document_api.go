@@ -1,7 +1,14 @@
 package api
 
 import (
+	"fmt"
+	"html/template"
+	"io"
+	"log"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -22,16 +29,17 @@ func NewDocumentAPI(client *clients.DocumentClient) *DocumentAPI {
 }
 
 // CreatePolicy godoc
-// @Summary      Create new policy document
-// @Description  Create a new policy document with version tracking and platform targeting
+// @Summary      Create new policy document (Admin only)
+// @Description  Create a new policy document version. The version is a draft (is_draft=true) or pending review and only becomes visible after another admin approves it. created_by is taken from the JWT.
 // @Tags         Policy Management
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        request body object{document_name=string,platform=string,is_mandatory=bool,effective_timestamp=int64,content_html=string,file_url=string,created_by=string} true "Policy document details. platform must be one of: Client, Merchant, Admin"
-// @Success      201  {object}  object{code=string,message=string,data=object{id=string,document_name=string,platform=string,is_mandatory=bool,effective_timestamp=int64,content_html=string,file_url=string,created_at=int64,created_by=string}}
+// @Param        request body object{document_name=string,platform=string,is_mandatory=bool,effective_timestamp=int64,content_html=string,file_url=string,file_hash=string,is_draft=bool,locale=string,translations=[]object{locale=string,content_html=string,file_hash=string},consent_validity_period=int32,purposes=[]object{key=string,name=string,description=string,is_required=bool}} true "Policy document details. platform must be one of: Client, Merchant, Admin. file_hash refers to a file uploaded via /admin/files. locale (vi, en; default vi) is the language of content_html/file_hash, translations carry the same version in other locales. consent_validity_period is the number of days a consent stays valid before users must re-confirm it (0 = never expires). purposes lists data-processing purposes (e.g. marketing, analytics) users accept or decline individually; required purposes cannot be declined"
+// @Success      201  {object}  object{code=string,message=string,data=object{id=string,document_name=string,platform=string,is_mandatory=bool,effective_timestamp=int64,content_html=string,file_url=string,created_at=int64,created_by=string,status=string,consent_validity_period=int32,purposes=[]object}}
 // @Failure      400  {object}  object{code=string,message=string}
 // @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
 // @Failure      500  {object}  object{code=string,message=string}
 // @Router       /policies [post]
 func (api *DocumentAPI) CreatePolicy(c *gin.Context) {
@@ -42,7 +50,21 @@ func (api *DocumentAPI) CreatePolicy(c *gin.Context) {
 		EffectiveTimestamp int64  `json:"effective_timestamp"`
 		ContentHTML        string `json:"content_html"`
 		FileURL            string `json:"file_url"`
-		CreatedBy          string `json:"created_by"`
+		FileHash           string `json:"file_hash"`
+		IsDraft            bool   `json:"is_draft"`
+		Locale             string `json:"locale"`
+		Translations       []struct {
+			Locale      string `json:"locale" binding:"required"`
+			ContentHTML string `json:"content_html"`
+			FileHash    string `json:"file_hash"`
+		} `json:"translations" binding:"dive"`
+		ConsentValidityPeriod int32 `json:"consent_validity_period" binding:"min=0"`
+		Purposes              []struct {
+			Key         string `json:"key" binding:"required"`
+			Name        string `json:"name" binding:"required"`
+			Description string `json:"description"`
+			IsRequired  bool   `json:"is_required"`
+		} `json:"purposes" binding:"dive"`
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -54,13 +76,32 @@ func (api *DocumentAPI) CreatePolicy(c *gin.Context) {
 	}
 
 	grpcReq := &pb.CreateDocumentRequest{
-		DocumentName:       reqBody.DocumentName,
-		Platform:           reqBody.Platform,
-		IsMandatory:        reqBody.IsMandatory,
-		EffectiveTimestamp: reqBody.EffectiveTimestamp,
-		ContentHtml:        reqBody.ContentHTML,
-		FileUrl:            reqBody.FileURL,
-		CreatedBy:          reqBody.CreatedBy,
+		DocumentName:          reqBody.DocumentName,
+		Platform:              reqBody.Platform,
+		IsMandatory:           reqBody.IsMandatory,
+		EffectiveTimestamp:    reqBody.EffectiveTimestamp,
+		ContentHtml:           reqBody.ContentHTML,
+		FileUrl:               reqBody.FileURL,
+		FileHash:              reqBody.FileHash,
+		CreatedBy:             c.GetString("user_id"), // Lấy từ JWT, không tin body để review flow có ý nghĩa
+		IsDraft:               reqBody.IsDraft,
+		Locale:                reqBody.Locale,
+		ConsentValidityPeriod: reqBody.ConsentValidityPeriod,
+	}
+	for _, t := range reqBody.Translations {
+		grpcReq.Translations = append(grpcReq.Translations, &pb.PolicyTranslation{
+			Locale:      t.Locale,
+			ContentHtml: t.ContentHTML,
+			FileHash:    t.FileHash,
+		})
+	}
+	for _, p := range reqBody.Purposes {
+		grpcReq.Purposes = append(grpcReq.Purposes, &pb.PolicyPurpose{
+			Key:         p.Key,
+			Name:        p.Name,
+			Description: p.Description,
+			IsRequired:  p.IsRequired,
+		})
 	}
 
 	grpcResp, err := api.client.CreatePolicy(c.Request.Context(), grpcReq)
@@ -77,27 +118,36 @@ func (api *DocumentAPI) CreatePolicy(c *gin.Context) {
 		"code":    "201",
 		"message": "Policy created successfully",
 		"data": gin.H{
-			"id":                  grpcResp.Document.Id,
-			"document_name":       grpcResp.Document.DocumentName,
-			"platform":            grpcResp.Document.Platform,
-			"is_mandatory":        grpcResp.Document.IsMandatory,
-			"effective_timestamp": grpcResp.Document.EffectiveTimestamp,
-			"content_html":        grpcResp.Document.ContentHtml,
-			"file_url":            grpcResp.Document.FileUrl,
-			"created_at":          grpcResp.Document.CreatedAt,
-			"created_by":          grpcResp.Document.CreatedBy,
+			"id":                      grpcResp.Document.Id,
+			"document_name":           grpcResp.Document.DocumentName,
+			"platform":                grpcResp.Document.Platform,
+			"is_mandatory":            grpcResp.Document.IsMandatory,
+			"effective_timestamp":     grpcResp.Document.EffectiveTimestamp,
+			"content_html":            grpcResp.Document.ContentHtml,
+			"file_url":                grpcResp.Document.FileUrl,
+			"file_hash":               grpcResp.Document.FileHash,
+			"file_download_url":       fileDownloadURL(grpcResp.Document.FileHash),
+			"created_at":              grpcResp.Document.CreatedAt,
+			"created_by":              grpcResp.Document.CreatedBy,
+			"status":                  grpcResp.Document.Status,
+			"locale":                  grpcResp.Document.Locale,
+			"available_locales":       grpcResp.Document.AvailableLocales,
+			"consent_validity_period": grpcResp.Document.ConsentValidityPeriod,
+			"purposes":                policyPurposesJSON(grpcResp.Document.Purposes),
 		},
 	})
 }
 
 // GetLatestPolicy godoc
 // @Summary      Get latest policy document
-// @Description  Retrieve the latest policy document for a specific platform and optional document name
+// @Description  Retrieve the latest policy document for a specific platform and optional document name. Content is returned in the locale chosen from ?locale= or Accept-Language (vi, en), falling back to the version's original locale when no translation exists. The served locale is in Content-Language.
 // @Tags         Policy Management
 // @Produce      json
-// @Param        platform      query  string  true   "Platform (Client, Merchant, Admin)"
-// @Param        document_name query  string  false  "Filter by document name"
-// @Success      200  {object}  object{code=string,message=string,data=object{document=object{id=string,document_name=string,platform=string,is_mandatory=bool,effective_timestamp=int64,content_html=string,file_url=string,created_at=int64}}}
+// @Param        platform        query   string  true   "Platform (Client, Merchant, Admin)"
+// @Param        document_name   query   string  false  "Filter by document name"
+// @Param        locale          query   string  false  "Locale (vi, en), overrides Accept-Language"
+// @Param        Accept-Language header  string  false  "Preferred languages, e.g. en-US,en;q=0.9,vi;q=0.8"
+// @Success      200  {object}  object{code=string,message=string,data=object{document=object{id=string,document_name=string,platform=string,is_mandatory=bool,effective_timestamp=int64,content_html=string,file_url=string,created_at=int64,locale=string,available_locales=[]string,consent_validity_period=int32,purposes=[]object}}}
 // @Failure      400  {object}  object{code=string,message=string}
 // @Failure      404  {object}  object{code=string,message=string}
 // @Router       /policies/latest [get]
@@ -116,6 +166,7 @@ func (api *DocumentAPI) GetLatestPolicy(c *gin.Context) {
 	grpcReq := &pb.GetLatestPolicyRequest{
 		Platform:     platform,
 		DocumentName: documentName,
+		Locale:       c.GetString("locale"),
 	}
 
 	grpcResp, err := api.client.GetLatestPolicy(c.Request.Context(), grpcReq)
@@ -136,19 +187,581 @@ func (api *DocumentAPI) GetLatestPolicy(c *gin.Context) {
 		return
 	}
 
+	c.Header("Content-Language", grpcResp.Document.Locale)
 	c.JSON(http.StatusOK, gin.H{
 		"code":    "200",
 		"message": "Success",
 		"data": gin.H{
-			"id":                  grpcResp.Document.Id,
-			"document_name":       grpcResp.Document.DocumentName,
-			"platform":            grpcResp.Document.Platform,
-			"is_mandatory":        grpcResp.Document.IsMandatory,
-			"effective_timestamp": grpcResp.Document.EffectiveTimestamp,
-			"content_html":        grpcResp.Document.ContentHtml,
-			"file_url":            grpcResp.Document.FileUrl,
-			"created_at":          grpcResp.Document.CreatedAt,
-			"created_by":          grpcResp.Document.CreatedBy,
+			"id":                      grpcResp.Document.Id,
+			"document_name":           grpcResp.Document.DocumentName,
+			"platform":                grpcResp.Document.Platform,
+			"is_mandatory":            grpcResp.Document.IsMandatory,
+			"effective_timestamp":     grpcResp.Document.EffectiveTimestamp,
+			"content_html":            grpcResp.Document.ContentHtml,
+			"file_url":                grpcResp.Document.FileUrl,
+			"file_hash":               grpcResp.Document.FileHash,
+			"file_download_url":       fileDownloadURL(grpcResp.Document.FileHash),
+			"created_at":              grpcResp.Document.CreatedAt,
+			"created_by":              grpcResp.Document.CreatedBy,
+			"status":                  grpcResp.Document.Status,
+			"locale":                  grpcResp.Document.Locale,
+			"available_locales":       grpcResp.Document.AvailableLocales,
+			"consent_validity_period": grpcResp.Document.ConsentValidityPeriod,
+			"purposes":                policyPurposesJSON(grpcResp.Document.Purposes),
 		},
 	})
 }
+
+// ComparePolicyVersions godoc
+// @Summary      Compare two policy versions
+// @Description  Show what changed in content_html between two approved versions of a document, identified by effective_timestamp (see policy history). format=json (default) returns the diff segments, format=html renders a page with insertions and deletions highlighted, format=text returns a unified text diff.
+// @Tags         Policy Management
+// @Produce      json,html,plain
+// @Param        platform      query  string  true   "Platform (Client, Merchant, Admin)"
+// @Param        document_name query  string  true   "Document name"
+// @Param        from          query  int64   true   "effective_timestamp of the older version"
+// @Param        to            query  int64   true   "effective_timestamp of the newer version"
+// @Param        format        query  string  false  "json (default), html or text"
+// @Param        locale        query  string  false  "Locale (vi, en), overrides Accept-Language"
+// @Success      200  {object}  object{code=string,message=string,data=object{from=object{id=string,document_name=string,platform=string,is_mandatory=bool,effective_timestamp=int64,status=string},to=object,segments=[]object{op=string,text=string},html_diff=string,text_diff=string,insertions=int32,deletions=int32}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Router       /policies/compare [get]
+func (api *DocumentAPI) ComparePolicyVersions(c *gin.Context) {
+	api.comparePolicyVersions(c, false)
+}
+
+// AdminComparePolicyVersions godoc
+// @Summary      Compare two policy versions including unpublished ones (Admin only)
+// @Description  Same as /policies/compare but draft, pending_review and rejected versions can also be compared, e.g. to review a draft against the live version. Requires Admin role.
+// @Tags         Admin - Policy Management
+// @Produce      json,html,plain
+// @Security     BearerAuth
+// @Param        platform      query  string  true   "Platform (Client, Merchant, Admin)"
+// @Param        document_name query  string  true   "Document name"
+// @Param        from          query  int64   true   "effective_timestamp of the older version"
+// @Param        to            query  int64   true   "effective_timestamp of the newer version"
+// @Param        format        query  string  false  "json (default), html or text"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Router       /admin/policies/compare [get]
+func (api *DocumentAPI) AdminComparePolicyVersions(c *gin.Context) {
+	api.comparePolicyVersions(c, true)
+}
+
+func (api *DocumentAPI) comparePolicyVersions(c *gin.Context, includeUnpublished bool) {
+	platform := c.Query("platform")
+	documentName := c.Query("document_name")
+	if platform == "" || documentName == "" {
+		errorResponse(c, http.StatusBadRequest, "platform and document_name query parameters are required")
+		return
+	}
+
+	from, errFrom := strconv.ParseInt(c.Query("from"), 10, 64)
+	to, errTo := strconv.ParseInt(c.Query("to"), 10, 64)
+	if errFrom != nil || errTo != nil {
+		errorResponse(c, http.StatusBadRequest, "from and to must be effective timestamps (Unix epoch seconds)")
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "html" && format != "text" {
+		errorResponse(c, http.StatusBadRequest, "format must be one of: json, html, text")
+		return
+	}
+
+	grpcResp, err := api.client.ComparePolicyVersions(c.Request.Context(), &pb.ComparePolicyVersionsRequest{
+		Platform:           platform,
+		DocumentName:       documentName,
+		FromTimestamp:      from,
+		ToTimestamp:        to,
+		IncludeUnpublished: includeUnpublished,
+		Locale:             c.GetString("locale"),
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	switch format {
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(grpcResp.TextDiff))
+	case "html":
+		// content_html do admin soạn, chặn script để trang diff chỉ hiển thị nội dung
+		c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; img-src * data:")
+		c.Status(http.StatusOK)
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		if err := policyDiffPage.Execute(c.Writer, gin.H{
+			"From":       grpcResp.FromDocument,
+			"To":         grpcResp.ToDocument,
+			"Diff":       template.HTML(grpcResp.HtmlDiff),
+			"Insertions": grpcResp.Insertions,
+			"Deletions":  grpcResp.Deletions,
+		}); err != nil {
+			log.Printf("WARNING: Failed to render policy diff page: %v", err)
+		}
+	default:
+		segments := make([]gin.H, len(grpcResp.Segments))
+		for i, seg := range grpcResp.Segments {
+			segments[i] = gin.H{"op": seg.Op, "text": seg.Text}
+		}
+
+		successResponse(c, http.StatusOK, "Policy versions compared successfully", gin.H{
+			"from":       policyVersionSummaryJSON(grpcResp.FromDocument),
+			"to":         policyVersionSummaryJSON(grpcResp.ToDocument),
+			"segments":   segments,
+			"html_diff":  grpcResp.HtmlDiff,
+			"text_diff":  grpcResp.TextDiff,
+			"insertions": grpcResp.Insertions,
+			"deletions":  grpcResp.Deletions,
+		})
+	}
+}
+
+// policyDiffPage - trang HTML hiển thị diff giữa 2 version (format=html)
+var policyDiffPage = template.Must(template.New("policy-diff").Parse(`<!DOCTYPE html>
+<html lang="{{.To.Locale}}">
+<head>
+<meta charset="utf-8">
+<title>{{.To.DocumentName}} - changes</title>
+<style>
+body { font-family: sans-serif; max-width: 860px; margin: 2em auto; line-height: 1.5; }
+.policy-diff-meta { color: #555; border-bottom: 1px solid #ddd; padding-bottom: 1em; }
+ins.policy-diff-ins { background: #d4f7d4; text-decoration: none; }
+del.policy-diff-del { background: #fbd5d5; }
+</style>
+</head>
+<body>
+<div class="policy-diff-meta">
+<h1>{{.To.DocumentName}} ({{.To.Platform}})</h1>
+<p>Changes from version effective {{.From.EffectiveTimestamp}} to version effective {{.To.EffectiveTimestamp}}: {{.Insertions}} words added, {{.Deletions}} words removed.</p>
+</div>
+<div class="policy-diff-content">{{.Diff}}</div>
+</body>
+</html>
+`))
+
+// SubmitForReview godoc
+// @Summary      Submit a draft policy version for review (Admin only)
+// @Description  Move a draft policy version to pending_review so another admin can approve it. Requires Admin role.
+// @Tags         Admin - Policy Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Policy document version ID"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string}
+// @Router       /admin/policies/{id}/submit [post]
+func (api *DocumentAPI) SubmitForReview(c *gin.Context) {
+	grpcResp, err := api.client.SubmitForReview(c.Request.Context(), &pb.SubmitForReviewRequest{
+		DocumentId:  c.Param("id"),
+		SubmittedBy: c.GetString("user_id"), // Admin đang đăng nhập
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, grpcResp.Message, policyDocumentToJSON(grpcResp.Document))
+}
+
+// ApprovePolicy godoc
+// @Summary      Approve a pending policy version (Admin only)
+// @Description  Approve a pending_review version. The reviewer must be a different admin than the author/submitter. The version is scheduled if its effective time is in the future, otherwise published immediately.
+// @Tags         Admin - Policy Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string  true   "Policy document version ID"
+// @Param        request  body  object{comment=string}  false  "Optional review comment"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string}
+// @Router       /admin/policies/{id}/approve [post]
+func (api *DocumentAPI) ApprovePolicy(c *gin.Context) {
+	var reqBody struct {
+		Comment string `json:"comment"`
+	}
+
+	// Body là optional
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	grpcResp, err := api.client.ApprovePolicy(c.Request.Context(), &pb.ApprovePolicyRequest{
+		DocumentId: c.Param("id"),
+		ReviewedBy: c.GetString("user_id"), // Admin đang đăng nhập
+		Comment:    reqBody.Comment,
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, grpcResp.Message, policyDocumentToJSON(grpcResp.Document))
+}
+
+// RejectPolicy godoc
+// @Summary      Reject a pending policy version (Admin only)
+// @Description  Reject a pending_review version with a mandatory comment. The reviewer must be a different admin than the author/submitter.
+// @Tags         Admin - Policy Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string  true  "Policy document version ID"
+// @Param        request  body  object{comment=string}  true  "Rejection reason"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string}
+// @Router       /admin/policies/{id}/reject [post]
+func (api *DocumentAPI) RejectPolicy(c *gin.Context) {
+	var reqBody struct {
+		Comment string `json:"comment" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	grpcResp, err := api.client.RejectPolicy(c.Request.Context(), &pb.RejectPolicyRequest{
+		DocumentId: c.Param("id"),
+		ReviewedBy: c.GetString("user_id"), // Admin đang đăng nhập
+		Comment:    reqBody.Comment,
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, grpcResp.Message, policyDocumentToJSON(grpcResp.Document))
+}
+
+// ListUpcomingPolicies godoc
+// @Summary      List upcoming policy versions (Admin only)
+// @Description  List draft, pending_review and scheduled policy versions that are not yet effective. Requires Admin role.
+// @Tags         Admin - Policy Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        platform      query  string  true   "Platform (Client, Merchant, Admin)"
+// @Param        document_name query  string  false  "Filter by document name"
+// @Success      200  {object}  object{code=string,message=string,data=object{documents=[]object,total=int32}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Router       /admin/policies/upcoming [get]
+func (api *DocumentAPI) ListUpcomingPolicies(c *gin.Context) {
+	platform := c.Query("platform")
+	if platform == "" {
+		errorResponse(c, http.StatusBadRequest, "platform query parameter is required")
+		return
+	}
+
+	grpcResp, err := api.client.ListUpcomingPolicies(c.Request.Context(), &pb.ListUpcomingPoliciesRequest{
+		Platform:     platform,
+		DocumentName: c.Query("document_name"),
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	documents := make([]gin.H, len(grpcResp.Documents))
+	for i, doc := range grpcResp.Documents {
+		documents[i] = policyDocumentToJSON(doc)
+	}
+
+	successResponse(c, http.StatusOK, "Upcoming policies retrieved successfully", gin.H{
+		"documents": documents,
+		"total":     grpcResp.Total,
+	})
+}
+
+// SchedulePolicy godoc
+// @Summary      Reschedule an approved policy version (Admin only)
+// @Description  Change the future effective time of an approved (scheduled) policy version. Requires Admin role.
+// @Tags         Admin - Policy Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string  true  "Policy document version ID"
+// @Param        request  body  object{effective_timestamp=int64}  true  "Future effective time (Unix epoch seconds)"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string}
+// @Router       /admin/policies/{id}/schedule [post]
+func (api *DocumentAPI) SchedulePolicy(c *gin.Context) {
+	var reqBody struct {
+		EffectiveTimestamp int64 `json:"effective_timestamp" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	grpcResp, err := api.client.SchedulePolicy(c.Request.Context(), &pb.SchedulePolicyRequest{
+		DocumentId:         c.Param("id"),
+		EffectiveTimestamp: reqBody.EffectiveTimestamp,
+		ScheduledBy:        c.GetString("user_id"), // Admin đang đăng nhập
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, grpcResp.Message, policyDocumentToJSON(grpcResp.Document))
+}
+
+// PublishPolicy godoc
+// @Summary      Publish a policy version now (Admin only)
+// @Description  Publish an approved (scheduled) policy version immediately. Requires Admin role.
+// @Tags         Admin - Policy Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Policy document version ID"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string}
+// @Router       /admin/policies/{id}/publish [post]
+func (api *DocumentAPI) PublishPolicy(c *gin.Context) {
+	grpcResp, err := api.client.PublishPolicy(c.Request.Context(), &pb.PublishPolicyRequest{
+		DocumentId:  c.Param("id"),
+		PublishedBy: c.GetString("user_id"), // Admin đang đăng nhập
+	})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, grpcResp.Message, policyDocumentToJSON(grpcResp.Document))
+}
+
+// policyDocumentToJSON chuyển PolicyDocument proto sang JSON response
+func policyDocumentToJSON(doc *pb.PolicyDocument) gin.H {
+	return gin.H{
+		"id":                      doc.Id,
+		"document_name":           doc.DocumentName,
+		"platform":                doc.Platform,
+		"is_mandatory":            doc.IsMandatory,
+		"effective_timestamp":     doc.EffectiveTimestamp,
+		"content_html":            doc.ContentHtml,
+		"file_url":                doc.FileUrl,
+		"file_hash":               doc.FileHash,
+		"file_download_url":       fileDownloadURL(doc.FileHash),
+		"created_at":              doc.CreatedAt,
+		"created_by":              doc.CreatedBy,
+		"status":                  doc.Status,
+		"locale":                  doc.Locale,
+		"available_locales":       doc.AvailableLocales,
+		"published_at":            doc.PublishedAt,
+		"published_by":            doc.PublishedBy,
+		"submitted_by":            doc.SubmittedBy,
+		"submitted_at":            doc.SubmittedAt,
+		"reviewed_by":             doc.ReviewedBy,
+		"reviewed_at":             doc.ReviewedAt,
+		"review_comment":          doc.ReviewComment,
+		"consent_validity_period": doc.ConsentValidityPeriod,
+		"purposes":                policyPurposesJSON(doc.Purposes),
+	}
+}
+
+// policyPurposesJSON - mục đích xử lý dữ liệu user đồng ý/từ chối riêng khi đồng ý version
+func policyPurposesJSON(purposes []*pb.PolicyPurpose) []gin.H {
+	result := make([]gin.H, len(purposes))
+	for i, p := range purposes {
+		result[i] = gin.H{
+			"key":         p.Key,
+			"name":        p.Name,
+			"description": p.Description,
+			"is_required": p.IsRequired,
+		}
+	}
+	return result
+}
+
+// policyVersionSummaryJSON - thông tin version trong kết quả so sánh (không kèm content và thông tin duyệt)
+func policyVersionSummaryJSON(doc *pb.PolicyDocument) gin.H {
+	return gin.H{
+		"id":                  doc.Id,
+		"document_name":       doc.DocumentName,
+		"platform":            doc.Platform,
+		"is_mandatory":        doc.IsMandatory,
+		"effective_timestamp": doc.EffectiveTimestamp,
+		"status":              doc.Status,
+		"locale":              doc.Locale,
+		"file_url":            doc.FileUrl,
+		"file_hash":           doc.FileHash,
+		"file_download_url":   fileDownloadURL(doc.FileHash),
+	}
+}
+
+// UploadFile godoc
+// @Summary      Upload a policy file (Admin only)
+// @Description  Upload a policy file (pdf, docx, html, txt, md, jpg, png) as multipart form field "file". The file is stored by the SHA-256 of its content; use the returned content_hash as file_hash when creating a policy. Requires Admin role.
+// @Tags         Admin - Policy Management
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file  formData  file  true  "Policy file"
+// @Success      201  {object}  object{code=string,message=string,data=object{content_hash=string,content_type=string,size_bytes=int64,filename=string,uploaded_by=string,created_at=int64,download_url=string}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      413  {object}  object{code=string,message=string}
+// @Router       /admin/files [post]
+func (api *DocumentAPI) UploadFile(c *gin.Context) {
+	// Đọc multipart theo stream, không buffer cả file trong gateway
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "request must be multipart/form-data")
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "invalid multipart body")
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		grpcResp, err := api.client.UploadFile(c.Request.Context(), &pb.UploadFileRequest{
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			UploadedBy:  c.GetString("user_id"), // Admin đang đăng nhập
+		}, part)
+		part.Close()
+		if err != nil {
+			log.Printf("[ADMIN] Failed to upload file: %v", err)
+			statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+			c.JSON(statusCode, gin.H{
+				"code":    code,
+				"message": msg,
+			})
+			return
+		}
+
+		successResponse(c, http.StatusCreated, "File uploaded successfully", fileBlobToJSON(grpcResp.File))
+		return
+	}
+
+	errorResponse(c, http.StatusBadRequest, "form field 'file' is required")
+}
+
+// DownloadFile godoc
+// @Summary      Download a policy file
+// @Description  Download an uploaded policy file by its SHA-256 content hash. Content is immutable, so responses are cacheable forever.
+// @Tags         Policy Management
+// @Produce      octet-stream
+// @Param        hash  path  string  true  "SHA-256 content hash"
+// @Success      200  {file}  file
+// @Success      304  "Not modified"
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Router       /files/{hash} [get]
+func (api *DocumentAPI) DownloadFile(c *gin.Context) {
+	hash := c.Param("hash")
+	if !contentHashPattern.MatchString(hash) {
+		errorResponse(c, http.StatusBadRequest, "hash must be a lowercase hex SHA-256")
+		return
+	}
+
+	// Content-addressed: cùng hash luôn là cùng nội dung
+	etag := `"` + hash + `"`
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	file, reader, err := api.client.DownloadFile(c.Request.Context(), hash)
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+	defer reader.Close()
+
+	headers := map[string]string{
+		"ETag":                   etag,
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"Content-Disposition":    fmt.Sprintf("inline; filename=%q", file.Filename),
+		"X-Content-Type-Options": "nosniff",
+	}
+	// File HTML do admin upload được mở inline trên origin của Gateway (route public):
+	// sandbox để script trong file không chạy và không đọc được cookie/storage của Gateway
+	if strings.HasPrefix(file.ContentType, "text/") {
+		headers["Content-Security-Policy"] = "sandbox; default-src 'none'; style-src 'unsafe-inline'; img-src * data:"
+	}
+	c.DataFromReader(http.StatusOK, file.SizeBytes, file.ContentType, reader, headers)
+}
+
+var contentHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// fileDownloadURL trả về đường dẫn download qua gateway (rỗng nếu version không dùng file upload)
+func fileDownloadURL(fileHash string) string {
+	if fileHash == "" {
+		return ""
+	}
+	return "/api/v1/files/" + fileHash
+}
+
+// fileBlobToJSON chuyển FileBlob proto sang JSON response
+func fileBlobToJSON(file *pb.FileBlob) gin.H {
+	return gin.H{
+		"content_hash": file.ContentHash,
+		"content_type": file.ContentType,
+		"size_bytes":   file.SizeBytes,
+		"filename":     file.Filename,
+		"uploaded_by":  file.UploadedBy,
+		"created_at":   file.CreatedAt,
+		"download_url": fileDownloadURL(file.ContentHash),
+	}
+}
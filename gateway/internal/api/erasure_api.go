@@ -0,0 +1,120 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/thatlq1812/policy-system/gateway/internal/clients"
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/user"
+)
+
+// ErasureAPI xử lý yêu cầu xóa dữ liệu cá nhân (right to erasure, GDPR Art. 17 / Nghị định 13/2023/NĐ-CP)
+// Giải thích: User Service chạy erasure saga (ẩn danh user → xóa IP/user agent của consent → cấp certificate),
+// bước lỗi được chạy lại nền tới khi xong nên Admin chỉ cần gửi yêu cầu 1 lần
+type ErasureAPI struct {
+	userClient *clients.UserClient
+}
+
+// NewErasureAPI tạo mới ErasureAPI handler
+func NewErasureAPI(userClient *clients.UserClient) *ErasureAPI {
+	return &ErasureAPI{userClient: userClient}
+}
+
+// EraseUser godoc
+// @Summary      Erase a user's personal data (Admin only)
+// @Description  Carry out a right-to-erasure request. The user's phone number, name and password are replaced, IP addresses and devices are removed from their sessions and consents, and an erasure certificate is issued. Consent evidence is kept in pseudonymous form so the consent chain still verifies. Returns 200 with the certificate when done, or 202 when a step failed and is being retried in the background (see saga_id). Calling again returns the current status; the request is never run twice. Reason is required and recorded in the audit log.
+// @Tags         Admin - User Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "User ID"
+// @Param        request  body  object{reason=string}  true  "Erasure request"
+// @Success      200  {object}  object{code=string,message=string,data=object{saga_id=string,status=string,certificate=object}}
+// @Success      202  {object}  object{code=string,message=string,data=object{saga_id=string,status=string,current_step=string,last_error=string}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/users/{user_id}/erase [post]
+func (api *ErasureAPI) EraseUser(c *gin.Context) {
+	var reqBody struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	adminID := c.GetString("user_id")
+	userID := c.Param("user_id")
+	log.Printf("[AUDIT] Admin %s erasing personal data of user %s", adminID, userID)
+
+	resp, err := api.userClient.EraseUser(c.Request.Context(), &pb.EraseUserRequest{
+		UserId:      userID,
+		RequestedBy: adminID,
+		Reason:      reqBody.Reason,
+	})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	if resp.Certificate == nil {
+		successResponse(c, http.StatusAccepted, "Erasure in progress, failed steps are retried automatically", gin.H{
+			"saga_id":      resp.SagaId,
+			"status":       resp.Status,
+			"current_step": resp.CurrentStep,
+			"last_error":   resp.LastError,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, "User erased successfully", gin.H{
+		"saga_id":     resp.SagaId,
+		"status":      resp.Status,
+		"certificate": erasureCertificateJSON(resp.Certificate),
+	})
+}
+
+// GetErasureCertificate godoc
+// @Summary      Get a user's erasure certificate (Admin only)
+// @Description  Proof that a right-to-erasure request was carried out. Contains no personal data; certificate_hash is the SHA-256 of the other fields and consent_chain_head can be compared with GET /admin/consents/{user_id}/verify-chain.
+// @Tags         Admin - User Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "User ID"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/users/{user_id}/erasure-certificate [get]
+func (api *ErasureAPI) GetErasureCertificate(c *gin.Context) {
+	resp, err := api.userClient.GetErasureCertificate(c.Request.Context(), &pb.GetErasureCertificateRequest{
+		UserId: c.Param("user_id"),
+	})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Erasure certificate retrieved successfully", erasureCertificateJSON(resp.Certificate))
+}
+
+func erasureCertificateJSON(cert *pb.ErasureCertificate) gin.H {
+	return gin.H{
+		"id":                 cert.Id,
+		"user_id":            cert.UserId,
+		"requested_by":       cert.RequestedBy,
+		"reason":             cert.Reason,
+		"user_erased_at":     cert.UserErasedAt,
+		"sessions_scrubbed":  cert.SessionsScrubbed,
+		"consents_erased":    cert.ConsentsErased,
+		"consent_chain_head": cert.ConsentChainHead,
+		"issued_at":          cert.IssuedAt,
+		"certificate_hash":   cert.CertificateHash,
+	}
+}
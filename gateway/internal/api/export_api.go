@@ -0,0 +1,414 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/thatlq1812/policy-system/gateway/internal/clients"
+	"github.com/thatlq1812/policy-system/gateway/internal/middleware"
+	consentpb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/user"
+)
+
+// ExportAPI trả lời yêu cầu truy cập dữ liệu của chủ thể dữ liệu (GDPR Art. 15, Nghị định 13/2023/NĐ-CP)
+// Giải thích: Gom dữ liệu từ User Service và Consent Service thành 1 file zip (JSON + HTML)
+// thay vì Admin phải tổng hợp bằng tay
+type ExportAPI struct {
+	userClient    *clients.UserClient
+	consentClient *clients.ConsentClient
+}
+
+// NewExportAPI tạo mới ExportAPI handler
+func NewExportAPI(userClient *clients.UserClient, consentClient *clients.ConsentClient) *ExportAPI {
+	return &ExportAPI{userClient: userClient, consentClient: consentClient}
+}
+
+// userDataExport là nội dung export.json trong archive
+type userDataExport struct {
+	GeneratedAt int64            `json:"generated_at"` // Unix timestamp
+	RequestedBy string           `json:"requested_by"` // User tự export hoặc Admin xử lý yêu cầu
+	Profile     exportProfile    `json:"profile"`
+	Sessions    []exportSession  `json:"sessions"`
+	Consents    []exportConsent  `json:"consents"`
+	Documents   []exportDocument `json:"documents"` // Version document user đã đồng ý
+}
+
+type exportProfile struct {
+	ID           string `json:"id"`
+	PhoneNumber  string `json:"phone_number"`
+	Name         string `json:"name"`
+	PlatformRole string `json:"platform_role"`
+	CreatedAt    int64  `json:"created_at"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+type exportSession struct {
+	ID         string `json:"id"`
+	DeviceInfo string `json:"device_info"`
+	IPAddress  string `json:"ip_address"`
+	CreatedAt  int64  `json:"created_at"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
+
+type exportConsent struct {
+	ID                  string          `json:"id"`
+	Platform            string          `json:"platform"`
+	DocumentID          string          `json:"document_id"`
+	DocumentName        string          `json:"document_name"`
+	VersionTimestamp    int64           `json:"version_timestamp"`
+	Status              string          `json:"status"`
+	AgreedAt            int64           `json:"agreed_at"`
+	ConsentMethod       string          `json:"consent_method"`
+	IPAddress           string          `json:"ip_address"`
+	UserAgent           string          `json:"user_agent"`
+	Locale              string          `json:"locale"`
+	RecordedBy          string          `json:"recorded_by,omitempty"`
+	OnBehalfReason      string          `json:"on_behalf_reason,omitempty"`
+	RevokedAt           int64           `json:"revoked_at,omitempty"`
+	RevokedBy           string          `json:"revoked_by,omitempty"`
+	RevokedReason       string          `json:"revoked_reason,omitempty"`
+	ExpiresAt           int64           `json:"expires_at,omitempty"`
+	ExpiredAt           int64           `json:"expired_at,omitempty"`
+	ErasedAt            int64           `json:"erased_at,omitempty"` // IP/user agent đã bị xóa
+	Purposes            []exportPurpose `json:"purposes,omitempty"`
+	DocumentContentHash string          `json:"document_content_hash"`
+	RecordHash          string          `json:"record_hash"`
+}
+
+type exportPurpose struct {
+	Purpose  string `json:"purpose"`
+	Accepted bool   `json:"accepted"`
+}
+
+type exportDocument struct {
+	ContentHash      string `json:"content_hash"`
+	DocumentName     string `json:"document_name"`
+	VersionTimestamp int64  `json:"version_timestamp"`
+	ContentType      string `json:"content_type,omitempty"`
+	Source           string `json:"source,omitempty"`
+	SourceURL        string `json:"source_url,omitempty"`
+	CapturedAt       int64  `json:"captured_at,omitempty"`
+	File             string `json:"file,omitempty"` // Đường dẫn trong archive, rỗng nếu không còn snapshot
+}
+
+// ExportMyData godoc
+// @Summary      Export my personal data
+// @Description  Download everything the system holds about the authenticated user as a zip archive: export.json (profile, active sessions, full consent history including withdrawn/declined/expired consents), index.html (human-readable report) and documents/ (the exact document versions the user agreed to).
+// @Tags         User
+// @Produce      application/zip
+// @Security     BearerAuth
+// @Success      200  {file}  file
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /user/export [get]
+func (api *ExportAPI) ExportMyData(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		errorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	api.export(c, userID, userID)
+}
+
+// AdminExportUserData godoc
+// @Summary      Export a user's personal data (Admin only)
+// @Description  Answer a data subject access request (GDPR / Decree 13) on behalf of a user. Returns the same archive as GET /user/export. Every export is written to the gateway log as [AUDIT].
+// @Tags         Admin - User Management
+// @Produce      application/zip
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "User ID"
+// @Success      200  {file}  file
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/users/{user_id}/export [get]
+func (api *ExportAPI) AdminExportUserData(c *gin.Context) {
+	adminID := c.GetString("user_id")
+	userID := c.Param("user_id")
+	log.Printf("[AUDIT] Admin %s exporting personal data of user %s", adminID, userID)
+
+	api.export(c, userID, adminID)
+}
+
+// export gom dữ liệu của user và trả về archive. Lỗi ở bất kỳ nguồn nào thì không trả archive
+// (export thiếu dữ liệu không đáp ứng được yêu cầu truy cập), trừ snapshot document không còn lưu
+func (api *ExportAPI) export(c *gin.Context, userID, requestedBy string) {
+	ctx := c.Request.Context()
+
+	profileResp, err := api.userClient.GetUserProfile(ctx, &pb.GetUserProfileRequest{UserId: userID})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	sessionsResp, err := api.userClient.GetActiveSessions(ctx, &pb.GetActiveSessionsRequest{UserId: userID})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	consentsResp, err := api.consentClient.GetUserConsents(ctx, &consentpb.GetUserConsentsRequest{
+		UserId:         userID,
+		IncludeDeleted: true, // Consent đã thu hồi/từ chối cũng là dữ liệu của user
+	})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	user := profileResp.User
+	data := userDataExport{
+		GeneratedAt: time.Now().Unix(),
+		RequestedBy: requestedBy,
+		Profile: exportProfile{
+			ID:           user.Id,
+			PhoneNumber:  user.PhoneNumber,
+			Name:         user.Name,
+			PlatformRole: user.PlatformRole,
+			CreatedAt:    user.CreatedAt,
+			UpdatedAt:    user.UpdatedAt,
+		},
+		Sessions:  make([]exportSession, len(sessionsResp.Sessions)),
+		Consents:  make([]exportConsent, len(consentsResp.Consents)),
+		Documents: []exportDocument{},
+	}
+
+	for i, s := range sessionsResp.Sessions {
+		data.Sessions[i] = exportSession{
+			ID:         s.Id,
+			DeviceInfo: s.DeviceInfo,
+			IPAddress:  s.IpAddress,
+			CreatedAt:  s.CreatedAt,
+			ExpiresAt:  s.ExpiresAt,
+		}
+	}
+
+	// Archive files: export.json, index.html, documents/<content_hash><ext>
+	files := map[string][]byte{}
+	seen := map[string]bool{}
+	for i, consent := range consentsResp.Consents {
+		data.Consents[i] = exportConsentFromPb(consent)
+
+		hash := consent.DocumentContentHash
+		if hash == "" || seen[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		doc := exportDocument{
+			ContentHash:      hash,
+			DocumentName:     consent.DocumentName,
+			VersionTimestamp: consent.VersionTimestamp,
+		}
+		snapshot, err := api.consentClient.GetDocumentSnapshot(ctx, &consentpb.GetDocumentSnapshotRequest{ContentHash: hash})
+		if status.Code(err) == codes.NotFound {
+			// Consent tạo trước khi có snapshot: vẫn liệt kê version, không có file
+			data.Documents = append(data.Documents, doc)
+			continue
+		}
+		if err != nil {
+			grpcErrorResponse(c, err)
+			return
+		}
+
+		doc.ContentType = snapshot.ContentType
+		doc.Source = snapshot.Source
+		doc.SourceURL = snapshot.SourceUrl
+		doc.CapturedAt = snapshot.CapturedAt
+		doc.File = "documents/" + hash + snapshotExtension(snapshot.ContentType)
+		files[doc.File] = snapshot.Content
+		data.Documents = append(data.Documents, doc)
+	}
+
+	exportJSON, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		log.Printf("ERROR: Failed to encode data export of user %s: %v", userID, err)
+		errorResponse(c, http.StatusInternalServerError, "failed to build data export")
+		return
+	}
+	files["export.json"] = exportJSON
+
+	var report bytes.Buffer
+	if err := userDataExportPage.Execute(&report, data); err != nil {
+		log.Printf("ERROR: Failed to render data export of user %s: %v", userID, err)
+		errorResponse(c, http.StatusInternalServerError, "failed to build data export")
+		return
+	}
+	files["index.html"] = report.Bytes()
+
+	archive, err := zipFiles(files)
+	if err != nil {
+		log.Printf("ERROR: Failed to zip data export of user %s: %v", userID, err)
+		errorResponse(c, http.StatusInternalServerError, "failed to build data export")
+		return
+	}
+
+	filename := fmt.Sprintf("user-data-%s-%s.zip", userID, time.Unix(data.GeneratedAt, 0).UTC().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+func exportConsentFromPb(consent *consentpb.Consent) exportConsent {
+	purposes := make([]exportPurpose, len(consent.Purposes))
+	for i, p := range consent.Purposes {
+		purposes[i] = exportPurpose{Purpose: p.Purpose, Accepted: p.Accepted}
+	}
+
+	return exportConsent{
+		ID:                  consent.Id,
+		Platform:            consent.Platform,
+		DocumentID:          consent.DocumentId,
+		DocumentName:        consent.DocumentName,
+		VersionTimestamp:    consent.VersionTimestamp,
+		Status:              consent.Status,
+		AgreedAt:            consent.AgreedAt,
+		ConsentMethod:       consent.ConsentMethod,
+		IPAddress:           consent.IpAddress,
+		UserAgent:           consent.UserAgent,
+		Locale:              consent.Locale,
+		RecordedBy:          consent.RecordedBy,
+		OnBehalfReason:      consent.OnBehalfReason,
+		RevokedAt:           consent.RevokedAt,
+		RevokedBy:           consent.RevokedBy,
+		RevokedReason:       consent.RevokedReason,
+		ExpiresAt:           consent.ExpiresAt,
+		ExpiredAt:           consent.ExpiredAt,
+		ErasedAt:            consent.ErasedAt,
+		Purposes:            purposes,
+		DocumentContentHash: consent.DocumentContentHash,
+		RecordHash:          consent.RecordHash,
+	}
+}
+
+// snapshotExtension chọn đuôi file theo content type của snapshot
+func snapshotExtension(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.TrimSpace(mediaType) {
+	case "text/html":
+		return ".html"
+	case "application/pdf":
+		return ".pdf"
+	case "text/plain":
+		return ".txt"
+	default:
+		return ".bin"
+	}
+}
+
+// zipFiles đóng gói các file (path -> nội dung) thành 1 zip archive
+func zipFiles(files map[string][]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// grpcErrorResponse trả lỗi gRPC từ downstream service theo format chung
+func grpcErrorResponse(c *gin.Context, err error) {
+	statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+	c.JSON(statusCode, gin.H{
+		"code":    code,
+		"message": msg,
+	})
+}
+
+// userDataExportPage - báo cáo HTML trong archive, đọc được không cần công cụ
+var userDataExportPage = template.Must(template.New("user-data-export").Funcs(template.FuncMap{
+	"date": func(unix int64) string {
+		if unix == 0 {
+			return ""
+		}
+		return time.Unix(unix, 0).UTC().Format("2006-01-02 15:04:05 UTC")
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Personal data export - {{.Profile.Name}}</title>
+<style>
+body { font-family: sans-serif; max-width: 960px; margin: 2em auto; line-height: 1.5; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; font-size: 0.9em; }
+th { background: #f5f5f5; }
+</style>
+</head>
+<body>
+<h1>Personal data export</h1>
+<p>Generated {{date .GeneratedAt}}. Machine-readable data: <a href="export.json">export.json</a>.</p>
+
+<h2>Profile</h2>
+<table>
+<tr><th>User ID</th><td>{{.Profile.ID}}</td></tr>
+<tr><th>Name</th><td>{{.Profile.Name}}</td></tr>
+<tr><th>Phone number</th><td>{{.Profile.PhoneNumber}}</td></tr>
+<tr><th>Platform</th><td>{{.Profile.PlatformRole}}</td></tr>
+<tr><th>Created</th><td>{{date .Profile.CreatedAt}}</td></tr>
+<tr><th>Updated</th><td>{{date .Profile.UpdatedAt}}</td></tr>
+</table>
+
+<h2>Active sessions</h2>
+<table>
+<tr><th>Device</th><th>IP address</th><th>Signed in</th><th>Expires</th></tr>
+{{range .Sessions}}<tr><td>{{.DeviceInfo}}</td><td>{{.IPAddress}}</td><td>{{date .CreatedAt}}</td><td>{{date .ExpiresAt}}</td></tr>
+{{else}}<tr><td colspan="4">No active sessions</td></tr>
+{{end}}</table>
+
+<h2>Consents</h2>
+<table>
+<tr><th>Document</th><th>Version</th><th>Status</th><th>Agreed</th><th>Method</th><th>Purposes</th><th>Revoked / declined</th><th>Expires</th></tr>
+{{range .Consents}}<tr>
+<td>{{.DocumentName}} ({{.Platform}})</td>
+<td>{{date .VersionTimestamp}}</td>
+<td>{{.Status}}</td>
+<td>{{date .AgreedAt}}{{if .RecordedBy}}<br>recorded by {{.RecordedBy}}: {{.OnBehalfReason}}{{end}}</td>
+<td>{{.ConsentMethod}}<br>{{.IPAddress}}</td>
+<td>{{range .Purposes}}{{.Purpose}}: {{if .Accepted}}accepted{{else}}declined{{end}}<br>{{end}}</td>
+<td>{{if .RevokedAt}}{{date .RevokedAt}}<br>by {{.RevokedBy}}: {{.RevokedReason}}{{end}}</td>
+<td>{{date .ExpiresAt}}</td>
+</tr>
+{{else}}<tr><td colspan="8">No consents</td></tr>
+{{end}}</table>
+
+<h2>Documents agreed to</h2>
+<table>
+<tr><th>Document</th><th>Version</th><th>Content hash (SHA-256)</th><th>File</th></tr>
+{{range .Documents}}<tr><td>{{.DocumentName}}</td><td>{{date .VersionTimestamp}}</td><td><code>{{.ContentHash}}</code></td><td>{{if .File}}<a href="{{.File}}">{{.File}}</a>{{else}}not archived{{end}}</td></tr>
+{{else}}<tr><td colspan="4">No documents</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
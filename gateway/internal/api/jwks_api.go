@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/thatlq1812/policy-system/gateway/internal/middleware"
+)
+
+// JWKSAPI publish public keys ký access token theo RFC 7517
+// Giải thích: service của team khác verify access token bằng các key này (theo kid),
+// không cần JWT secret dùng chung
+type JWKSAPI struct {
+	keys *middleware.KeySet
+}
+
+// NewJWKSAPI tạo mới JWKSAPI handler
+func NewJWKSAPI(keys *middleware.KeySet) *JWKSAPI {
+	return &JWKSAPI{keys: keys}
+}
+
+// GetJWKS trả về JSON Web Key Set tại /.well-known/jwks.json.
+// Response theo chuẩn JWKS ({"keys": [...]}) thay vì envelope code/message để thư viện JWT đọc trực tiếp.
+// Key mới có trong JWKS trước khi được dùng để ký, key cũ còn tới khi token cuối cùng nó ký hết hạn.
+func (api *JWKSAPI) GetJWKS(c *gin.Context) {
+	jwks := api.keys.JWKS()
+
+	keys := make([]gin.H, len(jwks))
+	for i, k := range jwks {
+		key := gin.H{
+			"kid": k.Kid,
+			"kty": k.Kty,
+			"alg": k.Alg,
+			"use": k.Use,
+		}
+		switch k.Kty {
+		case "RSA":
+			key["n"] = k.N
+			key["e"] = k.E
+		case "OKP":
+			key["crv"] = k.Crv
+			key["x"] = k.X
+		}
+		keys[i] = key
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/user"
+)
+
+// Two-factor authentication (TOTP)
+// Giải thích: user bật 2FA (và mọi Admin) đăng nhập 2 bước: /auth/login trả mfa_token thay cho tokens,
+// /auth/mfa/verify đổi mfa_token + mã TOTP (hoặc recovery code) lấy tokens.
+// Admin chưa có 2FA nhận mfa_enrollment_required và phải enroll bằng mfa_token trước khi nhận tokens.
+
+// VerifyMFA godoc
+// @Summary      Complete login with a two-factor code
+// @Description  Second step of login when /auth/login returned mfa_required=true. Send the mfa_token with the 6-digit code from the authenticator app, or one of the recovery codes. The mfa_token is single-use, expires after a few minutes and allows a limited number of attempts. Too many wrong codes lock two-factor verification for the account temporarily (403). The response is the same as a successful /auth/login.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body  object{mfa_token=string,code=string}  true  "MFA token and code"
+// @Success      200  {object}  object{code=string,message=string,data=object{user=object,access_token=string,refresh_token=string,access_token_expires_at=int64,refresh_token_expires_at=int64,requires_consent=boolean,requires_mandatory_consent=boolean,pending_policies=array,consent_message=string}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string} "Wrong code or invalid/expired mfa_token"
+// @Failure      403  {object}  object{code=string,message=string} "Two-factor verification locked"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /auth/mfa/verify [post]
+func (api *UserAPI) VerifyMFA(c *gin.Context) {
+	var reqBody struct {
+		MfaToken string `json:"mfa_token" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := api.userClient.VerifyMFA(c.Request.Context(), &pb.VerifyMFARequest{
+		MfaToken: reqBody.MfaToken,
+		Code:     reqBody.Code,
+	})
+	if err != nil {
+		log.Printf("[LOGIN] Two-factor verification failed: %v", err)
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	log.Printf("[LOGIN] Step 1 SUCCESS: User %s passed two-factor authentication", resp.User.Id)
+	api.completeLogin(c, resp)
+}
+
+// EnrollMFAOnLogin godoc
+// @Summary      Set up two-factor authentication during login (Admin)
+// @Description  For an Admin whose /auth/login returned mfa_enrollment_required=true. Returns a new TOTP secret and an otpauth:// URI to show as a QR code in an authenticator app. Then call /auth/mfa/confirm with the first code to finish login.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body  object{mfa_token=string}  true  "MFA token from /auth/login"
+// @Success      200  {object}  object{code=string,message=string,data=object{secret=string,otpauth_uri=string}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /auth/mfa/enroll [post]
+func (api *UserAPI) EnrollMFAOnLogin(c *gin.Context) {
+	var reqBody struct {
+		MfaToken string `json:"mfa_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := api.userClient.EnrollTOTP(c.Request.Context(), &pb.EnrollTOTPRequest{MfaToken: reqBody.MfaToken})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Scan the QR code with an authenticator app, then confirm with the first code", totpEnrollmentJSON(resp))
+}
+
+// ConfirmMFAOnLogin godoc
+// @Summary      Confirm two-factor setup and finish login (Admin)
+// @Description  Enables two-factor authentication with the first code from the authenticator app and completes the login. recovery_codes are shown only once; each can replace a TOTP code one time if the device is lost.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body  object{mfa_token=string,code=string}  true  "MFA token and first TOTP code"
+// @Success      200  {object}  object{code=string,message=string,data=object{recovery_codes=array,user=object,access_token=string,refresh_token=string,access_token_expires_at=int64,refresh_token_expires_at=int64}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string} "Two-factor verification locked"
+// @Failure      409  {object}  object{code=string,message=string} "Enrollment not started"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /auth/mfa/confirm [post]
+func (api *UserAPI) ConfirmMFAOnLogin(c *gin.Context) {
+	var reqBody struct {
+		MfaToken string `json:"mfa_token" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := api.userClient.ConfirmTOTP(c.Request.Context(), &pb.ConfirmTOTPRequest{
+		MfaToken: reqBody.MfaToken,
+		Code:     reqBody.Code,
+	})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	log.Printf("[LOGIN] User %s enabled two-factor authentication and logged in", resp.Login.User.Id)
+	successResponse(c, http.StatusOK, "Two-factor authentication enabled, store the recovery codes safely", gin.H{
+		"recovery_codes":           resp.RecoveryCodes,
+		"user":                     loginUserJSON(resp.Login.User),
+		"access_token":             resp.Login.AccessToken,
+		"refresh_token":            resp.Login.RefreshToken,
+		"access_token_expires_at":  resp.Login.AccessTokenExpiresAt,
+		"refresh_token_expires_at": resp.Login.RefreshTokenExpiresAt,
+	})
+}
+
+// EnrollTOTP godoc
+// @Summary      Start two-factor authentication setup
+// @Description  Returns a new TOTP secret and an otpauth:// URI to show as a QR code. Two-factor authentication is only enabled after POST /user/mfa/totp/confirm. Calling again replaces a secret that was not confirmed yet.
+// @Tags         User - Security
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  object{code=string,message=string,data=object{secret=string,otpauth_uri=string}}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string} "Already enabled"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /user/mfa/totp/enroll [post]
+func (api *UserAPI) EnrollTOTP(c *gin.Context) {
+	resp, err := api.userClient.EnrollTOTP(c.Request.Context(), &pb.EnrollTOTPRequest{UserId: c.GetString("user_id")})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Scan the QR code with an authenticator app, then confirm with the first code", totpEnrollmentJSON(resp))
+}
+
+// ConfirmTOTP godoc
+// @Summary      Enable two-factor authentication
+// @Description  Confirms the secret from /user/mfa/totp/enroll with the first code from the authenticator app. From the next login a code is required. recovery_codes are shown only once.
+// @Tags         User - Security
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  object{code=string}  true  "First TOTP code"
+// @Success      200  {object}  object{code=string,message=string,data=object{recovery_codes=array}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string} "Two-factor verification locked"
+// @Failure      409  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /user/mfa/totp/confirm [post]
+func (api *UserAPI) ConfirmTOTP(c *gin.Context) {
+	var reqBody struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := api.userClient.ConfirmTOTP(c.Request.Context(), &pb.ConfirmTOTPRequest{
+		UserId: c.GetString("user_id"),
+		Code:   reqBody.Code,
+	})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Two-factor authentication enabled, store the recovery codes safely", gin.H{
+		"recovery_codes": resp.RecoveryCodes,
+	})
+}
+
+// DisableTOTP godoc
+// @Summary      Disable two-factor authentication
+// @Description  Requires a current TOTP code or a recovery code. Not allowed for Admin accounts, which must always use two-factor authentication.
+// @Tags         User - Security
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  object{code=string}  true  "TOTP or recovery code"
+// @Success      200  {object}  object{code=string,message=string}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string} "Two-factor verification locked"
+// @Failure      409  {object}  object{code=string,message=string} "Not enabled, or Admin account"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /user/mfa/totp/disable [post]
+func (api *UserAPI) DisableTOTP(c *gin.Context) {
+	var reqBody struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err := api.userClient.DisableTOTP(c.Request.Context(), &pb.DisableTOTPRequest{
+		UserId: c.GetString("user_id"),
+		Code:   reqBody.Code,
+	})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+// mfaChallengeResponse trả mfa_token khi password đúng nhưng còn bước 2FA (chưa có tokens)
+func mfaChallengeResponse(c *gin.Context, resp *pb.LoginResponse) {
+	message := "Two-factor authentication required"
+	if resp.MfaEnrollmentRequired {
+		message = "Two-factor authentication must be set up for this account"
+	}
+	successResponse(c, http.StatusOK, message, gin.H{
+		"user":                    loginUserJSON(resp.User),
+		"mfa_required":            true,
+		"mfa_token":               resp.MfaToken,
+		"mfa_token_expires_at":    resp.MfaTokenExpiresAt,
+		"mfa_enrollment_required": resp.MfaEnrollmentRequired,
+	})
+}
+
+func loginUserJSON(user *pb.User) gin.H {
+	return gin.H{
+		"id":             user.Id,
+		"phone_number":   user.PhoneNumber,
+		"name":           user.Name,
+		"platform_role":  user.PlatformRole,
+		"phone_verified": user.PhoneVerified,
+	}
+}
+
+func totpEnrollmentJSON(resp *pb.EnrollTOTPResponse) gin.H {
+	return gin.H{
+		"secret":      resp.Secret,
+		"otpauth_uri": resp.OtpauthUri,
+	}
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/user"
+)
+
+// Phone number verification (OTP qua SMS)
+// Giải thích: User Service gửi mã 6 số qua SMS, dùng để xác minh số sau đăng ký,
+// đổi số điện thoại (mã gửi tới số mới) và đăng nhập không mật khẩu.
+// Gửi lại bị giới hạn theo số điện thoại → 429.
+
+// RequestLoginOTP godoc
+// @Summary      Request a passwordless login code
+// @Description  Sends a one-time code by SMS to the phone number of an account. The response is the same whether or not the phone number is registered. Codes expire after a few minutes; a new code can be requested after resend_after.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body  object{phone_number=string}  true  "Phone number"
+// @Success      200  {object}  object{code=string,message=string,data=object{expires_at=int64,resend_after=int64}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      429  {object}  object{code=string,message=string} "Too many codes requested"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /auth/otp/request [post]
+func (api *UserAPI) RequestLoginOTP(c *gin.Context) {
+	var reqBody struct {
+		PhoneNumber string `json:"phone_number" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	api.sendPhoneOTP(c, &pb.SendPhoneOTPRequest{
+		Purpose:     "login",
+		PhoneNumber: reqBody.PhoneNumber,
+	})
+}
+
+// LoginWithOTP godoc
+// @Summary      Log in with an SMS code
+// @Description  Passwordless login with the code from /auth/otp/request. Accounts with two-factor authentication (and all Admins) still get mfa_required=true and must finish with /auth/mfa/verify, exactly like /auth/login. Logging in with a code also marks the phone number as verified.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body  object{phone_number=string,code=string}  true  "Phone number and code"
+// @Success      200  {object}  object{code=string,message=string,data=object{user=object,access_token=string,refresh_token=string,access_token_expires_at=int64,refresh_token_expires_at=int64,requires_consent=boolean,requires_mandatory_consent=boolean,pending_policies=array,consent_message=string}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string} "Wrong, expired or used code"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /auth/otp/login [post]
+func (api *UserAPI) LoginWithOTP(c *gin.Context) {
+	var reqBody struct {
+		PhoneNumber string `json:"phone_number" binding:"required"`
+		Code        string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := api.userClient.LoginWithOTP(c.Request.Context(), &pb.LoginWithOTPRequest{
+		PhoneNumber: reqBody.PhoneNumber,
+		Code:        reqBody.Code,
+	})
+	if err != nil {
+		log.Printf("[LOGIN] OTP login failed: %v", err)
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	if resp.MfaRequired {
+		log.Printf("[LOGIN] Step 1 SUCCESS: User %s requires two-factor authentication", resp.User.Id)
+		mfaChallengeResponse(c, resp)
+		return
+	}
+
+	log.Printf("[LOGIN] Step 1 SUCCESS: User %s authenticated with SMS code", resp.User.Id)
+	api.completeLogin(c, resp)
+}
+
+// RequestPhoneVerification godoc
+// @Summary      Send a code to verify the current phone number
+// @Description  Sends a new verification code by SMS to the phone number of the account. A code is already sent after registration; use this to get a new one.
+// @Tags         User - Security
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  object{code=string,message=string,data=object{expires_at=int64,resend_after=int64}}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string} "Already verified"
+// @Failure      429  {object}  object{code=string,message=string} "Too many codes requested"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /user/phone/verify/request [post]
+func (api *UserAPI) RequestPhoneVerification(c *gin.Context) {
+	api.sendPhoneOTP(c, &pb.SendPhoneOTPRequest{
+		Purpose: "verify_phone",
+		UserId:  c.GetString("user_id"),
+	})
+}
+
+// VerifyPhone godoc
+// @Summary      Verify the current phone number
+// @Description  Confirms ownership of the phone number with the code received by SMS.
+// @Tags         User - Security
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  object{code=string}  true  "Code from SMS"
+// @Success      200  {object}  object{code=string,message=string,data=object{user=object}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string} "Wrong, expired or used code"
+// @Failure      409  {object}  object{code=string,message=string} "Already verified"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /user/phone/verify [post]
+func (api *UserAPI) VerifyPhone(c *gin.Context) {
+	var reqBody struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := api.userClient.VerifyPhone(c.Request.Context(), &pb.VerifyPhoneRequest{
+		UserId: c.GetString("user_id"),
+		Code:   reqBody.Code,
+	})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Phone number verified", gin.H{
+		"user": loginUserJSON(resp.User),
+	})
+}
+
+// RequestPhoneChange godoc
+// @Summary      Send a code to a new phone number
+// @Description  First step of changing the phone number: sends a code by SMS to the new number. Then call /user/phone/change with the same number and the code.
+// @Tags         User - Security
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  object{phone_number=string}  true  "New phone number"
+// @Success      200  {object}  object{code=string,message=string,data=object{expires_at=int64,resend_after=int64}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string} "Phone number already in use"
+// @Failure      429  {object}  object{code=string,message=string} "Too many codes requested"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /user/phone/change/request [post]
+func (api *UserAPI) RequestPhoneChange(c *gin.Context) {
+	var reqBody struct {
+		PhoneNumber string `json:"phone_number" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	api.sendPhoneOTP(c, &pb.SendPhoneOTPRequest{
+		Purpose:     "change_phone",
+		UserId:      c.GetString("user_id"),
+		PhoneNumber: reqBody.PhoneNumber,
+	})
+}
+
+// ChangePhone godoc
+// @Summary      Change the phone number
+// @Description  Changes the phone number of the account using the code sent to the new number by /user/phone/change/request. The new number is verified immediately.
+// @Tags         User - Security
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  object{phone_number=string,code=string}  true  "New phone number and code"
+// @Success      200  {object}  object{code=string,message=string,data=object{user=object}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string} "Wrong, expired or used code"
+// @Failure      409  {object}  object{code=string,message=string} "Phone number already in use"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /user/phone/change [post]
+func (api *UserAPI) ChangePhone(c *gin.Context) {
+	var reqBody struct {
+		PhoneNumber string `json:"phone_number" binding:"required"`
+		Code        string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := api.userClient.UpdateUserProfile(c.Request.Context(), &pb.UpdateUserProfileRequest{
+		UserId:      c.GetString("user_id"),
+		PhoneNumber: reqBody.PhoneNumber,
+		OtpCode:     reqBody.Code,
+	})
+	if err != nil {
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Phone number changed", gin.H{
+		"user": loginUserJSON(resp.User),
+	})
+}
+
+// sendPhoneOTP gọi SendPhoneOTP và trả thời hạn của mã
+func (api *UserAPI) sendPhoneOTP(c *gin.Context, req *pb.SendPhoneOTPRequest) {
+	resp, err := api.userClient.SendPhoneOTP(c.Request.Context(), req)
+	if err != nil {
+		// ResourceExhausted của OTP là giới hạn gửi lại (GrpcErrorToHTTP map sang 413 cho upload file)
+		if status.Code(err) == codes.ResourceExhausted {
+			errorResponse(c, http.StatusTooManyRequests, status.Convert(err).Message())
+			return
+		}
+		grpcErrorResponse(c, err)
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Verification code sent", gin.H{
+		"expires_at":   resp.ExpiresAt,
+		"resend_after": resp.ResendAfter,
+	})
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/thatlq1812/policy-system/gateway/internal/clients"
+	"github.com/thatlq1812/policy-system/gateway/internal/middleware"
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/saga"
+)
+
+// SagaAPI cho Admin theo dõi saga (vd: đăng ký user + consent, xóa dữ liệu user) bị kẹt hoặc đang bù trừ
+type SagaAPI struct {
+	userClient *clients.UserClient
+}
+
+// NewSagaAPI tạo mới SagaAPI handler
+func NewSagaAPI(userClient *clients.UserClient) *SagaAPI {
+	return &SagaAPI{userClient: userClient}
+}
+
+// ListSagas godoc
+// @Summary      List sagas (Admin only)
+// @Description  List multi-service sagas (newest first). stuck=true shows sagas still unfinished 5 minutes after they started: compensation keeps failing or the orchestrator is catching up.
+// @Tags         Admin - Sagas
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type       query  string  false  "Saga type (registration | erasure)"
+// @Param        status     query  string  false  "running | compensating | completed | compensated"
+// @Param        stuck      query  bool    false  "Only stuck sagas"
+// @Param        page       query  int     false  "Page number (default: 1)"
+// @Param        page_size  query  int     false  "Items per page (default: 20, max: 100)"
+// @Success      200  {object}  object{code=string,message=string,data=object{sagas=[]object,total_count=int32,page=int32,page_size=int32,total_pages=int32}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/sagas [get]
+func (api *SagaAPI) ListSagas(c *gin.Context) {
+	page, err := parsePositiveInt(c.Query("page"), 1)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "page must be a positive integer")
+		return
+	}
+	pageSize, err := parsePositiveInt(c.Query("page_size"), 20)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "page_size must be a positive integer")
+		return
+	}
+
+	resp, err := api.userClient.ListSagas(c.Request.Context(), &pb.ListSagasRequest{
+		Type:      c.Query("type"),
+		Status:    c.Query("status"),
+		StuckOnly: c.Query("stuck") == "true",
+		Page:      int32(page),
+		PageSize:  int32(pageSize),
+	})
+	if err != nil {
+		log.Printf("[ADMIN] Failed to list sagas: %v", err)
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	sagas := make([]gin.H, len(resp.Sagas))
+	for i, s := range resp.Sagas {
+		sagas[i] = sagaJSON(s)
+	}
+
+	totalPages := int32(0)
+	if resp.PageSize > 0 {
+		totalPages = (resp.TotalCount + resp.PageSize - 1) / resp.PageSize
+	}
+	successResponse(c, http.StatusOK, "Sagas retrieved successfully", gin.H{
+		"sagas":       sagas,
+		"total_count": resp.TotalCount,
+		"page":        resp.Page,
+		"page_size":   resp.PageSize,
+		"total_pages": totalPages,
+	})
+}
+
+// GetSaga godoc
+// @Summary      Get saga details (Admin only)
+// @Description  Get a saga with every executed and compensated step (error, duration)
+// @Tags         Admin - Sagas
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Saga ID"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/sagas/{id} [get]
+func (api *SagaAPI) GetSaga(c *gin.Context) {
+	s, err := api.userClient.GetSaga(c.Request.Context(), &pb.GetSagaRequest{Id: c.Param("id")})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Saga retrieved successfully", sagaJSON(s))
+}
+
+// RetrySaga godoc
+// @Summary      Retry a saga now (Admin only)
+// @Description  Run the pending compensation of a compensating saga, or the failed step of a forward saga (e.g. erasure), immediately instead of waiting for its backoff
+// @Tags         Admin - Sagas
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Saga ID"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      409  {object}  object{code=string,message=string} "Saga is not waiting for a retry"
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/sagas/{id}/retry [post]
+func (api *SagaAPI) RetrySaga(c *gin.Context) {
+	id := c.Param("id")
+	log.Printf("[ADMIN] Admin %s retrying saga %s", c.GetString("user_id"), id)
+
+	s, err := api.userClient.RetrySaga(c.Request.Context(), &pb.RetrySagaRequest{Id: id})
+	if err != nil {
+		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		c.JSON(statusCode, gin.H{
+			"code":    code,
+			"message": msg,
+		})
+		return
+	}
+
+	successResponse(c, http.StatusOK, "Saga compensation scheduled", sagaJSON(s))
+}
+
+func sagaJSON(s *pb.Saga) gin.H {
+	steps := make([]gin.H, len(s.Steps))
+	for i, st := range s.Steps {
+		steps[i] = gin.H{
+			"step":        st.Step,
+			"action":      st.Action,
+			"error":       st.Error,
+			"at":          time.UnixMilli(st.At).UTC().Format(time.RFC3339Nano),
+			"duration_ms": st.DurationMs,
+		}
+	}
+
+	return gin.H{
+		"id":              s.Id,
+		"type":            s.Type,
+		"status":          s.Status,
+		"current_step":    s.CurrentStep,
+		"data":            rawJSONOrNil(s.DataJson),
+		"attempts":        s.Attempts,
+		"last_error":      s.LastError,
+		"next_attempt_at": s.NextAttemptAt,
+		"stuck":           s.Stuck,
+		"created_at":      time.UnixMilli(s.CreatedAt).UTC().Format(time.RFC3339Nano),
+		"updated_at":      time.UnixMilli(s.UpdatedAt).UTC().Format(time.RFC3339Nano),
+		"finished_at":     s.FinishedAt,
+		"steps":           steps,
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/thatlq1812/policy-system/gateway/internal/clients"
 	"github.com/thatlq1812/policy-system/gateway/internal/middleware"
@@ -18,10 +20,10 @@ import (
 )
 
 // UserAPI xử lý các HTTP endpoints liên quan đến User
-// Giải thích: Cần access đến cả 3 clients để làm orchestration
-// - userClient: Tạo/xóa user
-// - documentClient: Lấy policies mới nhất
-// - consentClient: Record consent cho user
+// Giải thích: Cần access đến cả 3 clients
+// - userClient: Đăng ký (saga đăng ký + consent chạy trong User Service), đăng nhập
+// - documentClient: Lấy policies đang hiệu lực khi login
+// - consentClient: Kiểm tra pending consents khi login
 type UserAPI struct {
 	userClient     *clients.UserClient
 	documentClient *clients.DocumentClient
@@ -128,6 +130,11 @@ func (api *UserAPI) Login(c *gin.Context) {
 		return
 	}
 
+	if grpcResp.MfaRequired {
+		mfaChallengeResponse(c, grpcResp)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"code":    "200",
 		"message": "Login successful",
@@ -148,12 +155,12 @@ func (api *UserAPI) Login(c *gin.Context) {
 
 // LoginWithPendingCheck godoc
 // @Summary      User Login with Pending Consent Check
-// @Description  Authenticate user with phone number and password. Returns access token, refresh token, and checks for pending consents that require user acceptance.
+// @Description  Authenticate user with phone number and password. Returns access token, refresh token, and checks every active policy of the platform for pending consents (mandatory and optional). When the account has two-factor authentication (always for Admin), no tokens are returned: the response has mfa_required=true and an mfa_token for POST /auth/mfa/verify, or mfa_enrollment_required=true for an Admin that must first set up 2FA via /auth/mfa/enroll and /auth/mfa/confirm.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
 // @Param        request body object{phone_number=string,password=string} true "Login credentials" example({"phone_number":"0901234567","password":"SecurePass@123"})
-// @Success      200  {object}  object{code=string,message=string,data=object{user=object{id=string,phone_number=string,name=string,platform_role=string},access_token=string,refresh_token=string,access_token_expires_at=int64,refresh_token_expires_at=int64,requires_consent=boolean,pending_policies=array,consent_message=string}}
+// @Success      200  {object}  object{code=string,message=string,data=object{user=object{id=string,phone_number=string,name=string,platform_role=string},access_token=string,refresh_token=string,access_token_expires_at=int64,refresh_token_expires_at=int64,requires_consent=boolean,requires_mandatory_consent=boolean,pending_policies=array,consent_message=string,mfa_required=boolean,mfa_token=string,mfa_token_expires_at=int64,mfa_enrollment_required=boolean}}
 // @Failure      400  {object}  object{code=string,message=string} "Bad Request - Missing phone/password"
 // @Failure      401  {object}  object{code=string,message=string} "Unauthorized - Invalid credentials"
 // @Failure      500  {object}  object{code=string,message=string}
@@ -194,59 +201,87 @@ func (api *UserAPI) LoginWithPendingCheck(c *gin.Context) {
 		return
 	}
 
+	// Password đúng nhưng còn bước 2FA: trả mfa_token, tokens chỉ cấp sau VerifyMFA
+	if userResp.MfaRequired {
+		log.Printf("[LOGIN] Step 1 SUCCESS: User %s requires two-factor authentication", userResp.User.Id)
+		mfaChallengeResponse(c, userResp)
+		return
+	}
+
+	log.Printf("[LOGIN] Step 1 SUCCESS: User %s authenticated", userResp.User.Id)
+	api.completeLogin(c, userResp)
+}
+
+// completeLogin trả tokens kèm pending consents (đăng nhập 1 bước hoặc sau bước 2FA)
+func (api *UserAPI) completeLogin(c *gin.Context, userResp *pb.LoginResponse) {
 	userID := userResp.User.Id
-	log.Printf("[LOGIN] Step 1 SUCCESS: User %s authenticated", userID)
 
-	// ===== STEP 2: Get Latest Policy (OPTIONAL - không block login) =====
+	// ===== STEP 2: Check Pending Consents (OPTIONAL - không block login) =====
+	// Consent Service tự resolve tất cả active policies (Terms, Privacy, Cookie...) của platform
+	// từ Document Service → kết quả authoritative, kèm mandatory flag và grace deadline
 	var pendingPolicies []map[string]interface{}
+	hasMandatoryPending := false
 
-	policyCtx, policyCancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
-	defer policyCancel()
+	consentCtx, consentCancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer consentCancel()
 
-	policyResp, err := api.documentClient.GetLatestPolicy(policyCtx, &docpb.GetLatestPolicyRequest{
-		Platform: userResp.User.PlatformRole,
+	pendingResp, err := api.consentClient.CheckPendingConsents(consentCtx, &consentpb.CheckPendingConsentsRequest{
+		UserId:                userID,
+		Platform:              userResp.User.PlatformRole,
+		ResolveActivePolicies: true,
 	})
 
 	if err != nil {
 		// Log warning nhưng KHÔNG fail login
-		log.Printf("[LOGIN WARNING] Step 2 FAILED: Cannot get latest policy: %v", err)
-		log.Printf("[LOGIN] Continuing login without pending check (DocumentService unavailable)")
-	} else if policyResp.Document != nil {
-		log.Printf("[LOGIN] Step 2 SUCCESS: Got policy=%s, mandatory=%t",
-			policyResp.Document.DocumentName,
-			policyResp.Document.IsMandatory)
-
-		// ===== STEP 3: Check Consent Status (OPTIONAL - không block login) =====
-		consentCtx, consentCancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
-		defer consentCancel()
-
-		checkResp, err := api.consentClient.CheckConsent(consentCtx, &consentpb.CheckConsentRequest{
-			UserId:              userID,
-			DocumentId:          policyResp.Document.Id,
-			MinVersionTimestamp: policyResp.Document.EffectiveTimestamp,
-		})
+		log.Printf("[LOGIN WARNING] Step 2 FAILED: Cannot check pending consents: %v", err)
+		log.Printf("[LOGIN] Continuing login without pending check (ConsentService/DocumentService unavailable)")
+	} else if len(pendingResp.PendingPolicies) > 0 {
+		log.Printf("[LOGIN] Step 2 SUCCESS: User %s has %d pending consent(s)", userID, len(pendingResp.PendingPolicies))
+
+		// ===== STEP 3: Get Policy Details for display (OPTIONAL) =====
+		// Chỉ để hiển thị content_summary/file_url, danh sách pending lấy từ Step 2
+		activeDocs := make(map[string]*docpb.PolicyDocument)
 
+		policyCtx, policyCancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+		defer policyCancel()
+
+		policyResp, err := api.documentClient.ListActivePolicies(policyCtx, &docpb.ListActivePoliciesRequest{
+			Platform: userResp.User.PlatformRole,
+			Locale:   c.GetString("locale"),
+		})
 		if err != nil {
-			// Log warning nhưng KHÔNG fail login
-			log.Printf("[LOGIN WARNING] Step 3 FAILED: Cannot check consent: %v", err)
-			log.Printf("[LOGIN] Continuing login without pending check (ConsentService unavailable)")
-		} else if !checkResp.HasConsented {
-			// User chưa consent policy này → Thêm vào pending list
-			log.Printf("[LOGIN] Step 3 SUCCESS: User %s has pending consent for policy %s",
-				userID, policyResp.Document.DocumentName)
-
-			pendingPolicies = append(pendingPolicies, map[string]interface{}{
-				"id":                  policyResp.Document.Id,
-				"document_name":       policyResp.Document.DocumentName,
-				"platform":            policyResp.Document.Platform,
-				"is_mandatory":        policyResp.Document.IsMandatory,
-				"effective_timestamp": policyResp.Document.EffectiveTimestamp,
-				"content_summary":     truncateString(policyResp.Document.ContentHtml, 200),
-				"file_url":            policyResp.Document.FileUrl,
-			})
+			log.Printf("[LOGIN WARNING] Step 3 FAILED: Cannot get policy details: %v", err)
 		} else {
-			log.Printf("[LOGIN] Step 3 SUCCESS: User %s has already consented to latest policy", userID)
+			for _, doc := range policyResp.Documents {
+				activeDocs[doc.Id] = doc
+			}
 		}
+
+		for _, p := range pendingResp.PendingPolicies {
+			if p.IsMandatory {
+				hasMandatoryPending = true
+			}
+
+			// User chưa consent policy này → Thêm vào pending list (cả mandatory lẫn optional)
+			pending := map[string]interface{}{
+				"id":                  p.DocumentId,
+				"document_name":       p.DocumentName,
+				"platform":            p.Platform,
+				"is_mandatory":        p.IsMandatory,
+				"effective_timestamp": p.VersionTimestamp,
+				"grace_deadline":      p.GraceDeadline,
+				"is_overdue":          p.IsOverdue,
+				"consent_expired_at":  p.ConsentExpiredAt,
+			}
+			if doc, ok := activeDocs[p.DocumentId]; ok {
+				pending["content_summary"] = truncateString(doc.ContentHtml, 200)
+				pending["file_url"] = doc.FileUrl
+				pending["locale"] = doc.Locale
+			}
+			pendingPolicies = append(pendingPolicies, pending)
+		}
+	} else {
+		log.Printf("[LOGIN] Step 2 SUCCESS: User %s has already consented to all active policies", userID)
 	}
 
 	// ===== STEP 4: Return Response =====
@@ -254,12 +289,10 @@ func (api *UserAPI) LoginWithPendingCheck(c *gin.Context) {
 
 	// Prepare consent message for frontend
 	consentMessage := ""
-	if requiresConsent {
-		if len(pendingPolicies) == 1 && pendingPolicies[0]["is_mandatory"].(bool) {
-			consentMessage = "Please review and accept the updated policy to continue using our services."
-		} else if requiresConsent {
-			consentMessage = "We have updated our policies. Please review the changes."
-		}
+	if hasMandatoryPending {
+		consentMessage = "Please review and accept the updated policies to continue using our services."
+	} else if requiresConsent {
+		consentMessage = "We have updated our policies. Please review the changes."
 	}
 
 	// Success response
@@ -269,10 +302,11 @@ func (api *UserAPI) LoginWithPendingCheck(c *gin.Context) {
 		"data": gin.H{
 			// User info và tokens
 			"user": gin.H{
-				"id":            userResp.User.Id,
-				"phone_number":  userResp.User.PhoneNumber,
-				"name":          userResp.User.Name,
-				"platform_role": userResp.User.PlatformRole,
+				"id":             userResp.User.Id,
+				"phone_number":   userResp.User.PhoneNumber,
+				"name":           userResp.User.Name,
+				"platform_role":  userResp.User.PlatformRole,
+				"phone_verified": userResp.User.PhoneVerified,
 			},
 			"access_token":             userResp.AccessToken,
 			"refresh_token":            userResp.RefreshToken,
@@ -280,9 +314,10 @@ func (api *UserAPI) LoginWithPendingCheck(c *gin.Context) {
 			"refresh_token_expires_at": userResp.RefreshTokenExpiresAt,
 
 			// Pending consent info
-			"requires_consent": requiresConsent,
-			"pending_policies": pendingPolicies,
-			"consent_message":  consentMessage,
+			"requires_consent":           requiresConsent,
+			"requires_mandatory_consent": hasMandatoryPending,
+			"pending_policies":           pendingPolicies,
+			"consent_message":            consentMessage,
 		},
 	})
 
@@ -297,7 +332,7 @@ func (api *UserAPI) LoginWithPendingCheck(c *gin.Context) {
 
 // RegisterWithConsent godoc
 // @Summary      Register new user with auto-consent
-// @Description  Register a new user account and automatically record consent for the latest policy document. Uses transaction rollback if consent fails. Note: Admin role cannot be registered via this endpoint for security reasons.
+// @Description  Register a new user account and automatically record consent for the latest policy document. Runs as a durable saga: if fetching the policy or recording consent fails, the created user and consent are rolled back (retried in the background until they succeed). Note: Admin role cannot be registered via this endpoint for security reasons. A verification code is sent by SMS to the phone number; confirm it with POST /user/phone/verify.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
@@ -335,150 +370,60 @@ func (api *UserAPI) RegisterWithConsent(c *gin.Context) {
 		return
 	}
 
-	// ===== STEP 1: Register User =====
+	// Saga đăng ký chạy trong User Service: create_user → fetch_policy → record_consent.
+	// Bước lỗi (hoặc process chết giữa chừng) thì user/consent đã tạo được bù trừ, thử lại tới khi thành công.
 	log.Printf("[ORCHESTRATION] Starting registration for phone=%s, role=%s",
 		reqBody.PhoneNumber, reqBody.PlatformRole)
 
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
-
-	userResp, err := api.userClient.Register(ctx, &pb.RegisterRequest{
+	resp, err := api.userClient.RegisterWithConsent(c.Request.Context(), &pb.RegisterWithConsentRequest{
 		PhoneNumber:  reqBody.PhoneNumber,
 		Password:     reqBody.Password,
 		Name:         reqBody.Name,
 		PlatformRole: reqBody.PlatformRole,
+		Locale:       c.GetString("locale"),
+		IpAddress:    c.ClientIP(), // Gin automatically handles X-Forwarded-For
+		UserAgent:    c.GetHeader("User-Agent"),
 	})
-
 	if err != nil {
-		// Step 1 failed - không cần rollback vì chưa tạo gì
-		log.Printf("[ORCHESTRATION] Step 1 FAILED: User registration failed: %v", err)
+		log.Printf("[ORCHESTRATION] FAILED: Registration failed: %v", err)
 		statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+		if status.Code(err) == codes.Unavailable {
+			// Policy/consent lỗi → saga đã rollback (hoặc đang rollback nền)
+			msg = "Failed to complete registration, it has been rolled back: " + msg
+		} else {
+			msg = "Failed to register user: " + msg
+		}
 		c.JSON(statusCode, gin.H{
 			"code":    code,
-			"message": "Failed to register user: " + msg,
-		})
-		return
-	}
-
-	userID := userResp.User.Id
-	log.Printf("[ORCHESTRATION] Step 1 SUCCESS: User created with ID=%s", userID)
-
-	// ===== STEP 2: Get Latest Policy =====
-	policyCtx, policyCancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
-	defer policyCancel()
-
-	policyResp, err := api.documentClient.GetLatestPolicy(policyCtx, &docpb.GetLatestPolicyRequest{
-		Platform: reqBody.PlatformRole,
-	})
-
-	if err != nil {
-		// Step 2 failed - PHẢI ROLLBACK Step 1
-		log.Printf("[ORCHESTRATION] Step 2 FAILED: Cannot get latest policy: %v", err)
-		log.Printf("[ORCHESTRATION] ROLLBACK: Deleting user %s", userID)
-
-		api.rollbackUser(c.Request.Context(), userID, "policy fetch failed")
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    "500",
-			"message": "Failed to fetch policies. Registration has been rolled back.",
+			"message": msg,
 		})
 		return
 	}
 
-	log.Printf("[ORCHESTRATION] Step 2 SUCCESS: Got policy=%s, mandatory=%t",
-		policyResp.Document.DocumentName,
-		policyResp.Document.IsMandatory)
-
-	// ===== STEP 3: Record Consent (chỉ nếu mandatory) =====
-	consentsRecorded := 0
-
-	if policyResp.Document != nil && policyResp.Document.IsMandatory {
-		consentCtx, consentCancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
-		defer consentCancel()
-
-		_, err := api.consentClient.RecordConsent(consentCtx, &consentpb.RecordConsentRequest{
-			UserId:   userID,
-			Platform: reqBody.PlatformRole,
-			Consents: []*consentpb.ConsentInput{{
-				DocumentId:       policyResp.Document.Id,
-				DocumentName:     policyResp.Document.DocumentName,
-				VersionTimestamp: policyResp.Document.EffectiveTimestamp,
-			}},
-			ConsentMethod: "REGISTRATION",
-			IpAddress:     c.ClientIP(), // Gin automatically handles X-Forwarded-For
-			UserAgent:     c.GetHeader("User-Agent"),
-		})
-
-		if err != nil {
-			// Step 3 failed - PHẢI ROLLBACK Step 1
-			log.Printf("[ORCHESTRATION] Step 3 FAILED: Cannot record consent: %v", err)
-			log.Printf("[ORCHESTRATION] ROLLBACK: Deleting user %s", userID)
-
-			api.rollbackUser(c.Request.Context(), userID, "consent record failed")
-
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"code":    "500",
-				"message": "Failed to record consent. Registration has been rolled back.",
-			})
-			return
-		}
-
-		consentsRecorded = 1
-		log.Printf("[ORCHESTRATION] Step 3 SUCCESS: Consent recorded for user %s", userID)
-	} else {
-		log.Printf("[ORCHESTRATION] Step 3 SKIPPED: Policy is not mandatory")
-	}
-
-	// ===== SUCCESS: All steps completed =====
-	log.Printf("[ORCHESTRATION] COMPLETE: User %s registered successfully with %d consents",
-		userID, consentsRecorded)
+	log.Printf("[ORCHESTRATION] COMPLETE: User %s registered successfully with %d consents (saga %s)",
+		resp.User.Id, resp.ConsentsRecorded, resp.SagaId)
 
 	c.JSON(http.StatusCreated, gin.H{
 		"code":    "201",
 		"message": "Registration successful",
 		"data": gin.H{
 			"user": gin.H{
-				"id":            userResp.User.Id,
-				"phone_number":  userResp.User.PhoneNumber,
-				"name":          userResp.User.Name,
-				"platform_role": userResp.User.PlatformRole,
-				"created_at":    userResp.User.CreatedAt,
+				"id":             resp.User.Id,
+				"phone_number":   resp.User.PhoneNumber,
+				"name":           resp.User.Name,
+				"platform_role":  resp.User.PlatformRole,
+				"created_at":     resp.User.CreatedAt,
+				"phone_verified": resp.User.PhoneVerified,
 			},
-			"access_token":             userResp.AccessToken,
-			"refresh_token":            userResp.RefreshToken,
-			"access_token_expires_at":  userResp.AccessTokenExpiresAt,
-			"refresh_token_expires_at": userResp.RefreshTokenExpiresAt,
-			"consents_recorded":        consentsRecorded,
+			"access_token":             resp.AccessToken,
+			"refresh_token":            resp.RefreshToken,
+			"access_token_expires_at":  resp.AccessTokenExpiresAt,
+			"refresh_token_expires_at": resp.RefreshTokenExpiresAt,
+			"consents_recorded":        resp.ConsentsRecorded,
 		},
 	})
 }
 
-// rollbackUser xóa user khi orchestration fails
-// Giải thích:
-// - Được gọi khi Step 2 hoặc Step 3 fails
-// - Đảm bảo không có "zombie users" (user tồn tại nhưng không có consent)
-// - Nếu rollback cũng fail → Log critical error (cần manual cleanup)
-func (api *UserAPI) rollbackUser(ctx context.Context, userID string, reason string) {
-	deleteCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-
-	_, err := api.userClient.DeleteUser(deleteCtx, &pb.DeleteUserRequest{
-		UserId: userID,
-		Reason: "Registration rollback - " + reason,
-	})
-
-	if err != nil {
-		// CRITICAL ERROR: Rollback failed!
-		// Giải thích: User vẫn tồn tại trong database nhưng không có consent
-		// Action: Cần alert ops team để manual cleanup
-		log.Printf("[ROLLBACK ERROR] CRITICAL: Failed to delete user %s: %v", userID, err)
-		log.Printf("[ROLLBACK ERROR] Manual cleanup required for user_id=%s", userID)
-		// TODO: Send alert to monitoring system (Slack, PagerDuty, etc.)
-	} else {
-		log.Printf("[ROLLBACK SUCCESS] User %s deleted successfully (reason: %s)", userID, reason)
-	}
-}
-
 // ========== HELPER FUNCTIONS ==========
 
 // errorResponse sends JSON error response với Gin
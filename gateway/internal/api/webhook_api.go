@@ -0,0 +1,469 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/thatlq1812/policy-system/gateway/internal/clients"
+	"github.com/thatlq1812/policy-system/gateway/internal/middleware"
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/webhook"
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	"github.com/thatlq1812/policy-system/shared/pkg/webhook"
+)
+
+// WebhookAPI xử lý webhook subscriptions (Admin).
+// Subscription được lưu ở service phát ra event (PolicyPublished → Document, Consent* → Consent)
+// với cùng ID, Gateway gộp kết quả khi đọc.
+type WebhookAPI struct {
+	services []*clients.WebhookClient
+	owners   map[string]*clients.WebhookClient // event type → service sở hữu
+}
+
+// NewWebhookAPI tạo mới WebhookAPI handler
+func NewWebhookAPI(documentClient *clients.DocumentClient, consentClient *clients.ConsentClient) *WebhookAPI {
+	documentWebhooks := documentClient.Webhooks()
+	consentWebhooks := consentClient.Webhooks()
+	return &WebhookAPI{
+		services: []*clients.WebhookClient{documentWebhooks, consentWebhooks},
+		owners: map[string]*clients.WebhookClient{
+			outbox.EventPolicyPublished: documentWebhooks,
+			outbox.EventConsentRecorded: consentWebhooks,
+			outbox.EventConsentRevoked:  consentWebhooks,
+			outbox.EventConsentExpired:  consentWebhooks,
+			outbox.EventConsentDeclined: consentWebhooks,
+		},
+	}
+}
+
+// CreateWebhook godoc
+// @Summary      Register a webhook endpoint (Admin only)
+// @Description  Register an endpoint that receives signed JSON payloads for ConsentRecorded, ConsentRevoked, ConsentExpired, ConsentDeclined and PolicyPublished events. The generated secret is returned only once; verify X-Signature = "sha256=" + hex(HMAC-SHA256(secret, body)).
+// @Tags         Admin - Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body object{url=string,event_types=[]string,description=string} true "Webhook endpoint"
+// @Success      201  {object}  object{code=string,message=string,data=object{id=string,url=string,event_types=[]string,secret=string}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/webhooks [post]
+func (api *WebhookAPI) CreateWebhook(c *gin.Context) {
+	var reqBody struct {
+		URL         string   `json:"url" binding:"required"`
+		EventTypes  []string `json:"event_types" binding:"required,min=1"`
+		Description string   `json:"description"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Nhóm event theo service sở hữu
+	byService := make(map[*clients.WebhookClient][]string)
+	seen := make(map[string]bool)
+	for _, t := range reqBody.EventTypes {
+		owner, ok := api.owners[t]
+		if !ok {
+			errorResponse(c, http.StatusBadRequest, fmt.Sprintf("unsupported event type %q, must be one of: %s", t, strings.Join(api.eventTypes(), ", ")))
+			return
+		}
+		if !seen[t] {
+			seen[t] = true
+			byService[owner] = append(byService[owner], t)
+		}
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "failed to generate webhook id")
+		return
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "failed to generate webhook secret")
+		return
+	}
+
+	adminID := c.GetString("user_id")
+	log.Printf("[ADMIN] Admin %s registering webhook %s: url=%s, events=%v", adminID, id, reqBody.URL, reqBody.EventTypes)
+
+	// Tạo ở từng service; service sau lỗi thì xóa các subscription đã tạo (compensation)
+	var created []*clients.WebhookClient
+	var sub *pb.Subscription
+	for _, svc := range api.services {
+		events := byService[svc]
+		if len(events) == 0 {
+			continue
+		}
+
+		sub, err = svc.CreateSubscription(c.Request.Context(), &pb.CreateSubscriptionRequest{
+			Id:          id,
+			Url:         reqBody.URL,
+			EventTypes:  events,
+			Secret:      secret,
+			Description: reqBody.Description,
+			CreatedBy:   adminID,
+		})
+		if err != nil {
+			log.Printf("[ADMIN] Failed to create webhook %s in %s service: %v", id, svc.Service(), err)
+			for _, done := range created {
+				if _, delErr := done.DeleteSubscription(context.WithoutCancel(c.Request.Context()), &pb.DeleteSubscriptionRequest{Id: id}); delErr != nil {
+					log.Printf("[ADMIN] Failed to roll back webhook %s in %s service: %v", id, done.Service(), delErr)
+				}
+			}
+			statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+			c.JSON(statusCode, gin.H{
+				"code":    code,
+				"message": msg,
+			})
+			return
+		}
+		created = append(created, svc)
+	}
+
+	data := subscriptionJSON(sub, uniqueEventTypes(reqBody.EventTypes))
+	data["secret"] = secret // Chỉ trả về 1 lần
+
+	successResponse(c, http.StatusCreated, "Webhook registered successfully, store the secret now: it is not shown again", data)
+}
+
+// ListWebhooks godoc
+// @Summary      List webhook endpoints (Admin only)
+// @Description  List registered webhook endpoints with the events they receive (secrets are never returned)
+// @Tags         Admin - Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  object{code=string,message=string,data=object{webhooks=[]object}}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/webhooks [get]
+func (api *WebhookAPI) ListWebhooks(c *gin.Context) {
+	merged := make(map[string]*pb.Subscription)
+	events := make(map[string][]string)
+	var order []string
+
+	for _, svc := range api.services {
+		resp, err := svc.ListSubscriptions(c.Request.Context(), &pb.ListSubscriptionsRequest{})
+		if err != nil {
+			log.Printf("[ADMIN] Failed to list webhooks of %s service: %v", svc.Service(), err)
+			statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+			c.JSON(statusCode, gin.H{
+				"code":    code,
+				"message": msg,
+			})
+			return
+		}
+		for _, sub := range resp.Subscriptions {
+			if _, ok := merged[sub.Id]; !ok {
+				merged[sub.Id] = sub
+				order = append(order, sub.Id)
+			}
+			events[sub.Id] = append(events[sub.Id], sub.EventTypes...)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return merged[order[i]].CreatedAt > merged[order[j]].CreatedAt
+	})
+
+	webhooks := make([]gin.H, len(order))
+	for i, id := range order {
+		webhooks[i] = subscriptionJSON(merged[id], events[id])
+	}
+
+	successResponse(c, http.StatusOK, "Webhooks retrieved successfully", gin.H{
+		"webhooks": webhooks,
+	})
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a webhook endpoint (Admin only)
+// @Description  Stop sending events to the endpoint. Pending deliveries are cancelled, delivery history is kept.
+// @Tags         Admin - Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/webhooks/{id} [delete]
+func (api *WebhookAPI) DeleteWebhook(c *gin.Context) {
+	id := c.Param("id")
+	log.Printf("[ADMIN] Admin %s deleting webhook %s", c.GetString("user_id"), id)
+
+	// Subscription chỉ tồn tại ở các service sở hữu event đã chọn → NotFound ở service khác là bình thường
+	deleted := false
+	for _, svc := range api.services {
+		_, err := svc.DeleteSubscription(c.Request.Context(), &pb.DeleteSubscriptionRequest{Id: id})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			log.Printf("[ADMIN] Failed to delete webhook %s in %s service: %v", id, svc.Service(), err)
+			statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+			c.JSON(statusCode, gin.H{
+				"code":    code,
+				"message": msg,
+			})
+			return
+		}
+		deleted = true
+	}
+
+	if !deleted {
+		errorResponse(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	successResponse(c, http.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      Webhook delivery history (Admin only)
+// @Description  List deliveries (newest first) with every attempt (status code, error, duration). status=dead lists the dead-letter queue.
+// @Tags         Admin - Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        subscription_id  query  string  false  "Filter by webhook ID"
+// @Param        status           query  string  false  "pending | sending | delivered | dead | cancelled"
+// @Param        page             query  int     false  "Page number (default: 1)"
+// @Param        page_size        query  int     false  "Items per page (default: 20, max: 100)"
+// @Success      200  {object}  object{code=string,message=string,data=object{deliveries=[]object,total_count=int32,page=int32,page_size=int32,total_pages=int32}}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/webhooks/deliveries [get]
+func (api *WebhookAPI) ListWebhookDeliveries(c *gin.Context) {
+	page, err := parsePositiveInt(c.Query("page"), 1)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "page must be a positive integer")
+		return
+	}
+	pageSize, err := parsePositiveInt(c.Query("page_size"), webhook.DefaultPageSize)
+	if err != nil || pageSize > webhook.MaxPageSize {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("page_size must be between 1 and %d", webhook.MaxPageSize))
+		return
+	}
+
+	// Gộp 2 service: lấy page*page_size delivery mới nhất của mỗi service rồi sắp xếp lại
+	window := page * pageSize
+	if window > maxAuditMergeWindow {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("page * page_size must not exceed %d, filter by subscription_id or status", maxAuditMergeWindow))
+		return
+	}
+
+	filter := &pb.ListDeliveriesRequest{
+		SubscriptionId: c.Query("subscription_id"),
+		Status:         c.Query("status"),
+	}
+
+	var deliveries []*pb.Delivery
+	var total int32
+	for _, svc := range api.services {
+		got, count, err := fetchDeliveries(c.Request.Context(), svc, filter, window)
+		if err != nil {
+			log.Printf("[ADMIN] Failed to list webhook deliveries of %s service: %v", svc.Service(), err)
+			statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+			c.JSON(statusCode, gin.H{
+				"code":    code,
+				"message": msg,
+			})
+			return
+		}
+		deliveries = append(deliveries, got...)
+		total += count
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt > deliveries[j].CreatedAt
+	})
+	start := (page - 1) * pageSize
+	if start > len(deliveries) {
+		start = len(deliveries)
+	}
+	end := start + pageSize
+	if end > len(deliveries) {
+		end = len(deliveries)
+	}
+
+	result := make([]gin.H, 0, end-start)
+	for _, d := range deliveries[start:end] {
+		result = append(result, deliveryJSON(d))
+	}
+
+	successResponse(c, http.StatusOK, "Webhook deliveries retrieved successfully", gin.H{
+		"deliveries":  result,
+		"total_count": total,
+		"page":        page,
+		"page_size":   pageSize,
+		"total_pages": (int(total) + pageSize - 1) / pageSize,
+	})
+}
+
+// RedeliverWebhook godoc
+// @Summary      Redeliver a webhook delivery (Admin only)
+// @Description  Queue a delivery (including dead-lettered or already delivered ones) to be sent again immediately. Attempts restart from 0, history is kept.
+// @Tags         Admin - Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        delivery_id  path      string  true  "Delivery ID"
+// @Success      200  {object}  object{code=string,message=string,data=object}
+// @Failure      400  {object}  object{code=string,message=string}
+// @Failure      401  {object}  object{code=string,message=string}
+// @Failure      403  {object}  object{code=string,message=string}
+// @Failure      404  {object}  object{code=string,message=string}
+// @Failure      500  {object}  object{code=string,message=string}
+// @Router       /admin/webhooks/deliveries/{delivery_id}/redeliver [post]
+func (api *WebhookAPI) RedeliverWebhook(c *gin.Context) {
+	deliveryID := c.Param("delivery_id")
+	log.Printf("[ADMIN] Admin %s redelivering webhook delivery %s", c.GetString("user_id"), deliveryID)
+
+	// Delivery ID là UUID riêng của từng service → thử lần lượt
+	for _, svc := range api.services {
+		d, err := svc.RedeliverDelivery(c.Request.Context(), &pb.RedeliverDeliveryRequest{DeliveryId: deliveryID})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			statusCode, code, msg := middleware.GrpcErrorToHTTP(err)
+			c.JSON(statusCode, gin.H{
+				"code":    code,
+				"message": msg,
+			})
+			return
+		}
+		successResponse(c, http.StatusOK, "Delivery queued for redelivery", deliveryJSON(d))
+		return
+	}
+
+	errorResponse(c, http.StatusNotFound, "Delivery not found")
+}
+
+// fetchDeliveries lấy tối đa window delivery mới nhất của 1 service
+func fetchDeliveries(ctx context.Context, svc *clients.WebhookClient, filter *pb.ListDeliveriesRequest, window int) ([]*pb.Delivery, int32, error) {
+	pageSize := window
+	if pageSize > webhook.MaxPageSize {
+		pageSize = webhook.MaxPageSize
+	}
+
+	var deliveries []*pb.Delivery
+	var total int32
+	for page := 1; len(deliveries) < window; page++ {
+		resp, err := svc.ListDeliveries(ctx, &pb.ListDeliveriesRequest{
+			SubscriptionId: filter.SubscriptionId,
+			Status:         filter.Status,
+			Page:           int32(page),
+			PageSize:       int32(pageSize),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, resp.Deliveries...)
+		total = resp.TotalCount
+		if len(resp.Deliveries) < pageSize {
+			break
+		}
+	}
+	if len(deliveries) > window {
+		deliveries = deliveries[:window]
+	}
+	return deliveries, total, nil
+}
+
+func (api *WebhookAPI) eventTypes() []string {
+	types := make([]string, 0, len(api.owners))
+	for t := range api.owners {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func subscriptionJSON(sub *pb.Subscription, eventTypes []string) gin.H {
+	return gin.H{
+		"id":          sub.Id,
+		"url":         sub.Url,
+		"event_types": eventTypes,
+		"description": sub.Description,
+		"is_active":   sub.IsActive,
+		"created_by":  sub.CreatedBy,
+		"created_at":  sub.CreatedAt,
+	}
+}
+
+func deliveryJSON(d *pb.Delivery) gin.H {
+	history := make([]gin.H, len(d.History))
+	for i, a := range d.History {
+		history[i] = gin.H{
+			"attempted_at": time.UnixMilli(a.AttemptedAt).UTC().Format(time.RFC3339Nano),
+			"status_code":  a.StatusCode,
+			"error":        a.Error,
+			"duration_ms":  a.DurationMs,
+		}
+	}
+
+	return gin.H{
+		"id":               d.Id,
+		"subscription_id":  d.SubscriptionId,
+		"service":          d.Service,
+		"event_id":         d.EventId,
+		"event_type":       d.EventType,
+		"status":           d.Status,
+		"attempts":         d.Attempts,
+		"next_attempt_at":  d.NextAttemptAt,
+		"last_status_code": d.LastStatusCode,
+		"last_error":       d.LastError,
+		"delivered_at":     d.DeliveredAt,
+		"created_at":       time.UnixMilli(d.CreatedAt).UTC().Format(time.RFC3339Nano),
+		"payload":          rawJSONOrNil(d.PayloadJson),
+		"history":          history,
+	}
+}
+
+func uniqueEventTypes(types []string) []string {
+	seen := make(map[string]bool, len(types))
+	var result []string
+	for _, t := range types {
+		if !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// newUUID sinh UUID v4
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
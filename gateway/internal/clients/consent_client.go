@@ -6,7 +6,9 @@ import (
 	"log"
 	"time"
 
+	auditpb "github.com/thatlq1812/policy-system/shared/pkg/api/audit"
 	pb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +18,8 @@ import (
 type ConsentClient struct {
 	conn    *grpc.ClientConn
 	client  pb.ConsentServiceClient
+	audit   auditpb.AuditServiceClient
+	webhook *WebhookClient
 	timeout time.Duration
 }
 
@@ -31,6 +35,9 @@ func NewConsentClient(addr string, timeout time.Duration) (*ConsentClient, error
 	conn, err := grpc.DialContext(ctx, addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(), // Wait until connection is ready
+		// Chuyển actor/request ID/IP trong context sang metadata cho audit log
+		grpc.WithUnaryInterceptor(audit.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(audit.StreamClientInterceptor()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to consent service at %s: %w", addr, err)
@@ -41,6 +48,8 @@ func NewConsentClient(addr string, timeout time.Duration) (*ConsentClient, error
 	return &ConsentClient{
 		conn:    conn,
 		client:  pb.NewConsentServiceClient(conn),
+		audit:   auditpb.NewAuditServiceClient(conn),
+		webhook: newWebhookClient(conn, "consent", timeout),
 		timeout: timeout,
 	}, nil
 }
@@ -85,6 +94,38 @@ func (c *ConsentClient) RevokeConsent(ctx context.Context, req *pb.RevokeConsent
 	return c.client.RevokeConsent(ctx, req)
 }
 
+// DeclineConsent gọi DeclineConsent RPC
+// Giải thích: Ghi lại việc user chủ động từ chối policy không bắt buộc
+func (c *ConsentClient) DeclineConsent(ctx context.Context, req *pb.DeclineConsentRequest) (*pb.DeclineConsentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.DeclineConsent(ctx, req)
+}
+
+// GetConsentHistory gọi GetConsentHistory RPC
+// Giải thích: Lấy mọi consent của user với 1 document kèm timeline đồng ý/thu hồi/từ chối
+func (c *ConsentClient) GetConsentHistory(ctx context.Context, req *pb.GetConsentHistoryRequest) (*pb.GetConsentHistoryResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.GetConsentHistory(ctx, req)
+}
+
+// GetDocumentSnapshot gọi GetDocumentSnapshot RPC
+// Giải thích: Lấy đúng nội dung document user đã đồng ý (theo document_content_hash của consent)
+func (c *ConsentClient) GetDocumentSnapshot(ctx context.Context, req *pb.GetDocumentSnapshotRequest) (*pb.GetDocumentSnapshotResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.GetDocumentSnapshot(ctx, req)
+}
+
+// CheckPurposeConsent gọi CheckPurposeConsent RPC
+// Giải thích: Lấy lựa chọn mới nhất của user với 1 purpose (vd: marketing)
+func (c *ConsentClient) CheckPurposeConsent(ctx context.Context, req *pb.CheckPurposeConsentRequest) (*pb.CheckPurposeConsentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.CheckPurposeConsent(ctx, req)
+}
+
 // GetConsentStats gọi GetConsentStats RPC (Admin only)
 // Giải thích: Lấy thống kê về consents
 func (c *ConsentClient) GetConsentStats(ctx context.Context, req *pb.GetConsentStatsRequest) (*pb.GetConsentStatsResponse, error) {
@@ -93,6 +134,59 @@ func (c *ConsentClient) GetConsentStats(ctx context.Context, req *pb.GetConsentS
 	return c.client.GetConsentStats(ctx, req)
 }
 
+// VerifyConsentChain gọi VerifyConsentChain RPC (Admin only)
+// Giải thích: Kiểm tra consent hash chain của user có bị sửa/xóa không
+func (c *ConsentClient) VerifyConsentChain(ctx context.Context, req *pb.VerifyConsentChainRequest) (*pb.VerifyConsentChainResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.VerifyConsentChain(ctx, req)
+}
+
+// ListReconsentCampaigns gọi ListReconsentCampaigns RPC (Admin only)
+// Tự động add timeout vào context
+func (c *ConsentClient) ListReconsentCampaigns(ctx context.Context, req *pb.ListReconsentCampaignsRequest) (*pb.ListReconsentCampaignsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ListReconsentCampaigns(ctx, req)
+}
+
+// GetReconsentCampaign gọi GetReconsentCampaign RPC (Admin only)
+// Tự động add timeout vào context
+func (c *ConsentClient) GetReconsentCampaign(ctx context.Context, req *pb.GetReconsentCampaignRequest) (*pb.GetReconsentCampaignResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.GetReconsentCampaign(ctx, req)
+}
+
+// ListCampaignUsers gọi ListCampaignUsers RPC (Admin only)
+// Tự động add timeout vào context
+func (c *ConsentClient) ListCampaignUsers(ctx context.Context, req *pb.ListCampaignUsersRequest) (*pb.ListCampaignUsersResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ListCampaignUsers(ctx, req)
+}
+
+// UpdateReconsentCampaign gọi UpdateReconsentCampaign RPC (Admin only)
+// Tự động add timeout vào context
+func (c *ConsentClient) UpdateReconsentCampaign(ctx context.Context, req *pb.UpdateReconsentCampaignRequest) (*pb.UpdateReconsentCampaignResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.UpdateReconsentCampaign(ctx, req)
+}
+
+// QueryAuditLog gọi QueryAuditLog RPC (audit log của Consent Service)
+// Tự động add timeout vào context
+func (c *ConsentClient) QueryAuditLog(ctx context.Context, req *auditpb.QueryAuditLogRequest) (*auditpb.QueryAuditLogResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.audit.QueryAuditLog(ctx, req)
+}
+
+// Webhooks trả về client WebhookService của Consent Service
+func (c *ConsentClient) Webhooks() *WebhookClient {
+	return c.webhook
+}
+
 // Close đóng kết nối gRPC
 func (c *ConsentClient) Close() error {
 	if c.conn != nil {
@@ -3,10 +3,13 @@ package clients
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"time"
 
+	auditpb "github.com/thatlq1812/policy-system/shared/pkg/api/audit"
 	pb "github.com/thatlq1812/policy-system/shared/pkg/api/document"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +19,8 @@ import (
 type DocumentClient struct {
 	conn    *grpc.ClientConn
 	client  pb.DocumentServiceClient
+	audit   auditpb.AuditServiceClient
+	webhook *WebhookClient
 	timeout time.Duration
 }
 
@@ -31,6 +36,9 @@ func NewDocumentClient(addr string, timeout time.Duration) (*DocumentClient, err
 	conn, err := grpc.DialContext(ctx, addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(), // Wait until connection is ready
+		// Chuyển actor/request ID/IP trong context sang metadata cho audit log
+		grpc.WithUnaryInterceptor(audit.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(audit.StreamClientInterceptor()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to document service at %s: %w", addr, err)
@@ -41,6 +49,8 @@ func NewDocumentClient(addr string, timeout time.Duration) (*DocumentClient, err
 	return &DocumentClient{
 		conn:    conn,
 		client:  pb.NewDocumentServiceClient(conn),
+		audit:   auditpb.NewAuditServiceClient(conn),
+		webhook: newWebhookClient(conn, "document", timeout),
 		timeout: timeout,
 	}, nil
 }
@@ -61,6 +71,174 @@ func (c *DocumentClient) GetLatestPolicy(ctx context.Context, req *pb.GetLatestP
 	return c.client.GetLatestPolicyByPlatform(ctx, req)
 }
 
+// ListActivePolicies gọi ListActivePolicies RPC
+// Tự động add timeout vào context
+func (c *DocumentClient) ListActivePolicies(ctx context.Context, req *pb.ListActivePoliciesRequest) (*pb.ListActivePoliciesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ListActivePolicies(ctx, req)
+}
+
+// SubmitForReview gọi SubmitForReview RPC
+// Tự động add timeout vào context
+func (c *DocumentClient) SubmitForReview(ctx context.Context, req *pb.SubmitForReviewRequest) (*pb.SubmitForReviewResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.SubmitForReview(ctx, req)
+}
+
+// ApprovePolicy gọi ApprovePolicy RPC
+// Tự động add timeout vào context
+func (c *DocumentClient) ApprovePolicy(ctx context.Context, req *pb.ApprovePolicyRequest) (*pb.ApprovePolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ApprovePolicy(ctx, req)
+}
+
+// RejectPolicy gọi RejectPolicy RPC
+// Tự động add timeout vào context
+func (c *DocumentClient) RejectPolicy(ctx context.Context, req *pb.RejectPolicyRequest) (*pb.RejectPolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.RejectPolicy(ctx, req)
+}
+
+// SchedulePolicy gọi SchedulePolicy RPC
+// Tự động add timeout vào context
+func (c *DocumentClient) SchedulePolicy(ctx context.Context, req *pb.SchedulePolicyRequest) (*pb.SchedulePolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.SchedulePolicy(ctx, req)
+}
+
+// PublishPolicy gọi PublishPolicy RPC
+// Tự động add timeout vào context
+func (c *DocumentClient) PublishPolicy(ctx context.Context, req *pb.PublishPolicyRequest) (*pb.PublishPolicyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.PublishPolicy(ctx, req)
+}
+
+// ListUpcomingPolicies gọi ListUpcomingPolicies RPC
+// Tự động add timeout vào context
+func (c *DocumentClient) ListUpcomingPolicies(ctx context.Context, req *pb.ListUpcomingPoliciesRequest) (*pb.ListUpcomingPoliciesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ListUpcomingPolicies(ctx, req)
+}
+
+// ComparePolicyVersions gọi ComparePolicyVersions RPC
+// Tự động add timeout vào context
+func (c *DocumentClient) ComparePolicyVersions(ctx context.Context, req *pb.ComparePolicyVersionsRequest) (*pb.ComparePolicyVersionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ComparePolicyVersions(ctx, req)
+}
+
+// uploadChunkSize - kích thước mỗi chunk khi stream file lên Document Service
+const uploadChunkSize = 64 * 1024
+
+// UploadFile gọi UploadFile RPC (client streaming)
+// Message đầu mang metadata (meta), nội dung từ r được gửi theo chunk
+func (c *DocumentClient) UploadFile(ctx context.Context, meta *pb.UploadFileRequest, r io.Reader) (*pb.UploadFileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	stream, err := c.client.UploadFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &pb.UploadFileRequest{
+		Filename:    meta.Filename,
+		ContentType: meta.ContentType,
+		UploadedBy:  meta.UploadedBy,
+	}
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			msg.Chunk = buf[:n]
+			if err := stream.Send(msg); err != nil {
+				// Server đã đóng stream (vd: file quá lớn) → lấy lỗi thật từ CloseAndRecv
+				if err == io.EOF {
+					break
+				}
+				return nil, err
+			}
+			msg = &pb.UploadFileRequest{}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read upload: %w", readErr)
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// DownloadFile gọi DownloadFile RPC (server streaming)
+// Trả về metadata và reader nội dung, caller phải Close reader để giải phóng stream
+func (c *DocumentClient) DownloadFile(ctx context.Context, contentHash string) (*pb.FileBlob, io.ReadCloser, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+
+	stream, err := c.client.DownloadFile(ctx, &pb.DownloadFileRequest{ContentHash: contentHash})
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	// Nhận message metadata trước để lỗi (NotFound...) trả về trước khi ghi response
+	first, err := stream.Recv()
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return first.File, &downloadStreamReader{stream: stream, cancel: cancel, buf: first.Chunk}, nil
+}
+
+// downloadStreamReader adapts the download stream to io.ReadCloser
+type downloadStreamReader struct {
+	stream grpc.ServerStreamingClient[pb.DownloadFileResponse]
+	cancel context.CancelFunc
+	buf    []byte
+}
+
+func (r *downloadStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err // io.EOF khi đã nhận hết
+		}
+		r.buf = msg.Chunk
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *downloadStreamReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+// QueryAuditLog gọi QueryAuditLog RPC (audit log của Document Service)
+// Tự động add timeout vào context
+func (c *DocumentClient) QueryAuditLog(ctx context.Context, req *auditpb.QueryAuditLogRequest) (*auditpb.QueryAuditLogResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.audit.QueryAuditLog(ctx, req)
+}
+
+// Webhooks trả về client WebhookService của Document Service
+func (c *DocumentClient) Webhooks() *WebhookClient {
+	return c.webhook
+}
+
 // Close đóng kết nối gRPC
 func (c *DocumentClient) Close() error {
 	if c.conn != nil {
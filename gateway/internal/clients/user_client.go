@@ -6,7 +6,10 @@ import (
 	"log"
 	"time"
 
+	auditpb "github.com/thatlq1812/policy-system/shared/pkg/api/audit"
+	sagapb "github.com/thatlq1812/policy-system/shared/pkg/api/saga"
 	pb "github.com/thatlq1812/policy-system/shared/pkg/api/user"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -16,6 +19,8 @@ import (
 type UserClient struct {
 	conn    *grpc.ClientConn
 	client  pb.UserServiceClient
+	audit   auditpb.AuditServiceClient
+	saga    sagapb.SagaServiceClient
 	timeout time.Duration
 }
 
@@ -32,6 +37,9 @@ func NewUserClient(url string, timeout time.Duration) (*UserClient, error) {
 	conn, err := grpc.DialContext(ctx, url,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(), // Wait until connection is ready
+		// Chuyển actor/request ID/IP trong context sang metadata cho audit log
+		grpc.WithUnaryInterceptor(audit.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(audit.StreamClientInterceptor()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
@@ -42,6 +50,8 @@ func NewUserClient(url string, timeout time.Duration) (*UserClient, error) {
 	return &UserClient{
 		conn:    conn,
 		client:  pb.NewUserServiceClient(conn),
+		audit:   auditpb.NewAuditServiceClient(conn),
+		saga:    sagapb.NewSagaServiceClient(conn),
 		timeout: timeout,
 	}, nil
 }
@@ -54,6 +64,14 @@ func (c *UserClient) Register(ctx context.Context, req *pb.RegisterRequest, opts
 	return c.client.Register(ctx, req, opts...)
 }
 
+// RegisterWithConsent gọi RegisterWithConsent RPC (saga đăng ký chạy trong User Service)
+// Tự động add timeout vào context
+func (c *UserClient) RegisterWithConsent(ctx context.Context, req *pb.RegisterWithConsentRequest, opts ...grpc.CallOption) (*pb.RegisterWithConsentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.RegisterWithConsent(ctx, req, opts...)
+}
+
 // Login gọi Login RPC
 // Tự động add timeout vào context
 func (c *UserClient) Login(ctx context.Context, req *pb.LoginRequest, opts ...grpc.CallOption) (*pb.LoginResponse, error) {
@@ -146,6 +164,21 @@ func (c *UserClient) UpdateUserRole(ctx context.Context, req *pb.UpdateUserRoleR
 	return c.client.UpdateUserRole(ctx, req, opts...)
 }
 
+// EraseUser gọi EraseUser RPC (Admin only)
+// Giải thích: Hết timeout thì saga vẫn chạy tiếp ở User Service, gọi lại để xem trạng thái
+func (c *UserClient) EraseUser(ctx context.Context, req *pb.EraseUserRequest, opts ...grpc.CallOption) (*pb.EraseUserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.EraseUser(ctx, req, opts...)
+}
+
+// GetErasureCertificate gọi GetErasureCertificate RPC (Admin only)
+func (c *UserClient) GetErasureCertificate(ctx context.Context, req *pb.GetErasureCertificateRequest, opts ...grpc.CallOption) (*pb.GetErasureCertificateResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.GetErasureCertificate(ctx, req, opts...)
+}
+
 // GetActiveSessions gọi GetActiveSessions RPC
 func (c *UserClient) GetActiveSessions(ctx context.Context, req *pb.GetActiveSessionsRequest, opts ...grpc.CallOption) (*pb.GetActiveSessionsResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
@@ -174,6 +207,102 @@ func (c *UserClient) IsTokenBlacklisted(ctx context.Context, req *pb.IsTokenBlac
 	return c.client.IsTokenBlacklisted(ctx, req, opts...)
 }
 
+// GetSigningKeys gọi GetSigningKeys RPC (public keys ký access token, JWKS)
+// Tự động add timeout vào context
+func (c *UserClient) GetSigningKeys(ctx context.Context, req *pb.GetSigningKeysRequest, opts ...grpc.CallOption) (*pb.GetSigningKeysResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.GetSigningKeys(ctx, req, opts...)
+}
+
+// VerifyMFA gọi VerifyMFA RPC (bước 2FA của đăng nhập)
+// Tự động add timeout vào context
+func (c *UserClient) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest, opts ...grpc.CallOption) (*pb.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.VerifyMFA(ctx, req, opts...)
+}
+
+// EnrollTOTP gọi EnrollTOTP RPC
+// Tự động add timeout vào context
+func (c *UserClient) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest, opts ...grpc.CallOption) (*pb.EnrollTOTPResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.EnrollTOTP(ctx, req, opts...)
+}
+
+// ConfirmTOTP gọi ConfirmTOTP RPC
+// Tự động add timeout vào context
+func (c *UserClient) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest, opts ...grpc.CallOption) (*pb.ConfirmTOTPResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ConfirmTOTP(ctx, req, opts...)
+}
+
+// DisableTOTP gọi DisableTOTP RPC
+// Tự động add timeout vào context
+func (c *UserClient) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest, opts ...grpc.CallOption) (*pb.DisableTOTPResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.DisableTOTP(ctx, req, opts...)
+}
+
+// SendPhoneOTP gọi SendPhoneOTP RPC
+// Tự động add timeout vào context
+func (c *UserClient) SendPhoneOTP(ctx context.Context, req *pb.SendPhoneOTPRequest, opts ...grpc.CallOption) (*pb.SendPhoneOTPResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.SendPhoneOTP(ctx, req, opts...)
+}
+
+// VerifyPhone gọi VerifyPhone RPC
+// Tự động add timeout vào context
+func (c *UserClient) VerifyPhone(ctx context.Context, req *pb.VerifyPhoneRequest, opts ...grpc.CallOption) (*pb.VerifyPhoneResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.VerifyPhone(ctx, req, opts...)
+}
+
+// LoginWithOTP gọi LoginWithOTP RPC
+// Tự động add timeout vào context
+func (c *UserClient) LoginWithOTP(ctx context.Context, req *pb.LoginWithOTPRequest, opts ...grpc.CallOption) (*pb.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.LoginWithOTP(ctx, req, opts...)
+}
+
+// QueryAuditLog gọi QueryAuditLog RPC (audit log của User Service)
+// Tự động add timeout vào context
+func (c *UserClient) QueryAuditLog(ctx context.Context, req *auditpb.QueryAuditLogRequest) (*auditpb.QueryAuditLogResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.audit.QueryAuditLog(ctx, req)
+}
+
+// ListSagas gọi ListSagas RPC (saga của User Service)
+// Tự động add timeout vào context
+func (c *UserClient) ListSagas(ctx context.Context, req *sagapb.ListSagasRequest) (*sagapb.ListSagasResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.saga.ListSagas(ctx, req)
+}
+
+// GetSaga gọi GetSaga RPC
+// Tự động add timeout vào context
+func (c *UserClient) GetSaga(ctx context.Context, req *sagapb.GetSagaRequest) (*sagapb.Saga, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.saga.GetSaga(ctx, req)
+}
+
+// RetrySaga gọi RetrySaga RPC
+// Tự động add timeout vào context
+func (c *UserClient) RetrySaga(ctx context.Context, req *sagapb.RetrySagaRequest) (*sagapb.Saga, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.saga.RetrySaga(ctx, req)
+}
+
 // Close đóng kết nối gRPC
 func (c *UserClient) Close() error {
 	if c.conn != nil {
@@ -0,0 +1,71 @@
+package clients
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/webhook"
+)
+
+// WebhookClient là wrapper cho WebhookService của 1 service (Document hoặc Consent),
+// dùng chung gRPC connection của client service đó
+type WebhookClient struct {
+	client  pb.WebhookServiceClient
+	service string
+	timeout time.Duration
+}
+
+func newWebhookClient(conn *grpc.ClientConn, service string, timeout time.Duration) *WebhookClient {
+	return &WebhookClient{
+		client:  pb.NewWebhookServiceClient(conn),
+		service: service,
+		timeout: timeout,
+	}
+}
+
+// Service trả về tên service sở hữu client (document | consent)
+func (c *WebhookClient) Service() string {
+	return c.service
+}
+
+// CreateSubscription gọi CreateSubscription RPC
+// Tự động add timeout vào context
+func (c *WebhookClient) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.Subscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.CreateSubscription(ctx, req)
+}
+
+// ListSubscriptions gọi ListSubscriptions RPC
+// Tự động add timeout vào context
+func (c *WebhookClient) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ListSubscriptions(ctx, req)
+}
+
+// DeleteSubscription gọi DeleteSubscription RPC
+// Tự động add timeout vào context
+func (c *WebhookClient) DeleteSubscription(ctx context.Context, req *pb.DeleteSubscriptionRequest) (*pb.DeleteSubscriptionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.DeleteSubscription(ctx, req)
+}
+
+// ListDeliveries gọi ListDeliveries RPC
+// Tự động add timeout vào context
+func (c *WebhookClient) ListDeliveries(ctx context.Context, req *pb.ListDeliveriesRequest) (*pb.ListDeliveriesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.ListDeliveries(ctx, req)
+}
+
+// RedeliverDelivery gọi RedeliverDelivery RPC
+// Tự động add timeout vào context
+func (c *WebhookClient) RedeliverDelivery(ctx context.Context, req *pb.RedeliverDeliveryRequest) (*pb.Delivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.client.RedeliverDelivery(ctx, req)
+}
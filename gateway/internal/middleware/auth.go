@@ -23,12 +23,12 @@ type Claims struct {
 
 // AuthMiddleware tạo middleware để validate JWT token với blacklist check
 // userClient is optional - if nil, blacklist check is skipped (backwards compatible)
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
-	return AuthMiddlewareWithBlacklist(jwtSecret, nil)
+func AuthMiddleware(keys *KeySet) gin.HandlerFunc {
+	return AuthMiddlewareWithBlacklist(keys, nil)
 }
 
-// AuthMiddlewareWithBlacklist validates JWT token and checks blacklist
-func AuthMiddlewareWithBlacklist(jwtSecret string, userClient pb.UserServiceClient) gin.HandlerFunc {
+// AuthMiddlewareWithBlacklist validates JWT token against the User Service key set (JWKS) and checks blacklist
+func AuthMiddlewareWithBlacklist(keys *KeySet, userClient pb.UserServiceClient) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Bước 1: Lấy Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -63,14 +63,9 @@ func AuthMiddlewareWithBlacklist(jwtSecret string, userClient pb.UserServiceClie
 		}
 
 		// Bước 3: Parse và validate token
-		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			// Check signing method (phải là HS256)
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			// Return secret key để verify signature
-			return []byte(jwtSecret), nil
-		})
+		// Signature verify bằng public key theo kid (RS256/EdDSA), không dùng secret dùng chung
+		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keys.Keyfunc,
+			jwt.WithValidMethods([]string{"RS256", "EdDSA"}))
 
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -131,6 +126,7 @@ func AuthMiddlewareWithBlacklist(jwtSecret string, userClient pb.UserServiceClie
 		c.Set("user_id", claims.UserID)
 		c.Set("phone_number", claims.PhoneNumber)
 		c.Set("platform_role", claims.PlatformRole)
+		setRequestActor(c, claims.UserID, claims.PlatformRole)
 
 		// Bước 6: Gọi handler tiếp theo
 		c.Next()
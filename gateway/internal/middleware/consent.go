@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	consentpb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+)
+
+// PendingConsentChecker là phần của consent client mà middleware cần
+// (clients.ConsentClient thỏa mãn interface này)
+type PendingConsentChecker interface {
+	CheckPendingConsents(ctx context.Context, req *consentpb.CheckPendingConsentsRequest) (*consentpb.CheckPendingConsentsResponse, error)
+}
+
+// consentCacheEntry lưu danh sách policy bắt buộc còn pending của 1 user
+type consentCacheEntry struct {
+	pending   []*consentpb.PendingPolicy
+	expiresAt time.Time
+}
+
+// ConsentCache cache kết quả check pending consent theo user
+// Invalidate khi user RecordConsent/RevokeConsent để không bắt user chờ hết TTL
+type ConsentCache struct {
+	mu      sync.RWMutex
+	entries map[string]consentCacheEntry
+	ttl     time.Duration
+}
+
+// NewConsentCache tạo cache với TTL cho mỗi entry
+func NewConsentCache(ttl time.Duration) *ConsentCache {
+	return &ConsentCache{
+		entries: make(map[string]consentCacheEntry),
+		ttl:     ttl,
+	}
+}
+
+// Get trả về pending mandatory policies của user nếu còn trong cache
+func (c *ConsentCache) Get(userID string) ([]*consentpb.PendingPolicy, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[userID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.pending, true
+}
+
+// Set lưu kết quả check vào cache
+func (c *ConsentCache) Set(userID string, pending []*consentpb.PendingPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Dọn các entry hết hạn khi ghi để map không phình vô hạn
+	now := time.Now()
+	for id, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+
+	c.entries[userID] = consentCacheEntry{
+		pending:   pending,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// Invalidate xóa cache của user (gọi sau khi consent thay đổi)
+func (c *ConsentCache) Invalidate(userID string) {
+	if c == nil || userID == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, userID)
+}
+
+// InvalidateAll xóa cache của mọi user (gọi khi grace deadline của policy thay đổi)
+func (c *ConsentCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]consentCacheEntry)
+}
+
+// ConsentEnforcement chặn request của user còn policy bắt buộc chưa đồng ý
+//   - 451 Unavailable For Legal Reasons: đã quá grace deadline của ít nhất 1 policy
+//   - Còn trong grace period: request vẫn đi tiếp, kèm header X-Consent-Required và
+//     X-Consent-Grace-Deadline (deadline sớm nhất, Unix seconds) để frontend nhắc user đồng ý
+//
+// Phải đặt SAU AuthMiddleware (cần user_id, platform_role trong context).
+// skipPrefixes: các path không bị chặn (vd: consent endpoints để user có thể đồng ý)
+func ConsentEnforcement(checker PendingConsentChecker, cache *ConsentCache, skipPrefixes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Bước 1: Whitelist - consent endpoints phải luôn truy cập được
+		path := c.Request.URL.Path
+		for _, prefix := range skipPrefixes {
+			if strings.HasPrefix(path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		// Bước 2: Lấy user info từ AuthMiddleware
+		userID := c.GetString("user_id")
+		platform := c.GetString("platform_role")
+		if userID == "" || platform == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"code":    "401",
+				"message": "User info not found. Did you apply AuthMiddleware first?",
+			})
+			c.Abort()
+			return
+		}
+
+		// Bước 3: Lấy pending mandatory policies (cache trước, sau đó Consent Service)
+		pending, ok := cache.Get(userID)
+		if !ok {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+			defer cancel()
+
+			resp, err := checker.CheckPendingConsents(ctx, &consentpb.CheckPendingConsentsRequest{
+				UserId:                userID,
+				Platform:              platform,
+				ResolveActivePolicies: true,
+			})
+			if err != nil {
+				// Fail open giống blacklist check - service lỗi không được block toàn bộ API
+				// Không cache kết quả lỗi
+				log.Printf("WARNING: Failed to check pending consents for user %s: %v", userID, err)
+				c.Next()
+				return
+			}
+
+			pending = make([]*consentpb.PendingPolicy, 0, len(resp.PendingPolicies))
+			for _, p := range resp.PendingPolicies {
+				if p.IsMandatory {
+					pending = append(pending, p)
+				}
+			}
+			cache.Set(userID, pending)
+		}
+
+		// Bước 4: Không còn policy bắt buộc nào → cho qua
+		if len(pending) == 0 {
+			c.Next()
+			return
+		}
+
+		// Bước 5: Chưa quá grace deadline → cho qua nhưng báo frontend
+		overdue := false
+		var earliestDeadline int64
+		for _, p := range pending {
+			if p.IsOverdue {
+				overdue = true
+			}
+			// GraceDeadline = 0: policy không có hạn chót (không tính là hạn sớm nhất)
+			if p.GraceDeadline > 0 && (earliestDeadline == 0 || p.GraceDeadline < earliestDeadline) {
+				earliestDeadline = p.GraceDeadline
+			}
+		}
+		if !overdue {
+			c.Header("X-Consent-Required", "true")
+			if earliestDeadline > 0 {
+				c.Header("X-Consent-Grace-Deadline", strconv.FormatInt(earliestDeadline, 10))
+			}
+			c.Next()
+			return
+		}
+
+		// Bước 6: Quá grace deadline → block với structured response để frontend hiển thị màn hình đồng ý
+		policies := make([]gin.H, len(pending))
+		for i, p := range pending {
+			policies[i] = gin.H{
+				"document_id":        p.DocumentId,
+				"document_name":      p.DocumentName,
+				"version_timestamp":  p.VersionTimestamp,
+				"platform":           p.Platform,
+				"grace_deadline":     p.GraceDeadline,
+				"is_overdue":         p.IsOverdue,
+				"consent_expired_at": p.ConsentExpiredAt,
+			}
+		}
+
+		c.JSON(http.StatusUnavailableForLegalReasons, gin.H{
+			"code":    strconv.Itoa(http.StatusUnavailableForLegalReasons),
+			"message": "Access suspended until the updated mandatory policies are accepted",
+			"data": gin.H{
+				"pending_policies": policies,
+				"consent_endpoint": "/api/v1/consents",
+			},
+		})
+		c.Abort()
+	}
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	consentpb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+)
+
+// fakePendingConsentChecker trả danh sách pending cố định và đếm số lần Consent Service bị gọi
+type fakePendingConsentChecker struct {
+	pending []*consentpb.PendingPolicy
+	err     error
+	calls   int
+}
+
+func (f *fakePendingConsentChecker) CheckPendingConsents(ctx context.Context, req *consentpb.CheckPendingConsentsRequest) (*consentpb.CheckPendingConsentsResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &consentpb.CheckPendingConsentsResponse{PendingPolicies: f.pending}, nil
+}
+
+func pendingPolicy(documentID string, mandatory bool, deadline time.Time) *consentpb.PendingPolicy {
+	return &consentpb.PendingPolicy{
+		DocumentId:    documentID,
+		IsMandatory:   mandatory,
+		GraceDeadline: deadline.Unix(),
+		IsOverdue:     time.Now().After(deadline),
+	}
+}
+
+func TestConsentEnforcement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Now()
+	soon, later := now.Add(24*time.Hour), now.Add(7*24*time.Hour)
+
+	// Policy không có hạn chót: Consent Service trả GraceDeadline = 0
+	noDeadline := &consentpb.PendingPolicy{DocumentId: "doc-3", IsMandatory: true}
+
+	tests := []struct {
+		name         string
+		path         string
+		pending      []*consentpb.PendingPolicy
+		checkerErr   error
+		wantStatus   int
+		wantRequired bool   // Header X-Consent-Required
+		wantDeadline string // Header X-Consent-Grace-Deadline, rỗng = không có header
+	}{
+		{"No pending policies", "/api/v1/documents", nil, nil, http.StatusOK, false, ""},
+		{"Only optional policies pending", "/api/v1/documents",
+			[]*consentpb.PendingPolicy{pendingPolicy("doc-1", false, now.Add(-time.Hour))}, nil, http.StatusOK, false, ""},
+		{"Grace period - request passes", "/api/v1/documents",
+			[]*consentpb.PendingPolicy{pendingPolicy("doc-1", true, later), pendingPolicy("doc-2", true, soon)}, nil,
+			http.StatusOK, true, strconv.FormatInt(soon.Unix(), 10)},
+		{"Grace period - policy without deadline ignored", "/api/v1/documents",
+			[]*consentpb.PendingPolicy{pendingPolicy("doc-1", true, later), noDeadline}, nil,
+			http.StatusOK, true, strconv.FormatInt(later.Unix(), 10)},
+		{"Only policies without deadline - no deadline header", "/api/v1/documents",
+			[]*consentpb.PendingPolicy{noDeadline}, nil, http.StatusOK, true, ""},
+		{"Overdue - request blocked", "/api/v1/documents",
+			[]*consentpb.PendingPolicy{pendingPolicy("doc-1", true, now.Add(-time.Hour))}, nil, http.StatusUnavailableForLegalReasons, false, ""},
+		{"One of several overdue - request blocked", "/api/v1/documents",
+			[]*consentpb.PendingPolicy{pendingPolicy("doc-1", true, later), pendingPolicy("doc-2", true, now.Add(-time.Minute))}, nil,
+			http.StatusUnavailableForLegalReasons, false, ""},
+		{"Overdue - consent endpoint skipped", "/api/v1/consents/pending",
+			[]*consentpb.PendingPolicy{pendingPolicy("doc-1", true, now.Add(-time.Hour))}, nil, http.StatusOK, false, ""},
+		{"Consent Service down - fail open", "/api/v1/documents", nil, errors.New("unavailable"), http.StatusOK, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &fakePendingConsentChecker{pending: tt.pending, err: tt.checkerErr}
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", "user-1")
+				c.Set("platform_role", "Client")
+			})
+			router.Use(ConsentEnforcement(checker, NewConsentCache(time.Minute), "/api/v1/consents"))
+			router.GET("/*path", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := w.Header().Get("X-Consent-Grace-Deadline"); got != tt.wantDeadline {
+				t.Errorf("X-Consent-Grace-Deadline = %q, want %q", got, tt.wantDeadline)
+			}
+			if (w.Header().Get("X-Consent-Required") == "true") != tt.wantRequired {
+				t.Errorf("X-Consent-Required = %q, want set %v", w.Header().Get("X-Consent-Required"), tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestConsentEnforcementCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := &fakePendingConsentChecker{pending: []*consentpb.PendingPolicy{
+		pendingPolicy("doc-1", true, time.Now().Add(-time.Hour)),
+	}}
+	cache := NewConsentCache(time.Minute)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		c.Set("platform_role", "Client")
+	})
+	router.Use(ConsentEnforcement(checker, cache))
+	router.GET("/resource", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	serve := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/resource", nil))
+		return w.Code
+	}
+
+	if code := serve(); code != http.StatusUnavailableForLegalReasons {
+		t.Fatalf("first request status = %d, want %d", code, http.StatusUnavailableForLegalReasons)
+	}
+	if code := serve(); code != http.StatusUnavailableForLegalReasons || checker.calls != 1 {
+		t.Fatalf("cached request status = %d with %d checks, want %d with 1 check", code, checker.calls, http.StatusUnavailableForLegalReasons)
+	}
+
+	// User đồng ý → API consent invalidate cache, request sau đi qua
+	checker.pending = nil
+	cache.Invalidate("user-1")
+	if code := serve(); code != http.StatusOK || checker.calls != 2 {
+		t.Errorf("request after invalidate status = %d with %d checks, want %d with 2 checks", code, checker.calls, http.StatusOK)
+	}
+}
@@ -29,6 +29,10 @@ func GrpcErrorToHTTP(err error) (int, string, string) {
 		return http.StatusForbidden, "403", st.Message()
 	case codes.Aborted:
 		return http.StatusConflict, response.CodeConflict, st.Message()
+	case codes.FailedPrecondition:
+		return http.StatusConflict, response.CodeConflict, st.Message()
+	case codes.ResourceExhausted:
+		return http.StatusRequestEntityTooLarge, "413", st.Message()
 	default:
 		return http.StatusInternalServerError, response.CodeInternalError, st.Message()
 	}
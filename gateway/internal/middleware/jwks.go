@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/user"
+)
+
+// keyMissRefreshInterval giới hạn số lần tải lại JWKS khi gặp kid lạ (token giả mạo không làm quá tải User Service)
+const keyMissRefreshInterval = 10 * time.Second
+
+// SigningKeyFetcher là phần của user client mà KeySet cần
+// (clients.UserClient thỏa mãn interface này)
+type SigningKeyFetcher interface {
+	GetSigningKeys(ctx context.Context, req *pb.GetSigningKeysRequest, opts ...grpc.CallOption) (*pb.GetSigningKeysResponse, error)
+}
+
+// verificationKey là public key đã parse từ JWK
+type verificationKey struct {
+	alg    string
+	public crypto.PublicKey
+}
+
+// KeySet cache public keys ký access token của User Service (JWKS) để verify token theo kid
+// Giải thích: User Service rotation key định kỳ và publish key mới trước khi dùng,
+// KeySet tải lại theo chu kỳ và khi gặp kid chưa biết
+type KeySet struct {
+	fetcher         SigningKeyFetcher
+	refreshInterval time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]verificationKey
+	jwks      []*pb.SigningKey
+	fetchedAt time.Time
+
+	refreshMu sync.Mutex // Chỉ 1 request tải lại JWKS tại 1 thời điểm
+}
+
+// NewKeySet tạo KeySet, gọi Refresh để tải keys lần đầu
+func NewKeySet(fetcher SigningKeyFetcher, refreshInterval time.Duration) *KeySet {
+	return &KeySet{
+		fetcher:         fetcher,
+		refreshInterval: refreshInterval,
+		keys:            make(map[string]verificationKey),
+	}
+}
+
+// Refresh tải lại public keys từ User Service
+func (k *KeySet) Refresh(ctx context.Context) error {
+	resp, err := k.fetcher.GetSigningKeys(ctx, &pb.GetSigningKeysRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get signing keys: %w", err)
+	}
+
+	now := time.Now()
+	keys := make(map[string]verificationKey, len(resp.Keys))
+	jwks := make([]*pb.SigningKey, 0, len(resp.Keys))
+	for _, jwk := range resp.Keys {
+		if jwk.ExpiresAt != 0 && now.Unix() >= jwk.ExpiresAt {
+			continue
+		}
+		public, err := parseJWK(jwk)
+		if err != nil {
+			log.Printf("WARNING: Skipping signing key %s: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = verificationKey{alg: jwk.Alg, public: public}
+		jwks = append(jwks, jwk)
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.jwks = jwks
+	k.fetchedAt = now
+	k.mu.Unlock()
+	return nil
+}
+
+// Run tải lại JWKS theo chu kỳ tới khi ctx bị hủy
+func (k *KeySet) Run(ctx context.Context) {
+	ticker := time.NewTicker(k.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := k.Refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("WARNING: Failed to refresh JWKS: %v", err)
+		}
+	}
+}
+
+// JWKS trả về public keys hiện có (cho /.well-known/jwks.json)
+func (k *KeySet) JWKS() []*pb.SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.jwks
+}
+
+// Keyfunc chọn public key theo header "kid" của token (dùng với jwt.Parse)
+func (k *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("missing kid header")
+	}
+
+	key, ok := k.lookup(kid)
+	if !ok {
+		// Key mới mà chu kỳ tải lại chưa tới
+		k.refreshOnMiss(kid)
+		if key, ok = k.lookup(kid); !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+	}
+
+	// Chống algorithm confusion: token phải dùng đúng thuật toán của key
+	if token.Method.Alg() != key.alg {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.public, nil
+}
+
+func (k *KeySet) lookup(kid string) (verificationKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+func (k *KeySet) refreshOnMiss(kid string) {
+	k.refreshMu.Lock()
+	defer k.refreshMu.Unlock()
+
+	// Request khác đã tải lại trong lúc chờ lock
+	if _, ok := k.lookup(kid); ok {
+		return
+	}
+	k.mu.RLock()
+	recent := time.Since(k.fetchedAt) < keyMissRefreshInterval
+	k.mu.RUnlock()
+	if recent {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := k.Refresh(ctx); err != nil {
+		log.Printf("WARNING: Failed to refresh JWKS for unknown kid %s: %v", kid, err)
+	}
+}
+
+// parseJWK chuyển JWK (RSA hoặc OKP Ed25519) thành public key
+func parseJWK(jwk *pb.SigningKey) (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
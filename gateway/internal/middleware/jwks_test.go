@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/user"
+)
+
+// fakeSigningKeyFetcher trả JWKS cố định và đếm số lần User Service bị gọi
+type fakeSigningKeyFetcher struct {
+	keys    []*pb.SigningKey
+	fetches int
+}
+
+func (f *fakeSigningKeyFetcher) GetSigningKeys(ctx context.Context, req *pb.GetSigningKeysRequest, opts ...grpc.CallOption) (*pb.GetSigningKeysResponse, error) {
+	f.fetches++
+	return &pb.GetSigningKeysResponse{Keys: f.keys}, nil
+}
+
+func ed25519JWK(kid string, public ed25519.PublicKey, expiresAt int64) *pb.SigningKey {
+	return &pb.SigningKey{
+		Kid:       kid,
+		Kty:       "OKP",
+		Alg:       "EdDSA",
+		Use:       "sig",
+		Crv:       "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(public),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func rsaJWK(kid string, public *rsa.PublicKey) *pb.SigningKey {
+	return &pb.SigningKey{
+		Kid: kid,
+		Kty: "RSA",
+		Alg: "RS256",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+	}
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, jwt.MapClaims{
+		"user_id": "user-1",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestKeySetKeyfunc(t *testing.T) {
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, oldPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPublic, newPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldPublic := oldPrivate.Public().(ed25519.PublicKey)
+
+	tests := []struct {
+		name  string
+		token string
+		// staleCache: lần tải JWKS gần nhất đã quá keyMissRefreshInterval
+		staleCache  bool
+		wantErr     bool
+		wantFetches int // Số lần tải JWKS sau lần Refresh đầu tiên
+	}{
+		{"Valid EdDSA token", signTestToken(t, jwt.SigningMethodEdDSA, "ed-1", edPrivate), false, false, 0},
+		{"Valid RS256 token", signTestToken(t, jwt.SigningMethodRS256, "rsa-1", rsaPrivate), false, false, 0},
+		{"Missing kid", signTestToken(t, jwt.SigningMethodEdDSA, "", edPrivate), false, true, 0},
+		{"Kid miss - refreshed recently", signTestToken(t, jwt.SigningMethodEdDSA, "ed-new", newPrivate), false, true, 0},
+		{"Kid miss - new key published", signTestToken(t, jwt.SigningMethodEdDSA, "ed-new", newPrivate), true, false, 1},
+		{"Kid miss - unknown key", signTestToken(t, jwt.SigningMethodEdDSA, "forged", newPrivate), true, true, 1},
+		{"Alg mismatch - RS256 token for EdDSA key", signTestToken(t, jwt.SigningMethodRS256, "ed-1", rsaPrivate), false, true, 0},
+		{"Alg mismatch - HS256 signed with public key", signTestToken(t, jwt.SigningMethodHS256, "rsa-1", []byte(rsaJWK("rsa-1", &rsaPrivate.PublicKey).N)), false, true, 0},
+		{"Alg mismatch - none", signTestToken(t, jwt.SigningMethodNone, "ed-1", jwt.UnsafeAllowNoneSignatureType), false, true, 0},
+		{"Expired key", signTestToken(t, jwt.SigningMethodEdDSA, "ed-old", oldPrivate), true, true, 1},
+		{"Wrong key for kid", signTestToken(t, jwt.SigningMethodEdDSA, "ed-1", newPrivate), false, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &fakeSigningKeyFetcher{keys: []*pb.SigningKey{
+				ed25519JWK("ed-1", edPublic, 0),
+				rsaJWK("rsa-1", &rsaPrivate.PublicKey),
+				ed25519JWK("ed-old", oldPublic, time.Now().Add(-time.Minute).Unix()),
+			}}
+			ks := NewKeySet(fetcher, time.Hour)
+			if err := ks.Refresh(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			// User Service rotation: key mới chỉ có trong JWKS sau lần tải đầu
+			fetcher.keys = append(fetcher.keys, ed25519JWK("ed-new", newPublic, 0))
+			fetcher.fetches = 0
+			if tt.staleCache {
+				ks.fetchedAt = time.Now().Add(-2 * keyMissRefreshInterval)
+			}
+
+			_, err := jwt.Parse(tt.token, ks.Keyfunc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("jwt.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fetcher.fetches != tt.wantFetches {
+				t.Errorf("JWKS fetches = %d, want %d", fetcher.fetches, tt.wantFetches)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SupportedLocales - locale có nội dung policy (khớp Document Service)
+var SupportedLocales = []string{"vi", "en"}
+
+// Locale middleware chọn locale cho request và lưu vào context key "locale"
+// Ưu tiên: query ?locale= > header Accept-Language > rỗng (Document Service dùng nội dung gốc)
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := matchLocale(c.Query("locale"))
+		if locale == "" {
+			locale = NegotiateLocale(c.GetHeader("Accept-Language"))
+		}
+
+		// Response phụ thuộc Accept-Language → cache phải tách theo header này
+		c.Header("Vary", "Accept-Language")
+		c.Set("locale", locale)
+		c.Next()
+	}
+}
+
+// NegotiateLocale chọn locale được hỗ trợ có q cao nhất trong Accept-Language
+// vd: "fr-FR, en-US;q=0.8, vi;q=0.5" -> "en"
+func NegotiateLocale(header string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue // q=0: client không chấp nhận locale này
+		}
+		candidates = append(candidates, candidate{tag: tag, q: q})
+	}
+
+	// Giữ thứ tự trong header khi q bằng nhau
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, cand := range candidates {
+		if locale := matchLocale(cand.tag); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+// matchLocale trả về locale được hỗ trợ cho language tag ("en-US" -> "en"), rỗng nếu không hỗ trợ
+func matchLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, supported := range SupportedLocales {
+		if tag == supported {
+			return supported
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+)
+
+// RequestIDHeader - header mang request ID (nhận từ client hoặc Gateway tự sinh)
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern giới hạn request ID client gửi lên (tránh ghi chuỗi tùy ý vào audit log)
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,100}$`)
+
+// RequestContext middleware gán request ID và lưu thông tin request (IP, user agent) vào context.
+// gRPC client interceptor chuyển thông tin này sang metadata để service ghi audit log.
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Request = c.Request.WithContext(audit.WithRequestInfo(c.Request.Context(), audit.RequestInfo{
+			RequestID: requestID,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.GetHeader("User-Agent"),
+		}))
+
+		c.Next()
+	}
+}
+
+// setRequestActor gắn user đã xác thực vào thông tin request (actor của audit log)
+func setRequestActor(c *gin.Context, userID, platformRole string) {
+	info, _ := audit.RequestInfoFromContext(c.Request.Context())
+	info.ActorID = userID
+	info.ActorRole = platformRole
+	c.Request = c.Request.WithContext(audit.WithRequestInfo(c.Request.Context(), info))
+}
+
+// GetRequestID lấy request_id từ context
+func GetRequestID(c *gin.Context) string {
+	return c.GetString("request_id")
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -44,6 +44,21 @@ type Consent struct {
 	RevokedAt     int64  `protobuf:"varint,17,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
 	RevokedReason string `protobuf:"bytes,18,opt,name=revoked_reason,json=revokedReason,proto3" json:"revoked_reason,omitempty"`
 	RevokedBy     string `protobuf:"bytes,19,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+	// Hash chain: record_hash của GRANTED event (rỗng nếu row chưa được backfill)
+	RecordHash string `protobuf:"bytes,20,opt,name=record_hash,json=recordHash,proto3" json:"record_hash,omitempty"`
+	// SHA-256 của nội dung document tại thời điểm đồng ý (content_html hoặc bytes của file_url)
+	DocumentContentHash string `protobuf:"bytes,21,opt,name=document_content_hash,json=documentContentHash,proto3" json:"document_content_hash,omitempty"`
+	// Locale của nội dung document đã hiển thị cho user ("vi", "en")
+	Locale string `protobuf:"bytes,22,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Admin đã ghi consent thay user (rỗng = user tự đồng ý) và lý do
+	RecordedBy     string `protobuf:"bytes,23,opt,name=recorded_by,json=recordedBy,proto3" json:"recorded_by,omitempty"`
+	OnBehalfReason string `protobuf:"bytes,24,opt,name=on_behalf_reason,json=onBehalfReason,proto3" json:"on_behalf_reason,omitempty"`
+	// Consent có thời hạn (consent_validity_period của document): 0 = không hết hạn
+	ExpiresAt     int64            `protobuf:"varint,25,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ExpiredAt     int64            `protobuf:"varint,26,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"` // Đã được đánh dấu hết hạn (0 = chưa), user phải xác nhận lại
+	Purposes      []*PurposeChoice `protobuf:"bytes,27,rep,name=purposes,proto3" json:"purposes,omitempty"`                     // Lựa chọn theo purpose của document (rỗng = document không có purpose)
+	Status        string           `protobuf:"bytes,28,opt,name=status,proto3" json:"status,omitempty"`                         // "granted", "withdrawn" hoặc "declined"
+	ErasedAt      int64            `protobuf:"varint,29,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`    // Dữ liệu cá nhân (IP, user agent) đã bị xóa (0 = chưa)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -211,14 +226,87 @@ func (x *Consent) GetRevokedBy() string {
 	return ""
 }
 
+func (x *Consent) GetRecordHash() string {
+	if x != nil {
+		return x.RecordHash
+	}
+	return ""
+}
+
+func (x *Consent) GetDocumentContentHash() string {
+	if x != nil {
+		return x.DocumentContentHash
+	}
+	return ""
+}
+
+func (x *Consent) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Consent) GetRecordedBy() string {
+	if x != nil {
+		return x.RecordedBy
+	}
+	return ""
+}
+
+func (x *Consent) GetOnBehalfReason() string {
+	if x != nil {
+		return x.OnBehalfReason
+	}
+	return ""
+}
+
+func (x *Consent) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Consent) GetExpiredAt() int64 {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return 0
+}
+
+func (x *Consent) GetPurposes() []*PurposeChoice {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+func (x *Consent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Consent) GetErasedAt() int64 {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return 0
+}
+
 type ConsentInput struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	DocumentId       string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
 	DocumentName     string                 `protobuf:"bytes,2,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
 	VersionTimestamp int64                  `protobuf:"varint,3,opt,name=version_timestamp,json=versionTimestamp,proto3" json:"version_timestamp,omitempty"`
 	AgreedFileUrl    string                 `protobuf:"bytes,4,opt,name=agreed_file_url,json=agreedFileUrl,proto3" json:"agreed_file_url,omitempty"` // Optional
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	Locale           string                 `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`                                      // Optional: locale nội dung user đã xem, rỗng = nội dung gốc
+	// Optional: đồng ý/từ chối từng purpose. Không gửi: purpose bắt buộc = đồng ý, tùy chọn = từ chối
+	Purposes      []*PurposeChoice `protobuf:"bytes,6,rep,name=purposes,proto3" json:"purposes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ConsentInput) Reset() {
@@ -279,6 +367,73 @@ func (x *ConsentInput) GetAgreedFileUrl() string {
 	return ""
 }
 
+func (x *ConsentInput) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ConsentInput) GetPurposes() []*PurposeChoice {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+// Lựa chọn của user với 1 purpose của document
+type PurposeChoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purpose       string                 `protobuf:"bytes,1,opt,name=purpose,proto3" json:"purpose,omitempty"` // Purpose key, vd: "marketing"
+	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurposeChoice) Reset() {
+	*x = PurposeChoice{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurposeChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurposeChoice) ProtoMessage() {}
+
+func (x *PurposeChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurposeChoice.ProtoReflect.Descriptor instead.
+func (*PurposeChoice) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PurposeChoice) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *PurposeChoice) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
 // RecordConsent - Lưu đồng ý mới
 type RecordConsentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -288,13 +443,16 @@ type RecordConsentRequest struct {
 	ConsentMethod string                 `protobuf:"bytes,4,opt,name=consent_method,json=consentMethod,proto3" json:"consent_method,omitempty"` // 'REGISTRATION', 'UI', 'API'
 	IpAddress     string                 `protobuf:"bytes,5,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`             // Optional
 	UserAgent     string                 `protobuf:"bytes,6,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`             // Optional
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Act on behalf of: Admin (lấy từ JWT ở gateway) ghi consent thay user_id, bắt buộc kèm lý do
+	ActingAdminId  string `protobuf:"bytes,7,opt,name=acting_admin_id,json=actingAdminId,proto3" json:"acting_admin_id,omitempty"`
+	OnBehalfReason string `protobuf:"bytes,8,opt,name=on_behalf_reason,json=onBehalfReason,proto3" json:"on_behalf_reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RecordConsentRequest) Reset() {
 	*x = RecordConsentRequest{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[2]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -306,7 +464,7 @@ func (x *RecordConsentRequest) String() string {
 func (*RecordConsentRequest) ProtoMessage() {}
 
 func (x *RecordConsentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[2]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,7 +477,7 @@ func (x *RecordConsentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordConsentRequest.ProtoReflect.Descriptor instead.
 func (*RecordConsentRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{2}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RecordConsentRequest) GetUserId() string {
@@ -364,6 +522,20 @@ func (x *RecordConsentRequest) GetUserAgent() string {
 	return ""
 }
 
+func (x *RecordConsentRequest) GetActingAdminId() string {
+	if x != nil {
+		return x.ActingAdminId
+	}
+	return ""
+}
+
+func (x *RecordConsentRequest) GetOnBehalfReason() string {
+	if x != nil {
+		return x.OnBehalfReason
+	}
+	return ""
+}
+
 type RecordConsentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Consents      []*Consent             `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
@@ -374,7 +546,7 @@ type RecordConsentResponse struct {
 
 func (x *RecordConsentResponse) Reset() {
 	*x = RecordConsentResponse{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[3]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -386,7 +558,7 @@ func (x *RecordConsentResponse) String() string {
 func (*RecordConsentResponse) ProtoMessage() {}
 
 func (x *RecordConsentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[3]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,7 +571,7 @@ func (x *RecordConsentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordConsentResponse.ProtoReflect.Descriptor instead.
 func (*RecordConsentResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{3}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RecordConsentResponse) GetConsents() []*Consent {
@@ -428,7 +600,7 @@ type CheckConsentRequest struct {
 
 func (x *CheckConsentRequest) Reset() {
 	*x = CheckConsentRequest{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[4]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -440,7 +612,7 @@ func (x *CheckConsentRequest) String() string {
 func (*CheckConsentRequest) ProtoMessage() {}
 
 func (x *CheckConsentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[4]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -453,7 +625,7 @@ func (x *CheckConsentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckConsentRequest.ProtoReflect.Descriptor instead.
 func (*CheckConsentRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{4}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CheckConsentRequest) GetUserId() string {
@@ -487,7 +659,7 @@ type CheckConsentResponse struct {
 
 func (x *CheckConsentResponse) Reset() {
 	*x = CheckConsentResponse{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[5]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +671,7 @@ func (x *CheckConsentResponse) String() string {
 func (*CheckConsentResponse) ProtoMessage() {}
 
 func (x *CheckConsentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[5]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,7 +684,7 @@ func (x *CheckConsentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckConsentResponse.ProtoReflect.Descriptor instead.
 func (*CheckConsentResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{5}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CheckConsentResponse) GetHasConsented() bool {
@@ -540,7 +712,7 @@ type GetUserConsentsRequest struct {
 
 func (x *GetUserConsentsRequest) Reset() {
 	*x = GetUserConsentsRequest{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[6]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -552,7 +724,7 @@ func (x *GetUserConsentsRequest) String() string {
 func (*GetUserConsentsRequest) ProtoMessage() {}
 
 func (x *GetUserConsentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[6]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +737,7 @@ func (x *GetUserConsentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserConsentsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserConsentsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{6}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetUserConsentsRequest) GetUserId() string {
@@ -592,7 +764,7 @@ type GetUserConsentsResponse struct {
 
 func (x *GetUserConsentsResponse) Reset() {
 	*x = GetUserConsentsResponse{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[7]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -604,7 +776,7 @@ func (x *GetUserConsentsResponse) String() string {
 func (*GetUserConsentsResponse) ProtoMessage() {}
 
 func (x *GetUserConsentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[7]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,7 +789,7 @@ func (x *GetUserConsentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserConsentsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserConsentsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{7}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetUserConsentsResponse) GetConsents() []*Consent {
@@ -641,13 +813,17 @@ type PendingPolicy struct {
 	DocumentName     string                 `protobuf:"bytes,2,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
 	VersionTimestamp int64                  `protobuf:"varint,3,opt,name=version_timestamp,json=versionTimestamp,proto3" json:"version_timestamp,omitempty"`
 	Platform         string                 `protobuf:"bytes,4,opt,name=platform,proto3" json:"platform,omitempty"`
+	IsMandatory      bool                   `protobuf:"varint,5,opt,name=is_mandatory,json=isMandatory,proto3" json:"is_mandatory,omitempty"`
+	GraceDeadline    int64                  `protobuf:"varint,6,opt,name=grace_deadline,json=graceDeadline,proto3" json:"grace_deadline,omitempty"`            // Unix timestamp, 0 nếu không bắt buộc
+	IsOverdue        bool                   `protobuf:"varint,7,opt,name=is_overdue,json=isOverdue,proto3" json:"is_overdue,omitempty"`                        // Đã quá grace_deadline
+	ConsentExpiredAt int64                  `protobuf:"varint,8,opt,name=consent_expired_at,json=consentExpiredAt,proto3" json:"consent_expired_at,omitempty"` // > 0 nếu user đã đồng ý version này nhưng consent đã hết hạn (cần xác nhận lại)
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *PendingPolicy) Reset() {
 	*x = PendingPolicy{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[8]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -659,7 +835,7 @@ func (x *PendingPolicy) String() string {
 func (*PendingPolicy) ProtoMessage() {}
 
 func (x *PendingPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[8]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -672,7 +848,7 @@ func (x *PendingPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PendingPolicy.ProtoReflect.Descriptor instead.
 func (*PendingPolicy) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{8}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PendingPolicy) GetDocumentId() string {
@@ -703,18 +879,48 @@ func (x *PendingPolicy) GetPlatform() string {
 	return ""
 }
 
+func (x *PendingPolicy) GetIsMandatory() bool {
+	if x != nil {
+		return x.IsMandatory
+	}
+	return false
+}
+
+func (x *PendingPolicy) GetGraceDeadline() int64 {
+	if x != nil {
+		return x.GraceDeadline
+	}
+	return 0
+}
+
+func (x *PendingPolicy) GetIsOverdue() bool {
+	if x != nil {
+		return x.IsOverdue
+	}
+	return false
+}
+
+func (x *PendingPolicy) GetConsentExpiredAt() int64 {
+	if x != nil {
+		return x.ConsentExpiredAt
+	}
+	return 0
+}
+
 type CheckPendingConsentsRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Platform       string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
 	LatestPolicies []*PendingPolicy       `protobuf:"bytes,3,rep,name=latest_policies,json=latestPolicies,proto3" json:"latest_policies,omitempty"` // Gateway truyền vào
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// true = Consent Service tự lấy active policies từ Document Service, bỏ qua latest_policies
+	ResolveActivePolicies bool `protobuf:"varint,4,opt,name=resolve_active_policies,json=resolveActivePolicies,proto3" json:"resolve_active_policies,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *CheckPendingConsentsRequest) Reset() {
 	*x = CheckPendingConsentsRequest{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[9]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -726,7 +932,7 @@ func (x *CheckPendingConsentsRequest) String() string {
 func (*CheckPendingConsentsRequest) ProtoMessage() {}
 
 func (x *CheckPendingConsentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[9]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -739,7 +945,7 @@ func (x *CheckPendingConsentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckPendingConsentsRequest.ProtoReflect.Descriptor instead.
 func (*CheckPendingConsentsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{9}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CheckPendingConsentsRequest) GetUserId() string {
@@ -763,6 +969,13 @@ func (x *CheckPendingConsentsRequest) GetLatestPolicies() []*PendingPolicy {
 	return nil
 }
 
+func (x *CheckPendingConsentsRequest) GetResolveActivePolicies() bool {
+	if x != nil {
+		return x.ResolveActivePolicies
+	}
+	return false
+}
+
 type CheckPendingConsentsResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	PendingPolicies []*PendingPolicy       `protobuf:"bytes,1,rep,name=pending_policies,json=pendingPolicies,proto3" json:"pending_policies,omitempty"`
@@ -773,7 +986,7 @@ type CheckPendingConsentsResponse struct {
 
 func (x *CheckPendingConsentsResponse) Reset() {
 	*x = CheckPendingConsentsResponse{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[10]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -785,7 +998,7 @@ func (x *CheckPendingConsentsResponse) String() string {
 func (*CheckPendingConsentsResponse) ProtoMessage() {}
 
 func (x *CheckPendingConsentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[10]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,7 +1011,7 @@ func (x *CheckPendingConsentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckPendingConsentsResponse.ProtoReflect.Descriptor instead.
 func (*CheckPendingConsentsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{10}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CheckPendingConsentsResponse) GetPendingPolicies() []*PendingPolicy {
@@ -821,13 +1034,16 @@ type RevokeConsentRequest struct {
 	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	DocumentId       string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
 	VersionTimestamp int64                  `protobuf:"varint,3,opt,name=version_timestamp,json=versionTimestamp,proto3" json:"version_timestamp,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Act on behalf of: Admin thu hồi thay user_id, bắt buộc kèm lý do
+	ActingAdminId string `protobuf:"bytes,4,opt,name=acting_admin_id,json=actingAdminId,proto3" json:"acting_admin_id,omitempty"`
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RevokeConsentRequest) Reset() {
 	*x = RevokeConsentRequest{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[11]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -839,7 +1055,7 @@ func (x *RevokeConsentRequest) String() string {
 func (*RevokeConsentRequest) ProtoMessage() {}
 
 func (x *RevokeConsentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[11]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -852,7 +1068,7 @@ func (x *RevokeConsentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeConsentRequest.ProtoReflect.Descriptor instead.
 func (*RevokeConsentRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{11}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RevokeConsentRequest) GetUserId() string {
@@ -876,6 +1092,20 @@ func (x *RevokeConsentRequest) GetVersionTimestamp() int64 {
 	return 0
 }
 
+func (x *RevokeConsentRequest) GetActingAdminId() string {
+	if x != nil {
+		return x.ActingAdminId
+	}
+	return ""
+}
+
+func (x *RevokeConsentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type RevokeConsentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -886,7 +1116,7 @@ type RevokeConsentResponse struct {
 
 func (x *RevokeConsentResponse) Reset() {
 	*x = RevokeConsentResponse{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[12]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -898,7 +1128,7 @@ func (x *RevokeConsentResponse) String() string {
 func (*RevokeConsentResponse) ProtoMessage() {}
 
 func (x *RevokeConsentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[12]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -911,7 +1141,7 @@ func (x *RevokeConsentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeConsentResponse.ProtoReflect.Descriptor instead.
 func (*RevokeConsentResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{12}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RevokeConsentResponse) GetSuccess() bool {
@@ -939,7 +1169,7 @@ type GetConsentHistoryRequest struct {
 
 func (x *GetConsentHistoryRequest) Reset() {
 	*x = GetConsentHistoryRequest{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[13]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -951,7 +1181,7 @@ func (x *GetConsentHistoryRequest) String() string {
 func (*GetConsentHistoryRequest) ProtoMessage() {}
 
 func (x *GetConsentHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[13]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -964,7 +1194,7 @@ func (x *GetConsentHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConsentHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetConsentHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{13}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetConsentHistoryRequest) GetUserId() string {
@@ -982,16 +1212,17 @@ func (x *GetConsentHistoryRequest) GetDocumentId() string {
 }
 
 type GetConsentHistoryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	History       []*Consent             `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	History       []*Consent              `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	Total         int32                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Timeline      []*ConsentTimelineEvent `protobuf:"bytes,3,rep,name=timeline,proto3" json:"timeline,omitempty"` // Đồng ý → thu hồi → đồng ý lại..., cũ nhất trước
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetConsentHistoryResponse) Reset() {
 	*x = GetConsentHistoryResponse{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[14]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1003,7 +1234,7 @@ func (x *GetConsentHistoryResponse) String() string {
 func (*GetConsentHistoryResponse) ProtoMessage() {}
 
 func (x *GetConsentHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[14]
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,7 +1247,7 @@ func (x *GetConsentHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConsentHistoryResponse.ProtoReflect.Descriptor instead.
 func (*GetConsentHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{14}
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetConsentHistoryResponse) GetHistory() []*Consent {
@@ -1033,17 +1264,124 @@ func (x *GetConsentHistoryResponse) GetTotal() int32 {
 	return 0
 }
 
-// GetConsentStats - Get consent statistics
-type GetConsentStatsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"` // Optional: filter by platform
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetConsentHistoryResponse) GetTimeline() []*ConsentTimelineEvent {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
 }
 
-func (x *GetConsentStatsRequest) Reset() {
-	*x = GetConsentStatsRequest{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[15]
+type ConsentTimelineEvent struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId        string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	DocumentId       string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	DocumentName     string                 `protobuf:"bytes,3,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	VersionTimestamp int64                  `protobuf:"varint,4,opt,name=version_timestamp,json=versionTimestamp,proto3" json:"version_timestamp,omitempty"`
+	Action           string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"` // "granted", "withdrawn", "declined" hoặc "expired"
+	At               int64                  `protobuf:"varint,6,opt,name=at,proto3" json:"at,omitempty"`
+	ActorId          string                 `protobuf:"bytes,7,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // User hoặc Admin thao tác thay, rỗng với expired
+	Reason           string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConsentTimelineEvent) Reset() {
+	*x = ConsentTimelineEvent{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentTimelineEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentTimelineEvent) ProtoMessage() {}
+
+func (x *ConsentTimelineEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentTimelineEvent.ProtoReflect.Descriptor instead.
+func (*ConsentTimelineEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConsentTimelineEvent) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *ConsentTimelineEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *ConsentTimelineEvent) GetDocumentName() string {
+	if x != nil {
+		return x.DocumentName
+	}
+	return ""
+}
+
+func (x *ConsentTimelineEvent) GetVersionTimestamp() int64 {
+	if x != nil {
+		return x.VersionTimestamp
+	}
+	return 0
+}
+
+func (x *ConsentTimelineEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ConsentTimelineEvent) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+func (x *ConsentTimelineEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ConsentTimelineEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// GetConsentStats - Get consent statistics
+type GetConsentStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"` // Optional: filter by platform
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsentStatsRequest) Reset() {
+	*x = GetConsentStatsRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1052,10 +1390,1237 @@ func (x *GetConsentStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConsentStatsRequest) ProtoMessage() {}
+func (*GetConsentStatsRequest) ProtoMessage() {}
+
+func (x *GetConsentStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsentStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetConsentStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetConsentStatsRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type GetConsentStatsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TotalConsents      int32                  `protobuf:"varint,1,opt,name=total_consents,json=totalConsents,proto3" json:"total_consents,omitempty"`
+	ActiveConsents     int32                  `protobuf:"varint,2,opt,name=active_consents,json=activeConsents,proto3" json:"active_consents,omitempty"`
+	RevokedConsents    int32                  `protobuf:"varint,3,opt,name=revoked_consents,json=revokedConsents,proto3" json:"revoked_consents,omitempty"`
+	ConsentsByDocument map[string]int32       `protobuf:"bytes,4,rep,name=consents_by_document,json=consentsByDocument,proto3" json:"consents_by_document,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ConsentsByPlatform map[string]int32       `protobuf:"bytes,5,rep,name=consents_by_platform,json=consentsByPlatform,proto3" json:"consents_by_platform,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ConsentsByMethod   map[string]int32       `protobuf:"bytes,6,rep,name=consents_by_method,json=consentsByMethod,proto3" json:"consents_by_method,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	DeclinedConsents   int32                  `protobuf:"varint,7,opt,name=declined_consents,json=declinedConsents,proto3" json:"declined_consents,omitempty"` // User chủ động từ chối policy không bắt buộc
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetConsentStatsResponse) Reset() {
+	*x = GetConsentStatsResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsentStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsentStatsResponse) ProtoMessage() {}
+
+func (x *GetConsentStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsentStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetConsentStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetConsentStatsResponse) GetTotalConsents() int32 {
+	if x != nil {
+		return x.TotalConsents
+	}
+	return 0
+}
+
+func (x *GetConsentStatsResponse) GetActiveConsents() int32 {
+	if x != nil {
+		return x.ActiveConsents
+	}
+	return 0
+}
+
+func (x *GetConsentStatsResponse) GetRevokedConsents() int32 {
+	if x != nil {
+		return x.RevokedConsents
+	}
+	return 0
+}
+
+func (x *GetConsentStatsResponse) GetConsentsByDocument() map[string]int32 {
+	if x != nil {
+		return x.ConsentsByDocument
+	}
+	return nil
+}
+
+func (x *GetConsentStatsResponse) GetConsentsByPlatform() map[string]int32 {
+	if x != nil {
+		return x.ConsentsByPlatform
+	}
+	return nil
+}
+
+func (x *GetConsentStatsResponse) GetConsentsByMethod() map[string]int32 {
+	if x != nil {
+		return x.ConsentsByMethod
+	}
+	return nil
+}
+
+func (x *GetConsentStatsResponse) GetDeclinedConsents() int32 {
+	if x != nil {
+		return x.DeclinedConsents
+	}
+	return 0
+}
+
+// VerifyConsentChain - Verify tamper-evident hash chain of a user's consents
+type VerifyConsentChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyConsentChainRequest) Reset() {
+	*x = VerifyConsentChainRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyConsentChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyConsentChainRequest) ProtoMessage() {}
+
+func (x *VerifyConsentChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyConsentChainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyConsentChainRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VerifyConsentChainRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ChainIssue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeqNo         int64                  `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"` // 0 nếu issue không gắn với event cụ thể
+	ConsentId     string                 `protobuf:"bytes,2,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"` // BROKEN_LINK, SEQ_GAP, HASH_MISMATCH, HEAD_MISMATCH, ROW_MISSING, ROW_MODIFIED, REVOKE_MISMATCH, UNCHAINED_ROW
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChainIssue) Reset() {
+	*x = ChainIssue{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainIssue) ProtoMessage() {}
+
+func (x *ChainIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainIssue.ProtoReflect.Descriptor instead.
+func (*ChainIssue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChainIssue) GetSeqNo() int64 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *ChainIssue) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *ChainIssue) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ChainIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type VerifyConsentChainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	ChainLength   int64                  `protobuf:"varint,3,opt,name=chain_length,json=chainLength,proto3" json:"chain_length,omitempty"`
+	HeadHash      string                 `protobuf:"bytes,4,opt,name=head_hash,json=headHash,proto3" json:"head_hash,omitempty"` // Có thể lưu/công bố ra ngoài để neo chain
+	Issues        []*ChainIssue          `protobuf:"bytes,5,rep,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyConsentChainResponse) Reset() {
+	*x = VerifyConsentChainResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyConsentChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyConsentChainResponse) ProtoMessage() {}
+
+func (x *VerifyConsentChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyConsentChainResponse.ProtoReflect.Descriptor instead.
+func (*VerifyConsentChainResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VerifyConsentChainResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyConsentChainResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyConsentChainResponse) GetChainLength() int64 {
+	if x != nil {
+		return x.ChainLength
+	}
+	return 0
+}
+
+func (x *VerifyConsentChainResponse) GetHeadHash() string {
+	if x != nil {
+		return x.HeadHash
+	}
+	return ""
+}
+
+func (x *VerifyConsentChainResponse) GetIssues() []*ChainIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+// GetDocumentSnapshot - Exact document content a user agreed to
+type GetDocumentSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentHash   string                 `protobuf:"bytes,1,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentSnapshotRequest) Reset() {
+	*x = GetDocumentSnapshotRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentSnapshotRequest) ProtoMessage() {}
+
+func (x *GetDocumentSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDocumentSnapshotRequest) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+type GetDocumentSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentHash   string                 `protobuf:"bytes,1,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`                        // 'content_html', 'file_hash' hoặc 'file_url'
+	SourceUrl     string                 `protobuf:"bytes,5,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"` // file_url nếu content tải từ URL
+	SizeBytes     int64                  `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	CapturedAt    int64                  `protobuf:"varint,7,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"` // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentSnapshotResponse) Reset() {
+	*x = GetDocumentSnapshotResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentSnapshotResponse) ProtoMessage() {}
+
+func (x *GetDocumentSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*GetDocumentSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetDocumentSnapshotResponse) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *GetDocumentSnapshotResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *GetDocumentSnapshotResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetDocumentSnapshotResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetDocumentSnapshotResponse) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *GetDocumentSnapshotResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *GetDocumentSnapshotResponse) GetCapturedAt() int64 {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return 0
+}
+
+// Re-consent campaign - mở tự động khi 1 version bắt buộc mới có hiệu lực
+type ReconsentCampaign struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Platform         string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	DocumentName     string                 `protobuf:"bytes,3,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	DocumentId       string                 `protobuf:"bytes,4,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"` // Version mới
+	VersionTimestamp int64                  `protobuf:"varint,5,opt,name=version_timestamp,json=versionTimestamp,proto3" json:"version_timestamp,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                     // 'active' hoặc 'superseded'
+	GraceDeadline    int64                  `protobuf:"varint,7,opt,name=grace_deadline,json=graceDeadline,proto3" json:"grace_deadline,omitempty"` // Unix timestamp, quá hạn chưa đồng ý thì bị chặn truy cập
+	AffectedUsers    int32                  `protobuf:"varint,8,opt,name=affected_users,json=affectedUsers,proto3" json:"affected_users,omitempty"` // User đã đồng ý version cũ lúc mở campaign
+	AcceptedUsers    int32                  `protobuf:"varint,9,opt,name=accepted_users,json=acceptedUsers,proto3" json:"accepted_users,omitempty"`
+	PendingUsers     int32                  `protobuf:"varint,10,opt,name=pending_users,json=pendingUsers,proto3" json:"pending_users,omitempty"`
+	OverdueUsers     int32                  `protobuf:"varint,11,opt,name=overdue_users,json=overdueUsers,proto3" json:"overdue_users,omitempty"`
+	SupersededBy     string                 `protobuf:"bytes,12,opt,name=superseded_by,json=supersededBy,proto3" json:"superseded_by,omitempty"`
+	UpdatedBy        string                 `protobuf:"bytes,13,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	CreatedAt        int64                  `protobuf:"varint,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        int64                  `protobuf:"varint,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReconsentCampaign) Reset() {
+	*x = ReconsentCampaign{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconsentCampaign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconsentCampaign) ProtoMessage() {}
+
+func (x *ReconsentCampaign) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconsentCampaign.ProtoReflect.Descriptor instead.
+func (*ReconsentCampaign) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReconsentCampaign) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReconsentCampaign) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ReconsentCampaign) GetDocumentName() string {
+	if x != nil {
+		return x.DocumentName
+	}
+	return ""
+}
+
+func (x *ReconsentCampaign) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *ReconsentCampaign) GetVersionTimestamp() int64 {
+	if x != nil {
+		return x.VersionTimestamp
+	}
+	return 0
+}
+
+func (x *ReconsentCampaign) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReconsentCampaign) GetGraceDeadline() int64 {
+	if x != nil {
+		return x.GraceDeadline
+	}
+	return 0
+}
+
+func (x *ReconsentCampaign) GetAffectedUsers() int32 {
+	if x != nil {
+		return x.AffectedUsers
+	}
+	return 0
+}
+
+func (x *ReconsentCampaign) GetAcceptedUsers() int32 {
+	if x != nil {
+		return x.AcceptedUsers
+	}
+	return 0
+}
+
+func (x *ReconsentCampaign) GetPendingUsers() int32 {
+	if x != nil {
+		return x.PendingUsers
+	}
+	return 0
+}
+
+func (x *ReconsentCampaign) GetOverdueUsers() int32 {
+	if x != nil {
+		return x.OverdueUsers
+	}
+	return 0
+}
+
+func (x *ReconsentCampaign) GetSupersededBy() string {
+	if x != nil {
+		return x.SupersededBy
+	}
+	return ""
+}
+
+func (x *ReconsentCampaign) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *ReconsentCampaign) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ReconsentCampaign) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type ListReconsentCampaignsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`                             // Optional
+	DocumentName  string                 `protobuf:"bytes,2,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"` // Optional
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                 // Optional: 'active' hoặc 'superseded'
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReconsentCampaignsRequest) Reset() {
+	*x = ListReconsentCampaignsRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReconsentCampaignsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReconsentCampaignsRequest) ProtoMessage() {}
+
+func (x *ListReconsentCampaignsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReconsentCampaignsRequest.ProtoReflect.Descriptor instead.
+func (*ListReconsentCampaignsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListReconsentCampaignsRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ListReconsentCampaignsRequest) GetDocumentName() string {
+	if x != nil {
+		return x.DocumentName
+	}
+	return ""
+}
+
+func (x *ListReconsentCampaignsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListReconsentCampaignsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReconsentCampaignsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListReconsentCampaignsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaigns     []*ReconsentCampaign   `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReconsentCampaignsResponse) Reset() {
+	*x = ListReconsentCampaignsResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReconsentCampaignsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReconsentCampaignsResponse) ProtoMessage() {}
+
+func (x *ListReconsentCampaignsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReconsentCampaignsResponse.ProtoReflect.Descriptor instead.
+func (*ListReconsentCampaignsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListReconsentCampaignsResponse) GetCampaigns() []*ReconsentCampaign {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *ListReconsentCampaignsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListReconsentCampaignsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReconsentCampaignsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetReconsentCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReconsentCampaignRequest) Reset() {
+	*x = GetReconsentCampaignRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReconsentCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReconsentCampaignRequest) ProtoMessage() {}
+
+func (x *GetReconsentCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReconsentCampaignRequest.ProtoReflect.Descriptor instead.
+func (*GetReconsentCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetReconsentCampaignRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetReconsentCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *ReconsentCampaign     `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReconsentCampaignResponse) Reset() {
+	*x = GetReconsentCampaignResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReconsentCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReconsentCampaignResponse) ProtoMessage() {}
+
+func (x *GetReconsentCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReconsentCampaignResponse.ProtoReflect.Descriptor instead.
+func (*GetReconsentCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetReconsentCampaignResponse) GetCampaign() *ReconsentCampaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+type CampaignUser struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	UserId                   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PreviousVersionTimestamp int64                  `protobuf:"varint,2,opt,name=previous_version_timestamp,json=previousVersionTimestamp,proto3" json:"previous_version_timestamp,omitempty"`
+	Status                   string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                            // 'accepted', 'pending' hoặc 'overdue'
+	AcceptedAt               int64                  `protobuf:"varint,4,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"` // Unix timestamp, 0 nếu chưa đồng ý
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CampaignUser) Reset() {
+	*x = CampaignUser{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignUser) ProtoMessage() {}
+
+func (x *CampaignUser) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignUser.ProtoReflect.Descriptor instead.
+func (*CampaignUser) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CampaignUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CampaignUser) GetPreviousVersionTimestamp() int64 {
+	if x != nil {
+		return x.PreviousVersionTimestamp
+	}
+	return 0
+}
+
+func (x *CampaignUser) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CampaignUser) GetAcceptedAt() int64 {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return 0
+}
+
+type ListCampaignUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // Optional: 'accepted', 'pending' hoặc 'overdue'
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignUsersRequest) Reset() {
+	*x = ListCampaignUsersRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignUsersRequest) ProtoMessage() {}
+
+func (x *ListCampaignUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListCampaignUsersRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListCampaignUsersRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *ListCampaignUsersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListCampaignUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCampaignUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListCampaignUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*CampaignUser        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignUsersResponse) Reset() {
+	*x = ListCampaignUsersResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignUsersResponse) ProtoMessage() {}
+
+func (x *ListCampaignUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListCampaignUsersResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListCampaignUsersResponse) GetUsers() []*CampaignUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListCampaignUsersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListCampaignUsersResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCampaignUsersResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// UpdateReconsentCampaign - Admin gia hạn hoặc rút ngắn grace period
+type UpdateReconsentCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GraceDeadline int64                  `protobuf:"varint,2,opt,name=grace_deadline,json=graceDeadline,proto3" json:"grace_deadline,omitempty"` // Unix timestamp
+	AdminId       string                 `protobuf:"bytes,3,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // Bắt buộc
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReconsentCampaignRequest) Reset() {
+	*x = UpdateReconsentCampaignRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReconsentCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReconsentCampaignRequest) ProtoMessage() {}
+
+func (x *UpdateReconsentCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReconsentCampaignRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReconsentCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateReconsentCampaignRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateReconsentCampaignRequest) GetGraceDeadline() int64 {
+	if x != nil {
+		return x.GraceDeadline
+	}
+	return 0
+}
+
+func (x *UpdateReconsentCampaignRequest) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+func (x *UpdateReconsentCampaignRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateReconsentCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *ReconsentCampaign     `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReconsentCampaignResponse) Reset() {
+	*x = UpdateReconsentCampaignResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReconsentCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReconsentCampaignResponse) ProtoMessage() {}
+
+func (x *UpdateReconsentCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReconsentCampaignResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReconsentCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateReconsentCampaignResponse) GetCampaign() *ReconsentCampaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+// CheckPurposeConsent - Lựa chọn mới nhất (consent còn hiệu lực) của user với 1 purpose
+type CheckPurposeConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Purpose       string                 `protobuf:"bytes,2,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPurposeConsentRequest) Reset() {
+	*x = CheckPurposeConsentRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPurposeConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPurposeConsentRequest) ProtoMessage() {}
+
+func (x *CheckPurposeConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPurposeConsentRequest.ProtoReflect.Descriptor instead.
+func (*CheckPurposeConsentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CheckPurposeConsentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckPurposeConsentRequest) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+type CheckPurposeConsentResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Allowed          bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`                            // true nếu user đã đồng ý purpose
+	HasDecision      bool                   `protobuf:"varint,2,opt,name=has_decision,json=hasDecision,proto3" json:"has_decision,omitempty"` // false = user chưa đồng ý/từ chối (coi như không cho phép)
+	ConsentId        string                 `protobuf:"bytes,3,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`        // Consent ghi lại lựa chọn
+	DocumentId       string                 `protobuf:"bytes,4,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	DocumentName     string                 `protobuf:"bytes,5,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	VersionTimestamp int64                  `protobuf:"varint,6,opt,name=version_timestamp,json=versionTimestamp,proto3" json:"version_timestamp,omitempty"`
+	DecidedAt        int64                  `protobuf:"varint,7,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"` // agreed_at của consent
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CheckPurposeConsentResponse) Reset() {
+	*x = CheckPurposeConsentResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPurposeConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPurposeConsentResponse) ProtoMessage() {}
 
-func (x *GetConsentStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[15]
+func (x *CheckPurposeConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1066,45 +2631,92 @@ func (x *GetConsentStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConsentStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetConsentStatsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use CheckPurposeConsentResponse.ProtoReflect.Descriptor instead.
+func (*CheckPurposeConsentResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *GetConsentStatsRequest) GetPlatform() string {
+func (x *CheckPurposeConsentResponse) GetAllowed() bool {
 	if x != nil {
-		return x.Platform
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *CheckPurposeConsentResponse) GetHasDecision() bool {
+	if x != nil {
+		return x.HasDecision
+	}
+	return false
+}
+
+func (x *CheckPurposeConsentResponse) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
 	}
 	return ""
 }
 
-type GetConsentStatsResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	TotalConsents      int32                  `protobuf:"varint,1,opt,name=total_consents,json=totalConsents,proto3" json:"total_consents,omitempty"`
-	ActiveConsents     int32                  `protobuf:"varint,2,opt,name=active_consents,json=activeConsents,proto3" json:"active_consents,omitempty"`
-	RevokedConsents    int32                  `protobuf:"varint,3,opt,name=revoked_consents,json=revokedConsents,proto3" json:"revoked_consents,omitempty"`
-	ConsentsByDocument map[string]int32       `protobuf:"bytes,4,rep,name=consents_by_document,json=consentsByDocument,proto3" json:"consents_by_document,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	ConsentsByPlatform map[string]int32       `protobuf:"bytes,5,rep,name=consents_by_platform,json=consentsByPlatform,proto3" json:"consents_by_platform,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	ConsentsByMethod   map[string]int32       `protobuf:"bytes,6,rep,name=consents_by_method,json=consentsByMethod,proto3" json:"consents_by_method,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *CheckPurposeConsentResponse) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
 }
 
-func (x *GetConsentStatsResponse) Reset() {
-	*x = GetConsentStatsResponse{}
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[16]
+func (x *CheckPurposeConsentResponse) GetDocumentName() string {
+	if x != nil {
+		return x.DocumentName
+	}
+	return ""
+}
+
+func (x *CheckPurposeConsentResponse) GetVersionTimestamp() int64 {
+	if x != nil {
+		return x.VersionTimestamp
+	}
+	return 0
+}
+
+func (x *CheckPurposeConsentResponse) GetDecidedAt() int64 {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return 0
+}
+
+// DeclineConsent - User từ chối policy không bắt buộc (policy bắt buộc không từ chối được)
+type DeclineConsentRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Platform         string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	DocumentId       string                 `protobuf:"bytes,3,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	DocumentName     string                 `protobuf:"bytes,4,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	VersionTimestamp int64                  `protobuf:"varint,5,opt,name=version_timestamp,json=versionTimestamp,proto3" json:"version_timestamp,omitempty"`
+	ConsentMethod    string                 `protobuf:"bytes,6,opt,name=consent_method,json=consentMethod,proto3" json:"consent_method,omitempty"`    // 'REGISTRATION', 'UI', 'API'
+	IpAddress        string                 `protobuf:"bytes,7,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`                // Optional
+	UserAgent        string                 `protobuf:"bytes,8,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`                // Optional
+	Reason           string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`                                       // Optional với user, bắt buộc khi acting_admin_id
+	ActingAdminId    string                 `protobuf:"bytes,10,opt,name=acting_admin_id,json=actingAdminId,proto3" json:"acting_admin_id,omitempty"` // Admin từ chối thay user
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeclineConsentRequest) Reset() {
+	*x = DeclineConsentRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConsentStatsResponse) String() string {
+func (x *DeclineConsentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConsentStatsResponse) ProtoMessage() {}
+func (*DeclineConsentRequest) ProtoMessage() {}
 
-func (x *GetConsentStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_consent_consent_proto_msgTypes[16]
+func (x *DeclineConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1115,58 +2727,243 @@ func (x *GetConsentStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConsentStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetConsentStatsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use DeclineConsentRequest.ProtoReflect.Descriptor instead.
+func (*DeclineConsentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *GetConsentStatsResponse) GetTotalConsents() int32 {
+func (x *DeclineConsentRequest) GetUserId() string {
 	if x != nil {
-		return x.TotalConsents
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetConsentStatsResponse) GetActiveConsents() int32 {
+func (x *DeclineConsentRequest) GetPlatform() string {
 	if x != nil {
-		return x.ActiveConsents
+		return x.Platform
 	}
-	return 0
+	return ""
 }
 
-func (x *GetConsentStatsResponse) GetRevokedConsents() int32 {
+func (x *DeclineConsentRequest) GetDocumentId() string {
 	if x != nil {
-		return x.RevokedConsents
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DeclineConsentRequest) GetDocumentName() string {
+	if x != nil {
+		return x.DocumentName
+	}
+	return ""
+}
+
+func (x *DeclineConsentRequest) GetVersionTimestamp() int64 {
+	if x != nil {
+		return x.VersionTimestamp
 	}
 	return 0
 }
 
-func (x *GetConsentStatsResponse) GetConsentsByDocument() map[string]int32 {
+func (x *DeclineConsentRequest) GetConsentMethod() string {
 	if x != nil {
-		return x.ConsentsByDocument
+		return x.ConsentMethod
 	}
-	return nil
+	return ""
 }
 
-func (x *GetConsentStatsResponse) GetConsentsByPlatform() map[string]int32 {
+func (x *DeclineConsentRequest) GetIpAddress() string {
 	if x != nil {
-		return x.ConsentsByPlatform
+		return x.IpAddress
 	}
-	return nil
+	return ""
 }
 
-func (x *GetConsentStatsResponse) GetConsentsByMethod() map[string]int32 {
+func (x *DeclineConsentRequest) GetUserAgent() string {
 	if x != nil {
-		return x.ConsentsByMethod
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *DeclineConsentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeclineConsentRequest) GetActingAdminId() string {
+	if x != nil {
+		return x.ActingAdminId
+	}
+	return ""
+}
+
+type DeclineConsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consent       *Consent               `protobuf:"bytes,1,opt,name=consent,proto3" json:"consent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineConsentResponse) Reset() {
+	*x = DeclineConsentResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineConsentResponse) ProtoMessage() {}
+
+func (x *DeclineConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineConsentResponse.ProtoReflect.Descriptor instead.
+func (*DeclineConsentResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeclineConsentResponse) GetConsent() *Consent {
+	if x != nil {
+		return x.Consent
 	}
 	return nil
 }
 
+// EraseUserConsents - Gọi từ erasure saga của User Service, chạy lại nhiều lần vẫn an toàn
+type EraseUserConsentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ErasedBy      string                 `protobuf:"bytes,2,opt,name=erased_by,json=erasedBy,proto3" json:"erased_by,omitempty"` // Admin xử lý yêu cầu xóa
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserConsentsRequest) Reset() {
+	*x = EraseUserConsentsRequest{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserConsentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserConsentsRequest) ProtoMessage() {}
+
+func (x *EraseUserConsentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserConsentsRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserConsentsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *EraseUserConsentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EraseUserConsentsRequest) GetErasedBy() string {
+	if x != nil {
+		return x.ErasedBy
+	}
+	return ""
+}
+
+func (x *EraseUserConsentsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type EraseUserConsentsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ErasedConsents int32                  `protobuf:"varint,1,opt,name=erased_consents,json=erasedConsents,proto3" json:"erased_consents,omitempty"` // Tổng số consent của user đã xóa dữ liệu cá nhân
+	ChainHeadHash  string                 `protobuf:"bytes,2,opt,name=chain_head_hash,json=chainHeadHash,proto3" json:"chain_head_hash,omitempty"`   // Hash cuối consent chain, đưa vào erasure certificate
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EraseUserConsentsResponse) Reset() {
+	*x = EraseUserConsentsResponse{}
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserConsentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserConsentsResponse) ProtoMessage() {}
+
+func (x *EraseUserConsentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_consent_consent_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserConsentsResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserConsentsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_consent_consent_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *EraseUserConsentsResponse) GetErasedConsents() int32 {
+	if x != nil {
+		return x.ErasedConsents
+	}
+	return 0
+}
+
+func (x *EraseUserConsentsResponse) GetChainHeadHash() string {
+	if x != nil {
+		return x.ChainHeadHash
+	}
+	return ""
+}
+
 var File_pkg_api_consent_consent_proto protoreflect.FileDescriptor
 
 const file_pkg_api_consent_consent_proto_rawDesc = "" +
 	"\n" +
-	"\x1dpkg/api/consent/consent.proto\x12\aconsent\"\xe9\x04\n" +
+	"\x1dpkg/api/consent/consent.proto\x12\aconsent\"\xc8\a\n" +
 	"\aConsent\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
@@ -1196,13 +2993,32 @@ const file_pkg_api_consent_consent_proto_rawDesc = "" +
 	"revoked_at\x18\x11 \x01(\x03R\trevokedAt\x12%\n" +
 	"\x0erevoked_reason\x18\x12 \x01(\tR\rrevokedReason\x12\x1d\n" +
 	"\n" +
-	"revoked_by\x18\x13 \x01(\tR\trevokedBy\"\xa9\x01\n" +
+	"revoked_by\x18\x13 \x01(\tR\trevokedBy\x12\x1f\n" +
+	"\vrecord_hash\x18\x14 \x01(\tR\n" +
+	"recordHash\x122\n" +
+	"\x15document_content_hash\x18\x15 \x01(\tR\x13documentContentHash\x12\x16\n" +
+	"\x06locale\x18\x16 \x01(\tR\x06locale\x12\x1f\n" +
+	"\vrecorded_by\x18\x17 \x01(\tR\n" +
+	"recordedBy\x12(\n" +
+	"\x10on_behalf_reason\x18\x18 \x01(\tR\x0eonBehalfReason\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x19 \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"expired_at\x18\x1a \x01(\x03R\texpiredAt\x122\n" +
+	"\bpurposes\x18\x1b \x03(\v2\x16.consent.PurposeChoiceR\bpurposes\x12\x16\n" +
+	"\x06status\x18\x1c \x01(\tR\x06status\x12\x1b\n" +
+	"\terased_at\x18\x1d \x01(\x03R\berasedAt\"\xf5\x01\n" +
 	"\fConsentInput\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
 	"documentId\x12#\n" +
 	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\x12+\n" +
 	"\x11version_timestamp\x18\x03 \x01(\x03R\x10versionTimestamp\x12&\n" +
-	"\x0fagreed_file_url\x18\x04 \x01(\tR\ragreedFileUrl\"\xe3\x01\n" +
+	"\x0fagreed_file_url\x18\x04 \x01(\tR\ragreedFileUrl\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\x122\n" +
+	"\bpurposes\x18\x06 \x03(\v2\x16.consent.PurposeChoiceR\bpurposes\"E\n" +
+	"\rPurposeChoice\x12\x18\n" +
+	"\apurpose\x18\x01 \x01(\tR\apurpose\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\"\xb5\x02\n" +
 	"\x14RecordConsentRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\bplatform\x18\x02 \x01(\tR\bplatform\x121\n" +
@@ -1211,7 +3027,9 @@ const file_pkg_api_consent_consent_proto_rawDesc = "" +
 	"\n" +
 	"ip_address\x18\x05 \x01(\tR\tipAddress\x12\x1d\n" +
 	"\n" +
-	"user_agent\x18\x06 \x01(\tR\tuserAgent\"l\n" +
+	"user_agent\x18\x06 \x01(\tR\tuserAgent\x12&\n" +
+	"\x0facting_admin_id\x18\a \x01(\tR\ractingAdminId\x12(\n" +
+	"\x10on_behalf_reason\x18\b \x01(\tR\x0eonBehalfReason\"l\n" +
 	"\x15RecordConsentResponse\x12,\n" +
 	"\bconsents\x18\x01 \x03(\v2\x10.consent.ConsentR\bconsents\x12%\n" +
 	"\x0etotal_recorded\x18\x02 \x01(\x05R\rtotalRecorded\"\x83\x01\n" +
@@ -1228,44 +3046,65 @@ const file_pkg_api_consent_consent_proto_rawDesc = "" +
 	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"]\n" +
 	"\x17GetUserConsentsResponse\x12,\n" +
 	"\bconsents\x18\x01 \x03(\v2\x10.consent.ConsentR\bconsents\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\x9e\x01\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xb5\x02\n" +
 	"\rPendingPolicy\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
 	"documentId\x12#\n" +
 	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\x12+\n" +
 	"\x11version_timestamp\x18\x03 \x01(\x03R\x10versionTimestamp\x12\x1a\n" +
-	"\bplatform\x18\x04 \x01(\tR\bplatform\"\x93\x01\n" +
+	"\bplatform\x18\x04 \x01(\tR\bplatform\x12!\n" +
+	"\fis_mandatory\x18\x05 \x01(\bR\visMandatory\x12%\n" +
+	"\x0egrace_deadline\x18\x06 \x01(\x03R\rgraceDeadline\x12\x1d\n" +
+	"\n" +
+	"is_overdue\x18\a \x01(\bR\tisOverdue\x12,\n" +
+	"\x12consent_expired_at\x18\b \x01(\x03R\x10consentExpiredAt\"\xcb\x01\n" +
 	"\x1bCheckPendingConsentsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\bplatform\x18\x02 \x01(\tR\bplatform\x12?\n" +
-	"\x0flatest_policies\x18\x03 \x03(\v2\x16.consent.PendingPolicyR\x0elatestPolicies\"\x8c\x01\n" +
+	"\x0flatest_policies\x18\x03 \x03(\v2\x16.consent.PendingPolicyR\x0elatestPolicies\x126\n" +
+	"\x17resolve_active_policies\x18\x04 \x01(\bR\x15resolveActivePolicies\"\x8c\x01\n" +
 	"\x1cCheckPendingConsentsResponse\x12A\n" +
 	"\x10pending_policies\x18\x01 \x03(\v2\x16.consent.PendingPolicyR\x0fpendingPolicies\x12)\n" +
-	"\x10requires_consent\x18\x02 \x01(\bR\x0frequiresConsent\"}\n" +
+	"\x10requires_consent\x18\x02 \x01(\bR\x0frequiresConsent\"\xbd\x01\n" +
 	"\x14RevokeConsentRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vdocument_id\x18\x02 \x01(\tR\n" +
 	"documentId\x12+\n" +
-	"\x11version_timestamp\x18\x03 \x01(\x03R\x10versionTimestamp\"K\n" +
+	"\x11version_timestamp\x18\x03 \x01(\x03R\x10versionTimestamp\x12&\n" +
+	"\x0facting_admin_id\x18\x04 \x01(\tR\ractingAdminId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"K\n" +
 	"\x15RevokeConsentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"T\n" +
 	"\x18GetConsentHistoryRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vdocument_id\x18\x02 \x01(\tR\n" +
-	"documentId\"]\n" +
+	"documentId\"\x98\x01\n" +
 	"\x19GetConsentHistoryResponse\x12*\n" +
 	"\ahistory\x18\x01 \x03(\v2\x10.consent.ConsentR\ahistory\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"4\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x129\n" +
+	"\btimeline\x18\x03 \x03(\v2\x1d.consent.ConsentTimelineEventR\btimeline\"\x83\x02\n" +
+	"\x14ConsentTimelineEvent\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12#\n" +
+	"\rdocument_name\x18\x03 \x01(\tR\fdocumentName\x12+\n" +
+	"\x11version_timestamp\x18\x04 \x01(\x03R\x10versionTimestamp\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x0e\n" +
+	"\x02at\x18\x06 \x01(\x03R\x02at\x12\x19\n" +
+	"\bactor_id\x18\a \x01(\tR\aactorId\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\"4\n" +
 	"\x16GetConsentStatsRequest\x12\x1a\n" +
-	"\bplatform\x18\x01 \x01(\tR\bplatform\"\xa5\x05\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\"\xd2\x05\n" +
 	"\x17GetConsentStatsResponse\x12%\n" +
 	"\x0etotal_consents\x18\x01 \x01(\x05R\rtotalConsents\x12'\n" +
 	"\x0factive_consents\x18\x02 \x01(\x05R\x0eactiveConsents\x12)\n" +
 	"\x10revoked_consents\x18\x03 \x01(\x05R\x0frevokedConsents\x12j\n" +
 	"\x14consents_by_document\x18\x04 \x03(\v28.consent.GetConsentStatsResponse.ConsentsByDocumentEntryR\x12consentsByDocument\x12j\n" +
 	"\x14consents_by_platform\x18\x05 \x03(\v28.consent.GetConsentStatsResponse.ConsentsByPlatformEntryR\x12consentsByPlatform\x12d\n" +
-	"\x12consents_by_method\x18\x06 \x03(\v26.consent.GetConsentStatsResponse.ConsentsByMethodEntryR\x10consentsByMethod\x1aE\n" +
+	"\x12consents_by_method\x18\x06 \x03(\v26.consent.GetConsentStatsResponse.ConsentsByMethodEntryR\x10consentsByMethod\x12+\n" +
+	"\x11declined_consents\x18\a \x01(\x05R\x10declinedConsents\x1aE\n" +
 	"\x17ConsentsByDocumentEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1aE\n" +
@@ -1274,7 +3113,135 @@ const file_pkg_api_consent_consent_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1aC\n" +
 	"\x15ConsentsByMethodEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x012\xea\x04\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"4\n" +
+	"\x19VerifyConsentChainRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"p\n" +
+	"\n" +
+	"ChainIssue\x12\x15\n" +
+	"\x06seq_no\x18\x01 \x01(\x03R\x05seqNo\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x02 \x01(\tR\tconsentId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xb8\x01\n" +
+	"\x1aVerifyConsentChainResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12!\n" +
+	"\fchain_length\x18\x03 \x01(\x03R\vchainLength\x12\x1b\n" +
+	"\thead_hash\x18\x04 \x01(\tR\bheadHash\x12+\n" +
+	"\x06issues\x18\x05 \x03(\v2\x13.consent.ChainIssueR\x06issues\"?\n" +
+	"\x1aGetDocumentSnapshotRequest\x12!\n" +
+	"\fcontent_hash\x18\x01 \x01(\tR\vcontentHash\"\xf4\x01\n" +
+	"\x1bGetDocumentSnapshotResponse\x12!\n" +
+	"\fcontent_hash\x18\x01 \x01(\tR\vcontentHash\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x05 \x01(\tR\tsourceUrl\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x06 \x01(\x03R\tsizeBytes\x12\x1f\n" +
+	"\vcaptured_at\x18\a \x01(\x03R\n" +
+	"capturedAt\"\x8b\x04\n" +
+	"\x11ReconsentCampaign\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12#\n" +
+	"\rdocument_name\x18\x03 \x01(\tR\fdocumentName\x12\x1f\n" +
+	"\vdocument_id\x18\x04 \x01(\tR\n" +
+	"documentId\x12+\n" +
+	"\x11version_timestamp\x18\x05 \x01(\x03R\x10versionTimestamp\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12%\n" +
+	"\x0egrace_deadline\x18\a \x01(\x03R\rgraceDeadline\x12%\n" +
+	"\x0eaffected_users\x18\b \x01(\x05R\raffectedUsers\x12%\n" +
+	"\x0eaccepted_users\x18\t \x01(\x05R\racceptedUsers\x12#\n" +
+	"\rpending_users\x18\n" +
+	" \x01(\x05R\fpendingUsers\x12#\n" +
+	"\roverdue_users\x18\v \x01(\x05R\foverdueUsers\x12#\n" +
+	"\rsuperseded_by\x18\f \x01(\tR\fsupersededBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\r \x01(\tR\tupdatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\x03R\tupdatedAt\"\xa9\x01\n" +
+	"\x1dListReconsentCampaignsRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12#\n" +
+	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"\xac\x01\n" +
+	"\x1eListReconsentCampaignsResponse\x128\n" +
+	"\tcampaigns\x18\x01 \x03(\v2\x1a.consent.ReconsentCampaignR\tcampaigns\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"-\n" +
+	"\x1bGetReconsentCampaignRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"V\n" +
+	"\x1cGetReconsentCampaignResponse\x126\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x1a.consent.ReconsentCampaignR\bcampaign\"\x9e\x01\n" +
+	"\fCampaignUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12<\n" +
+	"\x1aprevious_version_timestamp\x18\x02 \x01(\x03R\x18previousVersionTimestamp\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vaccepted_at\x18\x04 \x01(\x03R\n" +
+	"acceptedAt\"\x84\x01\n" +
+	"\x18ListCampaignUsersRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x9a\x01\n" +
+	"\x19ListCampaignUsersResponse\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.consent.CampaignUserR\x05users\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x8a\x01\n" +
+	"\x1eUpdateReconsentCampaignRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0egrace_deadline\x18\x02 \x01(\x03R\rgraceDeadline\x12\x19\n" +
+	"\badmin_id\x18\x03 \x01(\tR\aadminId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"Y\n" +
+	"\x1fUpdateReconsentCampaignResponse\x126\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x1a.consent.ReconsentCampaignR\bcampaign\"O\n" +
+	"\x1aCheckPurposeConsentRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
+	"\apurpose\x18\x02 \x01(\tR\apurpose\"\x8b\x02\n" +
+	"\x1bCheckPurposeConsentResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12!\n" +
+	"\fhas_decision\x18\x02 \x01(\bR\vhasDecision\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x03 \x01(\tR\tconsentId\x12\x1f\n" +
+	"\vdocument_id\x18\x04 \x01(\tR\n" +
+	"documentId\x12#\n" +
+	"\rdocument_name\x18\x05 \x01(\tR\fdocumentName\x12+\n" +
+	"\x11version_timestamp\x18\x06 \x01(\x03R\x10versionTimestamp\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\a \x01(\x03R\tdecidedAt\"\xe4\x02\n" +
+	"\x15DeclineConsentRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12\x1f\n" +
+	"\vdocument_id\x18\x03 \x01(\tR\n" +
+	"documentId\x12#\n" +
+	"\rdocument_name\x18\x04 \x01(\tR\fdocumentName\x12+\n" +
+	"\x11version_timestamp\x18\x05 \x01(\x03R\x10versionTimestamp\x12%\n" +
+	"\x0econsent_method\x18\x06 \x01(\tR\rconsentMethod\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\a \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\b \x01(\tR\tuserAgent\x12\x16\n" +
+	"\x06reason\x18\t \x01(\tR\x06reason\x12&\n" +
+	"\x0facting_admin_id\x18\n" +
+	" \x01(\tR\ractingAdminId\"D\n" +
+	"\x16DeclineConsentResponse\x12*\n" +
+	"\aconsent\x18\x01 \x01(\v2\x10.consent.ConsentR\aconsent\"h\n" +
+	"\x18EraseUserConsentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\terased_by\x18\x02 \x01(\tR\berasedBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"l\n" +
+	"\x19EraseUserConsentsResponse\x12'\n" +
+	"\x0ferased_consents\x18\x01 \x01(\x05R\x0eerasedConsents\x12&\n" +
+	"\x0fchain_head_hash\x18\x02 \x01(\tR\rchainHeadHash2\xd6\v\n" +
 	"\x0eConsentService\x12N\n" +
 	"\rRecordConsent\x12\x1d.consent.RecordConsentRequest\x1a\x1e.consent.RecordConsentResponse\x12K\n" +
 	"\fCheckConsent\x12\x1c.consent.CheckConsentRequest\x1a\x1d.consent.CheckConsentResponse\x12T\n" +
@@ -1282,7 +3249,16 @@ const file_pkg_api_consent_consent_proto_rawDesc = "" +
 	"\x14CheckPendingConsents\x12$.consent.CheckPendingConsentsRequest\x1a%.consent.CheckPendingConsentsResponse\x12N\n" +
 	"\rRevokeConsent\x12\x1d.consent.RevokeConsentRequest\x1a\x1e.consent.RevokeConsentResponse\x12Z\n" +
 	"\x11GetConsentHistory\x12!.consent.GetConsentHistoryRequest\x1a\".consent.GetConsentHistoryResponse\x12T\n" +
-	"\x0fGetConsentStats\x12\x1f.consent.GetConsentStatsRequest\x1a .consent.GetConsentStatsResponseB<Z:github.com/thatlq1812/policy-system/shared/pkg/api/consentb\x06proto3"
+	"\x0fGetConsentStats\x12\x1f.consent.GetConsentStatsRequest\x1a .consent.GetConsentStatsResponse\x12]\n" +
+	"\x12VerifyConsentChain\x12\".consent.VerifyConsentChainRequest\x1a#.consent.VerifyConsentChainResponse\x12`\n" +
+	"\x13GetDocumentSnapshot\x12#.consent.GetDocumentSnapshotRequest\x1a$.consent.GetDocumentSnapshotResponse\x12i\n" +
+	"\x16ListReconsentCampaigns\x12&.consent.ListReconsentCampaignsRequest\x1a'.consent.ListReconsentCampaignsResponse\x12c\n" +
+	"\x14GetReconsentCampaign\x12$.consent.GetReconsentCampaignRequest\x1a%.consent.GetReconsentCampaignResponse\x12Z\n" +
+	"\x11ListCampaignUsers\x12!.consent.ListCampaignUsersRequest\x1a\".consent.ListCampaignUsersResponse\x12l\n" +
+	"\x17UpdateReconsentCampaign\x12'.consent.UpdateReconsentCampaignRequest\x1a(.consent.UpdateReconsentCampaignResponse\x12Q\n" +
+	"\x0eDeclineConsent\x12\x1e.consent.DeclineConsentRequest\x1a\x1f.consent.DeclineConsentResponse\x12`\n" +
+	"\x13CheckPurposeConsent\x12#.consent.CheckPurposeConsentRequest\x1a$.consent.CheckPurposeConsentResponse\x12Z\n" +
+	"\x11EraseUserConsents\x12!.consent.EraseUserConsentsRequest\x1a\".consent.EraseUserConsentsResponseB<Z:github.com/thatlq1812/policy-system/shared/pkg/api/consentb\x06proto3"
 
 var (
 	file_pkg_api_consent_consent_proto_rawDescOnce sync.Once
@@ -1296,59 +3272,109 @@ func file_pkg_api_consent_consent_proto_rawDescGZIP() []byte {
 	return file_pkg_api_consent_consent_proto_rawDescData
 }
 
-var file_pkg_api_consent_consent_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_pkg_api_consent_consent_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_pkg_api_consent_consent_proto_goTypes = []any{
-	(*Consent)(nil),                      // 0: consent.Consent
-	(*ConsentInput)(nil),                 // 1: consent.ConsentInput
-	(*RecordConsentRequest)(nil),         // 2: consent.RecordConsentRequest
-	(*RecordConsentResponse)(nil),        // 3: consent.RecordConsentResponse
-	(*CheckConsentRequest)(nil),          // 4: consent.CheckConsentRequest
-	(*CheckConsentResponse)(nil),         // 5: consent.CheckConsentResponse
-	(*GetUserConsentsRequest)(nil),       // 6: consent.GetUserConsentsRequest
-	(*GetUserConsentsResponse)(nil),      // 7: consent.GetUserConsentsResponse
-	(*PendingPolicy)(nil),                // 8: consent.PendingPolicy
-	(*CheckPendingConsentsRequest)(nil),  // 9: consent.CheckPendingConsentsRequest
-	(*CheckPendingConsentsResponse)(nil), // 10: consent.CheckPendingConsentsResponse
-	(*RevokeConsentRequest)(nil),         // 11: consent.RevokeConsentRequest
-	(*RevokeConsentResponse)(nil),        // 12: consent.RevokeConsentResponse
-	(*GetConsentHistoryRequest)(nil),     // 13: consent.GetConsentHistoryRequest
-	(*GetConsentHistoryResponse)(nil),    // 14: consent.GetConsentHistoryResponse
-	(*GetConsentStatsRequest)(nil),       // 15: consent.GetConsentStatsRequest
-	(*GetConsentStatsResponse)(nil),      // 16: consent.GetConsentStatsResponse
-	nil,                                  // 17: consent.GetConsentStatsResponse.ConsentsByDocumentEntry
-	nil,                                  // 18: consent.GetConsentStatsResponse.ConsentsByPlatformEntry
-	nil,                                  // 19: consent.GetConsentStatsResponse.ConsentsByMethodEntry
+	(*Consent)(nil),                         // 0: consent.Consent
+	(*ConsentInput)(nil),                    // 1: consent.ConsentInput
+	(*PurposeChoice)(nil),                   // 2: consent.PurposeChoice
+	(*RecordConsentRequest)(nil),            // 3: consent.RecordConsentRequest
+	(*RecordConsentResponse)(nil),           // 4: consent.RecordConsentResponse
+	(*CheckConsentRequest)(nil),             // 5: consent.CheckConsentRequest
+	(*CheckConsentResponse)(nil),            // 6: consent.CheckConsentResponse
+	(*GetUserConsentsRequest)(nil),          // 7: consent.GetUserConsentsRequest
+	(*GetUserConsentsResponse)(nil),         // 8: consent.GetUserConsentsResponse
+	(*PendingPolicy)(nil),                   // 9: consent.PendingPolicy
+	(*CheckPendingConsentsRequest)(nil),     // 10: consent.CheckPendingConsentsRequest
+	(*CheckPendingConsentsResponse)(nil),    // 11: consent.CheckPendingConsentsResponse
+	(*RevokeConsentRequest)(nil),            // 12: consent.RevokeConsentRequest
+	(*RevokeConsentResponse)(nil),           // 13: consent.RevokeConsentResponse
+	(*GetConsentHistoryRequest)(nil),        // 14: consent.GetConsentHistoryRequest
+	(*GetConsentHistoryResponse)(nil),       // 15: consent.GetConsentHistoryResponse
+	(*ConsentTimelineEvent)(nil),            // 16: consent.ConsentTimelineEvent
+	(*GetConsentStatsRequest)(nil),          // 17: consent.GetConsentStatsRequest
+	(*GetConsentStatsResponse)(nil),         // 18: consent.GetConsentStatsResponse
+	(*VerifyConsentChainRequest)(nil),       // 19: consent.VerifyConsentChainRequest
+	(*ChainIssue)(nil),                      // 20: consent.ChainIssue
+	(*VerifyConsentChainResponse)(nil),      // 21: consent.VerifyConsentChainResponse
+	(*GetDocumentSnapshotRequest)(nil),      // 22: consent.GetDocumentSnapshotRequest
+	(*GetDocumentSnapshotResponse)(nil),     // 23: consent.GetDocumentSnapshotResponse
+	(*ReconsentCampaign)(nil),               // 24: consent.ReconsentCampaign
+	(*ListReconsentCampaignsRequest)(nil),   // 25: consent.ListReconsentCampaignsRequest
+	(*ListReconsentCampaignsResponse)(nil),  // 26: consent.ListReconsentCampaignsResponse
+	(*GetReconsentCampaignRequest)(nil),     // 27: consent.GetReconsentCampaignRequest
+	(*GetReconsentCampaignResponse)(nil),    // 28: consent.GetReconsentCampaignResponse
+	(*CampaignUser)(nil),                    // 29: consent.CampaignUser
+	(*ListCampaignUsersRequest)(nil),        // 30: consent.ListCampaignUsersRequest
+	(*ListCampaignUsersResponse)(nil),       // 31: consent.ListCampaignUsersResponse
+	(*UpdateReconsentCampaignRequest)(nil),  // 32: consent.UpdateReconsentCampaignRequest
+	(*UpdateReconsentCampaignResponse)(nil), // 33: consent.UpdateReconsentCampaignResponse
+	(*CheckPurposeConsentRequest)(nil),      // 34: consent.CheckPurposeConsentRequest
+	(*CheckPurposeConsentResponse)(nil),     // 35: consent.CheckPurposeConsentResponse
+	(*DeclineConsentRequest)(nil),           // 36: consent.DeclineConsentRequest
+	(*DeclineConsentResponse)(nil),          // 37: consent.DeclineConsentResponse
+	(*EraseUserConsentsRequest)(nil),        // 38: consent.EraseUserConsentsRequest
+	(*EraseUserConsentsResponse)(nil),       // 39: consent.EraseUserConsentsResponse
+	nil,                                     // 40: consent.GetConsentStatsResponse.ConsentsByDocumentEntry
+	nil,                                     // 41: consent.GetConsentStatsResponse.ConsentsByPlatformEntry
+	nil,                                     // 42: consent.GetConsentStatsResponse.ConsentsByMethodEntry
 }
 var file_pkg_api_consent_consent_proto_depIdxs = []int32{
-	1,  // 0: consent.RecordConsentRequest.consents:type_name -> consent.ConsentInput
-	0,  // 1: consent.RecordConsentResponse.consents:type_name -> consent.Consent
-	0,  // 2: consent.CheckConsentResponse.latest_consent:type_name -> consent.Consent
-	0,  // 3: consent.GetUserConsentsResponse.consents:type_name -> consent.Consent
-	8,  // 4: consent.CheckPendingConsentsRequest.latest_policies:type_name -> consent.PendingPolicy
-	8,  // 5: consent.CheckPendingConsentsResponse.pending_policies:type_name -> consent.PendingPolicy
-	0,  // 6: consent.GetConsentHistoryResponse.history:type_name -> consent.Consent
-	17, // 7: consent.GetConsentStatsResponse.consents_by_document:type_name -> consent.GetConsentStatsResponse.ConsentsByDocumentEntry
-	18, // 8: consent.GetConsentStatsResponse.consents_by_platform:type_name -> consent.GetConsentStatsResponse.ConsentsByPlatformEntry
-	19, // 9: consent.GetConsentStatsResponse.consents_by_method:type_name -> consent.GetConsentStatsResponse.ConsentsByMethodEntry
-	2,  // 10: consent.ConsentService.RecordConsent:input_type -> consent.RecordConsentRequest
-	4,  // 11: consent.ConsentService.CheckConsent:input_type -> consent.CheckConsentRequest
-	6,  // 12: consent.ConsentService.GetUserConsents:input_type -> consent.GetUserConsentsRequest
-	9,  // 13: consent.ConsentService.CheckPendingConsents:input_type -> consent.CheckPendingConsentsRequest
-	11, // 14: consent.ConsentService.RevokeConsent:input_type -> consent.RevokeConsentRequest
-	13, // 15: consent.ConsentService.GetConsentHistory:input_type -> consent.GetConsentHistoryRequest
-	15, // 16: consent.ConsentService.GetConsentStats:input_type -> consent.GetConsentStatsRequest
-	3,  // 17: consent.ConsentService.RecordConsent:output_type -> consent.RecordConsentResponse
-	5,  // 18: consent.ConsentService.CheckConsent:output_type -> consent.CheckConsentResponse
-	7,  // 19: consent.ConsentService.GetUserConsents:output_type -> consent.GetUserConsentsResponse
-	10, // 20: consent.ConsentService.CheckPendingConsents:output_type -> consent.CheckPendingConsentsResponse
-	12, // 21: consent.ConsentService.RevokeConsent:output_type -> consent.RevokeConsentResponse
-	14, // 22: consent.ConsentService.GetConsentHistory:output_type -> consent.GetConsentHistoryResponse
-	16, // 23: consent.ConsentService.GetConsentStats:output_type -> consent.GetConsentStatsResponse
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	2,  // 0: consent.Consent.purposes:type_name -> consent.PurposeChoice
+	2,  // 1: consent.ConsentInput.purposes:type_name -> consent.PurposeChoice
+	1,  // 2: consent.RecordConsentRequest.consents:type_name -> consent.ConsentInput
+	0,  // 3: consent.RecordConsentResponse.consents:type_name -> consent.Consent
+	0,  // 4: consent.CheckConsentResponse.latest_consent:type_name -> consent.Consent
+	0,  // 5: consent.GetUserConsentsResponse.consents:type_name -> consent.Consent
+	9,  // 6: consent.CheckPendingConsentsRequest.latest_policies:type_name -> consent.PendingPolicy
+	9,  // 7: consent.CheckPendingConsentsResponse.pending_policies:type_name -> consent.PendingPolicy
+	0,  // 8: consent.GetConsentHistoryResponse.history:type_name -> consent.Consent
+	16, // 9: consent.GetConsentHistoryResponse.timeline:type_name -> consent.ConsentTimelineEvent
+	40, // 10: consent.GetConsentStatsResponse.consents_by_document:type_name -> consent.GetConsentStatsResponse.ConsentsByDocumentEntry
+	41, // 11: consent.GetConsentStatsResponse.consents_by_platform:type_name -> consent.GetConsentStatsResponse.ConsentsByPlatformEntry
+	42, // 12: consent.GetConsentStatsResponse.consents_by_method:type_name -> consent.GetConsentStatsResponse.ConsentsByMethodEntry
+	20, // 13: consent.VerifyConsentChainResponse.issues:type_name -> consent.ChainIssue
+	24, // 14: consent.ListReconsentCampaignsResponse.campaigns:type_name -> consent.ReconsentCampaign
+	24, // 15: consent.GetReconsentCampaignResponse.campaign:type_name -> consent.ReconsentCampaign
+	29, // 16: consent.ListCampaignUsersResponse.users:type_name -> consent.CampaignUser
+	24, // 17: consent.UpdateReconsentCampaignResponse.campaign:type_name -> consent.ReconsentCampaign
+	0,  // 18: consent.DeclineConsentResponse.consent:type_name -> consent.Consent
+	3,  // 19: consent.ConsentService.RecordConsent:input_type -> consent.RecordConsentRequest
+	5,  // 20: consent.ConsentService.CheckConsent:input_type -> consent.CheckConsentRequest
+	7,  // 21: consent.ConsentService.GetUserConsents:input_type -> consent.GetUserConsentsRequest
+	10, // 22: consent.ConsentService.CheckPendingConsents:input_type -> consent.CheckPendingConsentsRequest
+	12, // 23: consent.ConsentService.RevokeConsent:input_type -> consent.RevokeConsentRequest
+	14, // 24: consent.ConsentService.GetConsentHistory:input_type -> consent.GetConsentHistoryRequest
+	17, // 25: consent.ConsentService.GetConsentStats:input_type -> consent.GetConsentStatsRequest
+	19, // 26: consent.ConsentService.VerifyConsentChain:input_type -> consent.VerifyConsentChainRequest
+	22, // 27: consent.ConsentService.GetDocumentSnapshot:input_type -> consent.GetDocumentSnapshotRequest
+	25, // 28: consent.ConsentService.ListReconsentCampaigns:input_type -> consent.ListReconsentCampaignsRequest
+	27, // 29: consent.ConsentService.GetReconsentCampaign:input_type -> consent.GetReconsentCampaignRequest
+	30, // 30: consent.ConsentService.ListCampaignUsers:input_type -> consent.ListCampaignUsersRequest
+	32, // 31: consent.ConsentService.UpdateReconsentCampaign:input_type -> consent.UpdateReconsentCampaignRequest
+	36, // 32: consent.ConsentService.DeclineConsent:input_type -> consent.DeclineConsentRequest
+	34, // 33: consent.ConsentService.CheckPurposeConsent:input_type -> consent.CheckPurposeConsentRequest
+	38, // 34: consent.ConsentService.EraseUserConsents:input_type -> consent.EraseUserConsentsRequest
+	4,  // 35: consent.ConsentService.RecordConsent:output_type -> consent.RecordConsentResponse
+	6,  // 36: consent.ConsentService.CheckConsent:output_type -> consent.CheckConsentResponse
+	8,  // 37: consent.ConsentService.GetUserConsents:output_type -> consent.GetUserConsentsResponse
+	11, // 38: consent.ConsentService.CheckPendingConsents:output_type -> consent.CheckPendingConsentsResponse
+	13, // 39: consent.ConsentService.RevokeConsent:output_type -> consent.RevokeConsentResponse
+	15, // 40: consent.ConsentService.GetConsentHistory:output_type -> consent.GetConsentHistoryResponse
+	18, // 41: consent.ConsentService.GetConsentStats:output_type -> consent.GetConsentStatsResponse
+	21, // 42: consent.ConsentService.VerifyConsentChain:output_type -> consent.VerifyConsentChainResponse
+	23, // 43: consent.ConsentService.GetDocumentSnapshot:output_type -> consent.GetDocumentSnapshotResponse
+	26, // 44: consent.ConsentService.ListReconsentCampaigns:output_type -> consent.ListReconsentCampaignsResponse
+	28, // 45: consent.ConsentService.GetReconsentCampaign:output_type -> consent.GetReconsentCampaignResponse
+	31, // 46: consent.ConsentService.ListCampaignUsers:output_type -> consent.ListCampaignUsersResponse
+	33, // 47: consent.ConsentService.UpdateReconsentCampaign:output_type -> consent.UpdateReconsentCampaignResponse
+	37, // 48: consent.ConsentService.DeclineConsent:output_type -> consent.DeclineConsentResponse
+	35, // 49: consent.ConsentService.CheckPurposeConsent:output_type -> consent.CheckPurposeConsentResponse
+	39, // 50: consent.ConsentService.EraseUserConsents:output_type -> consent.EraseUserConsentsResponse
+	35, // [35:51] is the sub-list for method output_type
+	19, // [19:35] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_pkg_api_consent_consent_proto_init() }
@@ -1362,7 +3388,7 @@ func file_pkg_api_consent_consent_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_consent_consent_proto_rawDesc), len(file_pkg_api_consent_consent_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
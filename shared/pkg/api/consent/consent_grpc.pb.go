@@ -19,13 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ConsentService_RecordConsent_FullMethodName        = "/consent.ConsentService/RecordConsent"
-	ConsentService_CheckConsent_FullMethodName         = "/consent.ConsentService/CheckConsent"
-	ConsentService_GetUserConsents_FullMethodName      = "/consent.ConsentService/GetUserConsents"
-	ConsentService_CheckPendingConsents_FullMethodName = "/consent.ConsentService/CheckPendingConsents"
-	ConsentService_RevokeConsent_FullMethodName        = "/consent.ConsentService/RevokeConsent"
-	ConsentService_GetConsentHistory_FullMethodName    = "/consent.ConsentService/GetConsentHistory"
-	ConsentService_GetConsentStats_FullMethodName      = "/consent.ConsentService/GetConsentStats"
+	ConsentService_RecordConsent_FullMethodName           = "/consent.ConsentService/RecordConsent"
+	ConsentService_CheckConsent_FullMethodName            = "/consent.ConsentService/CheckConsent"
+	ConsentService_GetUserConsents_FullMethodName         = "/consent.ConsentService/GetUserConsents"
+	ConsentService_CheckPendingConsents_FullMethodName    = "/consent.ConsentService/CheckPendingConsents"
+	ConsentService_RevokeConsent_FullMethodName           = "/consent.ConsentService/RevokeConsent"
+	ConsentService_GetConsentHistory_FullMethodName       = "/consent.ConsentService/GetConsentHistory"
+	ConsentService_GetConsentStats_FullMethodName         = "/consent.ConsentService/GetConsentStats"
+	ConsentService_VerifyConsentChain_FullMethodName      = "/consent.ConsentService/VerifyConsentChain"
+	ConsentService_GetDocumentSnapshot_FullMethodName     = "/consent.ConsentService/GetDocumentSnapshot"
+	ConsentService_ListReconsentCampaigns_FullMethodName  = "/consent.ConsentService/ListReconsentCampaigns"
+	ConsentService_GetReconsentCampaign_FullMethodName    = "/consent.ConsentService/GetReconsentCampaign"
+	ConsentService_ListCampaignUsers_FullMethodName       = "/consent.ConsentService/ListCampaignUsers"
+	ConsentService_UpdateReconsentCampaign_FullMethodName = "/consent.ConsentService/UpdateReconsentCampaign"
+	ConsentService_DeclineConsent_FullMethodName          = "/consent.ConsentService/DeclineConsent"
+	ConsentService_CheckPurposeConsent_FullMethodName     = "/consent.ConsentService/CheckPurposeConsent"
+	ConsentService_EraseUserConsents_FullMethodName       = "/consent.ConsentService/EraseUserConsents"
 )
 
 // ConsentServiceClient is the client API for ConsentService service.
@@ -48,6 +57,21 @@ type ConsentServiceClient interface {
 	GetConsentHistory(ctx context.Context, in *GetConsentHistoryRequest, opts ...grpc.CallOption) (*GetConsentHistoryResponse, error)
 	// Phase 4: Get consent statistics
 	GetConsentStats(ctx context.Context, in *GetConsentStatsRequest, opts ...grpc.CallOption) (*GetConsentStatsResponse, error)
+	// Kiểm tra tính toàn vẹn của consent hash chain của user
+	VerifyConsentChain(ctx context.Context, in *VerifyConsentChainRequest, opts ...grpc.CallOption) (*VerifyConsentChainResponse, error)
+	// Lấy snapshot nội dung document user đã đồng ý (theo document_content_hash)
+	GetDocumentSnapshot(ctx context.Context, in *GetDocumentSnapshotRequest, opts ...grpc.CallOption) (*GetDocumentSnapshotResponse, error)
+	// Re-consent campaigns: tiến độ đồng ý lại version bắt buộc mới (Admin)
+	ListReconsentCampaigns(ctx context.Context, in *ListReconsentCampaignsRequest, opts ...grpc.CallOption) (*ListReconsentCampaignsResponse, error)
+	GetReconsentCampaign(ctx context.Context, in *GetReconsentCampaignRequest, opts ...grpc.CallOption) (*GetReconsentCampaignResponse, error)
+	ListCampaignUsers(ctx context.Context, in *ListCampaignUsersRequest, opts ...grpc.CallOption) (*ListCampaignUsersResponse, error)
+	UpdateReconsentCampaign(ctx context.Context, in *UpdateReconsentCampaignRequest, opts ...grpc.CallOption) (*UpdateReconsentCampaignResponse, error)
+	// Ghi lại việc user chủ động từ chối policy không bắt buộc
+	DeclineConsent(ctx context.Context, in *DeclineConsentRequest, opts ...grpc.CallOption) (*DeclineConsentResponse, error)
+	// Lựa chọn mới nhất của user với 1 mục đích xử lý dữ liệu (vd: marketing)
+	CheckPurposeConsent(ctx context.Context, in *CheckPurposeConsentRequest, opts ...grpc.CallOption) (*CheckPurposeConsentResponse, error)
+	// Xóa IP/user agent khỏi mọi consent của user (right to erasure), giữ bằng chứng đã ẩn danh
+	EraseUserConsents(ctx context.Context, in *EraseUserConsentsRequest, opts ...grpc.CallOption) (*EraseUserConsentsResponse, error)
 }
 
 type consentServiceClient struct {
@@ -128,6 +152,96 @@ func (c *consentServiceClient) GetConsentStats(ctx context.Context, in *GetConse
 	return out, nil
 }
 
+func (c *consentServiceClient) VerifyConsentChain(ctx context.Context, in *VerifyConsentChainRequest, opts ...grpc.CallOption) (*VerifyConsentChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyConsentChainResponse)
+	err := c.cc.Invoke(ctx, ConsentService_VerifyConsentChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) GetDocumentSnapshot(ctx context.Context, in *GetDocumentSnapshotRequest, opts ...grpc.CallOption) (*GetDocumentSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDocumentSnapshotResponse)
+	err := c.cc.Invoke(ctx, ConsentService_GetDocumentSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) ListReconsentCampaigns(ctx context.Context, in *ListReconsentCampaignsRequest, opts ...grpc.CallOption) (*ListReconsentCampaignsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReconsentCampaignsResponse)
+	err := c.cc.Invoke(ctx, ConsentService_ListReconsentCampaigns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) GetReconsentCampaign(ctx context.Context, in *GetReconsentCampaignRequest, opts ...grpc.CallOption) (*GetReconsentCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReconsentCampaignResponse)
+	err := c.cc.Invoke(ctx, ConsentService_GetReconsentCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) ListCampaignUsers(ctx context.Context, in *ListCampaignUsersRequest, opts ...grpc.CallOption) (*ListCampaignUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCampaignUsersResponse)
+	err := c.cc.Invoke(ctx, ConsentService_ListCampaignUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) UpdateReconsentCampaign(ctx context.Context, in *UpdateReconsentCampaignRequest, opts ...grpc.CallOption) (*UpdateReconsentCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateReconsentCampaignResponse)
+	err := c.cc.Invoke(ctx, ConsentService_UpdateReconsentCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) DeclineConsent(ctx context.Context, in *DeclineConsentRequest, opts ...grpc.CallOption) (*DeclineConsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeclineConsentResponse)
+	err := c.cc.Invoke(ctx, ConsentService_DeclineConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) CheckPurposeConsent(ctx context.Context, in *CheckPurposeConsentRequest, opts ...grpc.CallOption) (*CheckPurposeConsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPurposeConsentResponse)
+	err := c.cc.Invoke(ctx, ConsentService_CheckPurposeConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) EraseUserConsents(ctx context.Context, in *EraseUserConsentsRequest, opts ...grpc.CallOption) (*EraseUserConsentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseUserConsentsResponse)
+	err := c.cc.Invoke(ctx, ConsentService_EraseUserConsents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConsentServiceServer is the server API for ConsentService service.
 // All implementations must embed UnimplementedConsentServiceServer
 // for forward compatibility.
@@ -148,6 +262,21 @@ type ConsentServiceServer interface {
 	GetConsentHistory(context.Context, *GetConsentHistoryRequest) (*GetConsentHistoryResponse, error)
 	// Phase 4: Get consent statistics
 	GetConsentStats(context.Context, *GetConsentStatsRequest) (*GetConsentStatsResponse, error)
+	// Kiểm tra tính toàn vẹn của consent hash chain của user
+	VerifyConsentChain(context.Context, *VerifyConsentChainRequest) (*VerifyConsentChainResponse, error)
+	// Lấy snapshot nội dung document user đã đồng ý (theo document_content_hash)
+	GetDocumentSnapshot(context.Context, *GetDocumentSnapshotRequest) (*GetDocumentSnapshotResponse, error)
+	// Re-consent campaigns: tiến độ đồng ý lại version bắt buộc mới (Admin)
+	ListReconsentCampaigns(context.Context, *ListReconsentCampaignsRequest) (*ListReconsentCampaignsResponse, error)
+	GetReconsentCampaign(context.Context, *GetReconsentCampaignRequest) (*GetReconsentCampaignResponse, error)
+	ListCampaignUsers(context.Context, *ListCampaignUsersRequest) (*ListCampaignUsersResponse, error)
+	UpdateReconsentCampaign(context.Context, *UpdateReconsentCampaignRequest) (*UpdateReconsentCampaignResponse, error)
+	// Ghi lại việc user chủ động từ chối policy không bắt buộc
+	DeclineConsent(context.Context, *DeclineConsentRequest) (*DeclineConsentResponse, error)
+	// Lựa chọn mới nhất của user với 1 mục đích xử lý dữ liệu (vd: marketing)
+	CheckPurposeConsent(context.Context, *CheckPurposeConsentRequest) (*CheckPurposeConsentResponse, error)
+	// Xóa IP/user agent khỏi mọi consent của user (right to erasure), giữ bằng chứng đã ẩn danh
+	EraseUserConsents(context.Context, *EraseUserConsentsRequest) (*EraseUserConsentsResponse, error)
 	mustEmbedUnimplementedConsentServiceServer()
 }
 
@@ -179,6 +308,33 @@ func (UnimplementedConsentServiceServer) GetConsentHistory(context.Context, *Get
 func (UnimplementedConsentServiceServer) GetConsentStats(context.Context, *GetConsentStatsRequest) (*GetConsentStatsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetConsentStats not implemented")
 }
+func (UnimplementedConsentServiceServer) VerifyConsentChain(context.Context, *VerifyConsentChainRequest) (*VerifyConsentChainResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyConsentChain not implemented")
+}
+func (UnimplementedConsentServiceServer) GetDocumentSnapshot(context.Context, *GetDocumentSnapshotRequest) (*GetDocumentSnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDocumentSnapshot not implemented")
+}
+func (UnimplementedConsentServiceServer) ListReconsentCampaigns(context.Context, *ListReconsentCampaignsRequest) (*ListReconsentCampaignsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReconsentCampaigns not implemented")
+}
+func (UnimplementedConsentServiceServer) GetReconsentCampaign(context.Context, *GetReconsentCampaignRequest) (*GetReconsentCampaignResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReconsentCampaign not implemented")
+}
+func (UnimplementedConsentServiceServer) ListCampaignUsers(context.Context, *ListCampaignUsersRequest) (*ListCampaignUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCampaignUsers not implemented")
+}
+func (UnimplementedConsentServiceServer) UpdateReconsentCampaign(context.Context, *UpdateReconsentCampaignRequest) (*UpdateReconsentCampaignResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateReconsentCampaign not implemented")
+}
+func (UnimplementedConsentServiceServer) DeclineConsent(context.Context, *DeclineConsentRequest) (*DeclineConsentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeclineConsent not implemented")
+}
+func (UnimplementedConsentServiceServer) CheckPurposeConsent(context.Context, *CheckPurposeConsentRequest) (*CheckPurposeConsentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckPurposeConsent not implemented")
+}
+func (UnimplementedConsentServiceServer) EraseUserConsents(context.Context, *EraseUserConsentsRequest) (*EraseUserConsentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EraseUserConsents not implemented")
+}
 func (UnimplementedConsentServiceServer) mustEmbedUnimplementedConsentServiceServer() {}
 func (UnimplementedConsentServiceServer) testEmbeddedByValue()                        {}
 
@@ -326,6 +482,168 @@ func _ConsentService_GetConsentStats_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConsentService_VerifyConsentChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyConsentChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).VerifyConsentChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_VerifyConsentChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).VerifyConsentChain(ctx, req.(*VerifyConsentChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_GetDocumentSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).GetDocumentSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_GetDocumentSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).GetDocumentSnapshot(ctx, req.(*GetDocumentSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_ListReconsentCampaigns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReconsentCampaignsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).ListReconsentCampaigns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_ListReconsentCampaigns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).ListReconsentCampaigns(ctx, req.(*ListReconsentCampaignsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_GetReconsentCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReconsentCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).GetReconsentCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_GetReconsentCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).GetReconsentCampaign(ctx, req.(*GetReconsentCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_ListCampaignUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCampaignUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).ListCampaignUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_ListCampaignUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).ListCampaignUsers(ctx, req.(*ListCampaignUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_UpdateReconsentCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReconsentCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).UpdateReconsentCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_UpdateReconsentCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).UpdateReconsentCampaign(ctx, req.(*UpdateReconsentCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_DeclineConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclineConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).DeclineConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_DeclineConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).DeclineConsent(ctx, req.(*DeclineConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_CheckPurposeConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPurposeConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).CheckPurposeConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_CheckPurposeConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).CheckPurposeConsent(ctx, req.(*CheckPurposeConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_EraseUserConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserConsentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).EraseUserConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConsentService_EraseUserConsents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).EraseUserConsents(ctx, req.(*EraseUserConsentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConsentService_ServiceDesc is the grpc.ServiceDesc for ConsentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -361,6 +679,42 @@ var ConsentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConsentStats",
 			Handler:    _ConsentService_GetConsentStats_Handler,
 		},
+		{
+			MethodName: "VerifyConsentChain",
+			Handler:    _ConsentService_VerifyConsentChain_Handler,
+		},
+		{
+			MethodName: "GetDocumentSnapshot",
+			Handler:    _ConsentService_GetDocumentSnapshot_Handler,
+		},
+		{
+			MethodName: "ListReconsentCampaigns",
+			Handler:    _ConsentService_ListReconsentCampaigns_Handler,
+		},
+		{
+			MethodName: "GetReconsentCampaign",
+			Handler:    _ConsentService_GetReconsentCampaign_Handler,
+		},
+		{
+			MethodName: "ListCampaignUsers",
+			Handler:    _ConsentService_ListCampaignUsers_Handler,
+		},
+		{
+			MethodName: "UpdateReconsentCampaign",
+			Handler:    _ConsentService_UpdateReconsentCampaign_Handler,
+		},
+		{
+			MethodName: "DeclineConsent",
+			Handler:    _ConsentService_DeclineConsent_Handler,
+		},
+		{
+			MethodName: "CheckPurposeConsent",
+			Handler:    _ConsentService_CheckPurposeConsent_Handler,
+		},
+		{
+			MethodName: "EraseUserConsents",
+			Handler:    _ConsentService_EraseUserConsents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/api/consent/consent.proto",
@@ -22,18 +22,31 @@ const (
 )
 
 type PolicyDocument struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	DocumentName       string                 `protobuf:"bytes,2,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
-	Platform           string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
-	IsMandatory        bool                   `protobuf:"varint,4,opt,name=is_mandatory,json=isMandatory,proto3" json:"is_mandatory,omitempty"`
-	EffectiveTimestamp int64                  `protobuf:"varint,5,opt,name=effective_timestamp,json=effectiveTimestamp,proto3" json:"effective_timestamp,omitempty"`
-	ContentHtml        string                 `protobuf:"bytes,6,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`
-	FileUrl            string                 `protobuf:"bytes,7,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
-	CreatedAt          int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	CreatedBy          string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DocumentName          string                 `protobuf:"bytes,2,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	Platform              string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	IsMandatory           bool                   `protobuf:"varint,4,opt,name=is_mandatory,json=isMandatory,proto3" json:"is_mandatory,omitempty"`
+	EffectiveTimestamp    int64                  `protobuf:"varint,5,opt,name=effective_timestamp,json=effectiveTimestamp,proto3" json:"effective_timestamp,omitempty"`
+	ContentHtml           string                 `protobuf:"bytes,6,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`
+	FileUrl               string                 `protobuf:"bytes,7,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	CreatedAt             int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy             string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Status                string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`                               // "draft", "pending_review", "rejected", "scheduled" hoặc "published"
+	PublishedAt           int64                  `protobuf:"varint,11,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"` // 0 nếu chưa phát hành
+	PublishedBy           string                 `protobuf:"bytes,12,opt,name=published_by,json=publishedBy,proto3" json:"published_by,omitempty"`  // Admin đã phát hành/lên lịch
+	SubmittedBy           string                 `protobuf:"bytes,13,opt,name=submitted_by,json=submittedBy,proto3" json:"submitted_by,omitempty"`  // Admin gửi duyệt
+	SubmittedAt           int64                  `protobuf:"varint,14,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	ReviewedBy            string                 `protobuf:"bytes,15,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"` // Admin duyệt/từ chối (khác người tạo)
+	ReviewedAt            int64                  `protobuf:"varint,16,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	ReviewComment         string                 `protobuf:"bytes,17,opt,name=review_comment,json=reviewComment,proto3" json:"review_comment,omitempty"`
+	FileHash              string                 `protobuf:"bytes,18,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`                                           // SHA-256 của file đã upload qua UploadFile (rỗng nếu dùng file_url/content_html)
+	Locale                string                 `protobuf:"bytes,19,opt,name=locale,proto3" json:"locale,omitempty"`                                                               // Locale của content_html/file trả về (sau khi fallback)
+	AvailableLocales      []string               `protobuf:"bytes,20,rep,name=available_locales,json=availableLocales,proto3" json:"available_locales,omitempty"`                   // Các locale version này có nội dung
+	ConsentValidityPeriod int32                  `protobuf:"varint,21,opt,name=consent_validity_period,json=consentValidityPeriod,proto3" json:"consent_validity_period,omitempty"` // Số ngày consent còn hiệu lực trước khi phải xác nhận lại, 0 = không hết hạn
+	Purposes              []*PolicyPurpose       `protobuf:"bytes,22,rep,name=purposes,proto3" json:"purposes,omitempty"`                                                           // Mục đích xử lý dữ liệu user đồng ý/từ chối riêng (rỗng = đồng ý cả document)
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *PolicyDocument) Reset() {
@@ -129,22 +142,249 @@ func (x *PolicyDocument) GetCreatedBy() string {
 	return ""
 }
 
+func (x *PolicyDocument) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PolicyDocument) GetPublishedAt() int64 {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return 0
+}
+
+func (x *PolicyDocument) GetPublishedBy() string {
+	if x != nil {
+		return x.PublishedBy
+	}
+	return ""
+}
+
+func (x *PolicyDocument) GetSubmittedBy() string {
+	if x != nil {
+		return x.SubmittedBy
+	}
+	return ""
+}
+
+func (x *PolicyDocument) GetSubmittedAt() int64 {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return 0
+}
+
+func (x *PolicyDocument) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *PolicyDocument) GetReviewedAt() int64 {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return 0
+}
+
+func (x *PolicyDocument) GetReviewComment() string {
+	if x != nil {
+		return x.ReviewComment
+	}
+	return ""
+}
+
+func (x *PolicyDocument) GetFileHash() string {
+	if x != nil {
+		return x.FileHash
+	}
+	return ""
+}
+
+func (x *PolicyDocument) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PolicyDocument) GetAvailableLocales() []string {
+	if x != nil {
+		return x.AvailableLocales
+	}
+	return nil
+}
+
+func (x *PolicyDocument) GetConsentValidityPeriod() int32 {
+	if x != nil {
+		return x.ConsentValidityPeriod
+	}
+	return 0
+}
+
+func (x *PolicyDocument) GetPurposes() []*PolicyPurpose {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+// Mục đích xử lý dữ liệu trong một version (vd: "marketing", "analytics")
+type PolicyPurpose struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // Định danh ổn định giữa các version: chữ thường, số, "_"
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	IsRequired    bool                   `protobuf:"varint,4,opt,name=is_required,json=isRequired,proto3" json:"is_required,omitempty"` // true = không thể từ chối khi đồng ý document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyPurpose) Reset() {
+	*x = PolicyPurpose{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyPurpose) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyPurpose) ProtoMessage() {}
+
+func (x *PolicyPurpose) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyPurpose.ProtoReflect.Descriptor instead.
+func (*PolicyPurpose) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PolicyPurpose) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PolicyPurpose) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PolicyPurpose) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PolicyPurpose) GetIsRequired() bool {
+	if x != nil {
+		return x.IsRequired
+	}
+	return false
+}
+
+// Bản dịch nội dung của cùng một version sang locale khác
+type PolicyTranslation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"` // "vi" hoặc "en"
+	ContentHtml   string                 `protobuf:"bytes,2,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`
+	FileHash      string                 `protobuf:"bytes,3,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"` // Optional: file đã upload qua UploadFile cho locale này
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyTranslation) Reset() {
+	*x = PolicyTranslation{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyTranslation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyTranslation) ProtoMessage() {}
+
+func (x *PolicyTranslation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyTranslation.ProtoReflect.Descriptor instead.
+func (*PolicyTranslation) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PolicyTranslation) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PolicyTranslation) GetContentHtml() string {
+	if x != nil {
+		return x.ContentHtml
+	}
+	return ""
+}
+
+func (x *PolicyTranslation) GetFileHash() string {
+	if x != nil {
+		return x.FileHash
+	}
+	return ""
+}
+
 type CreateDocumentRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	DocumentName       string                 `protobuf:"bytes,1,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
-	Platform           string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
-	IsMandatory        bool                   `protobuf:"varint,3,opt,name=is_mandatory,json=isMandatory,proto3" json:"is_mandatory,omitempty"`
-	EffectiveTimestamp int64                  `protobuf:"varint,4,opt,name=effective_timestamp,json=effectiveTimestamp,proto3" json:"effective_timestamp,omitempty"`
-	ContentHtml        string                 `protobuf:"bytes,5,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`
-	FileUrl            string                 `protobuf:"bytes,6,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
-	CreatedBy          string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DocumentName          string                 `protobuf:"bytes,1,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	Platform              string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	IsMandatory           bool                   `protobuf:"varint,3,opt,name=is_mandatory,json=isMandatory,proto3" json:"is_mandatory,omitempty"`
+	EffectiveTimestamp    int64                  `protobuf:"varint,4,opt,name=effective_timestamp,json=effectiveTimestamp,proto3" json:"effective_timestamp,omitempty"`
+	ContentHtml           string                 `protobuf:"bytes,5,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`
+	FileUrl               string                 `protobuf:"bytes,6,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	CreatedBy             string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	IsDraft               bool                   `protobuf:"varint,8,opt,name=is_draft,json=isDraft,proto3" json:"is_draft,omitempty"`                                              // true = lưu bản nháp, false = gửi duyệt ngay
+	FileHash              string                 `protobuf:"bytes,9,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`                                            // Optional: file đã upload qua UploadFile
+	Locale                string                 `protobuf:"bytes,10,opt,name=locale,proto3" json:"locale,omitempty"`                                                               // Locale của content_html/file_hash ở trên, rỗng = "vi"
+	Translations          []*PolicyTranslation   `protobuf:"bytes,11,rep,name=translations,proto3" json:"translations,omitempty"`                                                   // Optional: nội dung các locale khác
+	ConsentValidityPeriod int32                  `protobuf:"varint,12,opt,name=consent_validity_period,json=consentValidityPeriod,proto3" json:"consent_validity_period,omitempty"` // Optional: số ngày consent còn hiệu lực, 0 = không hết hạn
+	Purposes              []*PolicyPurpose       `protobuf:"bytes,13,rep,name=purposes,proto3" json:"purposes,omitempty"`                                                           // Optional: mục đích user chọn đồng ý/từ chối riêng
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *CreateDocumentRequest) Reset() {
 	*x = CreateDocumentRequest{}
-	mi := &file_pkg_api_document_document_proto_msgTypes[1]
+	mi := &file_pkg_api_document_document_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -156,7 +396,7 @@ func (x *CreateDocumentRequest) String() string {
 func (*CreateDocumentRequest) ProtoMessage() {}
 
 func (x *CreateDocumentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_document_document_proto_msgTypes[1]
+	mi := &file_pkg_api_document_document_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +409,7 @@ func (x *CreateDocumentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDocumentRequest.ProtoReflect.Descriptor instead.
 func (*CreateDocumentRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{1}
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateDocumentRequest) GetDocumentName() string {
@@ -221,6 +461,48 @@ func (x *CreateDocumentRequest) GetCreatedBy() string {
 	return ""
 }
 
+func (x *CreateDocumentRequest) GetIsDraft() bool {
+	if x != nil {
+		return x.IsDraft
+	}
+	return false
+}
+
+func (x *CreateDocumentRequest) GetFileHash() string {
+	if x != nil {
+		return x.FileHash
+	}
+	return ""
+}
+
+func (x *CreateDocumentRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *CreateDocumentRequest) GetTranslations() []*PolicyTranslation {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *CreateDocumentRequest) GetConsentValidityPeriod() int32 {
+	if x != nil {
+		return x.ConsentValidityPeriod
+	}
+	return 0
+}
+
+func (x *CreateDocumentRequest) GetPurposes() []*PolicyPurpose {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
 type CreateDocumentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
@@ -230,7 +512,7 @@ type CreateDocumentResponse struct {
 
 func (x *CreateDocumentResponse) Reset() {
 	*x = CreateDocumentResponse{}
-	mi := &file_pkg_api_document_document_proto_msgTypes[2]
+	mi := &file_pkg_api_document_document_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -242,7 +524,7 @@ func (x *CreateDocumentResponse) String() string {
 func (*CreateDocumentResponse) ProtoMessage() {}
 
 func (x *CreateDocumentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_document_document_proto_msgTypes[2]
+	mi := &file_pkg_api_document_document_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +537,7 @@ func (x *CreateDocumentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateDocumentResponse.ProtoReflect.Descriptor instead.
 func (*CreateDocumentResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{2}
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateDocumentResponse) GetDocument() *PolicyDocument {
@@ -269,13 +551,14 @@ type GetLatestPolicyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
 	DocumentName  string                 `protobuf:"bytes,2,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"` // Optional: "vi", "en" (hoặc "en-US"), không có bản dịch thì trả nội dung gốc
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetLatestPolicyRequest) Reset() {
 	*x = GetLatestPolicyRequest{}
-	mi := &file_pkg_api_document_document_proto_msgTypes[3]
+	mi := &file_pkg_api_document_document_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -287,7 +570,7 @@ func (x *GetLatestPolicyRequest) String() string {
 func (*GetLatestPolicyRequest) ProtoMessage() {}
 
 func (x *GetLatestPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_document_document_proto_msgTypes[3]
+	mi := &file_pkg_api_document_document_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +583,7 @@ func (x *GetLatestPolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestPolicyRequest.ProtoReflect.Descriptor instead.
 func (*GetLatestPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{3}
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetLatestPolicyRequest) GetPlatform() string {
@@ -317,6 +600,13 @@ func (x *GetLatestPolicyRequest) GetDocumentName() string {
 	return ""
 }
 
+func (x *GetLatestPolicyRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
 type GetLatestPolicyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
@@ -326,7 +616,7 @@ type GetLatestPolicyResponse struct {
 
 func (x *GetLatestPolicyResponse) Reset() {
 	*x = GetLatestPolicyResponse{}
-	mi := &file_pkg_api_document_document_proto_msgTypes[4]
+	mi := &file_pkg_api_document_document_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -338,7 +628,7 @@ func (x *GetLatestPolicyResponse) String() string {
 func (*GetLatestPolicyResponse) ProtoMessage() {}
 
 func (x *GetLatestPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_document_document_proto_msgTypes[4]
+	mi := &file_pkg_api_document_document_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,7 +641,7 @@ func (x *GetLatestPolicyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestPolicyResponse.ProtoReflect.Descriptor instead.
 func (*GetLatestPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{4}
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetLatestPolicyResponse) GetDocument() *PolicyDocument {
@@ -372,7 +662,7 @@ type GetPolicyHistoryRequest struct {
 
 func (x *GetPolicyHistoryRequest) Reset() {
 	*x = GetPolicyHistoryRequest{}
-	mi := &file_pkg_api_document_document_proto_msgTypes[5]
+	mi := &file_pkg_api_document_document_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -384,7 +674,7 @@ func (x *GetPolicyHistoryRequest) String() string {
 func (*GetPolicyHistoryRequest) ProtoMessage() {}
 
 func (x *GetPolicyHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_document_document_proto_msgTypes[5]
+	mi := &file_pkg_api_document_document_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,7 +687,7 @@ func (x *GetPolicyHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPolicyHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetPolicyHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{5}
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetPolicyHistoryRequest) GetPlatform() string {
@@ -425,7 +715,7 @@ type GetPolicyHistoryResponse struct {
 
 func (x *GetPolicyHistoryResponse) Reset() {
 	*x = GetPolicyHistoryResponse{}
-	mi := &file_pkg_api_document_document_proto_msgTypes[6]
+	mi := &file_pkg_api_document_document_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -437,7 +727,7 @@ func (x *GetPolicyHistoryResponse) String() string {
 func (*GetPolicyHistoryResponse) ProtoMessage() {}
 
 func (x *GetPolicyHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_document_document_proto_msgTypes[6]
+	mi := &file_pkg_api_document_document_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,7 +740,7 @@ func (x *GetPolicyHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPolicyHistoryResponse.ProtoReflect.Descriptor instead.
 func (*GetPolicyHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{6}
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetPolicyHistoryResponse) GetDocuments() []*PolicyDocument {
@@ -467,37 +757,384 @@ func (x *GetPolicyHistoryResponse) GetTotalVersions() int32 {
 	return 0
 }
 
-// Request cho update operation
-type UpdatePolicyRequest struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	DocumentName string                 `protobuf:"bytes,1,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"` // Ten document can update
-	Platform     string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`                             // "Client" hoac "Merchant"
-	IsMandatory  bool                   `protobuf:"varint,3,opt,name=is_mandatory,json=isMandatory,proto3" json:"is_mandatory,omitempty"`   // Co bat buoc khong
-	ContentHtml  string                 `protobuf:"bytes,4,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`    // Noi dung HTML moi
-	FileUrl      string                 `protobuf:"bytes,5,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`                // URL file moi
-	UpdatedBy    string                 `protobuf:"bytes,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`          // UUID cua admin thuc hien update
-	// Timestamp (Unix epoch) mà Admin muốn chính sách bắt đầu có hiệu lực
-	// Nếu Admin không chọn (gửi 0), Backend sẽ tự lấy time.Now()
-	EffectiveTimestamp int64 `protobuf:"varint,7,opt,name=effective_timestamp,json=effectiveTimestamp,proto3" json:"effective_timestamp,omitempty"`
+// Request so sánh 2 version, chọn theo effective_timestamp trong history
+type ComparePolicyVersionsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Platform           string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"` // "Client", "Merchant" hoặc "Admin"
+	DocumentName       string                 `protobuf:"bytes,2,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	FromTimestamp      int64                  `protobuf:"varint,3,opt,name=from_timestamp,json=fromTimestamp,proto3" json:"from_timestamp,omitempty"`                // effective_timestamp của version cũ
+	ToTimestamp        int64                  `protobuf:"varint,4,opt,name=to_timestamp,json=toTimestamp,proto3" json:"to_timestamp,omitempty"`                      // effective_timestamp của version mới
+	IncludeUnpublished bool                   `protobuf:"varint,5,opt,name=include_unpublished,json=includeUnpublished,proto3" json:"include_unpublished,omitempty"` // true = cho phép cả draft/pending_review/rejected (chỉ admin)
+	Locale             string                 `protobuf:"bytes,6,opt,name=locale,proto3" json:"locale,omitempty"`                                                    // Optional: so sánh nội dung của locale này (fallback về nội dung gốc)
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
 
-func (x *UpdatePolicyRequest) Reset() {
-	*x = UpdatePolicyRequest{}
-	mi := &file_pkg_api_document_document_proto_msgTypes[7]
+func (x *ComparePolicyVersionsRequest) Reset() {
+	*x = ComparePolicyVersionsRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdatePolicyRequest) String() string {
+func (x *ComparePolicyVersionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePolicyRequest) ProtoMessage() {}
+func (*ComparePolicyVersionsRequest) ProtoMessage() {}
 
-func (x *UpdatePolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_document_document_proto_msgTypes[7]
+func (x *ComparePolicyVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparePolicyVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ComparePolicyVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ComparePolicyVersionsRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ComparePolicyVersionsRequest) GetDocumentName() string {
+	if x != nil {
+		return x.DocumentName
+	}
+	return ""
+}
+
+func (x *ComparePolicyVersionsRequest) GetFromTimestamp() int64 {
+	if x != nil {
+		return x.FromTimestamp
+	}
+	return 0
+}
+
+func (x *ComparePolicyVersionsRequest) GetToTimestamp() int64 {
+	if x != nil {
+		return x.ToTimestamp
+	}
+	return 0
+}
+
+func (x *ComparePolicyVersionsRequest) GetIncludeUnpublished() bool {
+	if x != nil {
+		return x.IncludeUnpublished
+	}
+	return false
+}
+
+func (x *ComparePolicyVersionsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// Một đoạn content_html không đổi / được thêm / bị xóa
+type DiffSegment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"` // "equal", "insert" hoặc "delete"
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSegment) Reset() {
+	*x = DiffSegment{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSegment) ProtoMessage() {}
+
+func (x *DiffSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSegment.ProtoReflect.Descriptor instead.
+func (*DiffSegment) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DiffSegment) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *DiffSegment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ComparePolicyVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDocument  *PolicyDocument        `protobuf:"bytes,1,opt,name=from_document,json=fromDocument,proto3" json:"from_document,omitempty"`
+	ToDocument    *PolicyDocument        `protobuf:"bytes,2,opt,name=to_document,json=toDocument,proto3" json:"to_document,omitempty"`
+	Segments      []*DiffSegment         `protobuf:"bytes,3,rep,name=segments,proto3" json:"segments,omitempty"`                 // Diff theo từ trên HTML gốc
+	HtmlDiff      string                 `protobuf:"bytes,4,opt,name=html_diff,json=htmlDiff,proto3" json:"html_diff,omitempty"` // HTML của version mới, thay đổi đánh dấu bằng <ins>/<del>
+	TextDiff      string                 `protobuf:"bytes,5,opt,name=text_diff,json=textDiff,proto3" json:"text_diff,omitempty"` // Unified diff trên plain text
+	Insertions    int32                  `protobuf:"varint,6,opt,name=insertions,proto3" json:"insertions,omitempty"`            // Số từ được thêm
+	Deletions     int32                  `protobuf:"varint,7,opt,name=deletions,proto3" json:"deletions,omitempty"`              // Số từ bị xóa
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComparePolicyVersionsResponse) Reset() {
+	*x = ComparePolicyVersionsResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparePolicyVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparePolicyVersionsResponse) ProtoMessage() {}
+
+func (x *ComparePolicyVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparePolicyVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ComparePolicyVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ComparePolicyVersionsResponse) GetFromDocument() *PolicyDocument {
+	if x != nil {
+		return x.FromDocument
+	}
+	return nil
+}
+
+func (x *ComparePolicyVersionsResponse) GetToDocument() *PolicyDocument {
+	if x != nil {
+		return x.ToDocument
+	}
+	return nil
+}
+
+func (x *ComparePolicyVersionsResponse) GetSegments() []*DiffSegment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *ComparePolicyVersionsResponse) GetHtmlDiff() string {
+	if x != nil {
+		return x.HtmlDiff
+	}
+	return ""
+}
+
+func (x *ComparePolicyVersionsResponse) GetTextDiff() string {
+	if x != nil {
+		return x.TextDiff
+	}
+	return ""
+}
+
+func (x *ComparePolicyVersionsResponse) GetInsertions() int32 {
+	if x != nil {
+		return x.Insertions
+	}
+	return 0
+}
+
+func (x *ComparePolicyVersionsResponse) GetDeletions() int32 {
+	if x != nil {
+		return x.Deletions
+	}
+	return 0
+}
+
+// Request lấy tất cả document đang hiệu lực của platform (Terms, Privacy, Cookie...)
+type ListActivePoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"` // "Client", "Merchant" hoặc "Admin"
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`     // Optional, fallback giống GetLatestPolicyRequest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActivePoliciesRequest) Reset() {
+	*x = ListActivePoliciesRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActivePoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActivePoliciesRequest) ProtoMessage() {}
+
+func (x *ListActivePoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActivePoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListActivePoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListActivePoliciesRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ListActivePoliciesRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// Response: mỗi document_name một version - version đang hiệu lực
+type ListActivePoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documents     []*PolicyDocument      `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActivePoliciesResponse) Reset() {
+	*x = ListActivePoliciesResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActivePoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActivePoliciesResponse) ProtoMessage() {}
+
+func (x *ListActivePoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActivePoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListActivePoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListActivePoliciesResponse) GetDocuments() []*PolicyDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *ListActivePoliciesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Request cho update operation
+type UpdatePolicyRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	DocumentName string                 `protobuf:"bytes,1,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"` // Ten document can update
+	Platform     string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`                             // "Client" hoac "Merchant"
+	IsMandatory  bool                   `protobuf:"varint,3,opt,name=is_mandatory,json=isMandatory,proto3" json:"is_mandatory,omitempty"`   // Co bat buoc khong
+	ContentHtml  string                 `protobuf:"bytes,4,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`    // Noi dung HTML moi
+	FileUrl      string                 `protobuf:"bytes,5,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`                // URL file moi
+	UpdatedBy    string                 `protobuf:"bytes,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`          // UUID cua admin thuc hien update
+	// Timestamp (Unix epoch) mà Admin muốn chính sách bắt đầu có hiệu lực
+	// Nếu Admin không chọn (gửi 0), Backend sẽ tự lấy time.Now()
+	EffectiveTimestamp int64 `protobuf:"varint,7,opt,name=effective_timestamp,json=effectiveTimestamp,proto3" json:"effective_timestamp,omitempty"`
+	// true = lưu bản nháp, false = gửi duyệt ngay (cần admin khác duyệt)
+	IsDraft bool `protobuf:"varint,8,opt,name=is_draft,json=isDraft,proto3" json:"is_draft,omitempty"`
+	// Optional: file đã upload qua UploadFile
+	FileHash string `protobuf:"bytes,9,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
+	// Locale của nội dung ở trên (rỗng = "vi") và bản dịch sang các locale khác
+	Locale       string               `protobuf:"bytes,10,opt,name=locale,proto3" json:"locale,omitempty"`
+	Translations []*PolicyTranslation `protobuf:"bytes,11,rep,name=translations,proto3" json:"translations,omitempty"`
+	// Số ngày consent với version mới còn hiệu lực (vd: marketing phải xác nhận lại mỗi 12 tháng), 0 = không hết hạn
+	ConsentValidityPeriod int32 `protobuf:"varint,12,opt,name=consent_validity_period,json=consentValidityPeriod,proto3" json:"consent_validity_period,omitempty"`
+	// Mục đích xử lý dữ liệu của version mới (giữ key cũ để lựa chọn của user vẫn so sánh được)
+	Purposes      []*PolicyPurpose `protobuf:"bytes,13,rep,name=purposes,proto3" json:"purposes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePolicyRequest) Reset() {
+	*x = UpdatePolicyRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePolicyRequest) ProtoMessage() {}
+
+func (x *UpdatePolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,82 +1147,919 @@ func (x *UpdatePolicyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePolicyRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePolicyRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{7}
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdatePolicyRequest) GetDocumentName() string {
+	if x != nil {
+		return x.DocumentName
+	}
+	return ""
+}
+
+func (x *UpdatePolicyRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *UpdatePolicyRequest) GetIsMandatory() bool {
+	if x != nil {
+		return x.IsMandatory
+	}
+	return false
+}
+
+func (x *UpdatePolicyRequest) GetContentHtml() string {
+	if x != nil {
+		return x.ContentHtml
+	}
+	return ""
+}
+
+func (x *UpdatePolicyRequest) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
+}
+
+func (x *UpdatePolicyRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *UpdatePolicyRequest) GetEffectiveTimestamp() int64 {
+	if x != nil {
+		return x.EffectiveTimestamp
+	}
+	return 0
+}
+
+func (x *UpdatePolicyRequest) GetIsDraft() bool {
+	if x != nil {
+		return x.IsDraft
+	}
+	return false
+}
+
+func (x *UpdatePolicyRequest) GetFileHash() string {
+	if x != nil {
+		return x.FileHash
+	}
+	return ""
+}
+
+func (x *UpdatePolicyRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *UpdatePolicyRequest) GetTranslations() []*PolicyTranslation {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *UpdatePolicyRequest) GetConsentValidityPeriod() int32 {
+	if x != nil {
+		return x.ConsentValidityPeriod
+	}
+	return 0
+}
+
+func (x *UpdatePolicyRequest) GetPurposes() []*PolicyPurpose {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+// Response tra ve document moi duoc tao
+type UpdatePolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"` // Document voi effective_timestamp moi
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`   // "Policy updated successfully. New version created."
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePolicyResponse) Reset() {
+	*x = UpdatePolicyResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePolicyResponse) ProtoMessage() {}
+
+func (x *UpdatePolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePolicyResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePolicyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdatePolicyResponse) GetDocument() *PolicyDocument {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *UpdatePolicyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request gửi duyệt một version draft
+type SubmitForReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	SubmittedBy   string                 `protobuf:"bytes,2,opt,name=submitted_by,json=submittedBy,proto3" json:"submitted_by,omitempty"` // UUID của admin gửi duyệt
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitForReviewRequest) Reset() {
+	*x = SubmitForReviewRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitForReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitForReviewRequest) ProtoMessage() {}
+
+func (x *SubmitForReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitForReviewRequest.ProtoReflect.Descriptor instead.
+func (*SubmitForReviewRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SubmitForReviewRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *SubmitForReviewRequest) GetSubmittedBy() string {
+	if x != nil {
+		return x.SubmittedBy
+	}
+	return ""
+}
+
+type SubmitForReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitForReviewResponse) Reset() {
+	*x = SubmitForReviewResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitForReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitForReviewResponse) ProtoMessage() {}
+
+func (x *SubmitForReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitForReviewResponse.ProtoReflect.Descriptor instead.
+func (*SubmitForReviewResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SubmitForReviewResponse) GetDocument() *PolicyDocument {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *SubmitForReviewResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request duyệt version pending_review
+type ApprovePolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	ReviewedBy    string                 `protobuf:"bytes,2,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"` // UUID của admin duyệt, phải khác người tạo/gửi duyệt
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`                         // Optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovePolicyRequest) Reset() {
+	*x = ApprovePolicyRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovePolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovePolicyRequest) ProtoMessage() {}
+
+func (x *ApprovePolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovePolicyRequest.ProtoReflect.Descriptor instead.
+func (*ApprovePolicyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ApprovePolicyRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *ApprovePolicyRequest) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *ApprovePolicyRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ApprovePolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"` // status = scheduled hoặc published
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovePolicyResponse) Reset() {
+	*x = ApprovePolicyResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovePolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovePolicyResponse) ProtoMessage() {}
+
+func (x *ApprovePolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovePolicyResponse.ProtoReflect.Descriptor instead.
+func (*ApprovePolicyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ApprovePolicyResponse) GetDocument() *PolicyDocument {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *ApprovePolicyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request từ chối version pending_review
+type RejectPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	ReviewedBy    string                 `protobuf:"bytes,2,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"` // UUID của admin từ chối, phải khác người tạo/gửi duyệt
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`                         // Bắt buộc - lý do từ chối
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectPolicyRequest) Reset() {
+	*x = RejectPolicyRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectPolicyRequest) ProtoMessage() {}
+
+func (x *RejectPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectPolicyRequest.ProtoReflect.Descriptor instead.
+func (*RejectPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RejectPolicyRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *RejectPolicyRequest) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *RejectPolicyRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type RejectPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectPolicyResponse) Reset() {
+	*x = RejectPolicyResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectPolicyResponse) ProtoMessage() {}
+
+func (x *RejectPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectPolicyResponse.ProtoReflect.Descriptor instead.
+func (*RejectPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RejectPolicyResponse) GetDocument() *PolicyDocument {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *RejectPolicyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request đổi lịch phát hành một version đã duyệt
+type SchedulePolicyRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId         string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`                          // ID của version cần lên lịch
+	EffectiveTimestamp int64                  `protobuf:"varint,2,opt,name=effective_timestamp,json=effectiveTimestamp,proto3" json:"effective_timestamp,omitempty"` // Thời điểm có hiệu lực (Unix epoch), phải ở tương lai
+	ScheduledBy        string                 `protobuf:"bytes,3,opt,name=scheduled_by,json=scheduledBy,proto3" json:"scheduled_by,omitempty"`                       // UUID của admin thực hiện
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SchedulePolicyRequest) Reset() {
+	*x = SchedulePolicyRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePolicyRequest) ProtoMessage() {}
+
+func (x *SchedulePolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePolicyRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePolicyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SchedulePolicyRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *SchedulePolicyRequest) GetEffectiveTimestamp() int64 {
+	if x != nil {
+		return x.EffectiveTimestamp
+	}
+	return 0
+}
+
+func (x *SchedulePolicyRequest) GetScheduledBy() string {
+	if x != nil {
+		return x.ScheduledBy
+	}
+	return ""
+}
+
+type SchedulePolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePolicyResponse) Reset() {
+	*x = SchedulePolicyResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePolicyResponse) ProtoMessage() {}
+
+func (x *SchedulePolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePolicyResponse.ProtoReflect.Descriptor instead.
+func (*SchedulePolicyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SchedulePolicyResponse) GetDocument() *PolicyDocument {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *SchedulePolicyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request phát hành ngay một version đã duyệt (scheduled)
+type PublishPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`    // ID của version cần phát hành
+	PublishedBy   string                 `protobuf:"bytes,2,opt,name=published_by,json=publishedBy,proto3" json:"published_by,omitempty"` // UUID của admin thực hiện
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishPolicyRequest) Reset() {
+	*x = PublishPolicyRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishPolicyRequest) ProtoMessage() {}
+
+func (x *PublishPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishPolicyRequest.ProtoReflect.Descriptor instead.
+func (*PublishPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PublishPolicyRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *PublishPolicyRequest) GetPublishedBy() string {
+	if x != nil {
+		return x.PublishedBy
+	}
+	return ""
+}
+
+type PublishPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"` // effective_timestamp = thời điểm phát hành
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishPolicyResponse) Reset() {
+	*x = PublishPolicyResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishPolicyResponse) ProtoMessage() {}
+
+func (x *PublishPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *UpdatePolicyRequest) GetDocumentName() string {
+// Deprecated: Use PublishPolicyResponse.ProtoReflect.Descriptor instead.
+func (*PublishPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PublishPolicyResponse) GetDocument() *PolicyDocument {
 	if x != nil {
-		return x.DocumentName
+		return x.Document
+	}
+	return nil
+}
+
+func (x *PublishPolicyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *UpdatePolicyRequest) GetPlatform() string {
+// Request liệt kê các version chưa có hiệu lực cho admin
+type ListUpcomingPoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Platform      string                 `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`                             // "Client", "Merchant" hoặc "Admin"
+	DocumentName  string                 `protobuf:"bytes,2,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"` // Optional, rỗng = tất cả document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUpcomingPoliciesRequest) Reset() {
+	*x = ListUpcomingPoliciesRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUpcomingPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUpcomingPoliciesRequest) ProtoMessage() {}
+
+func (x *ListUpcomingPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUpcomingPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListUpcomingPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListUpcomingPoliciesRequest) GetPlatform() string {
 	if x != nil {
 		return x.Platform
 	}
 	return ""
 }
 
-func (x *UpdatePolicyRequest) GetIsMandatory() bool {
+func (x *ListUpcomingPoliciesRequest) GetDocumentName() string {
 	if x != nil {
-		return x.IsMandatory
+		return x.DocumentName
 	}
-	return false
+	return ""
 }
 
-func (x *UpdatePolicyRequest) GetContentHtml() string {
+type ListUpcomingPoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documents     []*PolicyDocument      `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"` // Sắp xếp theo effective_timestamp tăng dần
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUpcomingPoliciesResponse) Reset() {
+	*x = ListUpcomingPoliciesResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUpcomingPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUpcomingPoliciesResponse) ProtoMessage() {}
+
+func (x *ListUpcomingPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[27]
 	if x != nil {
-		return x.ContentHtml
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUpcomingPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListUpcomingPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListUpcomingPoliciesResponse) GetDocuments() []*PolicyDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *ListUpcomingPoliciesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// File đã upload, địa chỉ theo SHA-256 của nội dung
+type FileBlob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentHash   string                 `protobuf:"bytes,1,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Filename      string                 `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
+	UploadedBy    string                 `protobuf:"bytes,5,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileBlob) Reset() {
+	*x = FileBlob{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileBlob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileBlob) ProtoMessage() {}
+
+func (x *FileBlob) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileBlob.ProtoReflect.Descriptor instead.
+func (*FileBlob) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FileBlob) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
 	}
 	return ""
 }
 
-func (x *UpdatePolicyRequest) GetFileUrl() string {
+func (x *FileBlob) GetContentType() string {
 	if x != nil {
-		return x.FileUrl
+		return x.ContentType
 	}
 	return ""
 }
 
-func (x *UpdatePolicyRequest) GetUpdatedBy() string {
+func (x *FileBlob) GetSizeBytes() int64 {
 	if x != nil {
-		return x.UpdatedBy
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *FileBlob) GetFilename() string {
+	if x != nil {
+		return x.Filename
 	}
 	return ""
 }
 
-func (x *UpdatePolicyRequest) GetEffectiveTimestamp() int64 {
+func (x *FileBlob) GetUploadedBy() string {
 	if x != nil {
-		return x.EffectiveTimestamp
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *FileBlob) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return 0
 }
 
-// Response tra ve document moi duoc tao
-type UpdatePolicyResponse struct {
+// UploadFile: message đầu tiên mang metadata (filename, content_type, uploaded_by),
+// mọi message mang 1 chunk nội dung
+type UploadFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Document      *PolicyDocument        `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"` // Document voi effective_timestamp moi
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`   // "Policy updated successfully. New version created."
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	UploadedBy    string                 `protobuf:"bytes,3,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdatePolicyResponse) Reset() {
-	*x = UpdatePolicyResponse{}
-	mi := &file_pkg_api_document_document_proto_msgTypes[8]
+func (x *UploadFileRequest) Reset() {
+	*x = UploadFileRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdatePolicyResponse) String() string {
+func (x *UploadFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePolicyResponse) ProtoMessage() {}
+func (*UploadFileRequest) ProtoMessage() {}
 
-func (x *UpdatePolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_document_document_proto_msgTypes[8]
+func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -596,30 +2070,185 @@ func (x *UpdatePolicyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePolicyResponse.ProtoReflect.Descriptor instead.
-func (*UpdatePolicyResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
+func (*UploadFileRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *UpdatePolicyResponse) GetDocument() *PolicyDocument {
+func (x *UploadFileRequest) GetFilename() string {
 	if x != nil {
-		return x.Document
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
 	}
 	return nil
 }
 
-func (x *UpdatePolicyResponse) GetMessage() string {
+type UploadFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileBlob              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileResponse) Reset() {
+	*x = UploadFileResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileResponse) ProtoMessage() {}
+
+func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[30]
 	if x != nil {
-		return x.Message
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UploadFileResponse) GetFile() *FileBlob {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type DownloadFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentHash   string                 `protobuf:"bytes,1,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileRequest) Reset() {
+	*x = DownloadFileRequest{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileRequest) ProtoMessage() {}
+
+func (x *DownloadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileRequest.ProtoReflect.Descriptor instead.
+func (*DownloadFileRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DownloadFileRequest) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
 	}
 	return ""
 }
 
+// DownloadFile: message đầu tiên mang metadata (file), các message sau mang chunk
+type DownloadFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileBlob              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileResponse) Reset() {
+	*x = DownloadFileResponse{}
+	mi := &file_pkg_api_document_document_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileResponse) ProtoMessage() {}
+
+func (x *DownloadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_document_document_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileResponse.ProtoReflect.Descriptor instead.
+func (*DownloadFileResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_document_document_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DownloadFileResponse) GetFile() *FileBlob {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *DownloadFileResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
 var File_pkg_api_document_document_proto protoreflect.FileDescriptor
 
 const file_pkg_api_document_document_proto_rawDesc = "" +
 	"\n" +
-	"\x1fpkg/api/document/document.proto\x12\bdocument\"\xb1\x02\n" +
+	"\x1fpkg/api/document/document.proto\x12\bdocument\"\x8d\x06\n" +
 	"\x0ePolicyDocument\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
 	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\x12\x1a\n" +
@@ -631,7 +2260,33 @@ const file_pkg_api_document_document_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\t \x01(\tR\tcreatedBy\"\x89\x02\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12!\n" +
+	"\fpublished_at\x18\v \x01(\x03R\vpublishedAt\x12!\n" +
+	"\fpublished_by\x18\f \x01(\tR\vpublishedBy\x12!\n" +
+	"\fsubmitted_by\x18\r \x01(\tR\vsubmittedBy\x12!\n" +
+	"\fsubmitted_at\x18\x0e \x01(\x03R\vsubmittedAt\x12\x1f\n" +
+	"\vreviewed_by\x18\x0f \x01(\tR\n" +
+	"reviewedBy\x12\x1f\n" +
+	"\vreviewed_at\x18\x10 \x01(\x03R\n" +
+	"reviewedAt\x12%\n" +
+	"\x0ereview_comment\x18\x11 \x01(\tR\rreviewComment\x12\x1b\n" +
+	"\tfile_hash\x18\x12 \x01(\tR\bfileHash\x12\x16\n" +
+	"\x06locale\x18\x13 \x01(\tR\x06locale\x12+\n" +
+	"\x11available_locales\x18\x14 \x03(\tR\x10availableLocales\x126\n" +
+	"\x17consent_validity_period\x18\x15 \x01(\x05R\x15consentValidityPeriod\x123\n" +
+	"\bpurposes\x18\x16 \x03(\v2\x17.document.PolicyPurposeR\bpurposes\"x\n" +
+	"\rPolicyPurpose\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vis_required\x18\x04 \x01(\bR\n" +
+	"isRequired\"k\n" +
+	"\x11PolicyTranslation\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12!\n" +
+	"\fcontent_html\x18\x02 \x01(\tR\vcontentHtml\x12\x1b\n" +
+	"\tfile_hash\x18\x03 \x01(\tR\bfileHash\"\x87\x04\n" +
 	"\x15CreateDocumentRequest\x12#\n" +
 	"\rdocument_name\x18\x01 \x01(\tR\fdocumentName\x12\x1a\n" +
 	"\bplatform\x18\x02 \x01(\tR\bplatform\x12!\n" +
@@ -640,12 +2295,20 @@ const file_pkg_api_document_document_proto_rawDesc = "" +
 	"\fcontent_html\x18\x05 \x01(\tR\vcontentHtml\x12\x19\n" +
 	"\bfile_url\x18\x06 \x01(\tR\afileUrl\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\a \x01(\tR\tcreatedBy\"N\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x12\x19\n" +
+	"\bis_draft\x18\b \x01(\bR\aisDraft\x12\x1b\n" +
+	"\tfile_hash\x18\t \x01(\tR\bfileHash\x12\x16\n" +
+	"\x06locale\x18\n" +
+	" \x01(\tR\x06locale\x12?\n" +
+	"\ftranslations\x18\v \x03(\v2\x1b.document.PolicyTranslationR\ftranslations\x126\n" +
+	"\x17consent_validity_period\x18\f \x01(\x05R\x15consentValidityPeriod\x123\n" +
+	"\bpurposes\x18\r \x03(\v2\x17.document.PolicyPurposeR\bpurposes\"N\n" +
 	"\x16CreateDocumentResponse\x124\n" +
-	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\"Y\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\"q\n" +
 	"\x16GetLatestPolicyRequest\x12\x1a\n" +
 	"\bplatform\x18\x01 \x01(\tR\bplatform\x12#\n" +
-	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\"O\n" +
+	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"O\n" +
 	"\x17GetLatestPolicyResponse\x124\n" +
 	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\"Z\n" +
 	"\x17GetPolicyHistoryRequest\x12\x1a\n" +
@@ -653,7 +2316,34 @@ const file_pkg_api_document_document_proto_rawDesc = "" +
 	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\"y\n" +
 	"\x18GetPolicyHistoryResponse\x126\n" +
 	"\tdocuments\x18\x01 \x03(\v2\x18.document.PolicyDocumentR\tdocuments\x12%\n" +
-	"\x0etotal_versions\x18\x02 \x01(\x05R\rtotalVersions\"\x87\x02\n" +
+	"\x0etotal_versions\x18\x02 \x01(\x05R\rtotalVersions\"\xf2\x01\n" +
+	"\x1cComparePolicyVersionsRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12#\n" +
+	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\x12%\n" +
+	"\x0efrom_timestamp\x18\x03 \x01(\x03R\rfromTimestamp\x12!\n" +
+	"\fto_timestamp\x18\x04 \x01(\x03R\vtoTimestamp\x12/\n" +
+	"\x13include_unpublished\x18\x05 \x01(\bR\x12includeUnpublished\x12\x16\n" +
+	"\x06locale\x18\x06 \x01(\tR\x06locale\"1\n" +
+	"\vDiffSegment\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\xc4\x02\n" +
+	"\x1dComparePolicyVersionsResponse\x12=\n" +
+	"\rfrom_document\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\ffromDocument\x129\n" +
+	"\vto_document\x18\x02 \x01(\v2\x18.document.PolicyDocumentR\n" +
+	"toDocument\x121\n" +
+	"\bsegments\x18\x03 \x03(\v2\x15.document.DiffSegmentR\bsegments\x12\x1b\n" +
+	"\thtml_diff\x18\x04 \x01(\tR\bhtmlDiff\x12\x1b\n" +
+	"\ttext_diff\x18\x05 \x01(\tR\btextDiff\x12\x1e\n" +
+	"\n" +
+	"insertions\x18\x06 \x01(\x05R\n" +
+	"insertions\x12\x1c\n" +
+	"\tdeletions\x18\a \x01(\x05R\tdeletions\"O\n" +
+	"\x19ListActivePoliciesRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"j\n" +
+	"\x1aListActivePoliciesResponse\x126\n" +
+	"\tdocuments\x18\x01 \x03(\v2\x18.document.PolicyDocumentR\tdocuments\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\x85\x04\n" +
 	"\x13UpdatePolicyRequest\x12#\n" +
 	"\rdocument_name\x18\x01 \x01(\tR\fdocumentName\x12\x1a\n" +
 	"\bplatform\x18\x02 \x01(\tR\bplatform\x12!\n" +
@@ -662,15 +2352,102 @@ const file_pkg_api_document_document_proto_rawDesc = "" +
 	"\bfile_url\x18\x05 \x01(\tR\afileUrl\x12\x1d\n" +
 	"\n" +
 	"updated_by\x18\x06 \x01(\tR\tupdatedBy\x12/\n" +
-	"\x13effective_timestamp\x18\a \x01(\x03R\x12effectiveTimestamp\"f\n" +
+	"\x13effective_timestamp\x18\a \x01(\x03R\x12effectiveTimestamp\x12\x19\n" +
+	"\bis_draft\x18\b \x01(\bR\aisDraft\x12\x1b\n" +
+	"\tfile_hash\x18\t \x01(\tR\bfileHash\x12\x16\n" +
+	"\x06locale\x18\n" +
+	" \x01(\tR\x06locale\x12?\n" +
+	"\ftranslations\x18\v \x03(\v2\x1b.document.PolicyTranslationR\ftranslations\x126\n" +
+	"\x17consent_validity_period\x18\f \x01(\x05R\x15consentValidityPeriod\x123\n" +
+	"\bpurposes\x18\r \x03(\v2\x17.document.PolicyPurposeR\bpurposes\"f\n" +
 	"\x14UpdatePolicyResponse\x124\n" +
 	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xf0\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\\\n" +
+	"\x16SubmitForReviewRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12!\n" +
+	"\fsubmitted_by\x18\x02 \x01(\tR\vsubmittedBy\"i\n" +
+	"\x17SubmitForReviewResponse\x124\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"r\n" +
+	"\x14ApprovePolicyRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1f\n" +
+	"\vreviewed_by\x18\x02 \x01(\tR\n" +
+	"reviewedBy\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"g\n" +
+	"\x15ApprovePolicyResponse\x124\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"q\n" +
+	"\x13RejectPolicyRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1f\n" +
+	"\vreviewed_by\x18\x02 \x01(\tR\n" +
+	"reviewedBy\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"f\n" +
+	"\x14RejectPolicyResponse\x124\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8c\x01\n" +
+	"\x15SchedulePolicyRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12/\n" +
+	"\x13effective_timestamp\x18\x02 \x01(\x03R\x12effectiveTimestamp\x12!\n" +
+	"\fscheduled_by\x18\x03 \x01(\tR\vscheduledBy\"h\n" +
+	"\x16SchedulePolicyResponse\x124\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"Z\n" +
+	"\x14PublishPolicyRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12!\n" +
+	"\fpublished_by\x18\x02 \x01(\tR\vpublishedBy\"g\n" +
+	"\x15PublishPolicyResponse\x124\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.document.PolicyDocumentR\bdocument\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"^\n" +
+	"\x1bListUpcomingPoliciesRequest\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12#\n" +
+	"\rdocument_name\x18\x02 \x01(\tR\fdocumentName\"l\n" +
+	"\x1cListUpcomingPoliciesResponse\x126\n" +
+	"\tdocuments\x18\x01 \x03(\v2\x18.document.PolicyDocumentR\tdocuments\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xcb\x01\n" +
+	"\bFileBlob\x12!\n" +
+	"\fcontent_hash\x18\x01 \x01(\tR\vcontentHash\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x1a\n" +
+	"\bfilename\x18\x04 \x01(\tR\bfilename\x12\x1f\n" +
+	"\vuploaded_by\x18\x05 \x01(\tR\n" +
+	"uploadedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"\x89\x01\n" +
+	"\x11UploadFileRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1f\n" +
+	"\vuploaded_by\x18\x03 \x01(\tR\n" +
+	"uploadedBy\x12\x14\n" +
+	"\x05chunk\x18\x04 \x01(\fR\x05chunk\"<\n" +
+	"\x12UploadFileResponse\x12&\n" +
+	"\x04file\x18\x01 \x01(\v2\x12.document.FileBlobR\x04file\"8\n" +
+	"\x13DownloadFileRequest\x12!\n" +
+	"\fcontent_hash\x18\x01 \x01(\tR\vcontentHash\"T\n" +
+	"\x14DownloadFileResponse\x12&\n" +
+	"\x04file\x18\x01 \x01(\v2\x12.document.FileBlobR\x04file\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk2\xde\t\n" +
 	"\x0fDocumentService\x12Q\n" +
 	"\fCreatePolicy\x12\x1f.document.CreateDocumentRequest\x1a .document.CreateDocumentResponse\x12`\n" +
 	"\x19GetLatestPolicyByPlatform\x12 .document.GetLatestPolicyRequest\x1a!.document.GetLatestPolicyResponse\x12M\n" +
 	"\fUpdatePolicy\x12\x1d.document.UpdatePolicyRequest\x1a\x1e.document.UpdatePolicyResponse\x12Y\n" +
-	"\x10GetPolicyHistory\x12!.document.GetPolicyHistoryRequest\x1a\".document.GetPolicyHistoryResponseB=Z;github.com/thatlq1812/policy-system/shared/pkg/api/documentb\x06proto3"
+	"\x10GetPolicyHistory\x12!.document.GetPolicyHistoryRequest\x1a\".document.GetPolicyHistoryResponse\x12_\n" +
+	"\x12ListActivePolicies\x12#.document.ListActivePoliciesRequest\x1a$.document.ListActivePoliciesResponse\x12h\n" +
+	"\x15ComparePolicyVersions\x12&.document.ComparePolicyVersionsRequest\x1a'.document.ComparePolicyVersionsResponse\x12V\n" +
+	"\x0fSubmitForReview\x12 .document.SubmitForReviewRequest\x1a!.document.SubmitForReviewResponse\x12P\n" +
+	"\rApprovePolicy\x12\x1e.document.ApprovePolicyRequest\x1a\x1f.document.ApprovePolicyResponse\x12M\n" +
+	"\fRejectPolicy\x12\x1d.document.RejectPolicyRequest\x1a\x1e.document.RejectPolicyResponse\x12S\n" +
+	"\x0eSchedulePolicy\x12\x1f.document.SchedulePolicyRequest\x1a .document.SchedulePolicyResponse\x12P\n" +
+	"\rPublishPolicy\x12\x1e.document.PublishPolicyRequest\x1a\x1f.document.PublishPolicyResponse\x12e\n" +
+	"\x14ListUpcomingPolicies\x12%.document.ListUpcomingPoliciesRequest\x1a&.document.ListUpcomingPoliciesResponse\x12I\n" +
+	"\n" +
+	"UploadFile\x12\x1b.document.UploadFileRequest\x1a\x1c.document.UploadFileResponse(\x01\x12O\n" +
+	"\fDownloadFile\x12\x1d.document.DownloadFileRequest\x1a\x1e.document.DownloadFileResponse0\x01B=Z;github.com/thatlq1812/policy-system/shared/pkg/api/documentb\x06proto3"
 
 var (
 	file_pkg_api_document_document_proto_rawDescOnce sync.Once
@@ -684,36 +2461,97 @@ func file_pkg_api_document_document_proto_rawDescGZIP() []byte {
 	return file_pkg_api_document_document_proto_rawDescData
 }
 
-var file_pkg_api_document_document_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pkg_api_document_document_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_pkg_api_document_document_proto_goTypes = []any{
-	(*PolicyDocument)(nil),           // 0: document.PolicyDocument
-	(*CreateDocumentRequest)(nil),    // 1: document.CreateDocumentRequest
-	(*CreateDocumentResponse)(nil),   // 2: document.CreateDocumentResponse
-	(*GetLatestPolicyRequest)(nil),   // 3: document.GetLatestPolicyRequest
-	(*GetLatestPolicyResponse)(nil),  // 4: document.GetLatestPolicyResponse
-	(*GetPolicyHistoryRequest)(nil),  // 5: document.GetPolicyHistoryRequest
-	(*GetPolicyHistoryResponse)(nil), // 6: document.GetPolicyHistoryResponse
-	(*UpdatePolicyRequest)(nil),      // 7: document.UpdatePolicyRequest
-	(*UpdatePolicyResponse)(nil),     // 8: document.UpdatePolicyResponse
+	(*PolicyDocument)(nil),                // 0: document.PolicyDocument
+	(*PolicyPurpose)(nil),                 // 1: document.PolicyPurpose
+	(*PolicyTranslation)(nil),             // 2: document.PolicyTranslation
+	(*CreateDocumentRequest)(nil),         // 3: document.CreateDocumentRequest
+	(*CreateDocumentResponse)(nil),        // 4: document.CreateDocumentResponse
+	(*GetLatestPolicyRequest)(nil),        // 5: document.GetLatestPolicyRequest
+	(*GetLatestPolicyResponse)(nil),       // 6: document.GetLatestPolicyResponse
+	(*GetPolicyHistoryRequest)(nil),       // 7: document.GetPolicyHistoryRequest
+	(*GetPolicyHistoryResponse)(nil),      // 8: document.GetPolicyHistoryResponse
+	(*ComparePolicyVersionsRequest)(nil),  // 9: document.ComparePolicyVersionsRequest
+	(*DiffSegment)(nil),                   // 10: document.DiffSegment
+	(*ComparePolicyVersionsResponse)(nil), // 11: document.ComparePolicyVersionsResponse
+	(*ListActivePoliciesRequest)(nil),     // 12: document.ListActivePoliciesRequest
+	(*ListActivePoliciesResponse)(nil),    // 13: document.ListActivePoliciesResponse
+	(*UpdatePolicyRequest)(nil),           // 14: document.UpdatePolicyRequest
+	(*UpdatePolicyResponse)(nil),          // 15: document.UpdatePolicyResponse
+	(*SubmitForReviewRequest)(nil),        // 16: document.SubmitForReviewRequest
+	(*SubmitForReviewResponse)(nil),       // 17: document.SubmitForReviewResponse
+	(*ApprovePolicyRequest)(nil),          // 18: document.ApprovePolicyRequest
+	(*ApprovePolicyResponse)(nil),         // 19: document.ApprovePolicyResponse
+	(*RejectPolicyRequest)(nil),           // 20: document.RejectPolicyRequest
+	(*RejectPolicyResponse)(nil),          // 21: document.RejectPolicyResponse
+	(*SchedulePolicyRequest)(nil),         // 22: document.SchedulePolicyRequest
+	(*SchedulePolicyResponse)(nil),        // 23: document.SchedulePolicyResponse
+	(*PublishPolicyRequest)(nil),          // 24: document.PublishPolicyRequest
+	(*PublishPolicyResponse)(nil),         // 25: document.PublishPolicyResponse
+	(*ListUpcomingPoliciesRequest)(nil),   // 26: document.ListUpcomingPoliciesRequest
+	(*ListUpcomingPoliciesResponse)(nil),  // 27: document.ListUpcomingPoliciesResponse
+	(*FileBlob)(nil),                      // 28: document.FileBlob
+	(*UploadFileRequest)(nil),             // 29: document.UploadFileRequest
+	(*UploadFileResponse)(nil),            // 30: document.UploadFileResponse
+	(*DownloadFileRequest)(nil),           // 31: document.DownloadFileRequest
+	(*DownloadFileResponse)(nil),          // 32: document.DownloadFileResponse
 }
 var file_pkg_api_document_document_proto_depIdxs = []int32{
-	0, // 0: document.CreateDocumentResponse.document:type_name -> document.PolicyDocument
-	0, // 1: document.GetLatestPolicyResponse.document:type_name -> document.PolicyDocument
-	0, // 2: document.GetPolicyHistoryResponse.documents:type_name -> document.PolicyDocument
-	0, // 3: document.UpdatePolicyResponse.document:type_name -> document.PolicyDocument
-	1, // 4: document.DocumentService.CreatePolicy:input_type -> document.CreateDocumentRequest
-	3, // 5: document.DocumentService.GetLatestPolicyByPlatform:input_type -> document.GetLatestPolicyRequest
-	7, // 6: document.DocumentService.UpdatePolicy:input_type -> document.UpdatePolicyRequest
-	5, // 7: document.DocumentService.GetPolicyHistory:input_type -> document.GetPolicyHistoryRequest
-	2, // 8: document.DocumentService.CreatePolicy:output_type -> document.CreateDocumentResponse
-	4, // 9: document.DocumentService.GetLatestPolicyByPlatform:output_type -> document.GetLatestPolicyResponse
-	8, // 10: document.DocumentService.UpdatePolicy:output_type -> document.UpdatePolicyResponse
-	6, // 11: document.DocumentService.GetPolicyHistory:output_type -> document.GetPolicyHistoryResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	1,  // 0: document.PolicyDocument.purposes:type_name -> document.PolicyPurpose
+	2,  // 1: document.CreateDocumentRequest.translations:type_name -> document.PolicyTranslation
+	1,  // 2: document.CreateDocumentRequest.purposes:type_name -> document.PolicyPurpose
+	0,  // 3: document.CreateDocumentResponse.document:type_name -> document.PolicyDocument
+	0,  // 4: document.GetLatestPolicyResponse.document:type_name -> document.PolicyDocument
+	0,  // 5: document.GetPolicyHistoryResponse.documents:type_name -> document.PolicyDocument
+	0,  // 6: document.ComparePolicyVersionsResponse.from_document:type_name -> document.PolicyDocument
+	0,  // 7: document.ComparePolicyVersionsResponse.to_document:type_name -> document.PolicyDocument
+	10, // 8: document.ComparePolicyVersionsResponse.segments:type_name -> document.DiffSegment
+	0,  // 9: document.ListActivePoliciesResponse.documents:type_name -> document.PolicyDocument
+	2,  // 10: document.UpdatePolicyRequest.translations:type_name -> document.PolicyTranslation
+	1,  // 11: document.UpdatePolicyRequest.purposes:type_name -> document.PolicyPurpose
+	0,  // 12: document.UpdatePolicyResponse.document:type_name -> document.PolicyDocument
+	0,  // 13: document.SubmitForReviewResponse.document:type_name -> document.PolicyDocument
+	0,  // 14: document.ApprovePolicyResponse.document:type_name -> document.PolicyDocument
+	0,  // 15: document.RejectPolicyResponse.document:type_name -> document.PolicyDocument
+	0,  // 16: document.SchedulePolicyResponse.document:type_name -> document.PolicyDocument
+	0,  // 17: document.PublishPolicyResponse.document:type_name -> document.PolicyDocument
+	0,  // 18: document.ListUpcomingPoliciesResponse.documents:type_name -> document.PolicyDocument
+	28, // 19: document.UploadFileResponse.file:type_name -> document.FileBlob
+	28, // 20: document.DownloadFileResponse.file:type_name -> document.FileBlob
+	3,  // 21: document.DocumentService.CreatePolicy:input_type -> document.CreateDocumentRequest
+	5,  // 22: document.DocumentService.GetLatestPolicyByPlatform:input_type -> document.GetLatestPolicyRequest
+	14, // 23: document.DocumentService.UpdatePolicy:input_type -> document.UpdatePolicyRequest
+	7,  // 24: document.DocumentService.GetPolicyHistory:input_type -> document.GetPolicyHistoryRequest
+	12, // 25: document.DocumentService.ListActivePolicies:input_type -> document.ListActivePoliciesRequest
+	9,  // 26: document.DocumentService.ComparePolicyVersions:input_type -> document.ComparePolicyVersionsRequest
+	16, // 27: document.DocumentService.SubmitForReview:input_type -> document.SubmitForReviewRequest
+	18, // 28: document.DocumentService.ApprovePolicy:input_type -> document.ApprovePolicyRequest
+	20, // 29: document.DocumentService.RejectPolicy:input_type -> document.RejectPolicyRequest
+	22, // 30: document.DocumentService.SchedulePolicy:input_type -> document.SchedulePolicyRequest
+	24, // 31: document.DocumentService.PublishPolicy:input_type -> document.PublishPolicyRequest
+	26, // 32: document.DocumentService.ListUpcomingPolicies:input_type -> document.ListUpcomingPoliciesRequest
+	29, // 33: document.DocumentService.UploadFile:input_type -> document.UploadFileRequest
+	31, // 34: document.DocumentService.DownloadFile:input_type -> document.DownloadFileRequest
+	4,  // 35: document.DocumentService.CreatePolicy:output_type -> document.CreateDocumentResponse
+	6,  // 36: document.DocumentService.GetLatestPolicyByPlatform:output_type -> document.GetLatestPolicyResponse
+	15, // 37: document.DocumentService.UpdatePolicy:output_type -> document.UpdatePolicyResponse
+	8,  // 38: document.DocumentService.GetPolicyHistory:output_type -> document.GetPolicyHistoryResponse
+	13, // 39: document.DocumentService.ListActivePolicies:output_type -> document.ListActivePoliciesResponse
+	11, // 40: document.DocumentService.ComparePolicyVersions:output_type -> document.ComparePolicyVersionsResponse
+	17, // 41: document.DocumentService.SubmitForReview:output_type -> document.SubmitForReviewResponse
+	19, // 42: document.DocumentService.ApprovePolicy:output_type -> document.ApprovePolicyResponse
+	21, // 43: document.DocumentService.RejectPolicy:output_type -> document.RejectPolicyResponse
+	23, // 44: document.DocumentService.SchedulePolicy:output_type -> document.SchedulePolicyResponse
+	25, // 45: document.DocumentService.PublishPolicy:output_type -> document.PublishPolicyResponse
+	27, // 46: document.DocumentService.ListUpcomingPolicies:output_type -> document.ListUpcomingPoliciesResponse
+	30, // 47: document.DocumentService.UploadFile:output_type -> document.UploadFileResponse
+	32, // 48: document.DocumentService.DownloadFile:output_type -> document.DownloadFileResponse
+	35, // [35:49] is the sub-list for method output_type
+	21, // [21:35] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_pkg_api_document_document_proto_init() }
@@ -727,7 +2565,7 @@ func file_pkg_api_document_document_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_document_document_proto_rawDesc), len(file_pkg_api_document_document_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
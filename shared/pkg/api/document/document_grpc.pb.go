@@ -23,6 +23,16 @@ const (
 	DocumentService_GetLatestPolicyByPlatform_FullMethodName = "/document.DocumentService/GetLatestPolicyByPlatform"
 	DocumentService_UpdatePolicy_FullMethodName              = "/document.DocumentService/UpdatePolicy"
 	DocumentService_GetPolicyHistory_FullMethodName          = "/document.DocumentService/GetPolicyHistory"
+	DocumentService_ListActivePolicies_FullMethodName        = "/document.DocumentService/ListActivePolicies"
+	DocumentService_ComparePolicyVersions_FullMethodName     = "/document.DocumentService/ComparePolicyVersions"
+	DocumentService_SubmitForReview_FullMethodName           = "/document.DocumentService/SubmitForReview"
+	DocumentService_ApprovePolicy_FullMethodName             = "/document.DocumentService/ApprovePolicy"
+	DocumentService_RejectPolicy_FullMethodName              = "/document.DocumentService/RejectPolicy"
+	DocumentService_SchedulePolicy_FullMethodName            = "/document.DocumentService/SchedulePolicy"
+	DocumentService_PublishPolicy_FullMethodName             = "/document.DocumentService/PublishPolicy"
+	DocumentService_ListUpcomingPolicies_FullMethodName      = "/document.DocumentService/ListUpcomingPolicies"
+	DocumentService_UploadFile_FullMethodName                = "/document.DocumentService/UploadFile"
+	DocumentService_DownloadFile_FullMethodName              = "/document.DocumentService/DownloadFile"
 )
 
 // DocumentServiceClient is the client API for DocumentService service.
@@ -33,6 +43,20 @@ type DocumentServiceClient interface {
 	GetLatestPolicyByPlatform(ctx context.Context, in *GetLatestPolicyRequest, opts ...grpc.CallOption) (*GetLatestPolicyResponse, error)
 	UpdatePolicy(ctx context.Context, in *UpdatePolicyRequest, opts ...grpc.CallOption) (*UpdatePolicyResponse, error)
 	GetPolicyHistory(ctx context.Context, in *GetPolicyHistoryRequest, opts ...grpc.CallOption) (*GetPolicyHistoryResponse, error)
+	ListActivePolicies(ctx context.Context, in *ListActivePoliciesRequest, opts ...grpc.CallOption) (*ListActivePoliciesResponse, error)
+	// So sánh content_html giữa 2 version (xem thay đổi trước khi đồng ý lại)
+	ComparePolicyVersions(ctx context.Context, in *ComparePolicyVersionsRequest, opts ...grpc.CallOption) (*ComparePolicyVersionsResponse, error)
+	// Review flow: draft -> pending_review -> approved (scheduled/published) | rejected
+	SubmitForReview(ctx context.Context, in *SubmitForReviewRequest, opts ...grpc.CallOption) (*SubmitForReviewResponse, error)
+	ApprovePolicy(ctx context.Context, in *ApprovePolicyRequest, opts ...grpc.CallOption) (*ApprovePolicyResponse, error)
+	RejectPolicy(ctx context.Context, in *RejectPolicyRequest, opts ...grpc.CallOption) (*RejectPolicyResponse, error)
+	// Vòng đời phát hành: scheduled -> published
+	SchedulePolicy(ctx context.Context, in *SchedulePolicyRequest, opts ...grpc.CallOption) (*SchedulePolicyResponse, error)
+	PublishPolicy(ctx context.Context, in *PublishPolicyRequest, opts ...grpc.CallOption) (*PublishPolicyResponse, error)
+	ListUpcomingPolicies(ctx context.Context, in *ListUpcomingPoliciesRequest, opts ...grpc.CallOption) (*ListUpcomingPoliciesResponse, error)
+	// Content-addressed file storage: upload theo chunk, download theo SHA-256
+	UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse], error)
+	DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadFileResponse], error)
 }
 
 type documentServiceClient struct {
@@ -83,6 +107,118 @@ func (c *documentServiceClient) GetPolicyHistory(ctx context.Context, in *GetPol
 	return out, nil
 }
 
+func (c *documentServiceClient) ListActivePolicies(ctx context.Context, in *ListActivePoliciesRequest, opts ...grpc.CallOption) (*ListActivePoliciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActivePoliciesResponse)
+	err := c.cc.Invoke(ctx, DocumentService_ListActivePolicies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) ComparePolicyVersions(ctx context.Context, in *ComparePolicyVersionsRequest, opts ...grpc.CallOption) (*ComparePolicyVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComparePolicyVersionsResponse)
+	err := c.cc.Invoke(ctx, DocumentService_ComparePolicyVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) SubmitForReview(ctx context.Context, in *SubmitForReviewRequest, opts ...grpc.CallOption) (*SubmitForReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitForReviewResponse)
+	err := c.cc.Invoke(ctx, DocumentService_SubmitForReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) ApprovePolicy(ctx context.Context, in *ApprovePolicyRequest, opts ...grpc.CallOption) (*ApprovePolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApprovePolicyResponse)
+	err := c.cc.Invoke(ctx, DocumentService_ApprovePolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) RejectPolicy(ctx context.Context, in *RejectPolicyRequest, opts ...grpc.CallOption) (*RejectPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectPolicyResponse)
+	err := c.cc.Invoke(ctx, DocumentService_RejectPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) SchedulePolicy(ctx context.Context, in *SchedulePolicyRequest, opts ...grpc.CallOption) (*SchedulePolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SchedulePolicyResponse)
+	err := c.cc.Invoke(ctx, DocumentService_SchedulePolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) PublishPolicy(ctx context.Context, in *PublishPolicyRequest, opts ...grpc.CallOption) (*PublishPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishPolicyResponse)
+	err := c.cc.Invoke(ctx, DocumentService_PublishPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) ListUpcomingPolicies(ctx context.Context, in *ListUpcomingPoliciesRequest, opts ...grpc.CallOption) (*ListUpcomingPoliciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUpcomingPoliciesResponse)
+	err := c.cc.Invoke(ctx, DocumentService_ListUpcomingPolicies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DocumentService_ServiceDesc.Streams[0], DocumentService_UploadFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadFileRequest, UploadFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DocumentService_UploadFileClient = grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse]
+
+func (c *documentServiceClient) DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DocumentService_ServiceDesc.Streams[1], DocumentService_DownloadFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadFileRequest, DownloadFileResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DocumentService_DownloadFileClient = grpc.ServerStreamingClient[DownloadFileResponse]
+
 // DocumentServiceServer is the server API for DocumentService service.
 // All implementations must embed UnimplementedDocumentServiceServer
 // for forward compatibility.
@@ -91,6 +227,20 @@ type DocumentServiceServer interface {
 	GetLatestPolicyByPlatform(context.Context, *GetLatestPolicyRequest) (*GetLatestPolicyResponse, error)
 	UpdatePolicy(context.Context, *UpdatePolicyRequest) (*UpdatePolicyResponse, error)
 	GetPolicyHistory(context.Context, *GetPolicyHistoryRequest) (*GetPolicyHistoryResponse, error)
+	ListActivePolicies(context.Context, *ListActivePoliciesRequest) (*ListActivePoliciesResponse, error)
+	// So sánh content_html giữa 2 version (xem thay đổi trước khi đồng ý lại)
+	ComparePolicyVersions(context.Context, *ComparePolicyVersionsRequest) (*ComparePolicyVersionsResponse, error)
+	// Review flow: draft -> pending_review -> approved (scheduled/published) | rejected
+	SubmitForReview(context.Context, *SubmitForReviewRequest) (*SubmitForReviewResponse, error)
+	ApprovePolicy(context.Context, *ApprovePolicyRequest) (*ApprovePolicyResponse, error)
+	RejectPolicy(context.Context, *RejectPolicyRequest) (*RejectPolicyResponse, error)
+	// Vòng đời phát hành: scheduled -> published
+	SchedulePolicy(context.Context, *SchedulePolicyRequest) (*SchedulePolicyResponse, error)
+	PublishPolicy(context.Context, *PublishPolicyRequest) (*PublishPolicyResponse, error)
+	ListUpcomingPolicies(context.Context, *ListUpcomingPoliciesRequest) (*ListUpcomingPoliciesResponse, error)
+	// Content-addressed file storage: upload theo chunk, download theo SHA-256
+	UploadFile(grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]) error
+	DownloadFile(*DownloadFileRequest, grpc.ServerStreamingServer[DownloadFileResponse]) error
 	mustEmbedUnimplementedDocumentServiceServer()
 }
 
@@ -113,6 +263,36 @@ func (UnimplementedDocumentServiceServer) UpdatePolicy(context.Context, *UpdateP
 func (UnimplementedDocumentServiceServer) GetPolicyHistory(context.Context, *GetPolicyHistoryRequest) (*GetPolicyHistoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPolicyHistory not implemented")
 }
+func (UnimplementedDocumentServiceServer) ListActivePolicies(context.Context, *ListActivePoliciesRequest) (*ListActivePoliciesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListActivePolicies not implemented")
+}
+func (UnimplementedDocumentServiceServer) ComparePolicyVersions(context.Context, *ComparePolicyVersionsRequest) (*ComparePolicyVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ComparePolicyVersions not implemented")
+}
+func (UnimplementedDocumentServiceServer) SubmitForReview(context.Context, *SubmitForReviewRequest) (*SubmitForReviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitForReview not implemented")
+}
+func (UnimplementedDocumentServiceServer) ApprovePolicy(context.Context, *ApprovePolicyRequest) (*ApprovePolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApprovePolicy not implemented")
+}
+func (UnimplementedDocumentServiceServer) RejectPolicy(context.Context, *RejectPolicyRequest) (*RejectPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectPolicy not implemented")
+}
+func (UnimplementedDocumentServiceServer) SchedulePolicy(context.Context, *SchedulePolicyRequest) (*SchedulePolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SchedulePolicy not implemented")
+}
+func (UnimplementedDocumentServiceServer) PublishPolicy(context.Context, *PublishPolicyRequest) (*PublishPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PublishPolicy not implemented")
+}
+func (UnimplementedDocumentServiceServer) ListUpcomingPolicies(context.Context, *ListUpcomingPoliciesRequest) (*ListUpcomingPoliciesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUpcomingPolicies not implemented")
+}
+func (UnimplementedDocumentServiceServer) UploadFile(grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadFile not implemented")
+}
+func (UnimplementedDocumentServiceServer) DownloadFile(*DownloadFileRequest, grpc.ServerStreamingServer[DownloadFileResponse]) error {
+	return status.Error(codes.Unimplemented, "method DownloadFile not implemented")
+}
 func (UnimplementedDocumentServiceServer) mustEmbedUnimplementedDocumentServiceServer() {}
 func (UnimplementedDocumentServiceServer) testEmbeddedByValue()                         {}
 
@@ -206,6 +386,168 @@ func _DocumentService_GetPolicyHistory_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocumentService_ListActivePolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActivePoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).ListActivePolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_ListActivePolicies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).ListActivePolicies(ctx, req.(*ListActivePoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_ComparePolicyVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComparePolicyVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).ComparePolicyVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_ComparePolicyVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).ComparePolicyVersions(ctx, req.(*ComparePolicyVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_SubmitForReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitForReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).SubmitForReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_SubmitForReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).SubmitForReview(ctx, req.(*SubmitForReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_ApprovePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).ApprovePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_ApprovePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).ApprovePolicy(ctx, req.(*ApprovePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_RejectPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).RejectPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_RejectPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).RejectPolicy(ctx, req.(*RejectPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_SchedulePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).SchedulePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_SchedulePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).SchedulePolicy(ctx, req.(*SchedulePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_PublishPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).PublishPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_PublishPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).PublishPolicy(ctx, req.(*PublishPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_ListUpcomingPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUpcomingPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).ListUpcomingPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_ListUpcomingPolicies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).ListUpcomingPolicies(ctx, req.(*ListUpcomingPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DocumentServiceServer).UploadFile(&grpc.GenericServerStream[UploadFileRequest, UploadFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DocumentService_UploadFileServer = grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]
+
+func _DocumentService_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocumentServiceServer).DownloadFile(m, &grpc.GenericServerStream[DownloadFileRequest, DownloadFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DocumentService_DownloadFileServer = grpc.ServerStreamingServer[DownloadFileResponse]
+
 // DocumentService_ServiceDesc is the grpc.ServiceDesc for DocumentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +571,50 @@ var DocumentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPolicyHistory",
 			Handler:    _DocumentService_GetPolicyHistory_Handler,
 		},
+		{
+			MethodName: "ListActivePolicies",
+			Handler:    _DocumentService_ListActivePolicies_Handler,
+		},
+		{
+			MethodName: "ComparePolicyVersions",
+			Handler:    _DocumentService_ComparePolicyVersions_Handler,
+		},
+		{
+			MethodName: "SubmitForReview",
+			Handler:    _DocumentService_SubmitForReview_Handler,
+		},
+		{
+			MethodName: "ApprovePolicy",
+			Handler:    _DocumentService_ApprovePolicy_Handler,
+		},
+		{
+			MethodName: "RejectPolicy",
+			Handler:    _DocumentService_RejectPolicy_Handler,
+		},
+		{
+			MethodName: "SchedulePolicy",
+			Handler:    _DocumentService_SchedulePolicy_Handler,
+		},
+		{
+			MethodName: "PublishPolicy",
+			Handler:    _DocumentService_PublishPolicy_Handler,
+		},
+		{
+			MethodName: "ListUpcomingPolicies",
+			Handler:    _DocumentService_ListUpcomingPolicies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadFile",
+			Handler:       _DocumentService_UploadFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadFile",
+			Handler:       _DocumentService_DownloadFile_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/api/document/document.proto",
 }
@@ -30,6 +30,7 @@ type User struct {
 	PlatformRole  string                 `protobuf:"bytes,4,opt,name=platform_role,json=platformRole,proto3" json:"platform_role,omitempty"` // "Client" or "Merchant"
 	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PhoneVerified bool                   `protobuf:"varint,7,opt,name=phone_verified,json=phoneVerified,proto3" json:"phone_verified,omitempty"` // Đã xác minh số điện thoại bằng OTP
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,6 +107,13 @@ func (x *User) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *User) GetPhoneVerified() bool {
+	if x != nil {
+		return x.PhoneVerified
+	}
+	return false
+}
+
 // RegisterRequest for user registration
 type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -253,29 +261,34 @@ func (x *RegisterResponse) GetRefreshTokenExpiresAt() int64 {
 	return 0
 }
 
-// LoginRequest for user authentication
-type LoginRequest struct {
+// RegisterWithConsentRequest - đăng ký và tự động consent policy bắt buộc mới nhất của platform
+type RegisterWithConsentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PhoneNumber   string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	PlatformRole  string                 `protobuf:"bytes,4,opt,name=platform_role,json=platformRole,proto3" json:"platform_role,omitempty"` // Client | Merchant
+	Locale        string                 `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`                                 // Ngôn ngữ policy được consent
+	IpAddress     string                 `protobuf:"bytes,6,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
+func (x *RegisterWithConsentRequest) Reset() {
+	*x = RegisterWithConsentRequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginRequest) String() string {
+func (x *RegisterWithConsentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginRequest) ProtoMessage() {}
+func (*RegisterWithConsentRequest) ProtoMessage() {}
 
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+func (x *RegisterWithConsentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -287,51 +300,87 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterWithConsentRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWithConsentRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *LoginRequest) GetPhoneNumber() string {
+func (x *RegisterWithConsentRequest) GetPhoneNumber() string {
 	if x != nil {
 		return x.PhoneNumber
 	}
 	return ""
 }
 
-func (x *LoginRequest) GetPassword() string {
+func (x *RegisterWithConsentRequest) GetPassword() string {
 	if x != nil {
 		return x.Password
 	}
 	return ""
 }
 
-// LoginResponse return user info and JWT token
-type LoginResponse struct {
+func (x *RegisterWithConsentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterWithConsentRequest) GetPlatformRole() string {
+	if x != nil {
+		return x.PlatformRole
+	}
+	return ""
+}
+
+func (x *RegisterWithConsentRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *RegisterWithConsentRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *RegisterWithConsentRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+type RegisterWithConsentResponse struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	User                  *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	AccessToken           string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
 	RefreshToken          string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	AccessTokenExpiresAt  int64                  `protobuf:"varint,4,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`    // Unix timestamp
 	RefreshTokenExpiresAt int64                  `protobuf:"varint,5,opt,name=refresh_token_expires_at,json=refreshTokenExpiresAt,proto3" json:"refresh_token_expires_at,omitempty"` // Unix timestamp
+	ConsentsRecorded      int32                  `protobuf:"varint,6,opt,name=consents_recorded,json=consentsRecorded,proto3" json:"consents_recorded,omitempty"`
+	SagaId                string                 `protobuf:"bytes,7,opt,name=saga_id,json=sagaId,proto3" json:"saga_id,omitempty"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
 
-func (x *LoginResponse) Reset() {
-	*x = LoginResponse{}
+func (x *RegisterWithConsentResponse) Reset() {
+	*x = RegisterWithConsentResponse{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginResponse) String() string {
+func (x *RegisterWithConsentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginResponse) ProtoMessage() {}
+func (*RegisterWithConsentResponse) ProtoMessage() {}
 
-func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+func (x *RegisterWithConsentResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -343,71 +392,83 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
-func (*LoginResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterWithConsentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterWithConsentResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *LoginResponse) GetUser() *User {
+func (x *RegisterWithConsentResponse) GetUser() *User {
 	if x != nil {
 		return x.User
 	}
 	return nil
 }
 
-func (x *LoginResponse) GetAccessToken() string {
+func (x *RegisterWithConsentResponse) GetAccessToken() string {
 	if x != nil {
 		return x.AccessToken
 	}
 	return ""
 }
 
-func (x *LoginResponse) GetRefreshToken() string {
+func (x *RegisterWithConsentResponse) GetRefreshToken() string {
 	if x != nil {
 		return x.RefreshToken
 	}
 	return ""
 }
 
-func (x *LoginResponse) GetAccessTokenExpiresAt() int64 {
+func (x *RegisterWithConsentResponse) GetAccessTokenExpiresAt() int64 {
 	if x != nil {
 		return x.AccessTokenExpiresAt
 	}
 	return 0
 }
 
-func (x *LoginResponse) GetRefreshTokenExpiresAt() int64 {
+func (x *RegisterWithConsentResponse) GetRefreshTokenExpiresAt() int64 {
 	if x != nil {
 		return x.RefreshTokenExpiresAt
 	}
 	return 0
 }
 
-type RefreshTokenInfo struct {
+func (x *RegisterWithConsentResponse) GetConsentsRecorded() int32 {
+	if x != nil {
+		return x.ConsentsRecorded
+	}
+	return 0
+}
+
+func (x *RegisterWithConsentResponse) GetSagaId() string {
+	if x != nil {
+		return x.SagaId
+	}
+	return ""
+}
+
+// LoginRequest for user authentication
+type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // Token ID
-	DeviceInfo    string                 `protobuf:"bytes,2,opt,name=device_info,json=deviceInfo,proto3" json:"device_info,omitempty"` // Device information
-	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`    // IP address
-	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`   // Creation timestamp
-	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`   // Expiration timestamp
+	PhoneNumber   string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenInfo) Reset() {
-	*x = RefreshTokenInfo{}
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenInfo) String() string {
+func (x *LoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenInfo) ProtoMessage() {}
+func (*LoginRequest) ProtoMessage() {}
 
-func (x *RefreshTokenInfo) ProtoReflect() protoreflect.Message {
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,67 +480,56 @@ func (x *RefreshTokenInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenInfo.ProtoReflect.Descriptor instead.
-func (*RefreshTokenInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *RefreshTokenInfo) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *RefreshTokenInfo) GetDeviceInfo() string {
+func (x *LoginRequest) GetPhoneNumber() string {
 	if x != nil {
-		return x.DeviceInfo
+		return x.PhoneNumber
 	}
 	return ""
 }
 
-func (x *RefreshTokenInfo) GetIpAddress() string {
+func (x *LoginRequest) GetPassword() string {
 	if x != nil {
-		return x.IpAddress
+		return x.Password
 	}
 	return ""
 }
 
-func (x *RefreshTokenInfo) GetCreatedAt() int64 {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return 0
-}
-
-func (x *RefreshTokenInfo) GetExpiresAt() int64 {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return 0
-}
-
-type GetActiveSessionsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// LoginResponse return user info and JWT token
+// mfa_required = true: chưa có tokens, dùng mfa_token cho VerifyMFA (hoặc EnrollTOTP/ConfirmTOTP khi mfa_enrollment_required)
+type LoginResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	User                  *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken           string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken          string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	AccessTokenExpiresAt  int64                  `protobuf:"varint,4,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`    // Unix timestamp
+	RefreshTokenExpiresAt int64                  `protobuf:"varint,5,opt,name=refresh_token_expires_at,json=refreshTokenExpiresAt,proto3" json:"refresh_token_expires_at,omitempty"` // Unix timestamp
+	MfaRequired           bool                   `protobuf:"varint,6,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	MfaToken              string                 `protobuf:"bytes,7,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`                                           // Dùng 1 lần, hết hạn sau vài phút
+	MfaTokenExpiresAt     int64                  `protobuf:"varint,8,opt,name=mfa_token_expires_at,json=mfaTokenExpiresAt,proto3" json:"mfa_token_expires_at,omitempty"`           // Unix timestamp
+	MfaEnrollmentRequired bool                   `protobuf:"varint,9,opt,name=mfa_enrollment_required,json=mfaEnrollmentRequired,proto3" json:"mfa_enrollment_required,omitempty"` // Admin chưa bật 2FA phải enroll trước khi nhận tokens
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
-func (x *GetActiveSessionsRequest) Reset() {
-	*x = GetActiveSessionsRequest{}
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActiveSessionsRequest) String() string {
+func (x *LoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActiveSessionsRequest) ProtoMessage() {}
+func (*LoginResponse) ProtoMessage() {}
 
-func (x *GetActiveSessionsRequest) ProtoReflect() protoreflect.Message {
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -491,40 +541,97 @@ func (x *GetActiveSessionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActiveSessionsRequest.ProtoReflect.Descriptor instead.
-func (*GetActiveSessionsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetActiveSessionsRequest) GetUserId() string {
+func (x *LoginResponse) GetUser() *User {
 	if x != nil {
-		return x.UserId
+		return x.User
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
 	}
 	return ""
 }
 
-type GetActiveSessionsResponse struct {
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetAccessTokenExpiresAt() int64 {
+	if x != nil {
+		return x.AccessTokenExpiresAt
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetRefreshTokenExpiresAt() int64 {
+	if x != nil {
+		return x.RefreshTokenExpiresAt
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *LoginResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetMfaTokenExpiresAt() int64 {
+	if x != nil {
+		return x.MfaTokenExpiresAt
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetMfaEnrollmentRequired() bool {
+	if x != nil {
+		return x.MfaEnrollmentRequired
+	}
+	return false
+}
+
+// VerifyMFA - Bước 2 của đăng nhập
+type VerifyMFARequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sessions      []*RefreshTokenInfo    `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Mã TOTP 6 số hoặc recovery code
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActiveSessionsResponse) Reset() {
-	*x = GetActiveSessionsResponse{}
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActiveSessionsResponse) String() string {
+func (x *VerifyMFARequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActiveSessionsResponse) ProtoMessage() {}
+func (*VerifyMFARequest) ProtoMessage() {}
 
-func (x *GetActiveSessionsResponse) ProtoReflect() protoreflect.Message {
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -536,46 +643,48 @@ func (x *GetActiveSessionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActiveSessionsResponse.ProtoReflect.Descriptor instead.
-func (*GetActiveSessionsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetActiveSessionsResponse) GetSessions() []*RefreshTokenInfo {
+func (x *VerifyMFARequest) GetMfaToken() string {
 	if x != nil {
-		return x.Sessions
+		return x.MfaToken
 	}
-	return nil
+	return ""
 }
 
-func (x *GetActiveSessionsResponse) GetTotalCount() int32 {
+func (x *VerifyMFARequest) GetCode() string {
 	if x != nil {
-		return x.TotalCount
+		return x.Code
 	}
-	return 0
+	return ""
 }
 
-type LogoutAllDevicesRequest struct {
+// EnrollTOTP - Tạo TOTP secret mới (user đang đăng nhập, hoặc Admin bị buộc enroll bằng mfa_token)
+type EnrollTOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,2,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutAllDevicesRequest) Reset() {
-	*x = LogoutAllDevicesRequest{}
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutAllDevicesRequest) String() string {
+func (x *EnrollTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutAllDevicesRequest) ProtoMessage() {}
+func (*EnrollTOTPRequest) ProtoMessage() {}
 
-func (x *LogoutAllDevicesRequest) ProtoReflect() protoreflect.Message {
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -587,41 +696,47 @@ func (x *LogoutAllDevicesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutAllDevicesRequest.ProtoReflect.Descriptor instead.
-func (*LogoutAllDevicesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *LogoutAllDevicesRequest) GetUserId() string {
+func (x *EnrollTOTPRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type LogoutAllDevicesResponse struct {
+func (x *EnrollTOTPRequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+type EnrollTOTPResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	RevokedCount  int32                  `protobuf:"varint,2,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                           // Base32, nhập tay vào authenticator app
+	OtpauthUri    string                 `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"` // otpauth://totp/... để hiển thị QR code
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutAllDevicesResponse) Reset() {
-	*x = LogoutAllDevicesResponse{}
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutAllDevicesResponse) String() string {
+func (x *EnrollTOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutAllDevicesResponse) ProtoMessage() {}
+func (*EnrollTOTPResponse) ProtoMessage() {}
 
-func (x *LogoutAllDevicesResponse) ProtoReflect() protoreflect.Message {
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -633,54 +748,49 @@ func (x *LogoutAllDevicesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutAllDevicesResponse.ProtoReflect.Descriptor instead.
-func (*LogoutAllDevicesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *LogoutAllDevicesResponse) GetSuccess() bool {
+func (x *EnrollTOTPResponse) GetSecret() string {
 	if x != nil {
-		return x.Success
+		return x.Secret
 	}
-	return false
+	return ""
 }
 
-func (x *LogoutAllDevicesResponse) GetRevokedCount() int32 {
+func (x *EnrollTOTPResponse) GetOtpauthUri() string {
 	if x != nil {
-		return x.RevokedCount
+		return x.OtpauthUri
 	}
-	return 0
+	return ""
 }
 
-func (x *LogoutAllDevicesResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-type RevokeSessionRequest struct {
+// ConfirmTOTP - Bật 2FA bằng mã đầu tiên từ authenticator app
+type ConfirmTOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TokenId       string                 `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,2,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RevokeSessionRequest) Reset() {
-	*x = RevokeSessionRequest{}
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RevokeSessionRequest) String() string {
+func (x *ConfirmTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeSessionRequest) ProtoMessage() {}
+func (*ConfirmTOTPRequest) ProtoMessage() {}
 
-func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -692,47 +802,54 @@ func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
-func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *RevokeSessionRequest) GetUserId() string {
+func (x *ConfirmTOTPRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *RevokeSessionRequest) GetTokenId() string {
+func (x *ConfirmTOTPRequest) GetMfaToken() string {
 	if x != nil {
-		return x.TokenId
+		return x.MfaToken
 	}
 	return ""
 }
 
-type RevokeSessionResponse struct {
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTOTPResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // Chỉ trả về 1 lần
+	Login         *LoginResponse         `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`                                      // Chỉ có khi enroll bằng mfa_token (hoàn tất đăng nhập)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RevokeSessionResponse) Reset() {
-	*x = RevokeSessionResponse{}
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RevokeSessionResponse) String() string {
+func (x *ConfirmTOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeSessionResponse) ProtoMessage() {}
+func (*ConfirmTOTPResponse) ProtoMessage() {}
 
-func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -744,47 +861,48 @@ func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
-func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *RevokeSessionResponse) GetSuccess() bool {
+func (x *ConfirmTOTPResponse) GetRecoveryCodes() []string {
 	if x != nil {
-		return x.Success
+		return x.RecoveryCodes
 	}
-	return false
+	return nil
 }
 
-func (x *RevokeSessionResponse) GetMessage() string {
+func (x *ConfirmTOTPResponse) GetLogin() *LoginResponse {
 	if x != nil {
-		return x.Message
+		return x.Login
 	}
-	return ""
+	return nil
 }
 
-// ReqfreshTokenRequest - Client sends refresh token to get new access token
-type RefreshTokenRequest struct {
+// DisableTOTP - Tắt 2FA (Admin không được tắt)
+type DisableTOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Mã TOTP hoặc recovery code
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *DisableTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*DisableTOTPRequest) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -796,43 +914,46 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *RefreshTokenRequest) GetRefreshToken() string {
+func (x *DisableTOTPRequest) GetUserId() string {
 	if x != nil {
-		return x.RefreshToken
+		return x.UserId
 	}
 	return ""
 }
 
-// RefreshTokenResponse - Server returns new access token and refresh token
-type RefreshTokenResponse struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken           string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	RefreshToken          string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
-	AccessTokenExpiresAt  int64                  `protobuf:"varint,3,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`    // Unix timestamp
-	RefreshTokenExpiresAt int64                  `protobuf:"varint,4,opt,name=refresh_token_expires_at,json=refreshTokenExpiresAt,proto3" json:"refresh_token_expires_at,omitempty"` // Unix timestamp
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
 }
 
-func (x *RefreshTokenResponse) Reset() {
-	*x = RefreshTokenResponse{}
+type DisableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPResponse) Reset() {
+	*x = DisableTOTPResponse{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenResponse) String() string {
+func (x *DisableTOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenResponse) ProtoMessage() {}
+func (*DisableTOTPResponse) ProtoMessage() {}
 
-func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+func (x *DisableTOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -844,62 +965,41 @@ func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
-func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *RefreshTokenResponse) GetAccessToken() string {
-	if x != nil {
-		return x.AccessToken
-	}
-	return ""
-}
-
-func (x *RefreshTokenResponse) GetRefreshToken() string {
-	if x != nil {
-		return x.RefreshToken
-	}
-	return ""
-}
-
-func (x *RefreshTokenResponse) GetAccessTokenExpiresAt() int64 {
-	if x != nil {
-		return x.AccessTokenExpiresAt
-	}
-	return 0
-}
-
-func (x *RefreshTokenResponse) GetRefreshTokenExpiresAt() int64 {
+func (x *DisableTOTPResponse) GetSuccess() bool {
 	if x != nil {
-		return x.RefreshTokenExpiresAt
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-// LogoutRequest - Client sends refresh token and optionally access token to logout
-type LogoutRequest struct {
+type SendPhoneOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Required: refresh token to revoke
-	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`    // Optional: access token to blacklist for immediate revocation
+	Purpose       string                 `protobuf:"bytes,1,opt,name=purpose,proto3" json:"purpose,omitempty"`                            // "verify_phone" | "change_phone" | "login"
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                // verify_phone, change_phone (from jwt)
+	PhoneNumber   string                 `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"` // change_phone: số mới; login: số của tài khoản
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutRequest) Reset() {
-	*x = LogoutRequest{}
+func (x *SendPhoneOTPRequest) Reset() {
+	*x = SendPhoneOTPRequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutRequest) String() string {
+func (x *SendPhoneOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutRequest) ProtoMessage() {}
+func (*SendPhoneOTPRequest) ProtoMessage() {}
 
-func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+func (x *SendPhoneOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -911,48 +1011,54 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
-func (*LogoutRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendPhoneOTPRequest.ProtoReflect.Descriptor instead.
+func (*SendPhoneOTPRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *LogoutRequest) GetRefreshToken() string {
+func (x *SendPhoneOTPRequest) GetPurpose() string {
 	if x != nil {
-		return x.RefreshToken
+		return x.Purpose
 	}
 	return ""
 }
 
-func (x *LogoutRequest) GetAccessToken() string {
+func (x *SendPhoneOTPRequest) GetUserId() string {
 	if x != nil {
-		return x.AccessToken
+		return x.UserId
 	}
 	return ""
 }
 
-// LogoutResponse - Server confirms logout
-type LogoutResponse struct {
+func (x *SendPhoneOTPRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+type SendPhoneOTPResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,1,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`       // Unix timestamp
+	ResendAfter   int64                  `protobuf:"varint,2,opt,name=resend_after,json=resendAfter,proto3" json:"resend_after,omitempty"` // Unix timestamp, yêu cầu mã mới trước thời điểm này bị từ chối
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutResponse) Reset() {
-	*x = LogoutResponse{}
+func (x *SendPhoneOTPResponse) Reset() {
+	*x = SendPhoneOTPResponse{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutResponse) String() string {
+func (x *SendPhoneOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutResponse) ProtoMessage() {}
+func (*SendPhoneOTPResponse) ProtoMessage() {}
 
-func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+func (x *SendPhoneOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -964,47 +1070,47 @@ func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
-func (*LogoutResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendPhoneOTPResponse.ProtoReflect.Descriptor instead.
+func (*SendPhoneOTPResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *LogoutResponse) GetSuccess() bool {
+func (x *SendPhoneOTPResponse) GetExpiresAt() int64 {
 	if x != nil {
-		return x.Success
+		return x.ExpiresAt
 	}
-	return false
+	return 0
 }
 
-func (x *LogoutResponse) GetMessage() string {
+func (x *SendPhoneOTPResponse) GetResendAfter() int64 {
 	if x != nil {
-		return x.Message
+		return x.ResendAfter
 	}
-	return ""
+	return 0
 }
 
-// GetUserProfile - Get current user's profile
-type GetUserProfileRequest struct {
+type VerifyPhoneRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // from jwt
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserProfileRequest) Reset() {
-	*x = GetUserProfileRequest{}
+func (x *VerifyPhoneRequest) Reset() {
+	*x = VerifyPhoneRequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserProfileRequest) String() string {
+func (x *VerifyPhoneRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserProfileRequest) ProtoMessage() {}
+func (*VerifyPhoneRequest) ProtoMessage() {}
 
-func (x *GetUserProfileRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifyPhoneRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1016,39 +1122,46 @@ func (x *GetUserProfileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserProfileRequest.ProtoReflect.Descriptor instead.
-func (*GetUserProfileRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifyPhoneRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetUserProfileRequest) GetUserId() string {
+func (x *VerifyPhoneRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type GetUserProfileResponse struct {
+func (x *VerifyPhoneRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyPhoneResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserProfileResponse) Reset() {
-	*x = GetUserProfileResponse{}
+func (x *VerifyPhoneResponse) Reset() {
+	*x = VerifyPhoneResponse{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserProfileResponse) String() string {
+func (x *VerifyPhoneResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserProfileResponse) ProtoMessage() {}
+func (*VerifyPhoneResponse) ProtoMessage() {}
 
-func (x *GetUserProfileResponse) ProtoReflect() protoreflect.Message {
+func (x *VerifyPhoneResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1060,42 +1173,40 @@ func (x *GetUserProfileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserProfileResponse.ProtoReflect.Descriptor instead.
-func (*GetUserProfileResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifyPhoneResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetUserProfileResponse) GetUser() *User {
+func (x *VerifyPhoneResponse) GetUser() *User {
 	if x != nil {
 		return x.User
 	}
 	return nil
 }
 
-// UpdateUserProfile - Update current user's profile - name and/or phone number
-type UpdateUserProfileRequest struct {
+type LoginWithOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                // from jwt
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                  // optional
-	PhoneNumber   string                 `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"` // optional
+	PhoneNumber   string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserProfileRequest) Reset() {
-	*x = UpdateUserProfileRequest{}
+func (x *LoginWithOTPRequest) Reset() {
+	*x = LoginWithOTPRequest{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserProfileRequest) String() string {
+func (x *LoginWithOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserProfileRequest) ProtoMessage() {}
+func (*LoginWithOTPRequest) ProtoMessage() {}
 
-func (x *UpdateUserProfileRequest) ProtoReflect() protoreflect.Message {
+func (x *LoginWithOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1107,54 +1218,50 @@ func (x *UpdateUserProfileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserProfileRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserProfileRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginWithOTPRequest.ProtoReflect.Descriptor instead.
+func (*LoginWithOTPRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *UpdateUserProfileRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *UpdateUserProfileRequest) GetName() string {
+func (x *LoginWithOTPRequest) GetPhoneNumber() string {
 	if x != nil {
-		return x.Name
+		return x.PhoneNumber
 	}
 	return ""
 }
 
-func (x *UpdateUserProfileRequest) GetPhoneNumber() string {
+func (x *LoginWithOTPRequest) GetCode() string {
 	if x != nil {
-		return x.PhoneNumber
+		return x.Code
 	}
 	return ""
 }
 
-type UpdateUserProfileResponse struct {
+type RefreshTokenInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // Token ID
+	DeviceInfo    string                 `protobuf:"bytes,2,opt,name=device_info,json=deviceInfo,proto3" json:"device_info,omitempty"` // Device information
+	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`    // IP address
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`   // Creation timestamp
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`   // Expiration timestamp
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserProfileResponse) Reset() {
-	*x = UpdateUserProfileResponse{}
+func (x *RefreshTokenInfo) Reset() {
+	*x = RefreshTokenInfo{}
 	mi := &file_pkg_api_user_user_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserProfileResponse) String() string {
+func (x *RefreshTokenInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserProfileResponse) ProtoMessage() {}
+func (*RefreshTokenInfo) ProtoMessage() {}
 
-func (x *UpdateUserProfileResponse) ProtoReflect() protoreflect.Message {
+func (x *RefreshTokenInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_user_user_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1166,50 +1273,1114 @@ func (x *UpdateUserProfileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserProfileResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserProfileResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefreshTokenInfo.ProtoReflect.Descriptor instead.
+func (*RefreshTokenInfo) Descriptor() ([]byte, []int) {
 	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *UpdateUserProfileResponse) GetUser() *User {
+func (x *RefreshTokenInfo) GetId() string {
 	if x != nil {
-		return x.User
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateUserProfileResponse) GetMessage() string {
+func (x *RefreshTokenInfo) GetDeviceInfo() string {
 	if x != nil {
-		return x.Message
+		return x.DeviceInfo
 	}
 	return ""
 }
 
-// ChangePassword - Change current user's password
-type ChangePasswordRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // from jwt
-	OldPassword   string                 `protobuf:"bytes,2,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
-	NewPassword   string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RefreshTokenInfo) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
 }
 
-func (x *ChangePasswordRequest) Reset() {
-	*x = ChangePasswordRequest{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[20]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *RefreshTokenInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
 }
 
-func (x *ChangePasswordRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *RefreshTokenInfo) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type GetActiveSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveSessionsRequest) Reset() {
+	*x = GetActiveSessionsRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveSessionsRequest) ProtoMessage() {}
+
+func (x *GetActiveSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveSessionsRequest.ProtoReflect.Descriptor instead.
+func (*GetActiveSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetActiveSessionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetActiveSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*RefreshTokenInfo    `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveSessionsResponse) Reset() {
+	*x = GetActiveSessionsResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveSessionsResponse) ProtoMessage() {}
+
+func (x *GetActiveSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveSessionsResponse.ProtoReflect.Descriptor instead.
+func (*GetActiveSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetActiveSessionsResponse) GetSessions() []*RefreshTokenInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *GetActiveSessionsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type LogoutAllDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllDevicesRequest) Reset() {
+	*x = LogoutAllDevicesRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllDevicesRequest) ProtoMessage() {}
+
+func (x *LogoutAllDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllDevicesRequest.ProtoReflect.Descriptor instead.
+func (*LogoutAllDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LogoutAllDevicesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type LogoutAllDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	RevokedCount  int32                  `protobuf:"varint,2,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllDevicesResponse) Reset() {
+	*x = LogoutAllDevicesResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllDevicesResponse) ProtoMessage() {}
+
+func (x *LogoutAllDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllDevicesResponse.ProtoReflect.Descriptor instead.
+func (*LogoutAllDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LogoutAllDevicesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LogoutAllDevicesResponse) GetRevokedCount() int32 {
+	if x != nil {
+		return x.RevokedCount
+	}
+	return 0
+}
+
+func (x *LogoutAllDevicesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TokenId       string                 `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RevokeSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeSessionRequest) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RevokeSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ReqfreshTokenRequest - Client sends refresh token to get new access token
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// RefreshTokenResponse - Server returns new access token and refresh token
+type RefreshTokenResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken           string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken          string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	AccessTokenExpiresAt  int64                  `protobuf:"varint,3,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`    // Unix timestamp
+	RefreshTokenExpiresAt int64                  `protobuf:"varint,4,opt,name=refresh_token_expires_at,json=refreshTokenExpiresAt,proto3" json:"refresh_token_expires_at,omitempty"` // Unix timestamp
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RefreshTokenResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetAccessTokenExpiresAt() int64 {
+	if x != nil {
+		return x.AccessTokenExpiresAt
+	}
+	return 0
+}
+
+func (x *RefreshTokenResponse) GetRefreshTokenExpiresAt() int64 {
+	if x != nil {
+		return x.RefreshTokenExpiresAt
+	}
+	return 0
+}
+
+// LogoutRequest - Client sends refresh token and optionally access token to logout
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Required: refresh token to revoke
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`    // Optional: access token to blacklist for immediate revocation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LogoutRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// LogoutResponse - Server confirms logout
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LogoutResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LogoutResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetUserProfile - Get current user's profile
+type GetUserProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserProfileRequest) Reset() {
+	*x = GetUserProfileRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserProfileRequest) ProtoMessage() {}
+
+func (x *GetUserProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetUserProfileRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetUserProfileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserProfileResponse) Reset() {
+	*x = GetUserProfileResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserProfileResponse) ProtoMessage() {}
+
+func (x *GetUserProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserProfileResponse.ProtoReflect.Descriptor instead.
+func (*GetUserProfileResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetUserProfileResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// UpdateUserProfile - Update current user's profile - name and/or phone number
+type UpdateUserProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                // from jwt
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                  // optional
+	PhoneNumber   string                 `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"` // optional
+	OtpCode       string                 `protobuf:"bytes,4,opt,name=otp_code,json=otpCode,proto3" json:"otp_code,omitempty"`             // Bắt buộc khi đổi phone_number: OTP change_phone gửi tới số mới
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserProfileRequest) Reset() {
+	*x = UpdateUserProfileRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserProfileRequest) ProtoMessage() {}
+
+func (x *UpdateUserProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserProfileRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateUserProfileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateUserProfileRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *UpdateUserProfileRequest) GetOtpCode() string {
+	if x != nil {
+		return x.OtpCode
+	}
+	return ""
+}
+
+type UpdateUserProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserProfileResponse) Reset() {
+	*x = UpdateUserProfileResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserProfileResponse) ProtoMessage() {}
+
+func (x *UpdateUserProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserProfileResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserProfileResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateUserProfileResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UpdateUserProfileResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ChangePassword - Change current user's password
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // from jwt
+	OldPassword   string                 `protobuf:"bytes,2,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ChangePasswordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ChangePasswordResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ChangePasswordResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ListUser - Get paginated list of users (for admin use)
+type ListUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                                           // page number (1-indexed)
+	PageSize       int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                   // number of users per page, default 10, max 100
+	PlatformRole   string                 `protobuf:"bytes,3,opt,name=platform_role,json=platformRole,proto3" json:"platform_role,omitempty"`        // optional filter by platform role
+	IncludeDeleted bool                   `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"` // whether to include deleted users
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPlatformRole() string {
+	if x != nil {
+		return x.PlatformRole
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`    // total number of users matching the criteria
+	CurrentPage   int32                  `protobuf:"varint,3,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"` // current page number
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`          // number of users per page
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`    // total number of pages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+// SearchUsers - Search users by name or phone number (for admin use)
+type SearchUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`  // search query for name or phone number
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // max number of results to return, default 10, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersRequest) Reset() {
+	*x = SearchUsersRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersRequest) ProtoMessage() {}
+
+func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (*ChangePasswordRequest) ProtoMessage() {}
+// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{38}
+}
 
-func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[20]
+func (x *SearchUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // total number of users matching the search
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersResponse) Reset() {
+	*x = SearchUsersResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersResponse) ProtoMessage() {}
+
+func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,33 +2391,79 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
-func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ChangePasswordRequest) GetUserId() string {
+func (x *SearchUsersResponse) GetUsers() []*User {
 	if x != nil {
-		return x.UserId
+		return x.Users
 	}
-	return ""
+	return nil
 }
 
-func (x *ChangePasswordRequest) GetOldPassword() string {
+func (x *SearchUsersResponse) GetTotalCount() int32 {
 	if x != nil {
-		return x.OldPassword
+		return x.TotalCount
+	}
+	return 0
+}
+
+// DeleteUser - Soft delete a user by ID (for admin use)
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // reason for deletion
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *ChangePasswordRequest) GetNewPassword() string {
+func (x *DeleteUserRequest) GetReason() string {
 	if x != nil {
-		return x.NewPassword
+		return x.Reason
 	}
 	return ""
 }
 
-type ChangePasswordResponse struct {
+type DeleteUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
@@ -1254,21 +2471,21 @@ type ChangePasswordResponse struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePasswordResponse) Reset() {
-	*x = ChangePasswordResponse{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[21]
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePasswordResponse) String() string {
+func (x *DeleteUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePasswordResponse) ProtoMessage() {}
+func (*DeleteUserResponse) ProtoMessage() {}
 
-func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[21]
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1279,51 +2496,50 @@ func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
-func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *ChangePasswordResponse) GetSuccess() bool {
+func (x *DeleteUserResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *ChangePasswordResponse) GetMessage() string {
+func (x *DeleteUserResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-// ListUser - Get paginated list of users (for admin use)
-type ListUsersRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                                           // page number (1-indexed)
-	PageSize       int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                   // number of users per page, default 10, max 100
-	PlatformRole   string                 `protobuf:"bytes,3,opt,name=platform_role,json=platformRole,proto3" json:"platform_role,omitempty"`        // optional filter by platform role
-	IncludeDeleted bool                   `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"` // whether to include deleted users
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// EraseUser - Xóa dữ liệu cá nhân của user (for admin use), gọi lại trả về trạng thái hiện tại
+type EraseUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestedBy   string                 `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"` // Admin xử lý yêu cầu
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                              // Bắt buộc, vd: số phiếu yêu cầu của chủ thể dữ liệu
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersRequest) Reset() {
-	*x = ListUsersRequest{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[22]
+func (x *EraseUserRequest) Reset() {
+	*x = EraseUserRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersRequest) String() string {
+func (x *EraseUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersRequest) ProtoMessage() {}
+func (*EraseUserRequest) ProtoMessage() {}
 
-func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[22]
+func (x *EraseUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1334,65 +2550,58 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
-func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *ListUsersRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
+// Deprecated: Use EraseUserRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ListUsersRequest) GetPageSize() int32 {
+func (x *EraseUserRequest) GetUserId() string {
 	if x != nil {
-		return x.PageSize
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-func (x *ListUsersRequest) GetPlatformRole() string {
+func (x *EraseUserRequest) GetRequestedBy() string {
 	if x != nil {
-		return x.PlatformRole
+		return x.RequestedBy
 	}
 	return ""
 }
 
-func (x *ListUsersRequest) GetIncludeDeleted() bool {
+func (x *EraseUserRequest) GetReason() string {
 	if x != nil {
-		return x.IncludeDeleted
+		return x.Reason
 	}
-	return false
+	return ""
 }
 
-type ListUsersResponse struct {
+type EraseUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`    // total number of users matching the criteria
-	CurrentPage   int32                  `protobuf:"varint,3,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"` // current page number
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`          // number of users per page
-	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`    // total number of pages
+	SagaId        string                 `protobuf:"bytes,1,opt,name=saga_id,json=sagaId,proto3" json:"saga_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                              // "running" (đang chờ chạy lại bước lỗi) hoặc "completed"
+	CurrentStep   string                 `protobuf:"bytes,3,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"` // Bước chưa xong khi status = running
+	LastError     string                 `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Certificate   *ErasureCertificate    `protobuf:"bytes,5,opt,name=certificate,proto3" json:"certificate,omitempty"` // Chỉ có khi status = completed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersResponse) Reset() {
-	*x = ListUsersResponse{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[23]
+func (x *EraseUserResponse) Reset() {
+	*x = EraseUserResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersResponse) String() string {
+func (x *EraseUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersResponse) ProtoMessage() {}
+func (*EraseUserResponse) ProtoMessage() {}
 
-func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[23]
+func (x *EraseUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1403,70 +2612,78 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
-func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use EraseUserResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ListUsersResponse) GetUsers() []*User {
+func (x *EraseUserResponse) GetSagaId() string {
 	if x != nil {
-		return x.Users
+		return x.SagaId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListUsersResponse) GetTotalCount() int32 {
+func (x *EraseUserResponse) GetStatus() string {
 	if x != nil {
-		return x.TotalCount
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *ListUsersResponse) GetCurrentPage() int32 {
+func (x *EraseUserResponse) GetCurrentStep() string {
 	if x != nil {
-		return x.CurrentPage
+		return x.CurrentStep
 	}
-	return 0
+	return ""
 }
 
-func (x *ListUsersResponse) GetPageSize() int32 {
+func (x *EraseUserResponse) GetLastError() string {
 	if x != nil {
-		return x.PageSize
+		return x.LastError
 	}
-	return 0
+	return ""
 }
 
-func (x *ListUsersResponse) GetTotalPages() int32 {
+func (x *EraseUserResponse) GetCertificate() *ErasureCertificate {
 	if x != nil {
-		return x.TotalPages
+		return x.Certificate
 	}
-	return 0
-}
-
-// SearchUsers - Search users by name or phone number (for admin use)
-type SearchUsersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`  // search query for name or phone number
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // max number of results to return, default 10, max 100
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return nil
 }
 
-func (x *SearchUsersRequest) Reset() {
-	*x = SearchUsersRequest{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[24]
+// ErasureCertificate - Bằng chứng đã xóa dữ liệu, không chứa dữ liệu cá nhân
+type ErasureCertificate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestedBy      string                 `protobuf:"bytes,3,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	Reason           string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	UserErasedAt     int64                  `protobuf:"varint,5,opt,name=user_erased_at,json=userErasedAt,proto3" json:"user_erased_at,omitempty"`
+	SessionsScrubbed int32                  `protobuf:"varint,6,opt,name=sessions_scrubbed,json=sessionsScrubbed,proto3" json:"sessions_scrubbed,omitempty"`
+	ConsentsErased   int32                  `protobuf:"varint,7,opt,name=consents_erased,json=consentsErased,proto3" json:"consents_erased,omitempty"`
+	ConsentChainHead string                 `protobuf:"bytes,8,opt,name=consent_chain_head,json=consentChainHead,proto3" json:"consent_chain_head,omitempty"` // Hash cuối consent chain, đối chiếu với VerifyConsentChain
+	IssuedAt         int64                  `protobuf:"varint,9,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	CertificateHash  string                 `protobuf:"bytes,10,opt,name=certificate_hash,json=certificateHash,proto3" json:"certificate_hash,omitempty"` // SHA-256 của các field trên
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ErasureCertificate) Reset() {
+	*x = ErasureCertificate{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchUsersRequest) String() string {
+func (x *ErasureCertificate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchUsersRequest) ProtoMessage() {}
+func (*ErasureCertificate) ProtoMessage() {}
 
-func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[24]
+func (x *ErasureCertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1477,101 +2694,103 @@ func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
-func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ErasureCertificate.ProtoReflect.Descriptor instead.
+func (*ErasureCertificate) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *SearchUsersRequest) GetQuery() string {
+func (x *ErasureCertificate) GetId() string {
 	if x != nil {
-		return x.Query
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SearchUsersRequest) GetLimit() int32 {
+func (x *ErasureCertificate) GetUserId() string {
 	if x != nil {
-		return x.Limit
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-type SearchUsersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // total number of users matching the search
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ErasureCertificate) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
 }
 
-func (x *SearchUsersResponse) Reset() {
-	*x = SearchUsersResponse{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[25]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ErasureCertificate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (x *SearchUsersResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ErasureCertificate) GetUserErasedAt() int64 {
+	if x != nil {
+		return x.UserErasedAt
+	}
+	return 0
 }
 
-func (*SearchUsersResponse) ProtoMessage() {}
-
-func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[25]
+func (x *ErasureCertificate) GetSessionsScrubbed() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SessionsScrubbed
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
-func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{25}
+func (x *ErasureCertificate) GetConsentsErased() int32 {
+	if x != nil {
+		return x.ConsentsErased
+	}
+	return 0
 }
 
-func (x *SearchUsersResponse) GetUsers() []*User {
+func (x *ErasureCertificate) GetConsentChainHead() string {
 	if x != nil {
-		return x.Users
+		return x.ConsentChainHead
 	}
-	return nil
+	return ""
 }
 
-func (x *SearchUsersResponse) GetTotalCount() int32 {
+func (x *ErasureCertificate) GetIssuedAt() int64 {
 	if x != nil {
-		return x.TotalCount
+		return x.IssuedAt
 	}
 	return 0
 }
 
-// DeleteUser - Soft delete a user by ID (for admin use)
-type DeleteUserRequest struct {
+func (x *ErasureCertificate) GetCertificateHash() string {
+	if x != nil {
+		return x.CertificateHash
+	}
+	return ""
+}
+
+type GetErasureCertificateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // reason for deletion
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserRequest) Reset() {
-	*x = DeleteUserRequest{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[26]
+func (x *GetErasureCertificateRequest) Reset() {
+	*x = GetErasureCertificateRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserRequest) String() string {
+func (x *GetErasureCertificateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserRequest) ProtoMessage() {}
+func (*GetErasureCertificateRequest) ProtoMessage() {}
 
-func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[26]
+func (x *GetErasureCertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1582,48 +2801,40 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
-func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use GetErasureCertificateRequest.ProtoReflect.Descriptor instead.
+func (*GetErasureCertificateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *DeleteUserRequest) GetUserId() string {
+func (x *GetErasureCertificateRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *DeleteUserRequest) GetReason() string {
-	if x != nil {
-		return x.Reason
-	}
-	return ""
-}
-
-type DeleteUserResponse struct {
+type GetErasureCertificateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Certificate   *ErasureCertificate    `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserResponse) Reset() {
-	*x = DeleteUserResponse{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[27]
+func (x *GetErasureCertificateResponse) Reset() {
+	*x = GetErasureCertificateResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserResponse) String() string {
+func (x *GetErasureCertificateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserResponse) ProtoMessage() {}
+func (*GetErasureCertificateResponse) ProtoMessage() {}
 
-func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[27]
+func (x *GetErasureCertificateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1634,23 +2845,16 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
-func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *DeleteUserResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use GetErasureCertificateResponse.ProtoReflect.Descriptor instead.
+func (*GetErasureCertificateResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *DeleteUserResponse) GetMessage() string {
+func (x *GetErasureCertificateResponse) GetCertificate() *ErasureCertificate {
 	if x != nil {
-		return x.Message
+		return x.Certificate
 	}
-	return ""
+	return nil
 }
 
 // HardDeleteUser - Permanently delete a user (for rollback scenarios ONLY)
@@ -1663,7 +2867,7 @@ type HardDeleteUserRequest struct {
 
 func (x *HardDeleteUserRequest) Reset() {
 	*x = HardDeleteUserRequest{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[28]
+	mi := &file_pkg_api_user_user_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1675,7 +2879,7 @@ func (x *HardDeleteUserRequest) String() string {
 func (*HardDeleteUserRequest) ProtoMessage() {}
 
 func (x *HardDeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[28]
+	mi := &file_pkg_api_user_user_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1688,7 +2892,7 @@ func (x *HardDeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HardDeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*HardDeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{28}
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *HardDeleteUserRequest) GetUserId() string {
@@ -1708,7 +2912,7 @@ type HardDeleteUserResponse struct {
 
 func (x *HardDeleteUserResponse) Reset() {
 	*x = HardDeleteUserResponse{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[29]
+	mi := &file_pkg_api_user_user_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1720,7 +2924,7 @@ func (x *HardDeleteUserResponse) String() string {
 func (*HardDeleteUserResponse) ProtoMessage() {}
 
 func (x *HardDeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[29]
+	mi := &file_pkg_api_user_user_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1733,7 +2937,7 @@ func (x *HardDeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HardDeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*HardDeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{29}
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *HardDeleteUserResponse) GetSuccess() bool {
@@ -1761,7 +2965,7 @@ type UpdateUserRoleRequest struct {
 
 func (x *UpdateUserRoleRequest) Reset() {
 	*x = UpdateUserRoleRequest{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[30]
+	mi := &file_pkg_api_user_user_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1773,7 +2977,7 @@ func (x *UpdateUserRoleRequest) String() string {
 func (*UpdateUserRoleRequest) ProtoMessage() {}
 
 func (x *UpdateUserRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[30]
+	mi := &file_pkg_api_user_user_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1786,7 +2990,7 @@ func (x *UpdateUserRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRoleRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRoleRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{30}
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *UpdateUserRoleRequest) GetUserId() string {
@@ -1813,7 +3017,7 @@ type UpdateUserRoleResponse struct {
 
 func (x *UpdateUserRoleResponse) Reset() {
 	*x = UpdateUserRoleResponse{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[31]
+	mi := &file_pkg_api_user_user_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1825,7 +3029,7 @@ func (x *UpdateUserRoleResponse) String() string {
 func (*UpdateUserRoleResponse) ProtoMessage() {}
 
 func (x *UpdateUserRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[31]
+	mi := &file_pkg_api_user_user_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1838,7 +3042,7 @@ func (x *UpdateUserRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRoleResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserRoleResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{31}
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *UpdateUserRoleResponse) GetUser() *User {
@@ -1863,7 +3067,7 @@ type GetUserStatsRequest struct {
 
 func (x *GetUserStatsRequest) Reset() {
 	*x = GetUserStatsRequest{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[32]
+	mi := &file_pkg_api_user_user_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1875,7 +3079,7 @@ func (x *GetUserStatsRequest) String() string {
 func (*GetUserStatsRequest) ProtoMessage() {}
 
 func (x *GetUserStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[32]
+	mi := &file_pkg_api_user_user_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1888,7 +3092,7 @@ func (x *GetUserStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserStatsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{32}
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{51}
 }
 
 type GetUserStatsResponse struct {
@@ -1905,7 +3109,7 @@ type GetUserStatsResponse struct {
 
 func (x *GetUserStatsResponse) Reset() {
 	*x = GetUserStatsResponse{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[33]
+	mi := &file_pkg_api_user_user_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1917,7 +3121,7 @@ func (x *GetUserStatsResponse) String() string {
 func (*GetUserStatsResponse) ProtoMessage() {}
 
 func (x *GetUserStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[33]
+	mi := &file_pkg_api_user_user_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1930,7 +3134,7 @@ func (x *GetUserStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserStatsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{33}
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *GetUserStatsResponse) GetTotalUsers() int32 {
@@ -1985,7 +3189,7 @@ type IsTokenBlacklistedRequest struct {
 
 func (x *IsTokenBlacklistedRequest) Reset() {
 	*x = IsTokenBlacklistedRequest{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[34]
+	mi := &file_pkg_api_user_user_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1997,7 +3201,7 @@ func (x *IsTokenBlacklistedRequest) String() string {
 func (*IsTokenBlacklistedRequest) ProtoMessage() {}
 
 func (x *IsTokenBlacklistedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[34]
+	mi := &file_pkg_api_user_user_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2010,7 +3214,7 @@ func (x *IsTokenBlacklistedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsTokenBlacklistedRequest.ProtoReflect.Descriptor instead.
 func (*IsTokenBlacklistedRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{34}
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *IsTokenBlacklistedRequest) GetJti() string {
@@ -2029,7 +3233,7 @@ type IsTokenBlacklistedResponse struct {
 
 func (x *IsTokenBlacklistedResponse) Reset() {
 	*x = IsTokenBlacklistedResponse{}
-	mi := &file_pkg_api_user_user_proto_msgTypes[35]
+	mi := &file_pkg_api_user_user_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2041,7 +3245,7 @@ func (x *IsTokenBlacklistedResponse) String() string {
 func (*IsTokenBlacklistedResponse) ProtoMessage() {}
 
 func (x *IsTokenBlacklistedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_user_user_proto_msgTypes[35]
+	mi := &file_pkg_api_user_user_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2054,7 +3258,7 @@ func (x *IsTokenBlacklistedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsTokenBlacklistedResponse.ProtoReflect.Descriptor instead.
 func (*IsTokenBlacklistedResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{35}
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *IsTokenBlacklistedResponse) GetIsBlacklisted() bool {
@@ -2064,11 +3268,208 @@ func (x *IsTokenBlacklistedResponse) GetIsBlacklisted() bool {
 	return false
 }
 
+// GetSigningKeys - Public keys used to sign access tokens (JWK, RFC 7517)
+type GetSigningKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSigningKeysRequest) Reset() {
+	*x = GetSigningKeysRequest{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSigningKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningKeysRequest) ProtoMessage() {}
+
+func (x *GetSigningKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetSigningKeysRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{55}
+}
+
+type SigningKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kid           string                 `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`                                     // Khớp header "kid" của access token
+	Kty           string                 `protobuf:"bytes,2,opt,name=kty,proto3" json:"kty,omitempty"`                                     // "RSA" | "OKP"
+	Alg           string                 `protobuf:"bytes,3,opt,name=alg,proto3" json:"alg,omitempty"`                                     // "RS256" | "EdDSA"
+	Use           string                 `protobuf:"bytes,4,opt,name=use,proto3" json:"use,omitempty"`                                     // "sig"
+	Crv           string                 `protobuf:"bytes,5,opt,name=crv,proto3" json:"crv,omitempty"`                                     // OKP: "Ed25519"
+	X             string                 `protobuf:"bytes,6,opt,name=x,proto3" json:"x,omitempty"`                                         // OKP: public key (base64url)
+	N             string                 `protobuf:"bytes,7,opt,name=n,proto3" json:"n,omitempty"`                                         // RSA: modulus (base64url)
+	E             string                 `protobuf:"bytes,8,opt,name=e,proto3" json:"e,omitempty"`                                         // RSA: exponent (base64url)
+	ActivatesAt   int64                  `protobuf:"varint,9,opt,name=activates_at,json=activatesAt,proto3" json:"activates_at,omitempty"` // Bắt đầu ký từ thời điểm này (key được publish trước)
+	ExpiresAt     int64                  `protobuf:"varint,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`      // 0 = đang dùng; sau thời điểm này không còn token hợp lệ ký bằng key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SigningKey) Reset() {
+	*x = SigningKey{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SigningKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigningKey) ProtoMessage() {}
+
+func (x *SigningKey) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigningKey.ProtoReflect.Descriptor instead.
+func (*SigningKey) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SigningKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *SigningKey) GetKty() string {
+	if x != nil {
+		return x.Kty
+	}
+	return ""
+}
+
+func (x *SigningKey) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
+}
+
+func (x *SigningKey) GetUse() string {
+	if x != nil {
+		return x.Use
+	}
+	return ""
+}
+
+func (x *SigningKey) GetCrv() string {
+	if x != nil {
+		return x.Crv
+	}
+	return ""
+}
+
+func (x *SigningKey) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *SigningKey) GetN() string {
+	if x != nil {
+		return x.N
+	}
+	return ""
+}
+
+func (x *SigningKey) GetE() string {
+	if x != nil {
+		return x.E
+	}
+	return ""
+}
+
+func (x *SigningKey) GetActivatesAt() int64 {
+	if x != nil {
+		return x.ActivatesAt
+	}
+	return 0
+}
+
+func (x *SigningKey) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type GetSigningKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*SigningKey          `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSigningKeysResponse) Reset() {
+	*x = GetSigningKeysResponse{}
+	mi := &file_pkg_api_user_user_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSigningKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningKeysResponse) ProtoMessage() {}
+
+func (x *GetSigningKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_user_user_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetSigningKeysResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_user_user_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetSigningKeysResponse) GetKeys() []*SigningKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
 var File_pkg_api_user_user_proto protoreflect.FileDescriptor
 
 const file_pkg_api_user_user_proto_rawDesc = "" +
 	"\n" +
-	"\x17pkg/api/user/user.proto\x12\x04user\"\xb0\x01\n" +
+	"\x17pkg/api/user/user.proto\x12\x04user\"\xd7\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\x12\x12\n" +
@@ -2077,7 +3478,8 @@ const file_pkg_api_user_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"\x89\x01\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x12%\n" +
+	"\x0ephone_verified\x18\a \x01(\bR\rphoneVerified\"\x89\x01\n" +
 	"\x0fRegisterRequest\x12!\n" +
 	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
@@ -2089,17 +3491,79 @@ const file_pkg_api_user_user_proto_rawDesc = "" +
 	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\x125\n" +
 	"\x17access_token_expires_at\x18\x04 \x01(\x03R\x14accessTokenExpiresAt\x127\n" +
-	"\x18refresh_token_expires_at\x18\x05 \x01(\x03R\x15refreshTokenExpiresAt\"M\n" +
+	"\x18refresh_token_expires_at\x18\x05 \x01(\x03R\x15refreshTokenExpiresAt\"\xea\x01\n" +
+	"\x1aRegisterWithConsentRequest\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
+	"\rplatform_role\x18\x04 \x01(\tR\fplatformRole\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x06 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\a \x01(\tR\tuserAgent\"\xbb\x02\n" +
+	"\x1bRegisterWithConsentResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\x125\n" +
+	"\x17access_token_expires_at\x18\x04 \x01(\x03R\x14accessTokenExpiresAt\x127\n" +
+	"\x18refresh_token_expires_at\x18\x05 \x01(\x03R\x15refreshTokenExpiresAt\x12+\n" +
+	"\x11consents_recorded\x18\x06 \x01(\x05R\x10consentsRecorded\x12\x17\n" +
+	"\asaga_id\x18\a \x01(\tR\x06sagaId\"M\n" +
 	"\fLoginRequest\x12!\n" +
 	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xe7\x01\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x90\x03\n" +
 	"\rLoginResponse\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
 	".user.UserR\x04user\x12!\n" +
 	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\x125\n" +
 	"\x17access_token_expires_at\x18\x04 \x01(\x03R\x14accessTokenExpiresAt\x127\n" +
-	"\x18refresh_token_expires_at\x18\x05 \x01(\x03R\x15refreshTokenExpiresAt\"\xa0\x01\n" +
+	"\x18refresh_token_expires_at\x18\x05 \x01(\x03R\x15refreshTokenExpiresAt\x12!\n" +
+	"\fmfa_required\x18\x06 \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\a \x01(\tR\bmfaToken\x12/\n" +
+	"\x14mfa_token_expires_at\x18\b \x01(\x03R\x11mfaTokenExpiresAt\x126\n" +
+	"\x17mfa_enrollment_required\x18\t \x01(\bR\x15mfaEnrollmentRequired\"C\n" +
+	"\x10VerifyMFARequest\x12\x1b\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"I\n" +
+	"\x11EnrollTOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmfa_token\x18\x02 \x01(\tR\bmfaToken\"M\n" +
+	"\x12EnrollTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_uri\x18\x02 \x01(\tR\n" +
+	"otpauthUri\"^\n" +
+	"\x12ConfirmTOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmfa_token\x18\x02 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\"g\n" +
+	"\x13ConfirmTOTPResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\x12)\n" +
+	"\x05login\x18\x02 \x01(\v2\x13.user.LoginResponseR\x05login\"A\n" +
+	"\x12DisableTOTPRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"/\n" +
+	"\x13DisableTOTPResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"k\n" +
+	"\x13SendPhoneOTPRequest\x12\x18\n" +
+	"\apurpose\x18\x01 \x01(\tR\apurpose\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\"X\n" +
+	"\x14SendPhoneOTPResponse\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x01 \x01(\x03R\texpiresAt\x12!\n" +
+	"\fresend_after\x18\x02 \x01(\x03R\vresendAfter\"A\n" +
+	"\x12VerifyPhoneRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"5\n" +
+	"\x13VerifyPhoneResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"L\n" +
+	"\x13LoginWithOTPRequest\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xa0\x01\n" +
 	"\x10RefreshTokenInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vdevice_info\x18\x02 \x01(\tR\n" +
@@ -2145,11 +3609,12 @@ const file_pkg_api_user_user_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"8\n" +
 	"\x16GetUserProfileResponse\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
-	".user.UserR\x04user\"j\n" +
+	".user.UserR\x04user\"\x85\x01\n" +
 	"\x18UpdateUserProfileRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
-	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\"U\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\x12\x19\n" +
+	"\botp_code\x18\x04 \x01(\tR\aotpCode\"U\n" +
 	"\x19UpdateUserProfileResponse\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
 	".user.UserR\x04user\x12\x18\n" +
@@ -2188,7 +3653,34 @@ const file_pkg_api_user_user_proto_rawDesc = "" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\"H\n" +
 	"\x12DeleteUserResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"0\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"f\n" +
+	"\x10EraseUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\frequested_by\x18\x02 \x01(\tR\vrequestedBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xc2\x01\n" +
+	"\x11EraseUserResponse\x12\x17\n" +
+	"\asaga_id\x18\x01 \x01(\tR\x06sagaId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\fcurrent_step\x18\x03 \x01(\tR\vcurrentStep\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x04 \x01(\tR\tlastError\x12:\n" +
+	"\vcertificate\x18\x05 \x01(\v2\x18.user.ErasureCertificateR\vcertificate\"\xea\x02\n" +
+	"\x12ErasureCertificate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\frequested_by\x18\x03 \x01(\tR\vrequestedBy\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12$\n" +
+	"\x0euser_erased_at\x18\x05 \x01(\x03R\fuserErasedAt\x12+\n" +
+	"\x11sessions_scrubbed\x18\x06 \x01(\x05R\x10sessionsScrubbed\x12'\n" +
+	"\x0fconsents_erased\x18\a \x01(\x05R\x0econsentsErased\x12,\n" +
+	"\x12consent_chain_head\x18\b \x01(\tR\x10consentChainHead\x12\x1b\n" +
+	"\tissued_at\x18\t \x01(\x03R\bissuedAt\x12)\n" +
+	"\x10certificate_hash\x18\n" +
+	" \x01(\tR\x0fcertificateHash\"7\n" +
+	"\x1cGetErasureCertificateRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"[\n" +
+	"\x1dGetErasureCertificateResponse\x12:\n" +
+	"\vcertificate\x18\x01 \x01(\v2\x18.user.ErasureCertificateR\vcertificate\"0\n" +
 	"\x15HardDeleteUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"L\n" +
 	"\x16HardDeleteUserResponse\x12\x18\n" +
@@ -2213,12 +3705,38 @@ const file_pkg_api_user_user_proto_rawDesc = "" +
 	"\x19IsTokenBlacklistedRequest\x12\x10\n" +
 	"\x03jti\x18\x01 \x01(\tR\x03jti\"C\n" +
 	"\x1aIsTokenBlacklistedResponse\x12%\n" +
-	"\x0eis_blacklisted\x18\x01 \x01(\bR\risBlacklisted2\xd6\t\n" +
+	"\x0eis_blacklisted\x18\x01 \x01(\bR\risBlacklisted\"\x17\n" +
+	"\x15GetSigningKeysRequest\"\xd2\x01\n" +
+	"\n" +
+	"SigningKey\x12\x10\n" +
+	"\x03kid\x18\x01 \x01(\tR\x03kid\x12\x10\n" +
+	"\x03kty\x18\x02 \x01(\tR\x03kty\x12\x10\n" +
+	"\x03alg\x18\x03 \x01(\tR\x03alg\x12\x10\n" +
+	"\x03use\x18\x04 \x01(\tR\x03use\x12\x10\n" +
+	"\x03crv\x18\x05 \x01(\tR\x03crv\x12\f\n" +
+	"\x01x\x18\x06 \x01(\tR\x01x\x12\f\n" +
+	"\x01n\x18\a \x01(\tR\x01n\x12\f\n" +
+	"\x01e\x18\b \x01(\tR\x01e\x12!\n" +
+	"\factivates_at\x18\t \x01(\x03R\vactivatesAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\x03R\texpiresAt\">\n" +
+	"\x16GetSigningKeysResponse\x12$\n" +
+	"\x04keys\x18\x01 \x03(\v2\x10.user.SigningKeyR\x04keys2\xed\x0f\n" +
 	"\vUserService\x129\n" +
-	"\bRegister\x12\x15.user.RegisterRequest\x1a\x16.user.RegisterResponse\x120\n" +
+	"\bRegister\x12\x15.user.RegisterRequest\x1a\x16.user.RegisterResponse\x12Z\n" +
+	"\x13RegisterWithConsent\x12 .user.RegisterWithConsentRequest\x1a!.user.RegisterWithConsentResponse\x120\n" +
 	"\x05Login\x12\x12.user.LoginRequest\x1a\x13.user.LoginResponse\x12E\n" +
 	"\fRefreshToken\x12\x19.user.RefreshTokenRequest\x1a\x1a.user.RefreshTokenResponse\x123\n" +
-	"\x06Logout\x12\x13.user.LogoutRequest\x1a\x14.user.LogoutResponse\x12K\n" +
+	"\x06Logout\x12\x13.user.LogoutRequest\x1a\x14.user.LogoutResponse\x128\n" +
+	"\tVerifyMFA\x12\x16.user.VerifyMFARequest\x1a\x13.user.LoginResponse\x12?\n" +
+	"\n" +
+	"EnrollTOTP\x12\x17.user.EnrollTOTPRequest\x1a\x18.user.EnrollTOTPResponse\x12B\n" +
+	"\vConfirmTOTP\x12\x18.user.ConfirmTOTPRequest\x1a\x19.user.ConfirmTOTPResponse\x12B\n" +
+	"\vDisableTOTP\x12\x18.user.DisableTOTPRequest\x1a\x19.user.DisableTOTPResponse\x12E\n" +
+	"\fSendPhoneOTP\x12\x19.user.SendPhoneOTPRequest\x1a\x1a.user.SendPhoneOTPResponse\x12B\n" +
+	"\vVerifyPhone\x12\x18.user.VerifyPhoneRequest\x1a\x19.user.VerifyPhoneResponse\x12>\n" +
+	"\fLoginWithOTP\x12\x19.user.LoginWithOTPRequest\x1a\x13.user.LoginResponse\x12K\n" +
 	"\x0eGetUserProfile\x12\x1b.user.GetUserProfileRequest\x1a\x1c.user.GetUserProfileResponse\x12T\n" +
 	"\x11UpdateUserProfile\x12\x1e.user.UpdateUserProfileRequest\x1a\x1f.user.UpdateUserProfileResponse\x12K\n" +
 	"\x0eChangePassword\x12\x1b.user.ChangePasswordRequest\x1a\x1c.user.ChangePasswordResponse\x12<\n" +
@@ -2227,12 +3745,15 @@ const file_pkg_api_user_user_proto_rawDesc = "" +
 	"\n" +
 	"DeleteUser\x12\x17.user.DeleteUserRequest\x1a\x18.user.DeleteUserResponse\x12K\n" +
 	"\x0eHardDeleteUser\x12\x1b.user.HardDeleteUserRequest\x1a\x1c.user.HardDeleteUserResponse\x12K\n" +
-	"\x0eUpdateUserRole\x12\x1b.user.UpdateUserRoleRequest\x1a\x1c.user.UpdateUserRoleResponse\x12T\n" +
+	"\x0eUpdateUserRole\x12\x1b.user.UpdateUserRoleRequest\x1a\x1c.user.UpdateUserRoleResponse\x12<\n" +
+	"\tEraseUser\x12\x16.user.EraseUserRequest\x1a\x17.user.EraseUserResponse\x12`\n" +
+	"\x15GetErasureCertificate\x12\".user.GetErasureCertificateRequest\x1a#.user.GetErasureCertificateResponse\x12T\n" +
 	"\x11GetActiveSessions\x12\x1e.user.GetActiveSessionsRequest\x1a\x1f.user.GetActiveSessionsResponse\x12Q\n" +
 	"\x10LogoutAllDevices\x12\x1d.user.LogoutAllDevicesRequest\x1a\x1e.user.LogoutAllDevicesResponse\x12H\n" +
 	"\rRevokeSession\x12\x1a.user.RevokeSessionRequest\x1a\x1b.user.RevokeSessionResponse\x12E\n" +
 	"\fGetUserStats\x12\x19.user.GetUserStatsRequest\x1a\x1a.user.GetUserStatsResponse\x12W\n" +
-	"\x12IsTokenBlacklisted\x12\x1f.user.IsTokenBlacklistedRequest\x1a .user.IsTokenBlacklistedResponseB9Z7github.com/thatlq1812/policy-system/shared/pkg/api/userb\x06proto3"
+	"\x12IsTokenBlacklisted\x12\x1f.user.IsTokenBlacklistedRequest\x1a .user.IsTokenBlacklistedResponse\x12K\n" +
+	"\x0eGetSigningKeys\x12\x1b.user.GetSigningKeysRequest\x1a\x1c.user.GetSigningKeysResponseB9Z7github.com/thatlq1812/policy-system/shared/pkg/api/userb\x06proto3"
 
 var (
 	file_pkg_api_user_user_proto_rawDescOnce sync.Once
@@ -2246,93 +3767,143 @@ func file_pkg_api_user_user_proto_rawDescGZIP() []byte {
 	return file_pkg_api_user_user_proto_rawDescData
 }
 
-var file_pkg_api_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_pkg_api_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_pkg_api_user_user_proto_goTypes = []any{
-	(*User)(nil),                       // 0: user.User
-	(*RegisterRequest)(nil),            // 1: user.RegisterRequest
-	(*RegisterResponse)(nil),           // 2: user.RegisterResponse
-	(*LoginRequest)(nil),               // 3: user.LoginRequest
-	(*LoginResponse)(nil),              // 4: user.LoginResponse
-	(*RefreshTokenInfo)(nil),           // 5: user.RefreshTokenInfo
-	(*GetActiveSessionsRequest)(nil),   // 6: user.GetActiveSessionsRequest
-	(*GetActiveSessionsResponse)(nil),  // 7: user.GetActiveSessionsResponse
-	(*LogoutAllDevicesRequest)(nil),    // 8: user.LogoutAllDevicesRequest
-	(*LogoutAllDevicesResponse)(nil),   // 9: user.LogoutAllDevicesResponse
-	(*RevokeSessionRequest)(nil),       // 10: user.RevokeSessionRequest
-	(*RevokeSessionResponse)(nil),      // 11: user.RevokeSessionResponse
-	(*RefreshTokenRequest)(nil),        // 12: user.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),       // 13: user.RefreshTokenResponse
-	(*LogoutRequest)(nil),              // 14: user.LogoutRequest
-	(*LogoutResponse)(nil),             // 15: user.LogoutResponse
-	(*GetUserProfileRequest)(nil),      // 16: user.GetUserProfileRequest
-	(*GetUserProfileResponse)(nil),     // 17: user.GetUserProfileResponse
-	(*UpdateUserProfileRequest)(nil),   // 18: user.UpdateUserProfileRequest
-	(*UpdateUserProfileResponse)(nil),  // 19: user.UpdateUserProfileResponse
-	(*ChangePasswordRequest)(nil),      // 20: user.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil),     // 21: user.ChangePasswordResponse
-	(*ListUsersRequest)(nil),           // 22: user.ListUsersRequest
-	(*ListUsersResponse)(nil),          // 23: user.ListUsersResponse
-	(*SearchUsersRequest)(nil),         // 24: user.SearchUsersRequest
-	(*SearchUsersResponse)(nil),        // 25: user.SearchUsersResponse
-	(*DeleteUserRequest)(nil),          // 26: user.DeleteUserRequest
-	(*DeleteUserResponse)(nil),         // 27: user.DeleteUserResponse
-	(*HardDeleteUserRequest)(nil),      // 28: user.HardDeleteUserRequest
-	(*HardDeleteUserResponse)(nil),     // 29: user.HardDeleteUserResponse
-	(*UpdateUserRoleRequest)(nil),      // 30: user.UpdateUserRoleRequest
-	(*UpdateUserRoleResponse)(nil),     // 31: user.UpdateUserRoleResponse
-	(*GetUserStatsRequest)(nil),        // 32: user.GetUserStatsRequest
-	(*GetUserStatsResponse)(nil),       // 33: user.GetUserStatsResponse
-	(*IsTokenBlacklistedRequest)(nil),  // 34: user.IsTokenBlacklistedRequest
-	(*IsTokenBlacklistedResponse)(nil), // 35: user.IsTokenBlacklistedResponse
+	(*User)(nil),                          // 0: user.User
+	(*RegisterRequest)(nil),               // 1: user.RegisterRequest
+	(*RegisterResponse)(nil),              // 2: user.RegisterResponse
+	(*RegisterWithConsentRequest)(nil),    // 3: user.RegisterWithConsentRequest
+	(*RegisterWithConsentResponse)(nil),   // 4: user.RegisterWithConsentResponse
+	(*LoginRequest)(nil),                  // 5: user.LoginRequest
+	(*LoginResponse)(nil),                 // 6: user.LoginResponse
+	(*VerifyMFARequest)(nil),              // 7: user.VerifyMFARequest
+	(*EnrollTOTPRequest)(nil),             // 8: user.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),            // 9: user.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),            // 10: user.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),           // 11: user.ConfirmTOTPResponse
+	(*DisableTOTPRequest)(nil),            // 12: user.DisableTOTPRequest
+	(*DisableTOTPResponse)(nil),           // 13: user.DisableTOTPResponse
+	(*SendPhoneOTPRequest)(nil),           // 14: user.SendPhoneOTPRequest
+	(*SendPhoneOTPResponse)(nil),          // 15: user.SendPhoneOTPResponse
+	(*VerifyPhoneRequest)(nil),            // 16: user.VerifyPhoneRequest
+	(*VerifyPhoneResponse)(nil),           // 17: user.VerifyPhoneResponse
+	(*LoginWithOTPRequest)(nil),           // 18: user.LoginWithOTPRequest
+	(*RefreshTokenInfo)(nil),              // 19: user.RefreshTokenInfo
+	(*GetActiveSessionsRequest)(nil),      // 20: user.GetActiveSessionsRequest
+	(*GetActiveSessionsResponse)(nil),     // 21: user.GetActiveSessionsResponse
+	(*LogoutAllDevicesRequest)(nil),       // 22: user.LogoutAllDevicesRequest
+	(*LogoutAllDevicesResponse)(nil),      // 23: user.LogoutAllDevicesResponse
+	(*RevokeSessionRequest)(nil),          // 24: user.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),         // 25: user.RevokeSessionResponse
+	(*RefreshTokenRequest)(nil),           // 26: user.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),          // 27: user.RefreshTokenResponse
+	(*LogoutRequest)(nil),                 // 28: user.LogoutRequest
+	(*LogoutResponse)(nil),                // 29: user.LogoutResponse
+	(*GetUserProfileRequest)(nil),         // 30: user.GetUserProfileRequest
+	(*GetUserProfileResponse)(nil),        // 31: user.GetUserProfileResponse
+	(*UpdateUserProfileRequest)(nil),      // 32: user.UpdateUserProfileRequest
+	(*UpdateUserProfileResponse)(nil),     // 33: user.UpdateUserProfileResponse
+	(*ChangePasswordRequest)(nil),         // 34: user.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),        // 35: user.ChangePasswordResponse
+	(*ListUsersRequest)(nil),              // 36: user.ListUsersRequest
+	(*ListUsersResponse)(nil),             // 37: user.ListUsersResponse
+	(*SearchUsersRequest)(nil),            // 38: user.SearchUsersRequest
+	(*SearchUsersResponse)(nil),           // 39: user.SearchUsersResponse
+	(*DeleteUserRequest)(nil),             // 40: user.DeleteUserRequest
+	(*DeleteUserResponse)(nil),            // 41: user.DeleteUserResponse
+	(*EraseUserRequest)(nil),              // 42: user.EraseUserRequest
+	(*EraseUserResponse)(nil),             // 43: user.EraseUserResponse
+	(*ErasureCertificate)(nil),            // 44: user.ErasureCertificate
+	(*GetErasureCertificateRequest)(nil),  // 45: user.GetErasureCertificateRequest
+	(*GetErasureCertificateResponse)(nil), // 46: user.GetErasureCertificateResponse
+	(*HardDeleteUserRequest)(nil),         // 47: user.HardDeleteUserRequest
+	(*HardDeleteUserResponse)(nil),        // 48: user.HardDeleteUserResponse
+	(*UpdateUserRoleRequest)(nil),         // 49: user.UpdateUserRoleRequest
+	(*UpdateUserRoleResponse)(nil),        // 50: user.UpdateUserRoleResponse
+	(*GetUserStatsRequest)(nil),           // 51: user.GetUserStatsRequest
+	(*GetUserStatsResponse)(nil),          // 52: user.GetUserStatsResponse
+	(*IsTokenBlacklistedRequest)(nil),     // 53: user.IsTokenBlacklistedRequest
+	(*IsTokenBlacklistedResponse)(nil),    // 54: user.IsTokenBlacklistedResponse
+	(*GetSigningKeysRequest)(nil),         // 55: user.GetSigningKeysRequest
+	(*SigningKey)(nil),                    // 56: user.SigningKey
+	(*GetSigningKeysResponse)(nil),        // 57: user.GetSigningKeysResponse
 }
 var file_pkg_api_user_user_proto_depIdxs = []int32{
 	0,  // 0: user.RegisterResponse.user:type_name -> user.User
-	0,  // 1: user.LoginResponse.user:type_name -> user.User
-	5,  // 2: user.GetActiveSessionsResponse.sessions:type_name -> user.RefreshTokenInfo
-	0,  // 3: user.GetUserProfileResponse.user:type_name -> user.User
-	0,  // 4: user.UpdateUserProfileResponse.user:type_name -> user.User
-	0,  // 5: user.ListUsersResponse.users:type_name -> user.User
-	0,  // 6: user.SearchUsersResponse.users:type_name -> user.User
-	0,  // 7: user.UpdateUserRoleResponse.user:type_name -> user.User
-	1,  // 8: user.UserService.Register:input_type -> user.RegisterRequest
-	3,  // 9: user.UserService.Login:input_type -> user.LoginRequest
-	12, // 10: user.UserService.RefreshToken:input_type -> user.RefreshTokenRequest
-	14, // 11: user.UserService.Logout:input_type -> user.LogoutRequest
-	16, // 12: user.UserService.GetUserProfile:input_type -> user.GetUserProfileRequest
-	18, // 13: user.UserService.UpdateUserProfile:input_type -> user.UpdateUserProfileRequest
-	20, // 14: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
-	22, // 15: user.UserService.ListUsers:input_type -> user.ListUsersRequest
-	24, // 16: user.UserService.SearchUsers:input_type -> user.SearchUsersRequest
-	26, // 17: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
-	28, // 18: user.UserService.HardDeleteUser:input_type -> user.HardDeleteUserRequest
-	30, // 19: user.UserService.UpdateUserRole:input_type -> user.UpdateUserRoleRequest
-	6,  // 20: user.UserService.GetActiveSessions:input_type -> user.GetActiveSessionsRequest
-	8,  // 21: user.UserService.LogoutAllDevices:input_type -> user.LogoutAllDevicesRequest
-	10, // 22: user.UserService.RevokeSession:input_type -> user.RevokeSessionRequest
-	32, // 23: user.UserService.GetUserStats:input_type -> user.GetUserStatsRequest
-	34, // 24: user.UserService.IsTokenBlacklisted:input_type -> user.IsTokenBlacklistedRequest
-	2,  // 25: user.UserService.Register:output_type -> user.RegisterResponse
-	4,  // 26: user.UserService.Login:output_type -> user.LoginResponse
-	13, // 27: user.UserService.RefreshToken:output_type -> user.RefreshTokenResponse
-	15, // 28: user.UserService.Logout:output_type -> user.LogoutResponse
-	17, // 29: user.UserService.GetUserProfile:output_type -> user.GetUserProfileResponse
-	19, // 30: user.UserService.UpdateUserProfile:output_type -> user.UpdateUserProfileResponse
-	21, // 31: user.UserService.ChangePassword:output_type -> user.ChangePasswordResponse
-	23, // 32: user.UserService.ListUsers:output_type -> user.ListUsersResponse
-	25, // 33: user.UserService.SearchUsers:output_type -> user.SearchUsersResponse
-	27, // 34: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
-	29, // 35: user.UserService.HardDeleteUser:output_type -> user.HardDeleteUserResponse
-	31, // 36: user.UserService.UpdateUserRole:output_type -> user.UpdateUserRoleResponse
-	7,  // 37: user.UserService.GetActiveSessions:output_type -> user.GetActiveSessionsResponse
-	9,  // 38: user.UserService.LogoutAllDevices:output_type -> user.LogoutAllDevicesResponse
-	11, // 39: user.UserService.RevokeSession:output_type -> user.RevokeSessionResponse
-	33, // 40: user.UserService.GetUserStats:output_type -> user.GetUserStatsResponse
-	35, // 41: user.UserService.IsTokenBlacklisted:output_type -> user.IsTokenBlacklistedResponse
-	25, // [25:42] is the sub-list for method output_type
-	8,  // [8:25] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	0,  // 1: user.RegisterWithConsentResponse.user:type_name -> user.User
+	0,  // 2: user.LoginResponse.user:type_name -> user.User
+	6,  // 3: user.ConfirmTOTPResponse.login:type_name -> user.LoginResponse
+	0,  // 4: user.VerifyPhoneResponse.user:type_name -> user.User
+	19, // 5: user.GetActiveSessionsResponse.sessions:type_name -> user.RefreshTokenInfo
+	0,  // 6: user.GetUserProfileResponse.user:type_name -> user.User
+	0,  // 7: user.UpdateUserProfileResponse.user:type_name -> user.User
+	0,  // 8: user.ListUsersResponse.users:type_name -> user.User
+	0,  // 9: user.SearchUsersResponse.users:type_name -> user.User
+	44, // 10: user.EraseUserResponse.certificate:type_name -> user.ErasureCertificate
+	44, // 11: user.GetErasureCertificateResponse.certificate:type_name -> user.ErasureCertificate
+	0,  // 12: user.UpdateUserRoleResponse.user:type_name -> user.User
+	56, // 13: user.GetSigningKeysResponse.keys:type_name -> user.SigningKey
+	1,  // 14: user.UserService.Register:input_type -> user.RegisterRequest
+	3,  // 15: user.UserService.RegisterWithConsent:input_type -> user.RegisterWithConsentRequest
+	5,  // 16: user.UserService.Login:input_type -> user.LoginRequest
+	26, // 17: user.UserService.RefreshToken:input_type -> user.RefreshTokenRequest
+	28, // 18: user.UserService.Logout:input_type -> user.LogoutRequest
+	7,  // 19: user.UserService.VerifyMFA:input_type -> user.VerifyMFARequest
+	8,  // 20: user.UserService.EnrollTOTP:input_type -> user.EnrollTOTPRequest
+	10, // 21: user.UserService.ConfirmTOTP:input_type -> user.ConfirmTOTPRequest
+	12, // 22: user.UserService.DisableTOTP:input_type -> user.DisableTOTPRequest
+	14, // 23: user.UserService.SendPhoneOTP:input_type -> user.SendPhoneOTPRequest
+	16, // 24: user.UserService.VerifyPhone:input_type -> user.VerifyPhoneRequest
+	18, // 25: user.UserService.LoginWithOTP:input_type -> user.LoginWithOTPRequest
+	30, // 26: user.UserService.GetUserProfile:input_type -> user.GetUserProfileRequest
+	32, // 27: user.UserService.UpdateUserProfile:input_type -> user.UpdateUserProfileRequest
+	34, // 28: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
+	36, // 29: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	38, // 30: user.UserService.SearchUsers:input_type -> user.SearchUsersRequest
+	40, // 31: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
+	47, // 32: user.UserService.HardDeleteUser:input_type -> user.HardDeleteUserRequest
+	49, // 33: user.UserService.UpdateUserRole:input_type -> user.UpdateUserRoleRequest
+	42, // 34: user.UserService.EraseUser:input_type -> user.EraseUserRequest
+	45, // 35: user.UserService.GetErasureCertificate:input_type -> user.GetErasureCertificateRequest
+	20, // 36: user.UserService.GetActiveSessions:input_type -> user.GetActiveSessionsRequest
+	22, // 37: user.UserService.LogoutAllDevices:input_type -> user.LogoutAllDevicesRequest
+	24, // 38: user.UserService.RevokeSession:input_type -> user.RevokeSessionRequest
+	51, // 39: user.UserService.GetUserStats:input_type -> user.GetUserStatsRequest
+	53, // 40: user.UserService.IsTokenBlacklisted:input_type -> user.IsTokenBlacklistedRequest
+	55, // 41: user.UserService.GetSigningKeys:input_type -> user.GetSigningKeysRequest
+	2,  // 42: user.UserService.Register:output_type -> user.RegisterResponse
+	4,  // 43: user.UserService.RegisterWithConsent:output_type -> user.RegisterWithConsentResponse
+	6,  // 44: user.UserService.Login:output_type -> user.LoginResponse
+	27, // 45: user.UserService.RefreshToken:output_type -> user.RefreshTokenResponse
+	29, // 46: user.UserService.Logout:output_type -> user.LogoutResponse
+	6,  // 47: user.UserService.VerifyMFA:output_type -> user.LoginResponse
+	9,  // 48: user.UserService.EnrollTOTP:output_type -> user.EnrollTOTPResponse
+	11, // 49: user.UserService.ConfirmTOTP:output_type -> user.ConfirmTOTPResponse
+	13, // 50: user.UserService.DisableTOTP:output_type -> user.DisableTOTPResponse
+	15, // 51: user.UserService.SendPhoneOTP:output_type -> user.SendPhoneOTPResponse
+	17, // 52: user.UserService.VerifyPhone:output_type -> user.VerifyPhoneResponse
+	6,  // 53: user.UserService.LoginWithOTP:output_type -> user.LoginResponse
+	31, // 54: user.UserService.GetUserProfile:output_type -> user.GetUserProfileResponse
+	33, // 55: user.UserService.UpdateUserProfile:output_type -> user.UpdateUserProfileResponse
+	35, // 56: user.UserService.ChangePassword:output_type -> user.ChangePasswordResponse
+	37, // 57: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	39, // 58: user.UserService.SearchUsers:output_type -> user.SearchUsersResponse
+	41, // 59: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
+	48, // 60: user.UserService.HardDeleteUser:output_type -> user.HardDeleteUserResponse
+	50, // 61: user.UserService.UpdateUserRole:output_type -> user.UpdateUserRoleResponse
+	43, // 62: user.UserService.EraseUser:output_type -> user.EraseUserResponse
+	46, // 63: user.UserService.GetErasureCertificate:output_type -> user.GetErasureCertificateResponse
+	21, // 64: user.UserService.GetActiveSessions:output_type -> user.GetActiveSessionsResponse
+	23, // 65: user.UserService.LogoutAllDevices:output_type -> user.LogoutAllDevicesResponse
+	25, // 66: user.UserService.RevokeSession:output_type -> user.RevokeSessionResponse
+	52, // 67: user.UserService.GetUserStats:output_type -> user.GetUserStatsResponse
+	54, // 68: user.UserService.IsTokenBlacklisted:output_type -> user.IsTokenBlacklistedResponse
+	57, // 69: user.UserService.GetSigningKeys:output_type -> user.GetSigningKeysResponse
+	42, // [42:70] is the sub-list for method output_type
+	14, // [14:42] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_pkg_api_user_user_proto_init() }
@@ -2346,7 +3917,7 @@ func file_pkg_api_user_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_user_user_proto_rawDesc), len(file_pkg_api_user_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   36,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
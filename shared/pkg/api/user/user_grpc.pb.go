@@ -19,23 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_Register_FullMethodName           = "/user.UserService/Register"
-	UserService_Login_FullMethodName              = "/user.UserService/Login"
-	UserService_RefreshToken_FullMethodName       = "/user.UserService/RefreshToken"
-	UserService_Logout_FullMethodName             = "/user.UserService/Logout"
-	UserService_GetUserProfile_FullMethodName     = "/user.UserService/GetUserProfile"
-	UserService_UpdateUserProfile_FullMethodName  = "/user.UserService/UpdateUserProfile"
-	UserService_ChangePassword_FullMethodName     = "/user.UserService/ChangePassword"
-	UserService_ListUsers_FullMethodName          = "/user.UserService/ListUsers"
-	UserService_SearchUsers_FullMethodName        = "/user.UserService/SearchUsers"
-	UserService_DeleteUser_FullMethodName         = "/user.UserService/DeleteUser"
-	UserService_HardDeleteUser_FullMethodName     = "/user.UserService/HardDeleteUser"
-	UserService_UpdateUserRole_FullMethodName     = "/user.UserService/UpdateUserRole"
-	UserService_GetActiveSessions_FullMethodName  = "/user.UserService/GetActiveSessions"
-	UserService_LogoutAllDevices_FullMethodName   = "/user.UserService/LogoutAllDevices"
-	UserService_RevokeSession_FullMethodName      = "/user.UserService/RevokeSession"
-	UserService_GetUserStats_FullMethodName       = "/user.UserService/GetUserStats"
-	UserService_IsTokenBlacklisted_FullMethodName = "/user.UserService/IsTokenBlacklisted"
+	UserService_Register_FullMethodName              = "/user.UserService/Register"
+	UserService_RegisterWithConsent_FullMethodName   = "/user.UserService/RegisterWithConsent"
+	UserService_Login_FullMethodName                 = "/user.UserService/Login"
+	UserService_RefreshToken_FullMethodName          = "/user.UserService/RefreshToken"
+	UserService_Logout_FullMethodName                = "/user.UserService/Logout"
+	UserService_VerifyMFA_FullMethodName             = "/user.UserService/VerifyMFA"
+	UserService_EnrollTOTP_FullMethodName            = "/user.UserService/EnrollTOTP"
+	UserService_ConfirmTOTP_FullMethodName           = "/user.UserService/ConfirmTOTP"
+	UserService_DisableTOTP_FullMethodName           = "/user.UserService/DisableTOTP"
+	UserService_SendPhoneOTP_FullMethodName          = "/user.UserService/SendPhoneOTP"
+	UserService_VerifyPhone_FullMethodName           = "/user.UserService/VerifyPhone"
+	UserService_LoginWithOTP_FullMethodName          = "/user.UserService/LoginWithOTP"
+	UserService_GetUserProfile_FullMethodName        = "/user.UserService/GetUserProfile"
+	UserService_UpdateUserProfile_FullMethodName     = "/user.UserService/UpdateUserProfile"
+	UserService_ChangePassword_FullMethodName        = "/user.UserService/ChangePassword"
+	UserService_ListUsers_FullMethodName             = "/user.UserService/ListUsers"
+	UserService_SearchUsers_FullMethodName           = "/user.UserService/SearchUsers"
+	UserService_DeleteUser_FullMethodName            = "/user.UserService/DeleteUser"
+	UserService_HardDeleteUser_FullMethodName        = "/user.UserService/HardDeleteUser"
+	UserService_UpdateUserRole_FullMethodName        = "/user.UserService/UpdateUserRole"
+	UserService_EraseUser_FullMethodName             = "/user.UserService/EraseUser"
+	UserService_GetErasureCertificate_FullMethodName = "/user.UserService/GetErasureCertificate"
+	UserService_GetActiveSessions_FullMethodName     = "/user.UserService/GetActiveSessions"
+	UserService_LogoutAllDevices_FullMethodName      = "/user.UserService/LogoutAllDevices"
+	UserService_RevokeSession_FullMethodName         = "/user.UserService/RevokeSession"
+	UserService_GetUserStats_FullMethodName          = "/user.UserService/GetUserStats"
+	UserService_IsTokenBlacklisted_FullMethodName    = "/user.UserService/IsTokenBlacklisted"
+	UserService_GetSigningKeys_FullMethodName        = "/user.UserService/GetSigningKeys"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -45,9 +56,20 @@ const (
 // UserService handles user authentication and management
 type UserServiceClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	// RegisterWithConsent: đăng ký + consent policy mới nhất bằng saga (bù trừ bền vững khi lỗi)
+	RegisterWithConsent(ctx context.Context, in *RegisterWithConsentRequest, opts ...grpc.CallOption) (*RegisterWithConsentResponse, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	// Two-factor authentication (TOTP): Login trả mfa_token khi còn bước 2FA, VerifyMFA hoàn tất đăng nhập
+	VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error)
+	DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponse, error)
+	// Xác minh số điện thoại bằng OTP qua SMS: sau đăng ký, đổi số (UpdateUserProfile.otp_code), đăng nhập không mật khẩu
+	SendPhoneOTP(ctx context.Context, in *SendPhoneOTPRequest, opts ...grpc.CallOption) (*SendPhoneOTPResponse, error)
+	VerifyPhone(ctx context.Context, in *VerifyPhoneRequest, opts ...grpc.CallOption) (*VerifyPhoneResponse, error)
+	LoginWithOTP(ctx context.Context, in *LoginWithOTPRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	// User profile management
 	GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*GetUserProfileResponse, error)
 	UpdateUserProfile(ctx context.Context, in *UpdateUserProfileRequest, opts ...grpc.CallOption) (*UpdateUserProfileResponse, error)
@@ -58,6 +80,9 @@ type UserServiceClient interface {
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	HardDeleteUser(ctx context.Context, in *HardDeleteUserRequest, opts ...grpc.CallOption) (*HardDeleteUserResponse, error)
 	UpdateUserRole(ctx context.Context, in *UpdateUserRoleRequest, opts ...grpc.CallOption) (*UpdateUserRoleResponse, error)
+	// Right to erasure: ẩn danh user + xóa dữ liệu cá nhân trong consent bằng saga (chạy lại tới khi xong)
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error)
+	GetErasureCertificate(ctx context.Context, in *GetErasureCertificateRequest, opts ...grpc.CallOption) (*GetErasureCertificateResponse, error)
 	// Session management
 	GetActiveSessions(ctx context.Context, in *GetActiveSessionsRequest, opts ...grpc.CallOption) (*GetActiveSessionsResponse, error)
 	LogoutAllDevices(ctx context.Context, in *LogoutAllDevicesRequest, opts ...grpc.CallOption) (*LogoutAllDevicesResponse, error)
@@ -66,6 +91,8 @@ type UserServiceClient interface {
 	GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*GetUserStatsResponse, error)
 	// Token blacklist operations (for Gateway middleware)
 	IsTokenBlacklisted(ctx context.Context, in *IsTokenBlacklistedRequest, opts ...grpc.CallOption) (*IsTokenBlacklistedResponse, error)
+	// Public keys ký access token (JWKS) - Gateway và service khác verify token không cần secret
+	GetSigningKeys(ctx context.Context, in *GetSigningKeysRequest, opts ...grpc.CallOption) (*GetSigningKeysResponse, error)
 }
 
 type userServiceClient struct {
@@ -86,6 +113,16 @@ func (c *userServiceClient) Register(ctx context.Context, in *RegisterRequest, o
 	return out, nil
 }
 
+func (c *userServiceClient) RegisterWithConsent(ctx context.Context, in *RegisterWithConsentRequest, opts ...grpc.CallOption) (*RegisterWithConsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterWithConsentResponse)
+	err := c.cc.Invoke(ctx, UserService_RegisterWithConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LoginResponse)
@@ -116,6 +153,76 @@ func (c *userServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts
 	return out, nil
 }
 
+func (c *userServiceClient) VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_VerifyMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollTOTPResponse)
+	err := c.cc.Invoke(ctx, UserService_EnrollTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTOTPResponse)
+	err := c.cc.Invoke(ctx, UserService_ConfirmTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableTOTPResponse)
+	err := c.cc.Invoke(ctx, UserService_DisableTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SendPhoneOTP(ctx context.Context, in *SendPhoneOTPRequest, opts ...grpc.CallOption) (*SendPhoneOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendPhoneOTPResponse)
+	err := c.cc.Invoke(ctx, UserService_SendPhoneOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) VerifyPhone(ctx context.Context, in *VerifyPhoneRequest, opts ...grpc.CallOption) (*VerifyPhoneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyPhoneResponse)
+	err := c.cc.Invoke(ctx, UserService_VerifyPhone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) LoginWithOTP(ctx context.Context, in *LoginWithOTPRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_LoginWithOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*GetUserProfileResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetUserProfileResponse)
@@ -196,6 +303,26 @@ func (c *userServiceClient) UpdateUserRole(ctx context.Context, in *UpdateUserRo
 	return out, nil
 }
 
+func (c *userServiceClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseUserResponse)
+	err := c.cc.Invoke(ctx, UserService_EraseUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetErasureCertificate(ctx context.Context, in *GetErasureCertificateRequest, opts ...grpc.CallOption) (*GetErasureCertificateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetErasureCertificateResponse)
+	err := c.cc.Invoke(ctx, UserService_GetErasureCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetActiveSessions(ctx context.Context, in *GetActiveSessionsRequest, opts ...grpc.CallOption) (*GetActiveSessionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetActiveSessionsResponse)
@@ -246,6 +373,16 @@ func (c *userServiceClient) IsTokenBlacklisted(ctx context.Context, in *IsTokenB
 	return out, nil
 }
 
+func (c *userServiceClient) GetSigningKeys(ctx context.Context, in *GetSigningKeysRequest, opts ...grpc.CallOption) (*GetSigningKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSigningKeysResponse)
+	err := c.cc.Invoke(ctx, UserService_GetSigningKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -253,9 +390,20 @@ func (c *userServiceClient) IsTokenBlacklisted(ctx context.Context, in *IsTokenB
 // UserService handles user authentication and management
 type UserServiceServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	// RegisterWithConsent: đăng ký + consent policy mới nhất bằng saga (bù trừ bền vững khi lỗi)
+	RegisterWithConsent(context.Context, *RegisterWithConsentRequest) (*RegisterWithConsentResponse, error)
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
 	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	// Two-factor authentication (TOTP): Login trả mfa_token khi còn bước 2FA, VerifyMFA hoàn tất đăng nhập
+	VerifyMFA(context.Context, *VerifyMFARequest) (*LoginResponse, error)
+	EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error)
+	DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponse, error)
+	// Xác minh số điện thoại bằng OTP qua SMS: sau đăng ký, đổi số (UpdateUserProfile.otp_code), đăng nhập không mật khẩu
+	SendPhoneOTP(context.Context, *SendPhoneOTPRequest) (*SendPhoneOTPResponse, error)
+	VerifyPhone(context.Context, *VerifyPhoneRequest) (*VerifyPhoneResponse, error)
+	LoginWithOTP(context.Context, *LoginWithOTPRequest) (*LoginResponse, error)
 	// User profile management
 	GetUserProfile(context.Context, *GetUserProfileRequest) (*GetUserProfileResponse, error)
 	UpdateUserProfile(context.Context, *UpdateUserProfileRequest) (*UpdateUserProfileResponse, error)
@@ -266,6 +414,9 @@ type UserServiceServer interface {
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	HardDeleteUser(context.Context, *HardDeleteUserRequest) (*HardDeleteUserResponse, error)
 	UpdateUserRole(context.Context, *UpdateUserRoleRequest) (*UpdateUserRoleResponse, error)
+	// Right to erasure: ẩn danh user + xóa dữ liệu cá nhân trong consent bằng saga (chạy lại tới khi xong)
+	EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error)
+	GetErasureCertificate(context.Context, *GetErasureCertificateRequest) (*GetErasureCertificateResponse, error)
 	// Session management
 	GetActiveSessions(context.Context, *GetActiveSessionsRequest) (*GetActiveSessionsResponse, error)
 	LogoutAllDevices(context.Context, *LogoutAllDevicesRequest) (*LogoutAllDevicesResponse, error)
@@ -274,6 +425,8 @@ type UserServiceServer interface {
 	GetUserStats(context.Context, *GetUserStatsRequest) (*GetUserStatsResponse, error)
 	// Token blacklist operations (for Gateway middleware)
 	IsTokenBlacklisted(context.Context, *IsTokenBlacklistedRequest) (*IsTokenBlacklistedResponse, error)
+	// Public keys ký access token (JWKS) - Gateway và service khác verify token không cần secret
+	GetSigningKeys(context.Context, *GetSigningKeysRequest) (*GetSigningKeysResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -287,6 +440,9 @@ type UnimplementedUserServiceServer struct{}
 func (UnimplementedUserServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedUserServiceServer) RegisterWithConsent(context.Context, *RegisterWithConsentRequest) (*RegisterWithConsentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterWithConsent not implemented")
+}
 func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Login not implemented")
 }
@@ -296,6 +452,27 @@ func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshToke
 func (UnimplementedUserServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedUserServiceServer) VerifyMFA(context.Context, *VerifyMFARequest) (*LoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyMFA not implemented")
+}
+func (UnimplementedUserServiceServer) EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (UnimplementedUserServiceServer) ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (UnimplementedUserServiceServer) DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DisableTOTP not implemented")
+}
+func (UnimplementedUserServiceServer) SendPhoneOTP(context.Context, *SendPhoneOTPRequest) (*SendPhoneOTPResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendPhoneOTP not implemented")
+}
+func (UnimplementedUserServiceServer) VerifyPhone(context.Context, *VerifyPhoneRequest) (*VerifyPhoneResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyPhone not implemented")
+}
+func (UnimplementedUserServiceServer) LoginWithOTP(context.Context, *LoginWithOTPRequest) (*LoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoginWithOTP not implemented")
+}
 func (UnimplementedUserServiceServer) GetUserProfile(context.Context, *GetUserProfileRequest) (*GetUserProfileResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserProfile not implemented")
 }
@@ -320,6 +497,12 @@ func (UnimplementedUserServiceServer) HardDeleteUser(context.Context, *HardDelet
 func (UnimplementedUserServiceServer) UpdateUserRole(context.Context, *UpdateUserRoleRequest) (*UpdateUserRoleResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateUserRole not implemented")
 }
+func (UnimplementedUserServiceServer) EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetErasureCertificate(context.Context, *GetErasureCertificateRequest) (*GetErasureCertificateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetErasureCertificate not implemented")
+}
 func (UnimplementedUserServiceServer) GetActiveSessions(context.Context, *GetActiveSessionsRequest) (*GetActiveSessionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetActiveSessions not implemented")
 }
@@ -335,6 +518,9 @@ func (UnimplementedUserServiceServer) GetUserStats(context.Context, *GetUserStat
 func (UnimplementedUserServiceServer) IsTokenBlacklisted(context.Context, *IsTokenBlacklistedRequest) (*IsTokenBlacklistedResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method IsTokenBlacklisted not implemented")
 }
+func (UnimplementedUserServiceServer) GetSigningKeys(context.Context, *GetSigningKeysRequest) (*GetSigningKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSigningKeys not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -374,6 +560,24 @@ func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_RegisterWithConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWithConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RegisterWithConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RegisterWithConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RegisterWithConsent(ctx, req.(*RegisterWithConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LoginRequest)
 	if err := dec(in); err != nil {
@@ -428,6 +632,132 @@ func _UserService_Logout_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_VerifyMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_VerifyMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyMFA(ctx, req.(*VerifyMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).EnrollTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_EnrollTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).EnrollTOTP(ctx, req.(*EnrollTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ConfirmTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ConfirmTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ConfirmTOTP(ctx, req.(*ConfirmTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DisableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DisableTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DisableTOTP(ctx, req.(*DisableTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SendPhoneOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendPhoneOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SendPhoneOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SendPhoneOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SendPhoneOTP(ctx, req.(*SendPhoneOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_VerifyPhone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPhoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyPhone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_VerifyPhone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyPhone(ctx, req.(*VerifyPhoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_LoginWithOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).LoginWithOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_LoginWithOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).LoginWithOTP(ctx, req.(*LoginWithOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserProfileRequest)
 	if err := dec(in); err != nil {
@@ -572,6 +902,42 @@ func _UserService_UpdateUserRole_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_EraseUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).EraseUser(ctx, req.(*EraseUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetErasureCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetErasureCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetErasureCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetErasureCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetErasureCertificate(ctx, req.(*GetErasureCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetActiveSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetActiveSessionsRequest)
 	if err := dec(in); err != nil {
@@ -662,6 +1028,24 @@ func _UserService_IsTokenBlacklisted_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetSigningKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSigningKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetSigningKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetSigningKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetSigningKeys(ctx, req.(*GetSigningKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -673,6 +1057,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _UserService_Register_Handler,
 		},
+		{
+			MethodName: "RegisterWithConsent",
+			Handler:    _UserService_RegisterWithConsent_Handler,
+		},
 		{
 			MethodName: "Login",
 			Handler:    _UserService_Login_Handler,
@@ -685,6 +1073,34 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _UserService_Logout_Handler,
 		},
+		{
+			MethodName: "VerifyMFA",
+			Handler:    _UserService_VerifyMFA_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _UserService_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _UserService_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _UserService_DisableTOTP_Handler,
+		},
+		{
+			MethodName: "SendPhoneOTP",
+			Handler:    _UserService_SendPhoneOTP_Handler,
+		},
+		{
+			MethodName: "VerifyPhone",
+			Handler:    _UserService_VerifyPhone_Handler,
+		},
+		{
+			MethodName: "LoginWithOTP",
+			Handler:    _UserService_LoginWithOTP_Handler,
+		},
 		{
 			MethodName: "GetUserProfile",
 			Handler:    _UserService_GetUserProfile_Handler,
@@ -717,6 +1133,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateUserRole",
 			Handler:    _UserService_UpdateUserRole_Handler,
 		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _UserService_EraseUser_Handler,
+		},
+		{
+			MethodName: "GetErasureCertificate",
+			Handler:    _UserService_GetErasureCertificate_Handler,
+		},
 		{
 			MethodName: "GetActiveSessions",
 			Handler:    _UserService_GetActiveSessions_Handler,
@@ -737,6 +1161,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IsTokenBlacklisted",
 			Handler:    _UserService_IsTokenBlacklisted_Handler,
 		},
+		{
+			MethodName: "GetSigningKeys",
+			Handler:    _UserService_GetSigningKeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/api/user/user.proto",
@@ -0,0 +1,156 @@
+// Package audit records mutating operations of every service into an append-only audit log.
+//
+// Gateway đưa actor (user trong JWT), request ID, IP và user agent vào gRPC metadata
+// (UnaryClientInterceptor/StreamClientInterceptor); service đọc lại bằng FromIncomingContext
+// và ghi Entry qua Logger. Mỗi service lưu log trong bảng audit_log của DB riêng
+// và expose QueryAuditLog (NewServer) để Gateway gộp lại.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrInvalidFilter is returned when a query filter is malformed
+var ErrInvalidFilter = errors.New("invalid audit filter")
+
+// Entry là 1 dòng trong audit log (không bao giờ được sửa/xóa)
+type Entry struct {
+	ID         string
+	Service    string // user | document | consent
+	Action     string // vd: user.delete, policy.publish
+	ActorID    string // Rỗng = system / không xác định
+	ActorRole  string
+	TargetType string
+	TargetID   string
+	Before     json.RawMessage // nil nếu không có
+	After      json.RawMessage // nil nếu không có
+	Reason     string
+	RequestID  string
+	IPAddress  string
+	UserAgent  string
+	OccurredAt time.Time
+}
+
+// Filter holds parameters for querying the audit log
+type Filter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       time.Time // Zero = không giới hạn
+	To         time.Time // Zero = không giới hạn
+	Page       int
+	PageSize   int
+}
+
+// Pagination mặc định/giới hạn cho QueryAuditLog
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Normalize clamps page and page size to the allowed range
+func (f *Filter) Normalize() {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.PageSize < 1 {
+		f.PageSize = DefaultPageSize
+	}
+	if f.PageSize > MaxPageSize {
+		f.PageSize = MaxPageSize
+	}
+}
+
+// Store persists audit entries. Implementations must be append-only.
+type Store interface {
+	// Append ghi 1 entry (ID và OccurredAt được điền nếu rỗng)
+	Append(ctx context.Context, entry *Entry) error
+
+	// Query trả về các entry khớp filter (mới nhất trước) và tổng số entry khớp
+	Query(ctx context.Context, filter Filter) ([]*Entry, int, error)
+}
+
+// Event mô tả 1 thao tác cần ghi audit, do service layer tạo ra
+type Event struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     any // Được marshal sang JSON, nil = không có
+	After      any
+	Reason     string
+
+	// ActorID dùng khi request không mang actor (vd: user tự đăng ký)
+	ActorID string
+}
+
+// Logger ghi Event của 1 service vào Store, kèm thông tin request từ gRPC metadata
+type Logger struct {
+	store   Store
+	service string
+}
+
+// NewLogger creates a Logger for the given service name
+func NewLogger(store Store, service string) *Logger {
+	return &Logger{store: store, service: service}
+}
+
+// Record ghi event vào audit log.
+// Thao tác đã commit trước khi gọi Record nên lỗi ghi audit chỉ được log, không trả về cho caller.
+// Logger nil (vd: tool offline) thì bỏ qua.
+func (l *Logger) Record(ctx context.Context, ev Event) {
+	if l == nil || l.store == nil {
+		return
+	}
+
+	info := FromIncomingContext(ctx)
+	entry := &Entry{
+		Service:    l.service,
+		Action:     ev.Action,
+		ActorID:    info.ActorID,
+		ActorRole:  info.ActorRole,
+		TargetType: ev.TargetType,
+		TargetID:   ev.TargetID,
+		Before:     marshalValue(ev.Before),
+		After:      marshalValue(ev.After),
+		Reason:     ev.Reason,
+		RequestID:  info.RequestID,
+		IPAddress:  info.IPAddress,
+		UserAgent:  info.UserAgent,
+	}
+	if entry.ActorID == "" {
+		entry.ActorID = ev.ActorID
+	}
+
+	// Request đã xong (hoặc client hủy) vẫn phải ghi được audit
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := l.store.Append(ctx, entry); err != nil {
+		log.Printf("ERROR: [AUDIT] failed to record %s on %s %s (actor=%s, request=%s): %v",
+			entry.Action, entry.TargetType, entry.TargetID, entry.ActorID, entry.RequestID, err)
+	}
+}
+
+func marshalValue(v any) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	if raw, ok := v.(json.RawMessage); ok {
+		return raw
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("WARNING: [AUDIT] failed to marshal value: %v", err)
+		return nil
+	}
+	if string(b) == "null" {
+		return nil
+	}
+	return b
+}
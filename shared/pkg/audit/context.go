@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC metadata keys mang thông tin request từ Gateway xuống các service
+const (
+	MetadataActorID   = "x-actor-id"
+	MetadataActorRole = "x-actor-role"
+	MetadataRequestID = "x-request-id"
+	MetadataClientIP  = "x-real-ip"
+	MetadataUserAgent = "x-client-user-agent" // "user-agent" bị gRPC ghi đè
+
+	MetadataOnBehalfOf     = "x-on-behalf-of"
+	MetadataOnBehalfReason = "x-on-behalf-reason" // URL-encoded: metadata chỉ nhận ASCII
+	MetadataHTTPRoute      = "x-http-route"       // "METHOD /path" của request HTTP gốc
+)
+
+// ActionActOnBehalf - Admin thao tác thay user khác (ghi bởi UnaryServerInterceptor)
+const ActionActOnBehalf = "admin.act_on_behalf"
+
+// RequestInfo is who made a request and where it came from
+type RequestInfo struct {
+	ActorID   string
+	ActorRole string
+	RequestID string
+	IPAddress string
+	UserAgent string
+
+	// Admin thao tác thay user khác (rỗng = tự thao tác)
+	OnBehalfOf     string
+	OnBehalfReason string
+	HTTPRoute      string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo lưu RequestInfo vào context phía Gateway, interceptor sẽ chuyển thành metadata
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext trả về RequestInfo đã lưu bằng WithRequestInfo
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// FromIncomingContext đọc RequestInfo từ gRPC metadata phía service
+func FromIncomingContext(ctx context.Context) RequestInfo {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return RequestInfo{}
+	}
+	return RequestInfo{
+		ActorID:   first(md, MetadataActorID),
+		ActorRole: first(md, MetadataActorRole),
+		RequestID: first(md, MetadataRequestID),
+		IPAddress: first(md, MetadataClientIP),
+		UserAgent: first(md, MetadataUserAgent),
+
+		OnBehalfOf:     first(md, MetadataOnBehalfOf),
+		OnBehalfReason: unescape(first(md, MetadataOnBehalfReason)),
+		HTTPRoute:      first(md, MetadataHTTPRoute),
+	}
+}
+
+// UnaryServerInterceptor ghi audit event cho mọi call Admin thực hiện thay user khác (on_behalf_of),
+// kể cả thao tác chỉ đọc. Event ghi sau khi handler chạy, kèm gRPC status của call.
+func UnaryServerInterceptor(l *Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+
+		if reqInfo := FromIncomingContext(ctx); reqInfo.OnBehalfOf != "" {
+			l.Record(ctx, Event{
+				Action:     ActionActOnBehalf,
+				TargetType: "user",
+				TargetID:   reqInfo.OnBehalfOf,
+				After: map[string]any{
+					"rpc":    info.FullMethod,
+					"route":  reqInfo.HTTPRoute,
+					"status": status.Code(err).String(),
+				},
+				Reason: reqInfo.OnBehalfReason,
+			})
+		}
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor gắn RequestInfo trong context vào metadata của mọi unary call
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor gắn RequestInfo trong context vào metadata của mọi streaming call
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	info, ok := RequestInfoFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	pairs := make([]string, 0, 16)
+	for _, kv := range [][2]string{
+		{MetadataActorID, info.ActorID},
+		{MetadataActorRole, info.ActorRole},
+		{MetadataRequestID, info.RequestID},
+		{MetadataClientIP, info.IPAddress},
+		{MetadataUserAgent, info.UserAgent},
+		{MetadataOnBehalfOf, info.OnBehalfOf},
+		{MetadataOnBehalfReason, url.QueryEscape(info.OnBehalfReason)},
+		{MetadataHTTPRoute, info.HTTPRoute},
+	} {
+		if kv[1] != "" {
+			pairs = append(pairs, kv[0], kv[1])
+		}
+	}
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func unescape(v string) string {
+	if u, err := url.QueryUnescape(v); err == nil {
+		return u
+	}
+	return v
+}
@@ -0,0 +1,140 @@
+// Package pgstore stores the audit log in PostgreSQL (bảng audit_log, xem migration audit_log của từng service)
+package pgstore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+)
+
+const entryColumns = `id, service, action, COALESCE(actor_id, ''), COALESCE(actor_role, ''),
+	target_type, COALESCE(target_id, ''), before_value, after_value, COALESCE(reason, ''),
+	COALESCE(request_id, ''), COALESCE(ip_address, ''), COALESCE(user_agent, ''), occurred_at`
+
+type postgresStore struct {
+	db *pgxpool.Pool
+}
+
+// NewStore creates an audit.Store backed by the audit_log table
+func NewStore(db *pgxpool.Pool) audit.Store {
+	return &postgresStore{db: db}
+}
+
+// Append chỉ INSERT, bảng audit_log có trigger chặn UPDATE/DELETE
+func (s *postgresStore) Append(ctx context.Context, e *audit.Entry) error {
+	query := `
+		INSERT INTO audit_log (
+			service, action, actor_id, actor_role, target_type, target_id,
+			before_value, after_value, reason, request_id, ip_address, user_agent
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, occurred_at
+	`
+
+	err := s.db.QueryRow(ctx, query,
+		e.Service, e.Action, nullIfEmpty(e.ActorID), nullIfEmpty(e.ActorRole), e.TargetType, nullIfEmpty(e.TargetID),
+		jsonOrNil(e.Before), jsonOrNil(e.After), nullIfEmpty(e.Reason),
+		nullIfEmpty(e.RequestID), nullIfEmpty(e.IPAddress), nullIfEmpty(e.UserAgent),
+	).Scan(&e.ID, &e.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) Query(ctx context.Context, f audit.Filter) ([]*audit.Entry, int, error) {
+	f.Normalize()
+
+	var conds []string
+	var args []any
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.ActorID != "" {
+		add("actor_id = $%d", f.ActorID)
+	}
+	if f.Action != "" {
+		// "user.*" lọc theo prefix
+		if prefix, ok := strings.CutSuffix(f.Action, "*"); ok {
+			add("action LIKE $%d", escapeLike(prefix)+"%")
+		} else {
+			add("action = $%d", f.Action)
+		}
+	}
+	if f.TargetType != "" {
+		add("target_type = $%d", f.TargetType)
+	}
+	if f.TargetID != "" {
+		add("target_id = $%d", f.TargetID)
+	}
+	if f.RequestID != "" {
+		add("request_id = $%d", f.RequestID)
+	}
+	if !f.From.IsZero() {
+		add("occurred_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("occurred_at <= $%d", f.To)
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM audit_log "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	args = append(args, f.PageSize, (f.Page-1)*f.PageSize)
+	query := fmt.Sprintf(`SELECT %s FROM audit_log %s ORDER BY occurred_at DESC, id DESC LIMIT $%d OFFSET $%d`,
+		entryColumns, where, len(args)-1, len(args))
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*audit.Entry, error) {
+		var e audit.Entry
+		var before, after []byte
+		err := row.Scan(&e.ID, &e.Service, &e.Action, &e.ActorID, &e.ActorRole,
+			&e.TargetType, &e.TargetID, &before, &after, &e.Reason,
+			&e.RequestID, &e.IPAddress, &e.UserAgent, &e.OccurredAt)
+		e.Before, e.After = before, after
+		return &e, err
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan audit entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// jsonOrNil truyền JSONB dạng string để pgx không encode []byte thành bytea
+func jsonOrNil(b []byte) *string {
+	if len(b) == 0 {
+		return nil
+	}
+	s := string(b)
+	return &s
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
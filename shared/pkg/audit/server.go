@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/audit"
+)
+
+// Server implements the AuditService gRPC API on top of a Store
+type Server struct {
+	pb.UnimplementedAuditServiceServer
+	store Store
+}
+
+// NewServer creates an AuditService server backed by store
+func NewServer(store Store) *Server {
+	return &Server{store: store}
+}
+
+// QueryAuditLog trả về audit log của service, lọc và phân trang
+func (s *Server) QueryAuditLog(ctx context.Context, req *pb.QueryAuditLogRequest) (*pb.QueryAuditLogResponse, error) {
+	filter := Filter{
+		ActorID:    req.ActorId,
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetId,
+		RequestID:  req.RequestId,
+		Page:       int(req.Page),
+		PageSize:   int(req.PageSize),
+	}
+	if req.FromTimestamp > 0 {
+		filter.From = time.UnixMilli(req.FromTimestamp)
+	}
+	if req.ToTimestamp > 0 {
+		filter.To = time.UnixMilli(req.ToTimestamp)
+	}
+	filter.Normalize()
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, status.Error(codes.InvalidArgument, "to_timestamp must not be before from_timestamp")
+	}
+
+	entries, total, err := s.store.Query(ctx, filter)
+	if err != nil {
+		if errors.Is(err, ErrInvalidFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query audit log: %v", err)
+	}
+
+	pbEntries := make([]*pb.AuditEntry, len(entries))
+	for i, e := range entries {
+		pbEntries[i] = ToProto(e)
+	}
+
+	return &pb.QueryAuditLogResponse{
+		Entries:    pbEntries,
+		TotalCount: int32(total),
+		Page:       int32(filter.Page),
+		PageSize:   int32(filter.PageSize),
+	}, nil
+}
+
+// ToProto converts an Entry to its protobuf message
+func ToProto(e *Entry) *pb.AuditEntry {
+	return &pb.AuditEntry{
+		Id:         e.ID,
+		Service:    e.Service,
+		Action:     e.Action,
+		ActorId:    e.ActorID,
+		ActorRole:  e.ActorRole,
+		TargetType: e.TargetType,
+		TargetId:   e.TargetID,
+		BeforeJson: string(e.Before),
+		AfterJson:  string(e.After),
+		Reason:     e.Reason,
+		RequestId:  e.RequestID,
+		IpAddress:  e.IPAddress,
+		UserAgent:  e.UserAgent,
+		OccurredAt: e.OccurredAt.UnixMilli(),
+	}
+}
@@ -0,0 +1,96 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestCheckAddr(t *testing.T) {
+	guard, err := New("10.0.5.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ip      string
+		wantErr bool
+	}{
+		{"Public IPv4", "93.184.216.34", false},
+		{"Public IPv6", "2606:2800:220:1:248:1893:25c8:1946", false},
+		{"Loopback", "127.0.0.1", true},
+		{"Loopback IPv6", "::1", true},
+		{"Cloud metadata", "169.254.169.254", true},
+		{"RFC1918 10/8", "10.1.2.3", true},
+		{"RFC1918 172.16/12", "172.16.0.10", true},
+		{"RFC1918 192.168/16", "192.168.1.1", true},
+		{"Carrier-grade NAT", "100.64.0.1", true},
+		{"Unspecified", "0.0.0.0", true},
+		{"Unique local IPv6", "fd00::1", true},
+		{"IPv4-mapped loopback", "::ffff:127.0.0.1", true},
+		{"Allow-listed network", "10.0.5.20", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckAddr(netip.MustParseAddr(tt.ip))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckAddr(%s) error = %v, wantErr %v", tt.ip, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrBlocked) {
+				t.Errorf("CheckAddr(%s) error = %v, want %v", tt.ip, err, ErrBlocked)
+			}
+		})
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"Public IP literal", "https://93.184.216.34/hooks", false},
+		{"Loopback IP literal", "http://127.0.0.1:8080/hooks", true},
+		{"Metadata IP literal", "http://169.254.169.254/latest/meta-data/", true},
+		{"IPv6 loopback literal", "http://[::1]/hooks", true},
+		{"Localhost name", "http://localhost/hooks", true},
+		{"No host", "http:///hooks", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var guard *Guard
+			err := guard.CheckURL(context.Background(), tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckURL(%s) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPClientBlocksAtDial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// httptest lắng nghe trên 127.0.0.1: bị chặn lúc dial, trừ khi loopback nằm trong allow-list
+	var blocked *Guard
+	if _, err := blocked.HTTPClient(time.Second).Get(server.URL); !errors.Is(err, ErrBlocked) {
+		t.Errorf("Get(%s) error = %v, want %v", server.URL, err, ErrBlocked)
+	}
+
+	allowed, err := New("127.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := allowed.HTTPClient(time.Second).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get(%s) with allow-listed loopback error = %v", server.URL, err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/poller"
+)
+
+// DispatcherConfig cấu hình vòng lặp gửi event
+type DispatcherConfig struct {
+	PollInterval time.Duration // Chu kỳ quét outbox khi không còn event
+	BatchSize    int           // Số event tối đa mỗi lần quét
+	MaxAttempts  int           // Hết số lần thử thì event bị đánh dấu dead
+	BaseBackoff  time.Duration // Backoff lần thử lại đầu tiên, nhân đôi mỗi lần
+	MaxBackoff   time.Duration
+}
+
+// Giá trị mặc định khi field của DispatcherConfig bằng 0
+const (
+	DefaultPollInterval = 2 * time.Second
+	DefaultBatchSize    = 100
+	DefaultMaxAttempts  = 10
+	DefaultBaseBackoff  = 5 * time.Second
+	DefaultMaxBackoff   = 30 * time.Minute
+)
+
+// Dispatcher relays pending outbox events to every sink
+type Dispatcher struct {
+	store Store
+	sinks []Sink
+	cfg   DispatcherConfig
+}
+
+// NewDispatcher creates a Dispatcher; zero config fields take the defaults
+func NewDispatcher(store Store, sinks []Sink, cfg DispatcherConfig) *Dispatcher {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = DefaultBaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+	return &Dispatcher{store: store, sinks: sinks, cfg: cfg}
+}
+
+// Run gửi event cho tới khi ctx bị hủy. Batch đầy thì quét tiếp ngay, không chờ PollInterval.
+func (d *Dispatcher) Run(ctx context.Context) {
+	poller.Run(ctx, d.cfg.PollInterval, d.cfg.BatchSize, func(ctx context.Context) (int, error) {
+		return d.store.ProcessPending(ctx, d.cfg.BatchSize, d.deliver)
+	}, func(err error) {
+		log.Printf("WARNING: [OUTBOX] Failed to dispatch events: %v", err)
+	})
+}
+
+// deliver gửi event tới mọi sink. Sink nào lỗi thì cả event được gửi lại (at-least-once).
+func (d *Dispatcher) deliver(ctx context.Context, e *Event) Outcome {
+	var errs []error
+	for _, sink := range d.sinks {
+		if err := sink.Publish(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	if len(errs) == 0 {
+		return Outcome{}
+	}
+
+	err := errors.Join(errs...)
+	attempts := e.Attempts + 1
+	if attempts >= d.cfg.MaxAttempts {
+		log.Printf("ERROR: [OUTBOX] Giving up %s %s after %d attempts: %v", e.Type, e.ID, attempts, err)
+		return Outcome{Err: err, Dead: true}
+	}
+
+	log.Printf("WARNING: [OUTBOX] Failed to deliver %s %s (attempt %d): %v", e.Type, e.ID, attempts, err)
+	return Outcome{Err: err, RetryAt: time.Now().Add(poller.Backoff(d.cfg.BaseBackoff, d.cfg.MaxBackoff, attempts))}
+}
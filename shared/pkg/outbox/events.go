@@ -0,0 +1,120 @@
+package outbox
+
+// Event types - contract với các team downstream, không đổi tên
+const (
+	EventUserRegistered     = "UserRegistered"
+	EventUserDeleted        = "UserDeleted"
+	EventUserErased         = "UserErased"
+	EventSessionCompromised = "SessionCompromised"
+	EventPolicyPublished    = "PolicyPublished"
+	EventConsentRecorded    = "ConsentRecorded"
+	EventConsentRevoked     = "ConsentRevoked"
+	EventConsentExpired     = "ConsentExpired"
+	EventConsentDeclined    = "ConsentDeclined"
+)
+
+// Aggregate types
+const (
+	AggregateUser    = "user"
+	AggregatePolicy  = "policy"
+	AggregateConsent = "consent"
+)
+
+// Timestamp trong payload là Unix seconds, giống API của các service
+
+// UserRegistered - user mới được tạo (tự đăng ký hoặc Admin tạo).
+// Không chứa số điện thoại, consumer cần thì gọi GetUserProfile.
+type UserRegistered struct {
+	UserID       string `json:"user_id"`
+	Name         string `json:"name"`
+	PlatformRole string `json:"platform_role"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// UserDeleted - user bị xóa mềm, hoặc xóa hẳn khi rollback đăng ký (Hard = true)
+type UserDeleted struct {
+	UserID string `json:"user_id"`
+	Hard   bool   `json:"hard"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// UserErased - dữ liệu cá nhân của user đã bị xóa (right to erasure).
+// Consumer phải xóa bản sao dữ liệu của user (vd: name từ UserRegistered).
+type UserErased struct {
+	UserID   string `json:"user_id"`
+	ErasedAt int64  `json:"erased_at"`
+}
+
+// SessionCompromised - refresh token đã rotation bị dùng lại: cả phiên đăng nhập (family) bị revoke
+// và access tokens bị blacklist. Consumer gửi thông báo bảo mật cho user.
+type SessionCompromised struct {
+	UserID          string `json:"user_id"`
+	FamilyID        string `json:"family_id"`
+	Reason          string `json:"reason"`
+	RevokedSessions int    `json:"revoked_sessions"`
+	DetectedAt      int64  `json:"detected_at"`
+}
+
+// PolicyPublished - 1 version policy chuyển sang status published
+type PolicyPublished struct {
+	DocumentID         string `json:"document_id"`
+	Platform           string `json:"platform"`
+	DocumentName       string `json:"document_name"`
+	IsMandatory        bool   `json:"is_mandatory"`
+	EffectiveTimestamp int64  `json:"effective_timestamp"`
+	PublishedAt        int64  `json:"published_at"`
+	PublishedBy        string `json:"published_by,omitempty"`
+}
+
+// ConsentRecorded - user (hoặc Admin thay user) đồng ý 1 version policy
+type ConsentRecorded struct {
+	ConsentID        string          `json:"consent_id"`
+	UserID           string          `json:"user_id"`
+	Platform         string          `json:"platform"`
+	DocumentID       string          `json:"document_id"`
+	DocumentName     string          `json:"document_name"`
+	VersionTimestamp int64           `json:"version_timestamp"`
+	ConsentMethod    string          `json:"consent_method"`
+	Locale           string          `json:"locale,omitempty"`
+	RecordedBy       string          `json:"recorded_by,omitempty"` // Admin ghi thay, rỗng = user tự đồng ý
+	AgreedAt         int64           `json:"agreed_at"`
+	Purposes         map[string]bool `json:"purposes,omitempty"` // Purpose key -> accepted
+}
+
+// ConsentRevoked - consent bị thu hồi
+type ConsentRevoked struct {
+	ConsentID        string `json:"consent_id"`
+	UserID           string `json:"user_id"`
+	Platform         string `json:"platform"`
+	DocumentID       string `json:"document_id"`
+	DocumentName     string `json:"document_name"`
+	VersionTimestamp int64  `json:"version_timestamp"`
+	RevokedBy        string `json:"revoked_by"`
+	Reason           string `json:"reason,omitempty"`
+	RevokedAt        int64  `json:"revoked_at"`
+}
+
+// ConsentExpired - consent quá thời hạn (consent_validity_period của document), user phải xác nhận lại
+type ConsentExpired struct {
+	ConsentID        string `json:"consent_id"`
+	UserID           string `json:"user_id"`
+	Platform         string `json:"platform"`
+	DocumentID       string `json:"document_id"`
+	DocumentName     string `json:"document_name"`
+	VersionTimestamp int64  `json:"version_timestamp"`
+	AgreedAt         int64  `json:"agreed_at"`
+	ExpiresAt        int64  `json:"expires_at"`
+}
+
+// ConsentDeclined - user (hoặc Admin thay user) chủ động từ chối policy không bắt buộc
+type ConsentDeclined struct {
+	ConsentID        string `json:"consent_id"`
+	UserID           string `json:"user_id"`
+	Platform         string `json:"platform"`
+	DocumentID       string `json:"document_id"`
+	DocumentName     string `json:"document_name"`
+	VersionTimestamp int64  `json:"version_timestamp"`
+	DeclinedBy       string `json:"declined_by"`
+	Reason           string `json:"reason"`
+	DeclinedAt       int64  `json:"declined_at"`
+}
@@ -0,0 +1,57 @@
+// Package outbox publishes domain events with the transactional outbox pattern.
+//
+// Service ghi Event vào bảng outbox_events trong cùng transaction với thay đổi dữ liệu
+// (pgstore.Enqueue), nên event chỉ tồn tại khi thay đổi đã commit. Dispatcher chạy nền,
+// đọc các event chưa gửi và đẩy tới các Sink (channel in-process, webhook, NATS...).
+// Delivery là at-least-once: consumer phải bỏ qua event trùng theo Event.ID.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event là 1 domain event trong outbox
+type Event struct {
+	ID            string          `json:"id"`             // UUID, dùng để consumer dedupe
+	Type          string          `json:"type"`           // vd: ConsentRecorded
+	Service       string          `json:"service"`        // user | document | consent
+	AggregateType string          `json:"aggregate_type"` // user | policy | consent
+	AggregateID   string          `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+
+	// Số lần gửi thất bại trước đó (không gửi cho consumer)
+	Attempts int `json:"-"`
+}
+
+// NewEvent tạo event với payload được marshal sang JSON
+func NewEvent(service, eventType, aggregateType, aggregateID string, payload any) (*Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+	return &Event{
+		Type:          eventType,
+		Service:       service,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       data,
+	}, nil
+}
+
+// Outcome là kết quả gửi 1 event, Store lưu lại để quyết định gửi lại hay không
+type Outcome struct {
+	Err     error     // nil = đã gửi tới mọi sink
+	RetryAt time.Time // Thời điểm thử lại khi Err != nil
+	Dead    bool      // Hết số lần thử, không gửi lại nữa
+}
+
+// Store đọc các event đang chờ gửi. Ghi event nằm ở pgstore.Enqueue vì cần transaction của service.
+type Store interface {
+	// ProcessPending khóa tối đa limit event đã tới hạn gửi (theo thứ tự ghi), gọi handle
+	// cho từng event và lưu Outcome. Trả về số event đã xử lý.
+	ProcessPending(ctx context.Context, limit int, handle func(ctx context.Context, e *Event) Outcome) (int, error)
+}
@@ -0,0 +1,128 @@
+// Package pgstore stores outbox events in PostgreSQL (bảng outbox_events, xem migration outbox của từng service)
+package pgstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+)
+
+// Enqueue ghi event vào outbox trong transaction của thay đổi dữ liệu.
+// Transaction rollback thì event cũng mất, nên không bao giờ gửi event cho thay đổi chưa commit.
+func Enqueue(ctx context.Context, tx pgx.Tx, events ...*outbox.Event) error {
+	query := `
+		INSERT INTO outbox_events (service, event_type, aggregate_type, aggregate_id, payload)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING event_id, occurred_at
+	`
+
+	for _, e := range events {
+		err := tx.QueryRow(ctx, query,
+			e.Service, e.Type, e.AggregateType, e.AggregateID, string(e.Payload),
+		).Scan(&e.ID, &e.OccurredAt)
+		if err != nil {
+			return fmt.Errorf("failed to enqueue %s event: %w", e.Type, err)
+		}
+	}
+	return nil
+}
+
+type postgresStore struct {
+	db *pgxpool.Pool
+}
+
+// NewStore creates an outbox.Store backed by the outbox_events table
+func NewStore(db *pgxpool.Pool) outbox.Store {
+	return &postgresStore{db: db}
+}
+
+// ProcessPending dùng FOR UPDATE SKIP LOCKED để nhiều instance của service chạy Dispatcher song song
+// mà không gửi trùng 1 event
+func (s *postgresStore) ProcessPending(ctx context.Context, limit int, handle func(ctx context.Context, e *outbox.Event) outbox.Outcome) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(context.WithoutCancel(ctx))
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, event_id, service, event_type, aggregate_type, aggregate_id, payload, occurred_at, attempts
+		FROM outbox_events
+		WHERE dispatched_at IS NULL AND dead_at IS NULL AND next_attempt_at <= NOW()
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	type claimed struct {
+		seq   int64
+		event *outbox.Event
+	}
+	batch, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (claimed, error) {
+		var c claimed
+		var e outbox.Event
+		var payload []byte
+		err := row.Scan(&c.seq, &e.ID, &e.Service, &e.Type, &e.AggregateType, &e.AggregateID,
+			&payload, &e.OccurredAt, &e.Attempts)
+		e.Payload = payload
+		c.event = &e
+		return c, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan outbox events: %w", err)
+	}
+
+	// Kết quả đã gửi vẫn phải được lưu khi Dispatcher đang dừng
+	saveCtx := context.WithoutCancel(ctx)
+	processed := 0
+	for _, c := range batch {
+		if ctx.Err() != nil {
+			break
+		}
+
+		outcome := handle(ctx, c.event)
+		if err := saveOutcome(saveCtx, tx, c.seq, outcome); err != nil {
+			return 0, err
+		}
+		processed++
+	}
+
+	if err := tx.Commit(saveCtx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return processed, nil
+}
+
+func saveOutcome(ctx context.Context, tx pgx.Tx, seq int64, o outbox.Outcome) error {
+	var err error
+	switch {
+	case o.Err == nil:
+		_, err = tx.Exec(ctx, `
+			UPDATE outbox_events SET dispatched_at = NOW(), last_error = NULL WHERE id = $1
+		`, seq)
+	case o.Dead:
+		_, err = tx.Exec(ctx, `
+			UPDATE outbox_events SET attempts = attempts + 1, last_error = $2, dead_at = NOW() WHERE id = $1
+		`, seq, o.Err.Error())
+	default:
+		retryAt := o.RetryAt
+		if retryAt.IsZero() {
+			retryAt = time.Now()
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE outbox_events SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1
+		`, seq, o.Err.Error(), retryAt)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save outbox event outcome: %w", err)
+	}
+	return nil
+}
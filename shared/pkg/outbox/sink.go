@@ -0,0 +1,145 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sink nhận event từ Dispatcher. Publish trả lỗi thì event được gửi lại sau.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, e *Event) error
+}
+
+// ErrSinkFull is returned by ChannelSink when its buffer is full
+var ErrSinkFull = errors.New("sink buffer is full")
+
+// ChannelSink đẩy event vào channel cho consumer trong cùng process
+type ChannelSink struct {
+	ch chan *Event
+}
+
+// NewChannelSink creates a ChannelSink with the given buffer size
+func NewChannelSink(buffer int) *ChannelSink {
+	return &ChannelSink{ch: make(chan *Event, buffer)}
+}
+
+// Events trả về channel để consumer đọc event
+func (s *ChannelSink) Events() <-chan *Event {
+	return s.ch
+}
+
+func (s *ChannelSink) Name() string { return "channel" }
+
+// Publish không block: buffer đầy thì trả ErrSinkFull để Dispatcher gửi lại sau
+func (s *ChannelSink) Publish(ctx context.Context, e *Event) error {
+	select {
+	case s.ch <- e:
+		return nil
+	default:
+		return ErrSinkFull
+	}
+}
+
+// Webhook headers
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+	HeaderSignature = "X-Signature" // "sha256=" + hex(HMAC-SHA256(secret, body))
+)
+
+// WebhookSink POST event (JSON) tới 1 URL, ký body bằng HMAC nếu có secret
+type WebhookSink struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewWebhookSink creates a WebhookSink; empty secret disables the signature header
+func NewWebhookSink(url, secret string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+// Publish coi mọi response 2xx là thành công
+func (s *WebhookSink) Publish(ctx context.Context, e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, e.ID)
+	req.Header.Set(HeaderEventType, e.Type)
+	if len(s.secret) > 0 {
+		req.Header.Set(HeaderSignature, Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post event: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign trả về giá trị header X-Signature cho body, consumer dùng để verify
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Publisher is the subset of a NATS connection used by NATSSink (*nats.Conn implements it)
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATSSink publish event lên subject "<prefix>.<service>.<type>"
+type NATSSink struct {
+	pub    Publisher
+	prefix string
+}
+
+// NewNATSSink creates a NATSSink on top of a NATS-compatible publisher
+func NewNATSSink(pub Publisher, subjectPrefix string) *NATSSink {
+	return &NATSSink{pub: pub, prefix: strings.TrimSuffix(subjectPrefix, ".")}
+}
+
+func (s *NATSSink) Name() string { return "nats" }
+
+func (s *NATSSink) Publish(ctx context.Context, e *Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	subject := e.Service + "." + e.Type
+	if s.prefix != "" {
+		subject = s.prefix + "." + subject
+	}
+	return s.pub.Publish(subject, data)
+}
@@ -0,0 +1,47 @@
+// Package poller chứa vòng lặp xử lý theo batch và exponential backoff dùng chung cho các
+// job nền (outbox Dispatcher, webhook Worker).
+package poller
+
+import (
+	"context"
+	"time"
+)
+
+// Run gọi process cho tới khi ctx bị hủy. process trả về số item đã xử lý: batch đầy
+// (n == batchSize) thì gọi lại ngay, không chờ interval. Lỗi được báo qua onError
+// (không báo khi ctx đã bị hủy).
+func Run(ctx context.Context, interval time.Duration, batchSize int, process func(ctx context.Context) (int, error), onError func(err error)) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := process(ctx)
+		if err != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		next := interval
+		if err == nil && n == batchSize {
+			next = 0
+		}
+		timer.Reset(next)
+	}
+}
+
+// Backoff trả về thời gian chờ trước lần thử thứ attempts+1: base, nhân đôi mỗi lần, tối đa max
+func Backoff(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
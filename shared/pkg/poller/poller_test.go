@@ -0,0 +1,87 @@
+package poller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{"First retry", 1, 10 * time.Second},
+		{"Second retry doubles", 2, 20 * time.Second},
+		{"Fourth retry", 4, 80 * time.Second},
+		{"Capped at max", 10, 5 * time.Minute},
+		{"Zero attempts", 0, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Backoff(10*time.Second, 5*time.Minute, tt.attempts); got != tt.want {
+				t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	const batchSize = 10
+
+	tests := []struct {
+		name string
+		// results là kết quả các lần gọi process liên tiếp
+		results      []int
+		err          error
+		wantCalls    int // Số lần gọi trước khi interval (1 giờ) chặn vòng lặp
+		wantReported int
+	}{
+		{"Partial batch waits for interval", []int{3}, nil, 1, 0},
+		{"Full batches are processed back to back", []int{batchSize, batchSize, 2}, nil, 3, 0},
+		{"Error is reported and waits for interval", []int{batchSize}, errors.New("db down"), 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Vòng lặp dừng ở lần gọi thứ wantCalls: hủy ctx sau khi lỗi được báo, hoặc ngay trong
+			// process khi không có lỗi. Gọi thêm lần nào nghĩa là không chờ interval.
+			calls, reported := 0, 0
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				Run(ctx, time.Hour, batchSize, func(ctx context.Context) (int, error) {
+					calls++
+					if calls > len(tt.results) {
+						return 0, nil
+					}
+					if calls == tt.wantCalls && tt.err == nil {
+						cancel()
+					}
+					return tt.results[calls-1], tt.err
+				}, func(err error) {
+					reported++
+					cancel()
+				})
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Run() did not stop after ctx was cancelled")
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("process calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if reported != tt.wantReported {
+				t.Errorf("errors reported = %d, want %d", reported, tt.wantReported)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Step là 1 bước của saga. Bước được thực thi bởi caller (Execution.Do) nên input nhạy cảm
+// (vd: password) không cần lưu; Orchestrator chỉ cần Compensate để hoàn tác.
+type Step struct {
+	Name string
+	// Compensate hoàn tác bước từ Data đã lưu; nil = bước không cần hoàn tác.
+	// Phải idempotent: bước có thể chưa chạy xong, hoặc đã được bù trừ ở lần thử trước.
+	Compensate func(ctx context.Context, data json.RawMessage) error
+	// Execute chạy bước của saga Forward từ Data đã lưu và trả về Data mới.
+	// Phải idempotent: bước có thể đã chạy xong 1 phần (hoặc toàn bộ) ở lần thử trước.
+	Execute func(ctx context.Context, data json.RawMessage) (json.RawMessage, error)
+}
+
+// Definition mô tả 1 loại saga: các bước theo thứ tự thực thi
+type Definition struct {
+	Type  string
+	Steps []Step
+	// Forward: bước lỗi/bị bỏ dở được chạy lại (Step.Execute) tới khi hoàn tất thay vì bù trừ.
+	// Saga Forward chạy bằng Execution.Continue, không dùng Do.
+	Forward bool
+}
+
+func (d *Definition) index(step string) int {
+	for i, s := range d.Steps {
+		if s.Name == step {
+			return i
+		}
+	}
+	return -1
+}
+
+// Config cấu hình Orchestrator
+type Config struct {
+	PollInterval time.Duration // Chu kỳ quét saga cần bù trừ
+	BatchSize    int
+	BaseBackoff  time.Duration // Backoff lần bù trừ lại đầu tiên, nhân đôi mỗi lần
+	MaxBackoff   time.Duration // Bù trừ được thử lại mãi, tối đa mỗi MaxBackoff
+	StaleAfter   time.Duration // Saga running không cập nhật quá khoảng này coi như process đã chết
+}
+
+// Giá trị mặc định khi field của Config bằng 0
+const (
+	DefaultPollInterval = 5 * time.Second
+	DefaultBatchSize    = 20
+	DefaultBaseBackoff  = 5 * time.Second
+	DefaultMaxBackoff   = 10 * time.Minute
+	DefaultStaleAfter   = time.Minute
+)
+
+// Orchestrator tạo saga và chạy nền việc bù trừ các saga thất bại hoặc bị bỏ dở
+type Orchestrator struct {
+	store Store
+	defs  map[string]*Definition
+	cfg   Config
+}
+
+// NewOrchestrator creates an Orchestrator for defs; zero config fields take the defaults
+func NewOrchestrator(store Store, cfg Config, defs ...*Definition) *Orchestrator {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = DefaultBaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+	if cfg.StaleAfter <= 0 {
+		cfg.StaleAfter = DefaultStaleAfter
+	}
+
+	byType := make(map[string]*Definition, len(defs))
+	for _, d := range defs {
+		byType[d.Type] = d
+	}
+	return &Orchestrator{store: store, defs: byType, cfg: cfg}
+}
+
+// Execution là 1 saga đang được caller thực thi
+type Execution struct {
+	o     *Orchestrator
+	def   *Definition
+	saga  *Saga
+	state any
+	last  int // Index bước đã chạy gần nhất, -1 = chưa chạy bước nào
+}
+
+// Start lưu saga mới với id cho trước. state (con trỏ) được lưu dạng JSON sau mỗi bước thành công,
+// Compensate đọc lại từ đó nên không được chứa secret.
+func (o *Orchestrator) Start(ctx context.Context, sagaType, id string, state any) (*Execution, error) {
+	def, ok := o.defs[sagaType]
+	if !ok || len(def.Steps) == 0 {
+		return nil, fmt.Errorf("%w: unknown saga type %q", ErrInvalidInput, sagaType)
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal saga state: %w", err)
+	}
+
+	s := &Saga{
+		ID:          id,
+		Type:        sagaType,
+		Status:      StatusRunning,
+		Step:        0,
+		CurrentStep: def.Steps[0].Name,
+		Data:        data,
+	}
+	if err := o.store.Create(ctx, s); err != nil {
+		return nil, err
+	}
+	return &Execution{o: o, def: def, saga: s, state: state, last: -1}, nil
+}
+
+// Get returns a saga with its step history (ErrNotFound nếu không tồn tại)
+func (o *Orchestrator) Get(ctx context.Context, id string) (*Saga, error) {
+	return o.store.Get(ctx, id)
+}
+
+// ID returns the saga ID
+func (e *Execution) ID() string {
+	return e.saga.ID
+}
+
+// Status returns the current saga status
+func (e *Execution) Status() string {
+	return e.saga.Status
+}
+
+// Do chạy 1 bước. Bước phải theo thứ tự của Definition (được bỏ qua bước).
+// Index bước được lưu trước khi chạy fn nên process chết giữa chừng thì bước này cũng được bù trừ.
+// fn lỗi thì saga bị hủy (Abort) và lỗi của fn được trả về.
+func (e *Execution) Do(ctx context.Context, step string, fn func(ctx context.Context) error) error {
+	if e.def.Forward {
+		return fmt.Errorf("%w: saga %s runs forward, use Continue", ErrInvalidInput, e.saga.Type)
+	}
+	idx := e.def.index(step)
+	if idx <= e.last {
+		return fmt.Errorf("%w: step %q out of order in saga %s", ErrInvalidInput, step, e.saga.Type)
+	}
+	if e.saga.Status != StatusRunning {
+		return fmt.Errorf("%w: saga %s is %s", ErrConflict, e.saga.ID, e.saga.Status)
+	}
+
+	if idx != e.saga.Step {
+		e.saga.Step, e.saga.CurrentStep = idx, step
+		if err := e.o.store.Save(ctx, e.saga); err != nil {
+			return fmt.Errorf("failed to save saga step: %w", err)
+		}
+	}
+
+	start := time.Now()
+	err := fn(ctx)
+	entry := &StepLog{Step: step, Action: ActionExecute, At: start, Duration: time.Since(start)}
+	data, merr := json.Marshal(e.state)
+	if err != nil {
+		// Bước lỗi có thể đã ghi state (vd: ID vừa tạo) → lưu lại cho Compensate
+		if merr == nil {
+			e.saga.Data = data
+		}
+		entry.Error = err.Error()
+		e.abort(ctx, err, entry)
+		return err
+	}
+	if merr != nil {
+		e.abort(ctx, merr, entry)
+		return fmt.Errorf("failed to marshal saga state: %w", merr)
+	}
+	e.saga.Data = data
+	e.last = idx
+	if err := e.o.store.Save(ctx, e.saga, entry); err != nil {
+		e.abort(ctx, err)
+		return fmt.Errorf("failed to save saga step: %w", err)
+	}
+	return nil
+}
+
+// Continue chạy các bước còn lại của saga Forward, lưu sau mỗi bước. Bước lỗi thì saga vẫn running
+// và được Orchestrator chạy lại từ bước đó (backoff); lỗi của bước được trả về.
+// Không dùng ctx của request: client ngắt kết nối không được làm dừng giữa chừng.
+func (e *Execution) Continue(ctx context.Context) error {
+	if !e.def.Forward {
+		return fmt.Errorf("%w: saga %s is not a forward saga", ErrInvalidInput, e.saga.Type)
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	for e.saga.Status == StatusRunning {
+		entry, err := e.o.forward(ctx, e.def, e.saga)
+		var logs []*StepLog
+		if entry != nil {
+			logs = append(logs, entry)
+		}
+		if serr := e.o.store.Save(ctx, e.saga, logs...); serr != nil {
+			// Saga vẫn running trong DB → Orchestrator sẽ tiếp quản khi quá StaleAfter
+			return fmt.Errorf("failed to save saga step: %w", serr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Complete đánh dấu saga hoàn tất. Lưu lỗi thì saga bị hủy (bù trừ) để không có kết quả nửa vời.
+func (e *Execution) Complete(ctx context.Context) error {
+	now := time.Now()
+	e.saga.Status = StatusCompleted
+	e.saga.CurrentStep = ""
+	e.saga.FinishedAt = &now
+	if err := e.o.store.Save(ctx, e.saga); err != nil {
+		e.saga.Status, e.saga.FinishedAt = StatusRunning, nil
+		e.abort(ctx, err)
+		return fmt.Errorf("failed to complete saga: %w", err)
+	}
+	return nil
+}
+
+// Abort hủy saga vì cause (lỗi ngoài các bước) và bù trừ ngay các bước đã chạy
+func (e *Execution) Abort(ctx context.Context, cause error) {
+	if e.saga.Status == StatusRunning {
+		e.abort(ctx, cause)
+	}
+}
+
+// abort bù trừ inline; bù trừ lỗi thì saga nằm lại ở compensating cho Orchestrator thử lại.
+// Không dùng ctx của request: client ngắt kết nối không được làm dừng việc bù trừ.
+func (e *Execution) abort(ctx context.Context, cause error, logs ...*StepLog) {
+	ctx = context.WithoutCancel(ctx)
+
+	e.saga.Status = StatusCompensating
+	e.saga.LastError = cause.Error()
+	logs = append(logs, e.o.compensate(ctx, e.def, e.saga)...)
+
+	if err := e.o.store.Save(ctx, e.saga, logs...); err != nil {
+		// Saga vẫn running/compensating trong DB → Orchestrator sẽ tiếp quản khi quá StaleAfter
+		log.Printf("ERROR: [SAGA] Failed to save aborted saga %s (%s): %v", e.saga.ID, e.saga.Type, err)
+		return
+	}
+	if e.saga.Status == StatusCompensated {
+		log.Printf("[SAGA] Saga %s (%s) compensated: %s", e.saga.ID, e.saga.Type, cause)
+	}
+}
+
+// compensate bù trừ từ s.Step về 0, dừng ở bước lỗi đầu tiên và hẹn lần thử lại
+func (o *Orchestrator) compensate(ctx context.Context, def *Definition, s *Saga) []*StepLog {
+	var logs []*StepLog
+	for s.Step >= 0 {
+		step := def.Steps[s.Step]
+		s.CurrentStep = step.Name
+
+		if step.Compensate != nil {
+			start := time.Now()
+			err := step.Compensate(ctx, s.Data)
+			entry := &StepLog{Step: step.Name, Action: ActionCompensate, At: start, Duration: time.Since(start)}
+			logs = append(logs, entry)
+
+			if err != nil {
+				entry.Error = err.Error()
+				s.Attempts++
+				s.LastError = fmt.Sprintf("compensate %s: %v", step.Name, err)
+				next := time.Now().Add(o.backoff(s.Attempts))
+				s.NextAttemptAt = &next
+				log.Printf("WARNING: [SAGA] Saga %s (%s) failed to compensate step %s (attempt %d, retry at %s): %v",
+					s.ID, s.Type, step.Name, s.Attempts, next.Format(time.RFC3339), err)
+				return logs
+			}
+		}
+		s.Step--
+	}
+
+	o.finish(s, StatusCompensated)
+	return logs
+}
+
+// forward chạy bước s.Step của saga Forward (bước cuối xong thì saga completed).
+// Bước lỗi thì hẹn lần chạy lại và trả về lỗi của bước.
+func (o *Orchestrator) forward(ctx context.Context, def *Definition, s *Saga) (*StepLog, error) {
+	step := def.Steps[s.Step]
+	s.CurrentStep = step.Name
+	if step.Execute == nil {
+		o.advance(def, s)
+		return nil, nil
+	}
+
+	start := time.Now()
+	data, err := step.Execute(ctx, s.Data)
+	entry := &StepLog{Step: step.Name, Action: ActionExecute, At: start, Duration: time.Since(start)}
+	if err != nil {
+		entry.Error = err.Error()
+		s.Attempts++
+		s.LastError = fmt.Sprintf("execute %s: %v", step.Name, err)
+		next := time.Now().Add(o.backoff(s.Attempts))
+		s.NextAttemptAt = &next
+		log.Printf("WARNING: [SAGA] Saga %s (%s) failed at step %s (attempt %d, retry at %s): %v",
+			s.ID, s.Type, step.Name, s.Attempts, next.Format(time.RFC3339), err)
+		return entry, err
+	}
+
+	s.Data = data
+	s.NextAttemptAt = nil
+	o.advance(def, s)
+	return entry, nil
+}
+
+// advance chuyển saga Forward sang bước tiếp theo, hết bước thì completed (Step = -1)
+func (o *Orchestrator) advance(def *Definition, s *Saga) {
+	s.Step++
+	if s.Step >= len(def.Steps) {
+		s.Step = -1
+		o.finish(s, StatusCompleted)
+	}
+}
+
+// finish đưa saga về trạng thái kết thúc
+func (o *Orchestrator) finish(s *Saga, status string) {
+	now := time.Now()
+	s.Status = status
+	s.CurrentStep = ""
+	s.NextAttemptAt = nil
+	s.FinishedAt = &now
+}
+
+// Run bù trừ nền các saga thất bại/bị bỏ dở cho tới khi ctx bị hủy
+func (o *Orchestrator) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		staleBefore := time.Now().Add(-o.cfg.StaleAfter)
+		n, err := o.store.ProcessRecoverable(ctx, o.cfg.BatchSize, staleBefore, o.recover)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("WARNING: [SAGA] Failed to recover sagas: %v", err)
+		}
+
+		next := o.cfg.PollInterval
+		if err == nil && n == o.cfg.BatchSize {
+			next = 0
+		}
+		timer.Reset(next)
+	}
+}
+
+func (o *Orchestrator) recover(ctx context.Context, s *Saga) []*StepLog {
+	def, ok := o.defs[s.Type]
+	if !ok {
+		s.Attempts++
+		s.LastError = fmt.Sprintf("no definition registered for saga type %q", s.Type)
+		next := time.Now().Add(o.backoff(s.Attempts))
+		s.NextAttemptAt = &next
+		log.Printf("ERROR: [SAGA] Saga %s: %s", s.ID, s.LastError)
+		return nil
+	}
+
+	if def.Forward {
+		// Chạy tiếp từ bước lỗi/bị bỏ dở; lỗi thì dừng và chờ lần chạy lại tiếp theo
+		var logs []*StepLog
+		for s.Status == StatusRunning {
+			entry, err := o.forward(ctx, def, s)
+			if entry != nil {
+				logs = append(logs, entry)
+			}
+			if err != nil {
+				break
+			}
+		}
+		return logs
+	}
+
+	if s.Status == StatusRunning {
+		// Process thực thi saga đã chết giữa chừng → hủy và bù trừ cả bước đang chạy dở
+		log.Printf("WARNING: [SAGA] Saga %s (%s) abandoned at step %s, compensating", s.ID, s.Type, s.CurrentStep)
+		s.Status = StatusCompensating
+		s.LastError = fmt.Sprintf("abandoned at step %s", s.CurrentStep)
+	}
+	return o.compensate(ctx, def, s)
+}
+
+func (o *Orchestrator) backoff(attempts int) time.Duration {
+	delay := o.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < o.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > o.cfg.MaxBackoff {
+		delay = o.cfg.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,264 @@
+// Package pgstore stores sagas in PostgreSQL (bảng sagas, saga_steps, xem migration của User Service)
+package pgstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/saga"
+)
+
+const sagaColumns = `id, saga_type, status, step_index, current_step, data, attempts, COALESCE(last_error, ''),
+	next_attempt_at, version, created_at, updated_at, finished_at`
+
+type postgresStore struct {
+	db *pgxpool.Pool
+}
+
+// NewStore creates a saga.Store
+func NewStore(db *pgxpool.Pool) saga.Store {
+	return &postgresStore{db: db}
+}
+
+func scanSaga(row pgx.Row) (*saga.Saga, error) {
+	var s saga.Saga
+	var data []byte
+	err := row.Scan(&s.ID, &s.Type, &s.Status, &s.Step, &s.CurrentStep, &data, &s.Attempts, &s.LastError,
+		&s.NextAttemptAt, &s.Version, &s.CreatedAt, &s.UpdatedAt, &s.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.Data = data
+	return &s, nil
+}
+
+func (p *postgresStore) Create(ctx context.Context, s *saga.Saga) error {
+	query := `
+		INSERT INTO sagas (id, saga_type, status, step_index, current_step, data)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING version, created_at, updated_at
+	`
+
+	err := p.db.QueryRow(ctx, query, s.ID, s.Type, s.Status, s.Step, s.CurrentStep, string(s.Data)).
+		Scan(&s.Version, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create saga: %w", err)
+	}
+	return nil
+}
+
+func (p *postgresStore) Save(ctx context.Context, s *saga.Saga, steps ...*saga.StepLog) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := save(ctx, tx, s, steps); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// save cập nhật saga nếu Version chưa đổi, rồi ghi lịch sử các bước
+func save(ctx context.Context, tx pgx.Tx, s *saga.Saga, steps []*saga.StepLog) error {
+	err := tx.QueryRow(ctx, `
+		UPDATE sagas
+		SET status = $3, step_index = $4, current_step = $5, data = $6, attempts = $7,
+		    last_error = NULLIF($8, ''), next_attempt_at = $9, finished_at = $10,
+		    version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND version = $2
+		RETURNING version, updated_at
+	`, s.ID, s.Version, s.Status, s.Step, s.CurrentStep, string(s.Data), s.Attempts,
+		s.LastError, s.NextAttemptAt, s.FinishedAt,
+	).Scan(&s.Version, &s.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("%w: saga %s", saga.ErrConflict, s.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save saga: %w", err)
+	}
+
+	for _, st := range steps {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO saga_steps (saga_id, step, action, error, attempted_at, duration_ms)
+			VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+		`, s.ID, st.Step, st.Action, st.Error, st.At, st.Duration.Milliseconds())
+		if err != nil {
+			return fmt.Errorf("failed to record saga step: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p *postgresStore) Get(ctx context.Context, id string) (*saga.Saga, error) {
+	s, err := scanSaga(p.db.QueryRow(ctx, `SELECT `+sagaColumns+` FROM sagas WHERE id::text = $1`, id))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", saga.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saga: %w", err)
+	}
+
+	if err := p.loadHistory(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (p *postgresStore) loadHistory(ctx context.Context, s *saga.Saga) error {
+	rows, err := p.db.Query(ctx, `
+		SELECT step, action, COALESCE(error, ''), attempted_at, duration_ms
+		FROM saga_steps
+		WHERE saga_id = $1
+		ORDER BY id
+	`, s.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get saga steps: %w", err)
+	}
+
+	s.History, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (*saga.StepLog, error) {
+		var st saga.StepLog
+		var durationMs int64
+		err := row.Scan(&st.Step, &st.Action, &st.Error, &st.At, &durationMs)
+		st.Duration = time.Duration(durationMs) * time.Millisecond
+		return &st, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan saga steps: %w", err)
+	}
+	return nil
+}
+
+func (p *postgresStore) List(ctx context.Context, f saga.Filter) ([]*saga.Saga, int, error) {
+	f.Normalize()
+
+	// Bị kẹt: chưa kết thúc sau saga.StuckAfter
+	stuckBefore := time.Now().Add(-saga.StuckAfter)
+	where := `WHERE ($1 = '' OR saga_type = $1) AND ($2 = '' OR status = $2)
+		AND (NOT $3 OR (status IN ('running', 'compensating') AND created_at < $4))`
+	args := []any{f.Type, f.Status, f.StuckOnly, stuckBefore}
+
+	var total int
+	if err := p.db.QueryRow(ctx, `SELECT COUNT(*) FROM sagas `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count sagas: %w", err)
+	}
+
+	rows, err := p.db.Query(ctx, `
+		SELECT `+sagaColumns+`
+		FROM sagas
+		`+where+`
+		ORDER BY created_at DESC, id
+		LIMIT $5 OFFSET $6
+	`, append(args, f.PageSize, (f.Page-1)*f.PageSize)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list sagas: %w", err)
+	}
+
+	sagas, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*saga.Saga, error) {
+		return scanSaga(row)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan sagas: %w", err)
+	}
+	return sagas, total, nil
+}
+
+func (p *postgresStore) Retry(ctx context.Context, id string) (*saga.Saga, error) {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var status string
+	var nextAttemptAt *time.Time
+	err = tx.QueryRow(ctx, `SELECT status, next_attempt_at FROM sagas WHERE id::text = $1 FOR UPDATE`, id).Scan(&status, &nextAttemptAt)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", saga.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saga: %w", err)
+	}
+	// Saga Forward chờ chạy lại: running có next_attempt_at
+	waiting := status == saga.StatusCompensating || (status == saga.StatusRunning && nextAttemptAt != nil)
+	if !waiting {
+		return nil, fmt.Errorf("%w: saga %s is %s", saga.ErrNotRetryable, id, status)
+	}
+
+	s, err := scanSaga(tx.QueryRow(ctx, `
+		UPDATE sagas
+		SET next_attempt_at = NOW(), version = version + 1, updated_at = NOW()
+		WHERE id::text = $1
+		RETURNING `+sagaColumns, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry saga: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := p.loadHistory(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ProcessRecoverable dùng FOR UPDATE SKIP LOCKED để nhiều instance chạy Orchestrator song song.
+// Saga Forward lỗi (running, có next_attempt_at) được chạy lại khi tới hạn, không chờ staleBefore.
+func (p *postgresStore) ProcessRecoverable(ctx context.Context, limit int, staleBefore time.Time, handle func(ctx context.Context, s *saga.Saga) []*saga.StepLog) (int, error) {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(context.WithoutCancel(ctx))
+
+	rows, err := tx.Query(ctx, `
+		SELECT `+sagaColumns+`
+		FROM sagas
+		WHERE (status = 'compensating' AND next_attempt_at <= NOW())
+		   OR (status = 'running' AND COALESCE(next_attempt_at <= NOW(), updated_at < $1))
+		ORDER BY COALESCE(next_attempt_at, updated_at)
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, staleBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim sagas: %w", err)
+	}
+
+	batch, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*saga.Saga, error) {
+		return scanSaga(row)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan sagas: %w", err)
+	}
+
+	// Kết quả bù trừ đã chạy vẫn phải được lưu khi Orchestrator đang dừng
+	saveCtx := context.WithoutCancel(ctx)
+	processed := 0
+	for _, s := range batch {
+		if ctx.Err() != nil {
+			break
+		}
+
+		steps := handle(ctx, s)
+		if err := save(saveCtx, tx, s, steps); err != nil {
+			return 0, err
+		}
+		processed++
+	}
+
+	if err := tx.Commit(saveCtx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return processed, nil
+}
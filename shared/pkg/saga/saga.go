@@ -0,0 +1,129 @@
+// Package saga runs multi-step operations spanning several services with durable compensation.
+//
+// Mỗi saga được lưu (bảng sagas, saga_steps) trước khi chạy bước đầu tiên và sau mỗi bước.
+// Bước lỗi thì các bước đã chạy (kể cả bước đang chạy dở) được bù trừ theo thứ tự ngược lại;
+// bù trừ lỗi được Orchestrator chạy nền thử lại tới khi thành công. Saga "running" không được
+// cập nhật quá StaleAfter (process chết giữa chừng) cũng được Orchestrator bù trừ.
+//
+// Saga Forward (Definition.Forward) dùng cho thao tác không hoàn tác được (vd: xóa dữ liệu cá nhân):
+// bước lỗi hoặc bị bỏ dở được chạy lại từ bước đó (backoff) tới khi saga hoàn tất, không bù trừ.
+// Admin xem saga bị kẹt và ép thử lại qua SagaService.
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Saga statuses
+const (
+	StatusRunning      = "running"      // Saga Forward lỗi vẫn running, chờ chạy lại tới next_attempt_at
+	StatusCompensating = "compensating" // Đang bù trừ, lỗi thì chờ thử lại
+	StatusCompleted    = "completed"
+	StatusCompensated  = "compensated" // Đã bù trừ xong mọi bước
+)
+
+// Step actions trong lịch sử
+const (
+	ActionExecute    = "execute"
+	ActionCompensate = "compensate"
+)
+
+// StuckAfter - saga chưa kết thúc sau khoảng này được coi là bị kẹt (Admin cần xem)
+const StuckAfter = 5 * time.Minute
+
+// Pagination mặc định/giới hạn cho List
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var (
+	// ErrNotFound is returned when a saga does not exist
+	ErrNotFound = errors.New("saga not found")
+	// ErrInvalidInput is returned for malformed filters
+	ErrInvalidInput = errors.New("invalid saga input")
+	// ErrConflict is returned when the saga was changed by someone else (vd: bị Orchestrator tiếp quản)
+	ErrConflict = errors.New("saga was modified concurrently")
+	// ErrNotRetryable is returned when retrying a saga that is not waiting for a retry
+	ErrNotRetryable = errors.New("saga is not waiting for a retry")
+)
+
+// Saga là 1 lần chạy của 1 Definition
+type Saga struct {
+	ID            string
+	Type          string
+	Status        string
+	Step          int    // Index bước đang chạy (running) / cần bù trừ tiếp theo (compensating), -1 = không còn
+	CurrentStep   string // Tên bước tương ứng Step
+	Data          json.RawMessage
+	Attempts      int // Số lần bù trừ (saga Forward: chạy bước) thất bại
+	LastError     string
+	NextAttemptAt *time.Time
+	Version       int // Optimistic lock, tăng mỗi lần lưu
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	FinishedAt    *time.Time
+	History       []*StepLog // Cũ nhất trước, chỉ có khi Get
+}
+
+// Finished reports whether the saga reached a terminal status
+func (s *Saga) Finished() bool {
+	return s.Status == StatusCompleted || s.Status == StatusCompensated
+}
+
+// Stuck reports whether the saga is still unfinished StuckAfter after it started
+func (s *Saga) Stuck(now time.Time) bool {
+	return !s.Finished() && now.Sub(s.CreatedAt) > StuckAfter
+}
+
+// StepLog là 1 lần thực thi hoặc bù trừ 1 bước
+type StepLog struct {
+	Step     string
+	Action   string
+	Error    string // Rỗng = thành công
+	At       time.Time
+	Duration time.Duration
+}
+
+// Filter holds parameters for listing sagas
+type Filter struct {
+	Type      string
+	Status    string
+	StuckOnly bool
+	Page      int
+	PageSize  int
+}
+
+// Normalize clamps page and page size to the allowed range
+func (f *Filter) Normalize() {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.PageSize < 1 {
+		f.PageSize = DefaultPageSize
+	}
+	if f.PageSize > MaxPageSize {
+		f.PageSize = MaxPageSize
+	}
+}
+
+// Store persists sagas and their step history
+type Store interface {
+	// Create lưu saga mới (Version = 0)
+	Create(ctx context.Context, s *Saga) error
+	// Save lưu trạng thái saga kèm các bước vừa chạy; trả về ErrConflict nếu Version đã thay đổi
+	Save(ctx context.Context, s *Saga, steps ...*StepLog) error
+
+	// Get trả về saga kèm lịch sử các bước
+	Get(ctx context.Context, id string) (*Saga, error)
+	List(ctx context.Context, filter Filter) ([]*Saga, int, error)
+	// Retry cho saga đang chờ thử lại (compensating, hoặc saga Forward running có next_attempt_at) chạy lại ngay
+	Retry(ctx context.Context, id string) (*Saga, error)
+
+	// ProcessRecoverable khóa tối đa limit saga cần xử lý (compensating tới hạn, running có next_attempt_at
+	// tới hạn, hoặc running không cập nhật từ staleBefore), gọi handle và lưu saga cùng các bước handle trả về
+	ProcessRecoverable(ctx context.Context, limit int, staleBefore time.Time, handle func(ctx context.Context, s *Saga) []*StepLog) (int, error)
+}
@@ -0,0 +1,128 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/saga"
+)
+
+// Server implements the SagaService gRPC API on top of a Store
+type Server struct {
+	pb.UnimplementedSagaServiceServer
+	store Store
+}
+
+// NewServer creates a SagaService server
+func NewServer(store Store) *Server {
+	return &Server{store: store}
+}
+
+func (s *Server) ListSagas(ctx context.Context, req *pb.ListSagasRequest) (*pb.ListSagasResponse, error) {
+	filter := Filter{
+		Type:      req.Type,
+		Status:    req.Status,
+		StuckOnly: req.StuckOnly,
+		Page:      int(req.Page),
+		PageSize:  int(req.PageSize),
+	}
+	filter.Normalize()
+
+	switch filter.Status {
+	case "", StatusRunning, StatusCompensating, StatusCompleted, StatusCompensated:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid status %q", filter.Status)
+	}
+
+	sagas, total, err := s.store.List(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	now := time.Now()
+	resp := &pb.ListSagasResponse{
+		Sagas:      make([]*pb.Saga, len(sagas)),
+		TotalCount: int32(total),
+		Page:       int32(filter.Page),
+		PageSize:   int32(filter.PageSize),
+	}
+	for i, sg := range sagas {
+		resp.Sagas[i] = ToProto(sg, now)
+	}
+	return resp, nil
+}
+
+func (s *Server) GetSaga(ctx context.Context, req *pb.GetSagaRequest) (*pb.Saga, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	sg, err := s.store.Get(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ToProto(sg, time.Now()), nil
+}
+
+func (s *Server) RetrySaga(ctx context.Context, req *pb.RetrySagaRequest) (*pb.Saga, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	sg, err := s.store.Retry(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ToProto(sg, time.Now()), nil
+}
+
+// ToProto converts a Saga to its protobuf message
+func ToProto(s *Saga, now time.Time) *pb.Saga {
+	msg := &pb.Saga{
+		Id:          s.ID,
+		Type:        s.Type,
+		Status:      s.Status,
+		CurrentStep: s.CurrentStep,
+		DataJson:    string(s.Data),
+		Attempts:    int32(s.Attempts),
+		LastError:   s.LastError,
+		CreatedAt:   s.CreatedAt.UnixMilli(),
+		UpdatedAt:   s.UpdatedAt.UnixMilli(),
+		Stuck:       s.Stuck(now),
+	}
+	if s.NextAttemptAt != nil {
+		msg.NextAttemptAt = s.NextAttemptAt.Unix()
+	}
+	if s.FinishedAt != nil {
+		msg.FinishedAt = s.FinishedAt.Unix()
+	}
+	for _, st := range s.History {
+		msg.Steps = append(msg.Steps, &pb.SagaStep{
+			Step:       st.Step,
+			Action:     st.Action,
+			Error:      st.Error,
+			At:         st.At.UnixMilli(),
+			DurationMs: st.Duration.Milliseconds(),
+		})
+	}
+	return msg
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrNotRetryable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrConflict):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "saga store error: %v", err)
+	}
+}
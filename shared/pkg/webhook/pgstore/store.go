@@ -0,0 +1,450 @@
+// Package pgstore stores webhook subscriptions and deliveries in PostgreSQL
+// (các bảng webhook_*, xem migration webhook của Document/Consent Service)
+package pgstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	"github.com/thatlq1812/policy-system/shared/pkg/webhook"
+)
+
+const subscriptionColumns = `id, url, secret, event_types, COALESCE(description, ''), is_active,
+	COALESCE(created_by, ''), created_at`
+
+const deliveryColumns = `d.id, d.subscription_id, d.service, d.event_id, d.event_type, d.status, d.attempts,
+	d.next_attempt_at, COALESCE(d.last_status_code, 0), COALESCE(d.last_error, ''), d.delivered_at,
+	d.created_at, d.payload`
+
+type postgresStore struct {
+	db      *pgxpool.Pool
+	service string
+}
+
+// NewStore creates a webhook.Store for the given service name
+func NewStore(db *pgxpool.Pool, service string) webhook.Store {
+	return &postgresStore{db: db, service: service}
+}
+
+func scanSubscription(row pgx.Row) (*webhook.Subscription, error) {
+	var sub webhook.Subscription
+	err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, &sub.EventTypes, &sub.Description, &sub.IsActive,
+		&sub.CreatedBy, &sub.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func scanDelivery(row pgx.Row) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	var payload []byte
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.Service, &d.EventID, &d.EventType, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.DeliveredAt,
+		&d.CreatedAt, &payload)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	return &d, nil
+}
+
+func (s *postgresStore) CreateSubscription(ctx context.Context, sub *webhook.Subscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (id, url, secret, event_types, description, is_active, created_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, ''))
+		ON CONFLICT (id) DO NOTHING
+		RETURNING created_at
+	`
+
+	err := s.db.QueryRow(ctx, query,
+		sub.ID, sub.URL, sub.Secret, sub.EventTypes, sub.Description, sub.IsActive, sub.CreatedBy,
+	).Scan(&sub.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("%w: %s", webhook.ErrAlreadyExists, sub.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) ListSubscriptions(ctx context.Context) ([]*webhook.Subscription, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT `+subscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE deleted_at IS NULL
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	subs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*webhook.Subscription, error) {
+		return scanSubscription(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook subscriptions: %w", err)
+	}
+	return subs, nil
+}
+
+// DeleteSubscription chỉ xóa mềm để giữ lịch sử delivery
+func (s *postgresStore) DeleteSubscription(ctx context.Context, id string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE webhook_subscriptions
+		SET is_active = FALSE, deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: subscription %s", webhook.ErrNotFound, id)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'cancelled', next_attempt_at = NULL, locked_until = NULL
+		WHERE subscription_id = $1 AND status IN ('pending', 'sending')
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to cancel pending deliveries: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) EnqueueDeliveries(ctx context.Context, e *outbox.Event) (int, error) {
+	payload, err := eventPayload(e)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.db.Exec(ctx, `
+		INSERT INTO webhook_deliveries (subscription_id, service, event_id, event_type, payload)
+		SELECT id, $1, $2, $3, $4
+		FROM webhook_subscriptions
+		WHERE is_active = TRUE AND $3 = ANY(event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`, s.service, e.ID, e.Type, payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (s *postgresStore) ListDeliveries(ctx context.Context, f webhook.DeliveryFilter) ([]*webhook.Delivery, int, error) {
+	f.Normalize()
+
+	where := `WHERE ($1 = '' OR d.subscription_id::text = $1) AND ($2 = '' OR d.status = $2)`
+
+	var total int
+	err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM webhook_deliveries d `+where, f.SubscriptionID, f.Status).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT `+deliveryColumns+`
+		FROM webhook_deliveries d
+		`+where+`
+		ORDER BY d.created_at DESC, d.id
+		LIMIT $3 OFFSET $4
+	`, f.SubscriptionID, f.Status, f.PageSize, (f.Page-1)*f.PageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*webhook.Delivery, error) {
+		return scanDelivery(row)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan webhook deliveries: %w", err)
+	}
+
+	if err := s.loadHistory(ctx, deliveries); err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// loadHistory gắn lịch sử các lần gửi vào deliveries (1 query)
+func (s *postgresStore) loadHistory(ctx context.Context, deliveries []*webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*webhook.Delivery, len(deliveries))
+	ids := make([]string, len(deliveries))
+	for i, d := range deliveries {
+		byID[d.ID] = d
+		ids[i] = d.ID
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT delivery_id, attempted_at, COALESCE(status_code, 0), COALESCE(error, ''), duration_ms
+		FROM webhook_delivery_attempts
+		WHERE delivery_id::text = ANY($1)
+		ORDER BY attempted_at DESC, id DESC
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get delivery history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var deliveryID string
+		var a webhook.Attempt
+		var durationMs int64
+		if err := rows.Scan(&deliveryID, &a.AttemptedAt, &a.StatusCode, &a.Error, &durationMs); err != nil {
+			return fmt.Errorf("failed to scan delivery attempt: %w", err)
+		}
+		a.Duration = time.Duration(durationMs) * time.Millisecond
+		if d := byID[deliveryID]; d != nil {
+			d.History = append(d.History, &a)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating delivery history: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) Redeliver(ctx context.Context, id string) (*webhook.Delivery, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var subscriptionDeleted bool
+	err = tx.QueryRow(ctx, `
+		SELECT s.deleted_at IS NOT NULL
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.id = $1
+		FOR UPDATE OF d
+	`, id).Scan(&subscriptionDeleted)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("%w: delivery %s", webhook.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if subscriptionDeleted {
+		return nil, fmt.Errorf("%w: subscription of delivery %s is deleted", webhook.ErrInvalidInput, id)
+	}
+
+	// Đếm lại attempts từ 0, lịch sử cũ vẫn nằm trong webhook_delivery_attempts
+	d, err := scanDelivery(tx.QueryRow(ctx, `
+		UPDATE webhook_deliveries d
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), locked_until = NULL
+		WHERE d.id = $1
+		RETURNING `+deliveryColumns, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE webhook_dead_letters SET redelivered_at = NOW()
+		WHERE delivery_id = $1 AND redelivered_at IS NULL
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update dead letter: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := s.loadHistory(ctx, []*webhook.Delivery{d}); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ProcessPending nhận delivery bằng lease: transaction ngắn chuyển tối đa limit delivery tới hạn
+// (hoặc có lease đã hết hạn) sang 'sending' rồi commit. POST chạy ngoài transaction, mỗi Result
+// được lưu trong transaction riêng nên lỗi lưu 1 delivery không làm gửi lại cả batch.
+// FOR UPDATE SKIP LOCKED để nhiều instance chạy Worker song song.
+func (s *postgresStore) ProcessPending(ctx context.Context, limit int, lease time.Duration, send func(ctx context.Context, d *webhook.Delivery, sub *webhook.Subscription) webhook.Result) (int, error) {
+	batch, err := s.claim(ctx, limit, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	// Kết quả đã gửi vẫn phải được lưu khi Worker đang dừng
+	saveCtx := context.WithoutCancel(ctx)
+	processed := 0
+	for i, c := range batch {
+		if ctx.Err() != nil {
+			// Trả lại các delivery chưa gửi để instance khác nhận ngay, không chờ lease hết hạn
+			return processed, s.release(saveCtx, batch[i:])
+		}
+
+		result := send(ctx, c.delivery, c.sub)
+		if err := s.saveResult(saveCtx, c, result); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// claimedDelivery là delivery đang giữ lease; lockedUntil xác định lease của lần nhận này
+type claimedDelivery struct {
+	delivery    *webhook.Delivery
+	sub         *webhook.Subscription
+	lockedUntil time.Time
+}
+
+func (s *postgresStore) claim(ctx context.Context, limit int, lease time.Duration) ([]claimedDelivery, error) {
+	rows, err := s.db.Query(ctx, `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE (status = 'pending' AND next_attempt_at <= NOW())
+			   OR (status = 'sending' AND locked_until <= NOW())
+			ORDER BY next_attempt_at, created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET status = 'sending', locked_until = NOW() + make_interval(secs => $2)
+		FROM due, webhook_subscriptions s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING `+deliveryColumns+`, s.url, s.secret, d.locked_until
+	`, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	batch, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (claimedDelivery, error) {
+		var d webhook.Delivery
+		var sub webhook.Subscription
+		var payload []byte
+		var lockedUntil time.Time
+		err := row.Scan(&d.ID, &d.SubscriptionID, &d.Service, &d.EventID, &d.EventType, &d.Status, &d.Attempts,
+			&d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.DeliveredAt,
+			&d.CreatedAt, &payload, &sub.URL, &sub.Secret, &lockedUntil)
+		d.Payload = payload
+		sub.ID = d.SubscriptionID
+		return claimedDelivery{delivery: &d, sub: &sub, lockedUntil: lockedUntil}, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook deliveries: %w", err)
+	}
+
+	// RETURNING không giữ thứ tự của CTE
+	sort.Slice(batch, func(i, j int) bool {
+		return batch[i].delivery.CreatedAt.Before(batch[j].delivery.CreatedAt)
+	})
+	return batch, nil
+}
+
+// release đưa các delivery chưa gửi về pending (nếu lease vẫn thuộc lần nhận này)
+func (s *postgresStore) release(ctx context.Context, batch []claimedDelivery) error {
+	for _, c := range batch {
+		_, err := s.db.Exec(ctx, `
+			UPDATE webhook_deliveries
+			SET status = 'pending', locked_until = NULL
+			WHERE id = $1 AND status = 'sending' AND locked_until = $2
+		`, c.delivery.ID, c.lockedUntil)
+		if err != nil {
+			return fmt.Errorf("failed to release webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// saveResult lưu 1 lần gửi. Delivery đã bị hủy, gửi lại bởi Admin hoặc lease hết hạn và
+// instance khác đã nhận thì bỏ qua kết quả: delivery không còn thuộc lần nhận này.
+func (s *postgresStore) saveResult(ctx context.Context, c claimedDelivery, r webhook.Result) error {
+	d, a := c.delivery, r.Attempt
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var result pgconn.CommandTag
+	switch {
+	case r.Delivered:
+		result, err = tx.Exec(ctx, `
+			UPDATE webhook_deliveries
+			SET status = 'delivered', attempts = attempts + 1, delivered_at = $3, next_attempt_at = NULL,
+			    locked_until = NULL, last_status_code = NULLIF($4, 0), last_error = NULL
+			WHERE id = $1 AND status = 'sending' AND locked_until = $2
+		`, d.ID, c.lockedUntil, a.AttemptedAt, a.StatusCode)
+	case r.Dead:
+		result, err = tx.Exec(ctx, `
+			UPDATE webhook_deliveries
+			SET status = 'dead', attempts = attempts + 1, next_attempt_at = NULL, locked_until = NULL,
+			    last_status_code = NULLIF($3, 0), last_error = $4
+			WHERE id = $1 AND status = 'sending' AND locked_until = $2
+		`, d.ID, c.lockedUntil, a.StatusCode, a.Error)
+		if err == nil && result.RowsAffected() > 0 {
+			_, err = tx.Exec(ctx, `
+				INSERT INTO webhook_dead_letters (delivery_id, subscription_id, event_type, attempts, last_error)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (delivery_id) DO UPDATE
+				SET attempts = EXCLUDED.attempts, last_error = EXCLUDED.last_error,
+				    dead_at = NOW(), redelivered_at = NULL
+			`, d.ID, d.SubscriptionID, d.EventType, d.Attempts+1, a.Error)
+		}
+	default:
+		result, err = tx.Exec(ctx, `
+			UPDATE webhook_deliveries
+			SET status = 'pending', attempts = attempts + 1, next_attempt_at = $3, locked_until = NULL,
+			    last_status_code = NULLIF($4, 0), last_error = $5
+			WHERE id = $1 AND status = 'sending' AND locked_until = $2
+		`, d.ID, c.lockedUntil, r.RetryAt, a.StatusCode, a.Error)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save delivery result: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempted_at, status_code, error, duration_ms)
+		VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, ''), $5)
+	`, d.ID, a.AttemptedAt, a.StatusCode, a.Error, a.Duration.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// eventPayload là body POST tới endpoint: envelope của outbox event
+func eventPayload(e *outbox.Event) (string, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal event %s: %w", e.ID, err)
+	}
+	return string(b), nil
+}
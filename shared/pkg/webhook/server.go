@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/webhook"
+	"github.com/thatlq1812/policy-system/shared/pkg/netguard"
+)
+
+// MinSecretLength - secret HMAC tối thiểu (bytes)
+const MinSecretLength = 16
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Server implements the WebhookService gRPC API of one service
+type Server struct {
+	pb.UnimplementedWebhookServiceServer
+	store      Store
+	guard      *netguard.Guard // URL không được trỏ vào địa chỉ nội bộ
+	eventTypes map[string]bool // Event do service này phát ra
+}
+
+// NewServer creates a WebhookService server accepting subscriptions to eventTypes.
+// guard phải giống guard của Worker (WorkerConfig.Guard).
+func NewServer(store Store, guard *netguard.Guard, eventTypes ...string) *Server {
+	supported := make(map[string]bool, len(eventTypes))
+	for _, t := range eventTypes {
+		supported[t] = true
+	}
+	return &Server{store: store, guard: guard, eventTypes: supported}
+}
+
+func (s *Server) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.Subscription, error) {
+	sub := &Subscription{
+		ID:          req.Id,
+		URL:         strings.TrimSpace(req.Url),
+		Secret:      req.Secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		IsActive:    true,
+		CreatedBy:   req.CreatedBy,
+	}
+	if err := s.validate(ctx, sub); err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := s.store.CreateSubscription(ctx, sub); err != nil {
+		return nil, toStatus(err)
+	}
+	return SubscriptionToProto(sub), nil
+}
+
+func (s *Server) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	subs, err := s.store.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListSubscriptionsResponse{Subscriptions: make([]*pb.Subscription, len(subs))}
+	for i, sub := range subs {
+		resp.Subscriptions[i] = SubscriptionToProto(sub)
+	}
+	return resp, nil
+}
+
+func (s *Server) DeleteSubscription(ctx context.Context, req *pb.DeleteSubscriptionRequest) (*pb.DeleteSubscriptionResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if err := s.store.DeleteSubscription(ctx, req.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteSubscriptionResponse{Success: true}, nil
+}
+
+func (s *Server) ListDeliveries(ctx context.Context, req *pb.ListDeliveriesRequest) (*pb.ListDeliveriesResponse, error) {
+	filter := DeliveryFilter{
+		SubscriptionID: req.SubscriptionId,
+		Status:         req.Status,
+		Page:           int(req.Page),
+		PageSize:       int(req.PageSize),
+	}
+	filter.Normalize()
+
+	switch filter.Status {
+	case "", StatusPending, StatusSending, StatusDelivered, StatusDead, StatusCancelled:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid status %q", filter.Status)
+	}
+
+	deliveries, total, err := s.store.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListDeliveriesResponse{
+		Deliveries: make([]*pb.Delivery, len(deliveries)),
+		TotalCount: int32(total),
+		Page:       int32(filter.Page),
+		PageSize:   int32(filter.PageSize),
+	}
+	for i, d := range deliveries {
+		resp.Deliveries[i] = DeliveryToProto(d)
+	}
+	return resp, nil
+}
+
+func (s *Server) RedeliverDelivery(ctx context.Context, req *pb.RedeliverDeliveryRequest) (*pb.Delivery, error) {
+	if !uuidPattern.MatchString(req.DeliveryId) {
+		return nil, status.Error(codes.InvalidArgument, "delivery_id must be a UUID")
+	}
+
+	d, err := s.store.Redeliver(ctx, req.DeliveryId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return DeliveryToProto(d), nil
+}
+
+func (s *Server) validate(ctx context.Context, sub *Subscription) error {
+	if !uuidPattern.MatchString(sub.ID) {
+		return fmt.Errorf("%w: id must be a UUID", ErrInvalidInput)
+	}
+
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidInput)
+	}
+	// Worker kiểm tra lại lúc dial: DNS có thể đổi sau khi đăng ký
+	if err := s.guard.CheckURL(ctx, sub.URL); err != nil {
+		return fmt.Errorf("%w: url: %v", ErrInvalidInput, err)
+	}
+
+	if len(sub.Secret) < MinSecretLength {
+		return fmt.Errorf("%w: secret must be at least %d bytes", ErrInvalidInput, MinSecretLength)
+	}
+
+	if len(sub.EventTypes) == 0 {
+		return fmt.Errorf("%w: event_types is required", ErrInvalidInput)
+	}
+	for _, t := range sub.EventTypes {
+		if !s.eventTypes[t] {
+			return fmt.Errorf("%w: unsupported event type %q", ErrInvalidInput, t)
+		}
+	}
+	return nil
+}
+
+// SubscriptionToProto converts a Subscription to its protobuf message (không có secret)
+func SubscriptionToProto(sub *Subscription) *pb.Subscription {
+	return &pb.Subscription{
+		Id:          sub.ID,
+		Url:         sub.URL,
+		EventTypes:  sub.EventTypes,
+		Description: sub.Description,
+		IsActive:    sub.IsActive,
+		CreatedBy:   sub.CreatedBy,
+		CreatedAt:   sub.CreatedAt.Unix(),
+	}
+}
+
+// DeliveryToProto converts a Delivery to its protobuf message
+func DeliveryToProto(d *Delivery) *pb.Delivery {
+	msg := &pb.Delivery{
+		Id:             d.ID,
+		SubscriptionId: d.SubscriptionID,
+		Service:        d.Service,
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       int32(d.Attempts),
+		LastStatusCode: int32(d.LastStatusCode),
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.UnixMilli(),
+		PayloadJson:    string(d.Payload),
+	}
+	if d.NextAttemptAt != nil {
+		msg.NextAttemptAt = d.NextAttemptAt.Unix()
+	}
+	if d.DeliveredAt != nil {
+		msg.DeliveredAt = d.DeliveredAt.Unix()
+	}
+	for _, a := range d.History {
+		msg.History = append(msg.History, &pb.DeliveryAttempt{
+			AttemptedAt: a.AttemptedAt.UnixMilli(),
+			StatusCode:  int32(a.StatusCode),
+			Error:       a.Error,
+			DurationMs:  a.Duration.Milliseconds(),
+		})
+	}
+	return msg
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "webhook store error: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+// Package webhook delivers domain events to admin-registered HTTP endpoints.
+//
+// Outbox Dispatcher gọi FanoutSink: mỗi event được nhân thành 1 delivery cho từng subscription
+// đang nhận event type đó. Worker chạy nền POST payload (ký HMAC bằng secret của subscription),
+// lưu lịch sử từng lần gửi, thử lại với exponential backoff và chuyển delivery hết lượt thử
+// vào dead-letter. Admin có thể gửi lại bất kỳ delivery nào (Redeliver).
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+)
+
+// Delivery statuses
+const (
+	StatusPending   = "pending"
+	StatusSending   = "sending" // Worker đang giữ lease và gửi
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"      // Hết lượt thử, nằm trong dead-letter
+	StatusCancelled = "cancelled" // Subscription bị xóa khi delivery chưa gửi xong
+)
+
+// Pagination mặc định/giới hạn cho ListDeliveries
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var (
+	// ErrNotFound is returned when a subscription or delivery does not exist
+	ErrNotFound = errors.New("webhook not found")
+	// ErrInvalidInput is returned for malformed subscriptions or filters
+	ErrInvalidInput = errors.New("invalid webhook input")
+	// ErrAlreadyExists is returned when a subscription ID is already used
+	ErrAlreadyExists = errors.New("webhook subscription already exists")
+)
+
+// Subscription là 1 endpoint nhận event
+type Subscription struct {
+	ID          string
+	URL         string
+	Secret      string // Key HMAC-SHA256, không bao giờ trả ra ngoài sau khi tạo
+	EventTypes  []string
+	Description string
+	IsActive    bool
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+// Delivery là 1 event cần gửi tới 1 subscription
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	Service        string
+	EventID        string
+	EventType      string
+	Status         string
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	Payload        json.RawMessage // Body được POST (envelope outbox.Event)
+	History        []*Attempt      // Mới nhất trước
+}
+
+// Attempt là 1 lần POST tới endpoint
+type Attempt struct {
+	AttemptedAt time.Time
+	StatusCode  int // 0 = không nhận được response
+	Error       string
+	Duration    time.Duration
+}
+
+// DeliveryFilter holds parameters for listing deliveries
+type DeliveryFilter struct {
+	SubscriptionID string
+	Status         string
+	Page           int
+	PageSize       int
+}
+
+// Normalize clamps page and page size to the allowed range
+func (f *DeliveryFilter) Normalize() {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.PageSize < 1 {
+		f.PageSize = DefaultPageSize
+	}
+	if f.PageSize > MaxPageSize {
+		f.PageSize = MaxPageSize
+	}
+}
+
+// Result là kết quả 1 lần gửi, Store lưu Attempt vào lịch sử và cập nhật delivery
+type Result struct {
+	Attempt   Attempt
+	Delivered bool
+	RetryAt   time.Time // Lần thử tiếp theo khi chưa gửi được
+	Dead      bool      // Hết lượt thử → dead-letter
+}
+
+// Store persists subscriptions, deliveries and their history
+type Store interface {
+	CreateSubscription(ctx context.Context, sub *Subscription) error
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+	// DeleteSubscription tắt subscription và hủy các delivery đang chờ
+	DeleteSubscription(ctx context.Context, id string) error
+
+	// EnqueueDeliveries tạo delivery cho mọi subscription đang nhận event type của e.
+	// Idempotent theo (subscription, event) vì outbox có thể gửi lại event.
+	EnqueueDeliveries(ctx context.Context, e *outbox.Event) (int, error)
+
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, int, error)
+	// Redeliver đưa delivery (kể cả dead/delivered) về pending để Worker gửi lại ngay
+	Redeliver(ctx context.Context, id string) (*Delivery, error)
+
+	// ProcessPending nhận tối đa limit delivery tới hạn với lease dài lease, gọi send ngoài
+	// transaction và lưu từng Result. Delivery của Worker chết giữa chừng được nhận lại khi lease hết hạn.
+	ProcessPending(ctx context.Context, limit int, lease time.Duration, send func(ctx context.Context, d *Delivery, sub *Subscription) Result) (int, error)
+}
+
+// FanoutSink là outbox.Sink nhân event thành delivery cho các subscription
+type FanoutSink struct {
+	store Store
+}
+
+// NewFanoutSink creates a FanoutSink on top of store
+func NewFanoutSink(store Store) *FanoutSink {
+	return &FanoutSink{store: store}
+}
+
+func (s *FanoutSink) Name() string { return "webhook-subscriptions" }
+
+func (s *FanoutSink) Publish(ctx context.Context, e *outbox.Event) error {
+	_, err := s.store.EnqueueDeliveries(ctx, e)
+	return err
+}
@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/netguard"
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	"github.com/thatlq1812/policy-system/shared/pkg/poller"
+)
+
+// Headers gửi kèm mỗi delivery (ngoài X-Event-ID, X-Event-Type, X-Signature của outbox)
+const (
+	HeaderSubscriptionID = "X-Webhook-ID"
+	HeaderDeliveryID     = "X-Webhook-Delivery"
+)
+
+// WorkerConfig cấu hình vòng lặp gửi webhook
+type WorkerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int           // Hết số lần thử thì delivery vào dead-letter
+	BaseBackoff  time.Duration // Nhân đôi mỗi lần thử lại
+	MaxBackoff   time.Duration
+	Timeout      time.Duration // Timeout mỗi request
+	// Lease giữ mỗi batch, phải đủ gửi hết batch; mặc định BatchSize*Timeout + 1 phút
+	Lease time.Duration
+	// Guard chặn endpoint trỏ vào địa chỉ nội bộ lúc dial; nil = chặn mọi địa chỉ không public
+	Guard *netguard.Guard
+}
+
+// Giá trị mặc định khi field của WorkerConfig bằng 0
+const (
+	DefaultPollInterval = 2 * time.Second
+	DefaultBatchSize    = 50
+	DefaultMaxAttempts  = 8
+	DefaultBaseBackoff  = 10 * time.Second
+	DefaultMaxBackoff   = time.Hour
+	DefaultTimeout      = 10 * time.Second
+)
+
+// Worker POST các delivery đang chờ tới endpoint của subscription
+type Worker struct {
+	store  Store
+	cfg    WorkerConfig
+	client *http.Client
+}
+
+// NewWorker creates a Worker; zero config fields take the defaults
+func NewWorker(store Store, cfg WorkerConfig) *Worker {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = DefaultBaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = time.Duration(cfg.BatchSize)*cfg.Timeout + time.Minute
+	}
+	client := cfg.Guard.HTTPClient(cfg.Timeout)
+	// Không follow redirect: 3xx được tính là lỗi, endpoint phải trả 2xx trực tiếp
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Worker{
+		store:  store,
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+// Run gửi delivery cho tới khi ctx bị hủy
+func (w *Worker) Run(ctx context.Context) {
+	poller.Run(ctx, w.cfg.PollInterval, w.cfg.BatchSize, func(ctx context.Context) (int, error) {
+		return w.store.ProcessPending(ctx, w.cfg.BatchSize, w.cfg.Lease, w.send)
+	}, func(err error) {
+		log.Printf("WARNING: [WEBHOOK] Failed to process deliveries: %v", err)
+	})
+}
+
+func (w *Worker) send(ctx context.Context, d *Delivery, sub *Subscription) Result {
+	start := time.Now()
+	attempt := Attempt{AttemptedAt: start}
+
+	statusCode, err := w.post(ctx, d, sub)
+	attempt.Duration = time.Since(start)
+	attempt.StatusCode = statusCode
+	if err == nil {
+		return Result{Attempt: attempt, Delivered: true}
+	}
+	attempt.Error = err.Error()
+
+	attempts := d.Attempts + 1
+	if attempts >= w.cfg.MaxAttempts {
+		log.Printf("ERROR: [WEBHOOK] Delivery %s (%s) to %s moved to dead-letter after %d attempts: %v",
+			d.ID, d.EventType, sub.URL, attempts, err)
+		return Result{Attempt: attempt, Dead: true}
+	}
+	return Result{Attempt: attempt, RetryAt: time.Now().Add(poller.Backoff(w.cfg.BaseBackoff, w.cfg.MaxBackoff, attempts))}
+}
+
+// post gửi payload, trả về status code (0 nếu không có response)
+func (w *Worker) post(ctx context.Context, d *Delivery, sub *Subscription) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "policy-system-webhooks/1.0")
+	req.Header.Set(outbox.HeaderEventID, d.EventID)
+	req.Header.Set(outbox.HeaderEventType, d.EventType)
+	req.Header.Set(HeaderSubscriptionID, sub.ID)
+	req.Header.Set(HeaderDeliveryID, d.ID)
+	req.Header.Set(outbox.HeaderSignature, outbox.Sign([]byte(sub.Secret), d.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -7,12 +7,22 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	auditpb "github.com/thatlq1812/policy-system/shared/pkg/api/audit"
+	sagapb "github.com/thatlq1812/policy-system/shared/pkg/api/saga"
 	pb "github.com/thatlq1812/policy-system/shared/pkg/api/user"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit/pgstore"
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	outboxstore "github.com/thatlq1812/policy-system/shared/pkg/outbox/pgstore"
+	"github.com/thatlq1812/policy-system/shared/pkg/saga"
+	sagastore "github.com/thatlq1812/policy-system/shared/pkg/saga/pgstore"
+	"github.com/thatlq1812/policy-system/user/internal/clients"
 	configs "github.com/thatlq1812/policy-system/user/internal/configs"
 	"github.com/thatlq1812/policy-system/user/internal/handler"
 	"github.com/thatlq1812/policy-system/user/internal/repository"
@@ -39,12 +49,84 @@ func main() {
 	}
 	log.Println("Database connection established")
 
+	// Document/Consent Service clients cho saga đăng ký và erasure saga
+	documentClient, err := clients.NewDocumentClient(cfg.DocumentServiceURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to document service: %v", err)
+	}
+	defer documentClient.Close()
+	log.Printf("Connected to document service at %s", cfg.DocumentServiceURL)
+
+	consentClient, err := clients.NewConsentClient(cfg.ConsentServiceURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to consent service: %v", err)
+	}
+	defer consentClient.Close()
+	log.Printf("Connected to consent service at %s", cfg.ConsentServiceURL)
+
 	// 3. Initialize layers (bottom-up: Repository → Service → Handler)
 	userRepo := repository.NewPostgresUserRepository(dbpool)
 	refreshTokenRepo := repository.NewPostgresRefreshTokenRepository(dbpool)
 	blacklistRepo := repository.NewPostgresTokenBlacklistRepository(dbpool) // NEW
-	svc := service.NewUserService(userRepo, refreshTokenRepo, blacklistRepo, cfg.JWTSecret, cfg.JWTExpiryHours)
-	hdl := handler.NewUserHandler(svc)
+	mfaRepo := repository.NewPostgresMFARepository(dbpool)
+	otpRepo := repository.NewPostgresOTPRepository(dbpool)
+	auditStore := pgstore.NewStore(dbpool)
+	auditLog := audit.NewLogger(auditStore, "user")
+
+	// Khóa ký access token: tạo key đầu tiên nếu chưa có, rotation chạy nền (jobCtx)
+	keys, err := service.NewKeyManager(repository.NewPostgresSigningKeyRepository(dbpool), service.KeyManagerConfig{
+		Algorithm:      cfg.JWTSigningAlgorithm,
+		RotationPeriod: cfg.JWTKeyRotationPeriod,
+		PublishAhead:   cfg.JWTKeyPublishAhead,
+		CheckInterval:  cfg.JWTKeyCheckInterval,
+	})
+	if err != nil {
+		log.Fatalf("Invalid signing key config: %v", err)
+	}
+	if err := keys.Init(context.Background()); err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
+	}
+
+	// SMS sender cho OTP xác minh số điện thoại
+	smsSender, err := clients.NewSMSSender(cfg.SMSProvider, cfg.SMSFilePath)
+	if err != nil {
+		log.Fatalf("Invalid SMS config: %v", err)
+	}
+	log.Printf("WARNING: SMS provider %q only writes messages locally, configure a real provider before production", cfg.SMSProvider)
+
+	svc := service.NewUserService(userRepo, refreshTokenRepo, blacklistRepo, mfaRepo, otpRepo, smsSender, auditLog, keys, cfg.JWTExpiryHours, cfg.TokenReuseNotify, cfg.MFAIssuer)
+	erasureRepo := repository.NewPostgresErasureRepository(dbpool)
+	sagaStore := sagastore.NewStore(dbpool)
+	sagas := saga.NewOrchestrator(sagaStore, saga.Config{},
+		service.RegistrationSaga(svc, consentClient),
+		service.ErasureSaga(erasureRepo, blacklistRepo, consentClient, auditLog),
+	)
+	registration := service.NewRegistrationService(svc, documentClient, consentClient, sagas)
+	erasure := service.NewErasureService(erasureRepo, sagas)
+	hdl := handler.NewUserHandler(svc, registration, erasure, keys)
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Saga orchestrator: bù trừ nền các saga thất bại hoặc bị bỏ dở (process chết giữa chừng),
+	// chạy lại bước lỗi của erasure saga
+	go sagas.Run(jobCtx)
+
+	// Rotation khóa ký access token và nạp key do instance khác tạo
+	go keys.Run(jobCtx)
+
+	// TrackIssued ghi mọi access token đã cấp: xóa định kỳ JTI và blacklist đã hết hạn
+	go runTokenCleanup(jobCtx, blacklistRepo, cfg.TokenCleanupInterval)
+
+	// Outbox dispatcher: gửi domain events tới các sink đã cấu hình
+	if cfg.OutboxWebhookURL != "" {
+		sinks := []outbox.Sink{outbox.NewWebhookSink(cfg.OutboxWebhookURL, cfg.OutboxWebhookSecret, 10*time.Second)}
+		dispatcher := outbox.NewDispatcher(outboxstore.NewStore(dbpool), sinks, outbox.DispatcherConfig{PollInterval: cfg.OutboxPollInterval})
+		go dispatcher.Run(jobCtx)
+		log.Printf("Outbox dispatcher started (webhook: %s)", cfg.OutboxWebhookURL)
+	} else {
+		log.Println("WARNING: OUTBOX_WEBHOOK_URL not set, domain events stay in outbox_events until a sink is configured")
+	}
 
 	// 4. Setup gRPC server
 	lis, err := net.Listen("tcp", ":"+cfg.ServerPort)
@@ -54,6 +136,8 @@ func main() {
 
 	grpcServer := grpc.NewServer()
 	pb.RegisterUserServiceServer(grpcServer, hdl)
+	auditpb.RegisterAuditServiceServer(grpcServer, audit.NewServer(auditStore))
+	sagapb.RegisterSagaServiceServer(grpcServer, saga.NewServer(sagaStore))
 
 	// Enable gRPC reflection for grpcurl testing
 	reflection.Register(grpcServer)
@@ -65,6 +149,7 @@ func main() {
 	go func() {
 		<-sigChan
 		log.Println("\nShutting down gracefully...")
+		stopJobs()
 		grpcServer.GracefulStop()
 	}()
 
@@ -73,3 +158,28 @@ func main() {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
+
+// runTokenCleanup chạy cho tới khi ctx bị hủy
+func runTokenCleanup(ctx context.Context, repo repository.TokenBlacklistRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		removed, err := repo.CleanupExpired(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("WARNING: [TOKENS] Failed to clean up expired tokens: %v", err)
+			}
+			continue
+		}
+		if removed > 0 {
+			log.Printf("INFO: [TOKENS] Removed %d expired blacklisted tokens", removed)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+)
+
+// ConsentClient là wrapper cho Consent Service (saga đăng ký ghi / thu hồi consent, erasure saga xóa consent)
+type ConsentClient struct {
+	conn   *grpc.ClientConn
+	client pb.ConsentServiceClient
+}
+
+func NewConsentClient(address string) (*ConsentClient, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(), // Wait until connection is ready
+		// Chuyển actor/request ID/IP của request gốc sang Consent Service cho audit log
+		grpc.WithUnaryInterceptor(audit.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to consent service: %w", err)
+	}
+
+	return &ConsentClient{
+		conn:   conn,
+		client: pb.NewConsentServiceClient(conn),
+	}, nil
+}
+
+func (c *ConsentClient) Close() error {
+	return c.conn.Close()
+}
+
+// RecordConsent records consents of a user
+func (c *ConsentClient) RecordConsent(ctx context.Context, req *pb.RecordConsentRequest) error {
+	if _, err := c.client.RecordConsent(ctx, req); err != nil {
+		return fmt.Errorf("failed to record consent: %w", err)
+	}
+	return nil
+}
+
+// RevokeConsent revokes a consent; consent không tồn tại (chưa ghi hoặc đã thu hồi) không phải lỗi
+func (c *ConsentClient) RevokeConsent(ctx context.Context, req *pb.RevokeConsentRequest) error {
+	_, err := c.client.RevokeConsent(ctx, req)
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to revoke consent: %w", err)
+	}
+	return nil
+}
+
+// EraseUserConsents xóa dữ liệu cá nhân khỏi consent của user (erasure saga), chạy lại được
+func (c *ConsentClient) EraseUserConsents(ctx context.Context, req *pb.EraseUserConsentsRequest) (*pb.EraseUserConsentsResponse, error) {
+	resp, err := c.client.EraseUserConsents(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase consents: %w", err)
+	}
+	return resp, nil
+}
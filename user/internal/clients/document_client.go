@@ -0,0 +1,55 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/thatlq1812/policy-system/shared/pkg/api/document"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+)
+
+// DocumentClient là wrapper cho Document Service (saga đăng ký lấy policy mới nhất)
+type DocumentClient struct {
+	conn   *grpc.ClientConn
+	client pb.DocumentServiceClient
+}
+
+func NewDocumentClient(address string) (*DocumentClient, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(), // Wait until connection is ready
+		// Chuyển actor/request ID/IP của request gốc sang Document Service cho audit log
+		grpc.WithUnaryInterceptor(audit.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to document service: %w", err)
+	}
+
+	return &DocumentClient{
+		conn:   conn,
+		client: pb.NewDocumentServiceClient(conn),
+	}, nil
+}
+
+func (c *DocumentClient) Close() error {
+	return c.conn.Close()
+}
+
+// GetLatestPolicy gets the latest policy of a platform (bản dịch theo locale nếu có)
+func (c *DocumentClient) GetLatestPolicy(ctx context.Context, platform, locale string) (*pb.PolicyDocument, error) {
+	resp, err := c.client.GetLatestPolicyByPlatform(ctx, &pb.GetLatestPolicyRequest{
+		Platform: platform,
+		Locale:   locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest policy: %w", err)
+	}
+	return resp.Document, nil
+}
@@ -0,0 +1,84 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// SMSSender gửi tin nhắn SMS (OTP xác minh số điện thoại).
+// Provider thật (Twilio, eSMS, ...) chỉ cần implement interface này và đăng ký trong NewSMSSender
+type SMSSender interface {
+	Send(ctx context.Context, phoneNumber, message string) error
+}
+
+// SMS providers hỗ trợ sẵn (chỉ dùng cho local dev và test)
+const (
+	SMSProviderLog  = "log"  // Ghi tin nhắn ra log của service
+	SMSProviderFile = "file" // Ghi tin nhắn vào file JSON lines để test đọc OTP
+)
+
+// NewSMSSender creates the SMS sender configured by SMS_PROVIDER
+func NewSMSSender(provider, filePath string) (SMSSender, error) {
+	switch provider {
+	case "", SMSProviderLog:
+		return &LogSMSSender{}, nil
+	case SMSProviderFile:
+		if filePath == "" {
+			return nil, fmt.Errorf("SMS_FILE_PATH is required for sms provider %q", provider)
+		}
+		return NewFileSMSSender(filePath), nil
+	default:
+		return nil, fmt.Errorf("unsupported sms provider %q", provider)
+	}
+}
+
+// LogSMSSender ghi tin nhắn ra log thay vì gửi SMS
+type LogSMSSender struct{}
+
+// Send logs the message
+func (s *LogSMSSender) Send(ctx context.Context, phoneNumber, message string) error {
+	log.Printf("[SMS] To %s: %s", phoneNumber, message)
+	return nil
+}
+
+// FileSMSSender ghi mỗi tin nhắn thành 1 dòng JSON vào file (outbox cho integration test)
+type FileSMSSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSMSSender creates a sender appending messages to path
+func NewFileSMSSender(path string) *FileSMSSender {
+	return &FileSMSSender{path: path}
+}
+
+// Send appends the message to the file
+func (s *FileSMSSender) Send(ctx context.Context, phoneNumber, message string) error {
+	line, err := json.Marshal(struct {
+		PhoneNumber string    `json:"phone_number"`
+		Message     string    `json:"message"`
+		SentAt      time.Time `json:"sent_at"`
+	}{phoneNumber, message, time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to encode sms: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open sms file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write sms: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,28 +13,58 @@ type Config struct {
 	ServerPort      string
 	DatabaseURL     string
 	DatabaseMaxConn int
-	JWTSecret       string
 	JWTExpiryHours  int
+	// Khóa ký access token: RS256 | EdDSA, rotation định kỳ, public keys qua GetSigningKeys (JWKS)
+	JWTSigningAlgorithm  string
+	JWTKeyRotationPeriod time.Duration
+	JWTKeyPublishAhead   time.Duration // Key mới có trong JWKS trước khi ký, >= thời gian cache JWKS của Gateway/service khác
+	JWTKeyCheckInterval  time.Duration
+	// Chu kỳ xóa JTI access token và blacklist đã hết hạn
+	TokenCleanupInterval time.Duration
+	// Saga đăng ký gọi Document Service (policy mới nhất) và Consent Service (ghi/thu hồi consent)
+	DocumentServiceURL string
+	ConsentServiceURL  string
+	// Outbox: gửi domain events tới webhook (để trống = không gửi, event vẫn nằm trong outbox_events)
+	OutboxWebhookURL    string
+	OutboxWebhookSecret string // Ký body bằng HMAC-SHA256 (header X-Signature)
+	OutboxPollInterval  time.Duration
+	// Refresh token đã rotation bị dùng lại: ghi event SessionCompromised để downstream báo cho user
+	TokenReuseNotify bool
+	// Tên hiển thị trong authenticator app (issuer của otpauth URI)
+	MFAIssuer string
+	// Gửi OTP xác minh số điện thoại: log | file (chỉ dùng cho local dev/test)
+	SMSProvider string
+	SMSFilePath string // SMS_PROVIDER=file: mỗi tin nhắn 1 dòng JSON
 }
 
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		ServerPort:      getEnv("GRPC_PORT", "50052"),
-		DatabaseURL:     getEnv("DATABASE_URL", ""),
-		DatabaseMaxConn: getEnvAsInt("DB_MAX_CONN", 10),
-		JWTSecret:       getEnv("JWT_SECRET", ""),
-		JWTExpiryHours:  getEnvAsInt("JWT_EXPIRY_HOURS", 24),
+		ServerPort:           getEnv("GRPC_PORT", "50052"),
+		DatabaseURL:          getEnv("DATABASE_URL", ""),
+		DatabaseMaxConn:      getEnvAsInt("DB_MAX_CONN", 10),
+		JWTExpiryHours:       getEnvAsInt("JWT_EXPIRY_HOURS", 24),
+		JWTSigningAlgorithm:  getEnv("JWT_SIGNING_ALGORITHM", "RS256"),
+		JWTKeyRotationPeriod: time.Duration(getEnvAsInt("JWT_KEY_ROTATION_HOURS", 720)) * time.Hour,
+		JWTKeyPublishAhead:   time.Duration(getEnvAsInt("JWT_KEY_PUBLISH_AHEAD_MINUTES", 60)) * time.Minute,
+		JWTKeyCheckInterval:  time.Duration(getEnvAsInt("JWT_KEY_CHECK_INTERVAL_SECONDS", 60)) * time.Second,
+		TokenCleanupInterval: time.Duration(getEnvAsInt("TOKEN_CLEANUP_INTERVAL_MINUTES", 60)) * time.Minute,
+		DocumentServiceURL:   getEnv("DOCUMENT_SERVICE_URL", "localhost:50051"),
+		ConsentServiceURL:    getEnv("CONSENT_SERVICE_URL", "localhost:50053"),
+		OutboxWebhookURL:     getEnv("OUTBOX_WEBHOOK_URL", ""),
+		OutboxWebhookSecret:  getEnv("OUTBOX_WEBHOOK_SECRET", ""),
+		OutboxPollInterval:   time.Duration(getEnvAsInt("OUTBOX_POLL_INTERVAL_SECONDS", 2)) * time.Second,
+		TokenReuseNotify:     getEnvAsBool("TOKEN_REUSE_NOTIFY", true),
+		MFAIssuer:            getEnv("MFA_ISSUER", "Policy System"),
+		SMSProvider:          getEnv("SMS_PROVIDER", "log"),
+		SMSFilePath:          getEnv("SMS_FILE_PATH", ""),
 	}
 
 	// Validate required fields
 	if cfg.DatabaseURL == "" {
 		return nil, fmt.Errorf("DATABASE_URL is required")
 	}
-	if cfg.JWTSecret == "" {
-		return nil, fmt.Errorf("JWT_SECRET is required")
-	}
 
 	return cfg, nil
 }
@@ -52,3 +83,11 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Giá trị thay thế dữ liệu cá nhân khi xóa user (right to erasure)
+const (
+	ErasedUserName     = "Erased user"
+	ErasedPasswordHash = "!" // Không phải bcrypt hash → không đăng nhập được
+	ErasedPhonePrefix  = "erased:"
+)
+
+// UserErasure là kết quả ẩn danh user trong User Service
+type UserErasure struct {
+	ErasedAt         time.Time
+	SessionsScrubbed int  // Số refresh token của user đã xóa IP/device info
+	FirstErasure     bool // false nếu user đã được ẩn danh ở lần chạy trước
+}
+
+// ErasureCertificate là bằng chứng đã xử lý yêu cầu xóa dữ liệu, không chứa dữ liệu cá nhân
+type ErasureCertificate struct {
+	ID               string    `db:"id"` // = ID của erasure saga
+	UserID           string    `db:"user_id"`
+	RequestedBy      string    `db:"requested_by"`
+	Reason           string    `db:"reason"`
+	UserErasedAt     time.Time `db:"user_erased_at"`
+	SessionsScrubbed int       `db:"sessions_scrubbed"`
+	ConsentsErased   int       `db:"consents_erased"`
+	ConsentChainHead string    `db:"consent_chain_head"`
+	IssuedAt         time.Time `db:"issued_at"`
+	CertificateHash  string    `db:"certificate_hash"` // SHA-256 của các field trên
+}
@@ -26,9 +26,31 @@ var (
 	// ErrTokenRevoked indicates the token has been revoked
 	ErrTokenRevoked = errors.New("token revoked")
 
+	// ErrTokenReused indicates a rotated refresh token was presented again; the whole family was revoked
+	ErrTokenReused = errors.New("refresh token reuse detected")
+
 	// ErrTokenInvalid indicates the token is malformed or invalid
 	ErrTokenInvalid = errors.New("token invalid")
 
 	// ErrInsufficientPermissions indicates the user lacks required permissions
 	ErrInsufficientPermissions = errors.New("insufficient permissions")
+
+	// ErrMFAInvalidCode indicates a wrong, reused or expired TOTP/recovery code
+	ErrMFAInvalidCode = errors.New("invalid two-factor code")
+
+	// ErrMFALocked indicates 2FA verification is locked after too many wrong codes
+	ErrMFALocked = errors.New("two-factor authentication temporarily locked")
+
+	// ErrMFAPrecondition indicates the 2FA operation is not allowed in the current state
+	// (e.g. enrolling while already enabled, Admin disabling 2FA)
+	ErrMFAPrecondition = errors.New("two-factor operation not allowed")
+
+	// ErrOTPInvalid indicates a wrong, expired or already used phone OTP
+	ErrOTPInvalid = errors.New("invalid verification code")
+
+	// ErrOTPRateLimited indicates too many OTP requests for a phone number
+	ErrOTPRateLimited = errors.New("too many verification code requests")
+
+	// ErrRegistrationRolledBack indicates a registration step failed and the saga rolled it back
+	ErrRegistrationRolledBack = errors.New("registration rolled back")
 )
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Mục đích của MFA challenge
+const (
+	MFAPurposeVerify = "verify" // User đã bật 2FA: nhập mã TOTP hoặc recovery code
+	MFAPurposeEnroll = "enroll" // Admin chưa có 2FA: phải enroll rồi xác nhận mã đầu tiên
+)
+
+// UserMFA là cấu hình TOTP của user
+type UserMFA struct {
+	UserID         string     `db:"user_id"`
+	TOTPSecret     string     `db:"totp_secret"` // Base32
+	EnabledAt      *time.Time `db:"enabled_at"`  // nil = đang enroll
+	LastUsedStep   int64      `db:"last_used_step"`
+	FailedAttempts int        `db:"failed_attempts"`
+	LockedUntil    *time.Time `db:"locked_until"`
+}
+
+// IsEnabled checks if TOTP has been confirmed
+func (m *UserMFA) IsEnabled() bool {
+	return m != nil && m.EnabledAt != nil
+}
+
+// IsLocked checks if 2FA verification is temporarily locked after too many wrong codes
+func (m *UserMFA) IsLocked() bool {
+	return m != nil && m.LockedUntil != nil && time.Now().Before(*m.LockedUntil)
+}
+
+// MFAChallenge là bước 2 của đăng nhập: password đã đúng, chờ mã 2FA
+type MFAChallenge struct {
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	Purpose   string    `db:"purpose"`
+	Attempts  int       `db:"attempts"`
+	ExpiresAt time.Time `db:"expires_at"`
+	Token     string    // Chỉ có khi vừa tạo, DB chỉ lưu hash
+}
+
+// CreateMFAChallengeParams contains parameters for creating an MFA challenge
+type CreateMFAChallengeParams struct {
+	UserID     string
+	TokenHash  string
+	Purpose    string
+	ExpiresAt  time.Time
+	DeviceInfo string
+	IPAddress  string
+}
+
+// TOTPEnrollment là secret mới cho authenticator app, chưa có hiệu lực tới khi xác nhận mã đầu tiên
+type TOTPEnrollment struct {
+	Secret     string // Base32, nhập tay nếu không quét được QR
+	OTPAuthURI string // otpauth://totp/... để tạo QR code
+}
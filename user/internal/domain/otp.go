@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// Mục đích của OTP gửi qua SMS
+const (
+	OTPPurposeVerifyPhone = "verify_phone" // Xác minh số điện thoại đã đăng ký
+	OTPPurposeChangePhone = "change_phone" // Xác minh số mới trước khi đổi trong UpdateUserProfile
+	OTPPurposeLogin       = "login"        // Đăng nhập không mật khẩu
+)
+
+// PhoneOTP là mã OTP đã gửi tới 1 số điện thoại (DB chỉ lưu hash)
+type PhoneOTP struct {
+	ID          string    `db:"id"`
+	UserID      string    `db:"user_id"`
+	PhoneNumber string    `db:"phone_number"`
+	Purpose     string    `db:"purpose"`
+	CodeHash    string    `db:"code_hash"`
+	Attempts    int       `db:"attempts"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// CreatePhoneOTPParams contains parameters for creating a phone OTP
+type CreatePhoneOTPParams struct {
+	UserID      string
+	PhoneNumber string
+	Purpose     string
+	CodeHash    string
+	ExpiresAt   time.Time
+}
+
+// OTPDispatch là kết quả gửi OTP trả cho client
+type OTPDispatch struct {
+	ExpiresAt   time.Time // Mã hết hạn
+	ResendAfter time.Time // Được yêu cầu mã mới từ thời điểm này
+}
@@ -15,8 +15,15 @@ type RefreshToken struct {
 	DeviceInfo    *string    `db:"device_info"`
 	IPAddress     *string    `db:"ip_address"`
 	LastUsedAt    *time.Time `db:"last_used_at"` // For token reuse detection
+	FamilyID      string     `db:"family_id"`    // Login session, shared by all rotated tokens
 }
 
+// Revoked reasons của refresh token dùng cho reuse detection
+const (
+	RevokeReasonRotation      = "token_rotation"       // Đã đổi sang token mới, dùng lại = token bị lộ
+	RevokeReasonReuseDetected = "token_reuse_detected" // Cả family bị revoke do phát hiện dùng lại
+)
+
 // CreateRefreshTokenParams contains parameters for creating a refresh token
 type CreateRefreshTokenParams struct {
 	UserID     string
@@ -24,6 +31,7 @@ type CreateRefreshTokenParams struct {
 	ExpiresAt  time.Time
 	DeviceInfo string
 	IPAddress  string
+	FamilyID   string // Rỗng = mở family mới (login), rotation truyền family của token cũ
 }
 
 // IsRevoked checks if the refresh token has been revoked
@@ -31,6 +39,11 @@ func (rt *RefreshToken) IsRevoked() bool {
 	return rt.RevokedAt != nil
 }
 
+// IsRotated checks if the refresh token was revoked because it was exchanged for a new one
+func (rt *RefreshToken) IsRotated() bool {
+	return rt.RevokedReason != nil && *rt.RevokedReason == RevokeReasonRotation
+}
+
 // IsExpired checks if the refresh token has expired
 func (rt *RefreshToken) IsExpired() bool {
 	return time.Now().After(rt.ExpiresAt)
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// Thuật toán ký access token
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+// SigningKey là khóa ký access token, nhận diện bằng kid trong header JWT
+type SigningKey struct {
+	KID           string
+	Algorithm     string // SigningAlgorithmRS256 hoặc SigningAlgorithmEdDSA
+	PrivateKeyPEM string // PKCS#8
+	PublicKeyPEM  string // PKIX
+	CreatedAt     time.Time
+	ActivatesAt   time.Time  // Bắt đầu ký từ thời điểm này
+	RetiredAt     *time.Time // Ngừng ký, nil = chưa có key mới
+	ExpiresAt     *time.Time // Gỡ khỏi JWKS, nil = còn dùng
+}
+
+// JWK là public key của SigningKey theo RFC 7517, để bên ngoài verify access token
+type JWK struct {
+	KID         string
+	Kty         string // "RSA" | "OKP"
+	Alg         string
+	Use         string // "sig"
+	Crv         string // OKP
+	X           string // OKP
+	N           string // RSA
+	E           string // RSA
+	ActivatesAt time.Time
+	ExpiresAt   *time.Time
+}
@@ -12,10 +12,18 @@ type User struct {
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
 	IsDeleted    bool      `db:"is_deleted"`
+	// PhoneVerifiedAt = nil: chưa xác minh sở hữu số điện thoại bằng OTP
+	PhoneVerifiedAt *time.Time `db:"phone_verified_at"`
+}
+
+// IsPhoneVerified checks if the user has proven ownership of the phone number
+func (u *User) IsPhoneVerified() bool {
+	return u.PhoneVerifiedAt != nil
 }
 
 // CreateUserParams holds parameters for creating a new user
 type CreateUserParams struct {
+	ID           string // Rỗng = tự sinh; saga đăng ký sinh trước để bù trừ được khi process chết giữa chừng
 	PhoneNumber  string
 	PasswordHash string
 	Name         string
@@ -27,6 +35,8 @@ type UpdateUserParams struct {
 	ID          string
 	Name        *string // Pointer to distinguish between no update and empty string
 	PhoneNumber *string // Pointer to distinguish between no update and empty string
+	// PhoneVerified = số mới đã xác minh bằng OTP (ghi phone_verified_at)
+	PhoneVerified bool
 	// Add other updatable fields here
 }
 
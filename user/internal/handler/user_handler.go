@@ -17,12 +17,15 @@ import (
 // UserHandler implements gRPC UserService interface
 type UserHandler struct {
 	pb.UnimplementedUserServiceServer
-	service service.UserService
+	service      service.UserService
+	registration service.RegistrationService
+	erasure      service.ErasureService
+	keys         *service.KeyManager
 }
 
 // NewUserHandler creates a new handler instance
-func NewUserHandler(service service.UserService) *UserHandler {
-	return &UserHandler{service: service}
+func NewUserHandler(service service.UserService, registration service.RegistrationService, erasure service.ErasureService, keys *service.KeyManager) *UserHandler {
+	return &UserHandler{service: service, registration: registration, erasure: erasure, keys: keys}
 }
 
 // Register creates a new user account with dual token authentication
@@ -56,6 +59,36 @@ func (h *UserHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 	}, nil
 }
 
+// RegisterWithConsent registers a user and records consent for the latest mandatory policy (saga)
+func (h *UserHandler) RegisterWithConsent(ctx context.Context, req *pb.RegisterWithConsentRequest) (*pb.RegisterWithConsentResponse, error) {
+	result, err := h.registration.RegisterWithConsent(ctx, service.RegisterWithConsentParams{
+		PhoneNumber:  req.PhoneNumber,
+		Password:     req.Password,
+		Name:         req.Name,
+		PlatformRole: req.PlatformRole,
+		Locale:       req.Locale,
+		IPAddress:    req.IpAddress,
+		UserAgent:    req.UserAgent,
+	})
+	if err != nil {
+		// Bước sau create_user lỗi: đăng ký đã (hoặc đang) được bù trừ
+		if errors.Is(err, domain.ErrRegistrationRolledBack) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.RegisterWithConsentResponse{
+		User:                  domainToProto(result.User),
+		AccessToken:           result.AccessToken,
+		RefreshToken:          result.RefreshToken,
+		AccessTokenExpiresAt:  result.AccessExpiresAt,
+		RefreshTokenExpiresAt: result.RefreshExpiresAt,
+		ConsentsRecorded:      int32(result.ConsentsRecorded),
+		SagaId:                result.SagaID,
+	}, nil
+}
+
 // Login handles user authentication
 func (h *UserHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	// Step 1: Validate request
@@ -63,8 +96,8 @@ func (h *UserHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 		return nil, status.Error(codes.InvalidArgument, "phone number and password are required")
 	}
 
-	// Step 2: Call service layer with dual token support
-	user, accessToken, refreshToken, accessExpiresAt, refreshExpiresAt, err := h.service.Login(
+	// Step 2: Call service layer (tokens, hoặc MFA challenge khi còn bước 2FA)
+	result, err := h.service.Login(
 		ctx,
 		req.PhoneNumber,
 		req.Password,
@@ -74,15 +107,130 @@ func (h *UserHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 	}
 
 	// Step 3: Return with dual tokens
-	return &pb.LoginResponse{
-		User:                  domainToProto(user),
-		AccessToken:           accessToken,
-		RefreshToken:          refreshToken,
-		AccessTokenExpiresAt:  accessExpiresAt,
-		RefreshTokenExpiresAt: refreshExpiresAt,
+	return loginResultToProto(result), nil
+}
+
+// VerifyMFA completes a login with a TOTP or recovery code
+func (h *UserHandler) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.LoginResponse, error) {
+	if req.MfaToken == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "mfa token and code are required")
+	}
+
+	result, err := h.service.VerifyMFA(ctx, req.MfaToken, req.Code)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+	return loginResultToProto(result), nil
+}
+
+// EnrollTOTP generates a new TOTP secret
+func (h *UserHandler) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	if req.UserId == "" && req.MfaToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id or mfa_token is required")
+	}
+
+	enrollment, err := h.service.EnrollTOTP(ctx, req.UserId, req.MfaToken)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.OTPAuthURI,
+	}, nil
+}
+
+// ConfirmTOTP enables 2FA and returns recovery codes
+func (h *UserHandler) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	if (req.UserId == "" && req.MfaToken == "") || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id or mfa_token, and code are required")
+	}
+
+	recoveryCodes, result, err := h.service.ConfirmTOTP(ctx, req.UserId, req.MfaToken, req.Code)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	resp := &pb.ConfirmTOTPResponse{RecoveryCodes: recoveryCodes}
+	if result != nil {
+		resp.Login = loginResultToProto(result)
+	}
+	return resp, nil
+}
+
+// DisableTOTP turns off 2FA
+func (h *UserHandler) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	if req.UserId == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and code are required")
+	}
+
+	if err := h.service.DisableTOTP(ctx, req.UserId, req.Code); err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+	return &pb.DisableTOTPResponse{Success: true}, nil
+}
+
+// SendPhoneOTP sends a verification code by SMS
+func (h *UserHandler) SendPhoneOTP(ctx context.Context, req *pb.SendPhoneOTPRequest) (*pb.SendPhoneOTPResponse, error) {
+	if req.Purpose == "" {
+		return nil, status.Error(codes.InvalidArgument, "purpose is required")
+	}
+
+	dispatch, err := h.service.SendPhoneOTP(ctx, req.Purpose, req.UserId, req.PhoneNumber)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.SendPhoneOTPResponse{
+		ExpiresAt:   dispatch.ExpiresAt.Unix(),
+		ResendAfter: dispatch.ResendAfter.Unix(),
 	}, nil
 }
 
+// VerifyPhone verifies the user's current phone number with an OTP
+func (h *UserHandler) VerifyPhone(ctx context.Context, req *pb.VerifyPhoneRequest) (*pb.VerifyPhoneResponse, error) {
+	if req.UserId == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and code are required")
+	}
+
+	user, err := h.service.VerifyPhone(ctx, req.UserId, req.Code)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+	return &pb.VerifyPhoneResponse{User: domainToProto(user)}, nil
+}
+
+// LoginWithOTP handles passwordless login with an SMS code
+func (h *UserHandler) LoginWithOTP(ctx context.Context, req *pb.LoginWithOTPRequest) (*pb.LoginResponse, error) {
+	if req.PhoneNumber == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "phone number and code are required")
+	}
+
+	result, err := h.service.LoginWithOTP(ctx, req.PhoneNumber, req.Code)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+	return loginResultToProto(result), nil
+}
+
+// loginResultToProto converts LoginResult to LoginResponse (tokens hoặc MFA challenge)
+func loginResultToProto(result *service.LoginResult) *pb.LoginResponse {
+	resp := &pb.LoginResponse{
+		User:                  domainToProto(result.User),
+		AccessToken:           result.AccessToken,
+		RefreshToken:          result.RefreshToken,
+		AccessTokenExpiresAt:  result.AccessExpiresAt,
+		RefreshTokenExpiresAt: result.RefreshExpiresAt,
+	}
+	if result.MFA != nil {
+		resp.MfaRequired = true
+		resp.MfaToken = result.MFA.Token
+		resp.MfaTokenExpiresAt = result.MFA.ExpiresAt.Unix()
+		resp.MfaEnrollmentRequired = result.MFA.Purpose == domain.MFAPurposeEnroll
+	}
+	return resp
+}
+
 // validateRegisterRequest validates registration request fields
 func (h *UserHandler) validateRegisterRequest(req *pb.RegisterRequest) error {
 	if req.PhoneNumber == "" {
@@ -100,12 +248,13 @@ func (h *UserHandler) validateRegisterRequest(req *pb.RegisterRequest) error {
 // domainToProto converts domain User to protobuf User
 func domainToProto(user *domain.User) *pb.User {
 	return &pb.User{
-		Id:           user.ID,
-		PhoneNumber:  user.PhoneNumber,
-		Name:         user.Name,
-		PlatformRole: user.PlatformRole,
-		CreatedAt:    user.CreatedAt.Unix(),
-		UpdatedAt:    user.UpdatedAt.Unix(),
+		Id:            user.ID,
+		PhoneNumber:   user.PhoneNumber,
+		Name:          user.Name,
+		PlatformRole:  user.PlatformRole,
+		CreatedAt:     user.CreatedAt.Unix(),
+		UpdatedAt:     user.UpdatedAt.Unix(),
+		PhoneVerified: user.IsPhoneVerified(),
 	}
 }
 
@@ -152,6 +301,26 @@ func mapErrorToGRPCStatus(err error) error {
 		return status.Error(codes.PermissionDenied, "insufficient permissions")
 	}
 
+	if errors.Is(err, domain.ErrMFAInvalidCode) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrMFALocked) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrOTPInvalid) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrOTPRateLimited) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrMFAPrecondition) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
 	// Default to internal error
 	return status.Error(codes.Internal, "internal server error")
 }
@@ -239,18 +408,9 @@ func (h *UserHandler) UpdateUserProfile(ctx context.Context, req *pb.UpdateUserP
 		phoneNumber = &req.PhoneNumber
 	}
 
-	user, err := h.service.UpdateUserProfile(ctx, req.UserId, name, phoneNumber)
+	user, err := h.service.UpdateUserProfile(ctx, req.UserId, name, phoneNumber, req.OtpCode)
 	if err != nil {
-		if strings.Contains(err.Error(), "validation") {
-			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
-		}
-		if strings.Contains(err.Error(), "already exists") {
-			return nil, status.Errorf(codes.AlreadyExists, "%s", err.Error())
-		}
-		if strings.Contains(err.Error(), "not found") {
-			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
-		}
-		return nil, status.Errorf(codes.Internal, "failed to update user profile: %v", err)
+		return nil, mapErrorToGRPCStatus(err)
 	}
 
 	return &pb.UpdateUserProfileResponse{
@@ -368,6 +528,58 @@ func (h *UserHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 	}, nil
 }
 
+// EraseUser anonymizes a user and erases personal data from their consents (admin only)
+func (h *UserHandler) EraseUser(ctx context.Context, req *pb.EraseUserRequest) (*pb.EraseUserResponse, error) {
+	if req.UserId == "" || req.RequestedBy == "" || req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID, requested_by and reason are required")
+	}
+
+	result, err := h.erasure.EraseUser(ctx, req.UserId, req.RequestedBy, req.Reason)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	resp := &pb.EraseUserResponse{
+		SagaId:      result.SagaID,
+		Status:      result.Status,
+		CurrentStep: result.CurrentStep,
+		LastError:   result.LastError,
+	}
+	if result.Certificate != nil {
+		resp.Certificate = erasureCertificateToProto(result.Certificate)
+	}
+	return resp, nil
+}
+
+// GetErasureCertificate returns the erasure certificate of an erased user (admin only)
+func (h *UserHandler) GetErasureCertificate(ctx context.Context, req *pb.GetErasureCertificateRequest) (*pb.GetErasureCertificateResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID is required")
+	}
+
+	cert, err := h.erasure.GetErasureCertificate(ctx, req.UserId)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+
+	return &pb.GetErasureCertificateResponse{Certificate: erasureCertificateToProto(cert)}, nil
+}
+
+func erasureCertificateToProto(c *domain.ErasureCertificate) *pb.ErasureCertificate {
+	return &pb.ErasureCertificate{
+		Id:               c.ID,
+		UserId:           c.UserID,
+		RequestedBy:      c.RequestedBy,
+		Reason:           c.Reason,
+		UserErasedAt:     c.UserErasedAt.Unix(),
+		SessionsScrubbed: int32(c.SessionsScrubbed),
+		ConsentsErased:   int32(c.ConsentsErased),
+		ConsentChainHead: c.ConsentChainHead,
+		IssuedAt:         c.IssuedAt.Unix(),
+		CertificateHash:  c.CertificateHash,
+	}
+}
+
 // UpdateUserRole changes user's platform role (admin only)
 func (h *UserHandler) UpdateUserRole(ctx context.Context, req *pb.UpdateUserRoleRequest) (*pb.UpdateUserRoleResponse, error) {
 	// TODO: Add admin role check
@@ -517,3 +729,28 @@ func (h *UserHandler) IsTokenBlacklisted(ctx context.Context, req *pb.IsTokenBla
 		IsBlacklisted: isBlacklisted,
 	}, nil
 }
+
+// GetSigningKeys returns the public keys that verify access tokens (JWKS)
+func (h *UserHandler) GetSigningKeys(ctx context.Context, req *pb.GetSigningKeysRequest) (*pb.GetSigningKeysResponse, error) {
+	jwks := h.keys.PublicKeys()
+
+	keys := make([]*pb.SigningKey, len(jwks))
+	for i, k := range jwks {
+		keys[i] = &pb.SigningKey{
+			Kid:         k.KID,
+			Kty:         k.Kty,
+			Alg:         k.Alg,
+			Use:         k.Use,
+			Crv:         k.Crv,
+			X:           k.X,
+			N:           k.N,
+			E:           k.E,
+			ActivatesAt: k.ActivatesAt.Unix(),
+		}
+		if k.ExpiresAt != nil {
+			keys[i].ExpiresAt = k.ExpiresAt.Unix()
+		}
+	}
+
+	return &pb.GetSigningKeysResponse{Keys: keys}, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+)
+
+// ErasureRepository thực hiện right to erasure trên dữ liệu của User Service
+type ErasureRepository interface {
+	// AnonymizeUser thay phone/name/password, xóa IP/device của refresh tokens và revoke chúng.
+	// Access token còn hạn được blacklist riêng ở bước erasure (TokenBlacklistRepository.RevokeAllUserTokens).
+	// Chạy lại được; user không tồn tại (kể cả đã xóa mềm) trả về nil.
+	AnonymizeUser(ctx context.Context, userID string) (*domain.UserErasure, error)
+
+	// UserExists kiểm tra user tồn tại, kể cả user đã xóa mềm
+	UserExists(ctx context.Context, userID string) (bool, error)
+
+	// SaveCertificate lưu certificate; user đã có certificate thì trả về certificate đã lưu
+	SaveCertificate(ctx context.Context, cert *domain.ErasureCertificate) (*domain.ErasureCertificate, error)
+
+	// GetCertificate trả về certificate của user (nil nếu chưa có)
+	GetCertificate(ctx context.Context, userID string) (*domain.ErasureCertificate, error)
+}
+
+type postgresErasureRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresErasureRepository creates a new repository instance
+func NewPostgresErasureRepository(db *pgxpool.Pool) ErasureRepository {
+	return &postgresErasureRepository{db: db}
+}
+
+func (r *postgresErasureRepository) AnonymizeUser(ctx context.Context, userID string) (*domain.UserErasure, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var erasedAt *time.Time
+	err = tx.QueryRow(ctx, `SELECT erased_at FROM users WHERE id::text = $1 FOR UPDATE`, userID).Scan(&erasedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	result := &domain.UserErasure{FirstErasure: erasedAt == nil}
+	if erasedAt != nil {
+		result.ErasedAt = *erasedAt
+	} else {
+		// Phone vẫn UNIQUE nên thay bằng giá trị suy ra từ user ID (vừa VARCHAR(20))
+		err = tx.QueryRow(ctx, `
+			UPDATE users
+			SET phone_number = $2 || LEFT(REPLACE(id::text, '-', ''), 13),
+			    name = $3, password_hash = $4, is_deleted = TRUE, erased_at = NOW(), phone_verified_at = NULL
+			WHERE id::text = $1
+			RETURNING erased_at
+		`, userID, domain.ErasedPhonePrefix, domain.ErasedUserName, domain.ErasedPasswordHash).Scan(&result.ErasedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to anonymize user: %w", err)
+		}
+
+		err = enqueueEvent(ctx, tx, outbox.EventUserErased, userID, outbox.UserErased{
+			UserID:   userID,
+			ErasedAt: result.ErasedAt.Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Session đã hết hạn/đã revoke cũng chứa IP và device của user
+	tag, err := tx.Exec(ctx, `
+		UPDATE refresh_tokens
+		SET device_info = NULL, ip_address = NULL,
+		    revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP),
+		    revoked_reason = COALESCE(revoked_reason, 'user_erased')
+		WHERE user_id::text = $1
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrub refresh tokens: %w", err)
+	}
+	result.SessionsScrubbed = int(tag.RowsAffected())
+
+	// MFA challenge cũng lưu IP/device; 2FA của tài khoản đã xóa không còn dùng
+	for _, query := range []string{
+		`DELETE FROM mfa_challenges WHERE user_id::text = $1`,
+		`DELETE FROM mfa_recovery_codes WHERE user_id::text = $1`,
+		`DELETE FROM user_mfa WHERE user_id::text = $1`,
+	} {
+		if _, err := tx.Exec(ctx, query, userID); err != nil {
+			return nil, fmt.Errorf("failed to delete two-factor data: %w", err)
+		}
+	}
+
+	// OTP lưu số điện thoại (kể cả số mới chưa đổi xong)
+	if _, err := tx.Exec(ctx, `DELETE FROM phone_otps WHERE user_id::text = $1`, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete phone otps: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *postgresErasureRepository) UserExists(ctx context.Context, userID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id::text = $1)`, userID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user: %w", err)
+	}
+	return exists, nil
+}
+
+// certificateColumns - thứ tự khớp scanCertificate
+const certificateColumns = `id, user_id, requested_by, reason, user_erased_at, sessions_scrubbed,
+        consents_erased, consent_chain_head, issued_at, certificate_hash`
+
+func scanCertificate(row pgx.Row) (*domain.ErasureCertificate, error) {
+	var c domain.ErasureCertificate
+	err := row.Scan(
+		&c.ID, &c.UserID, &c.RequestedBy, &c.Reason, &c.UserErasedAt, &c.SessionsScrubbed,
+		&c.ConsentsErased, &c.ConsentChainHead, &c.IssuedAt, &c.CertificateHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *postgresErasureRepository) SaveCertificate(ctx context.Context, cert *domain.ErasureCertificate) (*domain.ErasureCertificate, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO erasure_certificates (`+certificateColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (user_id) DO NOTHING
+	`, cert.ID, cert.UserID, cert.RequestedBy, cert.Reason, cert.UserErasedAt, cert.SessionsScrubbed,
+		cert.ConsentsErased, cert.ConsentChainHead, cert.IssuedAt, cert.CertificateHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save erasure certificate: %w", err)
+	}
+
+	// Lần chạy trước đã lưu thì giữ certificate cũ (hash đã có thể được gửi cho chủ thể dữ liệu)
+	saved, err := r.GetCertificate(ctx, cert.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		return nil, fmt.Errorf("erasure certificate of user %s was not saved", cert.UserID)
+	}
+	return saved, nil
+}
+
+func (r *postgresErasureRepository) GetCertificate(ctx context.Context, userID string) (*domain.ErasureCertificate, error) {
+	cert, err := scanCertificate(r.db.QueryRow(ctx,
+		`SELECT `+certificateColumns+` FROM erasure_certificates WHERE user_id::text = $1`, userID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get erasure certificate: %w", err)
+	}
+	return cert, nil
+}
@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+)
+
+// MFARepository defines operations for TOTP two-factor authentication
+type MFARepository interface {
+	// Get retrieves the TOTP config of a user (nil nếu chưa enroll)
+	Get(ctx context.Context, userID string) (*domain.UserMFA, error)
+
+	// StartEnrollment lưu secret mới khi user chưa bật 2FA (enroll lại thì ghi đè secret cũ).
+	// Trả về false nếu 2FA đã bật
+	StartEnrollment(ctx context.Context, userID, secret string) (bool, error)
+
+	// Enable bật 2FA sau khi user nhập đúng mã đầu tiên và thay toàn bộ recovery codes.
+	// Trả về false nếu 2FA đã bật hoặc time step đã dùng
+	Enable(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) (bool, error)
+
+	// RecordSuccess ghi time step vừa dùng và reset số lần sai.
+	// Trả về false nếu step không mới hơn step đã dùng (mã bị dùng lại)
+	RecordSuccess(ctx context.Context, userID string, step int64) (bool, error)
+
+	// RecordFailure tăng số lần sai, khóa 2FA lockDuration khi đạt maxAttempts.
+	// Trả về thời điểm hết khóa (nil nếu chưa khóa)
+	RecordFailure(ctx context.Context, userID string, maxAttempts int, lockDuration time.Duration) (*time.Time, error)
+
+	// UseRecoveryCode đánh dấu recovery code đã dùng và reset số lần sai.
+	// Trả về false nếu code không tồn tại hoặc đã dùng
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+
+	// CountUnusedRecoveryCodes returns the number of recovery codes still usable
+	CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error)
+
+	// Disable xóa cấu hình TOTP và recovery codes của user
+	Disable(ctx context.Context, userID string) error
+
+	// CreateChallenge saves a new MFA challenge (xóa các challenge đã dùng/hết hạn của user)
+	CreateChallenge(ctx context.Context, params domain.CreateMFAChallengeParams) (*domain.MFAChallenge, error)
+
+	// GetChallenge retrieves an unused, unexpired challenge by token hash (nil nếu không có)
+	GetChallenge(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error)
+
+	// IncrementChallengeAttempts tăng số lần nhập mã của challenge, trả về số lần sau khi tăng
+	IncrementChallengeAttempts(ctx context.Context, challengeID string) (int, error)
+
+	// CompleteChallenge đánh dấu challenge đã dùng.
+	// Trả về false nếu request khác đã dùng challenge trước (challenge chỉ dùng 1 lần)
+	CompleteChallenge(ctx context.Context, challengeID string) (bool, error)
+}
+
+// postgresMFARepository implements MFARepository
+type postgresMFARepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresMFARepository creates a new MFA repository
+func NewPostgresMFARepository(db *pgxpool.Pool) MFARepository {
+	return &postgresMFARepository{db: db}
+}
+
+// Get retrieves the TOTP config of a user
+func (r *postgresMFARepository) Get(ctx context.Context, userID string) (*domain.UserMFA, error) {
+	var mfa domain.UserMFA
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, totp_secret, enabled_at, last_used_step, failed_attempts, locked_until
+		FROM user_mfa
+		WHERE user_id = $1
+	`, userID).Scan(
+		&mfa.UserID,
+		&mfa.TOTPSecret,
+		&mfa.EnabledAt,
+		&mfa.LastUsedStep,
+		&mfa.FailedAttempts,
+		&mfa.LockedUntil,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user mfa: %w", err)
+	}
+	return &mfa, nil
+}
+
+// StartEnrollment saves a pending TOTP secret
+func (r *postgresMFARepository) StartEnrollment(ctx context.Context, userID, secret string) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+		INSERT INTO user_mfa (user_id, totp_secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET totp_secret = EXCLUDED.totp_secret,
+		    last_used_step = 0,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE user_mfa.enabled_at IS NULL
+	`, userID, secret)
+	if err != nil {
+		return false, fmt.Errorf("failed to save totp secret: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// Enable confirms the pending TOTP secret and replaces recovery codes
+func (r *postgresMFARepository) Enable(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE user_mfa
+		SET enabled_at = CURRENT_TIMESTAMP, last_used_step = $2, failed_attempts = 0, locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND enabled_at IS NULL AND last_used_step < $2
+	`, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to enable totp: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return false, fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	for _, hash := range recoveryCodeHashes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO mfa_recovery_codes (user_id, code_hash)
+			VALUES ($1, $2)
+		`, userID, hash)
+		if err != nil {
+			return false, fmt.Errorf("failed to save recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// RecordSuccess stores the used time step and resets failed attempts
+func (r *postgresMFARepository) RecordSuccess(ctx context.Context, userID string, step int64) (bool, error) {
+	// Điều kiện last_used_step < $2: 2 request cùng mã thì chỉ 1 request thắng
+	result, err := r.db.Exec(ctx, `
+		UPDATE user_mfa
+		SET last_used_step = $2, failed_attempts = 0, locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND last_used_step < $2
+	`, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to record totp success: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// RecordFailure counts a wrong code and locks 2FA when maxAttempts is reached
+func (r *postgresMFARepository) RecordFailure(ctx context.Context, userID string, maxAttempts int, lockDuration time.Duration) (*time.Time, error) {
+	var lockedUntil *time.Time
+	err := r.db.QueryRow(ctx, `
+		UPDATE user_mfa
+		SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+		    locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN CURRENT_TIMESTAMP + make_interval(secs => $3) ELSE locked_until END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+		RETURNING locked_until
+	`, userID, maxAttempts, lockDuration.Seconds()).Scan(&lockedUntil)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record totp failure: %w", err)
+	}
+	return lockedUntil, nil
+}
+
+// UseRecoveryCode consumes a recovery code
+func (r *postgresMFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE mfa_recovery_codes
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE user_mfa
+		SET failed_attempts = 0, locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to reset totp failures: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// CountUnusedRecoveryCodes counts recovery codes that have not been used
+func (r *postgresMFARepository) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM mfa_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return count, nil
+}
+
+// Disable removes TOTP config and recovery codes
+func (r *postgresMFARepository) Disable(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete user mfa: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CreateChallenge saves a new MFA challenge
+func (r *postgresMFARepository) CreateChallenge(ctx context.Context, params domain.CreateMFAChallengeParams) (*domain.MFAChallenge, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM mfa_challenges
+		WHERE user_id = $1 AND (used_at IS NOT NULL OR expires_at < CURRENT_TIMESTAMP)
+	`, params.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete old mfa challenges: %w", err)
+	}
+
+	var challenge domain.MFAChallenge
+	err = tx.QueryRow(ctx, `
+		INSERT INTO mfa_challenges (token_hash, user_id, purpose, expires_at, device_info, ip_address)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, purpose, attempts, expires_at
+	`, params.TokenHash, params.UserID, params.Purpose, params.ExpiresAt, params.DeviceInfo, params.IPAddress).Scan(
+		&challenge.ID,
+		&challenge.UserID,
+		&challenge.Purpose,
+		&challenge.Attempts,
+		&challenge.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mfa challenge: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &challenge, nil
+}
+
+// GetChallenge retrieves an active challenge by token hash
+func (r *postgresMFARepository) GetChallenge(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	var challenge domain.MFAChallenge
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, purpose, attempts, expires_at
+		FROM mfa_challenges
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+	`, tokenHash).Scan(
+		&challenge.ID,
+		&challenge.UserID,
+		&challenge.Purpose,
+		&challenge.Attempts,
+		&challenge.ExpiresAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+	return &challenge, nil
+}
+
+// IncrementChallengeAttempts counts a code submitted against a challenge
+func (r *postgresMFARepository) IncrementChallengeAttempts(ctx context.Context, challengeID string) (int, error) {
+	var attempts int
+	err := r.db.QueryRow(ctx, `
+		UPDATE mfa_challenges
+		SET attempts = attempts + 1
+		WHERE id = $1
+		RETURNING attempts
+	`, challengeID).Scan(&attempts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update mfa challenge: %w", err)
+	}
+	return attempts, nil
+}
+
+// CompleteChallenge marks a challenge as used
+func (r *postgresMFARepository) CompleteChallenge(ctx context.Context, challengeID string) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE mfa_challenges
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND used_at IS NULL
+	`, challengeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to complete mfa challenge: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+)
+
+// OTPRepository defines operations for phone number OTPs
+type OTPRepository interface {
+	// Create lưu OTP mới; các OTP chưa dùng cùng số điện thoại và mục đích bị vô hiệu (chỉ mã mới nhất hợp lệ)
+	Create(ctx context.Context, params domain.CreatePhoneOTPParams) (*domain.PhoneOTP, error)
+
+	// RecordSuppressedSend ghi lượt yêu cầu OTP không gửi SMS (login với số chưa đăng ký): không có user/mã,
+	// đã vô hiệu ngay, chỉ để RecentSends tính giới hạn gửi như số đã đăng ký
+	RecordSuppressedSend(ctx context.Context, phoneNumber, purpose string, expiresAt time.Time) (*domain.PhoneOTP, error)
+
+	// GetActive retrieves the latest unused, unexpired OTP of a phone number (nil nếu không có)
+	GetActive(ctx context.Context, phoneNumber, purpose string) (*domain.PhoneOTP, error)
+
+	// IncrementAttempts tăng số lần nhập sai, trả về số lần sau khi tăng
+	IncrementAttempts(ctx context.Context, otpID string) (int, error)
+
+	// Consume đánh dấu OTP đã dùng.
+	// Trả về false nếu request khác đã dùng OTP trước (OTP chỉ dùng 1 lần)
+	Consume(ctx context.Context, otpID string) (bool, error)
+
+	// RecentSends đếm số OTP đã gửi tới số điện thoại từ thời điểm since và thời điểm gửi gần nhất (nil nếu chưa gửi)
+	RecentSends(ctx context.Context, phoneNumber string, since time.Time) (int, *time.Time, error)
+}
+
+// postgresOTPRepository implements OTPRepository
+type postgresOTPRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresOTPRepository creates a new OTP repository
+func NewPostgresOTPRepository(db *pgxpool.Pool) OTPRepository {
+	return &postgresOTPRepository{db: db}
+}
+
+// Create saves a new OTP and invalidates older ones
+func (r *postgresOTPRepository) Create(ctx context.Context, params domain.CreatePhoneOTPParams) (*domain.PhoneOTP, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE phone_otps
+		SET consumed_at = CURRENT_TIMESTAMP
+		WHERE phone_number = $1 AND purpose = $2 AND consumed_at IS NULL
+	`, params.PhoneNumber, params.Purpose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invalidate previous otps: %w", err)
+	}
+
+	otp := domain.PhoneOTP{
+		UserID:      params.UserID,
+		PhoneNumber: params.PhoneNumber,
+		Purpose:     params.Purpose,
+		CodeHash:    params.CodeHash,
+		ExpiresAt:   params.ExpiresAt,
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO phone_otps (user_id, phone_number, purpose, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, params.UserID, params.PhoneNumber, params.Purpose, params.CodeHash, params.ExpiresAt).Scan(&otp.ID, &otp.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otp: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &otp, nil
+}
+
+// RecordSuppressedSend records an OTP request that did not send an SMS
+func (r *postgresOTPRepository) RecordSuppressedSend(ctx context.Context, phoneNumber, purpose string, expiresAt time.Time) (*domain.PhoneOTP, error) {
+	otp := domain.PhoneOTP{
+		PhoneNumber: phoneNumber,
+		Purpose:     purpose,
+		ExpiresAt:   expiresAt,
+	}
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO phone_otps (user_id, phone_number, purpose, code_hash, expires_at, consumed_at)
+		VALUES (NULL, $1, $2, '', $3, CURRENT_TIMESTAMP)
+		RETURNING id, created_at
+	`, phoneNumber, purpose, expiresAt).Scan(&otp.ID, &otp.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record otp request: %w", err)
+	}
+	return &otp, nil
+}
+
+// GetActive retrieves the usable OTP of a phone number
+func (r *postgresOTPRepository) GetActive(ctx context.Context, phoneNumber, purpose string) (*domain.PhoneOTP, error) {
+	var otp domain.PhoneOTP
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, phone_number, purpose, code_hash, attempts, expires_at, created_at
+		FROM phone_otps
+		WHERE phone_number = $1 AND purpose = $2 AND consumed_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, phoneNumber, purpose).Scan(
+		&otp.ID,
+		&otp.UserID,
+		&otp.PhoneNumber,
+		&otp.Purpose,
+		&otp.CodeHash,
+		&otp.Attempts,
+		&otp.ExpiresAt,
+		&otp.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get otp: %w", err)
+	}
+	return &otp, nil
+}
+
+// IncrementAttempts counts a wrong code
+func (r *postgresOTPRepository) IncrementAttempts(ctx context.Context, otpID string) (int, error) {
+	var attempts int
+	err := r.db.QueryRow(ctx, `
+		UPDATE phone_otps
+		SET attempts = attempts + 1
+		WHERE id = $1
+		RETURNING attempts
+	`, otpID).Scan(&attempts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment otp attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// Consume marks the OTP as used
+func (r *postgresOTPRepository) Consume(ctx context.Context, otpID string) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE phone_otps
+		SET consumed_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND consumed_at IS NULL
+	`, otpID)
+	if err != nil {
+		return false, fmt.Errorf("failed to consume otp: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// RecentSends returns how many OTPs were sent to a phone number since a point in time
+func (r *postgresOTPRepository) RecentSends(ctx context.Context, phoneNumber string, since time.Time) (int, *time.Time, error) {
+	var count int
+	var last *time.Time
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*), MAX(created_at)
+		FROM phone_otps
+		WHERE phone_number = $1 AND created_at >= $2
+	`, phoneNumber, since).Scan(&count, &last)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to count sent otps: %w", err)
+	}
+	return count, last, nil
+}
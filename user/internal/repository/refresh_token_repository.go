@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
 	"github.com/thatlq1812/policy-system/user/internal/domain"
 )
 
@@ -16,6 +19,14 @@ type RefreshTokenRepository interface {
 	// GetByTokenHash retrieves a refresh token by its hash
 	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
 
+	// Rotate revoke token cũ (reason token_rotation) và tạo token mới cùng family trong 1 transaction.
+	// Trả về nil nếu token cũ đã bị revoke/hết hạn (request khác đã rotation trước → dùng lại token)
+	Rotate(ctx context.Context, oldTokenHash string, params domain.CreateRefreshTokenParams) (*domain.RefreshToken, error)
+
+	// RevokeFamily revokes every active token of a family (token reuse detected).
+	// notify = ghi event SessionCompromised vào outbox cùng transaction để báo cho user
+	RevokeFamily(ctx context.Context, userID, familyID, reason string, notify bool) (int64, error)
+
 	// Revoke marks a refresh token as revoked
 	Revoke(ctx context.Context, tokenHash, reason string) error
 
@@ -53,9 +64,9 @@ func NewPostgresRefreshTokenRepository(db *pgxpool.Pool) RefreshTokenRepository
 // Create saves a new refresh token
 func (r *postgresRefreshTokenRepository) Create(ctx context.Context, params domain.CreateRefreshTokenParams) (*domain.RefreshToken, error) {
 	query := `
-		INSERT INTO refresh_tokens (user_id, token_hash, expires_at, device_info, ip_address)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, user_id, token_hash, expires_at, created_at, revoked_at, revoked_reason, device_info, ip_address
+		INSERT INTO refresh_tokens (user_id, token_hash, expires_at, device_info, ip_address, family_id)
+		VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, '')::uuid, gen_random_uuid()))
+		RETURNING id, user_id, token_hash, expires_at, created_at, revoked_at, revoked_reason, device_info, ip_address, family_id
 	`
 	var token domain.RefreshToken
 	err := r.db.QueryRow(ctx, query,
@@ -64,6 +75,7 @@ func (r *postgresRefreshTokenRepository) Create(ctx context.Context, params doma
 		params.ExpiresAt,
 		params.DeviceInfo,
 		params.IPAddress,
+		params.FamilyID,
 	).Scan(
 		&token.ID,
 		&token.UserID,
@@ -74,6 +86,7 @@ func (r *postgresRefreshTokenRepository) Create(ctx context.Context, params doma
 		&token.RevokedReason,
 		&token.DeviceInfo,
 		&token.IPAddress,
+		&token.FamilyID,
 	)
 
 	if err != nil {
@@ -82,10 +95,97 @@ func (r *postgresRefreshTokenRepository) Create(ctx context.Context, params doma
 	return &token, nil
 }
 
+// Rotate exchanges an active refresh token for a new one in the same family
+func (r *postgresRefreshTokenRepository) Rotate(ctx context.Context, oldTokenHash string, params domain.CreateRefreshTokenParams) (*domain.RefreshToken, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Điều kiện revoked_at IS NULL: 2 request rotation cùng token thì chỉ 1 request thắng
+	var familyID string
+	err = tx.QueryRow(ctx, `
+		UPDATE refresh_tokens
+		SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $2
+		WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING family_id::text
+	`, oldTokenHash, domain.RevokeReasonRotation).Scan(&familyID)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke old refresh token: %w", err)
+	}
+
+	var token domain.RefreshToken
+	err = tx.QueryRow(ctx, `
+		INSERT INTO refresh_tokens (user_id, token_hash, expires_at, device_info, ip_address, family_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, token_hash, expires_at, created_at, revoked_at, revoked_reason, device_info, ip_address, family_id
+	`, params.UserID, params.TokenHash, params.ExpiresAt, params.DeviceInfo, params.IPAddress, familyID).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.RevokedAt,
+		&token.RevokedReason,
+		&token.DeviceInfo,
+		&token.IPAddress,
+		&token.FamilyID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &token, nil
+}
+
+// RevokeFamily revokes all active tokens of a refresh token family
+func (r *postgresRefreshTokenRepository) RevokeFamily(ctx context.Context, userID, familyID, reason string, notify bool) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE refresh_tokens
+		SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $2
+		WHERE family_id = $1::uuid AND revoked_at IS NULL
+	`, familyID, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke token family: %w", err)
+	}
+	revoked := result.RowsAffected()
+
+	if notify {
+		err = enqueueEvent(ctx, tx, outbox.EventSessionCompromised, userID, outbox.SessionCompromised{
+			UserID:          userID,
+			FamilyID:        familyID,
+			Reason:          reason,
+			RevokedSessions: int(revoked),
+			DetectedAt:      time.Now().Unix(),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to enqueue session compromised event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return revoked, nil
+}
+
 // GetByTokenHash retrieves a refresh token by its hash
 func (r *postgresRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token_hash, expires_at, created_at, revoked_at, revoked_reason, device_info, ip_address, last_used_at
+		SELECT id, user_id, token_hash, expires_at, created_at, revoked_at, revoked_reason, device_info, ip_address, last_used_at, family_id
 		FROM refresh_tokens
 		WHERE token_hash = $1
 	`
@@ -102,6 +202,7 @@ func (r *postgresRefreshTokenRepository) GetByTokenHash(ctx context.Context, tok
 		&token.DeviceInfo,
 		&token.IPAddress,
 		&token.LastUsedAt,
+		&token.FamilyID,
 	)
 
 	if err != nil {
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+)
+
+// SigningKeyRepository lưu khóa ký access token
+type SigningKeyRepository interface {
+	// ListValid trả về các key chưa hết hạn, activates_at tăng dần
+	ListValid(ctx context.Context) ([]*domain.SigningKey, error)
+
+	// Rotate lưu key mới nếu chưa có key nào activate sau dueBefore (instance khác chưa rotation).
+	// Key đang ký ngừng ký tại key.ActivatesAt và hết hạn tại previousExpiresAt.
+	Rotate(ctx context.Context, key *domain.SigningKey, dueBefore, previousExpiresAt time.Time) (bool, error)
+
+	// DeleteExpired xóa key đã hết hạn (kể cả private key)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type postgresSigningKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresSigningKeyRepository creates a new repository instance
+func NewPostgresSigningKeyRepository(db *pgxpool.Pool) SigningKeyRepository {
+	return &postgresSigningKeyRepository{db: db}
+}
+
+func (r *postgresSigningKeyRepository) ListValid(ctx context.Context) ([]*domain.SigningKey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT kid, algorithm, private_key, public_key, created_at, activates_at, retired_at, expires_at
+		FROM signing_keys
+		WHERE expires_at IS NULL OR expires_at > NOW()
+		ORDER BY activates_at, kid
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.SigningKey, error) {
+		var k domain.SigningKey
+		err := row.Scan(&k.KID, &k.Algorithm, &k.PrivateKeyPEM, &k.PublicKeyPEM,
+			&k.CreatedAt, &k.ActivatesAt, &k.RetiredAt, &k.ExpiresAt)
+		return &k, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan signing keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *postgresSigningKeyRepository) Rotate(ctx context.Context, key *domain.SigningKey, dueBefore, previousExpiresAt time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Nhiều instance cùng tới hạn rotation: chỉ 1 instance tạo key
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('signing_keys'))`); err != nil {
+		return false, fmt.Errorf("failed to lock signing keys: %w", err)
+	}
+
+	var latest *time.Time
+	if err := tx.QueryRow(ctx, `SELECT MAX(activates_at) FROM signing_keys`).Scan(&latest); err != nil {
+		return false, fmt.Errorf("failed to get latest signing key: %w", err)
+	}
+	if latest != nil && latest.After(dueBefore) {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE signing_keys
+		SET retired_at = $1, expires_at = $2
+		WHERE retired_at IS NULL
+	`, key.ActivatesAt, previousExpiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to retire signing keys: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO signing_keys (kid, algorithm, private_key, public_key, activates_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, key.KID, key.Algorithm, key.PrivateKeyPEM, key.PublicKeyPEM, key.ActivatesAt).Scan(&key.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+func (r *postgresSigningKeyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM signing_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired signing keys: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
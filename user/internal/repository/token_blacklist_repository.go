@@ -16,7 +16,10 @@ type TokenBlacklistRepository interface {
 	// IsBlacklisted checks if a token is blacklisted
 	IsBlacklisted(ctx context.Context, jti string) (bool, error)
 
-	// CleanupExpired removes expired tokens from blacklist
+	// TrackIssued ghi lại JTI của access token vừa cấp để RevokeAllUserTokens blacklist được
+	TrackIssued(ctx context.Context, jti, userID, familyID string, expiresAt time.Time) error
+
+	// CleanupExpired removes expired tokens from blacklist (and expired issued JTIs)
 	CleanupExpired(ctx context.Context) (int64, error)
 
 	// RevokeAllUserTokens adds all active tokens for a user to blacklist
@@ -64,8 +67,28 @@ func (r *postgresTokenBlacklistRepository) IsBlacklisted(ctx context.Context, jt
 	return exists, nil
 }
 
+// TrackIssued records an issued access token JTI
+func (r *postgresTokenBlacklistRepository) TrackIssued(ctx context.Context, jti, userID, familyID string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO issued_access_tokens (jti, user_id, family_id, expires_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4)
+		ON CONFLICT (jti) DO NOTHING
+	`
+	_, err := r.db.Exec(ctx, query, jti, userID, familyID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to track issued access token: %w", err)
+	}
+	return nil
+}
+
 // CleanupExpired removes expired tokens from blacklist
 func (r *postgresTokenBlacklistRepository) CleanupExpired(ctx context.Context) (int64, error) {
+	// JTI đã hết hạn không cần blacklist nữa
+	_, err := r.db.Exec(ctx, `DELETE FROM issued_access_tokens WHERE expires_at <= CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup expired issued tokens: %w", err)
+	}
+
 	query := `
 		DELETE FROM token_blacklist 
 		WHERE expires_at <= CURRENT_TIMESTAMP
@@ -77,20 +100,19 @@ func (r *postgresTokenBlacklistRepository) CleanupExpired(ctx context.Context) (
 	return result.RowsAffected(), nil
 }
 
-// RevokeAllUserTokens is a placeholder for future implementation
-// This would require tracking active JTIs per user, which is complex
-// For now, we rely on individual token revocation on logout
+// RevokeAllUserTokens blacklists every access token issued to a user that has not expired yet
 func (r *postgresTokenBlacklistRepository) RevokeAllUserTokens(ctx context.Context, userID string, reason string) (int64, error) {
-	// NOTE: This is not fully implemented because access tokens are stateless
-	// We don't track which access tokens are currently active for a user
-	//
-	// Options:
-	// 1. Track all issued access tokens (adds DB write on every login/refresh)
-	// 2. When password changes, blacklist a "user revoke timestamp" and check in middleware
-	// 3. Accept that password change only revokes refresh tokens (current approach)
-
-	// For now, this is a no-op
-	// In practice, password change revokes all refresh tokens,
-	// preventing generation of new access tokens
-	return 0, nil
+	// Access token là stateless nên dựa vào JTI đã ghi bằng TrackIssued
+	query := `
+		INSERT INTO token_blacklist (jti, user_id, expires_at, reason)
+		SELECT jti, user_id::text, expires_at, $2
+		FROM issued_access_tokens
+		WHERE user_id = $1::uuid AND expires_at > CURRENT_TIMESTAMP
+		ON CONFLICT (jti) DO NOTHING
+	`
+	result, err := r.db.Exec(ctx, query, userID, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to blacklist user tokens: %w", err)
+	}
+	return result.RowsAffected(), nil
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/thatlq1812/policy-system/shared/pkg/outbox"
+	outboxstore "github.com/thatlq1812/policy-system/shared/pkg/outbox/pgstore"
 	"github.com/thatlq1812/policy-system/user/internal/domain"
 )
 
@@ -18,6 +20,8 @@ type UserRepository interface {
 	GetByID(ctx context.Context, userID string) (*domain.User, error)
 	Update(ctx context.Context, params domain.UpdateUserParams) (*domain.User, error)
 	UpdatePassword(ctx context.Context, userID, newPasswordHash string) error
+	// MarkPhoneVerified ghi phone_verified_at nếu user vẫn dùng số phoneNumber (false nếu đã đổi số)
+	MarkPhoneVerified(ctx context.Context, userID, phoneNumber string) (bool, error)
 
 	// Admin operations
 	ListUsers(ctx context.Context, params domain.ListUsersParams) ([]*domain.User, int, error)
@@ -40,16 +44,26 @@ func NewPostgresUserRepository(db *pgxpool.Pool) UserRepository {
 }
 
 // Create inserts a new user into database
+// UserRegistered event được ghi vào outbox trong cùng transaction
 func (r *postgresUserRepository) Create(ctx context.Context, params domain.CreateUserParams) (*domain.User, error) {
-	id := uuid.New().String()
+	id := params.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
 	query := `
         INSERT INTO users (id, phone_number, password_hash, name, platform_role)
         VALUES ($1, $2, $3, $4, $5)
-        RETURNING id, phone_number, password_hash, name, platform_role, created_at, updated_at, is_deleted`
+        RETURNING id, phone_number, password_hash, name, platform_role, created_at, updated_at, is_deleted, phone_verified_at`
 
 	var user domain.User
-	err := r.db.QueryRow(ctx, query,
+	err = tx.QueryRow(ctx, query,
 		id,
 		params.PhoneNumber,
 		params.PasswordHash,
@@ -64,12 +78,27 @@ func (r *postgresUserRepository) Create(ctx context.Context, params domain.Creat
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.IsDeleted,
+		&user.PhoneVerifiedAt,
 	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	err = enqueueEvent(ctx, tx, outbox.EventUserRegistered, user.ID, outbox.UserRegistered{
+		UserID:       user.ID,
+		Name:         user.Name,
+		PlatformRole: user.PlatformRole,
+		CreatedAt:    user.CreatedAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return &user, nil
 }
 
@@ -77,7 +106,7 @@ func (r *postgresUserRepository) Create(ctx context.Context, params domain.Creat
 func (r *postgresUserRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
 	query := `
         SELECT id, phone_number, password_hash, name, platform_role, 
-               created_at, updated_at, is_deleted
+               created_at, updated_at, is_deleted, phone_verified_at
         FROM users
         WHERE phone_number = $1 AND is_deleted = FALSE`
 
@@ -91,6 +120,7 @@ func (r *postgresUserRepository) GetByPhoneNumber(ctx context.Context, phoneNumb
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.IsDeleted,
+		&user.PhoneVerifiedAt,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -107,7 +137,7 @@ func (r *postgresUserRepository) GetByPhoneNumber(ctx context.Context, phoneNumb
 // GetByID retrieves a user by ID
 func (r *postgresUserRepository) GetByID(ctx context.Context, userID string) (*domain.User, error) {
 	query := `
-		SELECT id, phone_number, password_hash, name, platform_role, created_at, updated_at
+		SELECT id, phone_number, password_hash, name, platform_role, created_at, updated_at, phone_verified_at
 		FROM users
 		WHERE id = $1 AND is_deleted = FALSE
 	`
@@ -121,6 +151,7 @@ func (r *postgresUserRepository) GetByID(ctx context.Context, userID string) (*d
 		&user.PlatformRole,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PhoneVerifiedAt,
 	)
 
 	if err != nil {
@@ -150,9 +181,16 @@ func (r *postgresUserRepository) Update(ctx context.Context, params domain.Updat
 		query += fmt.Sprintf(", phone_number = $%d", argPos)
 		args = append(args, *params.PhoneNumber)
 		argPos++
+
+		// Số mới chưa xác minh thì user phải xác minh lại
+		if params.PhoneVerified {
+			query += ", phone_verified_at = CURRENT_TIMESTAMP"
+		} else {
+			query += ", phone_verified_at = NULL"
+		}
 	}
 
-	query += fmt.Sprintf(" WHERE id = $%d AND is_deleted = FALSE RETURNING id, phone_number, password_hash, name, platform_role, created_at, updated_at, is_deleted", argPos)
+	query += fmt.Sprintf(" WHERE id = $%d AND is_deleted = FALSE RETURNING id, phone_number, password_hash, name, platform_role, created_at, updated_at, is_deleted, phone_verified_at", argPos)
 	args = append(args, params.ID)
 
 	var user domain.User
@@ -165,6 +203,7 @@ func (r *postgresUserRepository) Update(ctx context.Context, params domain.Updat
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.IsDeleted,
+		&user.PhoneVerifiedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -195,6 +234,20 @@ func (r *postgresUserRepository) UpdatePassword(ctx context.Context, userID, pas
 	return nil
 }
 
+// MarkPhoneVerified marks the user's current phone number as verified
+func (r *postgresUserRepository) MarkPhoneVerified(ctx context.Context, userID, phoneNumber string) (bool, error) {
+	// Điều kiện phone_number: OTP gửi tới số cũ không xác minh được số mới nếu user vừa đổi số
+	result, err := r.db.Exec(ctx, `
+		UPDATE users
+		SET phone_verified_at = COALESCE(phone_verified_at, CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND phone_number = $2 AND is_deleted = FALSE
+	`, userID, phoneNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark phone verified: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
 // ListUsers returns paginated list of users with optional filtering
 func (r *postgresUserRepository) ListUsers(ctx context.Context, params domain.ListUsersParams) ([]*domain.User, int, error) {
 	// Build WHERE clause
@@ -224,7 +277,7 @@ func (r *postgresUserRepository) ListUsers(ctx context.Context, params domain.Li
 	offset := (params.Page - 1) * params.PageSize
 	dataQuery := fmt.Sprintf(`
 		SELECT id, phone_number, password_hash, name, platform_role, 
-		       created_at, updated_at, is_deleted
+		       created_at, updated_at, is_deleted, phone_verified_at
 		FROM users %s
 		ORDER BY created_at DESC
 		LIMIT $%d OFFSET $%d
@@ -243,6 +296,7 @@ func (r *postgresUserRepository) ListUsers(ctx context.Context, params domain.Li
 		err := rows.Scan(
 			&user.ID, &user.PhoneNumber, &user.PasswordHash, &user.Name,
 			&user.PlatformRole, &user.CreatedAt, &user.UpdatedAt, &user.IsDeleted,
+			&user.PhoneVerifiedAt,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
@@ -264,7 +318,7 @@ func (r *postgresUserRepository) SearchUsers(ctx context.Context, query string,
 
 	sqlQuery := `
 		SELECT id, phone_number, password_hash, name, platform_role, 
-		       created_at, updated_at, is_deleted
+		       created_at, updated_at, is_deleted, phone_verified_at
 		FROM users
 		WHERE is_deleted = FALSE 
 		  AND (phone_number ILIKE $1 OR name ILIKE $1)
@@ -285,6 +339,7 @@ func (r *postgresUserRepository) SearchUsers(ctx context.Context, query string,
 		err := rows.Scan(
 			&user.ID, &user.PhoneNumber, &user.PasswordHash, &user.Name,
 			&user.PlatformRole, &user.CreatedAt, &user.UpdatedAt, &user.IsDeleted,
+			&user.PhoneVerifiedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -297,15 +352,21 @@ func (r *postgresUserRepository) SearchUsers(ctx context.Context, query string,
 
 // SoftDelete marks user as deleted (is_deleted = TRUE)
 func (r *postgresUserRepository) SoftDelete(ctx context.Context, userID, reason string) error {
-	// Note: 'reason' parameter is for audit logging but not stored in users table
-	// You may want to add a deletion_reason column or log it elsewhere
+	// Note: 'reason' is not stored in users table, the service records it in audit_log (user.delete)
+	// and it is sent with the UserDeleted event
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE users 
 		SET is_deleted = TRUE, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1 AND is_deleted = FALSE
 	`
 
-	result, err := r.db.Exec(ctx, query, userID)
+	result, err := tx.Exec(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -314,16 +375,32 @@ func (r *postgresUserRepository) SoftDelete(ctx context.Context, userID, reason
 		return fmt.Errorf("user not found or already deleted")
 	}
 
+	err = enqueueEvent(ctx, tx, outbox.EventUserDeleted, userID, outbox.UserDeleted{UserID: userID, Reason: reason})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
 // HardDelete permanently removes user from database
 // WARNING: This should only be used for rollback scenarios, not normal deletion
 // Normal deletions should use SoftDelete to preserve audit trail
+// UserRegistered đã được gửi nên vẫn ghi UserDeleted (hard = true) để downstream xóa theo
 func (r *postgresUserRepository) HardDelete(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `DELETE FROM users WHERE id = $1`
 
-	result, err := r.db.Exec(ctx, query, userID)
+	result, err := tx.Exec(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("failed to hard delete user: %w", err)
 	}
@@ -332,6 +409,15 @@ func (r *postgresUserRepository) HardDelete(ctx context.Context, userID string)
 		return fmt.Errorf("user not found")
 	}
 
+	err = enqueueEvent(ctx, tx, outbox.EventUserDeleted, userID, outbox.UserDeleted{UserID: userID, Hard: true})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -342,13 +428,14 @@ func (r *postgresUserRepository) UpdateRole(ctx context.Context, userID, platfor
 		SET platform_role = $1, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $2 AND is_deleted = FALSE
 		RETURNING id, phone_number, password_hash, name, platform_role, 
-		          created_at, updated_at, is_deleted
+		          created_at, updated_at, is_deleted, phone_verified_at
 	`
 
 	var user domain.User
 	err := r.db.QueryRow(ctx, query, platformRole, userID).Scan(
 		&user.ID, &user.PhoneNumber, &user.PasswordHash, &user.Name,
 		&user.PlatformRole, &user.CreatedAt, &user.UpdatedAt, &user.IsDeleted,
+		&user.PhoneVerifiedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -404,3 +491,12 @@ func (r *postgresUserRepository) GetUserStats(ctx context.Context) (map[string]i
 
 	return stats, nil
 }
+
+// enqueueEvent ghi domain event của user vào outbox trong transaction tx
+func enqueueEvent(ctx context.Context, tx pgx.Tx, eventType, userID string, payload any) error {
+	event, err := outbox.NewEvent("user", eventType, outbox.AggregateUser, userID, payload)
+	if err != nil {
+		return err
+	}
+	return outboxstore.Enqueue(ctx, tx, event)
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	consentpb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+	"github.com/thatlq1812/policy-system/shared/pkg/saga"
+	"github.com/thatlq1812/policy-system/user/internal/clients"
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+	"github.com/thatlq1812/policy-system/user/internal/repository"
+)
+
+// ErasureSagaType là loại saga xóa dữ liệu cá nhân của user
+const ErasureSagaType = "erasure"
+
+// Các bước của erasure saga, theo thứ tự
+const (
+	stepAnonymizeUser    = "anonymize_user"
+	stepEraseConsents    = "erase_consents"
+	stepIssueCertificate = "issue_certificate"
+)
+
+const erasureRPCTimeout = 10 * time.Second
+
+// Audit actions của right to erasure
+const (
+	auditActionErase            = "user.erase"
+	auditActionErasureCertified = "user.erasure_certificate"
+)
+
+// erasureNamespace sinh saga ID cố định theo user → 1 user chỉ có 1 erasure saga
+var erasureNamespace = uuid.MustParse("5b0a4c1e-2f7d-4c38-9d0e-8a6f3e1b7c42")
+
+// ErasureService xử lý yêu cầu xóa dữ liệu cá nhân (GDPR Art. 17, Nghị định 13/2023/NĐ-CP)
+type ErasureService interface {
+	// EraseUser chạy saga anonymize_user → erase_consents → issue_certificate.
+	// Bước lỗi thì saga được chạy lại nền từ bước đó; gọi lại trả về trạng thái saga hiện tại.
+	EraseUser(ctx context.Context, userID, requestedBy, reason string) (*ErasureStatus, error)
+
+	// GetErasureCertificate trả về certificate của user đã xóa xong
+	GetErasureCertificate(ctx context.Context, userID string) (*domain.ErasureCertificate, error)
+}
+
+// ErasureStatus là trạng thái yêu cầu xóa của 1 user
+type ErasureStatus struct {
+	SagaID      string
+	Status      string // saga.StatusRunning hoặc saga.StatusCompleted
+	CurrentStep string // Bước đang chờ chạy lại, rỗng khi đã xong
+	LastError   string
+	Certificate *domain.ErasureCertificate // Chỉ có khi Status = completed
+}
+
+// erasureState là Data của erasure saga: kết quả từng bước để lập certificate, không chứa dữ liệu cá nhân
+type erasureState struct {
+	UserID           string `json:"user_id"`
+	RequestedBy      string `json:"requested_by"`
+	Reason           string `json:"reason"`
+	UserErasedAt     int64  `json:"user_erased_at,omitempty"`
+	SessionsScrubbed int    `json:"sessions_scrubbed,omitempty"`
+	ConsentsErased   int    `json:"consents_erased,omitempty"`
+	ConsentChainHead string `json:"consent_chain_head,omitempty"`
+}
+
+// ErasureSaga định nghĩa các bước của erasure saga. Dữ liệu đã xóa không khôi phục được nên saga
+// chạy tiếp (Forward) thay vì bù trừ; mọi bước idempotent.
+func ErasureSaga(repo repository.ErasureRepository, blacklist repository.TokenBlacklistRepository, consents *clients.ConsentClient, auditLog *audit.Logger) *saga.Definition {
+	return &saga.Definition{
+		Type:    ErasureSagaType,
+		Forward: true,
+		Steps: []saga.Step{
+			{
+				Name: stepAnonymizeUser,
+				Execute: erasureStep(func(ctx context.Context, st *erasureState) error {
+					result, err := repo.AnonymizeUser(ctx, st.UserID)
+					if err != nil {
+						return err
+					}
+					if result == nil {
+						return fmt.Errorf("%w: user %s", domain.ErrNotFound, st.UserID)
+					}
+					st.UserErasedAt = result.ErasedAt.Unix()
+					st.SessionsScrubbed = result.SessionsScrubbed
+
+					// Refresh token đã revoke nhưng access token còn hạn vẫn dùng được tới khi hết hạn.
+					// Chạy cả khi retry: JTI đã blacklist được bỏ qua
+					if _, err := blacklist.RevokeAllUserTokens(ctx, st.UserID, "user_erased"); err != nil {
+						return err
+					}
+
+					if result.FirstErasure {
+						// Không ghi Before: audit log không được giữ thêm bản sao dữ liệu vừa xóa
+						auditLog.Record(ctx, audit.Event{
+							Action:     auditActionErase,
+							TargetType: "user",
+							TargetID:   st.UserID,
+							After:      map[string]any{"erased_at": st.UserErasedAt, "sessions_scrubbed": st.SessionsScrubbed},
+							Reason:     st.Reason,
+							ActorID:    st.RequestedBy,
+						})
+					}
+					return nil
+				}),
+			},
+			{
+				Name: stepEraseConsents,
+				Execute: erasureStep(func(ctx context.Context, st *erasureState) error {
+					ctx, cancel := context.WithTimeout(ctx, erasureRPCTimeout)
+					defer cancel()
+
+					resp, err := consents.EraseUserConsents(ctx, &consentpb.EraseUserConsentsRequest{
+						UserId:   st.UserID,
+						ErasedBy: st.RequestedBy,
+						Reason:   st.Reason,
+					})
+					if err != nil {
+						return err
+					}
+					st.ConsentsErased = int(resp.ErasedConsents)
+					st.ConsentChainHead = resp.ChainHeadHash
+					return nil
+				}),
+			},
+			{
+				Name: stepIssueCertificate,
+				Execute: erasureStep(func(ctx context.Context, st *erasureState) error {
+					cert := &domain.ErasureCertificate{
+						ID:               erasureSagaID(st.UserID),
+						UserID:           st.UserID,
+						RequestedBy:      st.RequestedBy,
+						Reason:           st.Reason,
+						UserErasedAt:     time.Unix(st.UserErasedAt, 0),
+						SessionsScrubbed: st.SessionsScrubbed,
+						ConsentsErased:   st.ConsentsErased,
+						ConsentChainHead: st.ConsentChainHead,
+						IssuedAt:         time.Now().Truncate(time.Second),
+					}
+					cert.CertificateHash = ErasureCertificateHash(cert)
+
+					saved, err := repo.SaveCertificate(ctx, cert)
+					if err != nil {
+						return err
+					}
+					if saved.CertificateHash != cert.CertificateHash {
+						// Đã lưu ở lần chạy trước (process chết trước khi saga được lưu)
+						return nil
+					}
+
+					auditLog.Record(ctx, audit.Event{
+						Action:     auditActionErasureCertified,
+						TargetType: "user",
+						TargetID:   st.UserID,
+						After: map[string]any{
+							"certificate_id":     saved.ID,
+							"certificate_hash":   saved.CertificateHash,
+							"consents_erased":    saved.ConsentsErased,
+							"consent_chain_head": saved.ConsentChainHead,
+						},
+						Reason:  st.Reason,
+						ActorID: st.RequestedBy,
+					})
+					return nil
+				}),
+			},
+		},
+	}
+}
+
+// erasureStep giải mã Data của saga, chạy fn và mã hóa lại state
+func erasureStep(fn func(ctx context.Context, st *erasureState) error) func(context.Context, json.RawMessage) (json.RawMessage, error) {
+	return func(ctx context.Context, data json.RawMessage) (json.RawMessage, error) {
+		var st erasureState
+		if err := json.Unmarshal(data, &st); err != nil {
+			return nil, fmt.Errorf("failed to decode erasure saga: %w", err)
+		}
+		if err := fn(ctx, &st); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&st)
+	}
+}
+
+// erasureSagaID là ID cố định của erasure saga (và certificate) của user
+func erasureSagaID(userID string) string {
+	return uuid.NewSHA1(erasureNamespace, []byte(userID)).String()
+}
+
+// ErasureCertificateHash tính SHA-256 trên nội dung certificate (mọi field trừ hash).
+// Chủ thể dữ liệu/cơ quan quản lý đối chiếu hash với certificate đã nhận.
+func ErasureCertificateHash(c *domain.ErasureCertificate) string {
+	content, _ := json.Marshal(struct {
+		ID               string `json:"id"`
+		UserID           string `json:"user_id"`
+		RequestedBy      string `json:"requested_by"`
+		Reason           string `json:"reason"`
+		UserErasedAt     int64  `json:"user_erased_at"`
+		SessionsScrubbed int    `json:"sessions_scrubbed"`
+		ConsentsErased   int    `json:"consents_erased"`
+		ConsentChainHead string `json:"consent_chain_head"`
+		IssuedAt         int64  `json:"issued_at"`
+	}{
+		ID:               c.ID,
+		UserID:           c.UserID,
+		RequestedBy:      c.RequestedBy,
+		Reason:           c.Reason,
+		UserErasedAt:     c.UserErasedAt.Unix(),
+		SessionsScrubbed: c.SessionsScrubbed,
+		ConsentsErased:   c.ConsentsErased,
+		ConsentChainHead: c.ConsentChainHead,
+		IssuedAt:         c.IssuedAt.Unix(),
+	})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// erasureService implements ErasureService
+type erasureService struct {
+	repo  repository.ErasureRepository
+	sagas *saga.Orchestrator
+}
+
+// NewErasureService creates a new ErasureService;
+// sagas phải được tạo với ErasureSaga
+func NewErasureService(repo repository.ErasureRepository, sagas *saga.Orchestrator) ErasureService {
+	return &erasureService{repo: repo, sagas: sagas}
+}
+
+func (s *erasureService) EraseUser(ctx context.Context, userID, requestedBy, reason string) (*ErasureStatus, error) {
+	if userID == "" || requestedBy == "" {
+		return nil, fmt.Errorf("%w: user_id and requested_by are required", domain.ErrInvalidInput)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("%w: reason is required when erasing a user", domain.ErrInvalidInput)
+	}
+
+	// Yêu cầu lặp lại: trả về trạng thái của saga đã có thay vì chạy lại
+	sagaID := erasureSagaID(userID)
+	existing, err := s.sagas.Get(ctx, sagaID)
+	if err == nil {
+		return s.status(ctx, existing)
+	}
+	if !errors.Is(err, saga.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get erasure saga: %w", err)
+	}
+
+	exists, err := s.repo.UserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: user %s", domain.ErrNotFound, userID)
+	}
+
+	exec, err := s.sagas.Start(ctx, ErasureSagaType, sagaID, &erasureState{
+		UserID:      userID,
+		RequestedBy: requestedBy,
+		Reason:      reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start erasure saga: %w", err)
+	}
+
+	if err := exec.Continue(ctx); err != nil {
+		// Saga vẫn running, Orchestrator chạy lại bước lỗi → Admin theo dõi qua saga ID
+		log.Printf("WARNING: [ERASURE] Erasure of user %s pending (saga %s): %v", userID, sagaID, err)
+	} else {
+		log.Printf("[ERASURE] User %s erased (saga %s)", userID, sagaID)
+	}
+
+	current, err := s.sagas.Get(ctx, sagaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get erasure saga: %w", err)
+	}
+	return s.status(ctx, current)
+}
+
+func (s *erasureService) status(ctx context.Context, sg *saga.Saga) (*ErasureStatus, error) {
+	result := &ErasureStatus{
+		SagaID:      sg.ID,
+		Status:      sg.Status,
+		CurrentStep: sg.CurrentStep,
+		LastError:   sg.LastError,
+	}
+	if sg.Status != saga.StatusCompleted {
+		return result, nil
+	}
+
+	var st erasureState
+	if err := json.Unmarshal(sg.Data, &st); err != nil {
+		return nil, fmt.Errorf("failed to decode erasure saga: %w", err)
+	}
+	cert, err := s.repo.GetCertificate(ctx, st.UserID)
+	if err != nil {
+		return nil, err
+	}
+	result.Certificate = cert
+	return result, nil
+}
+
+func (s *erasureService) GetErasureCertificate(ctx context.Context, userID string) (*domain.ErasureCertificate, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user_id is required", domain.ErrInvalidInput)
+	}
+
+	cert, err := s.repo.GetCertificate(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cert == nil {
+		return nil, fmt.Errorf("%w: no erasure certificate for user %s", domain.ErrNotFound, userID)
+	}
+	return cert, nil
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+)
+
+// Tham số 2FA
+const (
+	mfaChallengeTTL         = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5 // Số lần nhập mã tối đa của 1 challenge, sau đó phải đăng nhập lại
+	mfaMaxFailedAttempts    = 5 // Sai mã liên tiếp (mọi challenge) thì khóa 2FA
+	mfaLockDuration         = 15 * time.Minute
+	mfaRecoveryCodeCount    = 10
+	mfaRecoveryCodeBytes    = 5 // 8 ký tự Base32, hiển thị dạng xxxx-xxxx
+)
+
+// Audit actions của 2FA
+const (
+	auditActionMFAEnable       = "mfa.enable"
+	auditActionMFADisable      = "mfa.disable"
+	auditActionMFARecoveryCode = "mfa.recovery_code_used"
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// startMFAChallenge tạo challenge thay cho tokens khi password đúng nhưng còn bước 2FA
+func (s *userService) startMFAChallenge(ctx context.Context, user *domain.User, purpose string) (*LoginResult, error) {
+	b, err := generateRandomBytes(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mfa token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	challenge, err := s.mfaRepo.CreateChallenge(ctx, domain.CreateMFAChallengeParams{
+		UserID:     user.ID,
+		TokenHash:  s.hashToken(token),
+		Purpose:    purpose,
+		ExpiresAt:  time.Now().Add(mfaChallengeTTL),
+		DeviceInfo: extractDeviceInfo(ctx),
+		IPAddress:  extractIPAddress(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	challenge.Token = token
+
+	return &LoginResult{User: user, MFA: challenge}, nil
+}
+
+// useMFAChallenge lấy challenge theo token và tính 1 lần nhập mã.
+// Quá mfaChallengeMaxAttempts thì challenge bị hủy, user phải đăng nhập lại.
+func (s *userService) useMFAChallenge(ctx context.Context, mfaToken, purpose string) (*domain.MFAChallenge, error) {
+	challenge, err := s.mfaRepo.GetChallenge(ctx, s.hashToken(mfaToken))
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || challenge.Purpose != purpose {
+		return nil, fmt.Errorf("%w: mfa token is invalid or expired", domain.ErrTokenInvalid)
+	}
+
+	attempts, err := s.mfaRepo.IncrementChallengeAttempts(ctx, challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > mfaChallengeMaxAttempts {
+		if _, err := s.mfaRepo.CompleteChallenge(ctx, challenge.ID); err != nil {
+			log.Printf("WARNING: Failed to close mfa challenge %s: %v", challenge.ID, err)
+		}
+		return nil, fmt.Errorf("%w: too many attempts, log in again", domain.ErrTokenInvalid)
+	}
+	return challenge, nil
+}
+
+// completeMFAChallenge đóng challenge và cấp tokens (challenge chỉ dùng 1 lần)
+func (s *userService) completeMFAChallenge(ctx context.Context, challenge *domain.MFAChallenge) (*LoginResult, error) {
+	completed, err := s.mfaRepo.CompleteChallenge(ctx, challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		return nil, fmt.Errorf("%w: mfa token has already been used", domain.ErrTokenInvalid)
+	}
+
+	user, err := s.repo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return s.issueTokens(ctx, user)
+}
+
+// VerifyMFA completes a login with a TOTP or recovery code
+func (s *userService) VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResult, error) {
+	if mfaToken == "" || code == "" {
+		return nil, fmt.Errorf("%w: mfa token and code are required", domain.ErrInvalidInput)
+	}
+
+	challenge, err := s.useMFAChallenge(ctx, mfaToken, domain.MFAPurposeVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifySecondFactor(ctx, challenge.UserID, code); err != nil {
+		return nil, err
+	}
+
+	return s.completeMFAChallenge(ctx, challenge)
+}
+
+// EnrollTOTP generates a new TOTP secret for the logged-in user (userID)
+// hoặc Admin đang bị buộc enroll khi đăng nhập (mfaToken)
+func (s *userService) EnrollTOTP(ctx context.Context, userID, mfaToken string) (*domain.TOTPEnrollment, error) {
+	if mfaToken != "" {
+		challenge, err := s.useMFAChallenge(ctx, mfaToken, domain.MFAPurposeEnroll)
+		if err != nil {
+			return nil, err
+		}
+		userID = challenge.UserID
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user ID or mfa token is required", domain.ErrInvalidInput)
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ghi đè secret của lần enroll chưa xác nhận trước đó
+	started, err := s.mfaRepo.StartEnrollment(ctx, userID, secret)
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, fmt.Errorf("%w: two-factor authentication is already enabled", domain.ErrMFAPrecondition)
+	}
+
+	return &domain.TOTPEnrollment{
+		Secret:     secret,
+		OTPAuthURI: totpURI(s.mfaIssuer, user.PhoneNumber, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables 2FA with the first code from the authenticator app and returns recovery codes.
+// Enroll khi đăng nhập (mfaToken) thì trả thêm tokens để hoàn tất đăng nhập.
+func (s *userService) ConfirmTOTP(ctx context.Context, userID, mfaToken, code string) ([]string, *LoginResult, error) {
+	if code == "" {
+		return nil, nil, fmt.Errorf("%w: code is required", domain.ErrInvalidInput)
+	}
+
+	var challenge *domain.MFAChallenge
+	if mfaToken != "" {
+		var err error
+		challenge, err = s.useMFAChallenge(ctx, mfaToken, domain.MFAPurposeEnroll)
+		if err != nil {
+			return nil, nil, err
+		}
+		userID = challenge.UserID
+	}
+	if userID == "" {
+		return nil, nil, fmt.Errorf("%w: user ID or mfa token is required", domain.ErrInvalidInput)
+	}
+
+	mfa, err := s.mfaRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mfa == nil {
+		return nil, nil, fmt.Errorf("%w: start enrollment first", domain.ErrMFAPrecondition)
+	}
+	if mfa.IsEnabled() {
+		return nil, nil, fmt.Errorf("%w: two-factor authentication is already enabled", domain.ErrMFAPrecondition)
+	}
+	if mfa.IsLocked() {
+		return nil, nil, mfaLockedError(*mfa.LockedUntil)
+	}
+
+	step, ok := validateTOTP(mfa.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, nil, s.recordMFAFailure(ctx, userID)
+	}
+
+	recoveryCodes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enabled, err := s.mfaRepo.Enable(ctx, userID, step, hashes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !enabled {
+		// Request khác đã xác nhận trước hoặc mã đã dùng
+		return nil, nil, domain.ErrMFAInvalidCode
+	}
+
+	// Không ghi secret/recovery codes vào audit log
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionMFAEnable,
+		TargetType: "user",
+		TargetID:   userID,
+		After:      map[string]any{"method": "totp", "recovery_codes": len(recoveryCodes)},
+		ActorID:    userID,
+	})
+
+	if challenge == nil {
+		return recoveryCodes, nil, nil
+	}
+
+	result, err := s.completeMFAChallenge(ctx, challenge)
+	if err != nil {
+		return nil, nil, err
+	}
+	return recoveryCodes, result, nil
+}
+
+// DisableTOTP turns off 2FA after checking a current TOTP or recovery code
+func (s *userService) DisableTOTP(ctx context.Context, userID, code string) error {
+	if userID == "" || code == "" {
+		return fmt.Errorf("%w: user ID and code are required", domain.ErrInvalidInput)
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return domain.ErrNotFound
+	}
+	if user.PlatformRole == "Admin" {
+		return fmt.Errorf("%w: two-factor authentication is required for Admin accounts", domain.ErrMFAPrecondition)
+	}
+
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.mfaRepo.Disable(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionMFADisable,
+		TargetType: "user",
+		TargetID:   userID,
+		Before:     map[string]any{"method": "totp"},
+		ActorID:    userID,
+	})
+	return nil
+}
+
+// verifySecondFactor kiểm tra mã TOTP hoặc recovery code của user đã bật 2FA
+func (s *userService) verifySecondFactor(ctx context.Context, userID, code string) error {
+	mfa, err := s.mfaRepo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !mfa.IsEnabled() {
+		return fmt.Errorf("%w: two-factor authentication is not enabled", domain.ErrMFAPrecondition)
+	}
+	if mfa.IsLocked() {
+		return mfaLockedError(*mfa.LockedUntil)
+	}
+
+	if step, ok := validateTOTP(mfa.TOTPSecret, code, time.Now()); ok {
+		fresh, err := s.mfaRepo.RecordSuccess(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if fresh {
+			return nil
+		}
+		// Mã đúng nhưng đã dùng → có thể bị nhìn trộm/chặn, tính là sai
+		return s.recordMFAFailure(ctx, userID)
+	}
+
+	used, err := s.mfaRepo.UseRecoveryCode(ctx, userID, s.hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return s.recordMFAFailure(ctx, userID)
+	}
+
+	remaining, err := s.mfaRepo.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		log.Printf("WARNING: Failed to count recovery codes for user %s: %v", userID, err)
+	}
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionMFARecoveryCode,
+		TargetType: "user",
+		TargetID:   userID,
+		After:      map[string]any{"remaining_recovery_codes": remaining},
+		ActorID:    userID,
+	})
+	return nil
+}
+
+// recordMFAFailure ghi 1 lần sai mã, trả về lỗi cho request (khóa 2FA khi sai quá nhiều)
+func (s *userService) recordMFAFailure(ctx context.Context, userID string) error {
+	lockedUntil, err := s.mfaRepo.RecordFailure(ctx, userID, mfaMaxFailedAttempts, mfaLockDuration)
+	if err != nil {
+		return err
+	}
+	if lockedUntil != nil && time.Now().Before(*lockedUntil) {
+		log.Printf("WARNING: [SECURITY] Two-factor authentication locked for user %s until %s", userID, lockedUntil.Format(time.RFC3339))
+		return mfaLockedError(*lockedUntil)
+	}
+	return domain.ErrMFAInvalidCode
+}
+
+func mfaLockedError(until time.Time) error {
+	return fmt.Errorf("%w: try again after %s", domain.ErrMFALocked, until.UTC().Format(time.RFC3339))
+}
+
+// generateRecoveryCodes sinh recovery codes (trả cho user 1 lần) và hash để lưu DB
+func (s *userService) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, mfaRecoveryCodeCount)
+	hashes := make([]string, 0, mfaRecoveryCodeCount)
+	for i := 0; i < mfaRecoveryCodeCount; i++ {
+		b, err := generateRandomBytes(mfaRecoveryCodeBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes = append(codes, raw[:4]+"-"+raw[4:])
+		hashes = append(hashes, s.hashToken(raw))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode bỏ dấu gạch/khoảng trắng và chuyển chữ thường trước khi hash
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+	"github.com/thatlq1812/policy-system/user/internal/repository"
+)
+
+// fakeMFARepository giữ cấu hình TOTP của 1 user trong bộ nhớ (chỉ các method verifySecondFactor dùng)
+type fakeMFARepository struct {
+	repository.MFARepository
+	mfa      *domain.UserMFA
+	failures int
+}
+
+func (r *fakeMFARepository) Get(ctx context.Context, userID string) (*domain.UserMFA, error) {
+	return r.mfa, nil
+}
+
+func (r *fakeMFARepository) RecordSuccess(ctx context.Context, userID string, step int64) (bool, error) {
+	if step <= r.mfa.LastUsedStep {
+		return false, nil
+	}
+	r.mfa.LastUsedStep = step
+	r.mfa.FailedAttempts = 0
+	return true, nil
+}
+
+func (r *fakeMFARepository) RecordFailure(ctx context.Context, userID string, maxAttempts int, lockDuration time.Duration) (*time.Time, error) {
+	r.failures++
+	return nil, nil
+}
+
+func (r *fakeMFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	return false, nil
+}
+
+func TestVerifySecondFactorReplay(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfcTestSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := time.Now().Unix() / int64(totpPeriod.Seconds())
+
+	tests := []struct {
+		name         string
+		lastUsedStep int64
+		codeStep     int64
+		wantErr      error
+	}{
+		{"Fresh code", current - 5, current, nil},
+		{"Previous step not used yet", current - 5, current - 1, nil},
+		{"Same code reused", current, current, domain.ErrMFAInvalidCode},
+		{"Older code after newer one", current, current - 1, domain.ErrMFAInvalidCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabledAt := time.Now().Add(-time.Hour)
+			repo := &fakeMFARepository{mfa: &domain.UserMFA{
+				UserID:       "user-1",
+				TOTPSecret:   rfcTestSecret,
+				EnabledAt:    &enabledAt,
+				LastUsedStep: tt.lastUsedStep,
+			}}
+			s := &userService{mfaRepo: repo}
+
+			err := s.verifySecondFactor(context.Background(), "user-1", hotp(key, tt.codeStep))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("verifySecondFactor() error = %v, want %v", err, tt.wantErr)
+			}
+			wantFailures := 0
+			if tt.wantErr != nil {
+				wantFailures = 1
+			}
+			if repo.failures != wantFailures {
+				t.Errorf("failures recorded = %d, want %d", repo.failures, wantFailures)
+			}
+
+			// Mã vừa chấp nhận không dùng lại được
+			if tt.wantErr == nil {
+				err := s.verifySecondFactor(context.Background(), "user-1", hotp(key, tt.codeStep))
+				if !errors.Is(err, domain.ErrMFAInvalidCode) {
+					t.Errorf("verifySecondFactor() replay error = %v, want %v", err, domain.ErrMFAInvalidCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+)
+
+// Tham số OTP qua SMS
+const (
+	otpDigits         = 6
+	otpTTL            = 5 * time.Minute
+	otpMaxAttempts    = 5 // Số lần nhập mã tối đa của 1 OTP, sau đó phải yêu cầu mã mới
+	otpResendCooldown = 60 * time.Second
+	otpSendWindow     = time.Hour
+	otpMaxSends       = 5 // Số OTP tối đa gửi tới 1 số điện thoại trong otpSendWindow (chống spam SMS)
+)
+
+// Audit actions của xác minh số điện thoại
+const (
+	auditActionVerifyPhone = "user.verify_phone"
+)
+
+// SendPhoneOTP gửi OTP qua SMS theo purpose:
+//   - verify_phone: số hiện tại của userID (chưa xác minh)
+//   - change_phone: số mới phoneNumber của userID (chưa thuộc user nào)
+//   - login: phoneNumber của tài khoản; số không tồn tại vẫn trả kết quả như thường nhưng không gửi (không lộ số đã đăng ký).
+//     Giới hạn gửi áp dụng giống hệt số đã đăng ký (lượt yêu cầu được ghi lại dù không gửi SMS)
+func (s *userService) SendPhoneOTP(ctx context.Context, purpose, userID, phoneNumber string) (*domain.OTPDispatch, error) {
+	var targetUserID string
+
+	switch purpose {
+	case domain.OTPPurposeVerifyPhone:
+		if userID == "" {
+			return nil, fmt.Errorf("%w: user ID is required", domain.ErrInvalidInput)
+		}
+		user, err := s.repo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil {
+			return nil, domain.ErrNotFound
+		}
+		if user.IsPhoneVerified() {
+			return nil, fmt.Errorf("%w: phone number already verified", domain.ErrAlreadyExists)
+		}
+		targetUserID, phoneNumber = user.ID, user.PhoneNumber
+
+	case domain.OTPPurposeChangePhone:
+		if userID == "" {
+			return nil, fmt.Errorf("%w: user ID is required", domain.ErrInvalidInput)
+		}
+		if !isValidPhoneNumber(phoneNumber) {
+			return nil, fmt.Errorf("%w: invalid phone number format", domain.ErrInvalidInput)
+		}
+		existing, err := s.repo.GetByPhoneNumber(ctx, phoneNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing user: %w", err)
+		}
+		if existing != nil {
+			if existing.ID == userID {
+				return nil, fmt.Errorf("%w: phone number is already the current one", domain.ErrInvalidInput)
+			}
+			return nil, fmt.Errorf("%w: phone number already in use", domain.ErrAlreadyExists)
+		}
+		targetUserID = userID
+
+	case domain.OTPPurposeLogin:
+		if phoneNumber == "" {
+			return nil, fmt.Errorf("%w: phone number is required", domain.ErrInvalidInput)
+		}
+		user, err := s.repo.GetByPhoneNumber(ctx, phoneNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user != nil {
+			targetUserID = user.ID
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported otp purpose %q", domain.ErrInvalidInput, purpose)
+	}
+
+	// Giới hạn gửi theo số điện thoại (mọi purpose): SMS tốn tiền và có thể bị lạm dụng để spam
+	now := time.Now()
+	sent, lastSentAt, err := s.otpRepo.RecentSends(ctx, phoneNumber, now.Add(-otpSendWindow))
+	if err != nil {
+		return nil, err
+	}
+	if lastSentAt != nil && now.Before(lastSentAt.Add(otpResendCooldown)) {
+		return nil, fmt.Errorf("%w: try again after %s", domain.ErrOTPRateLimited, lastSentAt.Add(otpResendCooldown).UTC().Format(time.RFC3339))
+	}
+	if sent >= otpMaxSends {
+		return nil, fmt.Errorf("%w: too many codes sent to this phone number, try again later", domain.ErrOTPRateLimited)
+	}
+
+	// Số chưa đăng ký (login): ghi lượt yêu cầu để cooldown/giới hạn giống số thật, không gửi SMS
+	if targetUserID == "" {
+		otp, err := s.otpRepo.RecordSuppressedSend(ctx, phoneNumber, purpose, now.Add(otpTTL))
+		if err != nil {
+			return nil, err
+		}
+		return &domain.OTPDispatch{ExpiresAt: otp.ExpiresAt, ResendAfter: otp.CreatedAt.Add(otpResendCooldown)}, nil
+	}
+
+	code, err := generateOTPCode()
+	if err != nil {
+		return nil, err
+	}
+
+	otp, err := s.otpRepo.Create(ctx, domain.CreatePhoneOTPParams{
+		UserID:      targetUserID,
+		PhoneNumber: phoneNumber,
+		Purpose:     purpose,
+		CodeHash:    s.hashOTP(phoneNumber, code),
+		ExpiresAt:   now.Add(otpTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Your verification code is %s. It expires in %d minutes. Do not share this code with anyone.", code, int(otpTTL.Minutes()))
+	if err := s.sms.Send(ctx, phoneNumber, message); err != nil {
+		// Mã chưa tới tay user: vô hiệu để không còn OTP "treo"
+		if _, cerr := s.otpRepo.Consume(ctx, otp.ID); cerr != nil {
+			log.Printf("WARNING: Failed to invalidate unsent otp %s: %v", otp.ID, cerr)
+		}
+		return nil, fmt.Errorf("failed to send sms: %w", err)
+	}
+
+	return &domain.OTPDispatch{ExpiresAt: otp.ExpiresAt, ResendAfter: otp.CreatedAt.Add(otpResendCooldown)}, nil
+}
+
+// VerifyPhone xác minh số điện thoại hiện tại của user bằng OTP verify_phone
+func (s *userService) VerifyPhone(ctx context.Context, userID, code string) (*domain.User, error) {
+	if userID == "" || code == "" {
+		return nil, fmt.Errorf("%w: user ID and code are required", domain.ErrInvalidInput)
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+	if user.IsPhoneVerified() {
+		return nil, fmt.Errorf("%w: phone number already verified", domain.ErrAlreadyExists)
+	}
+
+	if _, err := s.checkOTP(ctx, user.PhoneNumber, domain.OTPPurposeVerifyPhone, userID, code); err != nil {
+		return nil, err
+	}
+
+	marked, err := s.repo.MarkPhoneVerified(ctx, userID, user.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		// User đổi số trong lúc đang xác minh
+		return nil, fmt.Errorf("%w: phone number has changed, request a new code", domain.ErrOTPInvalid)
+	}
+
+	verified, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionVerifyPhone,
+		TargetType: "user",
+		TargetID:   userID,
+		After:      map[string]any{"phone_number": user.PhoneNumber},
+		ActorID:    userID,
+	})
+	return verified, nil
+}
+
+// LoginWithOTP đăng nhập không mật khẩu bằng OTP login; 2FA vẫn áp dụng như Login
+func (s *userService) LoginWithOTP(ctx context.Context, phoneNumber, code string) (*LoginResult, error) {
+	if phoneNumber == "" || code == "" {
+		return nil, fmt.Errorf("%w: phone number and code are required", domain.ErrInvalidInput)
+	}
+
+	otp, err := s.checkOTP(ctx, phoneNumber, domain.OTPPurposeLogin, "", code)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, otp.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || user.PhoneNumber != phoneNumber {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	// Nhận được OTP qua SMS cũng chứng minh sở hữu số điện thoại
+	if !user.IsPhoneVerified() {
+		marked, err := s.repo.MarkPhoneVerified(ctx, user.ID, phoneNumber)
+		if err != nil {
+			log.Printf("WARNING: Failed to mark phone verified for user %s: %v", user.ID, err)
+		} else if marked {
+			now := time.Now()
+			user.PhoneVerifiedAt = &now
+		}
+	}
+
+	return s.finishLogin(ctx, user)
+}
+
+// sendVerificationOTP gửi OTP xác minh số sau khi đăng ký; lỗi không làm hỏng đăng ký (user yêu cầu lại được)
+func (s *userService) sendVerificationOTP(ctx context.Context, user *domain.User) {
+	if _, err := s.SendPhoneOTP(ctx, domain.OTPPurposeVerifyPhone, user.ID, ""); err != nil {
+		log.Printf("WARNING: Failed to send phone verification code to user %s: %v", user.ID, err)
+	}
+}
+
+// checkOTP kiểm tra mã của OTP đang hiệu lực và đánh dấu đã dùng.
+// userID rỗng = không ràng buộc user (login). Quá otpMaxAttempts thì OTP bị hủy.
+func (s *userService) checkOTP(ctx context.Context, phoneNumber, purpose, userID, code string) (*domain.PhoneOTP, error) {
+	otp, err := s.otpRepo.GetActive(ctx, phoneNumber, purpose)
+	if err != nil {
+		return nil, err
+	}
+	if otp == nil || !time.Now().Before(otp.ExpiresAt) || (userID != "" && otp.UserID != userID) {
+		return nil, fmt.Errorf("%w: code is invalid or expired", domain.ErrOTPInvalid)
+	}
+
+	attempts, err := s.otpRepo.IncrementAttempts(ctx, otp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > otpMaxAttempts {
+		if _, err := s.otpRepo.Consume(ctx, otp.ID); err != nil {
+			log.Printf("WARNING: Failed to close otp %s: %v", otp.ID, err)
+		}
+		return nil, fmt.Errorf("%w: too many attempts, request a new code", domain.ErrOTPInvalid)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(s.hashOTP(phoneNumber, code)), []byte(otp.CodeHash)) != 1 {
+		return nil, fmt.Errorf("%w: code is invalid or expired", domain.ErrOTPInvalid)
+	}
+
+	consumed, err := s.otpRepo.Consume(ctx, otp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, fmt.Errorf("%w: code has already been used", domain.ErrOTPInvalid)
+	}
+	return otp, nil
+}
+
+// hashOTP gắn số điện thoại vào hash: mã 6 số ít entropy, tránh trùng hash giữa các số
+func (s *userService) hashOTP(phoneNumber, code string) string {
+	return s.hashToken(phoneNumber + ":" + code)
+}
+
+// generateOTPCode sinh mã số ngẫu nhiên otpDigits chữ số (phân bố đều)
+func generateOTPCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < otpDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate otp: %w", err)
+	}
+	return fmt.Sprintf("%0*d", otpDigits, n.Int64()), nil
+}
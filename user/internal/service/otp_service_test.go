@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+	"github.com/thatlq1812/policy-system/user/internal/repository"
+)
+
+const testPhoneNumber = "0901234567"
+
+// fakeOTPRepository giữ 1 OTP trong bộ nhớ (chỉ các method checkOTP dùng).
+// GetActive không lọc hết hạn để test được kiểm tra của service
+type fakeOTPRepository struct {
+	repository.OTPRepository
+	otp               *domain.PhoneOTP
+	consumed          bool
+	consumedElsewhere bool // Request khác đã dùng OTP trước
+}
+
+func (r *fakeOTPRepository) GetActive(ctx context.Context, phoneNumber, purpose string) (*domain.PhoneOTP, error) {
+	if r.otp == nil || r.consumed || r.otp.PhoneNumber != phoneNumber || r.otp.Purpose != purpose {
+		return nil, nil
+	}
+	otp := *r.otp
+	return &otp, nil
+}
+
+func (r *fakeOTPRepository) IncrementAttempts(ctx context.Context, otpID string) (int, error) {
+	r.otp.Attempts++
+	return r.otp.Attempts, nil
+}
+
+func (r *fakeOTPRepository) Consume(ctx context.Context, otpID string) (bool, error) {
+	if r.consumed || r.consumedElsewhere {
+		return false, nil
+	}
+	r.consumed = true
+	return true, nil
+}
+
+func TestCheckOTP(t *testing.T) {
+	s := &userService{}
+	validHash := s.hashOTP(testPhoneNumber, "123456")
+
+	tests := []struct {
+		name         string
+		otp          *domain.PhoneOTP
+		raced        bool
+		userID       string
+		code         string
+		wantErr      bool
+		wantAttempts int
+		wantConsumed bool
+	}{
+		{"Valid code", &domain.PhoneOTP{Attempts: 0}, false, "", "123456", false, 1, true},
+		{"Valid code - bound user", &domain.PhoneOTP{UserID: "user-1"}, false, "user-1", "123456", false, 1, true},
+		{"Valid code - last attempt", &domain.PhoneOTP{Attempts: otpMaxAttempts - 1}, false, "", "123456", false, otpMaxAttempts, true},
+		{"Wrong code", &domain.PhoneOTP{}, false, "", "654321", true, 1, false},
+		{"Too many attempts - closes otp", &domain.PhoneOTP{Attempts: otpMaxAttempts}, false, "", "123456", true, otpMaxAttempts + 1, true},
+		{"Expired", &domain.PhoneOTP{ExpiresAt: time.Now().Add(-time.Second)}, false, "", "123456", true, 0, false},
+		{"Other user's code", &domain.PhoneOTP{UserID: "user-2"}, false, "user-1", "123456", true, 0, false},
+		{"Already used by another request", &domain.PhoneOTP{}, true, "", "123456", true, 1, false},
+		{"No active code", nil, false, "", "123456", true, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOTPRepository{otp: tt.otp, consumedElsewhere: tt.raced}
+			if tt.otp != nil {
+				tt.otp.ID = "otp-1"
+				tt.otp.PhoneNumber = testPhoneNumber
+				tt.otp.Purpose = domain.OTPPurposeLogin
+				tt.otp.CodeHash = validHash
+				if tt.otp.ExpiresAt.IsZero() {
+					tt.otp.ExpiresAt = time.Now().Add(otpTTL)
+				}
+			}
+			s.otpRepo = repo
+
+			_, err := s.checkOTP(context.Background(), testPhoneNumber, domain.OTPPurposeLogin, tt.userID, tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, domain.ErrOTPInvalid) {
+				t.Errorf("checkOTP() error = %v, want %v", err, domain.ErrOTPInvalid)
+			}
+			if tt.otp != nil && tt.otp.Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", tt.otp.Attempts, tt.wantAttempts)
+			}
+			if repo.consumed != tt.wantConsumed {
+				t.Errorf("consumed = %v, want %v", repo.consumed, tt.wantConsumed)
+			}
+		})
+	}
+}
+
+func TestCheckOTPAttemptLimit(t *testing.T) {
+	s := &userService{}
+	repo := &fakeOTPRepository{otp: &domain.PhoneOTP{
+		ID:          "otp-1",
+		PhoneNumber: testPhoneNumber,
+		Purpose:     domain.OTPPurposeVerifyPhone,
+		CodeHash:    s.hashOTP(testPhoneNumber, "123456"),
+		ExpiresAt:   time.Now().Add(otpTTL),
+	}}
+	s.otpRepo = repo
+
+	for i := 0; i < otpMaxAttempts; i++ {
+		if _, err := s.checkOTP(context.Background(), testPhoneNumber, domain.OTPPurposeVerifyPhone, "", "000000"); err == nil {
+			t.Fatalf("checkOTP() wrong code attempt %d succeeded", i+1)
+		}
+	}
+
+	// Đoán sai otpMaxAttempts lần thì mã đúng cũng bị từ chối và OTP bị hủy
+	if _, err := s.checkOTP(context.Background(), testPhoneNumber, domain.OTPPurposeVerifyPhone, "", "123456"); !errors.Is(err, domain.ErrOTPInvalid) {
+		t.Fatalf("checkOTP() after %d wrong attempts error = %v, want %v", otpMaxAttempts, err, domain.ErrOTPInvalid)
+	}
+	if !repo.consumed {
+		t.Error("otp was not closed after too many attempts")
+	}
+}
+
+func TestGenerateOTPCode(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9]{6}$`)
+	seen := make(map[string]bool)
+
+	for i := 0; i < 200; i++ {
+		code, err := generateOTPCode()
+		if err != nil {
+			t.Fatalf("generateOTPCode() error = %v", err)
+		}
+		if !pattern.MatchString(code) {
+			t.Fatalf("generateOTPCode() = %q, want %d digits", code, otpDigits)
+		}
+		seen[code] = true
+	}
+
+	// 200 mã trong 10^6 giá trị: trùng nhiều là dấu hiệu nguồn ngẫu nhiên hỏng
+	if len(seen) < 190 {
+		t.Errorf("generateOTPCode() produced only %d distinct codes out of 200", len(seen))
+	}
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	consentpb "github.com/thatlq1812/policy-system/shared/pkg/api/consent"
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
+	"github.com/thatlq1812/policy-system/shared/pkg/saga"
+	"github.com/thatlq1812/policy-system/user/internal/clients"
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+)
+
+// RegistrationSagaType là loại saga đăng ký kèm consent
+const RegistrationSagaType = "registration"
+
+// Các bước của saga đăng ký, theo thứ tự
+const (
+	stepCreateUser    = "create_user"
+	stepFetchPolicy   = "fetch_policy"
+	stepRecordConsent = "record_consent"
+)
+
+const (
+	// registrationSagaActor ghi vào revoked_by khi saga thu hồi consent lúc bù trừ
+	registrationSagaActor  = "system:registration-saga"
+	registrationRollback   = "Registration rollback"
+	registrationRPCTimeout = 3 * time.Second
+)
+
+// RegistrationService đăng ký user kèm consent policy bắt buộc mới nhất
+type RegistrationService interface {
+	// RegisterWithConsent chạy saga create_user → fetch_policy → record_consent.
+	// Bước sau lỗi thì user/consent đã tạo được bù trừ (thử lại nền tới khi thành công).
+	RegisterWithConsent(ctx context.Context, params RegisterWithConsentParams) (*RegistrationResult, error)
+}
+
+// RegisterWithConsentParams holds the registration input
+type RegisterWithConsentParams struct {
+	PhoneNumber  string
+	Password     string
+	Name         string
+	PlatformRole string
+	Locale       string
+	IPAddress    string
+	UserAgent    string
+}
+
+// RegistrationResult is the registered user with its tokens
+type RegistrationResult struct {
+	User             *domain.User
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresAt  int64
+	RefreshExpiresAt int64
+	ConsentsRecorded int
+	SagaID           string
+}
+
+// registrationState là Data của saga đăng ký: đủ để bù trừ, không chứa password/token
+type registrationState struct {
+	UserID           string `json:"user_id"`
+	PlatformRole     string `json:"platform_role"`
+	DocumentID       string `json:"document_id,omitempty"`
+	DocumentName     string `json:"document_name,omitempty"`
+	VersionTimestamp int64  `json:"version_timestamp,omitempty"`
+	Locale           string `json:"locale,omitempty"`
+	IsMandatory      bool   `json:"is_mandatory,omitempty"`
+	ConsentRecorded  bool   `json:"consent_recorded,omitempty"`
+}
+
+// RegistrationSaga định nghĩa cách bù trừ từng bước của saga đăng ký.
+// Bù trừ idempotent: user/consent không tồn tại (chưa tạo hoặc đã bù trừ) được coi là thành công.
+func RegistrationSaga(users UserService, consents *clients.ConsentClient) *saga.Definition {
+	return &saga.Definition{
+		Type: RegistrationSagaType,
+		Steps: []saga.Step{
+			{
+				Name: stepCreateUser,
+				Compensate: func(ctx context.Context, data json.RawMessage) error {
+					var st registrationState
+					if err := json.Unmarshal(data, &st); err != nil {
+						return fmt.Errorf("failed to decode registration saga: %w", err)
+					}
+
+					// Xóa mềm như các lần xóa khác: giữ audit trail, revoke refresh token đã cấp
+					err := users.DeleteUser(ctx, st.UserID, registrationRollback)
+					if errors.Is(err, domain.ErrNotFound) {
+						return nil
+					}
+					return err
+				},
+			},
+			{Name: stepFetchPolicy}, // Chỉ đọc
+			{
+				Name: stepRecordConsent,
+				Compensate: func(ctx context.Context, data json.RawMessage) error {
+					var st registrationState
+					if err := json.Unmarshal(data, &st); err != nil {
+						return fmt.Errorf("failed to decode registration saga: %w", err)
+					}
+					// Không dựa vào ConsentRecorded: process có thể chết sau khi Consent Service đã ghi
+					if st.DocumentID == "" {
+						return nil
+					}
+
+					ctx, cancel := context.WithTimeout(ctx, registrationRPCTimeout)
+					defer cancel()
+					return consents.RevokeConsent(ctx, &consentpb.RevokeConsentRequest{
+						UserId:           st.UserID,
+						DocumentId:       st.DocumentID,
+						VersionTimestamp: st.VersionTimestamp,
+						ActingAdminId:    registrationSagaActor,
+						Reason:           registrationRollback,
+					})
+				},
+			},
+		},
+	}
+}
+
+// registrationService implements RegistrationService
+type registrationService struct {
+	users     UserService
+	documents *clients.DocumentClient
+	consents  *clients.ConsentClient
+	sagas     *saga.Orchestrator
+}
+
+// NewRegistrationService creates a new RegistrationService;
+// sagas phải được tạo với RegistrationSaga
+func NewRegistrationService(users UserService, documents *clients.DocumentClient, consents *clients.ConsentClient, sagas *saga.Orchestrator) RegistrationService {
+	return &registrationService{
+		users:     users,
+		documents: documents,
+		consents:  consents,
+		sagas:     sagas,
+	}
+}
+
+func (s *registrationService) RegisterWithConsent(ctx context.Context, params RegisterWithConsentParams) (*RegistrationResult, error) {
+	// SECURITY: Admin chỉ được tạo qua create-admin
+	if params.PlatformRole == "Admin" {
+		return nil, fmt.Errorf("%w: admin accounts cannot be created through public registration", domain.ErrInsufficientPermissions)
+	}
+
+	// User ID sinh trước và lưu vào saga → bù trừ được kể cả khi process chết trong lúc tạo user
+	state := &registrationState{
+		UserID:       uuid.New().String(),
+		PlatformRole: params.PlatformRole,
+	}
+	exec, err := s.sagas.Start(ctx, RegistrationSagaType, uuid.New().String(), state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start registration saga: %w", err)
+	}
+
+	// Actor/request ID của request gốc được chuyển tiếp sang Document/Consent Service
+	downstream := audit.WithRequestInfo(ctx, audit.FromIncomingContext(ctx))
+	result := &RegistrationResult{SagaID: exec.ID()}
+
+	// ===== STEP 1: Create user =====
+	err = exec.Do(ctx, stepCreateUser, func(ctx context.Context) error {
+		user, accessToken, refreshToken, accessExpiresAt, refreshExpiresAt, err := s.users.RegisterWithID(
+			ctx, state.UserID, params.PhoneNumber, params.Password, params.Name, params.PlatformRole)
+		if err != nil {
+			return err
+		}
+		result.User = user
+		result.AccessToken, result.RefreshToken = accessToken, refreshToken
+		result.AccessExpiresAt, result.RefreshExpiresAt = accessExpiresAt, refreshExpiresAt
+		return nil
+	})
+	if err != nil {
+		// Lỗi validate/trùng số điện thoại: trả nguyên lỗi, chưa có gì cần bù trừ
+		return nil, err
+	}
+
+	// ===== STEP 2: Get latest policy =====
+	err = exec.Do(ctx, stepFetchPolicy, func(context.Context) error {
+		policyCtx, cancel := context.WithTimeout(downstream, registrationRPCTimeout)
+		defer cancel()
+
+		doc, err := s.documents.GetLatestPolicy(policyCtx, params.PlatformRole, params.Locale)
+		if err != nil {
+			return err
+		}
+		if doc != nil {
+			state.DocumentID = doc.Id
+			state.DocumentName = doc.DocumentName
+			state.VersionTimestamp = doc.EffectiveTimestamp
+			state.Locale = doc.Locale
+			state.IsMandatory = doc.IsMandatory
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, rolledBack(exec, "failed to fetch policies", err)
+	}
+
+	// ===== STEP 3: Record consent (chỉ nếu mandatory) =====
+	if state.IsMandatory {
+		err = exec.Do(ctx, stepRecordConsent, func(context.Context) error {
+			consentCtx, cancel := context.WithTimeout(downstream, registrationRPCTimeout)
+			defer cancel()
+
+			err := s.consents.RecordConsent(consentCtx, &consentpb.RecordConsentRequest{
+				UserId:   state.UserID,
+				Platform: params.PlatformRole,
+				Consents: []*consentpb.ConsentInput{{
+					DocumentId:       state.DocumentID,
+					DocumentName:     state.DocumentName,
+					VersionTimestamp: state.VersionTimestamp,
+					Locale:           state.Locale,
+				}},
+				ConsentMethod: "REGISTRATION",
+				IpAddress:     params.IPAddress,
+				UserAgent:     params.UserAgent,
+			})
+			if err != nil {
+				return err
+			}
+			state.ConsentRecorded = true
+			return nil
+		})
+		if err != nil {
+			return nil, rolledBack(exec, "failed to record consent", err)
+		}
+		result.ConsentsRecorded = 1
+	}
+
+	if err := exec.Complete(ctx); err != nil {
+		return nil, rolledBack(exec, "failed to complete registration", err)
+	}
+
+	log.Printf("[REGISTRATION] User %s registered with %d consent(s) (saga %s)",
+		state.UserID, result.ConsentsRecorded, exec.ID())
+
+	// OTP xác minh số điện thoại chỉ gửi khi đăng ký không bị rollback; lỗi gửi không làm hỏng đăng ký
+	if _, err := s.users.SendPhoneOTP(ctx, domain.OTPPurposeVerifyPhone, state.UserID, ""); err != nil {
+		log.Printf("WARNING: Failed to send phone verification code to user %s: %v", state.UserID, err)
+	}
+	return result, nil
+}
+
+// rolledBack bọc lỗi của bước thất bại; bù trừ chưa xong thì Orchestrator tiếp tục chạy nền
+func rolledBack(exec *saga.Execution, msg string, err error) error {
+	if exec.Status() == saga.StatusCompensated {
+		return fmt.Errorf("%w: %s: %v", domain.ErrRegistrationRolledBack, msg, err)
+	}
+	return fmt.Errorf("%w (cleanup pending, saga %s): %s: %v", domain.ErrRegistrationRolledBack, exec.ID(), msg, err)
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+	"github.com/thatlq1812/policy-system/user/internal/repository"
+)
+
+// signingKeyGracePeriod bù lệch đồng hồ giữa các service khi verify token ký bằng key cũ
+const signingKeyGracePeriod = 5 * time.Minute
+
+// KeyManagerConfig cấu hình rotation của khóa ký access token
+type KeyManagerConfig struct {
+	Algorithm      string        // domain.SigningAlgorithmRS256 hoặc domain.SigningAlgorithmEdDSA
+	RotationPeriod time.Duration // Key đang ký được thay sau khoảng này
+	PublishAhead   time.Duration // Key mới có trong JWKS trước khi ký, >= thời gian cache JWKS của bên verify
+	CheckInterval  time.Duration // Chu kỳ đọc lại key từ DB và kiểm tra tới hạn rotation
+}
+
+// KeyManager giữ các khóa ký access token: ký bằng key mới nhất đã active,
+// verify bằng mọi key chưa hết hạn (nhận diện bằng kid)
+type KeyManager struct {
+	repo repository.SigningKeyRepository
+	cfg  KeyManagerConfig
+
+	mu   sync.RWMutex
+	keys []*signingKey // activates_at tăng dần
+}
+
+// signingKey là SigningKey đã parse PEM
+type signingKey struct {
+	*domain.SigningKey
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// NewKeyManager creates a new KeyManager; gọi Init trước khi ký token
+func NewKeyManager(repo repository.SigningKeyRepository, cfg KeyManagerConfig) (*KeyManager, error) {
+	if _, err := signingMethod(cfg.Algorithm); err != nil {
+		return nil, err
+	}
+	if cfg.RotationPeriod <= cfg.PublishAhead {
+		return nil, fmt.Errorf("signing key rotation period (%s) must be longer than publish ahead (%s)", cfg.RotationPeriod, cfg.PublishAhead)
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = time.Minute
+	}
+	return &KeyManager{repo: repo, cfg: cfg}, nil
+}
+
+// Init tạo key đầu tiên nếu chưa có và nạp các key vào bộ nhớ
+func (m *KeyManager) Init(ctx context.Context) error {
+	return m.refresh(ctx)
+}
+
+// Run kiểm tra rotation và đọc lại key định kỳ tới khi ctx bị hủy
+func (m *KeyManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := m.refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("WARNING: [SIGNING KEYS] Failed to refresh signing keys: %v", err)
+		}
+	}
+}
+
+func (m *KeyManager) refresh(ctx context.Context) error {
+	if _, err := m.repo.DeleteExpired(ctx); err != nil {
+		return err
+	}
+	if err := m.load(ctx); err != nil {
+		return err
+	}
+
+	rotated, err := m.rotateIfDue(ctx)
+	if err != nil || !rotated {
+		return err
+	}
+	return m.load(ctx)
+}
+
+// load nạp lại các key chưa hết hạn từ DB (instance khác có thể đã rotation)
+func (m *KeyManager) load(ctx context.Context) error {
+	stored, err := m.repo.ListValid(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]*signingKey, 0, len(stored))
+	for _, k := range stored {
+		parsed, err := parseSigningKey(k)
+		if err != nil {
+			return fmt.Errorf("failed to parse signing key %s: %w", k.KID, err)
+		}
+		keys = append(keys, parsed)
+	}
+
+	m.mu.Lock()
+	m.keys = keys
+	m.mu.Unlock()
+	return nil
+}
+
+// rotateIfDue tạo key mới khi key mới nhất đã activate quá RotationPeriod.
+// Key đầu tiên ký ngay; các key sau được publish trước PublishAhead.
+func (m *KeyManager) rotateIfDue(ctx context.Context) (bool, error) {
+	now := time.Now()
+	dueBefore := now.Add(-m.cfg.RotationPeriod + m.cfg.PublishAhead)
+	latest, ok := m.latestActivation()
+	if ok && latest.After(dueBefore) {
+		return false, nil
+	}
+
+	activatesAt := now.Add(m.cfg.PublishAhead)
+	if !ok {
+		activatesAt = now
+	}
+
+	key, err := generateSigningKey(m.cfg.Algorithm, activatesAt)
+	if err != nil {
+		return false, err
+	}
+
+	// Key cũ còn verify được tới khi access token cuối cùng nó ký hết hạn
+	previousExpiresAt := activatesAt.Add(AccessTokenExpiry + signingKeyGracePeriod)
+
+	rotated, err := m.repo.Rotate(ctx, key, dueBefore, previousExpiresAt)
+	if err != nil {
+		return false, err
+	}
+	if rotated {
+		log.Printf("[SIGNING KEYS] New %s signing key %s, signs from %s", key.Algorithm, key.KID, key.ActivatesAt.Format(time.RFC3339))
+	}
+	return rotated, nil
+}
+
+// latestActivation trả về activates_at của key mới nhất đã nạp (false nếu chưa có key)
+func (m *KeyManager) latestActivation() (time.Time, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.keys) == 0 {
+		return time.Time{}, false
+	}
+	return m.keys[len(m.keys)-1].ActivatesAt, true
+}
+
+// current trả về key mới nhất đã active
+func (m *KeyManager) current() (*signingKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	for i := len(m.keys) - 1; i >= 0; i-- {
+		if !m.keys[i].ActivatesAt.After(now) {
+			return m.keys[i], nil
+		}
+	}
+	return nil, errors.New("no active signing key")
+}
+
+func (m *KeyManager) lookup(kid string) (*signingKey, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, k := range m.keys {
+		if k.KID == kid {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+// Sign ký claims bằng key hiện tại, header "kid" là key đã dùng
+func (m *KeyManager) Sign(claims jwt.Claims) (string, error) {
+	key, err := m.current()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.private)
+}
+
+// Parse verify token bằng key theo kid (chỉ chấp nhận thuật toán của key đó)
+func (m *KeyManager) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := m.lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.public, nil
+	}, jwt.WithValidMethods([]string{domain.SigningAlgorithmRS256, domain.SigningAlgorithmEdDSA}))
+}
+
+// PublicKeys trả về JWKS: mọi key chưa hết hạn, kể cả key publish trước chưa ký
+func (m *KeyManager) PublicKeys() []*domain.JWK {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jwks := make([]*domain.JWK, 0, len(m.keys))
+	for _, k := range m.keys {
+		jwk := &domain.JWK{
+			KID:         k.KID,
+			Alg:         k.Algorithm,
+			Use:         "sig",
+			ActivatesAt: k.ActivatesAt,
+			ExpiresAt:   k.ExpiresAt,
+		}
+		switch pub := k.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks
+}
+
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case domain.SigningAlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case domain.SigningAlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q (RS256 or EdDSA)", algorithm)
+	}
+}
+
+// generateSigningKey sinh cặp khóa mới (RSA 2048 hoặc Ed25519)
+func generateSigningKey(algorithm string, activatesAt time.Time) (*domain.SigningKey, error) {
+	var private crypto.Signer
+	var err error
+	switch algorithm {
+	case domain.SigningAlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case domain.SigningAlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signing key: %w", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return &domain.SigningKey{
+		KID:           uuid.New().String(),
+		Algorithm:     algorithm,
+		PrivateKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		PublicKeyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+		ActivatesAt:   activatesAt,
+	}, nil
+}
+
+func parseSigningKey(k *domain.SigningKey) (*signingKey, error) {
+	method, err := signingMethod(k.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(k.PrivateKeyPEM))
+	if block == nil {
+		return nil, errors.New("invalid private key PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key cannot sign")
+	}
+
+	return &signingKey{
+		SigningKey: k,
+		method:     method,
+		private:    private,
+		public:     private.Public(),
+	}, nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -17,8 +18,9 @@ const (
 	RefreshTokenExpiry = 30 * 24 * time.Hour // 30 days
 )
 
-// generateAccessToken creates a short-lived JWT access token
-func (s *userService) generateAccessToken(userID, platformRole string) (string, int64, error) {
+// generateAccessToken creates a short-lived JWT access token.
+// JTI được ghi lại theo user/family để blacklist được khi phát hiện token bị lộ.
+func (s *userService) generateAccessToken(ctx context.Context, userID, platformRole, familyID string) (string, int64, error) {
 	expiresAt := time.Now().Add(AccessTokenExpiry)
 
 	// Generate unique JTI (JWT ID) for token revocation
@@ -33,12 +35,17 @@ func (s *userService) generateAccessToken(userID, platformRole string) (string,
 		"iat":           time.Now().Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(s.jwtSecret))
+	signedToken, err := s.keys.Sign(claims)
 	if err != nil {
 		return "", 0, fmt.Errorf("failed to sign access token: %w", err)
 	}
 
+	if s.blacklistRepo != nil {
+		if err := s.blacklistRepo.TrackIssued(ctx, jti, userID, familyID, expiresAt); err != nil {
+			return "", 0, err
+		}
+	}
+
 	return signedToken, expiresAt.Unix(), nil
 }
 
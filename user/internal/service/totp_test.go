@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+// Secret của test vectors RFC 4226/6238 ("12345678901234567890" ASCII) dạng Base32
+const rfcTestSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestHOTP(t *testing.T) {
+	// RFC 4226 Appendix D
+	tests := []struct {
+		counter int64
+		want    string
+	}{
+		{0, "755224"},
+		{1, "287082"},
+		{2, "359152"},
+		{3, "969429"},
+		{4, "338314"},
+		{5, "254676"},
+		{6, "287922"},
+		{7, "162583"},
+		{8, "399871"},
+		{9, "520489"},
+	}
+
+	key := []byte("12345678901234567890")
+	for _, tt := range tests {
+		if got := hotp(key, tt.counter); got != tt.want {
+			t.Errorf("hotp(counter=%d) = %s, want %s", tt.counter, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	// RFC 6238 Appendix B (SHA1), 6 chữ số cuối của mã 8 chữ số
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		now      time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{"RFC 6238 - T=59", rfcTestSecret, "287082", time.Unix(59, 0), 1, true},
+		{"RFC 6238 - T=1111111109", rfcTestSecret, "081804", time.Unix(1111111109, 0), 37037036, true},
+		{"RFC 6238 - T=1111111111", rfcTestSecret, "050471", time.Unix(1111111111, 0), 37037037, true},
+		{"RFC 6238 - T=1234567890", rfcTestSecret, "005924", time.Unix(1234567890, 0), 41152263, true},
+		{"RFC 6238 - T=2000000000", rfcTestSecret, "279037", time.Unix(2000000000, 0), 66666666, true},
+		{"RFC 6238 - T=20000000000", rfcTestSecret, "353130", time.Unix(20000000000, 0), 666666666, true},
+		{"Window - previous step", rfcTestSecret, "050471", time.Unix(1111111111+30, 0), 37037037, true},
+		{"Window - next step", rfcTestSecret, "050471", time.Unix(1111111111-30, 0), 37037037, true},
+		{"Window - two steps late", rfcTestSecret, "050471", time.Unix(1111111111+60, 0), 0, false},
+		{"Window - two steps early", rfcTestSecret, "050471", time.Unix(1111111111-60, 0), 0, false},
+		{"Surrounding spaces", rfcTestSecret, " 287082 ", time.Unix(59, 0), 1, true},
+		{"Lowercase secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "287082", time.Unix(59, 0), 1, true},
+		{"Wrong code", rfcTestSecret, "287083", time.Unix(59, 0), 0, false},
+		{"8 digit code", rfcTestSecret, "94287082", time.Unix(59, 0), 0, false},
+		{"Too short", rfcTestSecret, "28708", time.Unix(59, 0), 0, false},
+		{"Invalid secret", "not base32!", "287082", time.Unix(59, 0), 0, false},
+		{"Empty", rfcTestSecret, "", time.Unix(59, 0), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := validateTOTP(tt.secret, tt.code, tt.now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("validateTOTP() = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/thatlq1812/policy-system/shared/pkg/audit"
 	"github.com/thatlq1812/policy-system/shared/pkg/validator"
+	"github.com/thatlq1812/policy-system/user/internal/clients"
 	"github.com/thatlq1812/policy-system/user/internal/domain"
 	"github.com/thatlq1812/policy-system/user/internal/repository"
 )
@@ -18,7 +20,33 @@ import (
 type UserService interface {
 	Register(ctx context.Context, phoneNumber, password, name, platformRole string) (*domain.User, string, string, int64, int64, error)
 
-	Login(ctx context.Context, phoneNumber, password string) (*domain.User, string, string, int64, int64, error)
+	// RegisterWithID như Register nhưng với user ID cho trước (saga đăng ký)
+	RegisterWithID(ctx context.Context, userID, phoneNumber, password, name, platformRole string) (*domain.User, string, string, int64, int64, error)
+
+	// Login kiểm tra password; user đã bật 2FA (và Admin) nhận MFA challenge thay cho tokens
+	Login(ctx context.Context, phoneNumber, password string) (*LoginResult, error)
+
+	// VerifyMFA hoàn tất đăng nhập bằng mã TOTP hoặc recovery code
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResult, error)
+
+	// EnrollTOTP tạo TOTP secret mới (chưa có hiệu lực tới khi ConfirmTOTP)
+	EnrollTOTP(ctx context.Context, userID, mfaToken string) (*domain.TOTPEnrollment, error)
+
+	// ConfirmTOTP bật 2FA bằng mã đầu tiên, trả về recovery codes
+	// (và tokens khi Admin enroll trong lúc đăng nhập)
+	ConfirmTOTP(ctx context.Context, userID, mfaToken, code string) ([]string, *LoginResult, error)
+
+	// DisableTOTP tắt 2FA (không cho phép với Admin)
+	DisableTOTP(ctx context.Context, userID, code string) error
+
+	// SendPhoneOTP gửi OTP qua SMS (verify_phone, change_phone, login)
+	SendPhoneOTP(ctx context.Context, purpose, userID, phoneNumber string) (*domain.OTPDispatch, error)
+
+	// VerifyPhone xác minh số điện thoại hiện tại bằng OTP
+	VerifyPhone(ctx context.Context, userID, code string) (*domain.User, error)
+
+	// LoginWithOTP đăng nhập không mật khẩu bằng OTP gửi tới số điện thoại
+	LoginWithOTP(ctx context.Context, phoneNumber, code string) (*LoginResult, error)
 
 	// RefreshToken generates new access token and rotates refresh token
 	RefreshToken(ctx context.Context, refreshToken string) (accessToken string, newRefreshToken string, accessExpiresAt int64, refreshExpiresAt int64, err error)
@@ -29,8 +57,8 @@ type UserService interface {
 	// GetUserProfile retrieves user profile by ID
 	GetUserProfile(ctx context.Context, userID string) (*domain.User, error)
 
-	// UpdateUserProfile updates user profile information
-	UpdateUserProfile(ctx context.Context, userID string, name, phoneNumber *string) (*domain.User, error)
+	// UpdateUserProfile updates user profile information; đổi số điện thoại cần OTP change_phone gửi tới số mới
+	UpdateUserProfile(ctx context.Context, userID string, name, phoneNumber *string, otpCode string) (*domain.User, error)
 
 	// ChangePassword changes user's password
 	ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error
@@ -68,13 +96,48 @@ type UserService interface {
 	IsTokenBlacklisted(ctx context.Context, jti string) (bool, error)
 }
 
+// LoginResult là kết quả đăng nhập: tokens, hoặc MFA challenge khi còn bước 2FA
+type LoginResult struct {
+	User             *domain.User
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresAt  int64
+	RefreshExpiresAt int64
+	// MFA != nil: chưa cấp tokens. Purpose verify → VerifyMFA,
+	// Purpose enroll (Admin chưa có 2FA) → EnrollTOTP rồi ConfirmTOTP
+	MFA *domain.MFAChallenge
+}
+
+// Audit actions của User Service
+const (
+	auditActionRegister       = "user.register"
+	auditActionCreateAdmin    = "user.create_admin"
+	auditActionUpdateProfile  = "user.update_profile"
+	auditActionChangePassword = "user.change_password"
+	auditActionDelete         = "user.delete"
+	auditActionHardDelete     = "user.hard_delete"
+	auditActionUpdateRole     = "user.update_role"
+	auditActionRevokeSessions = "session.revoke_all"
+	auditActionRevokeSession  = "session.revoke"
+	auditActionTokenReuse     = "session.token_reuse"
+)
+
+// auditActorTokenReuse là actor của security event do hệ thống tự phát hiện
+const auditActorTokenReuse = "system:token-reuse-detection"
+
 // userService implements UserService
 type userService struct {
 	repo             repository.UserRepository
 	refreshTokenRepo repository.RefreshTokenRepository
 	blacklistRepo    repository.TokenBlacklistRepository // NEW: For access token revocation
-	jwtSecret        string
-	jwtExpiryHours   int // Deprecated, use constants in token_helper.go
+	mfaRepo          repository.MFARepository
+	otpRepo          repository.OTPRepository
+	sms              clients.SMSSender // Gửi OTP xác minh số điện thoại
+	auditLog         *audit.Logger
+	keys             *KeyManager // Ký/verify access token (RS256/EdDSA, theo kid)
+	jwtExpiryHours   int         // Deprecated, use constants in token_helper.go
+	notifyTokenReuse bool        // Ghi event SessionCompromised khi phát hiện refresh token bị dùng lại
+	mfaIssuer        string      // Tên hiển thị trong authenticator app
 }
 
 // NewUserService creates a new service instance
@@ -82,20 +145,45 @@ func NewUserService(
 	repo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
 	blacklistRepo repository.TokenBlacklistRepository, // NEW
-	jwtSecret string,
+	mfaRepo repository.MFARepository,
+	otpRepo repository.OTPRepository,
+	sms clients.SMSSender,
+	auditLog *audit.Logger,
+	keys *KeyManager,
 	jwtExpiryHours int,
+	notifyTokenReuse bool,
+	mfaIssuer string,
 ) UserService {
 	return &userService{
 		repo:             repo,
 		refreshTokenRepo: refreshTokenRepo,
 		blacklistRepo:    blacklistRepo,
-		jwtSecret:        jwtSecret,
+		mfaRepo:          mfaRepo,
+		otpRepo:          otpRepo,
+		sms:              sms,
+		auditLog:         auditLog,
+		keys:             keys,
 		jwtExpiryHours:   jwtExpiryHours,
+		notifyTokenReuse: notifyTokenReuse,
+		mfaIssuer:        mfaIssuer,
 	}
 }
 
 // Register creates a new user with dual token authentication
 func (s *userService) Register(ctx context.Context, phoneNumber, password, name, platformRole string) (*domain.User, string, string, int64, int64, error) {
+	user, accessToken, refreshToken, accessExpiresAt, refreshExpiresAt, err := s.RegisterWithID(ctx, "", phoneNumber, password, name, platformRole)
+	if err != nil {
+		return nil, "", "", 0, 0, err
+	}
+
+	// Gửi OTP xác minh số điện thoại (saga đăng ký gửi sau khi saga hoàn tất)
+	s.sendVerificationOTP(ctx, user)
+
+	return user, accessToken, refreshToken, accessExpiresAt, refreshExpiresAt, nil
+}
+
+// RegisterWithID creates a user with a caller-chosen ID (rỗng = tự sinh)
+func (s *userService) RegisterWithID(ctx context.Context, userID, phoneNumber, password, name, platformRole string) (*domain.User, string, string, int64, int64, error) {
 	// 1. Validate input
 	if err := s.validateRegisterInput(phoneNumber, password, platformRole); err != nil {
 		return nil, "", "", 0, 0, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
@@ -118,6 +206,7 @@ func (s *userService) Register(ctx context.Context, phoneNumber, password, name,
 
 	// 4. Create user in database
 	user, err := s.repo.Create(ctx, domain.CreateUserParams{
+		ID:           userID,
 		PhoneNumber:  phoneNumber,
 		PasswordHash: passwordHash,
 		Name:         name,
@@ -127,64 +216,81 @@ func (s *userService) Register(ctx context.Context, phoneNumber, password, name,
 		return nil, "", "", 0, 0, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// 5. Generate access token
-	accessToken, accessExpiresAt, err := s.generateAccessToken(user.ID, user.PlatformRole)
-	if err != nil {
-		return nil, "", "", 0, 0, fmt.Errorf("failed to generate access token: %w", err)
+	// Admin chỉ được tạo qua endpoint create-admin (actor là Admin đang đăng nhập)
+	action := auditActionRegister
+	if platformRole == "Admin" {
+		action = auditActionCreateAdmin
 	}
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     action,
+		TargetType: "user",
+		TargetID:   user.ID,
+		After:      userAuditView(user),
+		ActorID:    user.ID, // Tự đăng ký: actor là chính user mới
+	})
 
-	// 6. Generate and store refresh token
-	refreshToken := s.generateRefreshToken()
-	refreshTokenHash := s.hashToken(refreshToken)
-	refreshExpiresAt := time.Now().Add(RefreshTokenExpiry)
+	// Admin không nhận tokens khi tạo: phải đăng nhập và enroll 2FA
+	if platformRole == "Admin" {
+		return user, "", "", 0, 0, nil
+	}
 
-	_, err = s.refreshTokenRepo.Create(ctx, domain.CreateRefreshTokenParams{
-		UserID:     user.ID,
-		TokenHash:  refreshTokenHash,
-		ExpiresAt:  refreshExpiresAt,
-		DeviceInfo: extractDeviceInfo(ctx),
-		IPAddress:  extractIPAddress(ctx),
-	})
+	// 5. Generate and store tokens (mở refresh token family mới)
+	tokens, err := s.issueTokens(ctx, user)
 	if err != nil {
-		return nil, "", "", 0, 0, fmt.Errorf("failed to store refresh token: %w", err)
+		return nil, "", "", 0, 0, err
 	}
 
-	return user, accessToken, refreshToken, accessExpiresAt, refreshExpiresAt.Unix(), nil
+	return user, tokens.AccessToken, tokens.RefreshToken, tokens.AccessExpiresAt, tokens.RefreshExpiresAt, nil
 }
 
-// Login authenticates user and returns dual tokens
-func (s *userService) Login(ctx context.Context, phoneNumber, password string) (*domain.User, string, string, int64, int64, error) {
+// Login authenticates user and returns dual tokens, or an MFA challenge when 2FA applies
+func (s *userService) Login(ctx context.Context, phoneNumber, password string) (*LoginResult, error) {
 	// 1. Validate input
 	if phoneNumber == "" || password == "" {
-		return nil, "", "", 0, 0, fmt.Errorf("%w: phone number and password are required", domain.ErrInvalidInput)
+		return nil, fmt.Errorf("%w: phone number and password are required", domain.ErrInvalidInput)
 	}
 
 	// 2. Get user by phone number
 	user, err := s.repo.GetByPhoneNumber(ctx, phoneNumber)
 	if err != nil {
-		return nil, "", "", 0, 0, fmt.Errorf("failed to get user: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return nil, "", "", 0, 0, domain.ErrInvalidCredentials
+		return nil, domain.ErrInvalidCredentials
 	}
 
 	// 3. Verify password
 	if err := s.verifyPassword(user.PasswordHash, password); err != nil {
-		return nil, "", "", 0, 0, domain.ErrInvalidCredentials
+		return nil, domain.ErrInvalidCredentials
 	}
 
-	// 4. Generate access token
-	accessToken, accessExpiresAt, err := s.generateAccessToken(user.ID, user.PlatformRole)
+	return s.finishLogin(ctx, user)
+}
+
+// finishLogin là bước cuối của đăng nhập (password hoặc OTP): cấp tokens, hoặc MFA challenge khi 2FA áp dụng
+func (s *userService) finishLogin(ctx context.Context, user *domain.User) (*LoginResult, error) {
+	// Second factor: user đã bật 2FA phải nhập mã, Admin chưa có 2FA phải enroll
+	mfa, err := s.mfaRepo.Get(ctx, user.ID)
 	if err != nil {
-		return nil, "", "", 0, 0, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return s.startMFAChallenge(ctx, user, domain.MFAPurposeVerify)
+	}
+	if user.PlatformRole == "Admin" {
+		return s.startMFAChallenge(ctx, user, domain.MFAPurposeEnroll)
 	}
 
-	// 5. Generate and store refresh token
+	return s.issueTokens(ctx, user)
+}
+
+// issueTokens mở refresh token family mới và cấp access token (bước cuối của đăng nhập)
+func (s *userService) issueTokens(ctx context.Context, user *domain.User) (*LoginResult, error) {
 	refreshToken := s.generateRefreshToken()
 	refreshTokenHash := s.hashToken(refreshToken)
 	refreshExpiresAt := time.Now().Add(RefreshTokenExpiry)
 
-	_, err = s.refreshTokenRepo.Create(ctx, domain.CreateRefreshTokenParams{
+	storedRefreshToken, err := s.refreshTokenRepo.Create(ctx, domain.CreateRefreshTokenParams{
 		UserID:     user.ID,
 		TokenHash:  refreshTokenHash,
 		ExpiresAt:  refreshExpiresAt,
@@ -192,10 +298,21 @@ func (s *userService) Login(ctx context.Context, phoneNumber, password string) (
 		IPAddress:  extractIPAddress(ctx),
 	})
 	if err != nil {
-		return nil, "", "", 0, 0, fmt.Errorf("failed to store refresh token: %w", err)
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
-	return user, accessToken, refreshToken, accessExpiresAt, refreshExpiresAt.Unix(), nil
+	accessToken, accessExpiresAt, err := s.generateAccessToken(ctx, user.ID, user.PlatformRole, storedRefreshToken.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return &LoginResult{
+		User:             user,
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshExpiresAt: refreshExpiresAt.Unix(),
+	}, nil
 }
 
 // RefreshToken generates new access token and rotates refresh token for security
@@ -214,6 +331,10 @@ func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (st
 
 	// 3. Verify token is valid (not revoked, not expired)
 	if !storedToken.IsValid() {
+		if storedToken.IsRotated() {
+			// Token đã đổi sang token mới mà vẫn được gửi lên → token đã lộ
+			return "", "", 0, 0, s.handleTokenReuse(ctx, storedToken)
+		}
 		if storedToken.IsRevoked() {
 			return "", "", 0, 0, fmt.Errorf("refresh token has been revoked")
 		}
@@ -221,7 +342,6 @@ func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (st
 	}
 
 	// 3.1. Update last_used_at for token reuse detection
-	// This helps detect if a revoked token is reused (indicates theft)
 	err = s.refreshTokenRepo.UpdateLastUsedAt(ctx, tokenHash)
 	if err != nil {
 		// Log but don't fail - this is monitoring data
@@ -237,13 +357,7 @@ func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (st
 		return "", "", 0, 0, fmt.Errorf("user not found")
 	}
 
-	// 5. Generate new access token
-	accessToken, accessExpiresAt, err := s.generateAccessToken(user.ID, user.PlatformRole)
-	if err != nil {
-		return "", "", 0, 0, fmt.Errorf("failed to generate access token: %w", err)
-	}
-
-	// 6. TOKEN ROTATION: Generate new refresh token and revoke old one
+	// 5. TOKEN ROTATION: revoke old refresh token and issue a new one in the same family
 	// This improves security - if old token is stolen, it becomes useless after one use
 	newRefreshToken := s.generateRefreshToken()
 	newRefreshTokenHash := s.hashToken(newRefreshToken)
@@ -259,8 +373,7 @@ func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (st
 		ipAddress = *storedToken.IPAddress
 	}
 
-	// Store new refresh token
-	_, err = s.refreshTokenRepo.Create(ctx, domain.CreateRefreshTokenParams{
+	rotated, err := s.refreshTokenRepo.Rotate(ctx, tokenHash, domain.CreateRefreshTokenParams{
 		UserID:     user.ID,
 		TokenHash:  newRefreshTokenHash,
 		ExpiresAt:  newRefreshExpiresAt,
@@ -268,20 +381,59 @@ func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (st
 		IPAddress:  ipAddress,
 	})
 	if err != nil {
-		return "", "", 0, 0, fmt.Errorf("failed to store new refresh token: %w", err)
+		return "", "", 0, 0, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if rotated == nil {
+		// Request khác đã rotation token này trong lúc đang xử lý → cũng là dùng lại
+		return "", "", 0, 0, s.handleTokenReuse(ctx, storedToken)
 	}
 
-	// Revoke old refresh token
-	err = s.refreshTokenRepo.Revoke(ctx, tokenHash, "token_rotation")
+	// 6. Generate new access token
+	accessToken, accessExpiresAt, err := s.generateAccessToken(ctx, user.ID, user.PlatformRole, rotated.FamilyID)
 	if err != nil {
-		// Log error but don't fail - new token is already issued
-		log.Printf("WARNING: Failed to revoke old refresh token during rotation: %v", err)
+		return "", "", 0, 0, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
 	log.Printf("INFO: Token rotated for user %s (old token revoked)", user.ID)
 	return accessToken, newRefreshToken, accessExpiresAt, newRefreshExpiresAt.Unix(), nil
 }
 
+// handleTokenReuse xử lý refresh token đã rotation bị dùng lại: revoke cả family, blacklist
+// access tokens còn hạn của user và ghi security event. Luôn trả về lỗi cho request.
+func (s *userService) handleTokenReuse(ctx context.Context, token *domain.RefreshToken) error {
+	log.Printf("WARNING: [SECURITY] Refresh token reuse detected for user %s (family %s)", token.UserID, token.FamilyID)
+
+	revokedSessions, err := s.refreshTokenRepo.RevokeFamily(ctx, token.UserID, token.FamilyID, domain.RevokeReasonReuseDetected, s.notifyTokenReuse)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+
+	// Access token của user đã cấp trước đó có thể đã lọt cùng refresh token
+	var blacklisted int64
+	if s.blacklistRepo != nil {
+		blacklisted, err = s.blacklistRepo.RevokeAllUserTokens(ctx, token.UserID, domain.RevokeReasonReuseDetected)
+		if err != nil {
+			return fmt.Errorf("failed to blacklist access tokens: %w", err)
+		}
+	}
+
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionTokenReuse,
+		TargetType: "refresh_token_family",
+		TargetID:   token.FamilyID,
+		After: map[string]any{
+			"user_id":                   token.UserID,
+			"reused_token_id":           token.ID,
+			"revoked_sessions":          revokedSessions,
+			"blacklisted_access_tokens": blacklisted,
+		},
+		Reason:  "rotated refresh token presented again",
+		ActorID: auditActorTokenReuse,
+	})
+
+	return fmt.Errorf("%w: refresh token has been revoked", domain.ErrTokenReused)
+}
+
 // Logout revokes a refresh token and blacklists access token
 func (s *userService) Logout(ctx context.Context, refreshToken string, accessToken string) error {
 	// 1. Hash the refresh token
@@ -296,9 +448,7 @@ func (s *userService) Logout(ctx context.Context, refreshToken string, accessTok
 	// 3. Blacklist the access token if provided
 	if accessToken != "" && s.blacklistRepo != nil {
 		// Parse access token to extract JTI and expiration
-		token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
-			return []byte(s.jwtSecret), nil
-		})
+		token, err := s.keys.Parse(accessToken, jwt.MapClaims{})
 
 		if err == nil && token.Valid {
 			if claims, ok := token.Claims.(jwt.MapClaims); ok {
@@ -344,7 +494,7 @@ func (s *userService) GetUserProfile(ctx context.Context, userID string) (*domai
 }
 
 // UpdateUserProfile updates user profile information
-func (s *userService) UpdateUserProfile(ctx context.Context, userID string, name, phoneNumber *string) (*domain.User, error) {
+func (s *userService) UpdateUserProfile(ctx context.Context, userID string, name, phoneNumber *string, otpCode string) (*domain.User, error) {
 	// Validate
 	if userID == "" {
 		return nil, fmt.Errorf("%w: user ID is required", domain.ErrInvalidInput)
@@ -354,6 +504,22 @@ func (s *userService) UpdateUserProfile(ctx context.Context, userID string, name
 		return nil, fmt.Errorf("%w: at least one field to update must be provided", domain.ErrInvalidInput)
 	}
 
+	before, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if before == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	// Số không đổi thì bỏ qua (giữ trạng thái đã xác minh)
+	if phoneNumber != nil && *phoneNumber == before.PhoneNumber {
+		phoneNumber = nil
+		if name == nil {
+			return before, nil
+		}
+	}
+
 	// Validate phone number if provided
 	if phoneNumber != nil {
 		if !isValidPhoneNumber(*phoneNumber) {
@@ -362,21 +528,41 @@ func (s *userService) UpdateUserProfile(ctx context.Context, userID string, name
 
 		// Check for uniqueness
 		existing, err := s.repo.GetByPhoneNumber(ctx, *phoneNumber)
-		if err == nil && existing.ID != userID {
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing user: %w", err)
+		}
+		if existing != nil && existing.ID != userID {
 			return nil, fmt.Errorf("%w: phone number already in use", domain.ErrAlreadyExists)
 		}
+
+		// Số mới phải nhận được OTP change_phone (SendPhoneOTP) trước khi đổi
+		if otpCode == "" {
+			return nil, fmt.Errorf("%w: verification code sent to the new phone number is required", domain.ErrInvalidInput)
+		}
+		if _, err := s.checkOTP(ctx, *phoneNumber, domain.OTPPurposeChangePhone, userID, otpCode); err != nil {
+			return nil, err
+		}
 	}
 
 	// Update in repository
 	updatedUser, err := s.repo.Update(ctx, domain.UpdateUserParams{
-		ID:          userID,
-		Name:        name,
-		PhoneNumber: phoneNumber,
+		ID:            userID,
+		Name:          name,
+		PhoneNumber:   phoneNumber,
+		PhoneVerified: phoneNumber != nil,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user profile: %w", err)
 	}
 
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionUpdateProfile,
+		TargetType: "user",
+		TargetID:   userID,
+		Before:     userAuditView(before),
+		After:      userAuditView(updatedUser),
+	})
+
 	return updatedUser, nil
 }
 
@@ -414,6 +600,13 @@ func (s *userService) ChangePassword(ctx context.Context, userID, oldPassword, n
 		return fmt.Errorf("failed to update password : %w", err)
 	}
 
+	// Không ghi password hash vào audit log
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionChangePassword,
+		TargetType: "user",
+		TargetID:   userID,
+	})
+
 	// Revoke all refresh tokens (force re-login)
 	count, err := s.refreshTokenRepo.RevokeAllUserTokens(ctx, userID, "password_changed")
 	if err != nil {
@@ -478,12 +671,32 @@ func (s *userService) DeleteUser(ctx context.Context, userID, reason string) err
 		return fmt.Errorf("%w: user ID is required", domain.ErrInvalidInput)
 	}
 
+	before, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if before == nil {
+		return domain.ErrNotFound
+	}
+
 	// Soft delete user
-	err := s.repo.SoftDelete(ctx, userID, reason)
+	err = s.repo.SoftDelete(ctx, userID, reason)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
+	// users không có cột reason → lý do xóa chỉ được lưu trong audit log
+	after := *before
+	after.IsDeleted = true
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionDelete,
+		TargetType: "user",
+		TargetID:   userID,
+		Before:     userAuditView(before),
+		After:      userAuditView(&after),
+		Reason:     reason,
+	})
+
 	// Revoke all refresh tokens
 	count, err := s.refreshTokenRepo.RevokeAllUserTokens(ctx, userID, "user_deleted")
 	if err != nil {
@@ -517,6 +730,13 @@ func (s *userService) HardDeleteUser(ctx context.Context, userID string) error {
 		return fmt.Errorf("failed to hard delete user: %w", err)
 	}
 
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionHardDelete,
+		TargetType: "user",
+		TargetID:   userID,
+		Before:     userAuditView(user),
+	})
+
 	log.Printf("WARNING: User %s (%s) permanently deleted (hard delete with cascade)", userID, user.PhoneNumber)
 	return nil
 }
@@ -531,12 +751,43 @@ func (s *userService) UpdateUserRole(ctx context.Context, userID, newPlatformRol
 		return nil, fmt.Errorf("%w: invalid platform role (must be 'Client' or 'Merchant' or 'Admin')", domain.ErrInvalidInput)
 	}
 
+	before, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if before == nil {
+		return nil, domain.ErrNotFound
+	}
+
 	// Update user role in repository
 	user, err := s.repo.UpdateRole(ctx, userID, newPlatformRole)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user role: %w", err)
 	}
 
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionUpdateRole,
+		TargetType: "user",
+		TargetID:   userID,
+		Before:     userAuditView(before),
+		After:      userAuditView(user),
+	})
+
+	// Admin mới phải enroll 2FA: đăng xuất các phiên hiện có để lần đăng nhập sau buộc enroll
+	if newPlatformRole == "Admin" && before.PlatformRole != "Admin" {
+		mfa, err := s.mfaRepo.Get(ctx, userID)
+		if err != nil {
+			log.Printf("WARNING: Failed to get 2FA status of new admin %s: %v", userID, err)
+		} else if !mfa.IsEnabled() {
+			count, err := s.refreshTokenRepo.RevokeAllUserTokens(ctx, userID, "mfa_enrollment_required")
+			if err != nil {
+				log.Printf("WARNING: Failed to revoke refresh tokens of new admin %s: %v", userID, err)
+			} else {
+				log.Printf("INFO: Revoked %d refresh tokens of new admin %s until 2FA is enrolled", count, userID)
+			}
+		}
+	}
+
 	return user, nil
 }
 
@@ -593,6 +844,13 @@ func (s *userService) LogoutAllDevices(ctx context.Context, userID string) (int6
 		return 0, fmt.Errorf("failed to revoke all tokens: %w", err)
 	}
 
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionRevokeSessions,
+		TargetType: "user",
+		TargetID:   userID,
+		After:      map[string]any{"revoked_count": revokedCount},
+	})
+
 	return revokedCount, nil
 }
 
@@ -608,6 +866,14 @@ func (s *userService) RevokeSession(ctx context.Context, userID, tokenID string)
 	if err != nil {
 		return fmt.Errorf("failed to revoke token: %w", err)
 	}
+
+	s.auditLog.Record(ctx, audit.Event{
+		Action:     auditActionRevokeSession,
+		TargetType: "session",
+		TargetID:   tokenID,
+		Before:     map[string]any{"user_id": userID},
+		Reason:     "manual_revoke",
+	})
 	return nil
 }
 
@@ -687,28 +953,25 @@ func (s *userService) verifyPassword(hashedPassword, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
 
-// generateJWT generates a JWT token for authenticated user
-func (s *userService) generateJWT(userID, platformRole string) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id":       userID,
-		"platform_role": platformRole,
-		"exp":           time.Now().Add(time.Hour * time.Duration(s.jwtExpiryHours)).Unix(),
-		"iat":           time.Now().Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(s.jwtSecret))
-	if err != nil {
-		return "", err
-	}
-
-	return signedToken, nil
-}
-
 func isValidPlatformRole(role string) bool {
 	return validator.ValidatePlatformRole(role) == nil
 }
 
+// userAuditView trả về các field của user được ghi vào audit log (không có password hash)
+func userAuditView(u *domain.User) map[string]any {
+	if u == nil {
+		return nil
+	}
+	return map[string]any{
+		"id":             u.ID,
+		"phone_number":   u.PhoneNumber,
+		"name":           u.Name,
+		"platform_role":  u.PlatformRole,
+		"is_deleted":     u.IsDeleted,
+		"phone_verified": u.IsPhoneVerified(),
+	}
+}
+
 // extractDeviceInfo extracts device information from gRPC metadata
 func extractDeviceInfo(ctx context.Context) string {
 	// Try to extract from gRPC metadata
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/thatlq1812/policy-system/user/internal/domain"
+	"github.com/thatlq1812/policy-system/user/internal/repository"
+)
+
+// fakeRefreshTokenRepository lưu refresh tokens trong bộ nhớ theo hash (chỉ các method RefreshToken dùng)
+type fakeRefreshTokenRepository struct {
+	repository.RefreshTokenRepository
+	tokens        map[string]*domain.RefreshToken
+	rotateRace    bool // Request khác rotation token trước (Rotate trả nil)
+	notified      bool
+	revokedFamily string
+}
+
+func (r *fakeRefreshTokenRepository) add(userID, tokenHash, familyID string, expiresAt time.Time, revokedReason string) {
+	token := &domain.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+		FamilyID:  familyID,
+	}
+	if revokedReason != "" {
+		now := time.Now()
+		token.RevokedAt, token.RevokedReason = &now, &revokedReason
+	}
+	r.tokens[tokenHash] = token
+}
+
+func (r *fakeRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return nil, nil
+	}
+	copied := *token
+	return &copied, nil
+}
+
+func (r *fakeRefreshTokenRepository) UpdateLastUsedAt(ctx context.Context, tokenHash string) error {
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) Rotate(ctx context.Context, oldTokenHash string, params domain.CreateRefreshTokenParams) (*domain.RefreshToken, error) {
+	old := r.tokens[oldTokenHash]
+	if r.rotateRace || old == nil || !old.IsValid() {
+		return nil, nil
+	}
+	now, reason := time.Now(), domain.RevokeReasonRotation
+	old.RevokedAt, old.RevokedReason = &now, &reason
+
+	r.add(params.UserID, params.TokenHash, old.FamilyID, params.ExpiresAt, "")
+	return r.tokens[params.TokenHash], nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeFamily(ctx context.Context, userID, familyID, reason string, notify bool) (int64, error) {
+	var revoked int64
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt, token.RevokedReason = &now, &reason
+			revoked++
+		}
+	}
+	r.revokedFamily, r.notified = familyID, notify
+	return revoked, nil
+}
+
+// fakeTokenBlacklistRepository đếm access token đã cấp và lần blacklist toàn bộ token của user
+type fakeTokenBlacklistRepository struct {
+	repository.TokenBlacklistRepository
+	issued         int
+	revokedAllUser string
+}
+
+func (r *fakeTokenBlacklistRepository) TrackIssued(ctx context.Context, jti, userID, familyID string, expiresAt time.Time) error {
+	r.issued++
+	return nil
+}
+
+func (r *fakeTokenBlacklistRepository) RevokeAllUserTokens(ctx context.Context, userID string, reason string) (int64, error) {
+	r.revokedAllUser = userID
+	return int64(r.issued), nil
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[string]*domain.User
+}
+
+func (r *fakeUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return r.users[id], nil
+}
+
+// fakeSigningKeyRepository giữ signing keys trong bộ nhớ cho KeyManager
+type fakeSigningKeyRepository struct {
+	keys []*domain.SigningKey
+}
+
+func (r *fakeSigningKeyRepository) ListValid(ctx context.Context) ([]*domain.SigningKey, error) {
+	return r.keys, nil
+}
+
+func (r *fakeSigningKeyRepository) Rotate(ctx context.Context, key *domain.SigningKey, dueBefore, previousExpiresAt time.Time) (bool, error) {
+	r.keys = append(r.keys, key)
+	return true, nil
+}
+
+func (r *fakeSigningKeyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func newTestKeyManager(t *testing.T) *KeyManager {
+	t.Helper()
+	keys, err := NewKeyManager(&fakeSigningKeyRepository{}, KeyManagerConfig{
+		Algorithm:      domain.SigningAlgorithmEdDSA,
+		RotationPeriod: 24 * time.Hour,
+		PublishAhead:   time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	const (
+		userID      = "user-1"
+		familyID    = "family-1"
+		otherFamily = "family-2" // Phiên đăng nhập trên thiết bị khác
+	)
+	keys := newTestKeyManager(t)
+
+	tests := []struct {
+		name string
+		// setup trả về refresh token client gửi lên
+		setup             func(t *testing.T, s *userService, repo *fakeRefreshTokenRepository) string
+		wantErr           bool
+		wantReuse         bool
+		wantFamilyRevoked bool
+	}{
+		{"Active token rotates", func(t *testing.T, s *userService, repo *fakeRefreshTokenRepository) string {
+			return "token-a"
+		}, false, false, false},
+		{"Rotated token reused", func(t *testing.T, s *userService, repo *fakeRefreshTokenRepository) string {
+			if _, _, _, _, err := s.RefreshToken(context.Background(), "token-a"); err != nil {
+				t.Fatal(err)
+			}
+			return "token-a"
+		}, true, true, true},
+		{"Older generation reused after two rotations", func(t *testing.T, s *userService, repo *fakeRefreshTokenRepository) string {
+			_, next, _, _, err := s.RefreshToken(context.Background(), "token-a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, _, _, err := s.RefreshToken(context.Background(), next); err != nil {
+				t.Fatal(err)
+			}
+			return "token-a"
+		}, true, true, true},
+		{"Concurrent rotation of the same token", func(t *testing.T, s *userService, repo *fakeRefreshTokenRepository) string {
+			repo.rotateRace = true
+			return "token-a"
+		}, true, true, true},
+		{"Token revoked by logout", func(t *testing.T, s *userService, repo *fakeRefreshTokenRepository) string {
+			repo.add(userID, s.hashToken("token-logout"), familyID, time.Now().Add(time.Hour), "user_logout")
+			return "token-logout"
+		}, true, false, false},
+		{"Expired token", func(t *testing.T, s *userService, repo *fakeRefreshTokenRepository) string {
+			repo.add(userID, s.hashToken("token-expired"), familyID, time.Now().Add(-time.Minute), "")
+			return "token-expired"
+		}, true, false, false},
+		{"Unknown token", func(t *testing.T, s *userService, repo *fakeRefreshTokenRepository) string {
+			return "token-unknown"
+		}, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRefreshTokenRepository{tokens: make(map[string]*domain.RefreshToken)}
+			blacklist := &fakeTokenBlacklistRepository{}
+			s := &userService{
+				repo:             &fakeUserRepository{users: map[string]*domain.User{userID: {ID: userID, PlatformRole: "Client"}}},
+				refreshTokenRepo: repo,
+				blacklistRepo:    blacklist,
+				keys:             keys,
+				notifyTokenReuse: true,
+			}
+			repo.add(userID, s.hashToken("token-a"), familyID, time.Now().Add(RefreshTokenExpiry), "")
+			repo.add(userID, s.hashToken("token-other-device"), otherFamily, time.Now().Add(RefreshTokenExpiry), "")
+
+			presented := tt.setup(t, s, repo)
+			accessToken, newRefreshToken, _, _, err := s.RefreshToken(context.Background(), presented)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, domain.ErrTokenReused) != tt.wantReuse {
+				t.Errorf("RefreshToken() error = %v, want reuse detected %v", err, tt.wantReuse)
+			}
+
+			if !tt.wantErr {
+				if accessToken == "" || newRefreshToken == "" || newRefreshToken == presented {
+					t.Fatalf("RefreshToken() returned access %q, refresh %q", accessToken, newRefreshToken)
+				}
+				old := repo.tokens[s.hashToken(presented)]
+				if !old.IsRotated() {
+					t.Errorf("presented token revoked reason = %v, want %s", old.RevokedReason, domain.RevokeReasonRotation)
+				}
+				next := repo.tokens[s.hashToken(newRefreshToken)]
+				if next == nil || !next.IsValid() || next.FamilyID != familyID {
+					t.Errorf("new refresh token = %+v, want active token in family %s", next, familyID)
+				}
+			}
+
+			if tt.wantFamilyRevoked {
+				if repo.revokedFamily != familyID || !repo.notified {
+					t.Errorf("revoked family = %q (notify %v), want %q (notify true)", repo.revokedFamily, repo.notified, familyID)
+				}
+				for _, token := range repo.tokens {
+					if token.FamilyID == familyID && token.IsValid() {
+						t.Errorf("token %s of reused family is still active", token.ID)
+					}
+				}
+				if blacklist.revokedAllUser != userID {
+					t.Errorf("access tokens blacklisted for %q, want %q", blacklist.revokedAllUser, userID)
+				}
+			} else if repo.revokedFamily != "" {
+				t.Errorf("family %s revoked, want no family revocation", repo.revokedFamily)
+			}
+
+			// Phiên trên thiết bị khác không bị ảnh hưởng
+			if other := repo.tokens[s.hashToken("token-other-device")]; !other.IsValid() {
+				t.Errorf("token of other family revoked: %v", *other.RevokedReason)
+			}
+		})
+	}
+}
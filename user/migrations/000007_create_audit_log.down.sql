@@ -0,0 +1,6 @@
+-- Rollback audit log
+
+DROP TRIGGER IF EXISTS trigger_prevent_audit_log_truncate ON audit_log;
+DROP TRIGGER IF EXISTS trigger_prevent_audit_log_mutation ON audit_log;
+DROP FUNCTION IF EXISTS prevent_audit_log_mutation();
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,3 @@
+-- Rollback outbox
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,27 @@
+-- Transactional outbox cho domain events (xem shared/pkg/outbox)
+-- Event được INSERT trong cùng transaction với thay đổi dữ liệu,
+-- Dispatcher chạy nền gửi event tới các sink rồi đánh dấu dispatched_at.
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY, -- Thứ tự gửi
+    event_id UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(), -- Consumer dedupe theo event_id
+    service VARCHAR(50) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    dispatched_at TIMESTAMPTZ, -- NULL = chưa gửi
+    dead_at TIMESTAMPTZ -- Hết số lần thử, cần xử lý thủ công
+);
+
+-- Dispatcher chỉ quét event chưa gửi
+CREATE INDEX idx_outbox_events_pending ON outbox_events(next_attempt_at, id)
+WHERE dispatched_at IS NULL AND dead_at IS NULL;
+
+CREATE INDEX idx_outbox_events_aggregate ON outbox_events(aggregate_type, aggregate_id, id);
+
+COMMENT ON TABLE outbox_events IS 'Transactional outbox of domain events, relayed to sinks by the outbox dispatcher';
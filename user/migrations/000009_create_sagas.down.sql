@@ -0,0 +1,4 @@
+-- Rollback sagas
+
+DROP TABLE IF EXISTS saga_steps;
+DROP TABLE IF EXISTS sagas;
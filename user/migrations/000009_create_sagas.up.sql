@@ -0,0 +1,42 @@
+-- Saga log cho giao dịch nhiều service (xem shared/pkg/saga)
+-- Saga đăng ký: create_user → fetch_policy → record_consent. Saga được lưu trước bước đầu tiên và
+-- sau mỗi bước; bước lỗi hoặc process chết giữa chừng thì các bước đã chạy được bù trừ,
+-- bù trừ lỗi được thử lại tới khi thành công.
+
+CREATE TABLE IF NOT EXISTS sagas (
+    id UUID PRIMARY KEY,
+    saga_type VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    step_index INT NOT NULL DEFAULT 0, -- Bước đang chạy / cần bù trừ tiếp theo, -1 = không còn
+    current_step VARCHAR(100) NOT NULL DEFAULT '',
+    data JSONB NOT NULL, -- Trạng thái để bù trừ (không chứa password/token)
+    attempts INT NOT NULL DEFAULT 0, -- Số lần bù trừ thất bại
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ, -- Lần bù trừ tiếp theo (status = compensating)
+    version INT NOT NULL DEFAULT 0, -- Optimistic lock giữa request và Orchestrator chạy nền
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ,
+    CONSTRAINT sagas_status_check
+        CHECK (status IN ('running', 'compensating', 'completed', 'compensated'))
+);
+
+-- Orchestrator quét saga compensating tới hạn và saga running bị bỏ dở
+CREATE INDEX idx_sagas_unfinished ON sagas(status, next_attempt_at, updated_at)
+WHERE status IN ('running', 'compensating');
+CREATE INDEX idx_sagas_created ON sagas(created_at DESC);
+
+-- Lịch sử thực thi / bù trừ từng bước
+CREATE TABLE IF NOT EXISTS saga_steps (
+    id BIGSERIAL PRIMARY KEY,
+    saga_id UUID NOT NULL REFERENCES sagas(id),
+    step VARCHAR(100) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('execute', 'compensate')),
+    error TEXT, -- NULL = thành công
+    attempted_at TIMESTAMPTZ NOT NULL,
+    duration_ms BIGINT NOT NULL
+);
+
+CREATE INDEX idx_saga_steps_saga ON saga_steps(saga_id, id);
+
+COMMENT ON TABLE sagas IS 'Multi-service transactions with durable compensation';
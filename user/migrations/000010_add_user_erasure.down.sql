@@ -0,0 +1,6 @@
+-- Rollback user erasure
+-- Dữ liệu đã ẩn danh không khôi phục được
+
+COMMENT ON COLUMN sagas.next_attempt_at IS NULL;
+DROP TABLE IF EXISTS erasure_certificates;
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
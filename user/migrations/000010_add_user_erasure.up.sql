@@ -0,0 +1,27 @@
+-- Right to erasure (GDPR Art. 17, Nghị định 13/2023/NĐ-CP)
+-- Erasure saga: anonymize_user → erase_consents → issue_certificate. Xóa dữ liệu không hoàn tác được
+-- nên saga chạy tiếp (forward recovery): bước lỗi được chạy lại tới khi xong, không bù trừ.
+
+-- Thời điểm xóa dữ liệu cá nhân; row được giữ (ẩn danh) để khóa ngoại và audit trail còn hợp lệ
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN users.erased_at IS 'Personal data anonymized by the erasure saga (phone, name, password replaced)';
+
+-- Erasure certificate: bằng chứng đã xử lý yêu cầu xóa, không chứa dữ liệu cá nhân
+CREATE TABLE IF NOT EXISTS erasure_certificates (
+    id UUID PRIMARY KEY, -- = ID của erasure saga
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id),
+    requested_by VARCHAR(100) NOT NULL, -- Admin xử lý yêu cầu
+    reason TEXT NOT NULL,
+    user_erased_at TIMESTAMPTZ NOT NULL,
+    sessions_scrubbed INT NOT NULL, -- Số refresh token đã xóa IP/device
+    consents_erased INT NOT NULL, -- Số consent đã xóa IP/user agent
+    consent_chain_head VARCHAR(64) NOT NULL, -- Hash cuối consent chain sau khi xóa
+    issued_at TIMESTAMPTZ NOT NULL,
+    certificate_hash VARCHAR(64) NOT NULL -- SHA-256 của nội dung certificate
+);
+
+COMMENT ON TABLE erasure_certificates IS 'Proof that a right-to-erasure request was carried out';
+
+-- Saga Forward lỗi vẫn running và chờ chạy lại tới next_attempt_at
+COMMENT ON COLUMN sagas.next_attempt_at IS 'Next retry: compensation (compensating) or re-running a failed step of a forward saga (running)';
@@ -0,0 +1,5 @@
+-- Rollback refresh token families
+
+DROP TABLE IF EXISTS issued_access_tokens;
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,28 @@
+-- Refresh token family: mỗi lần đăng nhập mở 1 family, token sinh ra khi rotation thuộc cùng family.
+-- Token đã rotation mà bị dùng lại nghĩa là token đã lộ → revoke cả family và blacklist access tokens
+-- (OAuth 2.0 Security BCP, refresh token rotation)
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS family_id UUID;
+
+-- Token cũ: mỗi token là 1 family riêng
+UPDATE refresh_tokens SET family_id = id WHERE family_id IS NULL;
+
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET DEFAULT gen_random_uuid();
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+COMMENT ON COLUMN refresh_tokens.family_id IS 'Login session: all tokens issued by rotation from one login share the family. Reuse of a rotated token revokes the family.';
+
+-- Access tokens đã cấp (theo JTI) để RevokeAllUserTokens blacklist được token chưa hết hạn
+CREATE TABLE IF NOT EXISTS issued_access_tokens (
+    jti VARCHAR(255) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID, -- Refresh token family cấp access token
+    expires_at TIMESTAMP NOT NULL,
+    issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_issued_access_tokens_user_id ON issued_access_tokens(user_id, expires_at);
+
+COMMENT ON TABLE issued_access_tokens IS 'Outstanding access token JTIs per user, so they can be blacklisted on security events. Rows are removed after expiry.';
@@ -0,0 +1,4 @@
+-- Rollback signing keys
+-- Access token đã ký bằng các key này không còn verify được
+
+DROP TABLE IF EXISTS signing_keys;
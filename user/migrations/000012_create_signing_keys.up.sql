@@ -0,0 +1,19 @@
+-- Khóa ký access token (RS256/EdDSA) thay cho JWT_SECRET dùng chung (HS256).
+-- Nhiều key cùng hợp lệ, nhận diện bằng kid: key mới được publish trong JWKS trước khi dùng để ký,
+-- key cũ vẫn verify được tới khi access token cuối cùng ký bằng nó hết hạn.
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(10) NOT NULL CHECK (algorithm IN ('RS256', 'EdDSA')),
+    private_key TEXT NOT NULL, -- PKCS#8 PEM, chỉ User Service đọc
+    public_key TEXT NOT NULL, -- PKIX PEM, publish qua GetSigningKeys / JWKS
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    activates_at TIMESTAMPTZ NOT NULL, -- Bắt đầu ký từ thời điểm này
+    retired_at TIMESTAMPTZ, -- Ngừng ký (key mới đã active)
+    expires_at TIMESTAMPTZ -- Gỡ khỏi JWKS và xóa: không còn token hợp lệ ký bằng key
+);
+
+CREATE INDEX IF NOT EXISTS idx_signing_keys_activates_at ON signing_keys(activates_at);
+
+COMMENT ON TABLE signing_keys IS 'Access token signing keys; rotated on a schedule by the User Service';
+COMMENT ON COLUMN signing_keys.private_key IS 'Database access grants signing access, same as JWT_SECRET did before';
@@ -0,0 +1,6 @@
+-- Rollback two-factor authentication
+-- Refresh token của Admin đã revoke không khôi phục
+
+DROP TABLE IF EXISTS mfa_challenges;
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
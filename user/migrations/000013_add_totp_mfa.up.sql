@@ -0,0 +1,48 @@
+-- Two-factor authentication (TOTP, RFC 6238)
+-- User bật 2FA (và mọi Admin) đăng nhập 2 bước: Login trả MFA challenge, VerifyMFA hoàn tất bằng mã TOTP/recovery code.
+-- Admin chưa enroll (kể cả super admin seed ở 000004) bị buộc enroll ngay khi đăng nhập.
+
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    totp_secret VARCHAR(64) NOT NULL, -- Base32, chỉ User Service đọc
+    enabled_at TIMESTAMPTZ, -- NULL = đang enroll, chưa xác nhận mã đầu tiên
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- Time step của mã đã dùng gần nhất, chống dùng lại mã
+    failed_attempts INT NOT NULL DEFAULT 0, -- Sai mã liên tiếp
+    locked_until TIMESTAMPTZ, -- Khóa xác thực 2FA sau quá nhiều lần sai
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN user_mfa.totp_secret IS 'Database access grants the second factor, restrict like signing_keys';
+
+-- Recovery codes: dùng 1 lần khi mất thiết bị, chỉ lưu SHA-256
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
+
+-- MFA challenge: token ngắn hạn thay cho access/refresh token khi password đúng nhưng còn bước 2FA
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256, token chỉ trả cho client
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('verify', 'enroll')), -- enroll: Admin buộc enroll trước
+    attempts INT NOT NULL DEFAULT 0,
+    device_info TEXT,
+    ip_address VARCHAR(50),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_challenges_expires_at ON mfa_challenges(expires_at);
+
+-- Admin đang đăng nhập (chưa có 2FA) phải đăng nhập lại để enroll
+UPDATE refresh_tokens
+SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = 'mfa_enrollment_required'
+WHERE revoked_at IS NULL
+  AND user_id IN (SELECT id FROM users WHERE platform_role = 'Admin');
@@ -0,0 +1,4 @@
+-- Rollback phone number OTP verification
+
+DROP TABLE IF EXISTS phone_otps;
+ALTER TABLE users DROP COLUMN IF EXISTS phone_verified_at;
@@ -0,0 +1,21 @@
+-- Xác minh số điện thoại bằng OTP gửi qua SMS
+-- Dùng cho: xác minh sau đăng ký (verify_phone), đổi số điện thoại (change_phone), đăng nhập không mật khẩu (login).
+
+-- NULL = chưa xác minh (user tạo trước migration này cũng chưa xác minh)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS phone_otps (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- NULL = login với số chưa đăng ký (không gửi SMS, chỉ tính giới hạn gửi)
+    phone_number VARCHAR(20) NOT NULL, -- Số nhận OTP (change_phone: số mới, chưa thuộc user)
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('verify_phone', 'change_phone', 'login')),
+    code_hash VARCHAR(64) NOT NULL, -- SHA-256, mã chỉ gửi qua SMS
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ, -- Đã dùng hoặc bị thay bằng mã mới
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Tìm mã đang hiệu lực và đếm số lần gửi theo số điện thoại (giới hạn gửi lại)
+CREATE INDEX IF NOT EXISTS idx_phone_otps_phone_created ON phone_otps(phone_number, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_phone_otps_user_id ON phone_otps(user_id);